@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog"
+
+	"github.com/go-petr/pet-bank/internal/accountrepo"
+	"github.com/go-petr/pet-bank/internal/entryrepo"
+	"github.com/go-petr/pet-bank/internal/entryservice"
+	"github.com/go-petr/pet-bank/internal/reconciliationrepo"
+	"github.com/go-petr/pet-bank/internal/reconciliationservice"
+)
+
+// Exit codes of the commands.
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
+
+const usage = `Usage:
+  bankapi                                 start the API server
+  bankapi verify-ledger [-account id]     verify the entries hash chain
+  bankapi reconcile                       check balances and transfers against entries
+`
+
+// runCommand runs the maintenance command with the given arguments and returns
+// the process exit code.
+func runCommand(ctx context.Context, db *sql.DB, name string, args []string) int {
+	switch name {
+	case "verify-ledger":
+		return verifyLedger(ctx, db, args)
+	case "reconcile":
+		return reconcile(ctx, db, args)
+	}
+
+	fmt.Fprint(os.Stderr, usage)
+
+	return exitUsage
+}
+
+// verifyLedger walks the entries hash chain and prints the report. It fails if
+// a broken link is found.
+func verifyLedger(ctx context.Context, db *sql.DB, args []string) int {
+	l := zerolog.Ctx(ctx)
+
+	fs := flag.NewFlagSet("verify-ledger", flag.ContinueOnError)
+	accountID := fs.Int("account", 0, "verify only the account with the given id")
+
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	entryService := entryservice.New(entryrepo.NewRepoPGS(db), accountrepo.NewRepoPGS(db))
+
+	report, err := entryService.VerifyChain(ctx, int32(*accountID))
+	if err != nil {
+		l.Error().Err(err).Msg("Cannot verify ledger")
+		return exitFailure
+	}
+
+	if err := printJSON(report); err != nil {
+		l.Error().Err(err).Send()
+		return exitFailure
+	}
+
+	if !report.Valid() {
+		l.Error().Int("breaks", len(report.Breaks)).Msg("Ledger hash chain is broken")
+		return exitFailure
+	}
+
+	return exitOK
+}
+
+// reconcile checks the ledger invariants, stores and prints the report. It
+// fails if a discrepancy is found.
+func reconcile(ctx context.Context, db *sql.DB, args []string) int {
+	l := zerolog.Ctx(ctx)
+
+	fs := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	reconciliationService := reconciliationservice.New(reconciliationrepo.NewRepoPGS(db))
+
+	report, err := reconciliationService.Run(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Cannot reconcile ledger")
+		return exitFailure
+	}
+
+	if err := printJSON(report); err != nil {
+		l.Error().Err(err).Send()
+		return exitFailure
+	}
+
+	if !report.Balanced() {
+		return exitFailure
+	}
+
+	return exitOK
+}
+
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(v)
+}
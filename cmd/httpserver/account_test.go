@@ -53,7 +53,7 @@ func TestCreateAccountAPI(t *testing.T) {
 			name:        "OK",
 			requestBody: requestBody{Currency: currencypkg.EUR},
 			setupAuth: func(t *testing.T, r *http.Request) error {
-				return middleware.AddAuthorization(r, tokenMaker, authType, user.Username, duration)
+				return helpers.AddAuthorization(t, server.DB, r, tokenMaker, authType, user.Username, duration)
 			},
 			wantStatusCode: http.StatusCreated,
 			checkData: func(req requestBody, res web.Response) {
@@ -65,10 +65,11 @@ func TestCreateAccountAPI(t *testing.T) {
 				}
 
 				want := domain.Account{
-					Owner:     user.Username,
-					Balance:   "0",
-					Currency:  currencypkg.EUR,
-					CreatedAt: time.Now().UTC().Truncate(time.Second),
+					Owner:            user.Username,
+					Balance:          "0",
+					AvailableBalance: "0",
+					Currency:         currencypkg.EUR,
+					CreatedAt:        time.Now().UTC().Truncate(time.Second),
 				}
 
 				ignoreFields := cmpopts.IgnoreFields(domain.Account{}, "ID")
@@ -91,25 +92,40 @@ func TestCreateAccountAPI(t *testing.T) {
 			name:        "InvalidCurrency",
 			requestBody: requestBody{Currency: "FAIL"},
 			setupAuth: func(t *testing.T, r *http.Request) error {
-				return middleware.AddAuthorization(r, tokenMaker, authType, user.Username, duration)
+				return helpers.AddAuthorization(t, server.DB, r, tokenMaker, authType, user.Username, duration)
 			},
 			wantStatusCode: http.StatusBadRequest,
 			wantError:      "Currency is not supported",
 		},
 		{
-			name:        "ErrOwnerNotFound",
+			name:        "ReadOnlyToken",
 			requestBody: requestBody{Currency: currencypkg.EUR},
 			setupAuth: func(t *testing.T, r *http.Request) error {
-				return middleware.AddAuthorization(r, tokenMaker, authType, "username", duration)
+				claims := tokenpkg.Claims{
+					Username: user.Username,
+					Role:     domain.RoleCustomer,
+					Scopes:   []string{domain.ScopeAccountsRead},
+				}
+
+				return helpers.AddAuthorizationWithClaims(t, server.DB, r, tokenMaker, authType, claims, duration)
 			},
-			wantStatusCode: http.StatusBadRequest,
-			wantError:      domain.ErrOwnerNotFound.Error(),
+			wantStatusCode: http.StatusForbidden,
+			wantError:      middleware.ErrInsufficientScope.Error(),
+		},
+		{
+			name:        "SessionlessToken",
+			requestBody: requestBody{Currency: currencypkg.EUR},
+			setupAuth: func(t *testing.T, r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, user.Username, duration)
+			},
+			wantStatusCode: http.StatusUnauthorized,
+			wantError:      tokenpkg.ErrInvalidToken.Error(),
 		},
 		{
 			name:        "ErrCurrencyAlreadyExists",
 			requestBody: requestBody{Currency: currencypkg.USD},
 			setupAuth: func(t *testing.T, r *http.Request) error {
-				return middleware.AddAuthorization(r, tokenMaker, authType, user.Username, duration)
+				return helpers.AddAuthorization(t, server.DB, r, tokenMaker, authType, user.Username, duration)
 			},
 			wantStatusCode: http.StatusConflict,
 			wantError:      domain.ErrCurrencyAlreadyExists.Error(),
@@ -194,7 +210,7 @@ func TestGetAccountAPI(t *testing.T) {
 			name:      "OK",
 			accountID: account.ID,
 			setupAuth: func(t *testing.T, r *http.Request) error {
-				return middleware.AddAuthorization(r, tokenMaker, authType, user.Username, duration)
+				return helpers.AddAuthorization(t, server.DB, r, tokenMaker, authType, user.Username, duration)
 			},
 			wantStatusCode: http.StatusOK,
 			checkData: func(res web.Response) {
@@ -226,7 +242,7 @@ func TestGetAccountAPI(t *testing.T) {
 			name:      "InvalidID",
 			accountID: -1,
 			setupAuth: func(t *testing.T, r *http.Request) error {
-				return middleware.AddAuthorization(r, tokenMaker, authType, user.Username, duration)
+				return helpers.AddAuthorization(t, server.DB, r, tokenMaker, authType, user.Username, duration)
 			},
 			wantStatusCode: http.StatusBadRequest,
 			wantError:      "ID must be at least 1 characters long",
@@ -235,7 +251,7 @@ func TestGetAccountAPI(t *testing.T) {
 			name:      "ErrAccountNotFound",
 			accountID: 1200000000,
 			setupAuth: func(t *testing.T, r *http.Request) error {
-				return middleware.AddAuthorization(r, tokenMaker, authType, user.Username, duration)
+				return helpers.AddAuthorization(t, server.DB, r, tokenMaker, authType, user.Username, duration)
 			},
 			wantStatusCode: http.StatusNotFound,
 			wantError:      domain.ErrAccountNotFound.Error(),
@@ -244,7 +260,7 @@ func TestGetAccountAPI(t *testing.T) {
 			name:      "ErrAccountOwnerMismatch",
 			accountID: account2.ID,
 			setupAuth: func(t *testing.T, r *http.Request) error {
-				return middleware.AddAuthorization(r, tokenMaker, authType, user.Username, duration)
+				return helpers.AddAuthorization(t, server.DB, r, tokenMaker, authType, user.Username, duration)
 			},
 			wantStatusCode: http.StatusUnauthorized,
 			wantError:      domain.ErrAccountOwnerMismatch.Error(),
@@ -325,7 +341,7 @@ func TestListAccountAPI(t *testing.T) {
 			pageID:   1,
 			pageSize: 5,
 			setupAuth: func(t *testing.T, r *http.Request) error {
-				return middleware.AddAuthorization(r, tokenMaker, authType, user.Username, duration)
+				return helpers.AddAuthorization(t, server.DB, r, tokenMaker, authType, user.Username, duration)
 			},
 			wantStatusCode: http.StatusOK,
 			checkData: func(res web.Response) {
@@ -349,7 +365,7 @@ func TestListAccountAPI(t *testing.T) {
 			pageID:   1,
 			pageSize: 2,
 			setupAuth: func(t *testing.T, r *http.Request) error {
-				return middleware.AddAuthorization(r, tokenMaker, authType, user.Username, duration)
+				return helpers.AddAuthorization(t, server.DB, r, tokenMaker, authType, user.Username, duration)
 			},
 			wantStatusCode: http.StatusOK,
 			checkData: func(res web.Response) {
@@ -373,7 +389,7 @@ func TestListAccountAPI(t *testing.T) {
 			pageID:   2,
 			pageSize: 2,
 			setupAuth: func(t *testing.T, r *http.Request) error {
-				return middleware.AddAuthorization(r, tokenMaker, authType, user.Username, duration)
+				return helpers.AddAuthorization(t, server.DB, r, tokenMaker, authType, user.Username, duration)
 			},
 			wantStatusCode: http.StatusOK,
 			checkData: func(res web.Response) {
@@ -407,7 +423,7 @@ func TestListAccountAPI(t *testing.T) {
 			pageID:   0,
 			pageSize: 5,
 			setupAuth: func(t *testing.T, r *http.Request) error {
-				return middleware.AddAuthorization(r, tokenMaker, authType, user.Username, duration)
+				return helpers.AddAuthorization(t, server.DB, r, tokenMaker, authType, user.Username, duration)
 			},
 			wantStatusCode: http.StatusBadRequest,
 			wantError:      "PageID field is required",
@@ -417,7 +433,7 @@ func TestListAccountAPI(t *testing.T) {
 			pageID:   1,
 			pageSize: 500,
 			setupAuth: func(t *testing.T, r *http.Request) error {
-				return middleware.AddAuthorization(r, tokenMaker, authType, user.Username, duration)
+				return helpers.AddAuthorization(t, server.DB, r, tokenMaker, authType, user.Username, duration)
 			},
 			wantStatusCode: http.StatusBadRequest,
 			wantError:      "PageSize must be less than 100",
@@ -427,7 +443,8 @@ func TestListAccountAPI(t *testing.T) {
 			pageID:   1,
 			pageSize: 5,
 			setupAuth: func(t *testing.T, r *http.Request) error {
-				return middleware.AddAuthorization(r, tokenMaker, authType, "user.Username", duration)
+				owner := helpers.SeedUser(t, server.DB)
+				return helpers.AddAuthorization(t, server.DB, r, tokenMaker, authType, owner.Username, duration)
 			},
 			wantStatusCode: http.StatusOK,
 			checkData: func(res web.Response) {
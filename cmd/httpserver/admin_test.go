@@ -0,0 +1,184 @@
+//go:build integration
+
+package httpserver_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/integrationtest"
+	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
+	"github.com/go-petr/pet-bank/internal/middleware"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/go-petr/pet-bank/pkg/tokenpkg"
+	"github.com/go-petr/pet-bank/pkg/web"
+)
+
+func TestAdminFreezeAccountAPI(t *testing.T) {
+	server := integrationtest.SetupServer(t)
+
+	admin := helpers.SeedUserWithRole(t, server.DB, randompkg.String(10), domain.RoleAdmin)
+	support := helpers.SeedUserWithRole(t, server.DB, randompkg.String(10), domain.RoleSupport)
+	user1 := helpers.SeedUser(t, server.DB)
+	user2 := helpers.SeedUser(t, server.DB)
+	account1 := helpers.SeedAccountWith1000USDBalance(t, server.DB, user1.Username)
+	account2 := helpers.SeedAccountWith1000USDBalance(t, server.DB, user2.Username)
+
+	tokenMaker, err := tokenpkg.NewPasetoMaker(server.Config.TokenSymmetricKey)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(%v) returned error: %v", server.Config.TokenSymmetricKey, err)
+	}
+
+	do := func(t *testing.T, method, url string, body any, user domain.User) (int, web.Response) {
+		t.Helper()
+
+		var buf bytes.Buffer
+		if body != nil {
+			if err := json.NewEncoder(&buf).Encode(body); err != nil {
+				t.Fatalf("encoding request body error: %v", err)
+			}
+		}
+
+		req, err := http.NewRequest(method, url, &buf)
+		if err != nil {
+			t.Fatalf("http.NewRequest(%v, %v, body) returned error: %v", method, url, err)
+		}
+
+		claims := tokenpkg.Claims{Username: user.Username, Role: user.Role, Scopes: domain.RoleScopes(user.Role)}
+
+		err = helpers.AddAuthorizationWithClaims(t, server.DB, req, tokenMaker, middleware.AuthTypeBearer, claims, server.Config.AccessTokenDuration)
+		if err != nil {
+			t.Fatalf("helpers.AddAuthorizationWithClaims(...) returned error: %v", err)
+		}
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+
+		var res web.Response
+		if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+			t.Fatalf("decoding response body error: %v", err)
+		}
+
+		return w.Code, res
+	}
+
+	freezeURL := fmt.Sprintf("/admin/accounts/%d/freeze", account2.ID)
+	transfer := map[string]any{
+		"from_account_id": account1.ID,
+		"to_account_id":   account2.ID,
+		"amount":          "10",
+	}
+
+	if code, res := do(t, http.MethodGet, "/admin/users?page_id=1&page_size=5", nil, user1); code != http.StatusForbidden {
+		t.Errorf("customer search users: status code %v, want %v (error %q)", code, http.StatusForbidden, res.Error)
+	}
+
+	if code, _ := do(t, http.MethodGet, fmt.Sprintf("/admin/accounts/%d", account2.ID), nil, support); code != http.StatusOK {
+		t.Errorf("support get account: status code %v, want %v", code, http.StatusOK)
+	}
+
+	if code, _ := do(t, http.MethodPost, freezeURL, nil, support); code != http.StatusForbidden {
+		t.Errorf("support freeze account: status code %v, want %v", code, http.StatusForbidden)
+	}
+
+	if code, res := do(t, http.MethodPost, freezeURL, nil, admin); code != http.StatusOK {
+		t.Fatalf("admin freeze account: status code %v, want %v (error %q)", code, http.StatusOK, res.Error)
+	}
+
+	if code, res := do(t, http.MethodPost, "/transfers", transfer, user1); code != http.StatusForbidden || res.Error != domain.ErrAccountFrozen.Error() {
+		t.Errorf("transfer to frozen account: status code %v, error %q, want %v, %q",
+			code, res.Error, http.StatusForbidden, domain.ErrAccountFrozen)
+	}
+
+	if code, res := do(t, http.MethodPost, fmt.Sprintf("/admin/accounts/%d/unfreeze", account2.ID), nil, admin); code != http.StatusOK {
+		t.Fatalf("admin unfreeze account: status code %v, want %v (error %q)", code, http.StatusOK, res.Error)
+	}
+
+	if code, res := do(t, http.MethodPost, "/transfers", transfer, user1); code != http.StatusCreated {
+		t.Errorf("transfer to unfrozen account: status code %v, want %v (error %q)", code, http.StatusCreated, res.Error)
+	}
+
+	var actions int
+
+	row := server.DB.QueryRow(`SELECT count(*) FROM admin_actions WHERE actor = $1`, admin.Username)
+	if err := row.Scan(&actions); err != nil {
+		t.Fatalf("counting admin actions returned error: %v", err)
+	}
+
+	if actions != 2 {
+		t.Errorf("admin actions = %v, want 2", actions)
+	}
+}
+
+func TestAdminVerifyLedgerAPI(t *testing.T) {
+	server := integrationtest.SetupServer(t)
+
+	admin := helpers.SeedUserWithRole(t, server.DB, randompkg.String(10), domain.RoleAdmin)
+	user := helpers.SeedUser(t, server.DB)
+	account := helpers.SeedAccountWith1000USDBalance(t, server.DB, user.Username)
+	entries := helpers.SeedEntries(t, server.DB, 3, account.ID)
+
+	tokenMaker, err := tokenpkg.NewPasetoMaker(server.Config.TokenSymmetricKey)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(%v) returned error: %v", server.Config.TokenSymmetricKey, err)
+	}
+
+	type verifyResponse struct {
+		Data struct {
+			Valid  bool               `json:"valid"`
+			Report domain.ChainReport `json:"report"`
+		} `json:"data"`
+	}
+
+	verify := func(t *testing.T) verifyResponse {
+		t.Helper()
+
+		url := fmt.Sprintf("/admin/ledger/verify?account_id=%d", account.ID)
+
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			t.Fatalf("http.NewRequest(GET, %v, nil) returned error: %v", url, err)
+		}
+
+		claims := tokenpkg.Claims{Username: admin.Username, Role: admin.Role, Scopes: domain.RoleScopes(admin.Role)}
+
+		err = helpers.AddAuthorizationWithClaims(t, server.DB, req, tokenMaker, middleware.AuthTypeBearer, claims, server.Config.AccessTokenDuration)
+		if err != nil {
+			t.Fatalf("helpers.AddAuthorizationWithClaims(...) returned error: %v", err)
+		}
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("verify ledger: status code %v, want %v", w.Code, http.StatusOK)
+		}
+
+		var res verifyResponse
+		if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+			t.Fatalf("decoding response body error: %v", err)
+		}
+
+		return res
+	}
+
+	if res := verify(t); !res.Data.Valid || res.Data.Report.Entries != 3 {
+		t.Errorf("verify untouched ledger = %+v, want valid report of 3 entries", res.Data)
+	}
+
+	if _, err := server.DB.Exec(`UPDATE entries SET amount = amount + 1 WHERE id = $1`, entries[1].ID); err != nil {
+		t.Fatalf("tampering entry returned error: %v", err)
+	}
+
+	res := verify(t)
+	want := domain.ChainBreak{AccountID: account.ID, EntryID: entries[1].ID, Reason: domain.ChainBreakHashMismatch}
+
+	if res.Data.Valid || len(res.Data.Report.Breaks) != 1 || res.Data.Report.Breaks[0] != want {
+		t.Errorf("verify tampered ledger = %+v, want the single break %+v", res.Data, want)
+	}
+}
@@ -0,0 +1,104 @@
+//go:build integration
+
+package httpserver_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/integrationtest"
+	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
+	"github.com/go-petr/pet-bank/internal/middleware"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/go-petr/pet-bank/pkg/tokenpkg"
+	"github.com/go-petr/pet-bank/pkg/web"
+)
+
+func TestDepositWithdrawAPI(t *testing.T) {
+	server := integrationtest.SetupServer(t)
+
+	integration := helpers.SeedUserWithRole(t, server.DB, randompkg.String(10), domain.RoleIntegration)
+	user := helpers.SeedUser(t, server.DB)
+	account := helpers.SeedAccountWith1000USDBalance(t, server.DB, user.Username)
+
+	tokenMaker, err := tokenpkg.NewPasetoMaker(server.Config.TokenSymmetricKey)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(%v) returned error: %v", server.Config.TokenSymmetricKey, err)
+	}
+
+	do := func(t *testing.T, url string, amount string, user domain.User) (int, domain.TransferTxResult, string) {
+		t.Helper()
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(map[string]any{"account_id": account.ID, "amount": amount}); err != nil {
+			t.Fatalf("encoding request body error: %v", err)
+		}
+
+		req, err := http.NewRequest(http.MethodPost, url, &buf)
+		if err != nil {
+			t.Fatalf("http.NewRequest(POST, %v, body) returned error: %v", url, err)
+		}
+
+		claims := tokenpkg.Claims{Username: user.Username, Role: user.Role, Scopes: domain.RoleScopes(user.Role)}
+
+		err = helpers.AddAuthorizationWithClaims(t, server.DB, req, tokenMaker, middleware.AuthTypeBearer, claims, server.Config.AccessTokenDuration)
+		if err != nil {
+			t.Fatalf("helpers.AddAuthorizationWithClaims(...) returned error: %v", err)
+		}
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+
+		data := &struct {
+			Transfer domain.TransferTxResult `json:"transfer"`
+		}{}
+		res := web.Response{Data: data}
+
+		if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+			t.Fatalf("decoding response body error: %v", err)
+		}
+
+		return w.Code, data.Transfer, res.Error
+	}
+
+	if code, _, _ := do(t, "/deposits", "500", user); code != http.StatusForbidden {
+		t.Errorf("customer deposit: status code %v, want %v", code, http.StatusForbidden)
+	}
+
+	code, deposit, errMsg := do(t, "/deposits", "500", integration)
+	if code != http.StatusCreated {
+		t.Fatalf("integration deposit: status code %v, want %v (error %q)", code, http.StatusCreated, errMsg)
+	}
+
+	if deposit.ToAccount.Balance != "1500" || deposit.FromAccount.Balance != "-500" || !deposit.FromAccount.IsSystem {
+		t.Errorf("integration deposit returned %+v", deposit)
+	}
+
+	code, withdrawal, errMsg := do(t, "/withdrawals", "200", integration)
+	if code != http.StatusCreated {
+		t.Fatalf("integration withdrawal: status code %v, want %v (error %q)", code, http.StatusCreated, errMsg)
+	}
+
+	if withdrawal.FromAccount.Balance != "1300" || withdrawal.Transfer.Kind != domain.TransferKindWithdrawal {
+		t.Errorf("integration withdrawal returned %+v", withdrawal)
+	}
+
+	if code, _, errMsg := do(t, "/withdrawals", "1301", integration); code != http.StatusBadRequest {
+		t.Errorf("overdrawing withdrawal: status code %v, want %v (error %q)", code, http.StatusBadRequest, errMsg)
+	}
+
+	var sum string
+
+	row := server.DB.QueryRow(`SELECT COALESCE(sum(amount), 0)::text FROM entries`)
+	if err := row.Scan(&sum); err != nil {
+		t.Fatalf("summing entries returned error: %v", err)
+	}
+
+	if sum != "0" {
+		t.Errorf("sum of entries = %v, want 0", sum)
+	}
+}
@@ -2,9 +2,12 @@
 package httpserver
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
@@ -14,7 +17,42 @@ import (
 	"github.com/go-petr/pet-bank/internal/accountdelivery"
 	"github.com/go-petr/pet-bank/internal/accountrepo"
 	"github.com/go-petr/pet-bank/internal/accountservice"
+	"github.com/go-petr/pet-bank/internal/admindelivery"
+	"github.com/go-petr/pet-bank/internal/adminrepo"
+	"github.com/go-petr/pet-bank/internal/adminservice"
+	"github.com/go-petr/pet-bank/internal/aliasdelivery"
+	"github.com/go-petr/pet-bank/internal/aliasrepo"
+	"github.com/go-petr/pet-bank/internal/aliasservice"
+	"github.com/go-petr/pet-bank/internal/auditdelivery"
+	"github.com/go-petr/pet-bank/internal/auditrepo"
+	"github.com/go-petr/pet-bank/internal/auditservice"
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/entrydelivery"
+	"github.com/go-petr/pet-bank/internal/entryrepo"
+	"github.com/go-petr/pet-bank/internal/entryservice"
+	"github.com/go-petr/pet-bank/internal/fxdelivery"
+	"github.com/go-petr/pet-bank/internal/fxrepo"
+	"github.com/go-petr/pet-bank/internal/fxservice"
+	"github.com/go-petr/pet-bank/internal/holddelivery"
+	"github.com/go-petr/pet-bank/internal/holdservice"
+	"github.com/go-petr/pet-bank/internal/idempotencyrepo"
+	"github.com/go-petr/pet-bank/internal/idempotencyservice"
+	"github.com/go-petr/pet-bank/internal/jwksdelivery"
+	"github.com/go-petr/pet-bank/internal/limitrepo"
+	"github.com/go-petr/pet-bank/internal/limitservice"
 	"github.com/go-petr/pet-bank/internal/middleware"
+	"github.com/go-petr/pet-bank/internal/monitoringrepo"
+	"github.com/go-petr/pet-bank/internal/monitoringservice"
+	"github.com/go-petr/pet-bank/internal/payeedelivery"
+	"github.com/go-petr/pet-bank/internal/payeerepo"
+	"github.com/go-petr/pet-bank/internal/payeeservice"
+	"github.com/go-petr/pet-bank/internal/reconciliationrepo"
+	"github.com/go-petr/pet-bank/internal/reconciliationservice"
+	"github.com/go-petr/pet-bank/internal/scheduledelivery"
+	"github.com/go-petr/pet-bank/internal/schedulerepo"
+	"github.com/go-petr/pet-bank/internal/scheduleservice"
+	"github.com/go-petr/pet-bank/internal/screeningrepo"
+	"github.com/go-petr/pet-bank/internal/screeningservice"
 	"github.com/go-petr/pet-bank/internal/sessiondelivery"
 	"github.com/go-petr/pet-bank/internal/sessionrepo"
 	"github.com/go-petr/pet-bank/internal/sessionservice"
@@ -26,6 +64,7 @@ import (
 	"github.com/go-petr/pet-bank/internal/userservice"
 	"github.com/go-petr/pet-bank/pkg/configpkg"
 	"github.com/go-petr/pet-bank/pkg/currencypkg"
+	"github.com/go-petr/pet-bank/pkg/fxpkg"
 	"github.com/go-petr/pet-bank/pkg/tokenpkg"
 )
 
@@ -34,6 +73,11 @@ type Server struct {
 	DB     *sql.DB
 	Engine *gin.Engine
 	Config configpkg.Config
+
+	idempotencyService    *idempotencyservice.Service
+	holdService           *holdservice.Service
+	scheduleService       *scheduleservice.Service
+	reconciliationService *reconciliationservice.Service
 }
 
 // ServeHTTP implements the http.Handler interface for the Server type.
@@ -47,25 +91,72 @@ func New(conn *sql.DB, logger zerolog.Logger, config configpkg.Config) (*Server,
 	accountRepo := accountrepo.NewRepoPGS(conn)
 	transferRepo := transferrepo.NewRepoPGS(conn)
 	sessionRepo := sessionrepo.NewRepoPGS(conn)
+	fxRepo := fxrepo.NewRepoPGS(conn)
+	entryRepo := entryrepo.NewRepoPGS(conn)
+	adminRepo := adminrepo.NewRepoPGS(conn)
+	auditRepo := auditrepo.NewRepoPGS(conn)
+	scheduleRepo := schedulerepo.NewRepoPGS(conn)
+	aliasRepo := aliasrepo.NewRepoPGS(conn)
+	payeeRepo := payeerepo.NewRepoPGS(conn)
+	limitRepo := limitrepo.NewRepoPGS(conn)
+
+	tokenMaker, err := newTokenMaker(config)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create token maker: %w", err)
+	}
+
+	limits, err := limitservice.ParseLimits(config.TransferLimitPerTransaction, config.TransferLimitDaily, config.TransferLimitMonthly)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse transfer limits: %w", err)
+	}
+
+	rates, err := newRateProvider(config)
+	if err != nil {
+		return nil, errors.New("cannot create fx rate provider")
+	}
+
+	monitor, err := newMonitor(config, monitoringrepo.NewRepoPGS(conn))
+	if err != nil {
+		return nil, fmt.Errorf("cannot load transaction monitoring rules: %w", err)
+	}
+
+	auditService := auditservice.New(auditRepo)
 
-	tokenMaker, err := tokenpkg.NewPasetoMaker(config.TokenSymmetricKey)
+	screeningService, err := screeningservice.New(screeningrepo.NewRepoPGS(conn), auditService, config.SanctionsListFile, config.SanctionsMatchThreshold)
 	if err != nil {
-		return nil, errors.New("cannot create token maker")
+		return nil, fmt.Errorf("cannot load sanctions list: %w", err)
 	}
 
-	userService := userservice.New(userRepo)
-	accountService := accountservice.New(accountRepo)
-	transferService := transferservice.New(transferRepo, accountService)
-	sessionService, err := sessionservice.New(sessionRepo, config, tokenMaker)
+	userService := userservice.New(userRepo, screeningService, auditService)
+	accountService := accountservice.New(accountRepo, auditService)
+	aliasService := aliasservice.New(aliasRepo, userRepo, accountRepo, auditService)
+	payeeService := payeeservice.New(payeeRepo, accountService, aliasService, auditService, config.PayeeCoolingOff)
+	limitService := limitservice.New(limitRepo, limits)
+	transferService := transferservice.New(transferRepo, accountRepo, aliasService, payeeService, limitService, monitor, screeningService, auditService)
+	fxService := fxservice.New(fxRepo, rates, config.FXQuoteDuration)
+	holdService := holdservice.New(transferRepo, accountRepo, limitService, monitor, screeningService, auditService, config.HoldDuration)
+	scheduleService := scheduleservice.New(scheduleRepo, accountRepo, transferService, auditService)
+	entryService := entryservice.New(entryRepo, accountRepo)
+	sessionService, err := sessionservice.New(sessionRepo, userRepo, config, tokenMaker, auditService)
 
 	if err != nil {
 		return nil, errors.New("cannot initialize session service")
 	}
 
+	adminService := adminservice.New(adminRepo, userRepo, accountRepo, entryRepo, sessionService, entryService, limitService, transferService, screeningService)
+
 	userHandler := userdelivery.NewHandler(userService, sessionService)
 	accountHandler := accountdelivery.NewHandler(accountService)
+	aliasHandler := aliasdelivery.NewHandler(aliasService)
+	payeeHandler := payeedelivery.NewHandler(payeeService)
 	transferHandler := transferdelivery.NewHandler(transferService)
 	sessionHandler := sessiondelivery.NewHandler(sessionService)
+	fxHandler := fxdelivery.NewHandler(fxService)
+	holdHandler := holddelivery.NewHandler(holdService)
+	scheduleHandler := scheduledelivery.NewHandler(scheduleService)
+	entryHandler := entrydelivery.NewHandler(entryService)
+	adminHandler := admindelivery.NewHandler(adminService)
+	auditHandler := auditdelivery.NewHandler(auditService)
 
 	gin.SetMode(gin.ReleaseMode)
 	engine := gin.New()
@@ -77,13 +168,79 @@ func New(conn *sql.DB, logger zerolog.Logger, config configpkg.Config) (*Server,
 	engine.POST("/users/login", userHandler.Login)
 	engine.POST("/sessions", sessionHandler.RenewAccessToken)
 
-	authRoutes := engine.Group("/").Use(middleware.AuthMiddleware(sessionService.TokenMaker))
+	if p, ok := tokenMaker.(tokenpkg.PublicKeyProvider); ok {
+		engine.GET("/.well-known/jwks.json", jwksdelivery.NewHandler(p).Get)
+	}
+
+	authRoutes := engine.Group("/").Use(middleware.AuthMiddleware(sessionService.TokenMaker, sessionService))
+
+	authRoutes.POST("/accounts", middleware.RequireScope(domain.ScopeAccountsWrite), accountHandler.Create)
+	authRoutes.GET("/accounts/:id", middleware.RequireScope(domain.ScopeAccountsRead), accountHandler.Get)
+	authRoutes.GET("/accounts", middleware.RequireScope(domain.ScopeAccountsRead), accountHandler.List)
+	authRoutes.GET("/accounts/:id/entries", middleware.RequireScope(domain.ScopeAccountsRead), entryHandler.List)
+
+	authRoutes.POST("/aliases", middleware.RequireScope(domain.ScopeAccountsWrite), aliasHandler.Create)
+	authRoutes.GET("/aliases", middleware.RequireScope(domain.ScopeAccountsRead), aliasHandler.List)
+	authRoutes.DELETE("/aliases/:id", middleware.RequireScope(domain.ScopeAccountsWrite), aliasHandler.Delete)
+
+	authRoutes.POST("/transfers", middleware.RequireScope(domain.ScopeTransfersWrite), transferHandler.Create)
+	authRoutes.GET("/transfers/:id", middleware.RequireScope(domain.ScopeTransfersRead), transferHandler.Get)
+	authRoutes.GET("/transfers", middleware.RequireScope(domain.ScopeTransfersRead), transferHandler.List)
+	authRoutes.POST("/transfers/:id/reversal", middleware.RequireScope(domain.ScopeTransfersWrite), transferHandler.Reverse)
+
+	authRoutes.POST("/payees", middleware.RequireScope(domain.ScopeTransfersWrite), payeeHandler.Create)
+	authRoutes.GET("/payees", middleware.RequireScope(domain.ScopeTransfersRead), payeeHandler.List)
+	authRoutes.GET("/payees/:id", middleware.RequireScope(domain.ScopeTransfersRead), payeeHandler.Get)
+	authRoutes.PATCH("/payees/:id", middleware.RequireScope(domain.ScopeTransfersWrite), payeeHandler.Update)
+	authRoutes.DELETE("/payees/:id", middleware.RequireScope(domain.ScopeTransfersWrite), payeeHandler.Delete)
 
-	authRoutes.POST("/accounts", accountHandler.Create)
-	authRoutes.GET("/accounts/:id", accountHandler.Get)
-	authRoutes.GET("/accounts", accountHandler.List)
+	authRoutes.POST("/holds", middleware.RequireScope(domain.ScopeTransfersWrite), holdHandler.Create)
+	authRoutes.GET("/holds/:id", middleware.RequireScope(domain.ScopeTransfersRead), holdHandler.Get)
+	authRoutes.POST("/holds/:id/capture", middleware.RequireScope(domain.ScopeTransfersWrite), holdHandler.Capture)
+	authRoutes.POST("/holds/:id/void", middleware.RequireScope(domain.ScopeTransfersWrite), holdHandler.Void)
 
-	authRoutes.POST("/transfers", transferHandler.Create)
+	authRoutes.POST("/scheduled-transfers", middleware.RequireScope(domain.ScopeTransfersWrite), scheduleHandler.Create)
+	authRoutes.GET("/scheduled-transfers", middleware.RequireScope(domain.ScopeTransfersRead), scheduleHandler.List)
+	authRoutes.GET("/scheduled-transfers/:id", middleware.RequireScope(domain.ScopeTransfersRead), scheduleHandler.Get)
+	authRoutes.GET("/scheduled-transfers/:id/runs", middleware.RequireScope(domain.ScopeTransfersRead), scheduleHandler.ListRuns)
+	authRoutes.POST("/scheduled-transfers/:id/pause", middleware.RequireScope(domain.ScopeTransfersWrite), scheduleHandler.Pause)
+	authRoutes.POST("/scheduled-transfers/:id/resume", middleware.RequireScope(domain.ScopeTransfersWrite), scheduleHandler.Resume)
+	authRoutes.POST("/scheduled-transfers/:id/cancel", middleware.RequireScope(domain.ScopeTransfersWrite), scheduleHandler.Cancel)
+
+	authRoutes.POST("/deposits", middleware.RequireScope(domain.ScopeCashWrite), transferHandler.Deposit)
+	authRoutes.POST("/withdrawals", middleware.RequireScope(domain.ScopeCashWrite), transferHandler.Withdraw)
+
+	authRoutes.POST("/fx/quotes", middleware.RequireScope(domain.ScopeTransfersWrite), fxHandler.CreateQuote)
+
+	authRoutes.GET("/sessions", middleware.RequireScope(domain.ScopeSessionsRead), sessionHandler.List)
+	authRoutes.DELETE("/sessions", middleware.RequireScope(domain.ScopeSessionsWrite), sessionHandler.RevokeAll)
+	authRoutes.DELETE("/sessions/current", middleware.RequireScope(domain.ScopeSessionsWrite), sessionHandler.Logout)
+	authRoutes.DELETE("/sessions/:id", middleware.RequireScope(domain.ScopeSessionsWrite), sessionHandler.Revoke)
+
+	adminRoutes := engine.Group("/admin").Use(
+		middleware.AuthMiddleware(sessionService.TokenMaker, sessionService),
+		middleware.RequireScope(domain.ScopeAdminRead),
+	)
+
+	adminRoutes.GET("/users", adminHandler.SearchUsers)
+	adminRoutes.GET("/users/:username/accounts", adminHandler.ListAccounts)
+	adminRoutes.DELETE("/users/:username/sessions", middleware.RequireScope(domain.ScopeAdminWrite), adminHandler.BlockSessions)
+	adminRoutes.GET("/users/:username/transfer-limits", adminHandler.ListTransferLimits)
+	adminRoutes.PUT("/users/:username/transfer-limits/:currency", middleware.RequireScope(domain.ScopeAdminWrite), adminHandler.SetTransferLimit)
+	adminRoutes.DELETE("/users/:username/transfer-limits/:currency", middleware.RequireScope(domain.ScopeAdminWrite), adminHandler.ResetTransferLimit)
+	adminRoutes.GET("/accounts/:id", adminHandler.GetAccount)
+	adminRoutes.GET("/accounts/:id/entries", adminHandler.ListEntries)
+	adminRoutes.POST("/accounts/:id/freeze", middleware.RequireScope(domain.ScopeAdminWrite), adminHandler.FreezeAccount)
+	adminRoutes.POST("/accounts/:id/unfreeze", middleware.RequireScope(domain.ScopeAdminWrite), adminHandler.UnfreezeAccount)
+	adminRoutes.GET("/transfer-reviews", adminHandler.ListTransferReviews)
+	adminRoutes.POST("/transfer-reviews/:id/approve", middleware.RequireScope(domain.ScopeAdminWrite), adminHandler.ApproveTransferReview)
+	adminRoutes.POST("/transfer-reviews/:id/reject", middleware.RequireScope(domain.ScopeAdminWrite), adminHandler.RejectTransferReview)
+	adminRoutes.GET("/compliance-cases", adminHandler.ListComplianceCases)
+	adminRoutes.POST("/compliance-cases/:id/clear", middleware.RequireScope(domain.ScopeAdminWrite), adminHandler.ClearComplianceCase)
+	adminRoutes.POST("/compliance-cases/:id/confirm", middleware.RequireScope(domain.ScopeAdminWrite), adminHandler.ConfirmComplianceCase)
+	adminRoutes.POST("/sanctions-list/reload", middleware.RequireScope(domain.ScopeAdminWrite), adminHandler.ReloadSanctionsList)
+	adminRoutes.GET("/audit-events", auditHandler.List)
+	adminRoutes.GET("/ledger/verify", adminHandler.VerifyLedger)
 
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		err := v.RegisterValidation("currency", currencypkg.ValidCurrency)
@@ -96,7 +253,95 @@ func New(conn *sql.DB, logger zerolog.Logger, config configpkg.Config) (*Server,
 		DB:     conn,
 		Engine: engine,
 		Config: config,
+
+		idempotencyService:    idempotencyservice.New(idempotencyrepo.NewRepoPGS(conn), config.IdempotencyKeyTTL),
+		holdService:           holdService,
+		scheduleService:       scheduleService,
+		reconciliationService: reconciliationservice.New(reconciliationrepo.NewRepoPGS(conn)),
 	}
 
 	return server, nil
 }
+
+// RunJobs starts the background jobs with the services the server handlers
+// use. The scheduled reconciliation is started only if its interval is set.
+// The other jobs are skipped with a warning if their interval is not
+// positive. The jobs stop when the context is done.
+func (s *Server) RunJobs(ctx context.Context) {
+	l := zerolog.Ctx(ctx)
+
+	jobs := []struct {
+		setting  string
+		interval time.Duration
+		run      func(ctx context.Context, interval time.Duration)
+	}{
+		{"IDEMPOTENCY_REAPER_INTERVAL", s.Config.IdempotencyReaperInterval, s.idempotencyService.RunReaper},
+		{"HOLD_REAPER_INTERVAL", s.Config.HoldReaperInterval, s.holdService.RunReaper},
+		{"SCHEDULER_INTERVAL", s.Config.SchedulerInterval, func(ctx context.Context, interval time.Duration) {
+			s.scheduleService.RunScheduler(ctx, interval, s.Config.SchedulerLease)
+		}},
+	}
+
+	// time.NewTicker panics on a non-positive interval.
+	for _, j := range jobs {
+		if j.interval <= 0 {
+			l.Warn().Str("setting", j.setting).Dur("interval", j.interval).Msg("Job is not run, its interval is not positive")
+			continue
+		}
+
+		go j.run(ctx, j.interval)
+	}
+
+	if s.Config.ReconciliationInterval > 0 {
+		go s.reconciliationService.RunScheduled(ctx, s.Config.ReconciliationInterval)
+	}
+}
+
+// newRateProvider returns exchange rates from the configured file or the built-in rates.
+func newRateProvider(config configpkg.Config) (fxpkg.RateProvider, error) {
+	if config.FXRatesFile != "" {
+		return fxpkg.NewFileProvider(config.FXRatesFile)
+	}
+
+	return fxpkg.NewStaticProvider(fxpkg.DefaultRates)
+}
+
+// newMonitor returns the transaction monitoring rules read from the configured
+// file, or nil if it is not set.
+func newMonitor(config configpkg.Config, r monitoringservice.Repo) (transferservice.Monitor, error) {
+	if config.MonitoringRulesFile == "" {
+		return nil, nil
+	}
+
+	monitor, err := monitoringservice.Load(config.MonitoringRulesFile, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return monitor, nil
+}
+
+// newTokenMaker returns token maker of the configured format.
+func newTokenMaker(config configpkg.Config) (tokenpkg.Maker, error) {
+	switch config.TokenFormat {
+	case "", "paseto.v2.local":
+		return tokenpkg.NewPasetoMaker(config.TokenSymmetricKey)
+	case "paseto.v2.public", "paseto.v4.public", "jwt.eddsa":
+	default:
+		return nil, fmt.Errorf("unsupported token format %q", config.TokenFormat)
+	}
+
+	keys, err := tokenpkg.ParseKeySet(config.TokenSigningKeyID, config.TokenSigningKey, config.TokenVerificationKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	switch config.TokenFormat {
+	case "paseto.v2.public":
+		return tokenpkg.NewPasetoPublicMaker(tokenpkg.PasetoV2, keys)
+	case "paseto.v4.public":
+		return tokenpkg.NewPasetoPublicMaker(tokenpkg.PasetoV4, keys)
+	default:
+		return tokenpkg.NewJWTEdDSAMaker(keys)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/go-petr/pet-bank/internal/domain"
 	"github.com/go-petr/pet-bank/internal/integrationtest"
 	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
+	"github.com/go-petr/pet-bank/internal/middleware"
 	"github.com/go-petr/pet-bank/pkg/tokenpkg"
 	"github.com/go-petr/pet-bank/pkg/web"
 )
@@ -277,3 +278,175 @@ func TestRenewAccessTokenAPI(t *testing.T) {
 		})
 	}
 }
+
+func TestRenewAccessTokenReuseAPI(t *testing.T) {
+	server := integrationtest.SetupServer(t)
+
+	tokenMaker, err := tokenpkg.NewPasetoMaker(server.Config.TokenSymmetricKey)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(%v) returned error: %v",
+			server.Config.TokenSymmetricKey, err)
+	}
+
+	duration := server.Config.RefreshTokenDuration
+	user := helpers.SeedUser(t, server.DB)
+
+	refreshToken, payload, err := tokenMaker.CreateToken(user.Username, duration)
+	if err != nil {
+		t.Fatalf("tokenMaker.CreateToken(%v, %v) returned error: %v", user.Username, duration, err)
+	}
+
+	helpers.SeedSession(t, server.DB, domain.CreateSessionParams{
+		ID:           payload.ID,
+		Username:     user.Username,
+		RefreshToken: refreshToken,
+		UserAgent:    "Mozilla/5.0",
+		ClientIP:     "123.123.123.123",
+		ExpiresAt:    payload.ExpiredAt,
+	})
+
+	renew := func(token string) (int, web.Response) {
+		body, err := json.Marshal(map[string]string{"refresh_token": token})
+		if err != nil {
+			t.Fatalf("Encoding request body error: %v", err)
+		}
+
+		req, err := http.NewRequest(http.MethodPost, "/sessions", bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("Creating request error: %v", err)
+		}
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+
+		var res web.Response
+		if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+			t.Fatalf("Decoding response body error: %v", err)
+		}
+
+		return w.Code, res
+	}
+
+	code, res := renew(refreshToken)
+	if code != http.StatusCreated {
+		t.Fatalf("Status code: got %v, want %v", code, http.StatusCreated)
+	}
+
+	if res.RefreshToken == "" || res.RefreshToken == refreshToken {
+		t.Fatalf("res.RefreshToken = %q, want new non empty token", res.RefreshToken)
+	}
+
+	rotatedToken := res.RefreshToken
+
+	// Reusing the rotated-out token blocks the whole family.
+	code, res = renew(refreshToken)
+	if code != http.StatusForbidden || res.Error != domain.ErrRefreshTokenReused.Error() {
+		t.Errorf("renew(old token) = %v %q, want %v %q",
+			code, res.Error, http.StatusForbidden, domain.ErrRefreshTokenReused.Error())
+	}
+
+	code, res = renew(rotatedToken)
+	if code != http.StatusForbidden || res.Error != domain.ErrBlockedSession.Error() {
+		t.Errorf("renew(new token) = %v %q, want %v %q",
+			code, res.Error, http.StatusForbidden, domain.ErrBlockedSession.Error())
+	}
+}
+
+func TestLogoutAPI(t *testing.T) {
+	server := integrationtest.SetupServer(t)
+
+	tokenMaker, err := tokenpkg.NewPasetoMaker(server.Config.TokenSymmetricKey)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(%v) returned error: %v",
+			server.Config.TokenSymmetricKey, err)
+	}
+
+	duration := server.Config.RefreshTokenDuration
+	user := helpers.SeedUser(t, server.DB)
+
+	refreshToken, payload, err := tokenMaker.CreateToken(user.Username, duration)
+	if err != nil {
+		t.Fatalf("tokenMaker.CreateToken(%v, %v) returned error: %v", user.Username, duration, err)
+	}
+
+	helpers.SeedSession(t, server.DB, domain.CreateSessionParams{
+		ID:           payload.ID,
+		Username:     user.Username,
+		RefreshToken: refreshToken,
+		UserAgent:    "Mozilla/5.0",
+		ClientIP:     "123.123.123.123",
+		ExpiresAt:    payload.ExpiredAt,
+	})
+
+	body, err := json.Marshal(map[string]string{"refresh_token": refreshToken})
+	if err != nil {
+		t.Fatalf("Encoding request body error: %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodDelete, "/sessions/current", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("Creating request error: %v", err)
+	}
+
+	claims := tokenpkg.Claims{
+		Username:  user.Username,
+		SessionID: payload.ID,
+		Role:      domain.RoleCustomer,
+		Scopes:    domain.RoleScopes(domain.RoleCustomer),
+	}
+
+	accessToken, _, err := tokenMaker.CreateTokenWithClaims(claims, time.Minute)
+	if err != nil {
+		t.Fatalf("tokenMaker.CreateTokenWithClaims(%+v, %v) returned error: %v", claims, time.Minute, err)
+	}
+
+	authHeader := middleware.AuthTypeBearer + " " + accessToken
+	req.Header.Set(middleware.AuthHeaderKey, authHeader)
+
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+
+	if got := w.Code; got != http.StatusNoContent {
+		t.Fatalf("Status code: got %v, want %v", got, http.StatusNoContent)
+	}
+
+	// The refresh token of the ended session cannot be renewed.
+	req, err = http.NewRequest(http.MethodPost, "/sessions", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("Creating request error: %v", err)
+	}
+
+	w = httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+
+	var res web.Response
+	if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+		t.Fatalf("Decoding response body error: %v", err)
+	}
+
+	if w.Code != http.StatusForbidden || res.Error != domain.ErrBlockedSession.Error() {
+		t.Errorf("renew after logout = %v %q, want %v %q",
+			w.Code, res.Error, http.StatusForbidden, domain.ErrBlockedSession.Error())
+	}
+
+	// The access token of the ended session is rejected before it expires.
+	req, err = http.NewRequest(http.MethodGet, "/sessions", nil)
+	if err != nil {
+		t.Fatalf("Creating request error: %v", err)
+	}
+
+	req.Header.Set(middleware.AuthHeaderKey, authHeader)
+
+	w = httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+
+	res = web.Response{}
+	if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+		t.Fatalf("Decoding response body error: %v", err)
+	}
+
+	if w.Code != http.StatusUnauthorized || res.Error != tokenpkg.ErrRevokedToken.Error() {
+		t.Errorf("request after logout = %v %q, want %v %q",
+			w.Code, res.Error, http.StatusUnauthorized, tokenpkg.ErrRevokedToken.Error())
+	}
+}
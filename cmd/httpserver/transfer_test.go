@@ -4,7 +4,9 @@ package httpserver_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -14,6 +16,7 @@ import (
 	"github.com/go-petr/pet-bank/internal/integrationtest"
 	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
 	"github.com/go-petr/pet-bank/internal/middleware"
+	"github.com/go-petr/pet-bank/internal/transferrepo"
 	"github.com/go-petr/pet-bank/pkg/currencypkg"
 	"github.com/go-petr/pet-bank/pkg/tokenpkg"
 	"github.com/go-petr/pet-bank/pkg/web"
@@ -61,7 +64,7 @@ func TestCreateTranferAPI(t *testing.T) {
 				Amount:        amount,
 			},
 			setupAuth: func(r *http.Request) error {
-				return middleware.AddAuthorization(r, tokenMaker, authType, user1.Username, duration)
+				return helpers.AddAuthorization(t, server.DB, r, tokenMaker, authType, user1.Username, duration)
 			},
 			wantStatusCode: http.StatusCreated,
 			checkData: func(req requestBody, data any) {
@@ -74,22 +77,27 @@ func TestCreateTranferAPI(t *testing.T) {
 
 				want := domain.TransferTxResult{
 					Transfer: domain.Transfer{
-						FromAccountID: req.FromAccountID,
-						ToAccountID:   req.ToAccountID,
-						Amount:        req.Amount,
-						CreatedAt:     time.Now().UTC().Truncate(time.Second),
+						FromAccountID:  req.FromAccountID,
+						ToAccountID:    req.ToAccountID,
+						Amount:         req.Amount,
+						Kind:           domain.TransferKindTransfer,
+						Status:         domain.TransferStatusCompleted,
+						RefundedAmount: "0",
+						CreatedAt:      time.Now().UTC().Truncate(time.Second),
 					},
 					FromAccount: domain.Account{
-						Owner:     account1.Owner,
-						Balance:   "900",
-						Currency:  account1.Currency,
-						CreatedAt: account1.CreatedAt,
+						Owner:            account1.Owner,
+						Balance:          "900",
+						AvailableBalance: "900",
+						Currency:         account1.Currency,
+						CreatedAt:        account1.CreatedAt,
 					},
 					ToAccount: domain.Account{
-						Owner:     account2.Owner,
-						Balance:   "1100",
-						Currency:  account2.Currency,
-						CreatedAt: account2.CreatedAt,
+						Owner:            account2.Owner,
+						Balance:          "1100",
+						AvailableBalance: "1100",
+						Currency:         account2.Currency,
+						CreatedAt:        account2.CreatedAt,
 					},
 					FromEntry: domain.Entry{
 						AccountID: account1.ID,
@@ -105,7 +113,7 @@ func TestCreateTranferAPI(t *testing.T) {
 
 				ignoreAccountID := cmpopts.IgnoreFields(domain.Account{}, "ID")
 				ignoreTransferID := cmpopts.IgnoreFields(domain.Transfer{}, "ID")
-				ignoreEntryID := cmpopts.IgnoreFields(domain.Entry{}, "ID")
+				ignoreEntryID := cmpopts.IgnoreFields(domain.Entry{}, "ID", "TransferID", "PrevHash", "Hash")
 
 				compareCreatedAt := cmpopts.EquateApproxTime(time.Second)
 				if diff := cmp.Diff(want, got.Transfer, ignoreTransferID, ignoreAccountID, ignoreEntryID, compareCreatedAt); diff != "" {
@@ -134,7 +142,7 @@ func TestCreateTranferAPI(t *testing.T) {
 				Amount:        amount,
 			},
 			setupAuth: func(r *http.Request) error {
-				return middleware.AddAuthorization(r, tokenMaker, authType, user1.Username, duration)
+				return helpers.AddAuthorization(t, server.DB, r, tokenMaker, authType, user1.Username, duration)
 			},
 			wantStatusCode: http.StatusBadRequest,
 			wantError:      "FromAccountID field is required",
@@ -147,7 +155,7 @@ func TestCreateTranferAPI(t *testing.T) {
 				Amount:        amount,
 			},
 			setupAuth: func(r *http.Request) error {
-				return middleware.AddAuthorization(r, tokenMaker, authType, user1.Username, duration)
+				return helpers.AddAuthorization(t, server.DB, r, tokenMaker, authType, user1.Username, duration)
 			},
 			wantStatusCode: http.StatusBadRequest,
 			wantError:      "ToAccountID field is required",
@@ -160,7 +168,7 @@ func TestCreateTranferAPI(t *testing.T) {
 				Amount:        "",
 			},
 			setupAuth: func(r *http.Request) error {
-				return middleware.AddAuthorization(r, tokenMaker, authType, user1.Username, duration)
+				return helpers.AddAuthorization(t, server.DB, r, tokenMaker, authType, user1.Username, duration)
 			},
 			wantStatusCode: http.StatusBadRequest,
 			wantError:      "Amount field is required",
@@ -173,7 +181,7 @@ func TestCreateTranferAPI(t *testing.T) {
 				Amount:        amount,
 			},
 			setupAuth: func(r *http.Request) error {
-				return middleware.AddAuthorization(r, tokenMaker, authType, user2.Username, duration)
+				return helpers.AddAuthorization(t, server.DB, r, tokenMaker, authType, user2.Username, duration)
 			},
 			wantStatusCode: http.StatusUnauthorized,
 			wantError:      domain.ErrInvalidOwner.Error(),
@@ -186,7 +194,7 @@ func TestCreateTranferAPI(t *testing.T) {
 				Amount:        amount,
 			},
 			setupAuth: func(r *http.Request) error {
-				return middleware.AddAuthorization(r, tokenMaker, authType, user1.Username, duration)
+				return helpers.AddAuthorization(t, server.DB, r, tokenMaker, authType, user1.Username, duration)
 			},
 			wantStatusCode: http.StatusBadRequest,
 			wantError:      domain.ErrCurrencyMismatch.Error(),
@@ -242,3 +250,113 @@ func TestCreateTranferAPI(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTransferAPI(t *testing.T) {
+	server := integrationtest.SetupServer(t)
+
+	user1 := helpers.SeedUser(t, server.DB)
+	account1 := helpers.SeedAccountWith1000USDBalance(t, server.DB, user1.Username)
+	user2 := helpers.SeedUser(t, server.DB)
+	account2 := helpers.SeedAccountWith1000USDBalance(t, server.DB, user2.Username)
+	user3 := helpers.SeedUser(t, server.DB)
+
+	arg := domain.CreateTransferParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        "10",
+	}
+
+	result, err := transferrepo.NewRepoPGS(server.DB).Transfer(context.Background(), user1.Username, arg)
+	if err != nil {
+		t.Fatalf("transferRepo.Transfer(context.Background(), %v, %+v) returned error: %v", user1.Username, arg, err)
+	}
+
+	tokenMaker, err := tokenpkg.NewPasetoMaker(server.Config.TokenSymmetricKey)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(%v) returned error: %v", server.Config.TokenSymmetricKey, err)
+	}
+
+	authType := middleware.AuthTypeBearer
+	duration := server.Config.AccessTokenDuration
+
+	testCases := []struct {
+		name           string
+		url            string
+		username       string
+		wantStatusCode int
+		wantError      string
+	}{
+		{
+			name:           "Sender",
+			url:            fmt.Sprintf("/transfers/%d", result.Transfer.ID),
+			username:       user1.Username,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "Recipient",
+			url:            fmt.Sprintf("/transfers/%d", result.Transfer.ID),
+			username:       user2.Username,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "ErrTransferOwnerMismatch",
+			url:            fmt.Sprintf("/transfers/%d", result.Transfer.ID),
+			username:       user3.Username,
+			wantStatusCode: http.StatusUnauthorized,
+			wantError:      domain.ErrTransferOwnerMismatch.Error(),
+		},
+		{
+			name:           "ErrTransferNotFound",
+			url:            fmt.Sprintf("/transfers/%d", result.Transfer.ID+1000),
+			username:       user1.Username,
+			wantStatusCode: http.StatusNotFound,
+			wantError:      domain.ErrTransferNotFound.Error(),
+		},
+		{
+			name:           "ListOutgoing",
+			url:            "/transfers?page_id=1&page_size=5&direction=outgoing",
+			username:       user1.Username,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "ListAnotherUserAccount",
+			url:            fmt.Sprintf("/transfers?page_id=1&page_size=5&account_id=%d", account1.ID),
+			username:       user3.Username,
+			wantStatusCode: http.StatusUnauthorized,
+			wantError:      domain.ErrAccountOwnerMismatch.Error(),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			if err != nil {
+				t.Fatalf("Creating request error: %v", err)
+			}
+
+			if err := helpers.AddAuthorization(t, server.DB, req, tokenMaker, authType, tc.username, duration); err != nil {
+				t.Fatalf("helpers.AddAuthorization(...) returned error: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			if got := w.Code; got != tc.wantStatusCode {
+				t.Errorf("Status code: got %v, want %v", got, tc.wantStatusCode)
+			}
+
+			var res web.Response
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Errorf("Decoding response body error: %v", err)
+			}
+
+			if res.Error != tc.wantError {
+				t.Errorf(`resp.Error=%q, want %q`, res.Error, tc.wantError)
+			}
+		})
+	}
+}
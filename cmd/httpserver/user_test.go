@@ -77,6 +77,7 @@ func TestCreateUserAPI(t *testing.T) {
 					Username:  reqBody["username"].(string),
 					FullName:  reqBody["fullname"].(string),
 					Email:     reqBody["email"].(string),
+					Role:      domain.RoleCustomer,
 					CreatedAt: time.Now().Truncate(time.Second),
 				}
 
@@ -248,6 +249,7 @@ func TestLoginUserAPI(t *testing.T) {
 					Username:  user.Username,
 					FullName:  user.FullName,
 					Email:     user.Email,
+					Role:      domain.RoleCustomer,
 					CreatedAt: user.CreatedAt,
 				}
 
@@ -2,6 +2,9 @@
 package main
 
 import (
+	"context"
+	"os"
+
 	"github.com/rs/zerolog/log"
 
 	"github.com/go-petr/pet-bank/cmd/httpserver"
@@ -25,11 +28,19 @@ func main() {
 		logger.Fatal().Err(err).Msg("Cannot connect to database")
 	}
 
+	ctx := logger.WithContext(context.Background())
+
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(ctx, db, os.Args[1], os.Args[2:]))
+	}
+
 	server, err := httpserver.New(db, logger, config)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Cannot create server")
 	}
 
+	server.RunJobs(ctx)
+
 	logger.Info().Msg("BANK API SERVER HAS STARTED")
 
 	err = server.Engine.Run(config.ServerAddress)
@@ -0,0 +1,12 @@
+CREATE TABLE "idempotency_keys" (
+    "key" varchar NOT NULL,
+    "username" varchar NOT NULL,
+    "request_hash" varchar NOT NULL,
+    "response_status" int NOT NULL,
+    "response_body" jsonb NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("username", "key"),
+    FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE
+);
+
+CREATE INDEX ON "idempotency_keys" ("created_at");
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS "transfers" DROP COLUMN IF EXISTS "fx_rate";
+DROP TABLE IF EXISTS "fx_quotes";
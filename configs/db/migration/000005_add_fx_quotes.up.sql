@@ -0,0 +1,16 @@
+CREATE TABLE "fx_quotes" (
+    "id" uuid PRIMARY KEY,
+    "username" varchar NOT NULL,
+    "from_currency" varchar NOT NULL,
+    "to_currency" varchar NOT NULL,
+    "rate" numeric NOT NULL CHECK ("rate" > 0),
+    "expires_at" timestamptz NOT NULL,
+    "used_at" timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE
+);
+
+CREATE INDEX ON "fx_quotes" ("username");
+
+ALTER TABLE "transfers" ADD COLUMN "fx_rate" numeric CHECK ("fx_rate" > 0);
+COMMENT ON COLUMN "transfers"."fx_rate" IS 'applied exchange rate for cross-currency transfers';
@@ -0,0 +1,5 @@
+ALTER TABLE "entries" ADD COLUMN "transfer_id" bigint;
+ALTER TABLE "entries" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "entries" ("transfer_id");
+COMMENT ON COLUMN "entries"."transfer_id" IS 'transfer that produced the entry';
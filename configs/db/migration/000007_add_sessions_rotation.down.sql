@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS "sessions" DROP COLUMN IF EXISTS "rotated_at";
+ALTER TABLE IF EXISTS "sessions" DROP COLUMN IF EXISTS "family_id";
+ALTER TABLE IF EXISTS "sessions" DROP COLUMN IF EXISTS "parent_id";
@@ -0,0 +1,13 @@
+ALTER TABLE "sessions" ADD COLUMN "parent_id" uuid;
+ALTER TABLE "sessions" ADD COLUMN "family_id" uuid;
+ALTER TABLE "sessions" ADD COLUMN "rotated_at" timestamptz;
+
+UPDATE "sessions" SET "family_id" = "id";
+ALTER TABLE "sessions" ALTER COLUMN "family_id" SET NOT NULL;
+
+ALTER TABLE "sessions" ADD FOREIGN KEY ("parent_id") REFERENCES "sessions" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "sessions" ("family_id");
+COMMENT ON COLUMN "sessions"."parent_id" IS 'session whose refresh token was rotated into this one';
+COMMENT ON COLUMN "sessions"."family_id" IS 'first session of the rotation chain';
+COMMENT ON COLUMN "sessions"."rotated_at" IS 'time the refresh token was replaced by a child session';
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS "sessions" DROP COLUMN IF EXISTS "scopes";
+ALTER TABLE IF EXISTS "users" DROP COLUMN IF EXISTS "role";
@@ -0,0 +1,7 @@
+ALTER TABLE "users" ADD COLUMN "role" varchar NOT NULL DEFAULT 'customer';
+ALTER TABLE "users" ADD CONSTRAINT "users_role_check" CHECK ("role" IN ('customer', 'support', 'admin'));
+
+ALTER TABLE "sessions" ADD COLUMN "scopes" varchar[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN "users"."role" IS 'customer, support or admin';
+COMMENT ON COLUMN "sessions"."scopes" IS 'scopes requested at login, empty means all scopes of the user role';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "admin_actions";
+ALTER TABLE IF EXISTS "accounts" DROP COLUMN IF EXISTS "frozen_at";
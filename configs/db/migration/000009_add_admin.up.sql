@@ -0,0 +1,16 @@
+ALTER TABLE "accounts" ADD COLUMN "frozen_at" timestamptz;
+COMMENT ON COLUMN "accounts"."frozen_at" IS 'set while the account is frozen, frozen accounts cannot send or receive money';
+
+CREATE TABLE "admin_actions" (
+    "id" bigserial PRIMARY KEY,
+    "actor" varchar NOT NULL,
+    "action" varchar NOT NULL,
+    "target" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    FOREIGN KEY ("actor") REFERENCES "users" ("username")
+);
+
+CREATE INDEX ON "admin_actions" ("actor");
+CREATE INDEX ON "admin_actions" ("target");
+
+COMMENT ON COLUMN "admin_actions"."actor" IS 'username of the staff member who performed the action';
@@ -0,0 +1,27 @@
+CREATE TABLE "audit_events" (
+    "id" bigserial PRIMARY KEY,
+    "type" varchar NOT NULL,
+    "actor" varchar NOT NULL,
+    "ip" varchar NOT NULL DEFAULT '',
+    "request_id" varchar NOT NULL DEFAULT '',
+    "before" jsonb,
+    "after" jsonb,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "audit_events" ("actor", "created_at");
+CREATE INDEX ON "audit_events" ("type", "created_at");
+CREATE INDEX ON "audit_events" ("created_at");
+
+COMMENT ON COLUMN "audit_events"."actor" IS 'username of the user who performed the action, no foreign key so events outlive users';
+COMMENT ON COLUMN "audit_events"."request_id" IS 'X-Request-ID of the http request';
+
+CREATE FUNCTION "audit_events_immutable"() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "audit_events_immutable"
+BEFORE UPDATE OR DELETE ON "audit_events"
+FOR EACH ROW EXECUTE PROCEDURE "audit_events_immutable"();
@@ -0,0 +1,8 @@
+ALTER TABLE "entries" ADD COLUMN "prev_hash" varchar;
+ALTER TABLE "entries" ADD COLUMN "hash" varchar;
+
+CREATE INDEX ON "entries" ("account_id", "id");
+CREATE UNIQUE INDEX "entries_account_id_prev_hash_key" ON "entries" ("account_id", "prev_hash");
+
+COMMENT ON COLUMN "entries"."prev_hash" IS 'hash of the previous entry of the account, empty for the first chained entry and NULL for entries created before the chain';
+COMMENT ON COLUMN "entries"."hash" IS 'hex encoded SHA-256 over the entry content and prev_hash';
@@ -0,0 +1,13 @@
+CREATE TABLE "reconciliation_reports" (
+    "id" bigserial PRIMARY KEY,
+    "accounts" bigint NOT NULL,
+    "transfers" bigint NOT NULL,
+    "discrepancies" jsonb NOT NULL DEFAULT '[]',
+    "started_at" timestamptz NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "reconciliation_reports" ("created_at");
+
+COMMENT ON COLUMN "reconciliation_reports"."accounts" IS 'number of checked accounts';
+COMMENT ON COLUMN "reconciliation_reports"."transfers" IS 'number of checked transfers';
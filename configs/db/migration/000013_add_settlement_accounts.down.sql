@@ -0,0 +1,8 @@
+ALTER TABLE IF EXISTS "users" DROP CONSTRAINT IF EXISTS "users_role_check";
+ALTER TABLE IF EXISTS "users" ADD CONSTRAINT "users_role_check" CHECK ("role" IN ('customer', 'support', 'admin'));
+
+ALTER TABLE IF EXISTS "transfers" DROP COLUMN IF EXISTS "kind";
+
+ALTER TABLE IF EXISTS "accounts" DROP CONSTRAINT IF EXISTS "accounts_balance_check";
+ALTER TABLE IF EXISTS "accounts" ADD CONSTRAINT "accounts_balance_check" CHECK ("balance" >= 0);
+ALTER TABLE IF EXISTS "accounts" DROP COLUMN IF EXISTS "is_system";
@@ -0,0 +1,13 @@
+ALTER TABLE "accounts" ADD COLUMN "is_system" boolean NOT NULL DEFAULT false;
+ALTER TABLE "accounts" DROP CONSTRAINT "accounts_balance_check";
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_balance_check" CHECK ("balance" >= 0 OR "is_system");
+
+ALTER TABLE "transfers" ADD COLUMN "kind" varchar NOT NULL DEFAULT 'transfer';
+ALTER TABLE "transfers" ADD CONSTRAINT "transfers_kind_check" CHECK ("kind" IN ('transfer', 'deposit', 'withdrawal'));
+
+ALTER TABLE "users" DROP CONSTRAINT "users_role_check";
+ALTER TABLE "users" ADD CONSTRAINT "users_role_check" CHECK ("role" IN ('customer', 'support', 'admin', 'integration'));
+
+COMMENT ON COLUMN "accounts"."is_system" IS 'settlement account which balance may go negative';
+COMMENT ON COLUMN "transfers"."kind" IS 'transfer, deposit or withdrawal';
+COMMENT ON COLUMN "users"."role" IS 'customer, support, admin or integration';
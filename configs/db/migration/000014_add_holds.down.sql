@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "holds";
+ALTER TABLE IF EXISTS "accounts" DROP CONSTRAINT IF EXISTS "accounts_available_balance_check";
+ALTER TABLE IF EXISTS "accounts" DROP COLUMN IF EXISTS "held_amount";
@@ -0,0 +1,25 @@
+ALTER TABLE "accounts" ADD COLUMN "held_amount" numeric NOT NULL DEFAULT 0 CHECK ("held_amount" >= 0);
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_available_balance_check" CHECK ("balance" - "held_amount" >= 0 OR "is_system");
+
+CREATE TABLE "holds" (
+    "id" bigserial PRIMARY KEY,
+    "account_id" int NOT NULL,
+    "to_account_id" int NOT NULL,
+    "amount" numeric NOT NULL CHECK ("amount" > 0),
+    "status" varchar NOT NULL DEFAULT 'active' CHECK ("status" IN ('active', 'captured', 'voided', 'expired')),
+    "captured_amount" numeric,
+    "transfer_id" bigint,
+    "expires_at" timestamptz NOT NULL,
+    "closed_at" timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE,
+    FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE,
+    FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX ON "holds" ("account_id");
+CREATE INDEX ON "holds" ("expires_at") WHERE "status" = 'active';
+
+COMMENT ON COLUMN "accounts"."held_amount" IS 'sum of the active holds, the available balance is balance - held_amount';
+COMMENT ON COLUMN "holds"."status" IS 'active, captured, voided or expired';
+COMMENT ON COLUMN "holds"."captured_amount" IS 'set once the hold is captured, up to amount';
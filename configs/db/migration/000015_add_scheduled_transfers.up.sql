@@ -0,0 +1,38 @@
+CREATE TABLE "scheduled_transfers" (
+    "id" bigserial PRIMARY KEY,
+    "username" varchar NOT NULL,
+    "from_account_id" int NOT NULL,
+    "to_account_id" int NOT NULL,
+    "amount" numeric NOT NULL CHECK ("amount" > 0),
+    "recurrence" varchar NOT NULL DEFAULT '',
+    "start_at" timestamptz NOT NULL,
+    "next_run_at" timestamptz,
+    "status" varchar NOT NULL DEFAULT 'active' CHECK ("status" IN ('active', 'paused', 'cancelled', 'completed')),
+    "locked_until" timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE,
+    FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE,
+    FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX ON "scheduled_transfers" ("username");
+CREATE INDEX ON "scheduled_transfers" ("next_run_at") WHERE "status" = 'active';
+
+CREATE TABLE "scheduled_transfer_runs" (
+    "id" bigserial PRIMARY KEY,
+    "scheduled_transfer_id" bigint NOT NULL,
+    "scheduled_at" timestamptz NOT NULL,
+    "status" varchar NOT NULL DEFAULT 'started' CHECK ("status" IN ('started', 'succeeded', 'failed')),
+    "transfer_id" bigint,
+    "error" varchar NOT NULL DEFAULT '',
+    "finished_at" timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    UNIQUE ("scheduled_transfer_id", "scheduled_at"),
+    FOREIGN KEY ("scheduled_transfer_id") REFERENCES "scheduled_transfers" ("id") ON DELETE CASCADE,
+    FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id") ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN "scheduled_transfers"."recurrence" IS 'empty for one-off, @monthly or five field cron expression in UTC';
+COMMENT ON COLUMN "scheduled_transfers"."next_run_at" IS 'NULL once there are no more runs';
+COMMENT ON COLUMN "scheduled_transfers"."locked_until" IS 'lease of the scheduler instance executing the due run';
+COMMENT ON COLUMN "scheduled_transfer_runs"."scheduled_at" IS 'unique per scheduled transfer, so a run is executed at most once';
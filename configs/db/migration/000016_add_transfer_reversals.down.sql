@@ -0,0 +1,7 @@
+ALTER TABLE IF EXISTS "transfers" DROP CONSTRAINT IF EXISTS "transfers_kind_check";
+-- Existing reversals are kept, so the constraint is checked for new rows only.
+ALTER TABLE IF EXISTS "transfers" ADD CONSTRAINT "transfers_kind_check" CHECK ("kind" IN ('transfer', 'deposit', 'withdrawal')) NOT VALID;
+
+ALTER TABLE IF EXISTS "transfers" DROP COLUMN IF EXISTS "reversed_transfer_id";
+ALTER TABLE IF EXISTS "transfers" DROP COLUMN IF EXISTS "refunded_amount";
+ALTER TABLE IF EXISTS "transfers" DROP COLUMN IF EXISTS "status";
@@ -0,0 +1,15 @@
+ALTER TABLE "transfers" ADD COLUMN "status" varchar NOT NULL DEFAULT 'completed' CHECK ("status" IN ('completed', 'partially_refunded', 'reversed'));
+ALTER TABLE "transfers" ADD COLUMN "refunded_amount" numeric NOT NULL DEFAULT 0;
+ALTER TABLE "transfers" ADD CONSTRAINT "transfers_refunded_amount_check" CHECK ("refunded_amount" >= 0 AND "refunded_amount" <= "amount");
+ALTER TABLE "transfers" ADD COLUMN "reversed_transfer_id" bigint;
+ALTER TABLE "transfers" ADD FOREIGN KEY ("reversed_transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfers" DROP CONSTRAINT "transfers_kind_check";
+ALTER TABLE "transfers" ADD CONSTRAINT "transfers_kind_check" CHECK ("kind" IN ('transfer', 'deposit', 'withdrawal', 'reversal'));
+
+CREATE INDEX ON "transfers" ("reversed_transfer_id");
+
+COMMENT ON COLUMN "transfers"."kind" IS 'transfer, deposit, withdrawal or reversal';
+COMMENT ON COLUMN "transfers"."status" IS 'completed, partially_refunded or reversed';
+COMMENT ON COLUMN "transfers"."refunded_amount" IS 'sum of the reversals of the transfer, up to amount';
+COMMENT ON COLUMN "transfers"."reversed_transfer_id" IS 'transfer compensated by this reversal';
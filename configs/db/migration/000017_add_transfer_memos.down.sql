@@ -0,0 +1,7 @@
+ALTER TABLE IF EXISTS "entries" DROP COLUMN IF EXISTS "metadata";
+ALTER TABLE IF EXISTS "entries" DROP COLUMN IF EXISTS "reference";
+ALTER TABLE IF EXISTS "entries" DROP COLUMN IF EXISTS "description";
+
+ALTER TABLE IF EXISTS "transfers" DROP COLUMN IF EXISTS "metadata";
+ALTER TABLE IF EXISTS "transfers" DROP COLUMN IF EXISTS "reference";
+ALTER TABLE IF EXISTS "transfers" DROP COLUMN IF EXISTS "description";
@@ -0,0 +1,14 @@
+ALTER TABLE "transfers" ADD COLUMN "description" varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE "transfers" ADD COLUMN "reference" varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE "transfers" ADD COLUMN "metadata" jsonb CONSTRAINT "transfers_metadata_check" CHECK (jsonb_typeof("metadata") = 'object');
+
+ALTER TABLE "entries" ADD COLUMN "description" varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE "entries" ADD COLUMN "reference" varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE "entries" ADD COLUMN "metadata" jsonb CONSTRAINT "entries_metadata_check" CHECK (jsonb_typeof("metadata") = 'object');
+
+CREATE INDEX ON "transfers" ("reference") WHERE "reference" <> '';
+
+COMMENT ON COLUMN "transfers"."description" IS 'what the transfer is for, given by the sender';
+COMMENT ON COLUMN "transfers"."reference" IS 'external reference, e.g. an invoice number';
+COMMENT ON COLUMN "transfers"."metadata" IS 'free-form JSON object';
+COMMENT ON COLUMN "entries"."description" IS 'copied from the transfer';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "users_lower_idx";
+DROP TABLE IF EXISTS "user_aliases";
@@ -0,0 +1,18 @@
+CREATE TABLE "user_aliases" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "kind" varchar NOT NULL CHECK ("kind" IN ('phone', 'handle')),
+  "value" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "user_aliases_kind_value_key" UNIQUE ("kind", "value")
+);
+
+ALTER TABLE "user_aliases" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+CREATE INDEX ON "user_aliases" ("username");
+
+-- Email aliases are resolved by the user email, case-insensitively.
+CREATE INDEX ON "users" (lower("email"));
+
+COMMENT ON COLUMN "user_aliases"."kind" IS 'phone or handle, the user email is the email alias';
+COMMENT ON COLUMN "user_aliases"."value" IS 'normalized alias: E.164 phone number or lowercase handle without @';
@@ -0,0 +1,19 @@
+CREATE TABLE "payees" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "nickname" varchar(64) NOT NULL,
+  "account_id" int NOT NULL,
+  "currency" varchar NOT NULL,
+  "usable_at" timestamptz NOT NULL DEFAULT (now()),
+  "last_used_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "payees_username_nickname_key" UNIQUE ("username", "nickname"),
+  CONSTRAINT "payees_username_account_id_key" UNIQUE ("username", "account_id")
+);
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "payees" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "payees" ("account_id");
+
+COMMENT ON COLUMN "payees"."usable_at" IS 'end of the cooling-off period, transfers to the payee are rejected before it';
@@ -0,0 +1,16 @@
+CREATE TABLE "user_transfer_limits" (
+  "username" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "per_transaction" numeric CHECK ("per_transaction" > 0),
+  "daily" numeric CHECK ("daily" > 0),
+  "monthly" numeric CHECK ("monthly" > 0),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("username", "currency")
+);
+
+ALTER TABLE "user_transfer_limits" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+-- Outgoing transfers of the day and month are summed on every transfer.
+CREATE INDEX ON "transfers" ("from_account_id", "created_at") WHERE "kind" = 'transfer';
+
+COMMENT ON TABLE "user_transfer_limits" IS 'per user overrides of the configured outgoing transfer limits, null limits fall back to the configured ones';
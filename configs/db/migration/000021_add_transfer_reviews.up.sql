@@ -0,0 +1,30 @@
+CREATE TABLE "transfer_reviews" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "from_account_id" int NOT NULL,
+  "to_account_id" int NOT NULL,
+  "amount" numeric NOT NULL CHECK ("amount" > 0),
+  "rules" text[] NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending_review' CHECK ("status" IN ('pending_review', 'approved', 'rejected')),
+  "transfer_id" bigint,
+  "reviewer" varchar,
+  "reviewed_at" timestamptz,
+  "description" varchar(255) NOT NULL DEFAULT '',
+  "reference" varchar(64) NOT NULL DEFAULT '',
+  "metadata" jsonb CONSTRAINT "transfer_reviews_metadata_check" CHECK (jsonb_typeof("metadata") = 'object'),
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "transfer_reviews" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "transfer_reviews" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+ALTER TABLE "transfer_reviews" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+ALTER TABLE "transfer_reviews" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "transfer_reviews" ("from_account_id") WHERE "status" = 'pending_review';
+CREATE INDEX ON "transfer_reviews" ("username", "created_at");
+
+COMMENT ON TABLE "transfer_reviews" IS 'transfers flagged by the transaction monitoring rules, their amount is held on the from account while pending';
+COMMENT ON COLUMN "transfer_reviews"."rules" IS 'names of the monitoring rules which flagged the transfer';
+COMMENT ON COLUMN "transfer_reviews"."status" IS 'pending_review, approved or rejected';
+COMMENT ON COLUMN "transfer_reviews"."transfer_id" IS 'transfer executed once approved';
+COMMENT ON COLUMN "transfer_reviews"."reviewer" IS 'staff member who approved or rejected the transfer';
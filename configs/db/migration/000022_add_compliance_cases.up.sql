@@ -0,0 +1,22 @@
+CREATE TABLE "compliance_cases" (
+  "id" bigserial PRIMARY KEY,
+  "action" varchar NOT NULL CHECK ("action" IN ('user_create', 'transfer')),
+  "actor" varchar NOT NULL,
+  "username" varchar NOT NULL,
+  "name" varchar NOT NULL,
+  "matches" jsonb NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'open' CHECK ("status" IN ('open', 'cleared', 'confirmed')),
+  "reviewer" varchar,
+  "reviewed_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX "compliance_cases_open_key" ON "compliance_cases" ("action", "actor", "username", "name") WHERE "status" = 'open';
+CREATE INDEX ON "compliance_cases" ("username", "name") WHERE "status" = 'cleared';
+
+COMMENT ON TABLE "compliance_cases" IS 'actions blocked by the sanctions screening, kept for compliance review';
+COMMENT ON COLUMN "compliance_cases"."actor" IS 'user who attempted the action, it may not exist if the user creation was blocked';
+COMMENT ON COLUMN "compliance_cases"."username" IS 'screened user, the created user or the transfer recipient';
+COMMENT ON COLUMN "compliance_cases"."name" IS 'screened full name';
+COMMENT ON COLUMN "compliance_cases"."matches" IS 'sanctions list entries similar to the name';
+COMMENT ON COLUMN "compliance_cases"."status" IS 'open, cleared as a false positive or confirmed';
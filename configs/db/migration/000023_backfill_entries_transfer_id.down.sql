@@ -0,0 +1 @@
+-- The backfilled transfer ids are kept, the column is dropped by 000006.
@@ -0,0 +1,20 @@
+-- Entries created before 000006 have no transfer_id. Each transfer and its two
+-- entries were inserted by a single transaction, so they share created_at.
+-- Entries matching more than one transfer are left for the reconciliation to
+-- report.
+WITH "matches" AS (
+  SELECT "e"."id" AS "entry_id", min("t"."id") AS "transfer_id"
+  FROM "entries" "e"
+  JOIN "transfers" "t" ON "t"."created_at" = "e"."created_at"
+    AND (
+      ("e"."account_id" = "t"."from_account_id" AND "e"."amount" = -"t"."amount")
+      OR ("e"."account_id" = "t"."to_account_id" AND "e"."amount" = "t"."amount")
+    )
+  WHERE "e"."transfer_id" IS NULL
+  GROUP BY "e"."id"
+  HAVING count(*) = 1
+)
+UPDATE "entries"
+SET "transfer_id" = "matches"."transfer_id"
+FROM "matches"
+WHERE "entries"."id" = "matches"."entry_id";
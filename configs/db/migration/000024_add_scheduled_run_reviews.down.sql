@@ -0,0 +1,5 @@
+ALTER TABLE IF EXISTS "scheduled_transfer_runs" DROP COLUMN IF EXISTS "transfer_review_id";
+UPDATE "scheduled_transfer_runs" SET "status" = 'succeeded' WHERE "status" = 'pending_review';
+ALTER TABLE IF EXISTS "scheduled_transfer_runs" DROP CONSTRAINT IF EXISTS "scheduled_transfer_runs_status_check";
+ALTER TABLE IF EXISTS "scheduled_transfer_runs" ADD CONSTRAINT "scheduled_transfer_runs_status_check"
+    CHECK ("status" IN ('started', 'succeeded', 'failed'));
@@ -0,0 +1,9 @@
+ALTER TABLE "scheduled_transfer_runs" DROP CONSTRAINT "scheduled_transfer_runs_status_check";
+ALTER TABLE "scheduled_transfer_runs" ADD CONSTRAINT "scheduled_transfer_runs_status_check"
+    CHECK ("status" IN ('started', 'succeeded', 'failed', 'pending_review'));
+ALTER TABLE "scheduled_transfer_runs" ADD COLUMN "transfer_review_id" bigint;
+ALTER TABLE "scheduled_transfer_runs" ADD FOREIGN KEY ("transfer_review_id") REFERENCES "transfer_reviews" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "scheduled_transfer_runs" ("transfer_review_id") WHERE "status" = 'pending_review';
+
+COMMENT ON COLUMN "scheduled_transfer_runs"."transfer_review_id" IS 'set if the transfer of the run was held for review';
@@ -15,6 +15,8 @@ require (
 	github.com/shopspring/decimal v1.3.1
 	github.com/spf13/viper v1.15.0
 	golang.org/x/crypto v0.6.0
+	golang.org/x/text v0.7.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -53,8 +55,6 @@ require (
 	github.com/ugorji/go/codec v1.2.10 // indirect
 	golang.org/x/net v0.7.0 // indirect
 	golang.org/x/sys v0.5.0 // indirect
-	golang.org/x/text v0.7.0 // indirect
 	google.golang.org/protobuf v1.28.1 // indirect
 	gopkg.in/ini.v1 v1.67.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -13,6 +13,7 @@ import (
 
 	"github.com/go-petr/pet-bank/internal/domain"
 	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
 	"github.com/go-petr/pet-bank/pkg/web"
 
 	"github.com/go-petr/pet-bank/pkg/tokenpkg"
@@ -24,7 +25,7 @@ import (
 type Service interface {
 	Create(ctx context.Context, owner, currency string) (domain.Account, error)
 	Get(ctx context.Context, id int32) (domain.Account, error)
-	List(ctx context.Context, owner string, pageSize, pageID int32) ([]domain.Account, error)
+	List(ctx context.Context, owner string, page pagepkg.Request) ([]domain.Account, pagepkg.Page, error)
 }
 
 // Handler facilitates account delivery layer logic.
@@ -147,11 +148,14 @@ func (h *Handler) Get(gctx *gin.Context) {
 }
 
 type listRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=1,max=100"`
+	PageID    int32  `form:"page_id" binding:"required_without=PageToken,omitempty,min=1"`
+	PageSize  int32  `form:"page_size" binding:"required,min=1,max=100"`
+	PageToken string `form:"page_token"`
 }
 
 // List handles http request to list accounts.
+//
+// The page is located by page_token if it is set, otherwise by page_id.
 func (h *Handler) List(gctx *gin.Context) {
 	ctx := gctx.Request.Context()
 	l := zerolog.Ctx(gctx)
@@ -172,9 +176,23 @@ func (h *Handler) List(gctx *gin.Context) {
 		return
 	}
 
+	pageReq := pagepkg.Request{PageID: req.PageID, PageSize: req.PageSize}
+
+	if req.PageToken != "" {
+		cursor, err := pagepkg.Parse(req.PageToken)
+		if err != nil {
+			l.Info().Err(err).Send()
+			gctx.JSON(http.StatusBadRequest, web.Error(err))
+
+			return
+		}
+
+		pageReq.Cursor = cursor
+	}
+
 	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
 
-	accounts, err := h.service.List(ctx, authPayload.Username, req.PageID, req.PageSize)
+	accounts, page, err := h.service.List(ctx, authPayload.Username, pageReq)
 	if err != nil {
 		gctx.JSON(http.StatusInternalServerError, web.Error(errorspkg.ErrInternal))
 
@@ -187,6 +205,8 @@ func (h *Handler) List(gctx *gin.Context) {
 		}{
 			Accounts: accounts,
 		},
+		NextCursor: page.Next,
+		PrevCursor: page.Prev,
 	}
 
 	gctx.JSON(http.StatusOK, res)
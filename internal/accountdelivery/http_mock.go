@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	domain "github.com/go-petr/pet-bank/internal/domain"
+	pagepkg "github.com/go-petr/pet-bank/pkg/pagepkg"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -66,16 +67,17 @@ func (mr *MockServiceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
 }
 
 // List mocks base method.
-func (m *MockService) List(ctx context.Context, owner string, pageSize, pageID int32) ([]domain.Account, error) {
+func (m *MockService) List(ctx context.Context, owner string, page pagepkg.Request) ([]domain.Account, pagepkg.Page, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, owner, pageSize, pageID)
+	ret := m.ctrl.Call(m, "List", ctx, owner, page)
 	ret0, _ := ret[0].([]domain.Account)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(pagepkg.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // List indicates an expected call of List.
-func (mr *MockServiceMockRecorder) List(ctx, owner, pageSize, pageID interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) List(ctx, owner, page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List), ctx, owner, pageSize, pageID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List), ctx, owner, page)
 }
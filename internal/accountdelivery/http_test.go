@@ -23,6 +23,7 @@ import (
 	"github.com/go-petr/pet-bank/internal/middleware"
 	"github.com/go-petr/pet-bank/pkg/currencypkg"
 	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
 	"github.com/go-petr/pet-bank/pkg/randompkg"
 	"github.com/go-petr/pet-bank/pkg/tokenpkg"
 	"github.com/go-petr/pet-bank/pkg/web"
@@ -203,7 +204,7 @@ func TestCreate(t *testing.T) {
 			accountHandler := NewHandler(accountService)
 
 			server := gin.New()
-			server.Use(middleware.AuthMiddleware(tokenMaker))
+			server.Use(middleware.AuthMiddleware(tokenMaker, nil))
 			server.POST("/accounts", accountHandler.Create)
 
 			tc.buildStubs(accountService)
@@ -393,7 +394,7 @@ func TestGet(t *testing.T) {
 			accountHandler := NewHandler(accountService)
 
 			server := gin.New()
-			server.Use(middleware.AuthMiddleware(tokenMaker))
+			server.Use(middleware.AuthMiddleware(tokenMaker, nil))
 			server.GET("/accounts/:id", accountHandler.Get)
 
 			tc.buildStubs(accountService)
@@ -458,14 +459,22 @@ func TestList(t *testing.T) {
 		accounts[i] = helpers.RandomAccount(username)
 	}
 
+	cursor := pagepkg.Cursor{AfterID: 20}
+	page := pagepkg.Page{
+		Next: pagepkg.Cursor{AfterID: 30}.Token(),
+		Prev: pagepkg.Cursor{BeforeID: 21}.Token(),
+	}
+
 	testCases := []struct {
 		name           string
 		pageID         int32
 		pageSize       int32
+		pageToken      string
 		setupAuth      func(t *testing.T, r *http.Request) error
 		buildStubs     func(accountService *MockService, pageID, pageSize int32)
 		wantStatusCode int
 		checkData      func(data any)
+		wantPage       pagepkg.Page
 		wantError      string
 	}{
 		{
@@ -477,9 +486,9 @@ func TestList(t *testing.T) {
 			},
 			buildStubs: func(accountService *MockService, pageID, pageSize int32) {
 				accountService.EXPECT().
-					List(context.Background(), username, pageID, pageSize).
+					List(context.Background(), username, pagepkg.Request{PageID: pageID, PageSize: pageSize}).
 					Times(1).
-					Return(accounts, nil)
+					Return(accounts, pagepkg.Page{}, nil)
 			},
 			wantStatusCode: http.StatusOK,
 			checkData: func(data any) {
@@ -495,6 +504,47 @@ func TestList(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:      "PageToken",
+			pageSize:  10,
+			pageToken: cursor.Token(),
+			setupAuth: func(t *testing.T, r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username, duration)
+			},
+			buildStubs: func(accountService *MockService, pageID, pageSize int32) {
+				accountService.EXPECT().
+					List(gomock.Any(), username, pagepkg.Request{PageSize: pageSize, Cursor: cursor}).
+					Times(1).
+					Return(accounts, page, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			checkData: func(data any) {
+				got := data.(*struct {
+					Accounts []domain.Account `json:"accounts"`
+				})
+
+				compareCreatedAt := cmpopts.EquateApproxTime(time.Second)
+				if diff := cmp.Diff(accounts, got.Accounts, compareCreatedAt); diff != "" {
+					t.Errorf("res.Data mismatch (-want +got):\n%s", diff)
+				}
+			},
+			wantPage: page,
+		},
+		{
+			name:      "InvalidPageToken",
+			pageSize:  10,
+			pageToken: "invalid",
+			setupAuth: func(t *testing.T, r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username, duration)
+			},
+			buildStubs: func(accountService *MockService, pageID, pageSize int32) {
+				accountService.EXPECT().
+					List(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      pagepkg.ErrInvalidPageToken.Error(),
+		},
 		{
 			name:     "NoAuthorization",
 			pageID:   1,
@@ -504,7 +554,7 @@ func TestList(t *testing.T) {
 			},
 			buildStubs: func(accountService *MockService, pageID, pageSize int32) {
 				accountService.EXPECT().
-					List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					List(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			wantStatusCode: http.StatusUnauthorized,
@@ -519,11 +569,11 @@ func TestList(t *testing.T) {
 			},
 			buildStubs: func(accountService *MockService, pageID, pageSize int32) {
 				accountService.EXPECT().
-					List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					List(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			wantStatusCode: http.StatusBadRequest,
-			wantError:      "PageID field is required",
+			wantError:      "PageID field is required without PageToken",
 		},
 		{
 			name:     "ExceededPageSize",
@@ -534,7 +584,7 @@ func TestList(t *testing.T) {
 			},
 			buildStubs: func(accountService *MockService, pageID, pageSize int32) {
 				accountService.EXPECT().
-					List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					List(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			wantStatusCode: http.StatusBadRequest,
@@ -549,9 +599,9 @@ func TestList(t *testing.T) {
 			},
 			buildStubs: func(accountService *MockService, pageID, pageSize int32) {
 				accountService.EXPECT().
-					List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					List(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
-					Return([]domain.Account{}, errorspkg.ErrInternal)
+					Return([]domain.Account{}, pagepkg.Page{}, errorspkg.ErrInternal)
 			},
 			wantStatusCode: http.StatusInternalServerError,
 			wantError:      errorspkg.ErrInternal.Error(),
@@ -571,13 +621,13 @@ func TestList(t *testing.T) {
 			accountHandler := NewHandler(accountService)
 
 			server := gin.New()
-			server.Use(middleware.AuthMiddleware(tokenMaker))
+			server.Use(middleware.AuthMiddleware(tokenMaker, nil))
 			server.GET("/accounts", accountHandler.List)
 
 			tc.buildStubs(accountService, tc.pageID, tc.pageSize)
 
 			// Send request
-			url := fmt.Sprintf("/accounts?page_id=%v&page_size=%v", tc.pageID, tc.pageSize)
+			url := fmt.Sprintf("/accounts?page_id=%v&page_size=%v&page_token=%v", tc.pageID, tc.pageSize, tc.pageToken)
 			req, err := http.NewRequest(http.MethodGet, url, nil)
 			if err != nil {
 				t.Fatalf("Creating request error: %v", err)
@@ -612,6 +662,10 @@ func TestList(t *testing.T) {
 			} else {
 				tc.checkData(res.Data)
 			}
+
+			if got := (pagepkg.Page{Next: res.NextCursor, Prev: res.PrevCursor}); got != tc.wantPage {
+				t.Errorf("res cursors = %+v, want %+v", got, tc.wantPage)
+			}
 		})
 	}
 }
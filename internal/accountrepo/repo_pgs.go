@@ -8,6 +8,7 @@ import (
 	"github.com/go-petr/pet-bank/internal/domain"
 	"github.com/go-petr/pet-bank/pkg/dbpkg"
 	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
 
 	"github.com/lib/pq"
 	"github.com/rs/zerolog"
@@ -25,11 +26,39 @@ func NewRepoPGS(db dbpkg.SQLInterface) *RepoPGS {
 	}
 }
 
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAccount(row scanner) (domain.Account, error) {
+	var (
+		a        domain.Account
+		frozenAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&a.ID,
+		&a.Owner,
+		&a.Balance,
+		&a.Currency,
+		&a.CreatedAt,
+		&frozenAt,
+		&a.IsSystem,
+		&a.AvailableBalance,
+	)
+
+	if frozenAt.Valid {
+		a.FrozenAt = &frozenAt.Time
+	}
+
+	return a, err
+}
+
 const addBalanceQuery = `
 UPDATE accounts
 SET balance = balance + $1
 WHERE id = $2
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, frozen_at, is_system, balance - held_amount
 `
 
 // AddBalance changes the account's balance and returns the changed account.
@@ -38,16 +67,43 @@ func (r *RepoPGS) AddBalance(ctx context.Context, amount string, id int32) (doma
 
 	row := r.db.QueryRowContext(ctx, addBalanceQuery, amount, id)
 
-	var a domain.Account
+	a, err := scanAccount(row)
+	if err != nil {
+		l.Error().Err(err).Send()
 
-	err := row.Scan(
-		&a.ID,
-		&a.Owner,
-		&a.Balance,
-		&a.Currency,
-		&a.CreatedAt,
-	)
+		if err == sql.ErrNoRows {
+			return a, domain.ErrAccountNotFound
+		}
+
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Constraint {
+			case "accounts_balance_check", "accounts_available_balance_check":
+				return a, domain.ErrInsufficientBalance
+			}
+		}
+
+		return a, errorspkg.ErrInternal
+	}
+
+	return a, nil
+}
+
+const addHeldQuery = `
+UPDATE accounts
+SET held_amount = held_amount + $1
+WHERE id = $2
+RETURNING id, owner, balance, currency, created_at, frozen_at, is_system, balance - held_amount
+`
 
+// AddHeld changes the amount held on the account and returns the changed
+// account. It returns domain.ErrInsufficientBalance if the held amount would
+// exceed the balance.
+func (r *RepoPGS) AddHeld(ctx context.Context, amount string, id int32) (domain.Account, error) {
+	l := zerolog.Ctx(ctx)
+
+	row := r.db.QueryRowContext(ctx, addHeldQuery, amount, id)
+
+	a, err := scanAccount(row)
 	if err != nil {
 		l.Error().Err(err).Send()
 
@@ -56,7 +112,7 @@ func (r *RepoPGS) AddBalance(ctx context.Context, amount string, id int32) (doma
 		}
 
 		if pqErr, ok := err.(*pq.Error); ok {
-			if pqErr.Constraint == "accounts_balance_check" {
+			if pqErr.Constraint == "accounts_available_balance_check" {
 				return a, domain.ErrInsufficientBalance
 			}
 		}
@@ -72,7 +128,7 @@ INSERT INTO
     accounts (owner, balance, currency)
 VALUES
     ($1, $2, $3)
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, frozen_at, is_system, balance - held_amount
 `
 
 // Create creates the account and then returns it.
@@ -81,16 +137,7 @@ func (r *RepoPGS) Create(ctx context.Context, owner, balance, currency string) (
 
 	row := r.db.QueryRowContext(ctx, createQuery, owner, balance, currency)
 
-	var a domain.Account
-
-	err := row.Scan(
-		&a.ID,
-		&a.Owner,
-		&a.Balance,
-		&a.Currency,
-		&a.CreatedAt,
-	)
-
+	a, err := scanAccount(row)
 	if err != nil {
 		l.Error().Err(err).Send()
 
@@ -122,7 +169,7 @@ func (r *RepoPGS) Delete(ctx context.Context, id int32) error {
 
 const getQuery = `
 SELECT 
-	id, owner, balance, currency, created_at 
+	id, owner, balance, currency, created_at, frozen_at, is_system, balance - held_amount
 FROM accounts
 WHERE id = $1
 `
@@ -133,16 +180,63 @@ func (r *RepoPGS) Get(ctx context.Context, id int32) (domain.Account, error) {
 
 	row := r.db.QueryRowContext(ctx, getQuery, id)
 
-	var a domain.Account
+	a, err := scanAccount(row)
+	if err != nil {
+		l.Error().Err(err).Send()
 
-	err := row.Scan(
-		&a.ID,
-		&a.Owner,
-		&a.Balance,
-		&a.Currency,
-		&a.CreatedAt,
-	)
+		if err == sql.ErrNoRows {
+			return a, domain.ErrAccountNotFound
+		}
+
+		return a, errorspkg.ErrInternal
+	}
+
+	return a, nil
+}
+
+const getByOwnerQuery = `
+SELECT
+	id, owner, balance, currency, created_at, frozen_at, is_system, balance - held_amount
+FROM accounts
+WHERE owner = $1 AND currency = $2
+`
+
+// GetByOwner returns the owner's account in the currency.
+func (r *RepoPGS) GetByOwner(ctx context.Context, owner, currency string) (domain.Account, error) {
+	l := zerolog.Ctx(ctx)
+
+	row := r.db.QueryRowContext(ctx, getByOwnerQuery, owner, currency)
 
+	a, err := scanAccount(row)
+	if err != nil {
+		l.Error().Err(err).Send()
+
+		if err == sql.ErrNoRows {
+			return a, domain.ErrAccountNotFound
+		}
+
+		return a, errorspkg.ErrInternal
+	}
+
+	return a, nil
+}
+
+const getForUpdateQuery = `
+SELECT 
+	id, owner, balance, currency, created_at, frozen_at, is_system, balance - held_amount
+FROM accounts
+WHERE id = $1
+FOR UPDATE
+`
+
+// GetForUpdate returns the account with the given id and locks its row until
+// the end of the current transaction.
+func (r *RepoPGS) GetForUpdate(ctx context.Context, id int32) (domain.Account, error) {
+	l := zerolog.Ctx(ctx)
+
+	row := r.db.QueryRowContext(ctx, getForUpdateQuery, id)
+
+	a, err := scanAccount(row)
 	if err != nil {
 		l.Error().Err(err).Send()
 
@@ -158,18 +252,28 @@ func (r *RepoPGS) Get(ctx context.Context, id int32) (domain.Account, error) {
 
 const listAccounts = `
 SELECT 
-	id, owner, balance, currency, created_at 
+	id, owner, balance, currency, created_at, frozen_at, is_system, balance - held_amount
 FROM accounts
 WHERE owner = $1
-ORDER BY id
-LIMIT $2 OFFSET $3
+    AND ($2 = 0 OR id > $2)
+    AND ($3 = 0 OR id < $3)
+ORDER BY CASE WHEN $3 = 0 THEN id END, id DESC
+LIMIT $4 OFFSET $5
 `
 
-// List returns the specified number of accounts for the given user.
-func (r *RepoPGS) List(ctx context.Context, owner string, limit, offset int32) ([]domain.Account, error) {
+// List returns the specified number of accounts for the given user ordered by id.
+//
+// If arg.BeforeID is set, the accounts right before it are returned.
+func (r *RepoPGS) List(ctx context.Context, arg domain.ListAccountsParams) ([]domain.Account, error) {
 	l := zerolog.Ctx(ctx)
 
-	rows, err := r.db.QueryContext(ctx, listAccounts, owner, limit, offset)
+	rows, err := r.db.QueryContext(ctx, listAccounts,
+		arg.Owner,
+		arg.AfterID,
+		arg.BeforeID,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		l.Error().Err(err).Send()
 		return nil, errorspkg.ErrInternal
@@ -179,8 +283,8 @@ func (r *RepoPGS) List(ctx context.Context, owner string, limit, offset int32) (
 	items := []domain.Account{}
 
 	for rows.Next() {
-		var a domain.Account
-		if err := rows.Scan(&a.ID, &a.Owner, &a.Balance, &a.Currency, &a.CreatedAt); err != nil {
+		a, err := scanAccount(rows)
+		if err != nil {
 			l.Error().Err(err).Send()
 			return nil, errorspkg.ErrInternal
 		}
@@ -198,5 +302,100 @@ func (r *RepoPGS) List(ctx context.Context, owner string, limit, offset int32) (
 		return nil, errorspkg.ErrInternal
 	}
 
+	if arg.BeforeID != 0 {
+		pagepkg.Reverse(items)
+	}
+
 	return items, nil
 }
+
+const freezeQuery = `
+UPDATE accounts
+SET frozen_at = COALESCE(frozen_at, now())
+WHERE id = $1
+RETURNING id, owner, balance, currency, created_at, frozen_at, is_system, balance - held_amount
+`
+
+// Freeze freezes the account with the given id and returns it. Freezing a
+// frozen account keeps its original freeze time.
+func (r *RepoPGS) Freeze(ctx context.Context, id int32) (domain.Account, error) {
+	return r.setFrozen(ctx, freezeQuery, id)
+}
+
+const unfreezeQuery = `
+UPDATE accounts
+SET frozen_at = NULL
+WHERE id = $1
+RETURNING id, owner, balance, currency, created_at, frozen_at, is_system, balance - held_amount
+`
+
+// Unfreeze unfreezes the account with the given id and returns it.
+func (r *RepoPGS) Unfreeze(ctx context.Context, id int32) (domain.Account, error) {
+	return r.setFrozen(ctx, unfreezeQuery, id)
+}
+
+func (r *RepoPGS) setFrozen(ctx context.Context, query string, id int32) (domain.Account, error) {
+	l := zerolog.Ctx(ctx)
+
+	a, err := scanAccount(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		l.Error().Err(err).Send()
+
+		if err == sql.ErrNoRows {
+			return a, domain.ErrAccountNotFound
+		}
+
+		return a, errorspkg.ErrInternal
+	}
+
+	return a, nil
+}
+
+const createSettlementOwnerQuery = `
+INSERT INTO
+    users (username, hashed_password, full_name, email)
+VALUES
+    ($1, '', 'Settlement', $1 || '@settlement.invalid')
+ON CONFLICT DO NOTHING
+`
+
+const createSettlementQuery = `
+INSERT INTO
+    accounts (owner, balance, currency, is_system)
+VALUES
+    ($1, 0, $2, true)
+ON CONFLICT (owner, currency) DO NOTHING
+`
+
+const getSettlementQuery = `
+SELECT
+	id, owner, balance, currency, created_at, frozen_at, is_system, balance - held_amount
+FROM accounts
+WHERE owner = $1 AND currency = $2
+`
+
+// GetSettlement returns the settlement account of the currency. The account
+// and its owner are created on first use.
+//
+// The settlement owner has no password, so it can't log in.
+func (r *RepoPGS) GetSettlement(ctx context.Context, currency string) (domain.Account, error) {
+	l := zerolog.Ctx(ctx)
+
+	if _, err := r.db.ExecContext(ctx, createSettlementOwnerQuery, domain.SettlementOwner); err != nil {
+		l.Error().Err(err).Send()
+		return domain.Account{}, errorspkg.ErrInternal
+	}
+
+	if _, err := r.db.ExecContext(ctx, createSettlementQuery, domain.SettlementOwner, currency); err != nil {
+		l.Error().Err(err).Send()
+		return domain.Account{}, errorspkg.ErrInternal
+	}
+
+	a, err := scanAccount(r.db.QueryRowContext(ctx, getSettlementQuery, domain.SettlementOwner, currency))
+	if err != nil {
+		l.Error().Err(err).Send()
+		return a, errorspkg.ErrInternal
+	}
+
+	return a, nil
+}
@@ -49,11 +49,13 @@ func TestCreate(t *testing.T) {
 			name: "OK",
 			wantAccount: func(tx *sql.Tx) domain.Account {
 				user := helpers.SeedUser(t, tx)
+				balance := randompkg.MoneyAmountBetween(100, 1000)
 				return domain.Account{
-					Owner:     user.Username,
-					Balance:   randompkg.MoneyAmountBetween(100, 1000),
-					Currency:  randompkg.Currency(),
-					CreatedAt: time.Now().UTC().Truncate(time.Second),
+					Owner:            user.Username,
+					Balance:          balance,
+					AvailableBalance: balance,
+					Currency:         randompkg.Currency(),
+					CreatedAt:        time.Now().UTC().Truncate(time.Second),
 				}
 			},
 		},
@@ -187,6 +189,59 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestGetForUpdate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		wantAccount func(tx *sql.Tx) domain.Account
+		wantErr     error
+	}{
+		{
+			name: "OK",
+			wantAccount: func(tx *sql.Tx) domain.Account {
+				user := helpers.SeedUser(t, tx)
+				account := helpers.SeedAccountWith1000USDBalance(t, tx, user.Username)
+				return account
+			},
+		},
+		{
+			name: "ErrAccountNotFound",
+			wantAccount: func(tx *sql.Tx) domain.Account {
+				return domain.Account{ID: 0}
+			},
+			wantErr: domain.ErrAccountNotFound,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Prepare test transaction and seed database
+			tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+			want := tc.wantAccount(tx)
+			accountRepo := accountrepo.NewRepoPGS(tx)
+
+			// Run test
+			got, err := accountRepo.GetForUpdate(context.Background(), want.ID)
+			if err != nil {
+				if err == tc.wantErr {
+					return
+				}
+				t.Fatalf(`accountRepo.GetForUpdate(context.Background(), %v) returned error: %v`,
+					want.ID, err.Error())
+			}
+
+			compareCreatedAt := cmpopts.EquateApproxTime(time.Second)
+			if diff := cmp.Diff(want, got, compareCreatedAt); diff != "" {
+				t.Errorf(`accountRepo.GetForUpdate(context.Background(), %v) returned unexpected difference (-want +got):\n%s"`,
+					want.ID, diff)
+			}
+		})
+	}
+}
+
 func TestDelete(t *testing.T) {
 	testCases := []struct {
 		name        string
@@ -297,25 +352,67 @@ func TestList(t *testing.T) {
 			wantOwner := want[0].Owner
 			accountRepo := accountrepo.NewRepoPGS(tx)
 
+			arg := domain.ListAccountsParams{
+				Owner:  wantOwner,
+				Limit:  tc.limit,
+				Offset: tc.offset,
+			}
+
 			// Run test
-			got, err := accountRepo.List(context.Background(), wantOwner, tc.limit, tc.offset)
+			got, err := accountRepo.List(context.Background(), arg)
 			if err != nil {
 				if err == tc.wantErr {
 					return
 				}
-				t.Fatalf(`accountRepo.List(context.Background(), %v, %v, %v) returned unexpected error: %v`,
-					wantOwner, tc.limit, tc.offset, err)
+				t.Fatalf(`accountRepo.List(context.Background(), %+v) returned unexpected error: %v`, arg, err)
 			}
 
 			compareCreatedAt := cmpopts.EquateApproxTime(time.Second)
 			if diff := cmp.Diff(want, got, compareCreatedAt); diff != "" {
-				t.Errorf(`accountRepo.List(context.Background(), %v, %v, %v) returned unexpected difference (-want +got):\n%s"`,
-					wantOwner, tc.limit, tc.offset, diff)
+				t.Errorf(`accountRepo.List(context.Background(), %+v) returned unexpected difference (-want +got):\n%s"`,
+					arg, diff)
 			}
 		})
 	}
 }
 
+func TestListCursor(t *testing.T) {
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	user := helpers.SeedUser(t, tx)
+	accounts := helpers.SeedAllCurrenciesAccountsWith1000Balance(t, tx, user.Username)
+	accountRepo := accountrepo.NewRepoPGS(tx)
+
+	testCases := []struct {
+		name string
+		arg  domain.ListAccountsParams
+		want []domain.Account
+	}{
+		{
+			name: "AfterID",
+			arg:  domain.ListAccountsParams{Owner: user.Username, AfterID: int64(accounts[0].ID), Limit: 10},
+			want: accounts[1:],
+		},
+		{
+			name: "BeforeID",
+			arg:  domain.ListAccountsParams{Owner: user.Username, BeforeID: int64(accounts[2].ID), Limit: 1},
+			want: accounts[1:2],
+		},
+	}
+
+	for _, tc := range testCases {
+		got, err := accountRepo.List(context.Background(), tc.arg)
+		if err != nil {
+			t.Fatalf(`%v: accountRepo.List(context.Background(), %+v) returned unexpected error: %v`, tc.name, tc.arg, err)
+		}
+
+		compareCreatedAt := cmpopts.EquateApproxTime(time.Second)
+		if diff := cmp.Diff(tc.want, got, compareCreatedAt); diff != "" {
+			t.Errorf(`%v: accountRepo.List(context.Background(), %+v) returned unexpected difference (-want +got):\n%s"`,
+				tc.name, tc.arg, diff)
+		}
+	}
+}
+
 func TestAddBalance(t *testing.T) {
 	testCases := []struct {
 		name        string
@@ -330,6 +427,7 @@ func TestAddBalance(t *testing.T) {
 				user := helpers.SeedUser(t, tx)
 				account := helpers.SeedAccountWith1000Balance(t, tx, user.Username, randompkg.Currency())
 				account.Balance = "1100"
+				account.AvailableBalance = "1100"
 				return account
 			},
 		},
@@ -395,3 +493,156 @@ func TestAddBalance(t *testing.T) {
 		})
 	}
 }
+
+func TestFreeze(t *testing.T) {
+	t.Parallel()
+
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	user := helpers.SeedUser(t, tx)
+	account := helpers.SeedAccountWith1000USDBalance(t, tx, user.Username)
+	accountRepo := accountrepo.NewRepoPGS(tx)
+	ctx := context.Background()
+
+	frozen, err := accountRepo.Freeze(ctx, account.ID)
+	if err != nil {
+		t.Fatalf("accountRepo.Freeze(ctx, %v) returned error: %v", account.ID, err)
+	}
+
+	if !frozen.IsFrozen() {
+		t.Fatalf("accountRepo.Freeze(ctx, %v) returned unfrozen account", account.ID)
+	}
+
+	// Freezing again keeps the original freeze time.
+	again, err := accountRepo.Freeze(ctx, account.ID)
+	if err != nil {
+		t.Fatalf("accountRepo.Freeze(ctx, %v) returned error: %v", account.ID, err)
+	}
+
+	if diff := cmp.Diff(frozen, again); diff != "" {
+		t.Errorf("accountRepo.Freeze(ctx, %v) returned unexpected difference (-want +got):\n%s", account.ID, diff)
+	}
+
+	got, err := accountRepo.Get(ctx, account.ID)
+	if err != nil {
+		t.Fatalf("accountRepo.Get(ctx, %v) returned error: %v", account.ID, err)
+	}
+
+	if diff := cmp.Diff(frozen, got); diff != "" {
+		t.Errorf("accountRepo.Get(ctx, %v) returned unexpected difference (-want +got):\n%s", account.ID, diff)
+	}
+
+	unfrozen, err := accountRepo.Unfreeze(ctx, account.ID)
+	if err != nil {
+		t.Fatalf("accountRepo.Unfreeze(ctx, %v) returned error: %v", account.ID, err)
+	}
+
+	compareCreatedAt := cmpopts.EquateApproxTime(time.Second)
+	if diff := cmp.Diff(account, unfrozen, compareCreatedAt); diff != "" {
+		t.Errorf("accountRepo.Unfreeze(ctx, %v) returned unexpected difference (-want +got):\n%s", account.ID, diff)
+	}
+
+	if _, err := accountRepo.Freeze(ctx, 0); err != domain.ErrAccountNotFound {
+		t.Errorf("accountRepo.Freeze(ctx, 0) returned error: %v, want %v", err, domain.ErrAccountNotFound)
+	}
+}
+
+func TestGetSettlement(t *testing.T) {
+	t.Parallel()
+
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	accountRepo := accountrepo.NewRepoPGS(tx)
+	ctx := context.Background()
+	currency := randompkg.Currency()
+
+	account, err := accountRepo.GetSettlement(ctx, currency)
+	if err != nil {
+		t.Fatalf("accountRepo.GetSettlement(ctx, %q) returned error: %v", currency, err)
+	}
+
+	if !account.IsSystem || account.Owner != domain.SettlementOwner || account.Currency != currency {
+		t.Fatalf("accountRepo.GetSettlement(ctx, %q) returned %+v, want %s system account", currency, account, currency)
+	}
+
+	// The settlement account is created once.
+	again, err := accountRepo.GetSettlement(ctx, currency)
+	if err != nil {
+		t.Fatalf("accountRepo.GetSettlement(ctx, %q) returned error: %v", currency, err)
+	}
+
+	if diff := cmp.Diff(account, again); diff != "" {
+		t.Errorf("accountRepo.GetSettlement(ctx, %q) returned unexpected difference (-want +got):\n%s", currency, diff)
+	}
+
+	// The settlement account may go negative.
+	got, err := accountRepo.AddBalance(ctx, "-100", account.ID)
+	if err != nil {
+		t.Fatalf("accountRepo.AddBalance(ctx, -100, %v) returned error: %v", account.ID, err)
+	}
+
+	if got.Balance != "-100" {
+		t.Errorf("got.Balance = %v, want -100", got.Balance)
+	}
+}
+
+func TestGetByOwner(t *testing.T) {
+	t.Parallel()
+
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	accountRepo := accountrepo.NewRepoPGS(tx)
+	ctx := context.Background()
+
+	user := helpers.SeedUser(t, tx)
+	want := helpers.SeedAccountWith1000USDBalance(t, tx, user.Username)
+
+	got, err := accountRepo.GetByOwner(ctx, user.Username, want.Currency)
+	if err != nil {
+		t.Fatalf("accountRepo.GetByOwner(ctx, %q, %q) returned error: %v", user.Username, want.Currency, err)
+	}
+
+	compareCreatedAt := cmpopts.EquateApproxTime(time.Second)
+	if diff := cmp.Diff(want, got, compareCreatedAt); diff != "" {
+		t.Errorf("accountRepo.GetByOwner(ctx, %q, %q) returned unexpected difference (-want +got):\n%s",
+			user.Username, want.Currency, diff)
+	}
+
+	if _, err := accountRepo.GetByOwner(ctx, user.Username, "EUR"); err != domain.ErrAccountNotFound {
+		t.Errorf("accountRepo.GetByOwner(ctx, %q, EUR) returned error: %v, want %v", user.Username, err, domain.ErrAccountNotFound)
+	}
+}
+
+func TestAddHeld(t *testing.T) {
+	t.Parallel()
+
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	user := helpers.SeedUser(t, tx)
+	account := helpers.SeedAccountWith1000USDBalance(t, tx, user.Username)
+	accountRepo := accountrepo.NewRepoPGS(tx)
+	ctx := context.Background()
+
+	got, err := accountRepo.AddHeld(ctx, "400", account.ID)
+	if err != nil {
+		t.Fatalf("accountRepo.AddHeld(ctx, 400, %v) returned error: %v", account.ID, err)
+	}
+
+	if got.Balance != "1000" || got.AvailableBalance != "600" {
+		t.Errorf("accountRepo.AddHeld(ctx, 400, %v) returned balance %v, available balance %v, want 1000, 600",
+			account.ID, got.Balance, got.AvailableBalance)
+	}
+
+	// Neither holds nor entries may take the available balance below zero.
+	if _, err := tx.Exec("SAVEPOINT held"); err != nil {
+		t.Fatalf("savepoint error: %v", err)
+	}
+
+	if _, err := accountRepo.AddHeld(ctx, "601", account.ID); err != domain.ErrInsufficientBalance {
+		t.Errorf("accountRepo.AddHeld(ctx, 601, %v) returned error: %v, want %v", account.ID, err, domain.ErrInsufficientBalance)
+	}
+
+	if _, err := tx.Exec("ROLLBACK TO SAVEPOINT held"); err != nil {
+		t.Fatalf("rollback to savepoint error: %v", err)
+	}
+
+	if _, err := accountRepo.AddBalance(ctx, "-601", account.ID); err != domain.ErrInsufficientBalance {
+		t.Errorf("accountRepo.AddBalance(ctx, -601, %v) returned error: %v, want %v", account.ID, err, domain.ErrInsufficientBalance)
+	}
+}
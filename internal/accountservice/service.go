@@ -5,23 +5,31 @@ import (
 	"context"
 
 	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
 )
 
 // Repo provides data access layer interface needed by account service layer.
 type Repo interface {
 	Create(ctx context.Context, owner, balance, currency string) (domain.Account, error)
 	Get(ctx context.Context, id int32) (domain.Account, error)
-	List(ctx context.Context, owner string, limit, offset int32) ([]domain.Account, error)
+	List(ctx context.Context, arg domain.ListAccountsParams) ([]domain.Account, error)
+}
+
+// Auditor records audit events of the account creation.
+type Auditor interface {
+	Record(ctx context.Context, eventType, actor string, before, after any)
 }
 
 // Service facilitates account service layer logic.
 type Service struct {
-	repo Repo
+	repo    Repo
+	auditor Auditor
 }
 
-// New returns account service struct to manage account bussines logic.
-func New(ar Repo) *Service {
-	return &Service{repo: ar}
+// New returns account service struct to manage account bussines logic. Audit
+// events are not recorded if a is nil.
+func New(ar Repo, a Auditor) *Service {
+	return &Service{repo: ar, auditor: a}
 }
 
 // Create creates and returns account for the given owner and currency.
@@ -31,6 +39,10 @@ func (s *Service) Create(ctx context.Context, owner, currency string) (domain.Ac
 		return account, err
 	}
 
+	if s.auditor != nil {
+		s.auditor.Record(ctx, domain.AuditAccountCreated, owner, nil, account)
+	}
+
 	return account, nil
 }
 
@@ -44,15 +56,22 @@ func (s *Service) Get(ctx context.Context, id int32) (domain.Account, error) {
 	return account, nil
 }
 
-// List returns accounts that are owned by the given user.
-func (s *Service) List(ctx context.Context, owner string, pageID, pageSize int32) ([]domain.Account, error) {
-	limit := pageSize
-	offset := (pageID - 1) * pageSize
+// List returns the page of accounts that are owned by the given user.
+func (s *Service) List(ctx context.Context, owner string, page pagepkg.Request) ([]domain.Account, pagepkg.Page, error) {
+	arg := domain.ListAccountsParams{
+		Owner:    owner,
+		AfterID:  page.Cursor.AfterID,
+		BeforeID: page.Cursor.BeforeID,
+		Limit:    page.Limit(),
+		Offset:   page.Offset(),
+	}
 
-	accounts, err := s.repo.List(ctx, owner, limit, offset)
+	accounts, err := s.repo.List(ctx, arg)
 	if err != nil {
-		return nil, err
+		return nil, pagepkg.Page{}, err
 	}
 
-	return accounts, err
+	accounts, p := pagepkg.Trim(accounts, page, func(a domain.Account) int64 { return int64(a.ID) })
+
+	return accounts, p, nil
 }
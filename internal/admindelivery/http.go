@@ -0,0 +1,688 @@
+// Package admindelivery manages delivery layer of the admin API.
+package admindelivery
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/middleware"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
+	"github.com/go-petr/pet-bank/pkg/tokenpkg"
+	"github.com/go-petr/pet-bank/pkg/web"
+)
+
+// Service provides service layer interface needed by admin delivery layer.
+//
+//go:generate mockgen -source http.go -destination http_mock.go -package admindelivery
+type Service interface {
+	SearchUsers(ctx context.Context, actor string, arg domain.SearchUsersParams) ([]domain.UserWihtoutPassword, error)
+	ListAccounts(ctx context.Context, actor, owner string, page pagepkg.Request) ([]domain.Account, pagepkg.Page, error)
+	GetAccount(ctx context.Context, actor string, id int32) (domain.Account, error)
+	ListEntries(ctx context.Context, actor string, arg domain.ListEntriesParams, page pagepkg.Request) ([]domain.StatementLine, pagepkg.Page, error)
+	FreezeAccount(ctx context.Context, actor string, id int32) (domain.Account, error)
+	UnfreezeAccount(ctx context.Context, actor string, id int32) (domain.Account, error)
+	BlockSessions(ctx context.Context, actor, username string) (int64, error)
+	VerifyLedger(ctx context.Context, actor string, accountID int32) (domain.ChainReport, error)
+	ListTransferLimits(ctx context.Context, actor, username string) ([]domain.TransferLimit, error)
+	SetTransferLimit(ctx context.Context, actor string, arg domain.TransferLimit) (domain.TransferLimit, error)
+	ResetTransferLimit(ctx context.Context, actor, username, currency string) error
+	ListTransferReviews(ctx context.Context, actor, status string, page pagepkg.Request) ([]domain.TransferReview, pagepkg.Page, error)
+	ApproveTransferReview(ctx context.Context, actor string, id int64) (domain.ApproveTransferReviewResult, error)
+	RejectTransferReview(ctx context.Context, actor string, id int64) (domain.TransferReview, error)
+	ListComplianceCases(ctx context.Context, actor, status string, page pagepkg.Request) ([]domain.ComplianceCase, pagepkg.Page, error)
+	ClearComplianceCase(ctx context.Context, actor string, id int64) (domain.ComplianceCase, error)
+	ConfirmComplianceCase(ctx context.Context, actor string, id int64) (domain.ComplianceCase, error)
+	ReloadSanctionsList(ctx context.Context, actor string) (domain.SanctionsList, error)
+}
+
+// Handler facilitates admin delivery layer logic.
+//
+// The actor of every action is the authenticated staff member.
+type Handler struct {
+	service Service
+}
+
+// NewHandler returns admin handler.
+func NewHandler(s Service) *Handler {
+	return &Handler{
+		service: s,
+	}
+}
+
+type searchUsersRequest struct {
+	Query    string `form:"q"`
+	PageID   int32  `form:"page_id" binding:"required,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=1,max=100"`
+}
+
+// SearchUsers handles http request to search users by username, full name or email.
+func (h *Handler) SearchUsers(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	var req searchUsersRequest
+	if err := gctx.ShouldBindQuery(&req); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	arg := domain.SearchUsersParams{
+		Query:  req.Query,
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+
+	users, err := h.service.SearchUsers(ctx, actor(gctx), arg)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	res := web.Response{
+		Data: &struct {
+			Users []domain.UserWihtoutPassword `json:"users"`
+		}{
+			Users: users,
+		},
+	}
+
+	gctx.JSON(http.StatusOK, res)
+}
+
+type usernameURI struct {
+	Username string `uri:"username" binding:"required"`
+}
+
+type pageRequest struct {
+	PageID    int32  `form:"page_id" binding:"required_without=PageToken,omitempty,min=1"`
+	PageSize  int32  `form:"page_size" binding:"required,min=1,max=100"`
+	PageToken string `form:"page_token"`
+}
+
+// ListAccounts handles http request to list accounts of any user.
+//
+// The page is located by page_token if it is set, otherwise by page_id.
+func (h *Handler) ListAccounts(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	var uri usernameURI
+	if err := gctx.ShouldBindUri(&uri); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	var req pageRequest
+	if err := gctx.ShouldBindQuery(&req); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	pageReq, ok := h.pageRequest(gctx, req.PageID, req.PageSize, req.PageToken)
+	if !ok {
+		return
+	}
+
+	accounts, page, err := h.service.ListAccounts(ctx, actor(gctx), uri.Username, pageReq)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	res := web.Response{
+		Data: &struct {
+			Accounts []domain.Account `json:"accounts"`
+		}{
+			Accounts: accounts,
+		},
+		NextCursor: page.Next,
+		PrevCursor: page.Prev,
+	}
+
+	gctx.JSON(http.StatusOK, res)
+}
+
+type accountURI struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+// GetAccount handles http request to get any account.
+func (h *Handler) GetAccount(gctx *gin.Context) {
+	h.accountAction(gctx, h.service.GetAccount)
+}
+
+// FreezeAccount handles http request to freeze the account.
+func (h *Handler) FreezeAccount(gctx *gin.Context) {
+	h.accountAction(gctx, h.service.FreezeAccount)
+}
+
+// UnfreezeAccount handles http request to unfreeze the account.
+func (h *Handler) UnfreezeAccount(gctx *gin.Context) {
+	h.accountAction(gctx, h.service.UnfreezeAccount)
+}
+
+func (h *Handler) accountAction(gctx *gin.Context, action func(ctx context.Context, actor string, id int32) (domain.Account, error)) {
+	ctx := gctx.Request.Context()
+
+	var uri accountURI
+	if err := gctx.ShouldBindUri(&uri); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	account, err := action(ctx, actor(gctx), uri.ID)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	res := web.Response{
+		Data: &struct {
+			Account domain.Account `json:"account"`
+		}{
+			Account: account,
+		},
+	}
+
+	gctx.JSON(http.StatusOK, res)
+}
+
+type listEntriesRequest struct {
+	pageRequest
+	StartDate time.Time `form:"start_date" time_format:"2006-01-02" time_utc:"1"`
+	EndDate   time.Time `form:"end_date" time_format:"2006-01-02" time_utc:"1"`
+}
+
+// ListEntries handles http request to get the statement of any account.
+//
+// The end date is inclusive. The page is located by page_token if it is set,
+// otherwise by page_id.
+func (h *Handler) ListEntries(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	var uri accountURI
+	if err := gctx.ShouldBindUri(&uri); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	var req listEntriesRequest
+	if err := gctx.ShouldBindQuery(&req); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	pageReq, ok := h.pageRequest(gctx, req.PageID, req.PageSize, req.PageToken)
+	if !ok {
+		return
+	}
+
+	arg := domain.ListEntriesParams{
+		AccountID: uri.ID,
+		StartTime: req.StartDate,
+	}
+
+	if !req.EndDate.IsZero() {
+		arg.EndTime = req.EndDate.AddDate(0, 0, 1)
+	}
+
+	entries, page, err := h.service.ListEntries(ctx, actor(gctx), arg, pageReq)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	res := web.Response{
+		Data: &struct {
+			Entries []domain.StatementLine `json:"entries"`
+		}{
+			Entries: entries,
+		},
+		NextCursor: page.Next,
+		PrevCursor: page.Prev,
+	}
+
+	gctx.JSON(http.StatusOK, res)
+}
+
+// BlockSessions handles http request to block all sessions of the user.
+func (h *Handler) BlockSessions(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	var uri usernameURI
+	if err := gctx.ShouldBindUri(&uri); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	n, err := h.service.BlockSessions(ctx, actor(gctx), uri.Username)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	res := web.Response{
+		Data: &struct {
+			BlockedSessions int64 `json:"blocked_sessions"`
+		}{
+			BlockedSessions: n,
+		},
+	}
+
+	gctx.JSON(http.StatusOK, res)
+}
+
+type verifyLedgerRequest struct {
+	AccountID int32 `form:"account_id" binding:"omitempty,min=1"`
+}
+
+// VerifyLedger handles http request to verify the entries hash chain of the
+// account, or of all accounts if account_id is not set.
+func (h *Handler) VerifyLedger(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	var req verifyLedgerRequest
+	if err := gctx.ShouldBindQuery(&req); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	report, err := h.service.VerifyLedger(ctx, actor(gctx), req.AccountID)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	res := web.Response{
+		Data: &struct {
+			Valid  bool               `json:"valid"`
+			Report domain.ChainReport `json:"report"`
+		}{
+			Valid:  report.Valid(),
+			Report: report,
+		},
+	}
+
+	gctx.JSON(http.StatusOK, res)
+}
+
+// ListTransferLimits handles http request to list the outgoing transfer limits
+// of the user.
+func (h *Handler) ListTransferLimits(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	var uri usernameURI
+	if err := gctx.ShouldBindUri(&uri); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	limits, err := h.service.ListTransferLimits(ctx, actor(gctx), uri.Username)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	res := web.Response{
+		Data: &struct {
+			Limits []domain.TransferLimit `json:"limits"`
+		}{
+			Limits: limits,
+		},
+	}
+
+	gctx.JSON(http.StatusOK, res)
+}
+
+type transferLimitURI struct {
+	Username string `uri:"username" binding:"required"`
+	Currency string `uri:"currency" binding:"required,currency"`
+}
+
+// setTransferLimitRequest holds the limits overriding the defaults, an omitted
+// limit falls back to the default limit.
+type setTransferLimitRequest struct {
+	PerTransaction string `json:"per_transaction"`
+	Daily          string `json:"daily"`
+	Monthly        string `json:"monthly"`
+}
+
+// SetTransferLimit handles http request to override the outgoing transfer
+// limits of the user in the currency.
+func (h *Handler) SetTransferLimit(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	var uri transferLimitURI
+	if err := gctx.ShouldBindUri(&uri); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	var req setTransferLimitRequest
+	if err := gctx.ShouldBindJSON(&req); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	arg := domain.TransferLimit{
+		Username:       uri.Username,
+		Currency:       uri.Currency,
+		PerTransaction: req.PerTransaction,
+		Daily:          req.Daily,
+		Monthly:        req.Monthly,
+	}
+
+	limit, err := h.service.SetTransferLimit(ctx, actor(gctx), arg)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	res := web.Response{
+		Data: &struct {
+			Limit domain.TransferLimit `json:"limit"`
+		}{
+			Limit: limit,
+		},
+	}
+
+	gctx.JSON(http.StatusOK, res)
+}
+
+// ResetTransferLimit handles http request to remove the user's transfer limit
+// override in the currency.
+func (h *Handler) ResetTransferLimit(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	var uri transferLimitURI
+	if err := gctx.ShouldBindUri(&uri); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	if err := h.service.ResetTransferLimit(ctx, actor(gctx), uri.Username, uri.Currency); err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	gctx.Status(http.StatusNoContent)
+}
+
+type listTransferReviewsRequest struct {
+	pageRequest
+	Status string `form:"status" binding:"omitempty,oneof=pending_review approved rejected"`
+}
+
+// ListTransferReviews handles http request to list the transfers held for
+// review by the transaction monitoring, optionally with the given status.
+//
+// The page is located by page_token if it is set, otherwise by page_id.
+func (h *Handler) ListTransferReviews(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	var req listTransferReviewsRequest
+	if err := gctx.ShouldBindQuery(&req); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	pageReq, ok := h.pageRequest(gctx, req.PageID, req.PageSize, req.PageToken)
+	if !ok {
+		return
+	}
+
+	reviews, page, err := h.service.ListTransferReviews(ctx, actor(gctx), req.Status, pageReq)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	res := web.Response{
+		Data: &struct {
+			Reviews []domain.TransferReview `json:"reviews"`
+		}{
+			Reviews: reviews,
+		},
+		NextCursor: page.Next,
+		PrevCursor: page.Prev,
+	}
+
+	gctx.JSON(http.StatusOK, res)
+}
+
+type transferReviewURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// ApproveTransferReview handles http request to execute the transfer held for
+// review.
+func (h *Handler) ApproveTransferReview(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	var uri transferReviewURI
+	if err := gctx.ShouldBindUri(&uri); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	result, err := h.service.ApproveTransferReview(ctx, actor(gctx), uri.ID)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	res := web.Response{
+		Data: &struct {
+			Review   domain.TransferReview   `json:"review"`
+			Transfer domain.TransferTxResult `json:"transfer"`
+		}{
+			Review:   result.Review,
+			Transfer: result.Transfer,
+		},
+	}
+
+	gctx.JSON(http.StatusOK, res)
+}
+
+// RejectTransferReview handles http request to release the amount of the
+// transfer held for review without executing it.
+func (h *Handler) RejectTransferReview(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	var uri transferReviewURI
+	if err := gctx.ShouldBindUri(&uri); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	review, err := h.service.RejectTransferReview(ctx, actor(gctx), uri.ID)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	res := web.Response{
+		Data: &struct {
+			Review domain.TransferReview `json:"review"`
+		}{
+			Review: review,
+		},
+	}
+
+	gctx.JSON(http.StatusOK, res)
+}
+
+type listComplianceCasesRequest struct {
+	pageRequest
+	Status string `form:"status" binding:"omitempty,oneof=open cleared confirmed"`
+}
+
+// ListComplianceCases handles http request to list the actions blocked by the
+// sanctions screening, optionally with the given status.
+func (h *Handler) ListComplianceCases(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	var req listComplianceCasesRequest
+	if err := gctx.ShouldBindQuery(&req); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	pageReq, ok := h.pageRequest(gctx, req.PageID, req.PageSize, req.PageToken)
+	if !ok {
+		return
+	}
+
+	cases, page, err := h.service.ListComplianceCases(ctx, actor(gctx), req.Status, pageReq)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	res := web.Response{
+		Data: &struct {
+			Cases []domain.ComplianceCase `json:"cases"`
+		}{
+			Cases: cases,
+		},
+		NextCursor: page.Next,
+		PrevCursor: page.Prev,
+	}
+
+	gctx.JSON(http.StatusOK, res)
+}
+
+type complianceCaseURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// ClearComplianceCase handles http request to close the compliance case as a
+// false positive.
+func (h *Handler) ClearComplianceCase(gctx *gin.Context) {
+	h.closeComplianceCase(gctx, h.service.ClearComplianceCase)
+}
+
+// ConfirmComplianceCase handles http request to close the compliance case as
+// a true match.
+func (h *Handler) ConfirmComplianceCase(gctx *gin.Context) {
+	h.closeComplianceCase(gctx, h.service.ConfirmComplianceCase)
+}
+
+func (h *Handler) closeComplianceCase(gctx *gin.Context, closeCase func(ctx context.Context, actor string, id int64) (domain.ComplianceCase, error)) {
+	ctx := gctx.Request.Context()
+
+	var uri complianceCaseURI
+	if err := gctx.ShouldBindUri(&uri); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	c, err := closeCase(ctx, actor(gctx), uri.ID)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	res := web.Response{
+		Data: &struct {
+			Case domain.ComplianceCase `json:"case"`
+		}{
+			Case: c,
+		},
+	}
+
+	gctx.JSON(http.StatusOK, res)
+}
+
+// ReloadSanctionsList handles http request to reread the sanctions list file.
+func (h *Handler) ReloadSanctionsList(gctx *gin.Context) {
+	list, err := h.service.ReloadSanctionsList(gctx.Request.Context(), actor(gctx))
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	res := web.Response{
+		Data: &struct {
+			SanctionsList domain.SanctionsList `json:"sanctions_list"`
+		}{
+			SanctionsList: list,
+		},
+	}
+
+	gctx.JSON(http.StatusOK, res)
+}
+
+// actor returns the username of the authenticated staff member.
+func actor(gctx *gin.Context) string {
+	return gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload).Username
+}
+
+func (h *Handler) pageRequest(gctx *gin.Context, pageID, pageSize int32, pageToken string) (pagepkg.Request, bool) {
+	pageReq := pagepkg.Request{PageID: pageID, PageSize: pageSize}
+
+	if pageToken != "" {
+		cursor, err := pagepkg.Parse(pageToken)
+		if err != nil {
+			zerolog.Ctx(gctx.Request.Context()).Info().Err(err).Send()
+			gctx.JSON(http.StatusBadRequest, web.Error(err))
+
+			return pageReq, false
+		}
+
+		pageReq.Cursor = cursor
+	}
+
+	return pageReq, true
+}
+
+func (h *Handler) serviceError(gctx *gin.Context, err error) {
+	zerolog.Ctx(gctx.Request.Context()).Info().Err(err).Send()
+
+	switch err {
+	case
+		domain.ErrAccountNotFound,
+		domain.ErrUserNotFound,
+		domain.ErrTransferReviewNotFound,
+		domain.ErrComplianceCaseNotFound:
+		gctx.JSON(http.StatusNotFound, web.Error(err))
+		return
+	case
+		domain.ErrTransferReviewClosed,
+		domain.ErrComplianceCaseClosed,
+		domain.ErrSanctionsListNotConfigured,
+		domain.ErrInsufficientBalance,
+		domain.ErrAccountFrozen:
+		gctx.JSON(http.StatusConflict, web.Error(err))
+		return
+	case
+		domain.ErrInvalidDateRange,
+		domain.ErrInvalidTransferLimit:
+		gctx.JSON(http.StatusBadRequest, web.Error(err))
+		return
+	case domain.ErrInvalidSanctionsList:
+		gctx.JSON(http.StatusUnprocessableEntity, web.Error(err))
+		return
+	}
+
+	gctx.JSON(http.StatusInternalServerError, web.Error(errorspkg.ErrInternal))
+}
+
+func (h *Handler) bindError(gctx *gin.Context, err error) {
+	zerolog.Ctx(gctx.Request.Context()).Info().Err(err).Send()
+
+	var ve validator.ValidationErrors
+	if errors.As(err, &ve) {
+		gctx.JSON(http.StatusBadRequest, web.Response{Error: web.GetErrorMsg(ve)})
+
+		return
+	}
+
+	gctx.JSON(http.StatusBadRequest, web.Error(err))
+}
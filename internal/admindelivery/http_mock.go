@@ -0,0 +1,310 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: http.go
+
+// Package admindelivery is a generated GoMock package.
+package admindelivery
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/go-petr/pet-bank/internal/domain"
+	pagepkg "github.com/go-petr/pet-bank/pkg/pagepkg"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// ApproveTransferReview mocks base method.
+func (m *MockService) ApproveTransferReview(ctx context.Context, actor string, id int64) (domain.ApproveTransferReviewResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveTransferReview", ctx, actor, id)
+	ret0, _ := ret[0].(domain.ApproveTransferReviewResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveTransferReview indicates an expected call of ApproveTransferReview.
+func (mr *MockServiceMockRecorder) ApproveTransferReview(ctx, actor, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveTransferReview", reflect.TypeOf((*MockService)(nil).ApproveTransferReview), ctx, actor, id)
+}
+
+// BlockSessions mocks base method.
+func (m *MockService) BlockSessions(ctx context.Context, actor, username string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockSessions", ctx, actor, username)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockSessions indicates an expected call of BlockSessions.
+func (mr *MockServiceMockRecorder) BlockSessions(ctx, actor, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockSessions", reflect.TypeOf((*MockService)(nil).BlockSessions), ctx, actor, username)
+}
+
+// ClearComplianceCase mocks base method.
+func (m *MockService) ClearComplianceCase(ctx context.Context, actor string, id int64) (domain.ComplianceCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearComplianceCase", ctx, actor, id)
+	ret0, _ := ret[0].(domain.ComplianceCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearComplianceCase indicates an expected call of ClearComplianceCase.
+func (mr *MockServiceMockRecorder) ClearComplianceCase(ctx, actor, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearComplianceCase", reflect.TypeOf((*MockService)(nil).ClearComplianceCase), ctx, actor, id)
+}
+
+// ConfirmComplianceCase mocks base method.
+func (m *MockService) ConfirmComplianceCase(ctx context.Context, actor string, id int64) (domain.ComplianceCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmComplianceCase", ctx, actor, id)
+	ret0, _ := ret[0].(domain.ComplianceCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmComplianceCase indicates an expected call of ConfirmComplianceCase.
+func (mr *MockServiceMockRecorder) ConfirmComplianceCase(ctx, actor, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmComplianceCase", reflect.TypeOf((*MockService)(nil).ConfirmComplianceCase), ctx, actor, id)
+}
+
+// FreezeAccount mocks base method.
+func (m *MockService) FreezeAccount(ctx context.Context, actor string, id int32) (domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FreezeAccount", ctx, actor, id)
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FreezeAccount indicates an expected call of FreezeAccount.
+func (mr *MockServiceMockRecorder) FreezeAccount(ctx, actor, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreezeAccount", reflect.TypeOf((*MockService)(nil).FreezeAccount), ctx, actor, id)
+}
+
+// GetAccount mocks base method.
+func (m *MockService) GetAccount(ctx context.Context, actor string, id int32) (domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", ctx, actor, id)
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockServiceMockRecorder) GetAccount(ctx, actor, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockService)(nil).GetAccount), ctx, actor, id)
+}
+
+// ListAccounts mocks base method.
+func (m *MockService) ListAccounts(ctx context.Context, actor, owner string, page pagepkg.Request) ([]domain.Account, pagepkg.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccounts", ctx, actor, owner, page)
+	ret0, _ := ret[0].([]domain.Account)
+	ret1, _ := ret[1].(pagepkg.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAccounts indicates an expected call of ListAccounts.
+func (mr *MockServiceMockRecorder) ListAccounts(ctx, actor, owner, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockService)(nil).ListAccounts), ctx, actor, owner, page)
+}
+
+// ListComplianceCases mocks base method.
+func (m *MockService) ListComplianceCases(ctx context.Context, actor, status string, page pagepkg.Request) ([]domain.ComplianceCase, pagepkg.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComplianceCases", ctx, actor, status, page)
+	ret0, _ := ret[0].([]domain.ComplianceCase)
+	ret1, _ := ret[1].(pagepkg.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListComplianceCases indicates an expected call of ListComplianceCases.
+func (mr *MockServiceMockRecorder) ListComplianceCases(ctx, actor, status, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComplianceCases", reflect.TypeOf((*MockService)(nil).ListComplianceCases), ctx, actor, status, page)
+}
+
+// ListEntries mocks base method.
+func (m *MockService) ListEntries(ctx context.Context, actor string, arg domain.ListEntriesParams, page pagepkg.Request) ([]domain.StatementLine, pagepkg.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntries", ctx, actor, arg, page)
+	ret0, _ := ret[0].([]domain.StatementLine)
+	ret1, _ := ret[1].(pagepkg.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListEntries indicates an expected call of ListEntries.
+func (mr *MockServiceMockRecorder) ListEntries(ctx, actor, arg, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockService)(nil).ListEntries), ctx, actor, arg, page)
+}
+
+// ListTransferLimits mocks base method.
+func (m *MockService) ListTransferLimits(ctx context.Context, actor, username string) ([]domain.TransferLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransferLimits", ctx, actor, username)
+	ret0, _ := ret[0].([]domain.TransferLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransferLimits indicates an expected call of ListTransferLimits.
+func (mr *MockServiceMockRecorder) ListTransferLimits(ctx, actor, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferLimits", reflect.TypeOf((*MockService)(nil).ListTransferLimits), ctx, actor, username)
+}
+
+// ListTransferReviews mocks base method.
+func (m *MockService) ListTransferReviews(ctx context.Context, actor, status string, page pagepkg.Request) ([]domain.TransferReview, pagepkg.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransferReviews", ctx, actor, status, page)
+	ret0, _ := ret[0].([]domain.TransferReview)
+	ret1, _ := ret[1].(pagepkg.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListTransferReviews indicates an expected call of ListTransferReviews.
+func (mr *MockServiceMockRecorder) ListTransferReviews(ctx, actor, status, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferReviews", reflect.TypeOf((*MockService)(nil).ListTransferReviews), ctx, actor, status, page)
+}
+
+// RejectTransferReview mocks base method.
+func (m *MockService) RejectTransferReview(ctx context.Context, actor string, id int64) (domain.TransferReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectTransferReview", ctx, actor, id)
+	ret0, _ := ret[0].(domain.TransferReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectTransferReview indicates an expected call of RejectTransferReview.
+func (mr *MockServiceMockRecorder) RejectTransferReview(ctx, actor, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectTransferReview", reflect.TypeOf((*MockService)(nil).RejectTransferReview), ctx, actor, id)
+}
+
+// ReloadSanctionsList mocks base method.
+func (m *MockService) ReloadSanctionsList(ctx context.Context, actor string) (domain.SanctionsList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReloadSanctionsList", ctx, actor)
+	ret0, _ := ret[0].(domain.SanctionsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReloadSanctionsList indicates an expected call of ReloadSanctionsList.
+func (mr *MockServiceMockRecorder) ReloadSanctionsList(ctx, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadSanctionsList", reflect.TypeOf((*MockService)(nil).ReloadSanctionsList), ctx, actor)
+}
+
+// ResetTransferLimit mocks base method.
+func (m *MockService) ResetTransferLimit(ctx context.Context, actor, username, currency string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetTransferLimit", ctx, actor, username, currency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetTransferLimit indicates an expected call of ResetTransferLimit.
+func (mr *MockServiceMockRecorder) ResetTransferLimit(ctx, actor, username, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetTransferLimit", reflect.TypeOf((*MockService)(nil).ResetTransferLimit), ctx, actor, username, currency)
+}
+
+// SearchUsers mocks base method.
+func (m *MockService) SearchUsers(ctx context.Context, actor string, arg domain.SearchUsersParams) ([]domain.UserWihtoutPassword, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchUsers", ctx, actor, arg)
+	ret0, _ := ret[0].([]domain.UserWihtoutPassword)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchUsers indicates an expected call of SearchUsers.
+func (mr *MockServiceMockRecorder) SearchUsers(ctx, actor, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsers", reflect.TypeOf((*MockService)(nil).SearchUsers), ctx, actor, arg)
+}
+
+// SetTransferLimit mocks base method.
+func (m *MockService) SetTransferLimit(ctx context.Context, actor string, arg domain.TransferLimit) (domain.TransferLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTransferLimit", ctx, actor, arg)
+	ret0, _ := ret[0].(domain.TransferLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTransferLimit indicates an expected call of SetTransferLimit.
+func (mr *MockServiceMockRecorder) SetTransferLimit(ctx, actor, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTransferLimit", reflect.TypeOf((*MockService)(nil).SetTransferLimit), ctx, actor, arg)
+}
+
+// UnfreezeAccount mocks base method.
+func (m *MockService) UnfreezeAccount(ctx context.Context, actor string, id int32) (domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnfreezeAccount", ctx, actor, id)
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnfreezeAccount indicates an expected call of UnfreezeAccount.
+func (mr *MockServiceMockRecorder) UnfreezeAccount(ctx, actor, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfreezeAccount", reflect.TypeOf((*MockService)(nil).UnfreezeAccount), ctx, actor, id)
+}
+
+// VerifyLedger mocks base method.
+func (m *MockService) VerifyLedger(ctx context.Context, actor string, accountID int32) (domain.ChainReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyLedger", ctx, actor, accountID)
+	ret0, _ := ret[0].(domain.ChainReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyLedger indicates an expected call of VerifyLedger.
+func (mr *MockServiceMockRecorder) VerifyLedger(ctx, actor, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyLedger", reflect.TypeOf((*MockService)(nil).VerifyLedger), ctx, actor, accountID)
+}
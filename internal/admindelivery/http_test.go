@@ -0,0 +1,636 @@
+package admindelivery
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
+	"github.com/go-petr/pet-bank/internal/middleware"
+	"github.com/go-petr/pet-bank/pkg/currencypkg"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/go-petr/pet-bank/pkg/tokenpkg"
+	"github.com/go-petr/pet-bank/pkg/web"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+func TestHandler(t *testing.T) {
+	actor := randompkg.Owner()
+	username := randompkg.Owner()
+	account := helpers.RandomAccount(username)
+	symmetricKey := randompkg.String(32)
+
+	tokenMaker, err := tokenpkg.NewPasetoMaker(symmetricKey)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(%v) returned error: %v", symmetricKey, err)
+	}
+
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		buildStubs     func(adminService *MockService)
+		wantStatusCode int
+		wantError      string
+	}{
+		{
+			name:   "SearchUsers",
+			method: http.MethodGet,
+			url:    "/admin/users?q=bob&page_id=2&page_size=5",
+			buildStubs: func(adminService *MockService) {
+				arg := domain.SearchUsersParams{Query: "bob", Limit: 5, Offset: 5}
+
+				adminService.EXPECT().
+					SearchUsers(gomock.Any(), gomock.Eq(actor), gomock.Eq(arg)).
+					Times(1).
+					Return([]domain.UserWihtoutPassword{{Username: "bob"}}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "SearchUsersRequiredPageID",
+			method: http.MethodGet,
+			url:    "/admin/users?q=bob&page_size=5",
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().SearchUsers(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "PageID field is required",
+		},
+		{
+			name:   "ListAccounts",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/admin/users/%s/accounts?page_id=1&page_size=5", username),
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					ListAccounts(gomock.Any(), gomock.Eq(actor), gomock.Eq(username), gomock.Eq(pagepkg.Request{PageID: 1, PageSize: 5})).
+					Times(1).
+					Return([]domain.Account{account}, pagepkg.Page{}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "GetAccount",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/admin/accounts/%d", account.ID),
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(actor), gomock.Eq(account.ID)).
+					Times(1).
+					Return(account, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "GetAccountInvalidID",
+			method: http.MethodGet,
+			url:    "/admin/accounts/0",
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().GetAccount(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "ID field is required",
+		},
+		{
+			name:   "GetAccountNotFound",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/admin/accounts/%d", account.ID),
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					GetAccount(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.Account{}, domain.ErrAccountNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      domain.ErrAccountNotFound.Error(),
+		},
+		{
+			name:   "ListEntries",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/admin/accounts/%d/entries?page_id=1&page_size=5&end_date=2023-01-31", account.ID),
+			buildStubs: func(adminService *MockService) {
+				arg := domain.ListEntriesParams{
+					AccountID: account.ID,
+					EndTime:   time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+				}
+
+				adminService.EXPECT().
+					ListEntries(gomock.Any(), gomock.Eq(actor), gomock.Eq(arg), gomock.Eq(pagepkg.Request{PageID: 1, PageSize: 5})).
+					Times(1).
+					Return([]domain.StatementLine{}, pagepkg.Page{}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "ListEntriesInvalidPageToken",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/admin/accounts/%d/entries?page_size=5&page_token=invalid", account.ID),
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().ListEntries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      pagepkg.ErrInvalidPageToken.Error(),
+		},
+		{
+			name:   "FreezeAccount",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/admin/accounts/%d/freeze", account.ID),
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					FreezeAccount(gomock.Any(), gomock.Eq(actor), gomock.Eq(account.ID)).
+					Times(1).
+					Return(account, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "UnfreezeAccount",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/admin/accounts/%d/unfreeze", account.ID),
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					UnfreezeAccount(gomock.Any(), gomock.Eq(actor), gomock.Eq(account.ID)).
+					Times(1).
+					Return(account, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "FreezeAccountInternalError",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/admin/accounts/%d/freeze", account.ID),
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					FreezeAccount(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.Account{}, errorspkg.ErrInternal)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      errorspkg.ErrInternal.Error(),
+		},
+		{
+			name:   "VerifyLedger",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/admin/ledger/verify?account_id=%d", account.ID),
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					VerifyLedger(gomock.Any(), gomock.Eq(actor), gomock.Eq(account.ID)).
+					Times(1).
+					Return(domain.ChainReport{Accounts: 1, Entries: 2, Breaks: []domain.ChainBreak{}}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "VerifyLedgerAllAccounts",
+			method: http.MethodGet,
+			url:    "/admin/ledger/verify",
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					VerifyLedger(gomock.Any(), gomock.Eq(actor), gomock.Eq(int32(0))).
+					Times(1).
+					Return(domain.ChainReport{Breaks: []domain.ChainBreak{}}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "VerifyLedgerAccountNotFound",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/admin/ledger/verify?account_id=%d", account.ID),
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					VerifyLedger(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.ChainReport{}, domain.ErrAccountNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      domain.ErrAccountNotFound.Error(),
+		},
+		{
+			name:   "BlockSessions",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("/admin/users/%s/sessions", username),
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					BlockSessions(gomock.Any(), gomock.Eq(actor), gomock.Eq(username)).
+					Times(1).
+					Return(int64(2), nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "BlockSessionsUserNotFound",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("/admin/users/%s/sessions", username),
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					BlockSessions(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), domain.ErrUserNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      domain.ErrUserNotFound.Error(),
+		},
+		{
+			name:   "ListTransferReviews",
+			method: http.MethodGet,
+			url:    "/admin/transfer-reviews?status=pending_review&page_id=1&page_size=5",
+			buildStubs: func(adminService *MockService) {
+				page := pagepkg.Request{PageID: 1, PageSize: 5}
+
+				adminService.EXPECT().
+					ListTransferReviews(gomock.Any(), gomock.Eq(actor), gomock.Eq(domain.TransferReviewStatusPending), gomock.Eq(page)).
+					Times(1).
+					Return([]domain.TransferReview{{ID: 1, Status: domain.TransferReviewStatusPending}}, pagepkg.Page{}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "ListTransferReviewsInvalidStatus",
+			method: http.MethodGet,
+			url:    "/admin/transfer-reviews?status=open&page_id=1&page_size=5",
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().ListTransferReviews(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "Status must be one of: pending_review approved rejected",
+		},
+		{
+			name:   "ApproveTransferReview",
+			method: http.MethodPost,
+			url:    "/admin/transfer-reviews/1/approve",
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					ApproveTransferReview(gomock.Any(), gomock.Eq(actor), gomock.Eq(int64(1))).
+					Times(1).
+					Return(domain.ApproveTransferReviewResult{Review: domain.TransferReview{ID: 1, Status: domain.TransferReviewStatusApproved}}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "ApproveTransferReviewClosed",
+			method: http.MethodPost,
+			url:    "/admin/transfer-reviews/1/approve",
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					ApproveTransferReview(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.ApproveTransferReviewResult{}, domain.ErrTransferReviewClosed)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantError:      domain.ErrTransferReviewClosed.Error(),
+		},
+		{
+			name:   "RejectTransferReview",
+			method: http.MethodPost,
+			url:    "/admin/transfer-reviews/1/reject",
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					RejectTransferReview(gomock.Any(), gomock.Eq(actor), gomock.Eq(int64(1))).
+					Times(1).
+					Return(domain.TransferReview{ID: 1, Status: domain.TransferReviewStatusRejected}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "RejectTransferReviewNotFound",
+			method: http.MethodPost,
+			url:    "/admin/transfer-reviews/1/reject",
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					RejectTransferReview(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.TransferReview{}, domain.ErrTransferReviewNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      domain.ErrTransferReviewNotFound.Error(),
+		},
+		{
+			name:   "ListComplianceCases",
+			method: http.MethodGet,
+			url:    "/admin/compliance-cases?status=open&page_id=1&page_size=5",
+			buildStubs: func(adminService *MockService) {
+				page := pagepkg.Request{PageID: 1, PageSize: 5}
+
+				adminService.EXPECT().
+					ListComplianceCases(gomock.Any(), gomock.Eq(actor), gomock.Eq(domain.ComplianceCaseStatusOpen), gomock.Eq(page)).
+					Times(1).
+					Return([]domain.ComplianceCase{{ID: 1, Status: domain.ComplianceCaseStatusOpen}}, pagepkg.Page{}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "ListComplianceCasesInvalidStatus",
+			method: http.MethodGet,
+			url:    "/admin/compliance-cases?status=pending_review&page_id=1&page_size=5",
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().ListComplianceCases(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "Status must be one of: open cleared confirmed",
+		},
+		{
+			name:   "ClearComplianceCase",
+			method: http.MethodPost,
+			url:    "/admin/compliance-cases/1/clear",
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					ClearComplianceCase(gomock.Any(), gomock.Eq(actor), gomock.Eq(int64(1))).
+					Times(1).
+					Return(domain.ComplianceCase{ID: 1, Status: domain.ComplianceCaseStatusCleared}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "ConfirmComplianceCaseClosed",
+			method: http.MethodPost,
+			url:    "/admin/compliance-cases/1/confirm",
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					ConfirmComplianceCase(gomock.Any(), gomock.Eq(actor), gomock.Eq(int64(1))).
+					Times(1).
+					Return(domain.ComplianceCase{}, domain.ErrComplianceCaseClosed)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantError:      domain.ErrComplianceCaseClosed.Error(),
+		},
+		{
+			name:   "ConfirmComplianceCaseInvalidID",
+			method: http.MethodPost,
+			url:    "/admin/compliance-cases/0/confirm",
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().ConfirmComplianceCase(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "ID field is required",
+		},
+		{
+			name:   "ReloadSanctionsList",
+			method: http.MethodPost,
+			url:    "/admin/sanctions-list/reload",
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					ReloadSanctionsList(gomock.Any(), gomock.Eq(actor)).
+					Times(1).
+					Return(domain.SanctionsList{File: "sdn.csv", Entries: 2}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "ReloadSanctionsListInvalid",
+			method: http.MethodPost,
+			url:    "/admin/sanctions-list/reload",
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					ReloadSanctionsList(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.SanctionsList{}, domain.ErrInvalidSanctionsList)
+			},
+			wantStatusCode: http.StatusUnprocessableEntity,
+			wantError:      domain.ErrInvalidSanctionsList.Error(),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			adminService := NewMockService(ctrl)
+			adminHandler := NewHandler(adminService)
+
+			server := gin.New()
+			admin := server.Group("/admin", middleware.AuthMiddleware(tokenMaker, nil))
+			admin.GET("/users", adminHandler.SearchUsers)
+			admin.GET("/users/:username/accounts", adminHandler.ListAccounts)
+			admin.DELETE("/users/:username/sessions", adminHandler.BlockSessions)
+			admin.GET("/accounts/:id", adminHandler.GetAccount)
+			admin.GET("/accounts/:id/entries", adminHandler.ListEntries)
+			admin.POST("/accounts/:id/freeze", adminHandler.FreezeAccount)
+			admin.POST("/accounts/:id/unfreeze", adminHandler.UnfreezeAccount)
+			admin.GET("/ledger/verify", adminHandler.VerifyLedger)
+			admin.GET("/transfer-reviews", adminHandler.ListTransferReviews)
+			admin.POST("/transfer-reviews/:id/approve", adminHandler.ApproveTransferReview)
+			admin.POST("/transfer-reviews/:id/reject", adminHandler.RejectTransferReview)
+			admin.GET("/compliance-cases", adminHandler.ListComplianceCases)
+			admin.POST("/compliance-cases/:id/clear", adminHandler.ClearComplianceCase)
+			admin.POST("/compliance-cases/:id/confirm", adminHandler.ConfirmComplianceCase)
+			admin.POST("/sanctions-list/reload", adminHandler.ReloadSanctionsList)
+
+			tc.buildStubs(adminService)
+
+			req, err := http.NewRequest(tc.method, tc.url, nil)
+			if err != nil {
+				t.Fatalf("Creating request error: %v", err)
+			}
+
+			if err := middleware.AddAuthorization(req, tokenMaker, middleware.AuthTypeBearer, actor, time.Minute); err != nil {
+				t.Fatalf("middleware.AddAuthorization(...) returned error: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			if got := w.Code; got != tc.wantStatusCode {
+				t.Errorf("Status code: got %v, want %v", got, tc.wantStatusCode)
+			}
+
+			var res web.Response
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Errorf("Decoding response body error: %v", err)
+			}
+
+			if res.Error != tc.wantError {
+				t.Errorf(`res.Error=%q, want %q`, res.Error, tc.wantError)
+			}
+		})
+	}
+}
+
+func TestTransferLimits(t *testing.T) {
+	actor := randompkg.Owner()
+	username := randompkg.Owner()
+	limit := domain.TransferLimit{Username: username, Currency: currencypkg.USD, Daily: "1000"}
+	symmetricKey := randompkg.String(32)
+
+	tokenMaker, err := tokenpkg.NewPasetoMaker(symmetricKey)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(%v) returned error: %v", symmetricKey, err)
+	}
+
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		if err := v.RegisterValidation("currency", currencypkg.ValidCurrency); err != nil {
+			t.Fatalf("v.RegisterValidation(currency) returned error: %v", err)
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		buildStubs     func(adminService *MockService)
+		wantStatusCode int
+		wantError      string
+	}{
+		{
+			name:   "List",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/admin/users/%s/transfer-limits", username),
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					ListTransferLimits(gomock.Any(), gomock.Eq(actor), gomock.Eq(username)).
+					Times(1).
+					Return([]domain.TransferLimit{limit}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "ListUserNotFound",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/admin/users/%s/transfer-limits", username),
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					ListTransferLimits(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrUserNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      domain.ErrUserNotFound.Error(),
+		},
+		{
+			name:   "Set",
+			method: http.MethodPut,
+			url:    fmt.Sprintf("/admin/users/%s/transfer-limits/USD", username),
+			body:   `{"daily":"1000"}`,
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					SetTransferLimit(gomock.Any(), gomock.Eq(actor), gomock.Eq(limit)).
+					Times(1).
+					Return(limit, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "SetUnsupportedCurrency",
+			method: http.MethodPut,
+			url:    fmt.Sprintf("/admin/users/%s/transfer-limits/GBP", username),
+			body:   `{"daily":"1000"}`,
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().SetTransferLimit(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "Currency is not supported",
+		},
+		{
+			name:   "SetInvalidTransferLimit",
+			method: http.MethodPut,
+			url:    fmt.Sprintf("/admin/users/%s/transfer-limits/USD", username),
+			body:   `{"daily":"-1"}`,
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					SetTransferLimit(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.TransferLimit{}, domain.ErrInvalidTransferLimit)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      domain.ErrInvalidTransferLimit.Error(),
+		},
+		{
+			name:   "Reset",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("/admin/users/%s/transfer-limits/USD", username),
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					ResetTransferLimit(gomock.Any(), gomock.Eq(actor), gomock.Eq(username), gomock.Eq(currencypkg.USD)).
+					Times(1).
+					Return(nil)
+			},
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name:   "ResetErrInternal",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("/admin/users/%s/transfer-limits/USD", username),
+			buildStubs: func(adminService *MockService) {
+				adminService.EXPECT().
+					ResetTransferLimit(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errorspkg.ErrInternal)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      errorspkg.ErrInternal.Error(),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			adminService := NewMockService(ctrl)
+			adminHandler := NewHandler(adminService)
+
+			server := gin.New()
+			admin := server.Group("/admin", middleware.AuthMiddleware(tokenMaker, nil))
+			admin.GET("/users/:username/transfer-limits", adminHandler.ListTransferLimits)
+			admin.PUT("/users/:username/transfer-limits/:currency", adminHandler.SetTransferLimit)
+			admin.DELETE("/users/:username/transfer-limits/:currency", adminHandler.ResetTransferLimit)
+
+			tc.buildStubs(adminService)
+
+			req, err := http.NewRequest(tc.method, tc.url, bytes.NewBufferString(tc.body))
+			if err != nil {
+				t.Fatalf("Creating request error: %v", err)
+			}
+
+			if err := middleware.AddAuthorization(req, tokenMaker, middleware.AuthTypeBearer, actor, time.Minute); err != nil {
+				t.Fatalf("middleware.AddAuthorization(...) returned error: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			if got := w.Code; got != tc.wantStatusCode {
+				t.Errorf("Status code: got %v, want %v", got, tc.wantStatusCode)
+			}
+
+			if w.Code == http.StatusNoContent {
+				return
+			}
+
+			var res web.Response
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Errorf("Decoding response body error: %v", err)
+			}
+
+			if res.Error != tc.wantError {
+				t.Errorf(`res.Error=%q, want %q`, res.Error, tc.wantError)
+			}
+		})
+	}
+}
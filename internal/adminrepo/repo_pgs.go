@@ -0,0 +1,63 @@
+// Package adminrepo manages repository layer of admin actions.
+package adminrepo
+
+import (
+	"context"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/dbpkg"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog"
+)
+
+// RepoPGS facilitates admin action repository layer logic.
+type RepoPGS struct {
+	db dbpkg.SQLInterface
+}
+
+// NewRepoPGS returns admin action RepoPGS.
+func NewRepoPGS(db dbpkg.SQLInterface) *RepoPGS {
+	return &RepoPGS{
+		db: db,
+	}
+}
+
+const createActionQuery = `
+INSERT INTO
+    admin_actions (actor, action, target)
+VALUES
+    ($1, $2, $3)
+RETURNING id, actor, action, target, created_at
+`
+
+// CreateAction records the admin action and then returns it.
+func (r *RepoPGS) CreateAction(ctx context.Context, arg domain.CreateAdminActionParams) (domain.AdminAction, error) {
+	l := zerolog.Ctx(ctx)
+
+	row := r.db.QueryRowContext(ctx, createActionQuery, arg.Actor, arg.Action, arg.Target)
+
+	var a domain.AdminAction
+
+	err := row.Scan(
+		&a.ID,
+		&a.Actor,
+		&a.Action,
+		&a.Target,
+		&a.CreatedAt,
+	)
+
+	if err != nil {
+		l.Error().Err(err).Send()
+
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Constraint == "admin_actions_actor_fkey" {
+				return a, domain.ErrUserNotFound
+			}
+		}
+
+		return a, errorspkg.ErrInternal
+	}
+
+	return a, nil
+}
@@ -0,0 +1,100 @@
+//go:build integration
+
+package adminrepo_test
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/go-petr/pet-bank/internal/adminrepo"
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/integrationtest"
+	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
+	"github.com/go-petr/pet-bank/pkg/configpkg"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	_ "github.com/lib/pq"
+)
+
+var (
+	dbDriver string
+	dbSource string
+)
+
+func TestMain(m *testing.M) {
+	config, err := configpkg.Load("../../configs")
+	if err != nil {
+		log.Fatal("cannot load config:", err)
+	}
+
+	dbDriver = config.DBDriver
+	dbSource = config.DBSource
+
+	os.Exit(m.Run())
+}
+
+func TestCreateAction(t *testing.T) {
+	testCases := []struct {
+		name    string
+		arg     func(tx *sql.Tx) domain.CreateAdminActionParams
+		wantErr error
+	}{
+		{
+			name: "OK",
+			arg: func(tx *sql.Tx) domain.CreateAdminActionParams {
+				user := helpers.SeedUser(t, tx)
+				return domain.CreateAdminActionParams{
+					Actor:  user.Username,
+					Action: domain.AdminActionFreezeAccount,
+					Target: "account:1",
+				}
+			},
+		},
+		{
+			name: "ErrUserNotFound",
+			arg: func(tx *sql.Tx) domain.CreateAdminActionParams {
+				return domain.CreateAdminActionParams{
+					Actor:  "notfound",
+					Action: domain.AdminActionFreezeAccount,
+					Target: "account:1",
+				}
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+			arg := tc.arg(tx)
+			repo := adminrepo.NewRepoPGS(tx)
+
+			got, err := repo.CreateAction(context.Background(), arg)
+			if err != tc.wantErr {
+				t.Fatalf("repo.CreateAction(context.Background(), %+v) returned error: %v, want %v", arg, err, tc.wantErr)
+			}
+
+			if tc.wantErr != nil {
+				return
+			}
+
+			want := domain.AdminAction{Actor: arg.Actor, Action: arg.Action, Target: arg.Target}
+			ignoreFields := cmpopts.IgnoreFields(domain.AdminAction{}, "ID", "CreatedAt")
+
+			if diff := cmp.Diff(want, got, ignoreFields); diff != "" {
+				t.Errorf("repo.CreateAction(context.Background(), %+v) returned unexpected difference (-want +got):\n%s", arg, diff)
+			}
+
+			if got.ID == 0 || got.CreatedAt.IsZero() {
+				t.Errorf("got = %+v, want non-zero ID and CreatedAt", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,483 @@
+// Package adminservice manages business logic layer of the admin API.
+package adminservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
+	"github.com/rs/zerolog"
+)
+
+// Repo provides data access layer interface needed to record admin actions.
+//
+//go:generate mockgen -source service.go -destination service_mock.go -package adminservice
+type Repo interface {
+	CreateAction(ctx context.Context, arg domain.CreateAdminActionParams) (domain.AdminAction, error)
+}
+
+// UserRepo provides user data access needed by admin service layer.
+type UserRepo interface {
+	Get(ctx context.Context, username string) (domain.User, error)
+	Search(ctx context.Context, arg domain.SearchUsersParams) ([]domain.UserWihtoutPassword, error)
+}
+
+// AccountRepo provides account data access needed by admin service layer.
+type AccountRepo interface {
+	Get(ctx context.Context, id int32) (domain.Account, error)
+	List(ctx context.Context, arg domain.ListAccountsParams) ([]domain.Account, error)
+	Freeze(ctx context.Context, id int32) (domain.Account, error)
+	Unfreeze(ctx context.Context, id int32) (domain.Account, error)
+}
+
+// EntryRepo provides entry data access needed by admin service layer.
+type EntryRepo interface {
+	List(ctx context.Context, arg domain.ListEntriesParams) ([]domain.StatementLine, error)
+}
+
+// SessionRevoker blocks the user's sessions and revokes their access tokens.
+type SessionRevoker interface {
+	RevokeAll(ctx context.Context, username string) (int64, error)
+}
+
+// LedgerVerifier verifies the entries hash chain.
+type LedgerVerifier interface {
+	VerifyChain(ctx context.Context, accountID int32) (domain.ChainReport, error)
+}
+
+// LimitManager manages the users' outgoing transfer limit overrides.
+type LimitManager interface {
+	List(ctx context.Context, username string) ([]domain.TransferLimit, error)
+	Set(ctx context.Context, arg domain.TransferLimit) (domain.TransferLimit, error)
+	Reset(ctx context.Context, username, currency string) error
+}
+
+// TransferReviewer manages the transfers held for review by the transaction
+// monitoring.
+type TransferReviewer interface {
+	GetReview(ctx context.Context, id int64) (domain.TransferReview, error)
+	ListReviews(ctx context.Context, status string, page pagepkg.Request) ([]domain.TransferReview, pagepkg.Page, error)
+	ApproveReview(ctx context.Context, reviewer string, id int64) (domain.ApproveTransferReviewResult, error)
+	RejectReview(ctx context.Context, reviewer string, id int64) (domain.TransferReview, error)
+}
+
+// ComplianceManager manages the compliance cases and the list of the sanctions
+// screening.
+type ComplianceManager interface {
+	GetCase(ctx context.Context, id int64) (domain.ComplianceCase, error)
+	ListCases(ctx context.Context, status string, page pagepkg.Request) ([]domain.ComplianceCase, pagepkg.Page, error)
+	CloseCase(ctx context.Context, reviewer string, id int64, status string) (domain.ComplianceCase, error)
+	Reload(ctx context.Context, actor string) (domain.SanctionsList, error)
+}
+
+// Service facilitates admin service layer logic.
+//
+// Every method takes the username of the staff member performing the action
+// and records the action once it has succeeded. The write actions are
+// idempotent, so they can be retried if the recording fails.
+type Service struct {
+	repo        Repo
+	userRepo    UserRepo
+	accountRepo AccountRepo
+	entryRepo   EntryRepo
+	sessions    SessionRevoker
+	ledger      LedgerVerifier
+	limits      LimitManager
+	reviews     TransferReviewer
+	compliance  ComplianceManager
+}
+
+// New returns admin service struct to manage admin bussines logic.
+func New(
+	r Repo,
+	ur UserRepo,
+	ar AccountRepo,
+	er EntryRepo,
+	sr SessionRevoker,
+	lv LedgerVerifier,
+	lm LimitManager,
+	tr TransferReviewer,
+	cm ComplianceManager,
+) *Service {
+	return &Service{
+		repo:        r,
+		userRepo:    ur,
+		accountRepo: ar,
+		entryRepo:   er,
+		sessions:    sr,
+		ledger:      lv,
+		limits:      lm,
+		reviews:     tr,
+		compliance:  cm,
+	}
+}
+
+func userTarget(username string) string {
+	return "user:" + username
+}
+
+func accountTarget(id int32) string {
+	return fmt.Sprintf("account:%d", id)
+}
+
+func transferReviewTarget(id int64) string {
+	return fmt.Sprintf("transfer_review:%d", id)
+}
+
+func complianceCaseTarget(id int64) string {
+	return fmt.Sprintf("compliance_case:%d", id)
+}
+
+func (s *Service) record(ctx context.Context, actor, action, target string) error {
+	_, err := s.repo.CreateAction(ctx, domain.CreateAdminActionParams{
+		Actor:  actor,
+		Action: action,
+		Target: target,
+	})
+
+	return err
+}
+
+// SearchUsers returns the users which username, full name or email contains the query.
+func (s *Service) SearchUsers(ctx context.Context, actor string, arg domain.SearchUsersParams) ([]domain.UserWihtoutPassword, error) {
+	users, err := s.userRepo.Search(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.record(ctx, actor, domain.AdminActionSearchUsers, "query:"+arg.Query); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// ListAccounts returns the page of accounts owned by the given user.
+func (s *Service) ListAccounts(ctx context.Context, actor, owner string, page pagepkg.Request) ([]domain.Account, pagepkg.Page, error) {
+	arg := domain.ListAccountsParams{
+		Owner:    owner,
+		AfterID:  page.Cursor.AfterID,
+		BeforeID: page.Cursor.BeforeID,
+		Limit:    page.Limit(),
+		Offset:   page.Offset(),
+	}
+
+	accounts, err := s.accountRepo.List(ctx, arg)
+	if err != nil {
+		return nil, pagepkg.Page{}, err
+	}
+
+	if err := s.record(ctx, actor, domain.AdminActionListAccounts, userTarget(owner)); err != nil {
+		return nil, pagepkg.Page{}, err
+	}
+
+	accounts, p := pagepkg.Trim(accounts, page, func(a domain.Account) int64 { return int64(a.ID) })
+
+	return accounts, p, nil
+}
+
+// GetAccount returns any account with the given id.
+func (s *Service) GetAccount(ctx context.Context, actor string, id int32) (domain.Account, error) {
+	account, err := s.accountRepo.Get(ctx, id)
+	if err != nil {
+		return domain.Account{}, err
+	}
+
+	if err := s.record(ctx, actor, domain.AdminActionViewAccount, accountTarget(id)); err != nil {
+		return domain.Account{}, err
+	}
+
+	return account, nil
+}
+
+// ListEntries returns the page of any account statement.
+func (s *Service) ListEntries(ctx context.Context, actor string, arg domain.ListEntriesParams, page pagepkg.Request) ([]domain.StatementLine, pagepkg.Page, error) {
+	l := zerolog.Ctx(ctx)
+
+	if _, err := s.accountRepo.Get(ctx, arg.AccountID); err != nil {
+		return nil, pagepkg.Page{}, err
+	}
+
+	if !arg.StartTime.IsZero() && !arg.EndTime.IsZero() && arg.EndTime.Before(arg.StartTime) {
+		l.Info().Err(domain.ErrInvalidDateRange).Send()
+		return nil, pagepkg.Page{}, domain.ErrInvalidDateRange
+	}
+
+	arg.AfterID = page.Cursor.AfterID
+	arg.BeforeID = page.Cursor.BeforeID
+	arg.Limit = page.Limit()
+	arg.Offset = page.Offset()
+
+	lines, err := s.entryRepo.List(ctx, arg)
+	if err != nil {
+		return nil, pagepkg.Page{}, err
+	}
+
+	if err := s.record(ctx, actor, domain.AdminActionViewEntries, accountTarget(arg.AccountID)); err != nil {
+		return nil, pagepkg.Page{}, err
+	}
+
+	lines, p := pagepkg.Trim(lines, page, func(line domain.StatementLine) int64 { return line.ID })
+
+	return lines, p, nil
+}
+
+// FreezeAccount freezes the account, so it can neither send nor receive transfers.
+func (s *Service) FreezeAccount(ctx context.Context, actor string, id int32) (domain.Account, error) {
+	account, err := s.accountRepo.Freeze(ctx, id)
+	if err != nil {
+		return domain.Account{}, err
+	}
+
+	zerolog.Ctx(ctx).Info().Str("actor", actor).Int32("account_id", id).Msg("account frozen")
+
+	if err := s.record(ctx, actor, domain.AdminActionFreezeAccount, accountTarget(id)); err != nil {
+		return domain.Account{}, err
+	}
+
+	return account, nil
+}
+
+// UnfreezeAccount unfreezes the account.
+func (s *Service) UnfreezeAccount(ctx context.Context, actor string, id int32) (domain.Account, error) {
+	account, err := s.accountRepo.Unfreeze(ctx, id)
+	if err != nil {
+		return domain.Account{}, err
+	}
+
+	zerolog.Ctx(ctx).Info().Str("actor", actor).Int32("account_id", id).Msg("account unfrozen")
+
+	if err := s.record(ctx, actor, domain.AdminActionUnfreezeAccount, accountTarget(id)); err != nil {
+		return domain.Account{}, err
+	}
+
+	return account, nil
+}
+
+// BlockSessions blocks all sessions of the user and returns the number of
+// newly blocked sessions. The access tokens bound to them are rejected from
+// then on.
+func (s *Service) BlockSessions(ctx context.Context, actor, username string) (int64, error) {
+	if _, err := s.userRepo.Get(ctx, username); err != nil {
+		return 0, err
+	}
+
+	n, err := s.sessions.RevokeAll(ctx, username)
+	if err != nil {
+		return 0, err
+	}
+
+	zerolog.Ctx(ctx).Info().Str("actor", actor).Str("username", username).Int64("sessions", n).Msg("sessions blocked")
+
+	if err := s.record(ctx, actor, domain.AdminActionBlockSessions, userTarget(username)); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// VerifyLedger walks the entries hash chain of the account, or of all accounts
+// if accountID is zero, and reports the first broken link of each account.
+func (s *Service) VerifyLedger(ctx context.Context, actor string, accountID int32) (domain.ChainReport, error) {
+	report, err := s.ledger.VerifyChain(ctx, accountID)
+	if err != nil {
+		return domain.ChainReport{}, err
+	}
+
+	target := "ledger"
+	if accountID != 0 {
+		target = accountTarget(accountID)
+	}
+
+	if err := s.record(ctx, actor, domain.AdminActionVerifyLedger, target); err != nil {
+		return domain.ChainReport{}, err
+	}
+
+	return report, nil
+}
+
+// ListTransferLimits returns the outgoing transfer limits of the user in all
+// the supported currencies.
+func (s *Service) ListTransferLimits(ctx context.Context, actor, username string) ([]domain.TransferLimit, error) {
+	if _, err := s.userRepo.Get(ctx, username); err != nil {
+		return nil, err
+	}
+
+	limits, err := s.limits.List(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.record(ctx, actor, domain.AdminActionViewTransferLimits, userTarget(username)); err != nil {
+		return nil, err
+	}
+
+	return limits, nil
+}
+
+// SetTransferLimit overrides the default outgoing transfer limits of the user
+// in arg.Currency and returns the resulting limits.
+func (s *Service) SetTransferLimit(ctx context.Context, actor string, arg domain.TransferLimit) (domain.TransferLimit, error) {
+	limit, err := s.limits.Set(ctx, arg)
+	if err != nil {
+		return domain.TransferLimit{}, err
+	}
+
+	zerolog.Ctx(ctx).Info().Str("actor", actor).Interface("limit", limit).Msg("transfer limit set")
+
+	if err := s.record(ctx, actor, domain.AdminActionSetTransferLimit, userTarget(arg.Username)); err != nil {
+		return domain.TransferLimit{}, err
+	}
+
+	return limit, nil
+}
+
+// ResetTransferLimit removes the user's limit override in the currency, so
+// the default limits apply again.
+func (s *Service) ResetTransferLimit(ctx context.Context, actor, username, currency string) error {
+	if _, err := s.userRepo.Get(ctx, username); err != nil {
+		return err
+	}
+
+	if err := s.limits.Reset(ctx, username, currency); err != nil && err != domain.ErrTransferLimitNotFound {
+		return err
+	}
+
+	zerolog.Ctx(ctx).Info().Str("actor", actor).Str("username", username).Str("currency", currency).Msg("transfer limit reset")
+
+	return s.record(ctx, actor, domain.AdminActionResetTransferLimit, userTarget(username))
+}
+
+// ListTransferReviews returns the page of the transfers held for review with
+// the given status, or all of them if it is empty.
+func (s *Service) ListTransferReviews(ctx context.Context, actor, status string, page pagepkg.Request) ([]domain.TransferReview, pagepkg.Page, error) {
+	reviews, p, err := s.reviews.ListReviews(ctx, status, page)
+	if err != nil {
+		return nil, pagepkg.Page{}, err
+	}
+
+	if err := s.record(ctx, actor, domain.AdminActionListTransferReviews, "status:"+status); err != nil {
+		return nil, pagepkg.Page{}, err
+	}
+
+	return reviews, p, nil
+}
+
+// ApproveTransferReview executes the transfer held for review. If the review
+// has already been approved, only the review of the result is set.
+func (s *Service) ApproveTransferReview(ctx context.Context, actor string, id int64) (domain.ApproveTransferReviewResult, error) {
+	result, err := s.reviews.ApproveReview(ctx, actor, id)
+	if err == domain.ErrTransferReviewClosed {
+		result.Review, err = s.closedReview(ctx, id, domain.TransferReviewStatusApproved)
+	}
+
+	if err != nil {
+		return domain.ApproveTransferReviewResult{}, err
+	}
+
+	if err := s.record(ctx, actor, domain.AdminActionApproveTransferReview, transferReviewTarget(id)); err != nil {
+		return domain.ApproveTransferReviewResult{}, err
+	}
+
+	return result, nil
+}
+
+// RejectTransferReview releases the amount of the transfer held for review
+// without executing it.
+func (s *Service) RejectTransferReview(ctx context.Context, actor string, id int64) (domain.TransferReview, error) {
+	review, err := s.reviews.RejectReview(ctx, actor, id)
+	if err == domain.ErrTransferReviewClosed {
+		review, err = s.closedReview(ctx, id, domain.TransferReviewStatusRejected)
+	}
+
+	if err != nil {
+		return domain.TransferReview{}, err
+	}
+
+	if err := s.record(ctx, actor, domain.AdminActionRejectTransferReview, transferReviewTarget(id)); err != nil {
+		return domain.TransferReview{}, err
+	}
+
+	return review, nil
+}
+
+// closedReview returns the closed transfer review if it has the given status,
+// so that a retried approval or rejection succeeds.
+func (s *Service) closedReview(ctx context.Context, id int64, status string) (domain.TransferReview, error) {
+	review, err := s.reviews.GetReview(ctx, id)
+	if err != nil {
+		return domain.TransferReview{}, err
+	}
+
+	if review.Status != status {
+		zerolog.Ctx(ctx).Info().Err(domain.ErrTransferReviewClosed).Str("status", review.Status).Send()
+		return domain.TransferReview{}, domain.ErrTransferReviewClosed
+	}
+
+	return review, nil
+}
+
+// ListComplianceCases returns the page of the compliance cases with the given
+// status, or all of them if it is empty.
+func (s *Service) ListComplianceCases(ctx context.Context, actor, status string, page pagepkg.Request) ([]domain.ComplianceCase, pagepkg.Page, error) {
+	cases, p, err := s.compliance.ListCases(ctx, status, page)
+	if err != nil {
+		return nil, pagepkg.Page{}, err
+	}
+
+	if err := s.record(ctx, actor, domain.AdminActionListComplianceCases, "status:"+status); err != nil {
+		return nil, pagepkg.Page{}, err
+	}
+
+	return cases, p, nil
+}
+
+// ClearComplianceCase closes the compliance case as a false positive, so that
+// the screened name of the user passes the screening afterwards.
+func (s *Service) ClearComplianceCase(ctx context.Context, actor string, id int64) (domain.ComplianceCase, error) {
+	return s.closeComplianceCase(ctx, actor, id, domain.ComplianceCaseStatusCleared, domain.AdminActionClearComplianceCase)
+}
+
+// ConfirmComplianceCase closes the compliance case as a true match.
+func (s *Service) ConfirmComplianceCase(ctx context.Context, actor string, id int64) (domain.ComplianceCase, error) {
+	return s.closeComplianceCase(ctx, actor, id, domain.ComplianceCaseStatusConfirmed, domain.AdminActionConfirmComplianceCase)
+}
+
+// closeComplianceCase sets the status of the open compliance case. A retry
+// succeeds if the case already has the status.
+func (s *Service) closeComplianceCase(ctx context.Context, actor string, id int64, status, action string) (domain.ComplianceCase, error) {
+	c, err := s.compliance.CloseCase(ctx, actor, id, status)
+	if err == domain.ErrComplianceCaseClosed {
+		c, err = s.compliance.GetCase(ctx, id)
+		if err == nil && c.Status != status {
+			zerolog.Ctx(ctx).Info().Err(domain.ErrComplianceCaseClosed).Str("status", c.Status).Send()
+			err = domain.ErrComplianceCaseClosed
+		}
+	}
+
+	if err != nil {
+		return domain.ComplianceCase{}, err
+	}
+
+	if err := s.record(ctx, actor, action, complianceCaseTarget(id)); err != nil {
+		return domain.ComplianceCase{}, err
+	}
+
+	return c, nil
+}
+
+// ReloadSanctionsList rereads the sanctions list file, e.g. right after it has
+// been updated.
+func (s *Service) ReloadSanctionsList(ctx context.Context, actor string) (domain.SanctionsList, error) {
+	list, err := s.compliance.Reload(ctx, actor)
+	if err != nil {
+		return domain.SanctionsList{}, err
+	}
+
+	if err := s.record(ctx, actor, domain.AdminActionReloadSanctionsList, "sanctions_list"); err != nil {
+		return domain.SanctionsList{}, err
+	}
+
+	return list, nil
+}
@@ -0,0 +1,537 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package adminservice is a generated GoMock package.
+package adminservice
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/go-petr/pet-bank/internal/domain"
+	pagepkg "github.com/go-petr/pet-bank/pkg/pagepkg"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepo is a mock of Repo interface.
+type MockRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoMockRecorder
+}
+
+// MockRepoMockRecorder is the mock recorder for MockRepo.
+type MockRepoMockRecorder struct {
+	mock *MockRepo
+}
+
+// NewMockRepo creates a new mock instance.
+func NewMockRepo(ctrl *gomock.Controller) *MockRepo {
+	mock := &MockRepo{ctrl: ctrl}
+	mock.recorder = &MockRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateAction mocks base method.
+func (m *MockRepo) CreateAction(ctx context.Context, arg domain.CreateAdminActionParams) (domain.AdminAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAction", ctx, arg)
+	ret0, _ := ret[0].(domain.AdminAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAction indicates an expected call of CreateAction.
+func (mr *MockRepoMockRecorder) CreateAction(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAction", reflect.TypeOf((*MockRepo)(nil).CreateAction), ctx, arg)
+}
+
+// MockUserRepo is a mock of UserRepo interface.
+type MockUserRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserRepoMockRecorder
+}
+
+// MockUserRepoMockRecorder is the mock recorder for MockUserRepo.
+type MockUserRepoMockRecorder struct {
+	mock *MockUserRepo
+}
+
+// NewMockUserRepo creates a new mock instance.
+func NewMockUserRepo(ctrl *gomock.Controller) *MockUserRepo {
+	mock := &MockUserRepo{ctrl: ctrl}
+	mock.recorder = &MockUserRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserRepo) EXPECT() *MockUserRepoMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockUserRepo) Get(ctx context.Context, username string) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, username)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockUserRepoMockRecorder) Get(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUserRepo)(nil).Get), ctx, username)
+}
+
+// Search mocks base method.
+func (m *MockUserRepo) Search(ctx context.Context, arg domain.SearchUsersParams) ([]domain.UserWihtoutPassword, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, arg)
+	ret0, _ := ret[0].([]domain.UserWihtoutPassword)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockUserRepoMockRecorder) Search(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockUserRepo)(nil).Search), ctx, arg)
+}
+
+// MockAccountRepo is a mock of AccountRepo interface.
+type MockAccountRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountRepoMockRecorder
+}
+
+// MockAccountRepoMockRecorder is the mock recorder for MockAccountRepo.
+type MockAccountRepoMockRecorder struct {
+	mock *MockAccountRepo
+}
+
+// NewMockAccountRepo creates a new mock instance.
+func NewMockAccountRepo(ctrl *gomock.Controller) *MockAccountRepo {
+	mock := &MockAccountRepo{ctrl: ctrl}
+	mock.recorder = &MockAccountRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountRepo) EXPECT() *MockAccountRepoMockRecorder {
+	return m.recorder
+}
+
+// Freeze mocks base method.
+func (m *MockAccountRepo) Freeze(ctx context.Context, id int32) (domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Freeze", ctx, id)
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Freeze indicates an expected call of Freeze.
+func (mr *MockAccountRepoMockRecorder) Freeze(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Freeze", reflect.TypeOf((*MockAccountRepo)(nil).Freeze), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockAccountRepo) Get(ctx context.Context, id int32) (domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAccountRepoMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAccountRepo)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockAccountRepo) List(ctx context.Context, arg domain.ListAccountsParams) ([]domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, arg)
+	ret0, _ := ret[0].([]domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAccountRepoMockRecorder) List(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAccountRepo)(nil).List), ctx, arg)
+}
+
+// Unfreeze mocks base method.
+func (m *MockAccountRepo) Unfreeze(ctx context.Context, id int32) (domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unfreeze", ctx, id)
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unfreeze indicates an expected call of Unfreeze.
+func (mr *MockAccountRepoMockRecorder) Unfreeze(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unfreeze", reflect.TypeOf((*MockAccountRepo)(nil).Unfreeze), ctx, id)
+}
+
+// MockEntryRepo is a mock of EntryRepo interface.
+type MockEntryRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockEntryRepoMockRecorder
+}
+
+// MockEntryRepoMockRecorder is the mock recorder for MockEntryRepo.
+type MockEntryRepoMockRecorder struct {
+	mock *MockEntryRepo
+}
+
+// NewMockEntryRepo creates a new mock instance.
+func NewMockEntryRepo(ctrl *gomock.Controller) *MockEntryRepo {
+	mock := &MockEntryRepo{ctrl: ctrl}
+	mock.recorder = &MockEntryRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEntryRepo) EXPECT() *MockEntryRepoMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockEntryRepo) List(ctx context.Context, arg domain.ListEntriesParams) ([]domain.StatementLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, arg)
+	ret0, _ := ret[0].([]domain.StatementLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockEntryRepoMockRecorder) List(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockEntryRepo)(nil).List), ctx, arg)
+}
+
+// MockSessionRevoker is a mock of SessionRevoker interface.
+type MockSessionRevoker struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionRevokerMockRecorder
+}
+
+// MockSessionRevokerMockRecorder is the mock recorder for MockSessionRevoker.
+type MockSessionRevokerMockRecorder struct {
+	mock *MockSessionRevoker
+}
+
+// NewMockSessionRevoker creates a new mock instance.
+func NewMockSessionRevoker(ctrl *gomock.Controller) *MockSessionRevoker {
+	mock := &MockSessionRevoker{ctrl: ctrl}
+	mock.recorder = &MockSessionRevokerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionRevoker) EXPECT() *MockSessionRevokerMockRecorder {
+	return m.recorder
+}
+
+// RevokeAll mocks base method.
+func (m *MockSessionRevoker) RevokeAll(ctx context.Context, username string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", ctx, username)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockSessionRevokerMockRecorder) RevokeAll(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockSessionRevoker)(nil).RevokeAll), ctx, username)
+}
+
+// MockLedgerVerifier is a mock of LedgerVerifier interface.
+type MockLedgerVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgerVerifierMockRecorder
+}
+
+// MockLedgerVerifierMockRecorder is the mock recorder for MockLedgerVerifier.
+type MockLedgerVerifierMockRecorder struct {
+	mock *MockLedgerVerifier
+}
+
+// NewMockLedgerVerifier creates a new mock instance.
+func NewMockLedgerVerifier(ctrl *gomock.Controller) *MockLedgerVerifier {
+	mock := &MockLedgerVerifier{ctrl: ctrl}
+	mock.recorder = &MockLedgerVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLedgerVerifier) EXPECT() *MockLedgerVerifierMockRecorder {
+	return m.recorder
+}
+
+// VerifyChain mocks base method.
+func (m *MockLedgerVerifier) VerifyChain(ctx context.Context, accountID int32) (domain.ChainReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyChain", ctx, accountID)
+	ret0, _ := ret[0].(domain.ChainReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyChain indicates an expected call of VerifyChain.
+func (mr *MockLedgerVerifierMockRecorder) VerifyChain(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyChain", reflect.TypeOf((*MockLedgerVerifier)(nil).VerifyChain), ctx, accountID)
+}
+
+// MockLimitManager is a mock of LimitManager interface.
+type MockLimitManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockLimitManagerMockRecorder
+}
+
+// MockLimitManagerMockRecorder is the mock recorder for MockLimitManager.
+type MockLimitManagerMockRecorder struct {
+	mock *MockLimitManager
+}
+
+// NewMockLimitManager creates a new mock instance.
+func NewMockLimitManager(ctrl *gomock.Controller) *MockLimitManager {
+	mock := &MockLimitManager{ctrl: ctrl}
+	mock.recorder = &MockLimitManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLimitManager) EXPECT() *MockLimitManagerMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockLimitManager) List(ctx context.Context, username string) ([]domain.TransferLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, username)
+	ret0, _ := ret[0].([]domain.TransferLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockLimitManagerMockRecorder) List(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockLimitManager)(nil).List), ctx, username)
+}
+
+// Reset mocks base method.
+func (m *MockLimitManager) Reset(ctx context.Context, username, currency string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx, username, currency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockLimitManagerMockRecorder) Reset(ctx, username, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockLimitManager)(nil).Reset), ctx, username, currency)
+}
+
+// Set mocks base method.
+func (m *MockLimitManager) Set(ctx context.Context, arg domain.TransferLimit) (domain.TransferLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, arg)
+	ret0, _ := ret[0].(domain.TransferLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockLimitManagerMockRecorder) Set(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockLimitManager)(nil).Set), ctx, arg)
+}
+
+// MockTransferReviewer is a mock of TransferReviewer interface.
+type MockTransferReviewer struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransferReviewerMockRecorder
+}
+
+// MockTransferReviewerMockRecorder is the mock recorder for MockTransferReviewer.
+type MockTransferReviewerMockRecorder struct {
+	mock *MockTransferReviewer
+}
+
+// NewMockTransferReviewer creates a new mock instance.
+func NewMockTransferReviewer(ctrl *gomock.Controller) *MockTransferReviewer {
+	mock := &MockTransferReviewer{ctrl: ctrl}
+	mock.recorder = &MockTransferReviewerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransferReviewer) EXPECT() *MockTransferReviewerMockRecorder {
+	return m.recorder
+}
+
+// ApproveReview mocks base method.
+func (m *MockTransferReviewer) ApproveReview(ctx context.Context, reviewer string, id int64) (domain.ApproveTransferReviewResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveReview", ctx, reviewer, id)
+	ret0, _ := ret[0].(domain.ApproveTransferReviewResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveReview indicates an expected call of ApproveReview.
+func (mr *MockTransferReviewerMockRecorder) ApproveReview(ctx, reviewer, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveReview", reflect.TypeOf((*MockTransferReviewer)(nil).ApproveReview), ctx, reviewer, id)
+}
+
+// GetReview mocks base method.
+func (m *MockTransferReviewer) GetReview(ctx context.Context, id int64) (domain.TransferReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReview", ctx, id)
+	ret0, _ := ret[0].(domain.TransferReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReview indicates an expected call of GetReview.
+func (mr *MockTransferReviewerMockRecorder) GetReview(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReview", reflect.TypeOf((*MockTransferReviewer)(nil).GetReview), ctx, id)
+}
+
+// ListReviews mocks base method.
+func (m *MockTransferReviewer) ListReviews(ctx context.Context, status string, page pagepkg.Request) ([]domain.TransferReview, pagepkg.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReviews", ctx, status, page)
+	ret0, _ := ret[0].([]domain.TransferReview)
+	ret1, _ := ret[1].(pagepkg.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListReviews indicates an expected call of ListReviews.
+func (mr *MockTransferReviewerMockRecorder) ListReviews(ctx, status, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviews", reflect.TypeOf((*MockTransferReviewer)(nil).ListReviews), ctx, status, page)
+}
+
+// RejectReview mocks base method.
+func (m *MockTransferReviewer) RejectReview(ctx context.Context, reviewer string, id int64) (domain.TransferReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectReview", ctx, reviewer, id)
+	ret0, _ := ret[0].(domain.TransferReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectReview indicates an expected call of RejectReview.
+func (mr *MockTransferReviewerMockRecorder) RejectReview(ctx, reviewer, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectReview", reflect.TypeOf((*MockTransferReviewer)(nil).RejectReview), ctx, reviewer, id)
+}
+
+// MockComplianceManager is a mock of ComplianceManager interface.
+type MockComplianceManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockComplianceManagerMockRecorder
+}
+
+// MockComplianceManagerMockRecorder is the mock recorder for MockComplianceManager.
+type MockComplianceManagerMockRecorder struct {
+	mock *MockComplianceManager
+}
+
+// NewMockComplianceManager creates a new mock instance.
+func NewMockComplianceManager(ctrl *gomock.Controller) *MockComplianceManager {
+	mock := &MockComplianceManager{ctrl: ctrl}
+	mock.recorder = &MockComplianceManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockComplianceManager) EXPECT() *MockComplianceManagerMockRecorder {
+	return m.recorder
+}
+
+// CloseCase mocks base method.
+func (m *MockComplianceManager) CloseCase(ctx context.Context, reviewer string, id int64, status string) (domain.ComplianceCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseCase", ctx, reviewer, id, status)
+	ret0, _ := ret[0].(domain.ComplianceCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseCase indicates an expected call of CloseCase.
+func (mr *MockComplianceManagerMockRecorder) CloseCase(ctx, reviewer, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseCase", reflect.TypeOf((*MockComplianceManager)(nil).CloseCase), ctx, reviewer, id, status)
+}
+
+// GetCase mocks base method.
+func (m *MockComplianceManager) GetCase(ctx context.Context, id int64) (domain.ComplianceCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCase", ctx, id)
+	ret0, _ := ret[0].(domain.ComplianceCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCase indicates an expected call of GetCase.
+func (mr *MockComplianceManagerMockRecorder) GetCase(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCase", reflect.TypeOf((*MockComplianceManager)(nil).GetCase), ctx, id)
+}
+
+// ListCases mocks base method.
+func (m *MockComplianceManager) ListCases(ctx context.Context, status string, page pagepkg.Request) ([]domain.ComplianceCase, pagepkg.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCases", ctx, status, page)
+	ret0, _ := ret[0].([]domain.ComplianceCase)
+	ret1, _ := ret[1].(pagepkg.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListCases indicates an expected call of ListCases.
+func (mr *MockComplianceManagerMockRecorder) ListCases(ctx, status, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCases", reflect.TypeOf((*MockComplianceManager)(nil).ListCases), ctx, status, page)
+}
+
+// Reload mocks base method.
+func (m *MockComplianceManager) Reload(ctx context.Context, actor string) (domain.SanctionsList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reload", ctx, actor)
+	ret0, _ := ret[0].(domain.SanctionsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reload indicates an expected call of Reload.
+func (mr *MockComplianceManagerMockRecorder) Reload(ctx, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reload", reflect.TypeOf((*MockComplianceManager)(nil).Reload), ctx, actor)
+}
@@ -0,0 +1,752 @@
+package adminservice
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+)
+
+type mocks struct {
+	repo        *MockRepo
+	userRepo    *MockUserRepo
+	accountRepo *MockAccountRepo
+	entryRepo   *MockEntryRepo
+	sessions    *MockSessionRevoker
+	ledger      *MockLedgerVerifier
+	limits      *MockLimitManager
+	reviews     *MockTransferReviewer
+	compliance  *MockComplianceManager
+}
+
+func newService(t *testing.T, buildStubs func(m mocks)) *Service {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	m := mocks{
+		repo:        NewMockRepo(ctrl),
+		userRepo:    NewMockUserRepo(ctrl),
+		accountRepo: NewMockAccountRepo(ctrl),
+		entryRepo:   NewMockEntryRepo(ctrl),
+		sessions:    NewMockSessionRevoker(ctrl),
+		ledger:      NewMockLedgerVerifier(ctrl),
+		limits:      NewMockLimitManager(ctrl),
+		reviews:     NewMockTransferReviewer(ctrl),
+		compliance:  NewMockComplianceManager(ctrl),
+	}
+
+	buildStubs(m)
+
+	return New(m.repo, m.userRepo, m.accountRepo, m.entryRepo, m.sessions, m.ledger, m.limits, m.reviews, m.compliance)
+}
+
+func expectAction(repo *MockRepo, actor, action, target string) {
+	arg := domain.CreateAdminActionParams{Actor: actor, Action: action, Target: target}
+
+	repo.EXPECT().
+		CreateAction(gomock.Any(), gomock.Eq(arg)).
+		Times(1).
+		Return(domain.AdminAction{ID: 1, Actor: actor, Action: action, Target: target}, nil)
+}
+
+func TestSearchUsers(t *testing.T) {
+	actor := randompkg.Owner()
+	arg := domain.SearchUsersParams{Query: "bob", Limit: 10}
+	users := []domain.UserWihtoutPassword{{Username: "bob", FullName: "Bob", Email: "bob@example.com", Role: domain.RoleCustomer}}
+
+	testCases := []struct {
+		name       string
+		buildStubs func(m mocks)
+		want       []domain.UserWihtoutPassword
+		wantErr    error
+	}{
+		{
+			name: "OK",
+			buildStubs: func(m mocks) {
+				m.userRepo.EXPECT().Search(gomock.Any(), gomock.Eq(arg)).Times(1).Return(users, nil)
+				expectAction(m.repo, actor, domain.AdminActionSearchUsers, "query:bob")
+			},
+			want: users,
+		},
+		{
+			name: "RecordError",
+			buildStubs: func(m mocks) {
+				m.userRepo.EXPECT().Search(gomock.Any(), gomock.Eq(arg)).Times(1).Return(users, nil)
+				m.repo.EXPECT().CreateAction(gomock.Any(), gomock.Any()).Times(1).
+					Return(domain.AdminAction{}, errorspkg.ErrInternal)
+			},
+			wantErr: errorspkg.ErrInternal,
+		},
+		{
+			name: "SearchError",
+			buildStubs: func(m mocks) {
+				m.userRepo.EXPECT().Search(gomock.Any(), gomock.Any()).Times(1).Return(nil, errorspkg.ErrInternal)
+				m.repo.EXPECT().CreateAction(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: errorspkg.ErrInternal,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := newService(t, tc.buildStubs)
+
+			got, err := s.SearchUsers(context.Background(), actor, arg)
+			if err != tc.wantErr {
+				t.Fatalf("s.SearchUsers(ctx, %q, %+v) returned error: %v, want %v", actor, arg, err, tc.wantErr)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("s.SearchUsers(ctx, %q, %+v) returned unexpected difference (-want +got):\n%s", actor, arg, diff)
+			}
+		})
+	}
+}
+
+func TestListAccounts(t *testing.T) {
+	actor := randompkg.Owner()
+	owner := randompkg.Owner()
+	accounts := []domain.Account{helpers.RandomAccount(owner)}
+	page := pagepkg.Request{PageID: 1, PageSize: 5}
+
+	s := newService(t, func(m mocks) {
+		arg := domain.ListAccountsParams{Owner: owner, Limit: 6}
+
+		m.accountRepo.EXPECT().List(gomock.Any(), gomock.Eq(arg)).Times(1).Return(accounts, nil)
+		expectAction(m.repo, actor, domain.AdminActionListAccounts, "user:"+owner)
+	})
+
+	got, _, err := s.ListAccounts(context.Background(), actor, owner, page)
+	if err != nil {
+		t.Fatalf("s.ListAccounts(ctx, %q, %q, %+v) returned error: %v", actor, owner, page, err)
+	}
+
+	if diff := cmp.Diff(accounts, got); diff != "" {
+		t.Errorf("s.ListAccounts(ctx, %q, %q, %+v) returned unexpected difference (-want +got):\n%s", actor, owner, page, diff)
+	}
+}
+
+func TestAccountActions(t *testing.T) {
+	actor := randompkg.Owner()
+	account := helpers.RandomAccount(randompkg.Owner())
+	target := fmt.Sprintf("account:%d", account.ID)
+
+	now := time.Now().UTC()
+	frozen := account
+	frozen.FrozenAt = &now
+
+	testCases := []struct {
+		name       string
+		call       func(s *Service) (domain.Account, error)
+		buildStubs func(m mocks)
+		want       domain.Account
+		wantErr    error
+	}{
+		{
+			name: "GetAccount",
+			call: func(s *Service) (domain.Account, error) {
+				return s.GetAccount(context.Background(), actor, account.ID)
+			},
+			buildStubs: func(m mocks) {
+				m.accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				expectAction(m.repo, actor, domain.AdminActionViewAccount, target)
+			},
+			want: account,
+		},
+		{
+			name: "GetAccountNotFound",
+			call: func(s *Service) (domain.Account, error) {
+				return s.GetAccount(context.Background(), actor, account.ID)
+			},
+			buildStubs: func(m mocks) {
+				m.accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(account.ID)).Times(1).
+					Return(domain.Account{}, domain.ErrAccountNotFound)
+				m.repo.EXPECT().CreateAction(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrAccountNotFound,
+		},
+		{
+			name: "FreezeAccount",
+			call: func(s *Service) (domain.Account, error) {
+				return s.FreezeAccount(context.Background(), actor, account.ID)
+			},
+			buildStubs: func(m mocks) {
+				m.accountRepo.EXPECT().Freeze(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(frozen, nil)
+				expectAction(m.repo, actor, domain.AdminActionFreezeAccount, target)
+			},
+			want: frozen,
+		},
+		{
+			name: "FreezeAccountNotFound",
+			call: func(s *Service) (domain.Account, error) {
+				return s.FreezeAccount(context.Background(), actor, account.ID)
+			},
+			buildStubs: func(m mocks) {
+				m.accountRepo.EXPECT().Freeze(gomock.Any(), gomock.Eq(account.ID)).Times(1).
+					Return(domain.Account{}, domain.ErrAccountNotFound)
+				m.repo.EXPECT().CreateAction(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrAccountNotFound,
+		},
+		{
+			name: "UnfreezeAccount",
+			call: func(s *Service) (domain.Account, error) {
+				return s.UnfreezeAccount(context.Background(), actor, account.ID)
+			},
+			buildStubs: func(m mocks) {
+				m.accountRepo.EXPECT().Unfreeze(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				expectAction(m.repo, actor, domain.AdminActionUnfreezeAccount, target)
+			},
+			want: account,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := newService(t, tc.buildStubs)
+
+			got, err := tc.call(s)
+			if err != tc.wantErr {
+				t.Fatalf("returned error: %v, want %v", err, tc.wantErr)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("returned unexpected difference (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestListEntries(t *testing.T) {
+	actor := randompkg.Owner()
+	account := helpers.RandomAccount(randompkg.Owner())
+	now := time.Now().UTC().Truncate(time.Second)
+	lines := []domain.StatementLine{
+		{Entry: domain.Entry{ID: 1, AccountID: account.ID, Amount: "100", CreatedAt: now}, Balance: "1100"},
+	}
+	page := pagepkg.Request{PageID: 1, PageSize: 5}
+
+	testCases := []struct {
+		name       string
+		arg        domain.ListEntriesParams
+		buildStubs func(m mocks)
+		want       []domain.StatementLine
+		wantErr    error
+	}{
+		{
+			name: "OK",
+			arg:  domain.ListEntriesParams{AccountID: account.ID},
+			buildStubs: func(m mocks) {
+				arg := domain.ListEntriesParams{AccountID: account.ID, Limit: 6}
+
+				m.accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				m.entryRepo.EXPECT().List(gomock.Any(), gomock.Eq(arg)).Times(1).Return(lines, nil)
+				expectAction(m.repo, actor, domain.AdminActionViewEntries, fmt.Sprintf("account:%d", account.ID))
+			},
+			want: lines,
+		},
+		{
+			name: "ErrInvalidDateRange",
+			arg:  domain.ListEntriesParams{AccountID: account.ID, StartTime: now, EndTime: now.Add(-time.Hour)},
+			buildStubs: func(m mocks) {
+				m.accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				m.entryRepo.EXPECT().List(gomock.Any(), gomock.Any()).Times(0)
+				m.repo.EXPECT().CreateAction(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrInvalidDateRange,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := newService(t, tc.buildStubs)
+
+			got, _, err := s.ListEntries(context.Background(), actor, tc.arg, page)
+			if err != tc.wantErr {
+				t.Fatalf("s.ListEntries(ctx, %q, %+v, %+v) returned error: %v, want %v", actor, tc.arg, page, err, tc.wantErr)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("s.ListEntries(ctx, %q, %+v, %+v) returned unexpected difference (-want +got):\n%s", actor, tc.arg, page, diff)
+			}
+		})
+	}
+}
+
+func TestBlockSessions(t *testing.T) {
+	actor := randompkg.Owner()
+	username := randompkg.Owner()
+
+	testCases := []struct {
+		name       string
+		buildStubs func(m mocks)
+		want       int64
+		wantErr    error
+	}{
+		{
+			name: "OK",
+			buildStubs: func(m mocks) {
+				m.userRepo.EXPECT().Get(gomock.Any(), gomock.Eq(username)).Times(1).Return(domain.User{Username: username}, nil)
+				m.sessions.EXPECT().RevokeAll(gomock.Any(), gomock.Eq(username)).Times(1).Return(int64(2), nil)
+				expectAction(m.repo, actor, domain.AdminActionBlockSessions, "user:"+username)
+			},
+			want: 2,
+		},
+		{
+			name: "ErrUserNotFound",
+			buildStubs: func(m mocks) {
+				m.userRepo.EXPECT().Get(gomock.Any(), gomock.Eq(username)).Times(1).Return(domain.User{}, domain.ErrUserNotFound)
+				m.sessions.EXPECT().RevokeAll(gomock.Any(), gomock.Any()).Times(0)
+				m.repo.EXPECT().CreateAction(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name: "RevokeError",
+			buildStubs: func(m mocks) {
+				m.userRepo.EXPECT().Get(gomock.Any(), gomock.Eq(username)).Times(1).Return(domain.User{Username: username}, nil)
+				m.sessions.EXPECT().RevokeAll(gomock.Any(), gomock.Eq(username)).Times(1).Return(int64(0), errorspkg.ErrInternal)
+				m.repo.EXPECT().CreateAction(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: errorspkg.ErrInternal,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := newService(t, tc.buildStubs)
+
+			got, err := s.BlockSessions(context.Background(), actor, username)
+			if err != tc.wantErr {
+				t.Fatalf("s.BlockSessions(ctx, %q, %q) returned error: %v, want %v", actor, username, err, tc.wantErr)
+			}
+
+			if got != tc.want {
+				t.Errorf("s.BlockSessions(ctx, %q, %q) = %v, want %v", actor, username, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestVerifyLedger(t *testing.T) {
+	actor := randompkg.Owner()
+	report := domain.ChainReport{
+		Accounts: 1,
+		Entries:  3,
+		Breaks:   []domain.ChainBreak{{AccountID: 7, EntryID: 2, Reason: domain.ChainBreakHashMismatch}},
+	}
+
+	testCases := []struct {
+		name       string
+		accountID  int32
+		buildStubs func(m mocks)
+		want       domain.ChainReport
+		wantErr    error
+	}{
+		{
+			name:      "Account",
+			accountID: 7,
+			buildStubs: func(m mocks) {
+				m.ledger.EXPECT().VerifyChain(gomock.Any(), gomock.Eq(int32(7))).Times(1).Return(report, nil)
+				expectAction(m.repo, actor, domain.AdminActionVerifyLedger, "account:7")
+			},
+			want: report,
+		},
+		{
+			name: "AllAccounts",
+			buildStubs: func(m mocks) {
+				m.ledger.EXPECT().VerifyChain(gomock.Any(), gomock.Eq(int32(0))).Times(1).Return(report, nil)
+				expectAction(m.repo, actor, domain.AdminActionVerifyLedger, "ledger")
+			},
+			want: report,
+		},
+		{
+			name:      "ErrAccountNotFound",
+			accountID: 7,
+			buildStubs: func(m mocks) {
+				m.ledger.EXPECT().VerifyChain(gomock.Any(), gomock.Any()).Times(1).Return(domain.ChainReport{}, domain.ErrAccountNotFound)
+				m.repo.EXPECT().CreateAction(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrAccountNotFound,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := newService(t, tc.buildStubs)
+
+			got, err := s.VerifyLedger(context.Background(), actor, tc.accountID)
+			if err != tc.wantErr {
+				t.Fatalf("s.VerifyLedger(ctx, %q, %d) returned error: %v, want %v", actor, tc.accountID, err, tc.wantErr)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("s.VerifyLedger(ctx, %q, %d) returned unexpected difference (-want +got):\n%s", actor, tc.accountID, diff)
+			}
+		})
+	}
+}
+
+func TestTransferLimits(t *testing.T) {
+	actor := randompkg.Owner()
+	username := randompkg.Owner()
+	limit := domain.TransferLimit{Username: username, Currency: "USD", Daily: "1000"}
+
+	t.Run("List", func(t *testing.T) {
+		t.Parallel()
+
+		s := newService(t, func(m mocks) {
+			m.userRepo.EXPECT().Get(gomock.Any(), gomock.Eq(username)).Times(1).Return(domain.User{Username: username}, nil)
+			m.limits.EXPECT().List(gomock.Any(), gomock.Eq(username)).Times(1).Return([]domain.TransferLimit{limit}, nil)
+			expectAction(m.repo, actor, domain.AdminActionViewTransferLimits, "user:"+username)
+		})
+
+		got, err := s.ListTransferLimits(context.Background(), actor, username)
+		if err != nil {
+			t.Fatalf("s.ListTransferLimits(ctx, %q, %q) returned error: %v", actor, username, err)
+		}
+
+		if diff := cmp.Diff([]domain.TransferLimit{limit}, got); diff != "" {
+			t.Errorf("s.ListTransferLimits(ctx, %q, %q) returned unexpected difference (-want +got):\n%s", actor, username, diff)
+		}
+	})
+
+	t.Run("ListErrUserNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		s := newService(t, func(m mocks) {
+			m.userRepo.EXPECT().Get(gomock.Any(), gomock.Eq(username)).Times(1).Return(domain.User{}, domain.ErrUserNotFound)
+			m.limits.EXPECT().List(gomock.Any(), gomock.Any()).Times(0)
+			m.repo.EXPECT().CreateAction(gomock.Any(), gomock.Any()).Times(0)
+		})
+
+		if _, err := s.ListTransferLimits(context.Background(), actor, username); err != domain.ErrUserNotFound {
+			t.Errorf("s.ListTransferLimits(ctx, %q, %q) returned error: %v, want %v", actor, username, err, domain.ErrUserNotFound)
+		}
+	})
+
+	t.Run("Set", func(t *testing.T) {
+		t.Parallel()
+
+		s := newService(t, func(m mocks) {
+			m.limits.EXPECT().Set(gomock.Any(), gomock.Eq(limit)).Times(1).Return(limit, nil)
+			expectAction(m.repo, actor, domain.AdminActionSetTransferLimit, "user:"+username)
+		})
+
+		got, err := s.SetTransferLimit(context.Background(), actor, limit)
+		if err != nil {
+			t.Fatalf("s.SetTransferLimit(ctx, %q, %+v) returned error: %v", actor, limit, err)
+		}
+
+		if got != limit {
+			t.Errorf("s.SetTransferLimit(ctx, %q, %+v) = %+v, want %+v", actor, limit, got, limit)
+		}
+	})
+
+	t.Run("SetErrInvalidTransferLimit", func(t *testing.T) {
+		t.Parallel()
+
+		s := newService(t, func(m mocks) {
+			m.limits.EXPECT().Set(gomock.Any(), gomock.Any()).Times(1).Return(domain.TransferLimit{}, domain.ErrInvalidTransferLimit)
+			m.repo.EXPECT().CreateAction(gomock.Any(), gomock.Any()).Times(0)
+		})
+
+		if _, err := s.SetTransferLimit(context.Background(), actor, limit); err != domain.ErrInvalidTransferLimit {
+			t.Errorf("s.SetTransferLimit(ctx, %q, %+v) returned error: %v, want %v", actor, limit, err, domain.ErrInvalidTransferLimit)
+		}
+	})
+
+	// Resetting a user without an override succeeds, so the reset can be retried.
+	for name, resetErr := range map[string]error{"Reset": nil, "ResetNoOverride": domain.ErrTransferLimitNotFound} {
+		resetErr := resetErr
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s := newService(t, func(m mocks) {
+				m.userRepo.EXPECT().Get(gomock.Any(), gomock.Eq(username)).Times(1).Return(domain.User{Username: username}, nil)
+				m.limits.EXPECT().Reset(gomock.Any(), gomock.Eq(username), gomock.Eq("USD")).Times(1).Return(resetErr)
+				expectAction(m.repo, actor, domain.AdminActionResetTransferLimit, "user:"+username)
+			})
+
+			if err := s.ResetTransferLimit(context.Background(), actor, username, "USD"); err != nil {
+				t.Errorf("s.ResetTransferLimit(ctx, %q, %q, USD) returned error: %v", actor, username, err)
+			}
+		})
+	}
+}
+
+func TestTransferReviews(t *testing.T) {
+	actor := randompkg.Owner()
+	pending := domain.TransferReview{ID: 1, Amount: "100", Status: domain.TransferReviewStatusPending}
+	approved := domain.ApproveTransferReviewResult{
+		Review:   domain.TransferReview{ID: 1, Amount: "100", Status: domain.TransferReviewStatusApproved, Reviewer: actor, TransferID: 2},
+		Transfer: domain.TransferTxResult{Transfer: domain.Transfer{ID: 2}},
+	}
+	rejected := domain.TransferReview{ID: 1, Amount: "100", Status: domain.TransferReviewStatusRejected, Reviewer: actor}
+
+	t.Run("List", func(t *testing.T) {
+		t.Parallel()
+
+		page := pagepkg.Request{PageID: 1, PageSize: 5}
+
+		s := newService(t, func(m mocks) {
+			m.reviews.EXPECT().ListReviews(gomock.Any(), gomock.Eq(domain.TransferReviewStatusPending), gomock.Eq(page)).
+				Times(1).
+				Return([]domain.TransferReview{pending}, pagepkg.Page{}, nil)
+			expectAction(m.repo, actor, domain.AdminActionListTransferReviews, "status:"+domain.TransferReviewStatusPending)
+		})
+
+		got, _, err := s.ListTransferReviews(context.Background(), actor, domain.TransferReviewStatusPending, page)
+		if err != nil {
+			t.Fatalf("s.ListTransferReviews(ctx, %q, pending, %+v) returned error: %v", actor, page, err)
+		}
+
+		if diff := cmp.Diff([]domain.TransferReview{pending}, got); diff != "" {
+			t.Errorf("s.ListTransferReviews(ctx, %q, pending, %+v) returned unexpected difference (-want +got):\n%s", actor, page, diff)
+		}
+	})
+
+	t.Run("Approve", func(t *testing.T) {
+		t.Parallel()
+
+		s := newService(t, func(m mocks) {
+			m.reviews.EXPECT().ApproveReview(gomock.Any(), gomock.Eq(actor), gomock.Eq(int64(1))).Times(1).Return(approved, nil)
+			expectAction(m.repo, actor, domain.AdminActionApproveTransferReview, "transfer_review:1")
+		})
+
+		got, err := s.ApproveTransferReview(context.Background(), actor, 1)
+		if err != nil {
+			t.Fatalf("s.ApproveTransferReview(ctx, %q, 1) returned error: %v", actor, err)
+		}
+
+		if diff := cmp.Diff(approved, got); diff != "" {
+			t.Errorf("s.ApproveTransferReview(ctx, %q, 1) returned unexpected difference (-want +got):\n%s", actor, diff)
+		}
+	})
+
+	// A retried approval succeeds once the review has been approved.
+	t.Run("ApproveApproved", func(t *testing.T) {
+		t.Parallel()
+
+		s := newService(t, func(m mocks) {
+			m.reviews.EXPECT().ApproveReview(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(domain.ApproveTransferReviewResult{}, domain.ErrTransferReviewClosed)
+			m.reviews.EXPECT().GetReview(gomock.Any(), gomock.Eq(int64(1))).Times(1).Return(approved.Review, nil)
+			expectAction(m.repo, actor, domain.AdminActionApproveTransferReview, "transfer_review:1")
+		})
+
+		got, err := s.ApproveTransferReview(context.Background(), actor, 1)
+		if err != nil {
+			t.Fatalf("s.ApproveTransferReview(ctx, %q, 1) returned error: %v", actor, err)
+		}
+
+		if diff := cmp.Diff(domain.ApproveTransferReviewResult{Review: approved.Review}, got); diff != "" {
+			t.Errorf("s.ApproveTransferReview(ctx, %q, 1) returned unexpected difference (-want +got):\n%s", actor, diff)
+		}
+	})
+
+	t.Run("ApproveRejected", func(t *testing.T) {
+		t.Parallel()
+
+		s := newService(t, func(m mocks) {
+			m.reviews.EXPECT().ApproveReview(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(domain.ApproveTransferReviewResult{}, domain.ErrTransferReviewClosed)
+			m.reviews.EXPECT().GetReview(gomock.Any(), gomock.Eq(int64(1))).Times(1).Return(rejected, nil)
+			m.repo.EXPECT().CreateAction(gomock.Any(), gomock.Any()).Times(0)
+		})
+
+		if _, err := s.ApproveTransferReview(context.Background(), actor, 1); err != domain.ErrTransferReviewClosed {
+			t.Errorf("s.ApproveTransferReview(ctx, %q, 1) returned error: %v, want %v", actor, err, domain.ErrTransferReviewClosed)
+		}
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		t.Parallel()
+
+		s := newService(t, func(m mocks) {
+			m.reviews.EXPECT().RejectReview(gomock.Any(), gomock.Eq(actor), gomock.Eq(int64(1))).Times(1).Return(rejected, nil)
+			expectAction(m.repo, actor, domain.AdminActionRejectTransferReview, "transfer_review:1")
+		})
+
+		got, err := s.RejectTransferReview(context.Background(), actor, 1)
+		if err != nil {
+			t.Fatalf("s.RejectTransferReview(ctx, %q, 1) returned error: %v", actor, err)
+		}
+
+		if diff := cmp.Diff(rejected, got); diff != "" {
+			t.Errorf("s.RejectTransferReview(ctx, %q, 1) returned unexpected difference (-want +got):\n%s", actor, diff)
+		}
+	})
+
+	t.Run("RejectErrTransferReviewNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		s := newService(t, func(m mocks) {
+			m.reviews.EXPECT().RejectReview(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(domain.TransferReview{}, domain.ErrTransferReviewNotFound)
+			m.repo.EXPECT().CreateAction(gomock.Any(), gomock.Any()).Times(0)
+		})
+
+		if _, err := s.RejectTransferReview(context.Background(), actor, 1); err != domain.ErrTransferReviewNotFound {
+			t.Errorf("s.RejectTransferReview(ctx, %q, 1) returned error: %v, want %v", actor, err, domain.ErrTransferReviewNotFound)
+		}
+	})
+}
+
+func TestComplianceCases(t *testing.T) {
+	actor := randompkg.Owner()
+	open := domain.ComplianceCase{ID: 1, Action: domain.ScreeningActionUserCreate, Status: domain.ComplianceCaseStatusOpen}
+	cleared := domain.ComplianceCase{ID: 1, Action: domain.ScreeningActionUserCreate, Status: domain.ComplianceCaseStatusCleared, Reviewer: actor}
+	confirmed := domain.ComplianceCase{ID: 1, Action: domain.ScreeningActionUserCreate, Status: domain.ComplianceCaseStatusConfirmed, Reviewer: actor}
+
+	t.Run("List", func(t *testing.T) {
+		t.Parallel()
+
+		page := pagepkg.Request{PageID: 1, PageSize: 5}
+
+		s := newService(t, func(m mocks) {
+			m.compliance.EXPECT().ListCases(gomock.Any(), gomock.Eq(domain.ComplianceCaseStatusOpen), gomock.Eq(page)).
+				Times(1).
+				Return([]domain.ComplianceCase{open}, pagepkg.Page{}, nil)
+			expectAction(m.repo, actor, domain.AdminActionListComplianceCases, "status:"+domain.ComplianceCaseStatusOpen)
+		})
+
+		got, _, err := s.ListComplianceCases(context.Background(), actor, domain.ComplianceCaseStatusOpen, page)
+		if err != nil {
+			t.Fatalf("s.ListComplianceCases(ctx, %q, open, %+v) returned error: %v", actor, page, err)
+		}
+
+		if diff := cmp.Diff([]domain.ComplianceCase{open}, got); diff != "" {
+			t.Errorf("s.ListComplianceCases(ctx, %q, open, %+v) returned unexpected difference (-want +got):\n%s", actor, page, diff)
+		}
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		t.Parallel()
+
+		s := newService(t, func(m mocks) {
+			m.compliance.EXPECT().CloseCase(gomock.Any(), gomock.Eq(actor), gomock.Eq(int64(1)), gomock.Eq(domain.ComplianceCaseStatusCleared)).
+				Times(1).
+				Return(cleared, nil)
+			expectAction(m.repo, actor, domain.AdminActionClearComplianceCase, "compliance_case:1")
+		})
+
+		got, err := s.ClearComplianceCase(context.Background(), actor, 1)
+		if err != nil {
+			t.Fatalf("s.ClearComplianceCase(ctx, %q, 1) returned error: %v", actor, err)
+		}
+
+		if diff := cmp.Diff(cleared, got); diff != "" {
+			t.Errorf("s.ClearComplianceCase(ctx, %q, 1) returned unexpected difference (-want +got):\n%s", actor, diff)
+		}
+	})
+
+	// A retried confirmation succeeds once the case has been confirmed.
+	t.Run("ConfirmConfirmed", func(t *testing.T) {
+		t.Parallel()
+
+		s := newService(t, func(m mocks) {
+			m.compliance.EXPECT().CloseCase(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(domain.ComplianceCase{}, domain.ErrComplianceCaseClosed)
+			m.compliance.EXPECT().GetCase(gomock.Any(), gomock.Eq(int64(1))).Times(1).Return(confirmed, nil)
+			expectAction(m.repo, actor, domain.AdminActionConfirmComplianceCase, "compliance_case:1")
+		})
+
+		got, err := s.ConfirmComplianceCase(context.Background(), actor, 1)
+		if err != nil {
+			t.Fatalf("s.ConfirmComplianceCase(ctx, %q, 1) returned error: %v", actor, err)
+		}
+
+		if diff := cmp.Diff(confirmed, got); diff != "" {
+			t.Errorf("s.ConfirmComplianceCase(ctx, %q, 1) returned unexpected difference (-want +got):\n%s", actor, diff)
+		}
+	})
+
+	t.Run("ConfirmCleared", func(t *testing.T) {
+		t.Parallel()
+
+		s := newService(t, func(m mocks) {
+			m.compliance.EXPECT().CloseCase(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(domain.ComplianceCase{}, domain.ErrComplianceCaseClosed)
+			m.compliance.EXPECT().GetCase(gomock.Any(), gomock.Eq(int64(1))).Times(1).Return(cleared, nil)
+			m.repo.EXPECT().CreateAction(gomock.Any(), gomock.Any()).Times(0)
+		})
+
+		if _, err := s.ConfirmComplianceCase(context.Background(), actor, 1); err != domain.ErrComplianceCaseClosed {
+			t.Errorf("s.ConfirmComplianceCase(ctx, %q, 1) returned error: %v, want %v", actor, err, domain.ErrComplianceCaseClosed)
+		}
+	})
+
+	t.Run("ClearErrComplianceCaseNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		s := newService(t, func(m mocks) {
+			m.compliance.EXPECT().CloseCase(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(domain.ComplianceCase{}, domain.ErrComplianceCaseClosed)
+			m.compliance.EXPECT().GetCase(gomock.Any(), gomock.Eq(int64(1))).
+				Times(1).
+				Return(domain.ComplianceCase{}, domain.ErrComplianceCaseNotFound)
+			m.repo.EXPECT().CreateAction(gomock.Any(), gomock.Any()).Times(0)
+		})
+
+		if _, err := s.ClearComplianceCase(context.Background(), actor, 1); err != domain.ErrComplianceCaseNotFound {
+			t.Errorf("s.ClearComplianceCase(ctx, %q, 1) returned error: %v, want %v", actor, err, domain.ErrComplianceCaseNotFound)
+		}
+	})
+
+	t.Run("ReloadSanctionsList", func(t *testing.T) {
+		t.Parallel()
+
+		list := domain.SanctionsList{File: "sdn.csv", Entries: 2}
+
+		s := newService(t, func(m mocks) {
+			m.compliance.EXPECT().Reload(gomock.Any(), gomock.Eq(actor)).Times(1).Return(list, nil)
+			expectAction(m.repo, actor, domain.AdminActionReloadSanctionsList, "sanctions_list")
+		})
+
+		got, err := s.ReloadSanctionsList(context.Background(), actor)
+		if err != nil {
+			t.Fatalf("s.ReloadSanctionsList(ctx, %q) returned error: %v", actor, err)
+		}
+
+		if diff := cmp.Diff(list, got); diff != "" {
+			t.Errorf("s.ReloadSanctionsList(ctx, %q) returned unexpected difference (-want +got):\n%s", actor, diff)
+		}
+	})
+}
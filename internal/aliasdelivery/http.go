@@ -0,0 +1,144 @@
+// Package aliasdelivery manages delivery layer of user aliases.
+package aliasdelivery
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/middleware"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/tokenpkg"
+	"github.com/go-petr/pet-bank/pkg/web"
+)
+
+// Service provides service layer interface needed by alias delivery layer.
+//
+//go:generate mockgen -source http.go -destination http_mock.go -package aliasdelivery
+type Service interface {
+	Create(ctx context.Context, username, kind, value string) (domain.Alias, error)
+	List(ctx context.Context, username string) ([]domain.Alias, error)
+	Delete(ctx context.Context, username string, id int64) error
+}
+
+// Handler facilitates alias delivery layer logic.
+type Handler struct {
+	service Service
+}
+
+// NewHandler returns alias handler.
+func NewHandler(as Service) *Handler {
+	return &Handler{
+		service: as,
+	}
+}
+
+func (h *Handler) serviceError(gctx *gin.Context, err error) {
+	zerolog.Ctx(gctx.Request.Context()).Info().Err(err).Send()
+
+	switch err {
+	case domain.ErrInvalidAlias:
+		gctx.JSON(http.StatusBadRequest, web.Error(err))
+		return
+	case domain.ErrAliasNotFound:
+		gctx.JSON(http.StatusNotFound, web.Error(err))
+		return
+	case domain.ErrAliasAlreadyExists:
+		gctx.JSON(http.StatusConflict, web.Error(err))
+		return
+	}
+
+	gctx.JSON(http.StatusInternalServerError, web.Error(errorspkg.ErrInternal))
+}
+
+func (h *Handler) bindError(gctx *gin.Context, err error) {
+	zerolog.Ctx(gctx.Request.Context()).Info().Err(err).Send()
+
+	var ve validator.ValidationErrors
+	if errors.As(err, &ve) {
+		gctx.JSON(http.StatusBadRequest, web.Response{Error: web.GetErrorMsg(ve)})
+
+		return
+	}
+
+	gctx.JSON(http.StatusBadRequest, web.Error(err))
+}
+
+type aliasResponse struct {
+	Alias domain.Alias `json:"alias"`
+}
+
+type createRequest struct {
+	Kind  string `json:"kind" binding:"required,oneof=phone handle"`
+	Value string `json:"value" binding:"required,max=64"`
+}
+
+// Create handles http request to register the phone or handle alias of the
+// user.
+func (h *Handler) Create(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	var req createRequest
+	if err := gctx.ShouldBindJSON(&req); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	alias, err := h.service.Create(ctx, authPayload.Username, req.Kind, req.Value)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	gctx.JSON(http.StatusCreated, web.Response{Data: aliasResponse{Alias: alias}})
+}
+
+type listResponse struct {
+	Aliases []domain.Alias `json:"aliases"`
+}
+
+// List handles http request to list the aliases of the user.
+func (h *Handler) List(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	aliases, err := h.service.List(ctx, authPayload.Username)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	gctx.JSON(http.StatusOK, web.Response{Data: listResponse{Aliases: aliases}})
+}
+
+type idRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// Delete handles http request to delete the alias of the user.
+func (h *Handler) Delete(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	var req idRequest
+	if err := gctx.ShouldBindUri(&req); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	if err := h.service.Delete(ctx, authPayload.Username, req.ID); err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	gctx.Status(http.StatusNoContent)
+}
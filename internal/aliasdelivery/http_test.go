@@ -0,0 +1,267 @@
+package aliasdelivery
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/middleware"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/go-petr/pet-bank/pkg/tokenpkg"
+	"github.com/go-petr/pet-bank/pkg/web"
+)
+
+func newServer(t *testing.T, tokenMaker tokenpkg.Maker, handler *Handler) *gin.Engine {
+	t.Helper()
+
+	gin.SetMode(gin.ReleaseMode)
+	server := gin.New()
+	server.Use(middleware.AuthMiddleware(tokenMaker, nil))
+	server.POST("/aliases", handler.Create)
+	server.GET("/aliases", handler.List)
+	server.DELETE("/aliases/:id", handler.Delete)
+
+	return server
+}
+
+func TestHandler(t *testing.T) {
+	username := randompkg.Owner()
+	symmetricKey := randompkg.String(32)
+
+	tokenMaker, err := tokenpkg.NewPasetoMaker(symmetricKey)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(%v) returned error: %v", symmetricKey, err)
+	}
+
+	alias := domain.Alias{
+		ID:        1,
+		Username:  username,
+		Kind:      domain.AliasKindHandle,
+		Value:     "alice",
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           any
+		buildStubs     func(service *MockService)
+		wantStatusCode int
+		wantError      string
+	}{
+		{
+			name:   "Create",
+			method: http.MethodPost,
+			url:    "/aliases",
+			body:   gin.H{"kind": "handle", "value": "@Alice"},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Eq(username), "handle", "@Alice").Times(1).Return(alias, nil)
+			},
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name:   "CreateInvalidKind",
+			method: http.MethodPost,
+			url:    "/aliases",
+			body:   gin.H{"kind": "email", "value": "alice@example.com"},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "Kind must be one of: phone handle",
+		},
+		{
+			name:   "CreateRequiresValue",
+			method: http.MethodPost,
+			url:    "/aliases",
+			body:   gin.H{"kind": "phone"},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "Value field is required",
+		},
+		{
+			name:   "CreateErrInvalidAlias",
+			method: http.MethodPost,
+			url:    "/aliases",
+			body:   gin.H{"kind": "phone", "value": "12"},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.Alias{}, domain.ErrInvalidAlias)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      domain.ErrInvalidAlias.Error(),
+		},
+		{
+			name:   "CreateErrAliasAlreadyExists",
+			method: http.MethodPost,
+			url:    "/aliases",
+			body:   gin.H{"kind": "handle", "value": "alice"},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.Alias{}, domain.ErrAliasAlreadyExists)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantError:      domain.ErrAliasAlreadyExists.Error(),
+		},
+		{
+			name:   "Delete",
+			method: http.MethodDelete,
+			url:    "/aliases/1",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Delete(gomock.Any(), gomock.Eq(username), gomock.Eq(int64(1))).Times(1).Return(nil)
+			},
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name:   "DeleteErrAliasNotFound",
+			method: http.MethodDelete,
+			url:    "/aliases/1",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(domain.ErrAliasNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      domain.ErrAliasNotFound.Error(),
+		},
+		{
+			name:   "DeleteErrInternal",
+			method: http.MethodDelete,
+			url:    "/aliases/1",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(errorspkg.ErrInternal)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      errorspkg.ErrInternal.Error(),
+		},
+		{
+			name:   "DeleteInvalidID",
+			method: http.MethodDelete,
+			url:    "/aliases/0",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "ID field is required",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewMockService(ctrl)
+			tc.buildStubs(service)
+			server := newServer(t, tokenMaker, NewHandler(service))
+
+			var body []byte
+			if tc.body != nil {
+				if body, err = json.Marshal(tc.body); err != nil {
+					t.Fatalf("Encoding request body error: %v", err)
+				}
+			}
+
+			req, err := http.NewRequest(tc.method, tc.url, bytes.NewReader(body))
+			if err != nil {
+				t.Fatalf("Creating request error: %v", err)
+			}
+
+			if err := middleware.AddAuthorization(req, tokenMaker, middleware.AuthTypeBearer, username, time.Minute); err != nil {
+				t.Fatalf("middleware.AddAuthorization(...) returned error: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			if got := w.Code; got != tc.wantStatusCode {
+				t.Errorf("Status code: got %v, want %v", got, tc.wantStatusCode)
+			}
+
+			if w.Code == http.StatusNoContent {
+				return
+			}
+
+			data := &aliasResponse{}
+			res := web.Response{Data: data}
+
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Fatalf("Decoding response body error: %v", err)
+			}
+
+			if res.Error != tc.wantError {
+				t.Errorf(`res.Error=%q, want %q`, res.Error, tc.wantError)
+			}
+
+			if tc.wantError == "" {
+				if diff := cmp.Diff(alias, data.Alias); diff != "" {
+					t.Errorf("Response returned unexpected diff: %s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	username := randompkg.Owner()
+	symmetricKey := randompkg.String(32)
+
+	tokenMaker, err := tokenpkg.NewPasetoMaker(symmetricKey)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(%v) returned error: %v", symmetricKey, err)
+	}
+
+	aliases := []domain.Alias{
+		{ID: 1, Username: username, Kind: domain.AliasKindPhone, Value: "+15550102030"},
+		{ID: 2, Username: username, Kind: domain.AliasKindHandle, Value: "alice"},
+	}
+
+	ctrl := gomock.NewController(t)
+	service := NewMockService(ctrl)
+	service.EXPECT().List(gomock.Any(), gomock.Eq(username)).Times(1).Return(aliases, nil)
+
+	server := newServer(t, tokenMaker, NewHandler(service))
+
+	req, err := http.NewRequest(http.MethodGet, "/aliases", nil)
+	if err != nil {
+		t.Fatalf("Creating request error: %v", err)
+	}
+
+	if err := middleware.AddAuthorization(req, tokenMaker, middleware.AuthTypeBearer, username, time.Minute); err != nil {
+		t.Fatalf("middleware.AddAuthorization(...) returned error: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Status code: got %v, want %v", w.Code, http.StatusOK)
+	}
+
+	data := &listResponse{}
+	res := web.Response{Data: data}
+
+	if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+		t.Fatalf("Decoding response body error: %v", err)
+	}
+
+	if diff := cmp.Diff(aliases, data.Aliases); diff != "" {
+		t.Errorf("Response returned unexpected diff: %s", diff)
+	}
+}
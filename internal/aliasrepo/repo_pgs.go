@@ -0,0 +1,216 @@
+// Package aliasrepo manages repository layer of user aliases.
+package aliasrepo
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/dbpkg"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog"
+)
+
+// RepoPGS facilitates alias repository layer logic.
+type RepoPGS struct {
+	db dbpkg.SQLInterface
+}
+
+// NewRepoPGS returns alias RepoPGS.
+func NewRepoPGS(db dbpkg.SQLInterface) *RepoPGS {
+	return &RepoPGS{
+		db: db,
+	}
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAlias(row scanner) (domain.Alias, error) {
+	var a domain.Alias
+
+	err := row.Scan(&a.ID, &a.Username, &a.Kind, &a.Value, &a.CreatedAt)
+
+	return a, err
+}
+
+const createQuery = `
+INSERT INTO user_aliases (username, kind, value)
+VALUES ($1, $2, $3)
+RETURNING id, username, kind, value, created_at
+`
+
+// Create registers the alias and then returns it.
+func (r *RepoPGS) Create(ctx context.Context, arg domain.CreateAliasParams) (domain.Alias, error) {
+	l := zerolog.Ctx(ctx)
+
+	a, err := scanAlias(r.db.QueryRowContext(ctx, createQuery, arg.Username, arg.Kind, arg.Value))
+	if err != nil {
+		l.Error().Err(err).Send()
+
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Constraint {
+			case "user_aliases_kind_value_key":
+				return a, domain.ErrAliasAlreadyExists
+			case "user_aliases_username_fkey":
+				return a, domain.ErrUserNotFound
+			case "user_aliases_kind_check":
+				return a, domain.ErrInvalidAlias
+			}
+		}
+
+		return a, errorspkg.ErrInternal
+	}
+
+	return a, nil
+}
+
+const listQuery = `
+SELECT id, username, kind, value, created_at
+FROM user_aliases
+WHERE username = $1
+ORDER BY id
+`
+
+// List returns all the aliases of the user ordered by id.
+func (r *RepoPGS) List(ctx context.Context, username string) ([]domain.Alias, error) {
+	l := zerolog.Ctx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, listQuery, username)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+	defer rows.Close()
+
+	items := []domain.Alias{}
+
+	for rows.Next() {
+		a, err := scanAlias(rows)
+		if err != nil {
+			l.Error().Err(err).Send()
+			return nil, errorspkg.ErrInternal
+		}
+
+		items = append(items, a)
+	}
+
+	if err := rows.Close(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	if err := rows.Err(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	return items, nil
+}
+
+const deleteQuery = `
+DELETE FROM user_aliases
+WHERE id = $1 AND username = $2
+RETURNING id, username, kind, value, created_at
+`
+
+// Delete deletes the user's alias with the given id and returns it.
+func (r *RepoPGS) Delete(ctx context.Context, username string, id int64) (domain.Alias, error) {
+	l := zerolog.Ctx(ctx)
+
+	a, err := scanAlias(r.db.QueryRowContext(ctx, deleteQuery, id, username))
+	if err != nil {
+		l.Error().Err(err).Send()
+
+		if err == sql.ErrNoRows {
+			return a, domain.ErrAliasNotFound
+		}
+
+		return a, errorspkg.ErrInternal
+	}
+
+	return a, nil
+}
+
+const getUsernameQuery = `
+SELECT username FROM user_aliases WHERE kind = $1 AND value = $2
+`
+
+// Emails are unique case-sensitively only, so an email can match several
+// users case-insensitively.
+const getUsernamesByEmailQuery = `
+SELECT username FROM users WHERE lower(email) = $1 LIMIT 2
+`
+
+// GetUsername returns the username of the user with the alias. The value must
+// be normalized by domain.NormalizeAlias.
+//
+// The email alias is the user email. It returns domain.ErrAmbiguousRecipient
+// if the email matches several users.
+func (r *RepoPGS) GetUsername(ctx context.Context, kind, value string) (string, error) {
+	l := zerolog.Ctx(ctx)
+
+	if kind == domain.AliasKindEmail {
+		return r.getUsernameByEmail(ctx, value)
+	}
+
+	var username string
+
+	if err := r.db.QueryRowContext(ctx, getUsernameQuery, kind, value).Scan(&username); err != nil {
+		if err == sql.ErrNoRows {
+			return "", domain.ErrAliasNotFound
+		}
+
+		l.Error().Err(err).Send()
+
+		return "", errorspkg.ErrInternal
+	}
+
+	return username, nil
+}
+
+func (r *RepoPGS) getUsernameByEmail(ctx context.Context, email string) (string, error) {
+	l := zerolog.Ctx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, getUsernamesByEmailQuery, email)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return "", errorspkg.ErrInternal
+	}
+	defer rows.Close()
+
+	var usernames []string
+
+	for rows.Next() {
+		var username string
+		if err := rows.Scan(&username); err != nil {
+			l.Error().Err(err).Send()
+			return "", errorspkg.ErrInternal
+		}
+
+		usernames = append(usernames, username)
+	}
+
+	if err := rows.Close(); err != nil {
+		l.Error().Err(err).Send()
+		return "", errorspkg.ErrInternal
+	}
+
+	if err := rows.Err(); err != nil {
+		l.Error().Err(err).Send()
+		return "", errorspkg.ErrInternal
+	}
+
+	switch len(usernames) {
+	case 0:
+		return "", domain.ErrAliasNotFound
+	case 1:
+		return usernames[0], nil
+	}
+
+	l.Info().Err(domain.ErrAmbiguousRecipient).Send()
+
+	return "", domain.ErrAmbiguousRecipient
+}
@@ -0,0 +1,137 @@
+//go:build integration
+
+package aliasrepo_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-petr/pet-bank/internal/aliasrepo"
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/integrationtest"
+	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
+	"github.com/go-petr/pet-bank/pkg/configpkg"
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	dbDriver string
+	dbSource string
+)
+
+func TestMain(m *testing.M) {
+	config, err := configpkg.Load("../../configs")
+	if err != nil {
+		log.Fatal("cannot load config:", err)
+	}
+
+	dbDriver = config.DBDriver
+	dbSource = config.DBSource
+
+	os.Exit(m.Run())
+}
+
+func TestAliases(t *testing.T) {
+	t.Parallel()
+
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	aliasRepo := aliasrepo.NewRepoPGS(tx)
+	ctx := context.Background()
+
+	user := helpers.SeedUser(t, tx)
+	other := helpers.SeedUser(t, tx)
+	handle := strings.ToLower(user.Username)
+
+	arg := domain.CreateAliasParams{Username: user.Username, Kind: domain.AliasKindHandle, Value: handle}
+
+	alias, err := aliasRepo.Create(ctx, arg)
+	if err != nil {
+		t.Fatalf("aliasRepo.Create(ctx, %+v) returned error: %v", arg, err)
+	}
+
+	if alias.ID == 0 || alias.Username != user.Username || alias.Value != handle {
+		t.Fatalf("aliasRepo.Create(ctx, %+v) returned %+v", arg, alias)
+	}
+
+	aliases, err := aliasRepo.List(ctx, user.Username)
+	if err != nil {
+		t.Fatalf("aliasRepo.List(ctx, %q) returned error: %v", user.Username, err)
+	}
+
+	if diff := cmp.Diff([]domain.Alias{alias}, aliases); diff != "" {
+		t.Errorf("aliasRepo.List(ctx, %q) returned unexpected difference (-want +got):\n%s", user.Username, diff)
+	}
+
+	username, err := aliasRepo.GetUsername(ctx, domain.AliasKindHandle, handle)
+	if err != nil || username != user.Username {
+		t.Errorf("aliasRepo.GetUsername(ctx, handle, %q) = %q, %v, want %q", handle, username, err, user.Username)
+	}
+
+	// Emails are matched case-insensitively against the users emails.
+	username, err = aliasRepo.GetUsername(ctx, domain.AliasKindEmail, strings.ToLower(user.Email))
+	if err != nil || username != user.Username {
+		t.Errorf("aliasRepo.GetUsername(ctx, email, %q) = %q, %v, want %q", user.Email, username, err, user.Username)
+	}
+
+	if _, err := aliasRepo.Delete(ctx, other.Username, alias.ID); err != domain.ErrAliasNotFound {
+		t.Errorf("aliasRepo.Delete(ctx, %q, %v) returned error: %v, want %v", other.Username, alias.ID, err, domain.ErrAliasNotFound)
+	}
+
+	if _, err := aliasRepo.Delete(ctx, user.Username, alias.ID); err != nil {
+		t.Fatalf("aliasRepo.Delete(ctx, %q, %v) returned error: %v", user.Username, alias.ID, err)
+	}
+
+	if _, err := aliasRepo.GetUsername(ctx, domain.AliasKindHandle, handle); err != domain.ErrAliasNotFound {
+		t.Errorf("aliasRepo.GetUsername(ctx, handle, %q) returned error: %v, want %v", handle, err, domain.ErrAliasNotFound)
+	}
+}
+
+func TestGetUsernameErrAmbiguousRecipient(t *testing.T) {
+	t.Parallel()
+
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	aliasRepo := aliasrepo.NewRepoPGS(tx)
+	ctx := context.Background()
+
+	user := helpers.SeedUser(t, tx)
+	other := helpers.SeedUser(t, tx)
+
+	// The emails of the users differ in case only.
+	if _, err := tx.ExecContext(ctx, `UPDATE users SET email = upper($2) WHERE username = $1`, other.Username, user.Email); err != nil {
+		t.Fatalf("updating email of %q returned error: %v", other.Username, err)
+	}
+
+	email := strings.ToLower(user.Email)
+
+	if _, err := aliasRepo.GetUsername(ctx, domain.AliasKindEmail, email); err != domain.ErrAmbiguousRecipient {
+		t.Errorf("aliasRepo.GetUsername(ctx, email, %q) returned error: %v, want %v", email, err, domain.ErrAmbiguousRecipient)
+	}
+
+	if _, err := aliasRepo.GetUsername(ctx, domain.AliasKindEmail, "missing"+email); err != domain.ErrAliasNotFound {
+		t.Errorf("aliasRepo.GetUsername(ctx, email, missing) returned error: %v, want %v", err, domain.ErrAliasNotFound)
+	}
+}
+
+func TestCreateErrAliasAlreadyExists(t *testing.T) {
+	t.Parallel()
+
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	aliasRepo := aliasrepo.NewRepoPGS(tx)
+	ctx := context.Background()
+
+	user := helpers.SeedUser(t, tx)
+	other := helpers.SeedUser(t, tx)
+	arg := domain.CreateAliasParams{Username: user.Username, Kind: domain.AliasKindPhone, Value: "+15550102030"}
+
+	if _, err := aliasRepo.Create(ctx, arg); err != nil {
+		t.Fatalf("aliasRepo.Create(ctx, %+v) returned error: %v", arg, err)
+	}
+
+	arg.Username = other.Username
+	if _, err := aliasRepo.Create(ctx, arg); err != domain.ErrAliasAlreadyExists {
+		t.Errorf("aliasRepo.Create(ctx, %+v) returned error: %v, want %v", arg, err, domain.ErrAliasAlreadyExists)
+	}
+}
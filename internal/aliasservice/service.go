@@ -0,0 +1,165 @@
+// Package aliasservice manages business logic layer of user aliases and
+// resolves transfer recipients.
+package aliasservice
+
+import (
+	"context"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/rs/zerolog"
+)
+
+// Repo provides data access layer interface needed by alias service layer.
+//
+//go:generate mockgen -source service.go -destination service_mock.go -package aliasservice
+type Repo interface {
+	Create(ctx context.Context, arg domain.CreateAliasParams) (domain.Alias, error)
+	List(ctx context.Context, username string) ([]domain.Alias, error)
+	Delete(ctx context.Context, username string, id int64) (domain.Alias, error)
+	GetUsername(ctx context.Context, kind, value string) (string, error)
+}
+
+// UserRepo provides user data access needed to resolve recipients by username.
+type UserRepo interface {
+	Get(ctx context.Context, username string) (domain.User, error)
+}
+
+// AccountRepo provides account data access needed to resolve recipient accounts.
+type AccountRepo interface {
+	GetByOwner(ctx context.Context, owner, currency string) (domain.Account, error)
+}
+
+// Auditor records audit events of the aliases.
+type Auditor interface {
+	Record(ctx context.Context, eventType, actor string, before, after any)
+}
+
+// Service facilitates alias service layer logic.
+type Service struct {
+	repo        Repo
+	userRepo    UserRepo
+	accountRepo AccountRepo
+	auditor     Auditor
+}
+
+// New returns alias service struct to manage alias bussines logic. Audit
+// events are not recorded if a is nil.
+func New(r Repo, ur UserRepo, ar AccountRepo, a Auditor) *Service {
+	return &Service{
+		repo:        r,
+		userRepo:    ur,
+		accountRepo: ar,
+		auditor:     a,
+	}
+}
+
+// Create registers the phone or handle alias of the user. The user email is
+// the email alias, so email aliases can't be registered.
+func (s *Service) Create(ctx context.Context, username, kind, value string) (domain.Alias, error) {
+	l := zerolog.Ctx(ctx)
+
+	if kind != domain.AliasKindPhone && kind != domain.AliasKindHandle {
+		l.Info().Err(domain.ErrInvalidAlias).Send()
+		return domain.Alias{}, domain.ErrInvalidAlias
+	}
+
+	value, err := domain.NormalizeAlias(kind, value)
+	if err != nil {
+		l.Info().Err(err).Send()
+		return domain.Alias{}, err
+	}
+
+	alias, err := s.repo.Create(ctx, domain.CreateAliasParams{Username: username, Kind: kind, Value: value})
+	if err != nil {
+		return alias, err
+	}
+
+	if s.auditor != nil {
+		s.auditor.Record(ctx, domain.AuditAliasCreated, username, nil, alias)
+	}
+
+	return alias, nil
+}
+
+// List returns all the aliases of the user.
+func (s *Service) List(ctx context.Context, username string) ([]domain.Alias, error) {
+	return s.repo.List(ctx, username)
+}
+
+// Delete deletes the user's alias.
+func (s *Service) Delete(ctx context.Context, username string, id int64) error {
+	alias, err := s.repo.Delete(ctx, username, id)
+	if err != nil {
+		return err
+	}
+
+	if s.auditor != nil {
+		s.auditor.Record(ctx, domain.AuditAliasDeleted, username, alias, nil)
+	}
+
+	return nil
+}
+
+// Resolve returns the account of the recipient in arg.Currency. The recipient
+// is found by arg.Username if it is set, otherwise by arg.Alias.
+//
+// It returns domain.ErrRecipientNotFound if no user has the username or the
+// alias, domain.ErrAmbiguousRecipient if the email alias matches several users
+// and domain.ErrRecipientAccountNotFound if the user has no account in the
+// currency.
+func (s *Service) Resolve(ctx context.Context, arg domain.Recipient) (domain.Account, error) {
+	l := zerolog.Ctx(ctx)
+
+	username, err := s.username(ctx, arg)
+	if err != nil {
+		return domain.Account{}, err
+	}
+
+	account, err := s.accountRepo.GetByOwner(ctx, username, arg.Currency)
+	if err != nil {
+		if err == domain.ErrAccountNotFound {
+			l.Info().Err(domain.ErrRecipientAccountNotFound).Send()
+			return domain.Account{}, domain.ErrRecipientAccountNotFound
+		}
+
+		return domain.Account{}, err
+	}
+
+	return account, nil
+}
+
+func (s *Service) username(ctx context.Context, arg domain.Recipient) (string, error) {
+	l := zerolog.Ctx(ctx)
+
+	if arg.Username != "" {
+		user, err := s.userRepo.Get(ctx, arg.Username)
+		if err != nil {
+			if err == domain.ErrUserNotFound {
+				l.Info().Err(domain.ErrRecipientNotFound).Send()
+				return "", domain.ErrRecipientNotFound
+			}
+
+			return "", err
+		}
+
+		return user.Username, nil
+	}
+
+	kind, value, err := domain.ParseAlias(arg.Alias)
+	if err != nil {
+		l.Info().Err(err).Send()
+		return "", err
+	}
+
+	username, err := s.repo.GetUsername(ctx, kind, value)
+	if err != nil {
+		if err == domain.ErrAliasNotFound {
+			l.Info().Err(domain.ErrRecipientNotFound).Send()
+			return "", domain.ErrRecipientNotFound
+		}
+
+		return "", err
+	}
+
+	return username, nil
+}
@@ -0,0 +1,207 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package aliasservice is a generated GoMock package.
+package aliasservice
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/go-petr/pet-bank/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepo is a mock of Repo interface.
+type MockRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoMockRecorder
+}
+
+// MockRepoMockRecorder is the mock recorder for MockRepo.
+type MockRepoMockRecorder struct {
+	mock *MockRepo
+}
+
+// NewMockRepo creates a new mock instance.
+func NewMockRepo(ctrl *gomock.Controller) *MockRepo {
+	mock := &MockRepo{ctrl: ctrl}
+	mock.recorder = &MockRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRepo) Create(ctx context.Context, arg domain.CreateAliasParams) (domain.Alias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, arg)
+	ret0, _ := ret[0].(domain.Alias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepoMockRecorder) Create(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepo)(nil).Create), ctx, arg)
+}
+
+// Delete mocks base method.
+func (m *MockRepo) Delete(ctx context.Context, username string, id int64) (domain.Alias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, username, id)
+	ret0, _ := ret[0].(domain.Alias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepoMockRecorder) Delete(ctx, username, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepo)(nil).Delete), ctx, username, id)
+}
+
+// GetUsername mocks base method.
+func (m *MockRepo) GetUsername(ctx context.Context, kind, value string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsername", ctx, kind, value)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsername indicates an expected call of GetUsername.
+func (mr *MockRepoMockRecorder) GetUsername(ctx, kind, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsername", reflect.TypeOf((*MockRepo)(nil).GetUsername), ctx, kind, value)
+}
+
+// List mocks base method.
+func (m *MockRepo) List(ctx context.Context, username string) ([]domain.Alias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, username)
+	ret0, _ := ret[0].([]domain.Alias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepoMockRecorder) List(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepo)(nil).List), ctx, username)
+}
+
+// MockUserRepo is a mock of UserRepo interface.
+type MockUserRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserRepoMockRecorder
+}
+
+// MockUserRepoMockRecorder is the mock recorder for MockUserRepo.
+type MockUserRepoMockRecorder struct {
+	mock *MockUserRepo
+}
+
+// NewMockUserRepo creates a new mock instance.
+func NewMockUserRepo(ctrl *gomock.Controller) *MockUserRepo {
+	mock := &MockUserRepo{ctrl: ctrl}
+	mock.recorder = &MockUserRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserRepo) EXPECT() *MockUserRepoMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockUserRepo) Get(ctx context.Context, username string) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, username)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockUserRepoMockRecorder) Get(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUserRepo)(nil).Get), ctx, username)
+}
+
+// MockAccountRepo is a mock of AccountRepo interface.
+type MockAccountRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountRepoMockRecorder
+}
+
+// MockAccountRepoMockRecorder is the mock recorder for MockAccountRepo.
+type MockAccountRepoMockRecorder struct {
+	mock *MockAccountRepo
+}
+
+// NewMockAccountRepo creates a new mock instance.
+func NewMockAccountRepo(ctrl *gomock.Controller) *MockAccountRepo {
+	mock := &MockAccountRepo{ctrl: ctrl}
+	mock.recorder = &MockAccountRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountRepo) EXPECT() *MockAccountRepoMockRecorder {
+	return m.recorder
+}
+
+// GetByOwner mocks base method.
+func (m *MockAccountRepo) GetByOwner(ctx context.Context, owner, currency string) (domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOwner", ctx, owner, currency)
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOwner indicates an expected call of GetByOwner.
+func (mr *MockAccountRepoMockRecorder) GetByOwner(ctx, owner, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOwner", reflect.TypeOf((*MockAccountRepo)(nil).GetByOwner), ctx, owner, currency)
+}
+
+// MockAuditor is a mock of Auditor interface.
+type MockAuditor struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditorMockRecorder
+}
+
+// MockAuditorMockRecorder is the mock recorder for MockAuditor.
+type MockAuditorMockRecorder struct {
+	mock *MockAuditor
+}
+
+// NewMockAuditor creates a new mock instance.
+func NewMockAuditor(ctrl *gomock.Controller) *MockAuditor {
+	mock := &MockAuditor{ctrl: ctrl}
+	mock.recorder = &MockAuditorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditor) EXPECT() *MockAuditorMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockAuditor) Record(ctx context.Context, eventType, actor string, before, after any) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", ctx, eventType, actor, before, after)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAuditorMockRecorder) Record(ctx, eventType, actor, before, after interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAuditor)(nil).Record), ctx, eventType, actor, before, after)
+}
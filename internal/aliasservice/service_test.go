@@ -0,0 +1,241 @@
+package aliasservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/currencypkg"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCreate(t *testing.T) {
+	username := randompkg.Owner()
+
+	testCases := []struct {
+		name       string
+		kind       string
+		value      string
+		buildStubs func(repo *MockRepo, auditor *MockAuditor)
+		wantErr    error
+	}{
+		{
+			name:  "Phone",
+			kind:  domain.AliasKindPhone,
+			value: "+1 (555) 010-2030",
+			buildStubs: func(repo *MockRepo, auditor *MockAuditor) {
+				arg := domain.CreateAliasParams{Username: username, Kind: domain.AliasKindPhone, Value: "+15550102030"}
+				alias := domain.Alias{ID: 1, Username: username, Kind: arg.Kind, Value: arg.Value}
+				repo.EXPECT().Create(gomock.Any(), gomock.Eq(arg)).Times(1).Return(alias, nil)
+				auditor.EXPECT().Record(gomock.Any(), domain.AuditAliasCreated, username, nil, gomock.Eq(alias)).Times(1)
+			},
+		},
+		{
+			name:  "Handle",
+			kind:  domain.AliasKindHandle,
+			value: "@Alice_1",
+			buildStubs: func(repo *MockRepo, auditor *MockAuditor) {
+				arg := domain.CreateAliasParams{Username: username, Kind: domain.AliasKindHandle, Value: "alice_1"}
+				repo.EXPECT().Create(gomock.Any(), gomock.Eq(arg)).Times(1).Return(domain.Alias{}, nil)
+				auditor.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+			},
+		},
+		{
+			name:  "EmailErrInvalidAlias",
+			kind:  domain.AliasKindEmail,
+			value: "alice@example.com",
+			buildStubs: func(repo *MockRepo, auditor *MockAuditor) {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrInvalidAlias,
+		},
+		{
+			name:  "PhoneErrInvalidAlias",
+			kind:  domain.AliasKindPhone,
+			value: "555-0102",
+			buildStubs: func(repo *MockRepo, auditor *MockAuditor) {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrInvalidAlias,
+		},
+		{
+			name:  "HandleErrInvalidAlias",
+			kind:  domain.AliasKindHandle,
+			value: "a!",
+			buildStubs: func(repo *MockRepo, auditor *MockAuditor) {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrInvalidAlias,
+		},
+		{
+			name:  "ErrAliasAlreadyExists",
+			kind:  domain.AliasKindHandle,
+			value: "alice",
+			buildStubs: func(repo *MockRepo, auditor *MockAuditor) {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(domain.Alias{}, domain.ErrAliasAlreadyExists)
+				auditor.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrAliasAlreadyExists,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepo(ctrl)
+			auditor := NewMockAuditor(ctrl)
+			tc.buildStubs(repo, auditor)
+
+			service := New(repo, NewMockUserRepo(ctrl), NewMockAccountRepo(ctrl), auditor)
+
+			if _, err := service.Create(context.Background(), username, tc.kind, tc.value); err != tc.wantErr {
+				t.Errorf("service.Create(ctx, %v, %v, %v) returned error: %v, want %v", username, tc.kind, tc.value, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	user := domain.User{Username: randompkg.Owner()}
+	account := domain.Account{
+		ID:        7,
+		Owner:     user.Username,
+		Balance:   "100",
+		Currency:  currencypkg.USD,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	testCases := []struct {
+		name       string
+		recipient  domain.Recipient
+		buildStubs func(repo *MockRepo, userRepo *MockUserRepo, accountRepo *MockAccountRepo)
+		wantErr    error
+	}{
+		{
+			name:      "Username",
+			recipient: domain.Recipient{Username: user.Username, Currency: currencypkg.USD},
+			buildStubs: func(repo *MockRepo, userRepo *MockUserRepo, accountRepo *MockAccountRepo) {
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				accountRepo.EXPECT().GetByOwner(gomock.Any(), gomock.Eq(user.Username), gomock.Eq(currencypkg.USD)).
+					Times(1).
+					Return(account, nil)
+			},
+		},
+		{
+			name:      "Email",
+			recipient: domain.Recipient{Alias: " Alice@Example.com", Currency: currencypkg.USD},
+			buildStubs: func(repo *MockRepo, userRepo *MockUserRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().GetUsername(gomock.Any(), domain.AliasKindEmail, "alice@example.com").Times(1).Return(user.Username, nil)
+				accountRepo.EXPECT().GetByOwner(gomock.Any(), gomock.Eq(user.Username), gomock.Eq(currencypkg.USD)).
+					Times(1).
+					Return(account, nil)
+			},
+		},
+		{
+			name:      "Phone",
+			recipient: domain.Recipient{Alias: "+44 20 7946 0000", Currency: currencypkg.USD},
+			buildStubs: func(repo *MockRepo, userRepo *MockUserRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().GetUsername(gomock.Any(), domain.AliasKindPhone, "+442079460000").Times(1).Return(user.Username, nil)
+				accountRepo.EXPECT().GetByOwner(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(account, nil)
+			},
+		},
+		{
+			name:      "Handle",
+			recipient: domain.Recipient{Alias: "@Alice", Currency: currencypkg.USD},
+			buildStubs: func(repo *MockRepo, userRepo *MockUserRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().GetUsername(gomock.Any(), domain.AliasKindHandle, "alice").Times(1).Return(user.Username, nil)
+				accountRepo.EXPECT().GetByOwner(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(account, nil)
+			},
+		},
+		{
+			name:      "UsernameErrRecipientNotFound",
+			recipient: domain.Recipient{Username: user.Username, Currency: currencypkg.USD},
+			buildStubs: func(repo *MockRepo, userRepo *MockUserRepo, accountRepo *MockAccountRepo) {
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(domain.User{}, domain.ErrUserNotFound)
+				accountRepo.EXPECT().GetByOwner(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrRecipientNotFound,
+		},
+		{
+			name:      "AliasErrRecipientNotFound",
+			recipient: domain.Recipient{Alias: "@alice", Currency: currencypkg.USD},
+			buildStubs: func(repo *MockRepo, userRepo *MockUserRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().GetUsername(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return("", domain.ErrAliasNotFound)
+				accountRepo.EXPECT().GetByOwner(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrRecipientNotFound,
+		},
+		{
+			name:      "ErrAmbiguousRecipient",
+			recipient: domain.Recipient{Alias: "Alice@Example.com", Currency: currencypkg.USD},
+			buildStubs: func(repo *MockRepo, userRepo *MockUserRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().GetUsername(gomock.Any(), domain.AliasKindEmail, "alice@example.com").Times(1).
+					Return("", domain.ErrAmbiguousRecipient)
+				accountRepo.EXPECT().GetByOwner(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrAmbiguousRecipient,
+		},
+		{
+			name:      "ErrInvalidAlias",
+			recipient: domain.Recipient{Alias: "a b", Currency: currencypkg.USD},
+			buildStubs: func(repo *MockRepo, userRepo *MockUserRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().GetUsername(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrInvalidAlias,
+		},
+		{
+			name:      "ErrRecipientAccountNotFound",
+			recipient: domain.Recipient{Username: user.Username, Currency: currencypkg.EUR},
+			buildStubs: func(repo *MockRepo, userRepo *MockUserRepo, accountRepo *MockAccountRepo) {
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(user, nil)
+				accountRepo.EXPECT().GetByOwner(gomock.Any(), gomock.Eq(user.Username), gomock.Eq(currencypkg.EUR)).
+					Times(1).
+					Return(domain.Account{}, domain.ErrAccountNotFound)
+			},
+			wantErr: domain.ErrRecipientAccountNotFound,
+		},
+		{
+			name:      "ErrInternal",
+			recipient: domain.Recipient{Username: user.Username, Currency: currencypkg.USD},
+			buildStubs: func(repo *MockRepo, userRepo *MockUserRepo, accountRepo *MockAccountRepo) {
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(domain.User{}, errorspkg.ErrInternal)
+			},
+			wantErr: errorspkg.ErrInternal,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepo(ctrl)
+			userRepo := NewMockUserRepo(ctrl)
+			accountRepo := NewMockAccountRepo(ctrl)
+			tc.buildStubs(repo, userRepo, accountRepo)
+
+			service := New(repo, userRepo, accountRepo, nil)
+
+			got, err := service.Resolve(context.Background(), tc.recipient)
+			if err != tc.wantErr {
+				t.Fatalf("service.Resolve(ctx, %+v) returned error: %v, want %v", tc.recipient, err, tc.wantErr)
+			}
+
+			if tc.wantErr == nil {
+				if diff := cmp.Diff(account, got); diff != "" {
+					t.Errorf("service.Resolve(ctx, %+v) returned unexpected diff: %s", tc.recipient, diff)
+				}
+			}
+		})
+	}
+}
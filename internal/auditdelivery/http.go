@@ -0,0 +1,119 @@
+// Package auditdelivery manages delivery layer of the audit log.
+package auditdelivery
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
+	"github.com/go-petr/pet-bank/pkg/web"
+)
+
+// Service provides service layer interface needed by audit delivery layer.
+//
+//go:generate mockgen -source http.go -destination http_mock.go -package auditdelivery
+type Service interface {
+	List(ctx context.Context, arg domain.ListAuditEventsParams, page pagepkg.Request) ([]domain.AuditEvent, pagepkg.Page, error)
+}
+
+// Handler facilitates audit delivery layer logic.
+type Handler struct {
+	service Service
+}
+
+// NewHandler returns audit handler.
+func NewHandler(s Service) *Handler {
+	return &Handler{
+		service: s,
+	}
+}
+
+type listRequest struct {
+	Actor     string    `form:"actor"`
+	Type      string    `form:"type"`
+	StartTime time.Time `form:"start_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndTime   time.Time `form:"end_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	PageID    int32     `form:"page_id" binding:"required_without=PageToken,omitempty,min=1"`
+	PageSize  int32     `form:"page_size" binding:"required,min=1,max=100"`
+	PageToken string    `form:"page_token"`
+}
+
+// List handles http request to query audit events by actor, type and time range.
+//
+// The start time is inclusive and the end time is exclusive. The page is
+// located by page_token if it is set, otherwise by page_id.
+func (h *Handler) List(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+	l := zerolog.Ctx(ctx)
+
+	var req listRequest
+	if err := gctx.ShouldBindQuery(&req); err != nil {
+		l.Info().Err(err).Send()
+
+		var ve validator.ValidationErrors
+		if errors.As(err, &ve) {
+			gctx.JSON(http.StatusBadRequest, web.Response{Error: web.GetErrorMsg(ve)})
+
+			return
+		}
+
+		gctx.JSON(http.StatusBadRequest, web.Error(err))
+
+		return
+	}
+
+	pageReq := pagepkg.Request{PageID: req.PageID, PageSize: req.PageSize}
+
+	if req.PageToken != "" {
+		cursor, err := pagepkg.Parse(req.PageToken)
+		if err != nil {
+			l.Info().Err(err).Send()
+			gctx.JSON(http.StatusBadRequest, web.Error(err))
+
+			return
+		}
+
+		pageReq.Cursor = cursor
+	}
+
+	arg := domain.ListAuditEventsParams{
+		Actor:     req.Actor,
+		Type:      req.Type,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+	}
+
+	events, page, err := h.service.List(ctx, arg, pageReq)
+	if err != nil {
+		l.Info().Err(err).Send()
+
+		if err == domain.ErrInvalidDateRange {
+			gctx.JSON(http.StatusBadRequest, web.Error(err))
+			return
+		}
+
+		gctx.JSON(http.StatusInternalServerError, web.Error(errorspkg.ErrInternal))
+
+		return
+	}
+
+	res := web.Response{
+		Data: &struct {
+			Events []domain.AuditEvent `json:"events"`
+		}{
+			Events: events,
+		},
+		NextCursor: page.Next,
+		PrevCursor: page.Prev,
+	}
+
+	gctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: http.go
+
+// Package auditdelivery is a generated GoMock package.
+package auditdelivery
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/go-petr/pet-bank/internal/domain"
+	pagepkg "github.com/go-petr/pet-bank/pkg/pagepkg"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockService) List(ctx context.Context, arg domain.ListAuditEventsParams, page pagepkg.Request) ([]domain.AuditEvent, pagepkg.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, arg, page)
+	ret0, _ := ret[0].([]domain.AuditEvent)
+	ret1, _ := ret[1].(pagepkg.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockServiceMockRecorder) List(ctx, arg, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List), ctx, arg, page)
+}
@@ -0,0 +1,163 @@
+package auditdelivery
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/middleware"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/go-petr/pet-bank/pkg/tokenpkg"
+	"github.com/go-petr/pet-bank/pkg/web"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+func TestList(t *testing.T) {
+	actor := randompkg.Owner()
+	symmetricKey := randompkg.String(32)
+
+	tokenMaker, err := tokenpkg.NewPasetoMaker(symmetricKey)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(%v) returned error: %v", symmetricKey, err)
+	}
+
+	testCases := []struct {
+		name           string
+		url            string
+		buildStubs     func(auditService *MockService)
+		wantStatusCode int
+		wantError      string
+	}{
+		{
+			name: "OK",
+			url:  "/admin/audit-events?actor=alice&type=user.created&start_time=2023-01-01T00:00:00Z&end_time=2023-02-01T00:00:00Z&page_id=1&page_size=5",
+			buildStubs: func(auditService *MockService) {
+				arg := domain.ListAuditEventsParams{
+					Actor:     "alice",
+					Type:      domain.AuditUserCreated,
+					StartTime: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+				}
+
+				auditService.EXPECT().
+					List(gomock.Any(), gomock.Eq(arg), gomock.Eq(pagepkg.Request{PageID: 1, PageSize: 5})).
+					Times(1).
+					Return([]domain.AuditEvent{{ID: 1, Actor: "alice", Type: domain.AuditUserCreated}}, pagepkg.Page{}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "PageToken",
+			url:  "/admin/audit-events?page_size=5&page_token=" + pagepkg.Cursor{AfterID: 10}.Token(),
+			buildStubs: func(auditService *MockService) {
+				page := pagepkg.Request{PageSize: 5, Cursor: pagepkg.Cursor{AfterID: 10}}
+
+				auditService.EXPECT().
+					List(gomock.Any(), gomock.Eq(domain.ListAuditEventsParams{}), gomock.Eq(page)).
+					Times(1).
+					Return([]domain.AuditEvent{}, pagepkg.Page{}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "InvalidPageToken",
+			url:  "/admin/audit-events?page_size=5&page_token=invalid",
+			buildStubs: func(auditService *MockService) {
+				auditService.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      pagepkg.ErrInvalidPageToken.Error(),
+		},
+		{
+			name: "RequiredPageSize",
+			url:  "/admin/audit-events?page_id=1",
+			buildStubs: func(auditService *MockService) {
+				auditService.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "PageSize field is required",
+		},
+		{
+			name: "ErrInvalidDateRange",
+			url:  "/admin/audit-events?start_time=2023-02-01T00:00:00Z&end_time=2023-01-01T00:00:00Z&page_id=1&page_size=5",
+			buildStubs: func(auditService *MockService) {
+				auditService.EXPECT().
+					List(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, pagepkg.Page{}, domain.ErrInvalidDateRange)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      domain.ErrInvalidDateRange.Error(),
+		},
+		{
+			name: "InternalError",
+			url:  "/admin/audit-events?page_id=1&page_size=5",
+			buildStubs: func(auditService *MockService) {
+				auditService.EXPECT().
+					List(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, pagepkg.Page{}, errorspkg.ErrInternal)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      errorspkg.ErrInternal.Error(),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			auditService := NewMockService(ctrl)
+			auditHandler := NewHandler(auditService)
+
+			server := gin.New()
+			admin := server.Group("/admin", middleware.AuthMiddleware(tokenMaker, nil))
+			admin.GET("/audit-events", auditHandler.List)
+
+			tc.buildStubs(auditService)
+
+			req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			if err != nil {
+				t.Fatalf("Creating request error: %v", err)
+			}
+
+			if err := middleware.AddAuthorization(req, tokenMaker, middleware.AuthTypeBearer, actor, time.Minute); err != nil {
+				t.Fatalf("middleware.AddAuthorization(...) returned error: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			if got := w.Code; got != tc.wantStatusCode {
+				t.Errorf("Status code: got %v, want %v", got, tc.wantStatusCode)
+			}
+
+			var res web.Response
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Errorf("Decoding response body error: %v", err)
+			}
+
+			if res.Error != tc.wantError {
+				t.Errorf(`res.Error=%q, want %q`, res.Error, tc.wantError)
+			}
+		})
+	}
+}
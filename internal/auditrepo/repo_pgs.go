@@ -0,0 +1,162 @@
+// Package auditrepo manages repository layer of audit events.
+package auditrepo
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/dbpkg"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
+	"github.com/rs/zerolog"
+)
+
+// RepoPGS facilitates audit event repository layer logic.
+//
+// The audit_events table is append-only, so the repo has no update or delete.
+type RepoPGS struct {
+	db dbpkg.SQLInterface
+}
+
+// NewRepoPGS returns audit event RepoPGS.
+func NewRepoPGS(db dbpkg.SQLInterface) *RepoPGS {
+	return &RepoPGS{
+		db: db,
+	}
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanEvent(row scanner) (domain.AuditEvent, error) {
+	var (
+		e             domain.AuditEvent
+		before, after []byte
+	)
+
+	err := row.Scan(
+		&e.ID,
+		&e.Type,
+		&e.Actor,
+		&e.IP,
+		&e.RequestID,
+		&before,
+		&after,
+		&e.CreatedAt,
+	)
+
+	e.Before, e.After = before, after
+
+	return e, err
+}
+
+// nullJSON returns nil for the empty snapshot, so it is stored as NULL.
+func nullJSON(b []byte) any {
+	if len(b) == 0 {
+		return nil
+	}
+
+	return b
+}
+
+const createQuery = `
+INSERT INTO audit_events (
+	type,
+	actor,
+	ip,
+	request_id,
+	before,
+	after
+) VALUES (
+	$1, $2, $3, $4, $5, $6
+) RETURNING id, type, actor, ip, request_id, before, after, created_at
+`
+
+// Create records the audit event and then returns it.
+func (r *RepoPGS) Create(ctx context.Context, arg domain.CreateAuditEventParams) (domain.AuditEvent, error) {
+	l := zerolog.Ctx(ctx)
+
+	row := r.db.QueryRowContext(ctx, createQuery,
+		arg.Type,
+		arg.Actor,
+		arg.IP,
+		arg.RequestID,
+		nullJSON(arg.Before),
+		nullJSON(arg.After),
+	)
+
+	e, err := scanEvent(row)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return e, errorspkg.ErrInternal
+	}
+
+	return e, nil
+}
+
+const listQuery = `
+SELECT id, type, actor, ip, request_id, before, after, created_at
+FROM audit_events
+WHERE
+    ($1 = '' OR actor = $1)
+    AND ($2 = '' OR type = $2)
+    AND ($3::timestamptz IS NULL OR created_at >= $3)
+    AND ($4::timestamptz IS NULL OR created_at < $4)
+    AND ($7::bigint = 0 OR id > $7)
+    AND ($8::bigint = 0 OR id < $8)
+ORDER BY CASE WHEN $8 = 0 THEN id END, id DESC
+LIMIT $5 OFFSET $6
+`
+
+// List returns the audit events matching the given filters ordered by id.
+//
+// If arg.BeforeID is set, the events right before it are returned.
+func (r *RepoPGS) List(ctx context.Context, arg domain.ListAuditEventsParams) ([]domain.AuditEvent, error) {
+	l := zerolog.Ctx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, listQuery,
+		arg.Actor,
+		arg.Type,
+		sql.NullTime{Time: arg.StartTime, Valid: !arg.StartTime.IsZero()},
+		sql.NullTime{Time: arg.EndTime, Valid: !arg.EndTime.IsZero()},
+		arg.Limit,
+		arg.Offset,
+		arg.AfterID,
+		arg.BeforeID,
+	)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+	defer rows.Close()
+
+	items := []domain.AuditEvent{}
+
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			l.Error().Err(err).Send()
+			return nil, errorspkg.ErrInternal
+		}
+
+		items = append(items, e)
+	}
+
+	if err := rows.Close(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	if err := rows.Err(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	if arg.BeforeID != 0 {
+		pagepkg.Reverse(items)
+	}
+
+	return items, nil
+}
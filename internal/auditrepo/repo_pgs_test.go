@@ -0,0 +1,193 @@
+//go:build integration
+
+package auditrepo_test
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/auditrepo"
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/integrationtest"
+	"github.com/go-petr/pet-bank/pkg/configpkg"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	_ "github.com/lib/pq"
+)
+
+var (
+	dbDriver string
+	dbSource string
+)
+
+func TestMain(m *testing.M) {
+	config, err := configpkg.Load("../../configs")
+	if err != nil {
+		log.Fatal("cannot load config:", err)
+	}
+
+	dbDriver = config.DBDriver
+	dbSource = config.DBSource
+
+	os.Exit(m.Run())
+}
+
+func TestCreate(t *testing.T) {
+	testCases := []struct {
+		name string
+		arg  domain.CreateAuditEventParams
+	}{
+		{
+			name: "OK",
+			arg: domain.CreateAuditEventParams{
+				Type:      domain.AuditAccountCreated,
+				Actor:     "alice",
+				IP:        "10.0.0.1",
+				RequestID: "req-1",
+				After:     json.RawMessage(`{"id": 1}`),
+			},
+		},
+		{
+			name: "WithoutSnapshots",
+			arg: domain.CreateAuditEventParams{
+				Type:  domain.AuditLoginFailed,
+				Actor: "alice",
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+			repo := auditrepo.NewRepoPGS(tx)
+
+			got, err := repo.Create(context.Background(), tc.arg)
+			if err != nil {
+				t.Fatalf("repo.Create(context.Background(), %+v) returned error: %v", tc.arg, err)
+			}
+
+			want := domain.AuditEvent{
+				Type:      tc.arg.Type,
+				Actor:     tc.arg.Actor,
+				IP:        tc.arg.IP,
+				RequestID: tc.arg.RequestID,
+				Before:    tc.arg.Before,
+				After:     tc.arg.After,
+			}
+			ignoreFields := cmpopts.IgnoreFields(domain.AuditEvent{}, "ID", "CreatedAt")
+
+			if diff := cmp.Diff(want, got, ignoreFields, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("repo.Create(context.Background(), %+v) returned unexpected difference (-want +got):\n%s", tc.arg, diff)
+			}
+		})
+	}
+}
+
+func TestImmutable(t *testing.T) {
+	t.Parallel()
+
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	repo := auditrepo.NewRepoPGS(tx)
+
+	e, err := repo.Create(context.Background(), domain.CreateAuditEventParams{Type: domain.AuditUserCreated, Actor: "alice"})
+	if err != nil {
+		t.Fatalf("repo.Create(...) returned error: %v", err)
+	}
+
+	if _, err := tx.Exec("SAVEPOINT audit"); err != nil {
+		t.Fatalf("savepoint error: %v", err)
+	}
+
+	if _, err := tx.Exec("UPDATE audit_events SET actor = 'mallory' WHERE id = $1", e.ID); err == nil {
+		t.Errorf("UPDATE audit_events succeeded, want error")
+	}
+
+	if _, err := tx.Exec("ROLLBACK TO SAVEPOINT audit"); err != nil {
+		t.Fatalf("rollback to savepoint error: %v", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM audit_events WHERE id = $1", e.ID); err == nil {
+		t.Errorf("DELETE FROM audit_events succeeded, want error")
+	}
+}
+
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	repo := auditrepo.NewRepoPGS(tx)
+	ctx := context.Background()
+
+	params := []domain.CreateAuditEventParams{
+		{Type: domain.AuditUserCreated, Actor: "audit-alice"},
+		{Type: domain.AuditLoginSucceeded, Actor: "audit-alice"},
+		{Type: domain.AuditUserCreated, Actor: "audit-bob"},
+		{Type: domain.AuditLoginFailed, Actor: "audit-alice"},
+	}
+
+	events := make([]domain.AuditEvent, 0, len(params))
+
+	for _, arg := range params {
+		e, err := repo.Create(ctx, arg)
+		if err != nil {
+			t.Fatalf("repo.Create(ctx, %+v) returned error: %v", arg, err)
+		}
+
+		events = append(events, e)
+	}
+
+	testCases := []struct {
+		name string
+		arg  domain.ListAuditEventsParams
+		want []domain.AuditEvent
+	}{
+		{
+			name: "ByActor",
+			arg:  domain.ListAuditEventsParams{Actor: "audit-alice", Limit: 10},
+			want: []domain.AuditEvent{events[0], events[1], events[3]},
+		},
+		{
+			name: "ByActorAndType",
+			arg:  domain.ListAuditEventsParams{Actor: "audit-alice", Type: domain.AuditUserCreated, Limit: 10},
+			want: []domain.AuditEvent{events[0]},
+		},
+		{
+			name: "AfterID",
+			arg:  domain.ListAuditEventsParams{Actor: "audit-alice", AfterID: events[0].ID, Limit: 1},
+			want: []domain.AuditEvent{events[1]},
+		},
+		{
+			name: "BeforeID",
+			arg:  domain.ListAuditEventsParams{Actor: "audit-alice", BeforeID: events[3].ID, Limit: 2},
+			want: []domain.AuditEvent{events[0], events[1]},
+		},
+		{
+			name: "TimeRange",
+			arg: domain.ListAuditEventsParams{
+				Actor:     "audit-alice",
+				StartTime: time.Now().Add(time.Hour),
+				Limit:     10,
+			},
+			want: []domain.AuditEvent{},
+		},
+	}
+
+	for _, tc := range testCases {
+		got, err := repo.List(ctx, tc.arg)
+		if err != nil {
+			t.Fatalf("%s: repo.List(ctx, %+v) returned error: %v", tc.name, tc.arg, err)
+		}
+
+		if diff := cmp.Diff(tc.want, got, cmpopts.EquateApproxTime(time.Second)); diff != "" {
+			t.Errorf("%s: repo.List(ctx, %+v) returned unexpected difference (-want +got):\n%s", tc.name, tc.arg, diff)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Package auditservice manages business logic layer of the audit log.
+package auditservice
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
+	"github.com/go-petr/pet-bank/pkg/requestpkg"
+	"github.com/rs/zerolog"
+)
+
+// Repo provides data access layer interface needed by audit service layer.
+//
+//go:generate mockgen -source service.go -destination service_mock.go -package auditservice
+type Repo interface {
+	Create(ctx context.Context, arg domain.CreateAuditEventParams) (domain.AuditEvent, error)
+	List(ctx context.Context, arg domain.ListAuditEventsParams) ([]domain.AuditEvent, error)
+}
+
+// Service facilitates audit service layer logic.
+type Service struct {
+	repo Repo
+}
+
+// New returns audit service struct to record and query audit events.
+func New(r Repo) *Service {
+	return &Service{
+		repo: r,
+	}
+}
+
+// Record records the event of the given type performed by actor. The IP and
+// request id are taken from the request info in ctx. Before and after are the
+// snapshots of the changed entity marshaled to JSON, nil snapshots are omitted.
+//
+// Recording is best effort: the action has already happened, so failures are
+// logged and not returned to the caller.
+func (s *Service) Record(ctx context.Context, eventType, actor string, before, after any) {
+	l := zerolog.Ctx(ctx)
+
+	info := requestpkg.FromContext(ctx)
+
+	arg := domain.CreateAuditEventParams{
+		Type:      eventType,
+		Actor:     actor,
+		IP:        info.ClientIP,
+		RequestID: info.ID,
+	}
+
+	var err error
+
+	if arg.Before, err = snapshot(before); err != nil {
+		l.Error().Err(err).Str("audit_event", eventType).Msg("cannot marshal audit snapshot")
+	}
+
+	if arg.After, err = snapshot(after); err != nil {
+		l.Error().Err(err).Str("audit_event", eventType).Msg("cannot marshal audit snapshot")
+	}
+
+	if _, err := s.repo.Create(ctx, arg); err != nil {
+		l.Error().Err(err).Str("audit_event", eventType).Str("actor", actor).Msg("cannot record audit event")
+	}
+}
+
+func snapshot(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(v)
+}
+
+// List returns the page of audit events matching the filters.
+func (s *Service) List(ctx context.Context, arg domain.ListAuditEventsParams, page pagepkg.Request) ([]domain.AuditEvent, pagepkg.Page, error) {
+	l := zerolog.Ctx(ctx)
+
+	if !arg.StartTime.IsZero() && !arg.EndTime.IsZero() && arg.EndTime.Before(arg.StartTime) {
+		l.Info().Err(domain.ErrInvalidDateRange).Send()
+		return nil, pagepkg.Page{}, domain.ErrInvalidDateRange
+	}
+
+	arg.AfterID = page.Cursor.AfterID
+	arg.BeforeID = page.Cursor.BeforeID
+	arg.Limit = page.Limit()
+	arg.Offset = page.Offset()
+
+	events, err := s.repo.List(ctx, arg)
+	if err != nil {
+		return nil, pagepkg.Page{}, err
+	}
+
+	events, p := pagepkg.Trim(events, page, func(e domain.AuditEvent) int64 { return e.ID })
+
+	return events, p, nil
+}
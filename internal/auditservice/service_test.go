@@ -0,0 +1,150 @@
+package auditservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
+	"github.com/go-petr/pet-bank/pkg/requestpkg"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRecord(t *testing.T) {
+	info := requestpkg.Info{ID: "req-1", ClientIP: "10.0.0.1"}
+
+	testCases := []struct {
+		name       string
+		before     any
+		after      any
+		buildStubs func(repo *MockRepo)
+	}{
+		{
+			name:   "OK",
+			before: map[string]int{"balance": 1},
+			after:  map[string]int{"balance": 2},
+			buildStubs: func(repo *MockRepo) {
+				arg := domain.CreateAuditEventParams{
+					Type:      domain.AuditAccountCreated,
+					Actor:     "alice",
+					IP:        info.ClientIP,
+					RequestID: info.ID,
+					Before:    []byte(`{"balance":1}`),
+					After:     []byte(`{"balance":2}`),
+				}
+
+				repo.EXPECT().Create(gomock.Any(), gomock.Eq(arg)).Times(1).Return(domain.AuditEvent{ID: 1}, nil)
+			},
+		},
+		{
+			name: "NilSnapshots",
+			buildStubs: func(repo *MockRepo) {
+				arg := domain.CreateAuditEventParams{
+					Type:      domain.AuditAccountCreated,
+					Actor:     "alice",
+					IP:        info.ClientIP,
+					RequestID: info.ID,
+				}
+
+				repo.EXPECT().Create(gomock.Any(), gomock.Eq(arg)).Times(1).Return(domain.AuditEvent{ID: 1}, nil)
+			},
+		},
+		{
+			name:  "RepoErrorIsNotReturned",
+			after: map[string]int{"balance": 2},
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(domain.AuditEvent{}, errorspkg.ErrInternal)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepo(ctrl)
+			tc.buildStubs(repo)
+
+			ctx := requestpkg.NewContext(context.Background(), info)
+
+			New(repo).Record(ctx, domain.AuditAccountCreated, "alice", tc.before, tc.after)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	events := []domain.AuditEvent{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	testCases := []struct {
+		name       string
+		arg        domain.ListAuditEventsParams
+		page       pagepkg.Request
+		buildStubs func(repo *MockRepo)
+		want       []domain.AuditEvent
+		wantPage   pagepkg.Page
+		wantErr    error
+	}{
+		{
+			name: "OK",
+			arg:  domain.ListAuditEventsParams{Actor: "alice", StartTime: start, EndTime: end},
+			page: pagepkg.Request{PageID: 1, PageSize: 2},
+			buildStubs: func(repo *MockRepo) {
+				arg := domain.ListAuditEventsParams{Actor: "alice", StartTime: start, EndTime: end, Limit: 3}
+
+				repo.EXPECT().List(gomock.Any(), gomock.Eq(arg)).Times(1).Return(events, nil)
+			},
+			want:     events[:2],
+			wantPage: pagepkg.Page{Next: pagepkg.Cursor{AfterID: 2}.Token()},
+		},
+		{
+			name: "ErrInvalidDateRange",
+			arg:  domain.ListAuditEventsParams{StartTime: end, EndTime: start},
+			page: pagepkg.Request{PageID: 1, PageSize: 2},
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().List(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrInvalidDateRange,
+		},
+		{
+			name: "RepoError",
+			page: pagepkg.Request{PageID: 1, PageSize: 2},
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).Return(nil, errorspkg.ErrInternal)
+			},
+			wantErr: errorspkg.ErrInternal,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepo(ctrl)
+			tc.buildStubs(repo)
+
+			got, page, err := New(repo).List(context.Background(), tc.arg, tc.page)
+			if err != tc.wantErr {
+				t.Fatalf("List(...) returned error: %v, want %v", err, tc.wantErr)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("List(...) returned unexpected difference (-want +got):\n%s", diff)
+			}
+
+			if page != tc.wantPage {
+				t.Errorf("page = %+v, want %+v", page, tc.wantPage)
+			}
+		})
+	}
+}
@@ -15,13 +15,41 @@ var (
 	ErrOwnerNotFound = errors.New("owner not found")
 	// ErrAccountOwnerMismatch indicates that the requested account is not owned by the user.
 	ErrAccountOwnerMismatch = errors.New("account doesn't belong to the authenticated user")
+	// ErrAccountFrozen indicates that the account is frozen and cannot send or receive money.
+	ErrAccountFrozen = errors.New("account is frozen")
+	// ErrSystemAccount indicates that the operation is not allowed on a system account.
+	ErrSystemAccount = errors.New("operation is not allowed on a system account")
 )
 
+// SettlementOwner owns the per-currency settlement accounts which are the
+// counterparty of deposits and withdrawals. The name can't be registered since
+// usernames are alphanumeric.
+const SettlementOwner = "system.settlement"
+
 // Account holds user balance data for specific currency.
 type Account struct {
-	ID        int32     `json:"id"`
-	Owner     string    `json:"owner"`
-	Balance   string    `json:"balance"`
-	Currency  string    `json:"currency"`
-	CreatedAt time.Time `json:"created_at"`
+	ID               int32      `json:"id"`
+	Owner            string     `json:"owner"`
+	Balance          string     `json:"balance"`
+	AvailableBalance string     `json:"available_balance"` // balance less the active holds
+	Currency         string     `json:"currency"`
+	CreatedAt        time.Time  `json:"created_at"`
+	FrozenAt         *time.Time `json:"frozen_at,omitempty"` // set while the account is frozen
+	IsSystem         bool       `json:"is_system,omitempty"` // settlement account which balance may go negative
+}
+
+// IsFrozen reports whether the account is frozen.
+func (a Account) IsFrozen() bool {
+	return a.FrozenAt != nil
+}
+
+// ListAccountsParams is the input data to get accounts of the owner.
+//
+// Zero values of AfterID and BeforeID are ignored.
+type ListAccountsParams struct {
+	Owner    string `json:"owner"`
+	AfterID  int64  `json:"after_id"`
+	BeforeID int64  `json:"before_id"`
+	Limit    int32  `json:"limit"`
+	Offset   int32  `json:"offset"`
 }
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"time"
+)
+
+// Admin actions recorded with the staff member who performed them.
+const (
+	AdminActionSearchUsers     = "users.search"
+	AdminActionListAccounts    = "accounts.list"
+	AdminActionViewAccount     = "accounts.view"
+	AdminActionViewEntries     = "accounts.entries.view"
+	AdminActionFreezeAccount   = "accounts.freeze"
+	AdminActionUnfreezeAccount = "accounts.unfreeze"
+	AdminActionBlockSessions   = "sessions.block"
+	AdminActionVerifyLedger    = "ledger.verify"
+
+	AdminActionViewTransferLimits = "transfer_limits.view"
+	AdminActionSetTransferLimit   = "transfer_limits.set"
+	AdminActionResetTransferLimit = "transfer_limits.reset"
+
+	AdminActionListTransferReviews   = "transfer_reviews.list"
+	AdminActionApproveTransferReview = "transfer_reviews.approve"
+	AdminActionRejectTransferReview  = "transfer_reviews.reject"
+
+	AdminActionListComplianceCases   = "compliance_cases.list"
+	AdminActionClearComplianceCase   = "compliance_cases.clear"
+	AdminActionConfirmComplianceCase = "compliance_cases.confirm"
+	AdminActionReloadSanctionsList   = "sanctions_list.reload"
+)
+
+// AdminAction holds the record of an action performed through the admin API.
+type AdminAction struct {
+	ID        int64     `json:"id"`
+	Actor     string    `json:"actor"`
+	Action    string    `json:"action"`
+	Target    string    `json:"target"` // e.g. account:42 or user:alice
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateAdminActionParams is the input data to record an admin action.
+type CreateAdminActionParams struct {
+	Actor  string `json:"actor"`
+	Action string `json:"action"`
+	Target string `json:"target"`
+}
+
+// SearchUsersParams is the input data to search users by username, full name
+// or email.
+type SearchUsersParams struct {
+	Query  string `json:"query"`
+	Limit  int32  `json:"limit"`
+	Offset int32  `json:"offset"`
+}
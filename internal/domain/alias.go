@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrAliasNotFound indicates that the alias is not found.
+	ErrAliasNotFound = errors.New("alias not found")
+	// ErrAliasAlreadyExists indicates that the alias is registered by a user.
+	ErrAliasAlreadyExists = errors.New("alias already exists")
+	// ErrInvalidAlias indicates that the alias is not an email, a phone number or a handle.
+	ErrInvalidAlias = errors.New("invalid alias")
+	// ErrRecipientNotFound indicates that no user has the given username or alias.
+	ErrRecipientNotFound = errors.New("recipient not found")
+	// ErrRecipientAccountNotFound indicates that the recipient has no account in the currency.
+	ErrRecipientAccountNotFound = errors.New("recipient has no account in the currency")
+	// ErrAmbiguousRecipient indicates that the email alias matches several
+	// users, since emails are unique case-sensitively only.
+	ErrAmbiguousRecipient = errors.New("alias matches several users, pay by username instead")
+)
+
+// Alias kinds. Users register phone and handle aliases, the email alias is the
+// user email.
+const (
+	AliasKindEmail  = "email"
+	AliasKindPhone  = "phone"
+	AliasKindHandle = "handle"
+)
+
+var (
+	phoneRegexp  = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+	handleRegexp = regexp.MustCompile(`^[a-z0-9_]{3,30}$`)
+	phoneStrip   = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "")
+)
+
+// Alias holds an alternative identifier of the user to receive transfers.
+type Alias struct {
+	ID        int64     `json:"id"`
+	Username  string    `json:"username"`
+	Kind      string    `json:"kind"`
+	Value     string    `json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateAliasParams is the input data to register an alias.
+type CreateAliasParams struct {
+	Username string `json:"username"`
+	Kind     string `json:"kind"`
+	Value    string `json:"value"`
+}
+
+// Recipient identifies the recipient of a transfer by the username or the
+// alias. The recipient account is the account in the currency.
+type Recipient struct {
+	Username string `json:"username,omitempty"`
+	Alias    string `json:"alias,omitempty"`
+	Currency string `json:"currency"`
+}
+
+// ParseAlias returns the kind and the normalized value of the alias. Emails
+// contain @, phone numbers start with + and handles may start with @.
+func ParseAlias(alias string) (kind, value string, err error) {
+	alias = strings.TrimSpace(alias)
+
+	switch {
+	case strings.HasPrefix(alias, "+"):
+		kind = AliasKindPhone
+	case strings.LastIndex(alias, "@") > 0:
+		kind = AliasKindEmail
+	default:
+		kind = AliasKindHandle
+	}
+
+	value, err = NormalizeAlias(kind, alias)
+
+	return kind, value, err
+}
+
+// NormalizeAlias checks the alias of the given kind and returns its
+// normalized value: the lowercase email, the phone number in E.164 format or
+// the lowercase handle without the leading @.
+func NormalizeAlias(kind, value string) (string, error) {
+	value = strings.TrimSpace(value)
+
+	switch kind {
+	case AliasKindEmail:
+		local, domain, ok := strings.Cut(value, "@")
+		if !ok || local == "" || domain == "" || strings.Contains(domain, "@") {
+			return "", ErrInvalidAlias
+		}
+
+		return strings.ToLower(value), nil
+	case AliasKindPhone:
+		value = phoneStrip.Replace(value)
+		if !phoneRegexp.MatchString(value) {
+			return "", ErrInvalidAlias
+		}
+
+		return value, nil
+	case AliasKindHandle:
+		value = strings.ToLower(strings.TrimPrefix(value, "@"))
+		if !handleRegexp.MatchString(value) {
+			return "", ErrInvalidAlias
+		}
+
+		return value, nil
+	}
+
+	return "", ErrInvalidAlias
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Audit event types.
+const (
+	AuditUserCreated       = "user.created"
+	AuditLoginSucceeded    = "user.login_succeeded"
+	AuditLoginFailed       = "user.login_failed"
+	AuditSessionRenewed    = "session.renewed"
+	AuditAccountCreated    = "account.created"
+	AuditAliasCreated      = "alias.created"
+	AuditAliasDeleted      = "alias.deleted"
+	AuditPayeeCreated      = "payee.created"
+	AuditPayeeUpdated      = "payee.updated"
+	AuditPayeeDeleted      = "payee.deleted"
+	AuditTransferCreated   = "transfer.created"
+	AuditTransferReversed  = "transfer.reversed"
+	AuditTransferBlocked   = "transfer.blocked"
+	AuditDepositCreated    = "deposit.created"
+	AuditWithdrawalCreated = "withdrawal.created"
+	AuditHoldCreated       = "hold.created"
+	AuditHoldCaptured      = "hold.captured"
+	AuditHoldVoided        = "hold.voided"
+	AuditHoldBlocked       = "hold.blocked"
+
+	AuditScheduledTransferCreated   = "scheduled_transfer.created"
+	AuditScheduledTransferPaused    = "scheduled_transfer.paused"
+	AuditScheduledTransferResumed   = "scheduled_transfer.resumed"
+	AuditScheduledTransferCancelled = "scheduled_transfer.cancelled"
+
+	AuditTransferReviewCreated  = "transfer_review.created"
+	AuditTransferReviewApproved = "transfer_review.approved"
+	AuditTransferReviewRejected = "transfer_review.rejected"
+
+	AuditSanctionsHit            = "sanctions.hit"
+	AuditSanctionsListReloaded   = "sanctions.list_reloaded"
+	AuditComplianceCaseCleared   = "compliance_case.cleared"
+	AuditComplianceCaseConfirmed = "compliance_case.confirmed"
+)
+
+// AuditEvent holds the record of who did what. Before and After are the JSON
+// snapshots of the changed entity, either can be empty.
+type AuditEvent struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	Actor     string          `json:"actor"`
+	IP        string          `json:"ip"`
+	RequestID string          `json:"request_id"`
+	Before    json.RawMessage `json:"before,omitempty"`
+	After     json.RawMessage `json:"after,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// CreateAuditEventParams is the input data to record an audit event.
+type CreateAuditEventParams struct {
+	Type      string          `json:"type"`
+	Actor     string          `json:"actor"`
+	IP        string          `json:"ip"`
+	RequestID string          `json:"request_id"`
+	Before    json.RawMessage `json:"before"`
+	After     json.RawMessage `json:"after"`
+}
+
+// ListAuditEventsParams is the input data to query audit events.
+//
+// Zero values of the optional filters are ignored.
+type ListAuditEventsParams struct {
+	Actor     string    `json:"actor"`
+	Type      string    `json:"type"`
+	StartTime time.Time `json:"start_time"` // inclusive
+	EndTime   time.Time `json:"end_time"`   // exclusive
+	AfterID   int64     `json:"after_id"`
+	BeforeID  int64     `json:"before_id"`
+	Limit     int32     `json:"limit"`
+	Offset    int32     `json:"offset"`
+}
@@ -1,19 +1,111 @@
 package domain
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"time"
 )
 
 var (
 	// ErrEntryNotFound indicates that the entry is not found.
 	ErrEntryNotFound = errors.New("entry not found")
+	// ErrEntryChainConflict indicates that another entry has been chained to
+	// the same previous entry of the account concurrently.
+	ErrEntryChainConflict = errors.New("entry chain conflict")
 )
 
 // Entry holds balance change data for an account.
 type Entry struct {
-	ID        int64     `json:"id"`
+	ID         int64     `json:"id"`
+	AccountID  int32     `json:"account_id"`
+	Amount     string    `json:"amount"`                // can be negative or positive
+	TransferID int64     `json:"transfer_id,omitempty"` // set for entries produced by a transfer
+	CreatedAt  time.Time `json:"created_at"`
+	PrevHash   string    `json:"prev_hash,omitempty"` // hash of the previous entry of the account
+	Hash       string    `json:"hash,omitempty"`      // empty for entries created before the hash chain
+	Memo
+}
+
+// ComputeHash returns the hex encoded SHA-256 over the entry content and
+// PrevHash, so changing an entry breaks the link to the next one. The memo
+// describes the entry and doesn't change the ledger, so it is not hashed.
+func (e Entry) ComputeHash() string {
+	content := fmt.Sprintf("%d|%d|%s|%d|%s|%s",
+		e.ID,
+		e.AccountID,
+		e.Amount,
+		e.TransferID,
+		e.CreatedAt.UTC().Format(time.RFC3339Nano),
+		e.PrevHash,
+	)
+
+	sum := sha256.Sum256([]byte(content))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateEntryParams is the input data to create an entry.
+type CreateEntryParams struct {
+	AccountID  int32  `json:"account_id"`
+	Amount     string `json:"amount"`
+	TransferID int64  `json:"transfer_id"`
+	Memo
+}
+
+// StatementLine holds an account entry with the account balance right after it.
+type StatementLine struct {
+	Entry
+	Balance string `json:"balance"`
+}
+
+// ListEntriesParams is the input data to get the account statement.
+//
+// Zero values of the optional filters are ignored.
+type ListEntriesParams struct {
 	AccountID int32     `json:"account_id"`
-	Amount    string    // can be negative or positive `json:"amount"`
-	CreatedAt time.Time `json:"created_at"`
+	StartTime time.Time `json:"start_time"` // inclusive
+	EndTime   time.Time `json:"end_time"`   // exclusive
+	AfterID   int64     `json:"after_id"`
+	BeforeID  int64     `json:"before_id"`
+	Limit     int32     `json:"limit"`
+	Offset    int32     `json:"offset"`
+}
+
+// Reasons of a broken link in the entries hash chain.
+const (
+	ChainBreakHashMismatch     = "hash mismatch"
+	ChainBreakPrevHashMismatch = "previous hash mismatch"
+	ChainBreakMissingHash      = "missing hash"
+)
+
+// ChainBreak holds the first broken link in the account entries hash chain.
+type ChainBreak struct {
+	AccountID int32  `json:"account_id"`
+	EntryID   int64  `json:"entry_id"`
+	Reason    string `json:"reason"`
+}
+
+// ChainReport holds the result of the entries hash chain verification.
+type ChainReport struct {
+	Accounts int64        `json:"accounts"` // number of accounts with entries
+	Entries  int64        `json:"entries"`
+	Breaks   []ChainBreak `json:"breaks"` // the first broken link of each account
+}
+
+// Valid reports whether no broken link has been found.
+func (r ChainReport) Valid() bool {
+	return len(r.Breaks) == 0
+}
+
+// ListChainParams is the input data to walk the entries hash chain in
+// (account_id, id) order.
+//
+// Zero AccountID means all accounts.
+type ListChainParams struct {
+	AccountID      int32 `json:"account_id"`
+	AfterAccountID int32 `json:"after_account_id"`
+	AfterID        int64 `json:"after_id"`
+	Limit          int32 `json:"limit"`
 }
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrFXQuoteNotFound indicates that the exchange rate quote is not found.
+	ErrFXQuoteNotFound = errors.New("fx quote not found")
+	// ErrFXQuoteExpired indicates that the exchange rate quote has expired.
+	ErrFXQuoteExpired = errors.New("fx quote expired")
+	// ErrFXQuoteUsed indicates that the exchange rate quote has already been used.
+	ErrFXQuoteUsed = errors.New("fx quote already used")
+	// ErrFXQuoteCurrencyMismatch indicates that the quote currencies differ from the transfer accounts currencies.
+	ErrFXQuoteCurrencyMismatch = errors.New("fx quote currencies mismatch transfer accounts")
+	// ErrFXRateNotFound indicates that there is no exchange rate for the currency pair.
+	ErrFXRateNotFound = errors.New("fx rate not found")
+	// ErrSameCurrency indicates that the currencies to exchange are the same.
+	ErrSameCurrency = errors.New("currencies must be different")
+)
+
+// FXQuote holds an exchange rate locked for a user until it expires.
+type FXQuote struct {
+	ID           uuid.UUID  `json:"id"`
+	Username     string     `json:"username"`
+	FromCurrency string     `json:"from_currency"`
+	ToCurrency   string     `json:"to_currency"`
+	Rate         string     `json:"rate"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	UsedAt       *time.Time `json:"used_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// CreateFXQuoteParams is the input data to create an exchange rate quote.
+type CreateFXQuoteParams struct {
+	ID           uuid.UUID `json:"id"`
+	Username     string    `json:"username"`
+	FromCurrency string    `json:"from_currency"`
+	ToCurrency   string    `json:"to_currency"`
+	Rate         string    `json:"rate"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrHoldNotFound indicates that the hold is not found.
+	ErrHoldNotFound = errors.New("hold not found")
+	// ErrHoldNotActive indicates that the hold has already been captured, voided or expired.
+	ErrHoldNotActive = errors.New("hold is not active")
+	// ErrHoldExpired indicates that the hold has expired and can't be captured.
+	ErrHoldExpired = errors.New("hold expired")
+	// ErrHoldAmountExceeded indicates that the capture amount exceeds the held amount.
+	ErrHoldAmountExceeded = errors.New("capture amount exceeds the held amount")
+	// ErrHoldOwnerMismatch indicates that the requested hold involves none of the user's accounts.
+	ErrHoldOwnerMismatch = errors.New("hold doesn't belong to the authenticated user")
+	// ErrHoldBlocked indicates that the hold is flagged by the transaction monitoring rules.
+	ErrHoldBlocked = errors.New("hold blocked by transaction monitoring")
+)
+
+// Hold statuses.
+const (
+	HoldStatusActive   = "active"
+	HoldStatusCaptured = "captured"
+	HoldStatusVoided   = "voided"
+	HoldStatusExpired  = "expired"
+)
+
+// Hold reserves the amount of the account available balance for a transfer
+// to the to account until it is captured, voided or expires.
+type Hold struct {
+	ID             int64      `json:"id"`
+	AccountID      int32      `json:"account_id"`
+	ToAccountID    int32      `json:"to_account_id"`
+	Amount         string     `json:"amount"`
+	Status         string     `json:"status"`
+	CapturedAmount string     `json:"captured_amount,omitempty"` // set once captured
+	TransferID     int64      `json:"transfer_id,omitempty"`     // set once captured
+	ExpiresAt      time.Time  `json:"expires_at"`
+	ClosedAt       *time.Time `json:"closed_at,omitempty"` // set once no longer active
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// CreateHoldParams is the input data to place a hold on the account.
+type CreateHoldParams struct {
+	AccountID   int32     `json:"account_id"`
+	ToAccountID int32     `json:"to_account_id"`
+	Amount      string    `json:"amount"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	// Limit, if set, is checked by the repo against the account outgoing
+	// transfers and active holds of the day and month.
+	Limit *TransferLimit `json:"-"`
+	// SessionID is the session of the access token the hold is placed with.
+	SessionID uuid.UUID `json:"-"`
+}
+
+// CloseHoldParams is the input data to close the active hold.
+type CloseHoldParams struct {
+	ID             int64  `json:"id"`
+	Status         string `json:"status"`
+	CapturedAmount string `json:"captured_amount"`
+	TransferID     int64  `json:"transfer_id"`
+}
+
+// CaptureHoldResult is the result of the hold capture transaction.
+type CaptureHoldResult struct {
+	Hold     Hold             `json:"hold"`
+	Transfer TransferTxResult `json:"transfer"`
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrIdempotencyKeyNotFound indicates that the idempotency key is not found.
+	ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
+	// ErrIdempotencyKeyExists indicates that the idempotency key has already been stored.
+	ErrIdempotencyKeyExists = errors.New("idempotency key already exists")
+	// ErrInvalidIdempotencyKey indicates invalid idempotency key.
+	ErrInvalidIdempotencyKey = errors.New("invalid idempotency key")
+	// ErrIdempotencyKeyMismatch indicates that the idempotency key was used with a different payload.
+	ErrIdempotencyKeyMismatch = errors.New("idempotency key is already used with a different payload")
+)
+
+// IdempotencyKey holds the first result of a request sent with an idempotency key.
+//
+// ResponseBody is the result of the successful request, or the whole response
+// body if ResponseStatus is an error status.
+type IdempotencyKey struct {
+	Key            string    `json:"key"`
+	Username       string    `json:"username"`
+	RequestHash    string    `json:"request_hash"`
+	ResponseStatus int       `json:"response_status"`
+	ResponseBody   []byte    `json:"response_body"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// CreateIdempotencyKeyParams is the input data to store the result of an idempotent request.
+type CreateIdempotencyKeyParams struct {
+	Key            string `json:"key"`
+	Username       string `json:"username"`
+	RequestHash    string `json:"request_hash"`
+	ResponseStatus int    `json:"response_status"`
+	ResponseBody   []byte `json:"response_body"`
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrTransferLimitExceeded indicates that the transfer exceeds one of the
+	// sender's outgoing transfer limits. It is wrapped by TransferLimitError.
+	ErrTransferLimitExceeded = errors.New("transfer limit exceeded")
+	// ErrTransferLimitNotFound indicates that the user has no limit override in the currency.
+	ErrTransferLimitNotFound = errors.New("transfer limit not found")
+	// ErrInvalidTransferLimit indicates that the limit is not a positive amount.
+	ErrInvalidTransferLimit = errors.New("invalid transfer limit")
+)
+
+// Transfer limit periods.
+const (
+	LimitPerTransaction = "per_transaction"
+	LimitDaily          = "daily"
+	LimitMonthly        = "monthly"
+)
+
+// TransferLimit holds the outgoing transfer limits of the user in the
+// currency. An empty limit is unlimited, or, in a per user override, falls
+// back to the configured limit.
+//
+// Daily and monthly limits cap the sum of the outgoing transfers since the
+// start of the UTC day and month.
+type TransferLimit struct {
+	Username       string `json:"username,omitempty"`
+	Currency       string `json:"currency"`
+	PerTransaction string `json:"per_transaction"`
+	Daily          string `json:"daily"`
+	Monthly        string `json:"monthly"`
+}
+
+// Override returns the limit with the non-empty limits of o.
+func (l TransferLimit) Override(o TransferLimit) TransferLimit {
+	if o.PerTransaction != "" {
+		l.PerTransaction = o.PerTransaction
+	}
+
+	if o.Daily != "" {
+		l.Daily = o.Daily
+	}
+
+	if o.Monthly != "" {
+		l.Monthly = o.Monthly
+	}
+
+	return l
+}
+
+// TransferLimitError reports the exceeded limit and the amount the user can
+// still transfer within it.
+type TransferLimitError struct {
+	Limit     string `json:"limit"`
+	Currency  string `json:"currency"`
+	Remaining string `json:"remaining"`
+}
+
+func (e *TransferLimitError) Error() string {
+	return fmt.Sprintf("%s transfer limit exceeded, %s %s remaining", e.Limit, e.Remaining, e.Currency)
+}
+
+// Unwrap returns ErrTransferLimitExceeded.
+func (e *TransferLimitError) Unwrap() error {
+	return ErrTransferLimitExceeded
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "errors"
+
+// Memo limits. The metadata size is the length of its JSON encoding.
+const (
+	MaxMemoDescriptionLength = 255
+	MaxMemoReferenceLength   = 64
+	MaxMemoMetadataKeys      = 50
+	MaxMemoMetadataSize      = 4096
+)
+
+// ErrMetadataTooLarge indicates that the JSON encoded metadata exceeds MaxMemoMetadataSize.
+var ErrMetadataTooLarge = errors.New("metadata is too large")
+
+// Memo holds the optional details of a transfer given by the user. The entries
+// of the transfer carry the memo of the transfer.
+type Memo struct {
+	Description string         `json:"description"`
+	Reference   string         `json:"reference"` // external reference, e.g. an invoice number
+	Metadata    map[string]any `json:"metadata"`  // free-form JSON object
+}
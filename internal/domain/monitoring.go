@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrTransferBlocked indicates that the transfer is blocked by the transaction monitoring rules.
+	ErrTransferBlocked = errors.New("transfer blocked by transaction monitoring")
+	// ErrTransferReviewNotFound indicates that the transfer review is not found.
+	ErrTransferReviewNotFound = errors.New("transfer review not found")
+	// ErrTransferReviewClosed indicates that the transfer has already been approved or rejected.
+	ErrTransferReviewClosed = errors.New("transfer review is already closed")
+	// ErrTransferReviewRejected indicates that the transfer held for review has been rejected.
+	ErrTransferReviewRejected = errors.New("transfer rejected by review")
+)
+
+// Transaction monitoring decisions, from the least to the most severe.
+const (
+	RiskDecisionAllow  = "allow"
+	RiskDecisionReview = "review"
+	RiskDecisionBlock  = "block"
+)
+
+// Transfer review statuses.
+const (
+	TransferReviewStatusPending  = "pending_review"
+	TransferReviewStatusApproved = "approved"
+	TransferReviewStatusRejected = "rejected"
+)
+
+// RiskCheck is the transfer assessed by the transaction monitoring rules.
+type RiskCheck struct {
+	Username      string         `json:"username"`
+	SessionID     uuid.UUID      `json:"session_id"` // zero if the transfer is not made within a session
+	FromAccountID int32          `json:"from_account_id"`
+	ToAccountID   int32          `json:"to_account_id"`
+	Amount        string         `json:"amount"`
+	Limit         *TransferLimit `json:"limit,omitempty"` // the sender's limits, if checked
+}
+
+// RiskAssessment is the decision of the transaction monitoring rules and the
+// names of the rules which made it.
+type RiskAssessment struct {
+	Decision string   `json:"decision"`
+	Rules    []string `json:"rules"`
+}
+
+// TransferReview holds the transfer flagged for review. Its amount is held on
+// the from account until a staff member approves or rejects it.
+type TransferReview struct {
+	ID            int64      `json:"id"`
+	Username      string     `json:"username"`
+	FromAccountID int32      `json:"from_account_id"`
+	ToAccountID   int32      `json:"to_account_id"`
+	Amount        string     `json:"amount"`
+	Rules         []string   `json:"rules"` // names of the rules which flagged the transfer
+	Status        string     `json:"status"`
+	TransferID    int64      `json:"transfer_id,omitempty"` // set once approved
+	Reviewer      string     `json:"reviewer,omitempty"`    // set once approved or rejected
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	Memo
+}
+
+// CreateTransferReviewParams is the input data to hold the transfer for review.
+type CreateTransferReviewParams struct {
+	Username      string   `json:"username"`
+	FromAccountID int32    `json:"from_account_id"`
+	ToAccountID   int32    `json:"to_account_id"`
+	Amount        string   `json:"amount"`
+	Rules         []string `json:"rules"`
+	Memo
+}
+
+// CloseTransferReviewParams is the input data to approve or reject the
+// pending transfer review.
+type CloseTransferReviewParams struct {
+	ID         int64  `json:"id"`
+	Status     string `json:"status"`
+	Reviewer   string `json:"reviewer"`
+	TransferID int64  `json:"transfer_id"` // set on approval
+}
+
+// ListTransferReviewsParams is the input data to list the transfer reviews,
+// optionally with the given status.
+type ListTransferReviewsParams struct {
+	Status   string `json:"status"`
+	AfterID  int64  `json:"after_id"`
+	BeforeID int64  `json:"before_id"`
+	Limit    int32  `json:"limit"`
+	Offset   int32  `json:"offset"`
+}
+
+// ApproveTransferReviewResult is the result of the transfer review approval.
+type ApproveTransferReviewResult struct {
+	Review   TransferReview   `json:"review"`
+	Transfer TransferTxResult `json:"transfer"`
+}
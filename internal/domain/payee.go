@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrPayeeNotFound indicates that the user has no payee with the given id.
+	ErrPayeeNotFound = errors.New("payee not found")
+	// ErrPayeeAlreadyExists indicates that the user has a payee with the same account or nickname.
+	ErrPayeeAlreadyExists = errors.New("payee already exists")
+	// ErrPayeeCoolingOff indicates that the payee is new and can't be paid until its cooling-off period ends.
+	ErrPayeeCoolingOff = errors.New("payee is in cooling-off period")
+)
+
+// Payee holds an account the user saved to pay repeatedly.
+type Payee struct {
+	ID        int64  `json:"id"`
+	Username  string `json:"username"`
+	Nickname  string `json:"nickname"`
+	AccountID int32  `json:"account_id"`
+	Currency  string `json:"currency"`
+	// UsableAt is the end of the cooling-off period. Transfers to the payee
+	// are rejected before it.
+	UsableAt   time.Time  `json:"usable_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatePayeeParams is the input data to save a payee.
+type CreatePayeeParams struct {
+	Username string `json:"username"`
+	Nickname string `json:"nickname"`
+	// AccountID is the payee account. If it is zero, Recipient is resolved
+	// to the account.
+	AccountID int32      `json:"account_id"`
+	Recipient *Recipient `json:"recipient,omitempty"`
+	// Currency and UsableAt are set by the service.
+	Currency string    `json:"-"`
+	UsableAt time.Time `json:"-"`
+}
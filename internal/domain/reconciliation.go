@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"time"
+)
+
+// Kinds of the ledger discrepancies found by the reconciliation.
+const (
+	// DiscrepancyBalance means the account balance differs from the sum of its
+	// entries.
+	DiscrepancyBalance = "balance"
+	// DiscrepancyTransferEntries means the transfer does not have exactly two
+	// entries.
+	DiscrepancyTransferEntries = "transfer_entries"
+	// DiscrepancyTransferDebit means the debit entry of the transfer differs
+	// from the negated transfer amount.
+	DiscrepancyTransferDebit = "transfer_debit"
+	// DiscrepancyTransferCredit means the credit entry of the transfer differs
+	// from the transfer amount, converted at the applied rate for
+	// cross-currency transfers, so the entries do not net to zero.
+	DiscrepancyTransferCredit = "transfer_credit"
+)
+
+// Discrepancy holds a ledger invariant violation.
+type Discrepancy struct {
+	Kind       string `json:"kind"`
+	AccountID  int32  `json:"account_id,omitempty"`
+	TransferID int64  `json:"transfer_id,omitempty"`
+	Expected   string `json:"expected"`
+	Actual     string `json:"actual"`
+}
+
+// ReconciliationReport holds the result of the ledger reconciliation run.
+type ReconciliationReport struct {
+	ID            int64         `json:"id"`
+	Accounts      int64         `json:"accounts"`  // number of checked accounts
+	Transfers     int64         `json:"transfers"` // number of checked transfers
+	Discrepancies []Discrepancy `json:"discrepancies"`
+	StartedAt     time.Time     `json:"started_at"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+// Balanced reports whether no discrepancy has been found.
+func (r ReconciliationReport) Balanced() bool {
+	return len(r.Discrepancies) == 0
+}
+
+// CreateReconciliationReportParams is the input data to store the
+// reconciliation report.
+type CreateReconciliationReportParams struct {
+	Accounts      int64         `json:"accounts"`
+	Transfers     int64         `json:"transfers"`
+	Discrepancies []Discrepancy `json:"discrepancies"`
+	StartedAt     time.Time     `json:"started_at"`
+}
@@ -0,0 +1,76 @@
+package domain
+
+import "errors"
+
+// ErrScopeNotAllowed indicates that the requested scope is not granted to the user role.
+var ErrScopeNotAllowed = errors.New("scope is not allowed for the user role")
+
+// User roles.
+const (
+	RoleCustomer = "customer"
+	RoleSupport  = "support"
+	RoleAdmin    = "admin"
+	// RoleIntegration is the role of payment integrations which move money
+	// in and out of the bank.
+	RoleIntegration = "integration"
+)
+
+// Token scopes which guard the routes.
+const (
+	ScopeAccountsRead   = "accounts:read"
+	ScopeAccountsWrite  = "accounts:write"
+	ScopeTransfersRead  = "transfers:read"
+	ScopeTransfersWrite = "transfers:write"
+	ScopeSessionsRead   = "sessions:read"
+	ScopeSessionsWrite  = "sessions:write"
+	ScopeAdminRead      = "admin:read"
+	ScopeAdminWrite     = "admin:write"
+	ScopeCashWrite      = "cash:write"
+)
+
+var customerScopes = []string{
+	ScopeAccountsRead,
+	ScopeAccountsWrite,
+	ScopeTransfersRead,
+	ScopeTransfersWrite,
+	ScopeSessionsRead,
+	ScopeSessionsWrite,
+}
+
+var roleScopes = map[string][]string{
+	RoleCustomer:    customerScopes,
+	RoleSupport:     append(append([]string{}, customerScopes...), ScopeAdminRead),
+	RoleAdmin:       append(append([]string{}, customerScopes...), ScopeAdminRead, ScopeAdminWrite, ScopeCashWrite),
+	RoleIntegration: {ScopeCashWrite},
+}
+
+// RoleScopes returns the scopes granted to the role.
+func RoleScopes(role string) []string {
+	return append([]string{}, roleScopes[role]...)
+}
+
+// GrantScopes returns the requested scopes if all of them are granted to the
+// role, or all the role scopes if none are requested.
+func GrantScopes(role string, requested []string) ([]string, error) {
+	granted := RoleScopes(role)
+	if len(requested) == 0 {
+		return granted, nil
+	}
+
+	for _, r := range requested {
+		found := false
+
+		for _, g := range granted {
+			if r == g {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, ErrScopeNotAllowed
+		}
+	}
+
+	return append([]string{}, requested...), nil
+}
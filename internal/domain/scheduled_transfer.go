@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrScheduledTransferNotFound indicates that the scheduled transfer is not found.
+	ErrScheduledTransferNotFound = errors.New("scheduled transfer not found")
+	// ErrScheduledTransferOwnerMismatch indicates that the scheduled transfer is not created by the user.
+	ErrScheduledTransferOwnerMismatch = errors.New("scheduled transfer doesn't belong to the authenticated user")
+	// ErrScheduledTransferStatus indicates that the action is not allowed in the current status.
+	ErrScheduledTransferStatus = errors.New("action is not allowed in the scheduled transfer status")
+	// ErrInvalidSchedule indicates that the start or the recurrence of the scheduled transfer is invalid.
+	ErrInvalidSchedule = errors.New("invalid schedule")
+	// ErrScheduledRunExists indicates that the run has already been started by another scheduler.
+	ErrScheduledRunExists = errors.New("scheduled transfer run already exists")
+)
+
+// Scheduled transfer statuses. Completed scheduled transfers have no more runs.
+const (
+	ScheduledTransferStatusActive    = "active"
+	ScheduledTransferStatusPaused    = "paused"
+	ScheduledTransferStatusCancelled = "cancelled"
+	ScheduledTransferStatusCompleted = "completed"
+)
+
+// Scheduled transfer run statuses. The run pending review succeeds or fails
+// once its transfer review is approved or rejected.
+const (
+	ScheduledRunStatusStarted       = "started"
+	ScheduledRunStatusSucceeded     = "succeeded"
+	ScheduledRunStatusFailed        = "failed"
+	ScheduledRunStatusPendingReview = "pending_review"
+)
+
+// ScheduledTransfer is a one-off future or recurring transfer executed by the
+// scheduler on behalf of the user.
+type ScheduledTransfer struct {
+	ID            int64  `json:"id"`
+	Username      string `json:"username"`
+	FromAccountID int32  `json:"from_account_id"`
+	ToAccountID   int32  `json:"to_account_id"`
+	Amount        string `json:"amount"`
+	// Recurrence is empty for one-off transfers, @monthly or a five field
+	// cron expression in UTC.
+	Recurrence string     `json:"recurrence,omitempty"`
+	StartAt    time.Time  `json:"start_at"`
+	NextRunAt  *time.Time `json:"next_run_at,omitempty"` // nil once there are no more runs
+	Status     string     `json:"status"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreateScheduledTransferParams is the input data to schedule a transfer.
+type CreateScheduledTransferParams struct {
+	Username      string    `json:"username"`
+	FromAccountID int32     `json:"from_account_id"`
+	ToAccountID   int32     `json:"to_account_id"`
+	Amount        string    `json:"amount"`
+	Recurrence    string    `json:"recurrence"`
+	StartAt       time.Time `json:"start_at"`
+	// NextRunAt is the first run time computed by the service.
+	NextRunAt time.Time `json:"-"`
+}
+
+// UpdateScheduledTransferStatusParams is the input data to change the status
+// of the scheduled transfer. The update is applied only if the scheduled
+// transfer is still in the From status.
+type UpdateScheduledTransferStatusParams struct {
+	ID        int64      `json:"id"`
+	From      string     `json:"from"`
+	Status    string     `json:"status"`
+	NextRunAt *time.Time `json:"next_run_at"`
+}
+
+// ListScheduledTransfersParams is the input data to get scheduled transfers of
+// the user.
+//
+// Zero values of AfterID and BeforeID are ignored.
+type ListScheduledTransfersParams struct {
+	Username string `json:"username"`
+	AfterID  int64  `json:"after_id"`
+	BeforeID int64  `json:"before_id"`
+	Limit    int32  `json:"limit"`
+	Offset   int32  `json:"offset"`
+}
+
+// ScheduledTransferRun holds the outcome of one run of the scheduled transfer.
+type ScheduledTransferRun struct {
+	ID                  int64      `json:"id"`
+	ScheduledTransferID int64      `json:"scheduled_transfer_id"`
+	ScheduledAt         time.Time  `json:"scheduled_at"`
+	Status              string     `json:"status"`
+	TransferID          int64      `json:"transfer_id,omitempty"`        // set if succeeded
+	TransferReviewID    int64      `json:"transfer_review_id,omitempty"` // set if held for review
+	Error               string     `json:"error,omitempty"`              // set if failed, e.g. insufficient balance
+	FinishedAt          *time.Time `json:"finished_at,omitempty"`        // set once succeeded or failed
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+// FinishScheduledTransferRunParams is the input data to record the outcome of
+// the started run.
+type FinishScheduledTransferRunParams struct {
+	ID               int64  `json:"id"`
+	Status           string `json:"status"`
+	TransferID       int64  `json:"transfer_id"`
+	TransferReviewID int64  `json:"transfer_review_id"`
+	Error            string `json:"error"`
+}
+
+// ListScheduledTransferRunsParams is the input data to get runs of the
+// scheduled transfer.
+//
+// Zero values of AfterID and BeforeID are ignored.
+type ListScheduledTransferRunsParams struct {
+	ScheduledTransferID int64 `json:"scheduled_transfer_id"`
+	AfterID             int64 `json:"after_id"`
+	BeforeID            int64 `json:"before_id"`
+	Limit               int32 `json:"limit"`
+	Offset              int32 `json:"offset"`
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrSanctionsHit indicates that the screened name matches the sanctions list.
+	ErrSanctionsHit = errors.New("name matches the sanctions list")
+	// ErrSanctionsListNotConfigured indicates that there is no sanctions list file to reload.
+	ErrSanctionsListNotConfigured = errors.New("sanctions list is not configured")
+	// ErrInvalidSanctionsList indicates that the sanctions list file can't be read.
+	ErrInvalidSanctionsList = errors.New("invalid sanctions list file")
+	// ErrComplianceCaseNotFound indicates that the compliance case is not found.
+	ErrComplianceCaseNotFound = errors.New("compliance case not found")
+	// ErrComplianceCaseClosed indicates that the compliance case has already been cleared or confirmed.
+	ErrComplianceCaseClosed = errors.New("compliance case is already closed")
+)
+
+// Screened actions.
+const (
+	ScreeningActionUserCreate = "user_create"
+	ScreeningActionTransfer   = "transfer"
+)
+
+// Compliance case statuses. A cleared case is a false positive, so the same
+// name of the same user passes the screening afterwards.
+const (
+	ComplianceCaseStatusOpen      = "open"
+	ComplianceCaseStatusCleared   = "cleared"
+	ComplianceCaseStatusConfirmed = "confirmed"
+)
+
+// SanctionsMatch is the sanctions list entry similar to the screened name.
+type SanctionsMatch struct {
+	UID   string  `json:"uid"`
+	Name  string  `json:"name"` // the matched name or alias of the entry
+	Score float64 `json:"score"`
+}
+
+// SanctionsList describes the loaded sanctions list.
+type SanctionsList struct {
+	File     string    `json:"file"`
+	Entries  int       `json:"entries"`
+	LoadedAt time.Time `json:"loaded_at"`
+}
+
+// ComplianceCase holds the action blocked by the sanctions screening for
+// compliance review.
+type ComplianceCase struct {
+	ID         int64            `json:"id"`
+	Action     string           `json:"action"`
+	Actor      string           `json:"actor"`    // the user who attempted the action
+	Username   string           `json:"username"` // the screened user
+	Name       string           `json:"name"`     // the screened full name
+	Matches    []SanctionsMatch `json:"matches"`
+	Status     string           `json:"status"`
+	Reviewer   string           `json:"reviewer,omitempty"` // set once cleared or confirmed
+	ReviewedAt *time.Time       `json:"reviewed_at,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+// CreateComplianceCaseParams is the input data to open a compliance case.
+type CreateComplianceCaseParams struct {
+	Action   string           `json:"action"`
+	Actor    string           `json:"actor"`
+	Username string           `json:"username"`
+	Name     string           `json:"name"`
+	Matches  []SanctionsMatch `json:"matches"`
+}
+
+// CloseComplianceCaseParams is the input data to clear or confirm the open
+// compliance case.
+type CloseComplianceCaseParams struct {
+	ID       int64  `json:"id"`
+	Status   string `json:"status"`
+	Reviewer string `json:"reviewer"`
+}
+
+// ListComplianceCasesParams is the input data to list the compliance cases,
+// optionally with the given status.
+type ListComplianceCasesParams struct {
+	Status   string `json:"status"`
+	AfterID  int64  `json:"after_id"`
+	BeforeID int64  `json:"before_id"`
+	Limit    int32  `json:"limit"`
+	Offset   int32  `json:"offset"`
+}
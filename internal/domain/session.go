@@ -18,18 +18,30 @@ var (
 	ErrExpiredSession = errors.New("expired session")
 	// ErrSessionNotFound indicates that the session is not found.
 	ErrSessionNotFound = errors.New("session not found")
+	// ErrSessionOwnerMismatch indicates that the requested session is not owned by the user.
+	ErrSessionOwnerMismatch = errors.New("session doesn't belong to the authenticated user")
+	// ErrRefreshTokenReused indicates that the refresh token has already been rotated.
+	ErrRefreshTokenReused = errors.New("refresh token has already been used")
 )
 
 // Session holds session data for particular domain.
+//
+// Each refresh token renewal creates a child session. All sessions created
+// from the same login share FamilyID. Scopes are the scopes requested at login,
+// empty Scopes grant all scopes of the user role.
 type Session struct {
-	ID           uuid.UUID `json:"id"`
-	Username     string    `json:"username"`
-	RefreshToken string    `json:"refresh_token"`
-	UserAgent    string    `json:"user_agent"`
-	ClientIP     string    `json:"client_ip"`
-	IsBlocked    bool      `json:"is_blocked"`
-	ExpiresAt    time.Time `json:"expires_at"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID           uuid.UUID  `json:"id"`
+	Username     string     `json:"username"`
+	RefreshToken string     `json:"refresh_token"`
+	UserAgent    string     `json:"user_agent"`
+	ClientIP     string     `json:"client_ip"`
+	IsBlocked    bool       `json:"is_blocked"`
+	ParentID     *uuid.UUID `json:"parent_id,omitempty"`
+	FamilyID     uuid.UUID  `json:"family_id"`
+	RotatedAt    *time.Time `json:"rotated_at,omitempty"`
+	Scopes       []string   `json:"scopes"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	CreatedAt    time.Time  `json:"created_at"`
 }
 
 // CreateSessionParams holds data nedeed for Session creation.
@@ -40,5 +52,6 @@ type CreateSessionParams struct {
 	UserAgent    string    `json:"user_agent"`
 	ClientIP     string    `json:"client_ip"`
 	IsBlocked    bool      `json:"is_blocked"`
+	Scopes       []string  `json:"scopes"`
 	ExpiresAt    time.Time `json:"expires_at"`
 }
@@ -3,6 +3,8 @@ package domain
 import (
 	"errors"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 var (
@@ -18,15 +20,57 @@ var (
 	ErrInsufficientBalance = errors.New("insufficient balance")
 	// ErrInvalidOwner indicates that the user is unauthorized to transfer money from the account.
 	ErrInvalidOwner = errors.New("unauthorized owner")
+	// ErrTransferOwnerMismatch indicates that the requested transfer involves none of the user's accounts.
+	ErrTransferOwnerMismatch = errors.New("transfer doesn't belong to the authenticated user")
+	// ErrInvalidDateRange indicates that the end of the date range is before its start.
+	ErrInvalidDateRange = errors.New("invalid date range")
+	// ErrTransferNotReversible indicates that the transfer is not a same-currency transfer between users.
+	ErrTransferNotReversible = errors.New("transfer can't be reversed")
+	// ErrTransferReversed indicates that the whole transfer amount has already been refunded.
+	ErrTransferReversed = errors.New("transfer has already been reversed")
+	// ErrRefundAmountExceeded indicates that the refund exceeds the amount left to refund.
+	ErrRefundAmountExceeded = errors.New("refund amount exceeds the transfer amount left to refund")
+	// ErrReversalNotAllowed indicates that the user is neither the recipient of the transfer nor an admin.
+	ErrReversalNotAllowed = errors.New("only the recipient or an admin can reverse the transfer")
+)
+
+// Transfer kinds. Deposits and withdrawals move money between the account and
+// the settlement account of its currency. Reversals move money back from the
+// recipient of the reversed transfer to its sender.
+const (
+	TransferKindTransfer   = "transfer"
+	TransferKindDeposit    = "deposit"
+	TransferKindWithdrawal = "withdrawal"
+	TransferKindReversal   = "reversal"
+)
+
+// Transfer statuses. A transfer is partially refunded until the sum of its
+// reversals reaches its amount.
+const (
+	TransferStatusCompleted         = "completed"
+	TransferStatusPartiallyRefunded = "partially_refunded"
+	TransferStatusReversed          = "reversed"
+)
+
+// Transfer directions relative to the user's accounts.
+const (
+	TransferDirectionIncoming = "incoming"
+	TransferDirectionOutgoing = "outgoing"
 )
 
 // Transfer holds transfer data between two accounts.
 type Transfer struct {
-	ID            int64     `json:"id"`
-	FromAccountID int32     `json:"from_account_id"`
-	ToAccountID   int32     `json:"to_account_id"`
-	Amount        string    `json:"amount"` // must be positive
-	CreatedAt     time.Time `json:"created_at"`
+	ID                 int64     `json:"id"`
+	FromAccountID      int32     `json:"from_account_id"`
+	ToAccountID        int32     `json:"to_account_id"`
+	Amount             string    `json:"amount"`            // must be positive
+	FXRate             string    `json:"fx_rate,omitempty"` // set for cross-currency transfers
+	Kind               string    `json:"kind"`
+	Status             string    `json:"status"`
+	RefundedAmount     string    `json:"refunded_amount"`                // sum of the reversals of the transfer
+	ReversedTransferID int64     `json:"reversed_transfer_id,omitempty"` // set on reversals
+	CreatedAt          time.Time `json:"created_at"`
+	Memo
 }
 
 // CreateTransferParams is the input data for the transfer transaction.
@@ -34,21 +78,87 @@ type CreateTransferParams struct {
 	FromAccountID int32  `json:"from_account_id"`
 	ToAccountID   int32  `json:"to_account_id"`
 	Amount        string `json:"amount"`
+	// Recipient, if set and ToAccountID is zero, is resolved to the to
+	// account by the service.
+	Recipient *Recipient `json:"recipient,omitempty"`
+	// PayeeID, if set and ToAccountID is zero, is the sender's payee whose
+	// account is the to account.
+	PayeeID int64 `json:"payee_id,omitempty"`
+	// FXQuoteID, if set, converts the amount to the to account currency
+	// at the rate locked by the quote.
+	FXQuoteID *uuid.UUID `json:"fx_quote_id,omitempty"`
+	// FXRate is the applied exchange rate stored on the transfer.
+	FXRate string `json:"-"`
+	// Limit, if set, is checked by the repo against the from account outgoing
+	// transfers of the day and month.
+	Limit *TransferLimit `json:"-"`
+	// SessionID is the session of the access token the transfer is made with,
+	// zero for transfers made outside of a session.
+	SessionID uuid.UUID `json:"-"`
+	// Idempotency, if set, stores the transfer result under the idempotency key
+	// within the transfer transaction.
+	Idempotency *CreateIdempotencyKeyParams `json:"-"`
+	// Kind is set by the repo, transfer by default.
+	Kind string `json:"-"`
+	// ReversedTransferID is set by the repo on reversals.
+	ReversedTransferID int64 `json:"-"`
+	Memo
+}
+
+// ReverseTransferParams is the input data to refund the transfer.
+type ReverseTransferParams struct {
+	TransferID int64 `json:"transfer_id"`
+	// Amount is the refund amount, up to the transfer amount left to refund.
+	// Empty amount refunds all of it.
+	Amount string `json:"amount"`
+}
+
+// ReversalTxResult is the result of the reversal transaction.
+type ReversalTxResult struct {
+	// ReversedTransfer is the reversed transfer with the updated status.
+	ReversedTransfer Transfer `json:"reversed_transfer"`
+	// Reversal is the compensating transfer.
+	Reversal TransferTxResult `json:"reversal"`
+}
+
+// CreateCashParams is the input data for a deposit to or a withdrawal from the
+// account.
+type CreateCashParams struct {
+	AccountID int32  `json:"account_id"`
+	Amount    string `json:"amount"`
+	// Idempotency, if set, stores the result under the idempotency key within
+	// the transaction.
+	Idempotency *CreateIdempotencyKeyParams `json:"-"`
+	Memo
 }
 
-// ListTransfersParams is the input data to get transfers between two accounts.
+// ListTransfersParams is the input data to get transfers of the user's accounts.
+//
+// Zero values of the optional filters are ignored.
 type ListTransfersParams struct {
-	FromAccountID int32 `json:"from_account_id"`
-	ToAccountID   int32 `json:"to_account_id"`
-	Limit         int32 `json:"limit"`
-	Offset        int32 `json:"offset"`
+	Username  string    `json:"username"`
+	AccountID int32     `json:"account_id"`
+	Direction string    `json:"direction"`
+	MinAmount string    `json:"min_amount"`
+	MaxAmount string    `json:"max_amount"`
+	StartTime time.Time `json:"start_time"` // inclusive
+	EndTime   time.Time `json:"end_time"`   // exclusive
+	Search    string    `json:"search"`     // case-insensitive substring of the description or the reference
+	Reference string    `json:"reference"`
+	AfterID   int64     `json:"after_id"`
+	BeforeID  int64     `json:"before_id"`
+	Limit     int32     `json:"limit"`
+	Offset    int32     `json:"offset"`
 }
 
 // TransferTxResult is the result of the transfer transaction.
+//
+// If the transfer is held for review, only Review and the accounts are set.
 type TransferTxResult struct {
-	Transfer    Transfer `json:"transfer"`
-	FromAccount Account  `json:"from_account"`
-	ToAccount   Account  `json:"to_account"`
-	FromEntry   Entry    `json:"from_entry"`
-	ToEntry     Entry    `json:"to_entry"`
+	Transfer    Transfer        `json:"transfer"`
+	FromAccount Account         `json:"from_account"`
+	ToAccount   Account         `json:"to_account"`
+	FromEntry   Entry           `json:"from_entry"`
+	ToEntry     Entry           `json:"to_entry"`
+	Review      *TransferReview `json:"review,omitempty"`
 }
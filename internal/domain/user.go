@@ -22,6 +22,7 @@ type User struct {
 	HashedPassword    string    `json:"hashed_password"`
 	FullName          string    `json:"full_name"`
 	Email             string    `json:"email"`
+	Role              string    `json:"role"`
 	PasswordChangedAt time.Time `json:"password_changed_at,omitempty"`
 	CreatedAt         time.Time `json:"created_at,omitempty"`
 }
@@ -39,5 +40,6 @@ type UserWihtoutPassword struct {
 	Username  string    `json:"username"`
 	FullName  string    `json:"full_name"`
 	Email     string    `json:"email"`
+	Role      string    `json:"role"`
 	CreatedAt time.Time `json:"created_at"`
 }
@@ -0,0 +1,143 @@
+// Package entrydelivery manages delivery layer of account entries.
+package entrydelivery
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/middleware"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
+	"github.com/go-petr/pet-bank/pkg/tokenpkg"
+	"github.com/go-petr/pet-bank/pkg/web"
+)
+
+// Service provides service layer interface needed by entry delivery layer.
+//
+//go:generate mockgen -source http.go -destination http_mock.go -package entrydelivery
+type Service interface {
+	List(ctx context.Context, username string, arg domain.ListEntriesParams, page pagepkg.Request) ([]domain.StatementLine, pagepkg.Page, error)
+}
+
+// Handler facilitates entry delivery layer logic.
+type Handler struct {
+	service Service
+}
+
+// NewHandler returns entry handler.
+func NewHandler(s Service) *Handler {
+	return &Handler{
+		service: s,
+	}
+}
+
+type listURI struct {
+	AccountID int32 `uri:"id" binding:"required,min=1"`
+}
+
+type listRequest struct {
+	PageID    int32     `form:"page_id" binding:"required_without=PageToken,omitempty,min=1"`
+	PageSize  int32     `form:"page_size" binding:"required,min=1,max=100"`
+	PageToken string    `form:"page_token"`
+	StartDate time.Time `form:"start_date" time_format:"2006-01-02" time_utc:"1"`
+	EndDate   time.Time `form:"end_date" time_format:"2006-01-02" time_utc:"1"`
+}
+
+// List handles http request to get the account statement.
+//
+// The end date is inclusive. The page is located by page_token if it is set,
+// otherwise by page_id.
+func (h *Handler) List(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+	l := zerolog.Ctx(ctx)
+
+	var uri listURI
+	if err := gctx.ShouldBindUri(&uri); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	var req listRequest
+	if err := gctx.ShouldBindQuery(&req); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	pageReq := pagepkg.Request{PageID: req.PageID, PageSize: req.PageSize}
+
+	if req.PageToken != "" {
+		cursor, err := pagepkg.Parse(req.PageToken)
+		if err != nil {
+			l.Info().Err(err).Send()
+			gctx.JSON(http.StatusBadRequest, web.Error(err))
+
+			return
+		}
+
+		pageReq.Cursor = cursor
+	}
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	arg := domain.ListEntriesParams{
+		AccountID: uri.AccountID,
+		StartTime: req.StartDate,
+	}
+
+	if !req.EndDate.IsZero() {
+		arg.EndTime = req.EndDate.AddDate(0, 0, 1)
+	}
+
+	entries, page, err := h.service.List(ctx, authPayload.Username, arg, pageReq)
+	if err != nil {
+		l.Info().Err(err).Send()
+
+		switch err {
+		case domain.ErrAccountNotFound:
+			gctx.JSON(http.StatusNotFound, web.Error(err))
+			return
+		case domain.ErrAccountOwnerMismatch:
+			gctx.JSON(http.StatusUnauthorized, web.Error(err))
+			return
+		case domain.ErrInvalidDateRange:
+			gctx.JSON(http.StatusBadRequest, web.Error(err))
+			return
+		}
+
+		gctx.JSON(http.StatusInternalServerError, web.Error(errorspkg.ErrInternal))
+
+		return
+	}
+
+	res := web.Response{
+		Data: &struct {
+			Entries []domain.StatementLine `json:"entries"`
+		}{
+			Entries: entries,
+		},
+		NextCursor: page.Next,
+		PrevCursor: page.Prev,
+	}
+
+	gctx.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) bindError(gctx *gin.Context, err error) {
+	zerolog.Ctx(gctx.Request.Context()).Info().Err(err).Send()
+
+	var ve validator.ValidationErrors
+	if errors.As(err, &ve) {
+		gctx.JSON(http.StatusBadRequest, web.Response{Error: web.GetErrorMsg(ve)})
+
+		return
+	}
+
+	gctx.JSON(http.StatusBadRequest, web.Error(err))
+}
@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: http.go
+
+// Package entrydelivery is a generated GoMock package.
+package entrydelivery
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/go-petr/pet-bank/internal/domain"
+	pagepkg "github.com/go-petr/pet-bank/pkg/pagepkg"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockService) List(ctx context.Context, username string, arg domain.ListEntriesParams, page pagepkg.Request) ([]domain.StatementLine, pagepkg.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, username, arg, page)
+	ret0, _ := ret[0].([]domain.StatementLine)
+	ret1, _ := ret[1].(pagepkg.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockServiceMockRecorder) List(ctx, username, arg, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List), ctx, username, arg, page)
+}
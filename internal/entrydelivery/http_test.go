@@ -0,0 +1,237 @@
+package entrydelivery
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
+	"github.com/go-petr/pet-bank/internal/middleware"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/go-petr/pet-bank/pkg/tokenpkg"
+	"github.com/go-petr/pet-bank/pkg/web"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+func TestList(t *testing.T) {
+	username := randompkg.Owner()
+	account := helpers.RandomAccount(username)
+	symmetricKey := randompkg.String(32)
+
+	tokenMaker, err := tokenpkg.NewPasetoMaker(symmetricKey)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(%v) returned error: %v", symmetricKey, err)
+	}
+
+	authType := middleware.AuthTypeBearer
+	duration := time.Minute
+
+	lines := []domain.StatementLine{
+		{
+			Entry: domain.Entry{
+				ID:         1,
+				AccountID:  account.ID,
+				Amount:     "-100",
+				TransferID: 1,
+				CreatedAt:  time.Now().UTC().Truncate(time.Second),
+			},
+			Balance: "900",
+		},
+	}
+
+	cursor := pagepkg.Cursor{BeforeID: 2}
+	page := pagepkg.Page{Next: pagepkg.Cursor{AfterID: 1}.Token()}
+
+	testCases := []struct {
+		name           string
+		url            string
+		buildStubs     func(entryService *MockService)
+		wantStatusCode int
+		wantPage       pagepkg.Page
+		wantError      string
+	}{
+		{
+			name: "OK",
+			url:  fmt.Sprintf("/accounts/%d/entries?page_id=1&page_size=5", account.ID),
+			buildStubs: func(entryService *MockService) {
+				arg := domain.ListEntriesParams{AccountID: account.ID}
+
+				entryService.EXPECT().
+					List(gomock.Any(), gomock.Eq(username), gomock.Eq(arg), gomock.Eq(pagepkg.Request{PageID: 1, PageSize: 5})).
+					Times(1).
+					Return(lines, pagepkg.Page{}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "PageToken",
+			url:  fmt.Sprintf("/accounts/%d/entries?page_size=5&page_token=%s", account.ID, cursor.Token()),
+			buildStubs: func(entryService *MockService) {
+				arg := domain.ListEntriesParams{AccountID: account.ID}
+
+				entryService.EXPECT().
+					List(gomock.Any(), gomock.Eq(username), gomock.Eq(arg), gomock.Eq(pagepkg.Request{PageSize: 5, Cursor: cursor})).
+					Times(1).
+					Return(lines, page, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantPage:       page,
+		},
+		{
+			name: "InvalidPageToken",
+			url:  fmt.Sprintf("/accounts/%d/entries?page_size=5&page_token=invalid", account.ID),
+			buildStubs: func(entryService *MockService) {
+				entryService.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      pagepkg.ErrInvalidPageToken.Error(),
+		},
+		{
+			name: "DateRange",
+			url:  fmt.Sprintf("/accounts/%d/entries?page_id=1&page_size=5&start_date=2023-01-01&end_date=2023-01-31", account.ID),
+			buildStubs: func(entryService *MockService) {
+				arg := domain.ListEntriesParams{
+					AccountID: account.ID,
+					StartTime: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+				}
+
+				entryService.EXPECT().
+					List(gomock.Any(), gomock.Eq(username), gomock.Eq(arg), gomock.Any()).
+					Times(1).
+					Return(lines, pagepkg.Page{}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "InvalidAccountID",
+			url:  "/accounts/0/entries?page_id=1&page_size=5",
+			buildStubs: func(entryService *MockService) {
+				entryService.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "AccountID field is required",
+		},
+		{
+			name: "RequiredPageID",
+			url:  fmt.Sprintf("/accounts/%d/entries?page_size=5", account.ID),
+			buildStubs: func(entryService *MockService) {
+				entryService.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "PageID field is required without PageToken",
+		},
+		{
+			name: "ErrAccountNotFound",
+			url:  fmt.Sprintf("/accounts/%d/entries?page_id=1&page_size=5", account.ID),
+			buildStubs: func(entryService *MockService) {
+				entryService.EXPECT().
+					List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, pagepkg.Page{}, domain.ErrAccountNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      domain.ErrAccountNotFound.Error(),
+		},
+		{
+			name: "ErrAccountOwnerMismatch",
+			url:  fmt.Sprintf("/accounts/%d/entries?page_id=1&page_size=5", account.ID),
+			buildStubs: func(entryService *MockService) {
+				entryService.EXPECT().
+					List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, pagepkg.Page{}, domain.ErrAccountOwnerMismatch)
+			},
+			wantStatusCode: http.StatusUnauthorized,
+			wantError:      domain.ErrAccountOwnerMismatch.Error(),
+		},
+		{
+			name: "InternalServerError",
+			url:  fmt.Sprintf("/accounts/%d/entries?page_id=1&page_size=5", account.ID),
+			buildStubs: func(entryService *MockService) {
+				entryService.EXPECT().
+					List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, pagepkg.Page{}, errorspkg.ErrInternal)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      errorspkg.ErrInternal.Error(),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			entryService := NewMockService(ctrl)
+			entryHandler := NewHandler(entryService)
+
+			server := gin.New()
+			server.Use(middleware.AuthMiddleware(tokenMaker, nil))
+			server.GET("/accounts/:id/entries", entryHandler.List)
+
+			tc.buildStubs(entryService)
+
+			req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			if err != nil {
+				t.Fatalf("Creating request error: %v", err)
+			}
+
+			if err := middleware.AddAuthorization(req, tokenMaker, authType, username, duration); err != nil {
+				t.Fatalf("middleware.AddAuthorization(...) returned error: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			if got := w.Code; got != tc.wantStatusCode {
+				t.Errorf("Status code: got %v, want %v", got, tc.wantStatusCode)
+			}
+
+			data := &struct {
+				Entries []domain.StatementLine `json:"entries"`
+			}{}
+			res := web.Response{Data: data}
+
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Errorf("Decoding response body error: %v", err)
+			}
+
+			if res.Error != tc.wantError {
+				t.Errorf(`res.Error=%q, want %q`, res.Error, tc.wantError)
+			}
+
+			if tc.wantError != "" {
+				return
+			}
+
+			if diff := cmp.Diff(lines, data.Entries); diff != "" {
+				t.Errorf("res.Data mismatch (-want +got):\n%s", diff)
+			}
+
+			if got := (pagepkg.Page{Next: res.NextCursor, Prev: res.PrevCursor}); got != tc.wantPage {
+				t.Errorf("res cursors = %+v, want %+v", got, tc.wantPage)
+			}
+		})
+	}
+}
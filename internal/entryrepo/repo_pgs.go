@@ -8,6 +8,7 @@ import (
 	"github.com/go-petr/pet-bank/internal/domain"
 	"github.com/go-petr/pet-bank/pkg/dbpkg"
 	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
 	"github.com/lib/pq"
 	"github.com/rs/zerolog"
 )
@@ -22,41 +23,101 @@ func NewRepoPGS(db dbpkg.SQLInterface) *RepoPGS {
 	return &RepoPGS{db: db}
 }
 
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanEntry(row scanner, dest ...any) (domain.Entry, error) {
+	var (
+		e          domain.Entry
+		transferID sql.NullInt64
+		prevHash   sql.NullString
+		hash       sql.NullString
+	)
+
+	err := row.Scan(append([]any{
+		&e.ID, &e.AccountID, &e.Amount, &transferID, &e.CreatedAt, &prevHash, &hash,
+		&e.Description, &e.Reference, (*dbpkg.JSONMap)(&e.Metadata),
+	}, dest...)...)
+
+	e.TransferID = transferID.Int64
+	e.PrevHash = prevHash.String
+	e.Hash = hash.String
+
+	return e, err
+}
+
+// The new entry is linked to the last entry of the account. Entries created
+// before the hash chain have no hash, so the chain starts with an empty
+// prev_hash after them.
 const createQuery = `
 INSERT INTO
-    entries (account_id, amount)
+    entries (account_id, amount, transfer_id, prev_hash, description, reference, metadata)
 VALUES
-    ($1, $2)
-RETURNING id, account_id, amount, created_at
+    ($1, $2, $3, COALESCE((
+        SELECT hash FROM entries WHERE account_id = $1 ORDER BY id DESC LIMIT 1
+    ), ''), $4, $5, $6)
+RETURNING id, account_id, amount, transfer_id, created_at, prev_hash, hash,
+    description, reference, metadata
 `
 
-// Create creates the entry and then returns it.
-func (r *RepoPGS) Create(ctx context.Context, amount string, account int32) (domain.Entry, error) {
-	l := zerolog.Ctx(ctx)
+const setHashQuery = `
+UPDATE entries SET hash = $2 WHERE id = $1
+`
 
-	row := r.db.QueryRowContext(ctx, createQuery, account, amount)
+// Create creates the entry chained to the previous entry of the account and
+// then returns it.
+//
+// Entries of the same account must be created one at a time, e.g. under the
+// account row lock. Otherwise the concurrent entry violates the unique
+// (account_id, prev_hash) index and domain.ErrEntryChainConflict is returned.
+func (r *RepoPGS) Create(ctx context.Context, arg domain.CreateEntryParams) (domain.Entry, error) {
+	l := zerolog.Ctx(ctx)
 
-	var e domain.Entry
+	transferID := sql.NullInt64{Int64: arg.TransferID, Valid: arg.TransferID != 0}
 
-	err := row.Scan(&e.ID, &e.AccountID, &e.Amount, &e.CreatedAt)
+	row := r.db.QueryRowContext(ctx, createQuery,
+		arg.AccountID,
+		arg.Amount,
+		transferID,
+		arg.Description,
+		arg.Reference,
+		dbpkg.JSONMap(arg.Metadata),
+	)
 
+	e, err := scanEntry(row)
 	if err != nil {
 		l.Error().Err(err).Send()
 
 		if pqErr, ok := err.(*pq.Error); ok {
-			if pqErr.Constraint == "entries_account_id_fkey" {
+			switch pqErr.Constraint {
+			case "entries_account_id_fkey":
 				return e, domain.ErrAccountNotFound
+			case "entries_transfer_id_fkey":
+				return e, domain.ErrTransferNotFound
+			case "entries_account_id_prev_hash_key":
+				return e, domain.ErrEntryChainConflict
 			}
 		}
 
 		return e, errorspkg.ErrInternal
 	}
 
+	e.Hash = e.ComputeHash()
+
+	if _, err := r.db.ExecContext(ctx, setHashQuery, e.ID, e.Hash); err != nil {
+		l.Error().Err(err).Send()
+		return e, errorspkg.ErrInternal
+	}
+
 	return e, nil
 }
 
 const getQuery = `
-SELECT id, account_id, amount, created_at FROM entries
+SELECT
+    id, account_id, amount, transfer_id, created_at, prev_hash, hash,
+    description, reference, metadata
+FROM entries
 WHERE id = $1 LIMIT 1
 `
 
@@ -66,10 +127,7 @@ func (r *RepoPGS) Get(ctx context.Context, id int64) (domain.Entry, error) {
 
 	row := r.db.QueryRowContext(ctx, getQuery, id)
 
-	var e domain.Entry
-
-	err := row.Scan(&e.ID, &e.AccountID, &e.Amount, &e.CreatedAt)
-
+	e, err := scanEntry(row)
 	if err != nil {
 		l.Error().Err(err).Send()
 
@@ -83,19 +141,100 @@ func (r *RepoPGS) Get(ctx context.Context, id int64) (domain.Entry, error) {
 	return e, nil
 }
 
+// The balance after each entry is the current account balance
+// minus all the entries that come after it.
 const listQuery = `
-SELECT id, account_id, amount, created_at 
+SELECT
+    id, account_id, amount, transfer_id, created_at, prev_hash, hash,
+    description, reference, metadata, balance
+FROM (
+    SELECT
+        e.id, e.account_id, e.amount, e.transfer_id, e.created_at, e.prev_hash, e.hash,
+        e.description, e.reference, e.metadata,
+        a.balance - COALESCE(SUM(e.amount) OVER (
+            ORDER BY e.id DESC ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING
+        ), 0) AS balance
+    FROM entries e
+    JOIN accounts a ON a.id = e.account_id
+    WHERE e.account_id = $1
+) lines
+WHERE 
+    ($2::timestamptz IS NULL OR created_at >= $2)
+    AND ($3::timestamptz IS NULL OR created_at < $3)
+    AND ($6::bigint = 0 OR id > $6)
+    AND ($7::bigint = 0 OR id < $7)
+ORDER BY CASE WHEN $7 = 0 THEN id END, id DESC
+LIMIT $4 OFFSET $5
+`
+
+// List returns the account statement lines matching the given filters ordered by id.
+//
+// If arg.BeforeID is set, the lines right before it are returned.
+func (r *RepoPGS) List(ctx context.Context, arg domain.ListEntriesParams) ([]domain.StatementLine, error) {
+	l := zerolog.Ctx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, listQuery,
+		arg.AccountID,
+		sql.NullTime{Time: arg.StartTime, Valid: !arg.StartTime.IsZero()},
+		sql.NullTime{Time: arg.EndTime, Valid: !arg.EndTime.IsZero()},
+		arg.Limit,
+		arg.Offset,
+		arg.AfterID,
+		arg.BeforeID,
+	)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+	defer rows.Close()
+
+	items := []domain.StatementLine{}
+
+	for rows.Next() {
+		var line domain.StatementLine
+
+		line.Entry, err = scanEntry(rows, &line.Balance)
+		if err != nil {
+			l.Error().Err(err).Send()
+			return nil, errorspkg.ErrInternal
+		}
+
+		items = append(items, line)
+	}
+
+	if err := rows.Close(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	if err := rows.Err(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	if arg.BeforeID != 0 {
+		pagepkg.Reverse(items)
+	}
+
+	return items, nil
+}
+
+const listChainQuery = `
+SELECT
+    id, account_id, amount, transfer_id, created_at, prev_hash, hash,
+    description, reference, metadata
 FROM entries
-WHERE account_id = $1
-ORDER BY id
-LIMIT $2 OFFSET $3
+WHERE ($1::int = 0 OR account_id = $1) AND (account_id, id) > ($2, $3)
+ORDER BY account_id, id
+LIMIT $4
 `
 
-// List returns the specified number of entries for the given accountID.
-func (r *RepoPGS) List(ctx context.Context, accountID int32, limit, offset int32) ([]domain.Entry, error) {
+// ListChain returns the entries after (arg.AfterAccountID, arg.AfterID)
+// ordered by account id and then by id, i.e. in the hash chain order.
+func (r *RepoPGS) ListChain(ctx context.Context, arg domain.ListChainParams) ([]domain.Entry, error) {
 	l := zerolog.Ctx(ctx)
 
-	rows, err := r.db.QueryContext(ctx, listQuery, accountID, limit, offset)
+	rows, err := r.db.QueryContext(ctx, listChainQuery, arg.AccountID, arg.AfterAccountID, arg.AfterID, arg.Limit)
 	if err != nil {
 		l.Error().Err(err).Send()
 		return nil, errorspkg.ErrInternal
@@ -105,8 +244,8 @@ func (r *RepoPGS) List(ctx context.Context, accountID int32, limit, offset int32
 	items := []domain.Entry{}
 
 	for rows.Next() {
-		var e domain.Entry
-		if err := rows.Scan(&e.ID, &e.AccountID, &e.Amount, &e.CreatedAt); err != nil {
+		e, err := scanEntry(rows)
+		if err != nil {
 			l.Error().Err(err).Send()
 			return nil, errorspkg.ErrInternal
 		}
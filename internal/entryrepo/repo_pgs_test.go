@@ -61,6 +61,15 @@ func TestCreate(t *testing.T) {
 			},
 			wantErr: errorspkg.ErrInternal,
 		},
+		{
+			name: "ConstraintViolation:entries_transfer_id_fkey",
+			wantEntry: func(tx *sql.Tx) domain.Entry {
+				user := helpers.SeedUser(t, tx)
+				account := helpers.SeedAccountWith1000USDBalance(t, tx, user.Username)
+				return domain.Entry{AccountID: account.ID, Amount: "10", TransferID: -100500}
+			},
+			wantErr: domain.ErrTransferNotFound,
+		},
 		{
 			name: "ConstraintViolation:entries_account_id_fkey",
 			wantEntry: func(tx *sql.Tx) domain.Entry {
@@ -81,26 +90,36 @@ func TestCreate(t *testing.T) {
 			want := tc.wantEntry(tx)
 			entryRepo := entryrepo.NewRepoPGS(tx)
 
+			arg := domain.CreateEntryParams{
+				AccountID:  want.AccountID,
+				Amount:     want.Amount,
+				TransferID: want.TransferID,
+			}
+
 			// Run test
-			got, err := entryRepo.Create(context.Background(), want.Amount, want.AccountID)
+			got, err := entryRepo.Create(context.Background(), arg)
 			if err != nil {
 				if err == tc.wantErr {
 					return
 				}
-				t.Fatalf(`entryRepo.Create(context.Background(), %v, %v) returned error: %v`,
-					want.Amount, want.AccountID, err.Error())
+				t.Fatalf(`entryRepo.Create(context.Background(), %+v) returned error: %v`,
+					arg, err.Error())
 			}
 
-			ignoreFields := cmpopts.IgnoreFields(domain.Entry{}, "ID", "CreatedAt")
+			ignoreFields := cmpopts.IgnoreFields(domain.Entry{}, "ID", "CreatedAt", "Hash")
 			compareCreatedAt := cmpopts.EquateApproxTime(time.Second)
 			if diff := cmp.Diff(want, got, ignoreFields, compareCreatedAt); diff != "" {
-				t.Errorf(`entryRepo.Create(context.Background(), %v, %v) returned unexpected difference (-want +got):\n%s"`,
-					want.Amount, want.AccountID, diff)
+				t.Errorf(`entryRepo.Create(context.Background(), %+v) returned unexpected difference (-want +got):\n%s"`,
+					arg, diff)
 			}
 
 			if got.ID == 0 {
 				t.Error("got.ID = 0, want non-zero")
 			}
+
+			if got.Hash != got.ComputeHash() {
+				t.Errorf("got.Hash = %q, want %q", got.Hash, got.ComputeHash())
+			}
 		})
 	}
 }
@@ -247,22 +266,162 @@ func TestList(t *testing.T) {
 			wantAccountID, wantEntries := tc.wantAccountIDAndEntries(tx)
 			entryRepo := entryrepo.NewRepoPGS(tx)
 
+			arg := domain.ListEntriesParams{
+				AccountID: wantAccountID,
+				Limit:     tc.limit,
+				Offset:    tc.offset,
+			}
+
 			// Run test
-			got, err := entryRepo.List(context.Background(), wantAccountID, tc.limit, tc.offset)
+			lines, err := entryRepo.List(context.Background(), arg)
 			if err != nil {
 				if err == tc.wantErr {
 					return
 				}
 
-				t.Fatalf(`entryRepo.List(context.Background(), %v, %v, %v) returned unexpected error: %v`,
-					wantAccountID, tc.limit, tc.offset, err)
+				t.Fatalf(`entryRepo.List(context.Background(), %+v) returned unexpected error: %v`,
+					arg, err)
+			}
+
+			got := make([]domain.Entry, len(lines))
+			for i := range lines {
+				got[i] = lines[i].Entry
 			}
 
 			ignoreFields := cmpopts.IgnoreFields(domain.Entry{}, "CreatedAt")
 			if diff := cmp.Diff(wantEntries, got, ignoreFields); diff != "" {
-				t.Errorf(`entryRepo.List(context.Background(), %v, %v, %v) returned unexpected difference (-want +got):\n%s"`,
-					wantAccountID, tc.limit, tc.offset, diff)
+				t.Errorf(`entryRepo.List(context.Background(), %+v) returned unexpected difference (-want +got):\n%s"`,
+					arg, diff)
+			}
+		})
+	}
+}
+
+func TestListRunningBalance(t *testing.T) {
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	user := helpers.SeedUser(t, tx)
+	account := helpers.SeedAccountWith1000USDBalance(t, tx, user.Username)
+
+	helpers.SeedEntry(t, tx, "100", account.ID)
+	second := helpers.SeedEntry(t, tx, "-30", account.ID)
+	third := helpers.SeedEntry(t, tx, "50", account.ID)
+
+	entryRepo := entryrepo.NewRepoPGS(tx)
+
+	testCases := []struct {
+		name         string
+		arg          domain.ListEntriesParams
+		wantBalances []string
+	}{
+		{
+			name:         "All",
+			arg:          domain.ListEntriesParams{AccountID: account.ID, Limit: 10},
+			wantBalances: []string{"980", "950", "1000"},
+		},
+		{
+			name:         "Offset",
+			arg:          domain.ListEntriesParams{AccountID: account.ID, Limit: 10, Offset: 2},
+			wantBalances: []string{"1000"},
+		},
+		{
+			name:         "AfterID",
+			arg:          domain.ListEntriesParams{AccountID: account.ID, AfterID: second.ID, Limit: 10},
+			wantBalances: []string{"1000"},
+		},
+		{
+			name:         "BeforeID",
+			arg:          domain.ListEntriesParams{AccountID: account.ID, BeforeID: third.ID, Limit: 1},
+			wantBalances: []string{"950"},
+		},
+		{
+			name: "DateRange",
+			arg: domain.ListEntriesParams{
+				AccountID: account.ID,
+				StartTime: time.Now().Add(time.Hour),
+				Limit:     10,
+			},
+			wantBalances: []string{},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			lines, err := entryRepo.List(context.Background(), tc.arg)
+			if err != nil {
+				t.Fatalf(`entryRepo.List(context.Background(), %+v) returned unexpected error: %v`, tc.arg, err)
+			}
+
+			got := make([]string, len(lines))
+			for i := range lines {
+				got[i] = lines[i].Balance
+			}
+
+			if diff := cmp.Diff(tc.wantBalances, got); diff != "" {
+				t.Errorf(`entryRepo.List(context.Background(), %+v) returned unexpected balances (-want +got):\n%s"`,
+					tc.arg, diff)
 			}
 		})
 	}
 }
+
+func TestListChain(t *testing.T) {
+	t.Parallel()
+
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	user := helpers.SeedUser(t, tx)
+	account1 := helpers.SeedAccountWith1000USDBalance(t, tx, user.Username)
+	account2 := helpers.SeedAccountWith1000USDBalance(t, tx, user.Username)
+	entries1 := helpers.SeedEntries(t, tx, 3, account1.ID)
+	entries2 := helpers.SeedEntries(t, tx, 2, account2.ID)
+
+	entryRepo := entryrepo.NewRepoPGS(tx)
+	ctx := context.Background()
+
+	// Each account chain starts with an empty previous hash.
+	for _, entries := range [][]domain.Entry{entries1, entries2} {
+		prevHash := ""
+
+		for _, e := range entries {
+			if e.PrevHash != prevHash {
+				t.Errorf("entry %d PrevHash = %q, want %q", e.ID, e.PrevHash, prevHash)
+			}
+
+			prevHash = e.Hash
+		}
+	}
+
+	testCases := []struct {
+		name string
+		arg  domain.ListChainParams
+		want []domain.Entry
+	}{
+		{
+			name: "Account",
+			arg:  domain.ListChainParams{AccountID: account1.ID, Limit: 10},
+			want: entries1,
+		},
+		{
+			name: "AfterID",
+			arg:  domain.ListChainParams{AccountID: account1.ID, AfterAccountID: account1.ID, AfterID: entries1[0].ID, Limit: 1},
+			want: entries1[1:2],
+		},
+		{
+			name: "NextAccount",
+			arg:  domain.ListChainParams{AfterAccountID: account1.ID, AfterID: entries1[2].ID, Limit: 2},
+			want: entries2,
+		},
+	}
+
+	for _, tc := range testCases {
+		got, err := entryRepo.ListChain(ctx, tc.arg)
+		if err != nil {
+			t.Fatalf("%s: entryRepo.ListChain(ctx, %+v) returned error: %v", tc.name, tc.arg, err)
+		}
+
+		if diff := cmp.Diff(tc.want, got, cmpopts.EquateApproxTime(time.Second)); diff != "" {
+			t.Errorf("%s: entryRepo.ListChain(ctx, %+v) returned unexpected difference (-want +got):\n%s", tc.name, tc.arg, diff)
+		}
+	}
+}
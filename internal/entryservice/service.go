@@ -0,0 +1,169 @@
+// Package entryservice manages business logic layer of account entries.
+package entryservice
+
+import (
+	"context"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
+	"github.com/rs/zerolog"
+)
+
+// Repo provides data access layer interface needed by entry service layer.
+//
+//go:generate mockgen -source service.go -destination service_mock.go -package entryservice
+type Repo interface {
+	List(ctx context.Context, arg domain.ListEntriesParams) ([]domain.StatementLine, error)
+	ListChain(ctx context.Context, arg domain.ListChainParams) ([]domain.Entry, error)
+}
+
+// AccountRepo provides account data access needed to check entries ownership.
+type AccountRepo interface {
+	Get(ctx context.Context, id int32) (domain.Account, error)
+}
+
+// Service facilitates entry service layer logic.
+type Service struct {
+	repo        Repo
+	accountRepo AccountRepo
+}
+
+// New returns entry service struct to manage account statements.
+func New(er Repo, ar AccountRepo) *Service {
+	return &Service{
+		repo:        er,
+		accountRepo: ar,
+	}
+}
+
+// List returns the page of the account statement if the account is owned by the user.
+func (s *Service) List(ctx context.Context, username string, arg domain.ListEntriesParams, page pagepkg.Request) ([]domain.StatementLine, pagepkg.Page, error) {
+	l := zerolog.Ctx(ctx)
+
+	account, err := s.accountRepo.Get(ctx, arg.AccountID)
+	if err != nil {
+		return nil, pagepkg.Page{}, err
+	}
+
+	if account.Owner != username {
+		l.Warn().Err(domain.ErrAccountOwnerMismatch).Send()
+		return nil, pagepkg.Page{}, domain.ErrAccountOwnerMismatch
+	}
+
+	if !arg.StartTime.IsZero() && !arg.EndTime.IsZero() && arg.EndTime.Before(arg.StartTime) {
+		l.Info().Err(domain.ErrInvalidDateRange).Send()
+		return nil, pagepkg.Page{}, domain.ErrInvalidDateRange
+	}
+
+	arg.AfterID = page.Cursor.AfterID
+	arg.BeforeID = page.Cursor.BeforeID
+	arg.Limit = page.Limit()
+	arg.Offset = page.Offset()
+
+	lines, err := s.repo.List(ctx, arg)
+	if err != nil {
+		return nil, pagepkg.Page{}, err
+	}
+
+	lines, p := pagepkg.Trim(lines, page, func(line domain.StatementLine) int64 { return line.ID })
+
+	return lines, p, nil
+}
+
+// chainBatchSize is the number of entries fetched at once by VerifyChain.
+const chainBatchSize = 1000
+
+// VerifyChain walks the entries hash chain of the account, or of all accounts
+// if accountID is zero, and reports the first broken link of each account.
+//
+// Entries created before the hash chain have no hash and are skipped until the
+// first chained entry of the account. The removal of the latest entries
+// cannot be detected by the chain itself.
+func (s *Service) VerifyChain(ctx context.Context, accountID int32) (domain.ChainReport, error) {
+	l := zerolog.Ctx(ctx)
+
+	report := domain.ChainReport{Breaks: []domain.ChainBreak{}}
+
+	if accountID != 0 {
+		if _, err := s.accountRepo.Get(ctx, accountID); err != nil {
+			return report, err
+		}
+	}
+
+	var (
+		link  chainLink
+		arg   = domain.ListChainParams{AccountID: accountID, Limit: chainBatchSize}
+		first = true
+	)
+
+	for {
+		entries, err := s.repo.ListChain(ctx, arg)
+		if err != nil {
+			return domain.ChainReport{}, err
+		}
+
+		for _, e := range entries {
+			if first || e.AccountID != link.accountID {
+				link = chainLink{accountID: e.AccountID}
+				first = false
+				report.Accounts++
+			}
+
+			report.Entries++
+
+			if b, ok := link.next(e); !ok {
+				l.Warn().Int32("account_id", b.AccountID).Int64("entry_id", b.EntryID).Msg(b.Reason)
+				report.Breaks = append(report.Breaks, b)
+			}
+		}
+
+		if len(entries) < chainBatchSize {
+			break
+		}
+
+		last := entries[len(entries)-1]
+		arg.AfterAccountID, arg.AfterID = last.AccountID, last.ID
+	}
+
+	return report, nil
+}
+
+// chainLink holds the state of the account hash chain walk.
+type chainLink struct {
+	accountID int32
+	prevHash  string
+	started   bool
+	broken    bool
+}
+
+// next checks the entry against the previous one. It returns false with the
+// broken link only for the first break of the account.
+func (c *chainLink) next(e domain.Entry) (domain.ChainBreak, bool) {
+	if c.broken {
+		return domain.ChainBreak{}, true
+	}
+
+	var reason string
+
+	switch {
+	case e.Hash == "" && !c.started:
+		// Entry created before the hash chain.
+		return domain.ChainBreak{}, true
+	case e.Hash == "":
+		reason = domain.ChainBreakMissingHash
+	case e.PrevHash != c.prevHash:
+		reason = domain.ChainBreakPrevHashMismatch
+	case e.ComputeHash() != e.Hash:
+		reason = domain.ChainBreakHashMismatch
+	}
+
+	if reason != "" {
+		c.broken = true
+		return domain.ChainBreak{AccountID: e.AccountID, EntryID: e.ID, Reason: reason}, false
+	}
+
+	c.started = true
+	c.prevHash = e.Hash
+
+	return domain.ChainBreak{}, true
+}
@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package entryservice is a generated GoMock package.
+package entryservice
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/go-petr/pet-bank/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepo is a mock of Repo interface.
+type MockRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoMockRecorder
+}
+
+// MockRepoMockRecorder is the mock recorder for MockRepo.
+type MockRepoMockRecorder struct {
+	mock *MockRepo
+}
+
+// NewMockRepo creates a new mock instance.
+func NewMockRepo(ctrl *gomock.Controller) *MockRepo {
+	mock := &MockRepo{ctrl: ctrl}
+	mock.recorder = &MockRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockRepo) List(ctx context.Context, arg domain.ListEntriesParams) ([]domain.StatementLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, arg)
+	ret0, _ := ret[0].([]domain.StatementLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepoMockRecorder) List(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepo)(nil).List), ctx, arg)
+}
+
+// ListChain mocks base method.
+func (m *MockRepo) ListChain(ctx context.Context, arg domain.ListChainParams) ([]domain.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChain", ctx, arg)
+	ret0, _ := ret[0].([]domain.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChain indicates an expected call of ListChain.
+func (mr *MockRepoMockRecorder) ListChain(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChain", reflect.TypeOf((*MockRepo)(nil).ListChain), ctx, arg)
+}
+
+// MockAccountRepo is a mock of AccountRepo interface.
+type MockAccountRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountRepoMockRecorder
+}
+
+// MockAccountRepoMockRecorder is the mock recorder for MockAccountRepo.
+type MockAccountRepoMockRecorder struct {
+	mock *MockAccountRepo
+}
+
+// NewMockAccountRepo creates a new mock instance.
+func NewMockAccountRepo(ctrl *gomock.Controller) *MockAccountRepo {
+	mock := &MockAccountRepo{ctrl: ctrl}
+	mock.recorder = &MockAccountRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountRepo) EXPECT() *MockAccountRepoMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockAccountRepo) Get(ctx context.Context, id int32) (domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAccountRepoMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAccountRepo)(nil).Get), ctx, id)
+}
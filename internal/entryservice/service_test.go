@@ -0,0 +1,317 @@
+package entryservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestList(t *testing.T) {
+	username := randompkg.Owner()
+	account := helpers.RandomAccount(username)
+	now := time.Now().Truncate(time.Second).UTC()
+
+	lines := []domain.StatementLine{
+		{Entry: domain.Entry{ID: 1, AccountID: account.ID, Amount: "100", TransferID: 1, CreatedAt: now}, Balance: "1100"},
+		{Entry: domain.Entry{ID: 2, AccountID: account.ID, Amount: "-50", TransferID: 2, CreatedAt: now}, Balance: "1050"},
+	}
+	page := pagepkg.Request{PageID: 2, PageSize: 5}
+	wantPage := pagepkg.Page{Prev: pagepkg.Cursor{BeforeID: 1}.Token()}
+
+	testCases := []struct {
+		name       string
+		username   string
+		arg        domain.ListEntriesParams
+		buildStubs func(repo *MockRepo, accountRepo *MockAccountRepo)
+		wantErr    error
+	}{
+		{
+			name:     "OK",
+			username: username,
+			arg: domain.ListEntriesParams{
+				AccountID: account.ID,
+				StartTime: now.Add(-time.Hour),
+				EndTime:   now.Add(time.Hour),
+			},
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				arg := domain.ListEntriesParams{
+					AccountID: account.ID,
+					StartTime: now.Add(-time.Hour),
+					EndTime:   now.Add(time.Hour),
+					Limit:     6,
+					Offset:    5,
+				}
+
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				repo.EXPECT().List(gomock.Any(), gomock.Eq(arg)).Times(1).Return(lines, nil)
+			},
+		},
+		{
+			name:     "ErrAccountOwnerMismatch",
+			username: randompkg.Owner(),
+			arg:      domain.ListEntriesParams{AccountID: account.ID},
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				repo.EXPECT().List(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrAccountOwnerMismatch,
+		},
+		{
+			name:     "ErrAccountNotFound",
+			username: username,
+			arg:      domain.ListEntriesParams{AccountID: account.ID},
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(account.ID)).Times(1).
+					Return(domain.Account{}, domain.ErrAccountNotFound)
+				repo.EXPECT().List(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrAccountNotFound,
+		},
+		{
+			name:     "ErrInvalidDateRange",
+			username: username,
+			arg: domain.ListEntriesParams{
+				AccountID: account.ID,
+				StartTime: now,
+				EndTime:   now.Add(-time.Hour),
+			},
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				repo.EXPECT().List(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrInvalidDateRange,
+		},
+		{
+			name:     "RepoInternalError",
+			username: username,
+			arg:      domain.ListEntriesParams{AccountID: account.ID},
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				repo.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).Return(nil, errorspkg.ErrInternal)
+			},
+			wantErr: errorspkg.ErrInternal,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockRepo(ctrl)
+			accountRepo := NewMockAccountRepo(ctrl)
+			tc.buildStubs(repo, accountRepo)
+
+			entryService := New(repo, accountRepo)
+
+			got, gotPage, err := entryService.List(context.Background(), tc.username, tc.arg, page)
+			if err != tc.wantErr {
+				t.Fatalf("entryService.List(context.Background(), %v, %+v, %+v) returned error: %v, want %v",
+					tc.username, tc.arg, page, err, tc.wantErr)
+			}
+
+			if tc.wantErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(lines, got); diff != "" {
+				t.Errorf("entryService.List(context.Background(), %v, %+v, %+v) returned unexpected difference (-want +got):\n%s",
+					tc.username, tc.arg, page, diff)
+			}
+
+			if gotPage != wantPage {
+				t.Errorf("entryService.List(context.Background(), %v, %+v, %+v) returned page %+v, want %+v",
+					tc.username, tc.arg, page, gotPage, wantPage)
+			}
+		})
+	}
+}
+
+// chain returns count hash chained entries of the account starting from id.
+func chain(accountID int32, id int64, count int) []domain.Entry {
+	entries := make([]domain.Entry, count)
+	prevHash := ""
+
+	for i := range entries {
+		e := domain.Entry{
+			ID:        id + int64(i),
+			AccountID: accountID,
+			Amount:    "10",
+			CreatedAt: time.Date(2023, 1, 1, 0, 0, i, 0, time.UTC),
+			PrevHash:  prevHash,
+		}
+		e.Hash = e.ComputeHash()
+		prevHash = e.Hash
+
+		entries[i] = e
+	}
+
+	return entries
+}
+
+func TestVerifyChain(t *testing.T) {
+	account := helpers.RandomAccount(randompkg.Owner())
+
+	legacy := []domain.Entry{{ID: 1, AccountID: 1, Amount: "5"}, {ID: 2, AccountID: 1, Amount: "5"}}
+	withLegacy := append(legacy, chain(1, 3, 2)...)
+
+	tampered := chain(1, 1, 3)
+	tampered[1].Amount = "1000"
+
+	deleted := chain(1, 1, 3)
+	deleted = append(deleted[:1], deleted[2:]...)
+
+	missingHash := chain(1, 1, 3)
+	missingHash[1].Hash, missingHash[1].PrevHash = "", ""
+
+	twoAccounts := append(chain(1, 1, 2), chain(2, 3, 3)...)
+	twoAccounts[3].Amount = "1000"
+
+	long := chain(1, 1, chainBatchSize+1)
+
+	testCases := []struct {
+		name       string
+		accountID  int32
+		buildStubs func(repo *MockRepo, accountRepo *MockAccountRepo)
+		want       domain.ChainReport
+		wantErr    error
+	}{
+		{
+			name: "OK",
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().ListChain(gomock.Any(), gomock.Eq(domain.ListChainParams{Limit: chainBatchSize})).
+					Times(1).Return(chain(1, 1, 3), nil)
+			},
+			want: domain.ChainReport{Accounts: 1, Entries: 3, Breaks: []domain.ChainBreak{}},
+		},
+		{
+			name: "LegacyEntriesAreSkipped",
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().ListChain(gomock.Any(), gomock.Any()).Times(1).Return(withLegacy, nil)
+			},
+			want: domain.ChainReport{Accounts: 1, Entries: 4, Breaks: []domain.ChainBreak{}},
+		},
+		{
+			name: "HashMismatch",
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().ListChain(gomock.Any(), gomock.Any()).Times(1).Return(tampered, nil)
+			},
+			want: domain.ChainReport{
+				Accounts: 1,
+				Entries:  3,
+				Breaks:   []domain.ChainBreak{{AccountID: 1, EntryID: 2, Reason: domain.ChainBreakHashMismatch}},
+			},
+		},
+		{
+			name: "PrevHashMismatch",
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().ListChain(gomock.Any(), gomock.Any()).Times(1).Return(deleted, nil)
+			},
+			want: domain.ChainReport{
+				Accounts: 1,
+				Entries:  2,
+				Breaks:   []domain.ChainBreak{{AccountID: 1, EntryID: 3, Reason: domain.ChainBreakPrevHashMismatch}},
+			},
+		},
+		{
+			name: "MissingHash",
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().ListChain(gomock.Any(), gomock.Any()).Times(1).Return(missingHash, nil)
+			},
+			want: domain.ChainReport{
+				Accounts: 1,
+				Entries:  3,
+				Breaks:   []domain.ChainBreak{{AccountID: 1, EntryID: 2, Reason: domain.ChainBreakMissingHash}},
+			},
+		},
+		{
+			name: "FirstBreakOfEachAccount",
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().ListChain(gomock.Any(), gomock.Any()).Times(1).Return(twoAccounts, nil)
+			},
+			want: domain.ChainReport{
+				Accounts: 2,
+				Entries:  5,
+				Breaks:   []domain.ChainBreak{{AccountID: 2, EntryID: 4, Reason: domain.ChainBreakHashMismatch}},
+			},
+		},
+		{
+			name: "Batches",
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				gomock.InOrder(
+					repo.EXPECT().ListChain(gomock.Any(), gomock.Eq(domain.ListChainParams{Limit: chainBatchSize})).
+						Times(1).Return(long[:chainBatchSize], nil),
+					repo.EXPECT().ListChain(gomock.Any(), gomock.Eq(domain.ListChainParams{
+						AfterAccountID: 1,
+						AfterID:        chainBatchSize,
+						Limit:          chainBatchSize,
+					})).Times(1).Return(long[chainBatchSize:], nil),
+				)
+			},
+			want: domain.ChainReport{Accounts: 1, Entries: chainBatchSize + 1, Breaks: []domain.ChainBreak{}},
+		},
+		{
+			name:      "Account",
+			accountID: account.ID,
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				arg := domain.ListChainParams{AccountID: account.ID, Limit: chainBatchSize}
+
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				repo.EXPECT().ListChain(gomock.Any(), gomock.Eq(arg)).Times(1).Return(chain(account.ID, 1, 1), nil)
+			},
+			want: domain.ChainReport{Accounts: 1, Entries: 1, Breaks: []domain.ChainBreak{}},
+		},
+		{
+			name:      "ErrAccountNotFound",
+			accountID: account.ID,
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(account.ID)).Times(1).
+					Return(domain.Account{}, domain.ErrAccountNotFound)
+				repo.EXPECT().ListChain(gomock.Any(), gomock.Any()).Times(0)
+			},
+			want:    domain.ChainReport{Breaks: []domain.ChainBreak{}},
+			wantErr: domain.ErrAccountNotFound,
+		},
+		{
+			name: "RepoError",
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().ListChain(gomock.Any(), gomock.Any()).Times(1).Return(nil, errorspkg.ErrInternal)
+			},
+			wantErr: errorspkg.ErrInternal,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepo(ctrl)
+			accountRepo := NewMockAccountRepo(ctrl)
+			tc.buildStubs(repo, accountRepo)
+
+			got, err := New(repo, accountRepo).VerifyChain(context.Background(), tc.accountID)
+			if err != tc.wantErr {
+				t.Fatalf("VerifyChain(ctx, %d) returned error: %v, want %v", tc.accountID, err, tc.wantErr)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("VerifyChain(ctx, %d) returned unexpected difference (-want +got):\n%s", tc.accountID, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Package fxdelivery manages delivery layer of exchange rate quotes.
+package fxdelivery
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/middleware"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/tokenpkg"
+	"github.com/go-petr/pet-bank/pkg/web"
+)
+
+// Service provides service layer interface needed by fx delivery layer.
+//
+//go:generate mockgen -source http.go -destination http_mock.go -package fxdelivery
+type Service interface {
+	CreateQuote(ctx context.Context, username, fromCurrency, toCurrency string) (domain.FXQuote, error)
+}
+
+// Handler facilitates fx delivery layer logic.
+type Handler struct {
+	service Service
+}
+
+// NewHandler returns fx handler.
+func NewHandler(s Service) *Handler {
+	return &Handler{
+		service: s,
+	}
+}
+
+type createQuoteRequest struct {
+	FromCurrency string `json:"from_currency" binding:"required,currency"`
+	ToCurrency   string `json:"to_currency" binding:"required,currency"`
+}
+
+// CreateQuote handles http request to lock an exchange rate for a cross-currency transfer.
+func (h *Handler) CreateQuote(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+	l := zerolog.Ctx(ctx)
+
+	var req createQuoteRequest
+	if err := gctx.ShouldBindJSON(&req); err != nil {
+		var ve validator.ValidationErrors
+		if errors.As(err, &ve) {
+			gctx.JSON(http.StatusBadRequest, web.Response{Error: web.GetErrorMsg(ve)})
+
+			return
+		}
+
+		l.Error().Err(err).Send()
+		gctx.JSON(http.StatusBadRequest, web.Error(errorspkg.ErrInternal))
+
+		return
+	}
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	quote, err := h.service.CreateQuote(ctx, authPayload.Username, req.FromCurrency, req.ToCurrency)
+	if err != nil {
+		l.Info().Err(err).Send()
+
+		switch err {
+		case
+			domain.ErrSameCurrency,
+			domain.ErrFXRateNotFound:
+			gctx.JSON(http.StatusBadRequest, web.Error(err))
+
+			return
+		case
+			domain.ErrUserNotFound:
+			gctx.JSON(http.StatusNotFound, web.Error(err))
+
+			return
+		}
+
+		gctx.JSON(http.StatusInternalServerError, web.Error(errorspkg.ErrInternal))
+
+		return
+	}
+
+	res := web.Response{
+		Data: struct {
+			Quote domain.FXQuote `json:"quote"`
+		}{
+			Quote: quote,
+		},
+	}
+
+	gctx.JSON(http.StatusCreated, res)
+}
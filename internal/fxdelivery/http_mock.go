@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: http.go
+
+// Package fxdelivery is a generated GoMock package.
+package fxdelivery
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/go-petr/pet-bank/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateQuote mocks base method.
+func (m *MockService) CreateQuote(ctx context.Context, username, fromCurrency, toCurrency string) (domain.FXQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateQuote", ctx, username, fromCurrency, toCurrency)
+	ret0, _ := ret[0].(domain.FXQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateQuote indicates an expected call of CreateQuote.
+func (mr *MockServiceMockRecorder) CreateQuote(ctx, username, fromCurrency, toCurrency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQuote", reflect.TypeOf((*MockService)(nil).CreateQuote), ctx, username, fromCurrency, toCurrency)
+}
@@ -0,0 +1,209 @@
+package fxdelivery
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/middleware"
+	"github.com/go-petr/pet-bank/pkg/currencypkg"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/go-petr/pet-bank/pkg/tokenpkg"
+	"github.com/go-petr/pet-bank/pkg/web"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+func TestCreateQuote(t *testing.T) {
+	username := randompkg.Owner()
+	symmetricKey := randompkg.String(32)
+
+	tokenMaker, err := tokenpkg.NewPasetoMaker(symmetricKey)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(%v) returned error: %v", symmetricKey, err)
+	}
+
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		if err := v.RegisterValidation("currency", currencypkg.ValidCurrency); err != nil {
+			t.Fatalf("v.RegisterValidation(currency) returned error: %v", err)
+		}
+	}
+
+	authType := middleware.AuthTypeBearer
+	duration := time.Minute
+
+	type requestBody struct {
+		FromCurrency string `json:"from_currency"`
+		ToCurrency   string `json:"to_currency"`
+	}
+
+	quote := domain.FXQuote{
+		ID:           uuid.New(),
+		Username:     username,
+		FromCurrency: currencypkg.USD,
+		ToCurrency:   currencypkg.EUR,
+		Rate:         "0.92",
+		ExpiresAt:    time.Now().Add(30 * time.Second).UTC().Truncate(time.Second),
+		CreatedAt:    time.Now().UTC().Truncate(time.Second),
+	}
+
+	testCases := []struct {
+		name           string
+		requestBody    requestBody
+		setupAuth      func(r *http.Request) error
+		buildStubs     func(service *MockService)
+		wantStatusCode int
+		wantError      string
+		wantQuote      domain.FXQuote
+	}{
+		{
+			name:        "OK",
+			requestBody: requestBody{FromCurrency: currencypkg.USD, ToCurrency: currencypkg.EUR},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username, duration)
+			},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().
+					CreateQuote(gomock.Any(), gomock.Eq(username), gomock.Eq(currencypkg.USD), gomock.Eq(currencypkg.EUR)).
+					Times(1).
+					Return(quote, nil)
+			},
+			wantStatusCode: http.StatusCreated,
+			wantQuote:      quote,
+		},
+		{
+			name:        "NoAuthorization",
+			requestBody: requestBody{FromCurrency: currencypkg.USD, ToCurrency: currencypkg.EUR},
+			setupAuth: func(r *http.Request) error {
+				return nil
+			},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().CreateQuote(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusUnauthorized,
+			wantError:      middleware.ErrAuthHeaderNotFound.Error(),
+		},
+		{
+			name:        "InvalidCurrency",
+			requestBody: requestBody{FromCurrency: currencypkg.USD, ToCurrency: "RUB"},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username, duration)
+			},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().CreateQuote(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "ToCurrency is not supported",
+		},
+		{
+			name:        "ErrSameCurrency",
+			requestBody: requestBody{FromCurrency: currencypkg.USD, ToCurrency: currencypkg.USD},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username, duration)
+			},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().
+					CreateQuote(gomock.Any(), gomock.Eq(username), gomock.Eq(currencypkg.USD), gomock.Eq(currencypkg.USD)).
+					Times(1).
+					Return(domain.FXQuote{}, domain.ErrSameCurrency)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      domain.ErrSameCurrency.Error(),
+		},
+		{
+			name:        "InternalServerError",
+			requestBody: requestBody{FromCurrency: currencypkg.USD, ToCurrency: currencypkg.EUR},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username, duration)
+			},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().
+					CreateQuote(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.FXQuote{}, errorspkg.ErrInternal)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      errorspkg.ErrInternal.Error(),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewMockService(ctrl)
+			handler := NewHandler(service)
+
+			server := gin.New()
+			server.Use(middleware.AuthMiddleware(tokenMaker, nil))
+			server.POST("/fx/quotes", handler.CreateQuote)
+
+			tc.buildStubs(service)
+
+			body, err := json.Marshal(tc.requestBody)
+			if err != nil {
+				t.Fatalf("Encoding request body error: %v", err)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "/fx/quotes", bytes.NewReader(body))
+			if err != nil {
+				t.Fatalf("Creating request error: %v", err)
+			}
+
+			if err = tc.setupAuth(req); err != nil {
+				t.Fatalf("tc.setupAuth(%+v) returned error: %v", req, err)
+			}
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			if got := w.Code; got != tc.wantStatusCode {
+				t.Errorf("Status code: got %v, want %v", got, tc.wantStatusCode)
+			}
+
+			data := &struct {
+				Quote domain.FXQuote `json:"quote"`
+			}{}
+			res := web.Response{Data: data}
+
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Errorf("Decoding response body error: %v", err)
+			}
+
+			if res.Error != tc.wantError {
+				t.Errorf(`res.Error=%q, want %q`, res.Error, tc.wantError)
+			}
+
+			if tc.wantError != "" {
+				return
+			}
+
+			compareTime := cmpopts.EquateApproxTime(time.Second)
+			if diff := cmp.Diff(tc.wantQuote, data.Quote, compareTime); diff != "" {
+				t.Errorf("res.Data mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+// Package fxrepo manages repository layer of exchange rate quotes.
+package fxrepo
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/dbpkg"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog"
+)
+
+// RepoPGS facilitates exchange rate quote repository layer logic.
+type RepoPGS struct {
+	db dbpkg.SQLInterface
+}
+
+// NewRepoPGS returns fx quote RepoPGS.
+func NewRepoPGS(db dbpkg.SQLInterface) *RepoPGS {
+	return &RepoPGS{
+		db: db,
+	}
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanQuote(row scanner) (domain.FXQuote, error) {
+	var (
+		q      domain.FXQuote
+		usedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&q.ID,
+		&q.Username,
+		&q.FromCurrency,
+		&q.ToCurrency,
+		&q.Rate,
+		&q.ExpiresAt,
+		&usedAt,
+		&q.CreatedAt,
+	)
+
+	if usedAt.Valid {
+		q.UsedAt = &usedAt.Time
+	}
+
+	return q, err
+}
+
+const createQuery = `
+INSERT INTO fx_quotes (
+	id,
+	username,
+	from_currency,
+	to_currency,
+	rate,
+	expires_at
+) VALUES (
+	$1, $2, $3, $4, $5, $6
+) RETURNING id, username, from_currency, to_currency, rate, expires_at, used_at, created_at
+`
+
+// Create creates the exchange rate quote and then returns it.
+func (r *RepoPGS) Create(ctx context.Context, arg domain.CreateFXQuoteParams) (domain.FXQuote, error) {
+	l := zerolog.Ctx(ctx)
+
+	row := r.db.QueryRowContext(ctx, createQuery,
+		arg.ID,
+		arg.Username,
+		arg.FromCurrency,
+		arg.ToCurrency,
+		arg.Rate,
+		arg.ExpiresAt,
+	)
+
+	q, err := scanQuote(row)
+	if err != nil {
+		l.Error().Err(err).Send()
+
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Constraint == "fx_quotes_username_fkey" {
+				return q, domain.ErrUserNotFound
+			}
+		}
+
+		return q, errorspkg.ErrInternal
+	}
+
+	return q, nil
+}
+
+const getForUpdateQuery = `
+SELECT
+	id, username, from_currency, to_currency, rate, expires_at, used_at, created_at
+FROM fx_quotes
+WHERE id = $1
+FOR UPDATE
+`
+
+// GetForUpdate returns the quote with the given id and locks its row until
+// the end of the current transaction.
+func (r *RepoPGS) GetForUpdate(ctx context.Context, id uuid.UUID) (domain.FXQuote, error) {
+	l := zerolog.Ctx(ctx)
+
+	row := r.db.QueryRowContext(ctx, getForUpdateQuery, id)
+
+	q, err := scanQuote(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return q, domain.ErrFXQuoteNotFound
+		}
+
+		l.Error().Err(err).Send()
+
+		return q, errorspkg.ErrInternal
+	}
+
+	return q, nil
+}
+
+const markUsedQuery = `
+UPDATE fx_quotes
+SET used_at = now()
+WHERE id = $1 AND used_at IS NULL
+`
+
+// MarkUsed marks the quote as used so it cannot be applied again.
+func (r *RepoPGS) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	l := zerolog.Ctx(ctx)
+
+	res, err := r.db.ExecContext(ctx, markUsedQuery, id)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return errorspkg.ErrInternal
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		l.Error().Err(err).Send()
+		return errorspkg.ErrInternal
+	}
+
+	if n == 0 {
+		return domain.ErrFXQuoteUsed
+	}
+
+	return nil
+}
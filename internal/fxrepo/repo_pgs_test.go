@@ -0,0 +1,184 @@
+//go:build integration
+
+package fxrepo_test
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/fxrepo"
+	"github.com/go-petr/pet-bank/internal/integrationtest"
+	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
+	"github.com/go-petr/pet-bank/pkg/configpkg"
+	"github.com/go-petr/pet-bank/pkg/currencypkg"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+)
+
+var (
+	dbDriver string
+	dbSource string
+)
+
+func TestMain(m *testing.M) {
+	config, err := configpkg.Load("../../configs")
+	if err != nil {
+		log.Fatal("cannot load config:", err)
+	}
+
+	dbDriver = config.DBDriver
+	dbSource = config.DBSource
+
+	os.Exit(m.Run())
+}
+
+func randomParams(username string) domain.CreateFXQuoteParams {
+	return domain.CreateFXQuoteParams{
+		ID:           uuid.New(),
+		Username:     username,
+		FromCurrency: currencypkg.USD,
+		ToCurrency:   currencypkg.EUR,
+		Rate:         "0.92",
+		ExpiresAt:    time.Now().Add(time.Minute),
+	}
+}
+
+func TestCreate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		arg     func(tx *sql.Tx) domain.CreateFXQuoteParams
+		wantErr error
+	}{
+		{
+			name: "OK",
+			arg: func(tx *sql.Tx) domain.CreateFXQuoteParams {
+				user := helpers.SeedUser(t, tx)
+				return randomParams(user.Username)
+			},
+		},
+		{
+			name: "ErrUserNotFound",
+			arg: func(tx *sql.Tx) domain.CreateFXQuoteParams {
+				return randomParams(randompkg.Owner())
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+			arg := tc.arg(tx)
+			repo := fxrepo.NewRepoPGS(tx)
+
+			got, err := repo.Create(context.Background(), arg)
+			if err != nil {
+				if err == tc.wantErr {
+					return
+				}
+				t.Fatalf("repo.Create(context.Background(), %+v) returned error: %v", arg, err)
+			}
+
+			want := domain.FXQuote{
+				ID:           arg.ID,
+				Username:     arg.Username,
+				FromCurrency: arg.FromCurrency,
+				ToCurrency:   arg.ToCurrency,
+				Rate:         arg.Rate,
+				ExpiresAt:    arg.ExpiresAt,
+				CreatedAt:    time.Now(),
+			}
+
+			compareTime := cmpopts.EquateApproxTime(time.Second)
+			if diff := cmp.Diff(want, got, compareTime); diff != "" {
+				t.Errorf("repo.Create(context.Background(), %+v) returned unexpected difference (-want +got):\n%s",
+					arg, diff)
+			}
+		})
+	}
+}
+
+func TestGetForUpdate(t *testing.T) {
+	testCases := []struct {
+		name      string
+		wantQuote func(tx *sql.Tx) domain.FXQuote
+		wantErr   error
+	}{
+		{
+			name: "OK",
+			wantQuote: func(tx *sql.Tx) domain.FXQuote {
+				user := helpers.SeedUser(t, tx)
+				return helpers.SeedFXQuote(t, tx, randomParams(user.Username))
+			},
+		},
+		{
+			name: "ErrFXQuoteNotFound",
+			wantQuote: func(tx *sql.Tx) domain.FXQuote {
+				return domain.FXQuote{ID: uuid.New()}
+			},
+			wantErr: domain.ErrFXQuoteNotFound,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+			want := tc.wantQuote(tx)
+			repo := fxrepo.NewRepoPGS(tx)
+
+			got, err := repo.GetForUpdate(context.Background(), want.ID)
+			if err != nil {
+				if err == tc.wantErr {
+					return
+				}
+				t.Fatalf("repo.GetForUpdate(context.Background(), %v) returned error: %v", want.ID, err)
+			}
+
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("repo.GetForUpdate(context.Background(), %v) returned unexpected difference (-want +got):\n%s",
+					want.ID, diff)
+			}
+		})
+	}
+}
+
+func TestMarkUsed(t *testing.T) {
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	user := helpers.SeedUser(t, tx)
+	quote := helpers.SeedFXQuote(t, tx, randomParams(user.Username))
+	repo := fxrepo.NewRepoPGS(tx)
+
+	if err := repo.MarkUsed(context.Background(), quote.ID); err != nil {
+		t.Fatalf("repo.MarkUsed(context.Background(), %v) returned error: %v", quote.ID, err)
+	}
+
+	got, err := repo.GetForUpdate(context.Background(), quote.ID)
+	if err != nil {
+		t.Fatalf("repo.GetForUpdate(context.Background(), %v) returned error: %v", quote.ID, err)
+	}
+
+	if got.UsedAt == nil {
+		t.Errorf("got.UsedAt = nil, want used quote")
+	}
+
+	if err := repo.MarkUsed(context.Background(), quote.ID); err != domain.ErrFXQuoteUsed {
+		t.Errorf("repo.MarkUsed(context.Background(), %v) returned error: %v, want %v",
+			quote.ID, err, domain.ErrFXQuoteUsed)
+	}
+}
@@ -0,0 +1,69 @@
+// Package fxservice manages business logic layer of exchange rate quotes.
+package fxservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/fxpkg"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// Repo provides data access layer interface needed by fx service layer.
+//
+//go:generate mockgen -source service.go -destination service_mock.go -package fxservice
+type Repo interface {
+	Create(ctx context.Context, arg domain.CreateFXQuoteParams) (domain.FXQuote, error)
+}
+
+// Service facilitates fx service layer logic.
+type Service struct {
+	repo          Repo
+	rates         fxpkg.RateProvider
+	quoteDuration time.Duration
+}
+
+// New returns fx service struct to manage exchange rate quotes.
+//
+// Quotes created by the service lock the provider rate for quoteDuration.
+func New(r Repo, rates fxpkg.RateProvider, quoteDuration time.Duration) *Service {
+	return &Service{
+		repo:          r,
+		rates:         rates,
+		quoteDuration: quoteDuration,
+	}
+}
+
+// CreateQuote locks the current exchange rate between the currencies for the user.
+func (s *Service) CreateQuote(ctx context.Context, username, fromCurrency, toCurrency string) (domain.FXQuote, error) {
+	l := zerolog.Ctx(ctx)
+
+	if fromCurrency == toCurrency {
+		return domain.FXQuote{}, domain.ErrSameCurrency
+	}
+
+	rate, err := s.rates.Rate(ctx, fromCurrency, toCurrency)
+	if err != nil {
+		l.Info().Err(err).Send()
+
+		if err == fxpkg.ErrRateNotFound {
+			return domain.FXQuote{}, domain.ErrFXRateNotFound
+		}
+
+		return domain.FXQuote{}, errorspkg.ErrInternal
+	}
+
+	arg := domain.CreateFXQuoteParams{
+		ID:           uuid.New(),
+		Username:     username,
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+		Rate:         rate.String(),
+		ExpiresAt:    time.Now().Add(s.quoteDuration),
+	}
+
+	return s.repo.Create(ctx, arg)
+}
@@ -0,0 +1,152 @@
+package fxservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/currencypkg"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/fxpkg"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestCreateQuote(t *testing.T) {
+	username := randompkg.Owner()
+	quoteDuration := 30 * time.Second
+
+	rates, err := fxpkg.NewStaticProvider(map[string]string{"USD/EUR": "0.92"})
+	if err != nil {
+		t.Fatalf("fxpkg.NewStaticProvider(...) returned error: %v", err)
+	}
+
+	type input struct {
+		from string
+		to   string
+	}
+
+	testCases := []struct {
+		name       string
+		input      input
+		buildStubs func(repo *MockRepo)
+		want       domain.FXQuote
+		wantErr    error
+	}{
+		{
+			name:  "OK",
+			input: input{from: currencypkg.USD, to: currencypkg.EUR},
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg domain.CreateFXQuoteParams) (domain.FXQuote, error) {
+						return domain.FXQuote{
+							ID:           arg.ID,
+							Username:     arg.Username,
+							FromCurrency: arg.FromCurrency,
+							ToCurrency:   arg.ToCurrency,
+							Rate:         arg.Rate,
+							ExpiresAt:    arg.ExpiresAt,
+						}, nil
+					})
+			},
+			want: domain.FXQuote{
+				Username:     username,
+				FromCurrency: currencypkg.USD,
+				ToCurrency:   currencypkg.EUR,
+				Rate:         "0.92",
+				ExpiresAt:    time.Now().Add(quoteDuration),
+			},
+		},
+		{
+			name:  "InverseRate",
+			input: input{from: currencypkg.EUR, to: currencypkg.USD},
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg domain.CreateFXQuoteParams) (domain.FXQuote, error) {
+						return domain.FXQuote{
+							ID:           arg.ID,
+							Username:     arg.Username,
+							FromCurrency: arg.FromCurrency,
+							ToCurrency:   arg.ToCurrency,
+							Rate:         arg.Rate,
+							ExpiresAt:    arg.ExpiresAt,
+						}, nil
+					})
+			},
+			want: domain.FXQuote{
+				Username:     username,
+				FromCurrency: currencypkg.EUR,
+				ToCurrency:   currencypkg.USD,
+				Rate:         "1.08695652",
+				ExpiresAt:    time.Now().Add(quoteDuration),
+			},
+		},
+		{
+			name:  "ErrSameCurrency",
+			input: input{from: currencypkg.USD, to: currencypkg.USD},
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrSameCurrency,
+		},
+		{
+			name:  "ErrFXRateNotFound",
+			input: input{from: currencypkg.USD, to: currencypkg.RMB},
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrFXRateNotFound,
+		},
+		{
+			name:  "RepoInternalError",
+			input: input{from: currencypkg.USD, to: currencypkg.EUR},
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.FXQuote{}, errorspkg.ErrInternal)
+			},
+			wantErr: errorspkg.ErrInternal,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockRepo(ctrl)
+			tc.buildStubs(repo)
+
+			service := New(repo, rates, quoteDuration)
+
+			got, err := service.CreateQuote(context.Background(), username, tc.input.from, tc.input.to)
+			if err != tc.wantErr {
+				t.Fatalf("service.CreateQuote(context.Background(), %v, %v, %v) returned error: %v, want %v",
+					username, tc.input.from, tc.input.to, err, tc.wantErr)
+			}
+
+			if tc.wantErr != nil {
+				return
+			}
+
+			ignoreID := cmpopts.IgnoreFields(domain.FXQuote{}, "ID")
+			compareExpiresAt := cmpopts.EquateApproxTime(time.Second)
+			if diff := cmp.Diff(tc.want, got, ignoreID, compareExpiresAt); diff != "" {
+				t.Errorf("service.CreateQuote(context.Background(), %v, %v, %v) returned unexpected difference (-want +got):\n%s",
+					username, tc.input.from, tc.input.to, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+// Package holddelivery manages delivery layer of holds.
+package holddelivery
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/middleware"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/tokenpkg"
+	"github.com/go-petr/pet-bank/pkg/web"
+)
+
+// Service provides service layer interface needed by hold delivery layer.
+//
+//go:generate mockgen -source http.go -destination http_mock.go -package holddelivery
+type Service interface {
+	Create(ctx context.Context, username string, arg domain.CreateHoldParams) (domain.Hold, error)
+	Capture(ctx context.Context, username string, id int64, amount string) (domain.CaptureHoldResult, error)
+	Void(ctx context.Context, username string, id int64) (domain.Hold, error)
+	Get(ctx context.Context, username string, id int64) (domain.Hold, error)
+}
+
+// Handler facilitates hold delivery layer logic.
+type Handler struct {
+	service Service
+}
+
+// NewHandler returns hold handler.
+func NewHandler(hs Service) *Handler {
+	return &Handler{
+		service: hs,
+	}
+}
+
+func (h *Handler) serviceError(gctx *gin.Context, err error) {
+	zerolog.Ctx(gctx.Request.Context()).Info().Err(err).Send()
+
+	// The limit error reports the remaining allowance to the client.
+	var limitErr *domain.TransferLimitError
+	if errors.As(err, &limitErr) {
+		gctx.JSON(http.StatusForbidden, web.Response{
+			Data: struct {
+				Limit *domain.TransferLimitError `json:"limit"`
+			}{
+				Limit: limitErr,
+			},
+			Error: err.Error(),
+		})
+
+		return
+	}
+
+	switch err {
+	case
+		domain.ErrInvalidOwner,
+		domain.ErrHoldOwnerMismatch:
+		gctx.JSON(http.StatusUnauthorized, web.Error(err))
+		return
+	case
+		domain.ErrAccountFrozen,
+		domain.ErrHoldBlocked,
+		domain.ErrSanctionsHit:
+		gctx.JSON(http.StatusForbidden, web.Error(err))
+		return
+	case
+		domain.ErrAccountNotFound,
+		domain.ErrHoldNotFound:
+		gctx.JSON(http.StatusNotFound, web.Error(err))
+		return
+	case
+		domain.ErrInvalidAmount,
+		domain.ErrNegativeAmount,
+		domain.ErrInsufficientBalance,
+		domain.ErrCurrencyMismatch,
+		domain.ErrSystemAccount,
+		domain.ErrHoldNotActive,
+		domain.ErrHoldExpired,
+		domain.ErrHoldAmountExceeded:
+		gctx.JSON(http.StatusBadRequest, web.Error(err))
+		return
+	}
+
+	gctx.JSON(http.StatusInternalServerError, web.Error(errorspkg.ErrInternal))
+}
+
+func (h *Handler) bindError(gctx *gin.Context, err error) {
+	zerolog.Ctx(gctx.Request.Context()).Info().Err(err).Send()
+
+	var ve validator.ValidationErrors
+	if errors.As(err, &ve) {
+		gctx.JSON(http.StatusBadRequest, web.Response{Error: web.GetErrorMsg(ve)})
+
+		return
+	}
+
+	gctx.JSON(http.StatusBadRequest, web.Error(err))
+}
+
+type createRequest struct {
+	FromAccountID int32  `json:"from_account_id" binding:"required,min=1"`
+	ToAccountID   int32  `json:"to_account_id" binding:"required,min=1"`
+	Amount        string `json:"amount" binding:"required"`
+}
+
+// Create handles http request to place a hold on the user's account.
+func (h *Handler) Create(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	var req createRequest
+	if err := gctx.ShouldBindJSON(&req); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	arg := domain.CreateHoldParams{
+		AccountID:   req.FromAccountID,
+		ToAccountID: req.ToAccountID,
+		Amount:      req.Amount,
+		SessionID:   authPayload.SessionID,
+	}
+
+	hold, err := h.service.Create(ctx, authPayload.Username, arg)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	res := web.Response{
+		Data: struct {
+			Hold domain.Hold `json:"hold"`
+		}{
+			Hold: hold,
+		},
+	}
+
+	gctx.JSON(http.StatusCreated, res)
+}
+
+type idRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type captureRequest struct {
+	Amount string `json:"amount"`
+}
+
+// Capture handles http request to capture the hold. The whole hold is
+// captured if the amount is omitted.
+func (h *Handler) Capture(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	var uri idRequest
+	if err := gctx.ShouldBindUri(&uri); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	var req captureRequest
+	if gctx.Request.ContentLength != 0 {
+		if err := gctx.ShouldBindJSON(&req); err != nil {
+			h.bindError(gctx, err)
+			return
+		}
+	}
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	result, err := h.service.Capture(ctx, authPayload.Username, uri.ID, req.Amount)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	gctx.JSON(http.StatusCreated, web.Response{Data: result})
+}
+
+// Void handles http request to release the hold.
+func (h *Handler) Void(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	var uri idRequest
+	if err := gctx.ShouldBindUri(&uri); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	hold, err := h.service.Void(ctx, authPayload.Username, uri.ID)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	res := web.Response{
+		Data: struct {
+			Hold domain.Hold `json:"hold"`
+		}{
+			Hold: hold,
+		},
+	}
+
+	gctx.JSON(http.StatusOK, res)
+}
+
+// Get handles http request to get a hold on or to the user's accounts.
+func (h *Handler) Get(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	var uri idRequest
+	if err := gctx.ShouldBindUri(&uri); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	hold, err := h.service.Get(ctx, authPayload.Username, uri.ID)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	res := web.Response{
+		Data: struct {
+			Hold domain.Hold `json:"hold"`
+		}{
+			Hold: hold,
+		},
+	}
+
+	gctx.JSON(http.StatusOK, res)
+}
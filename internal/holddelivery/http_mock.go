@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: http.go
+
+// Package holddelivery is a generated GoMock package.
+package holddelivery
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/go-petr/pet-bank/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Capture mocks base method.
+func (m *MockService) Capture(ctx context.Context, username string, id int64, amount string) (domain.CaptureHoldResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capture", ctx, username, id, amount)
+	ret0, _ := ret[0].(domain.CaptureHoldResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Capture indicates an expected call of Capture.
+func (mr *MockServiceMockRecorder) Capture(ctx, username, id, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capture", reflect.TypeOf((*MockService)(nil).Capture), ctx, username, id, amount)
+}
+
+// Create mocks base method.
+func (m *MockService) Create(ctx context.Context, username string, arg domain.CreateHoldParams) (domain.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, username, arg)
+	ret0, _ := ret[0].(domain.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockServiceMockRecorder) Create(ctx, username, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockService)(nil).Create), ctx, username, arg)
+}
+
+// Get mocks base method.
+func (m *MockService) Get(ctx context.Context, username string, id int64) (domain.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, username, id)
+	ret0, _ := ret[0].(domain.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockServiceMockRecorder) Get(ctx, username, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockService)(nil).Get), ctx, username, id)
+}
+
+// Void mocks base method.
+func (m *MockService) Void(ctx context.Context, username string, id int64) (domain.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Void", ctx, username, id)
+	ret0, _ := ret[0].(domain.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Void indicates an expected call of Void.
+func (mr *MockServiceMockRecorder) Void(ctx, username, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Void", reflect.TypeOf((*MockService)(nil).Void), ctx, username, id)
+}
@@ -0,0 +1,290 @@
+package holddelivery
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/middleware"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/go-petr/pet-bank/pkg/tokenpkg"
+	"github.com/go-petr/pet-bank/pkg/web"
+)
+
+func TestHandler(t *testing.T) {
+	username := randompkg.Owner()
+	symmetricKey := randompkg.String(32)
+
+	tokenMaker, err := tokenpkg.NewPasetoMaker(symmetricKey)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(%v) returned error: %v", symmetricKey, err)
+	}
+
+	hold := domain.Hold{
+		ID:          1,
+		AccountID:   1,
+		ToAccountID: 2,
+		Amount:      "100",
+		Status:      domain.HoldStatusActive,
+		ExpiresAt:   time.Now().Add(time.Hour).UTC().Truncate(time.Second),
+		CreatedAt:   time.Now().UTC().Truncate(time.Second),
+	}
+	limitErr := &domain.TransferLimitError{Limit: domain.LimitDaily, Currency: "USD", Remaining: "50"}
+
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           any
+		buildStubs     func(service *MockService)
+		wantStatusCode int
+		wantError      string
+	}{
+		{
+			name:   "Create",
+			method: http.MethodPost,
+			url:    "/holds",
+			body:   gin.H{"from_account_id": 1, "to_account_id": 2, "amount": "100"},
+			buildStubs: func(service *MockService) {
+				arg := domain.CreateHoldParams{AccountID: 1, ToAccountID: 2, Amount: "100"}
+				service.EXPECT().Create(gomock.Any(), gomock.Eq(username), gomock.Eq(arg)).Times(1).Return(hold, nil)
+			},
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name:   "CreateRequiresToAccount",
+			method: http.MethodPost,
+			url:    "/holds",
+			body:   gin.H{"from_account_id": 1, "amount": "100"},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "ToAccountID field is required",
+		},
+		{
+			name:   "CreateErrInsufficientBalance",
+			method: http.MethodPost,
+			url:    "/holds",
+			body:   gin.H{"from_account_id": 1, "to_account_id": 2, "amount": "100"},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.Hold{}, domain.ErrInsufficientBalance)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      domain.ErrInsufficientBalance.Error(),
+		},
+		{
+			name:   "CreateErrHoldBlocked",
+			method: http.MethodPost,
+			url:    "/holds",
+			body:   gin.H{"from_account_id": 1, "to_account_id": 2, "amount": "100"},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.Hold{}, domain.ErrHoldBlocked)
+			},
+			wantStatusCode: http.StatusForbidden,
+			wantError:      domain.ErrHoldBlocked.Error(),
+		},
+		{
+			name:   "CreateErrSanctionsHit",
+			method: http.MethodPost,
+			url:    "/holds",
+			body:   gin.H{"from_account_id": 1, "to_account_id": 2, "amount": "100"},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.Hold{}, domain.ErrSanctionsHit)
+			},
+			wantStatusCode: http.StatusForbidden,
+			wantError:      domain.ErrSanctionsHit.Error(),
+		},
+		{
+			name:   "Get",
+			method: http.MethodGet,
+			url:    "/holds/1",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Get(gomock.Any(), gomock.Eq(username), gomock.Eq(int64(1))).Times(1).Return(hold, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "GetErrHoldOwnerMismatch",
+			method: http.MethodGet,
+			url:    "/holds/1",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.Hold{}, domain.ErrHoldOwnerMismatch)
+			},
+			wantStatusCode: http.StatusUnauthorized,
+			wantError:      domain.ErrHoldOwnerMismatch.Error(),
+		},
+		{
+			name:   "CaptureFull",
+			method: http.MethodPost,
+			url:    "/holds/1/capture",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Capture(gomock.Any(), gomock.Eq(username), gomock.Eq(int64(1)), gomock.Eq("")).
+					Times(1).
+					Return(domain.CaptureHoldResult{Hold: hold}, nil)
+			},
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name:   "CapturePartial",
+			method: http.MethodPost,
+			url:    "/holds/1/capture",
+			body:   gin.H{"amount": "60"},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Capture(gomock.Any(), gomock.Eq(username), gomock.Eq(int64(1)), gomock.Eq("60")).
+					Times(1).
+					Return(domain.CaptureHoldResult{Hold: hold}, nil)
+			},
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name:   "CaptureErrHoldExpired",
+			method: http.MethodPost,
+			url:    "/holds/1/capture",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Capture(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.CaptureHoldResult{}, domain.ErrHoldExpired)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      domain.ErrHoldExpired.Error(),
+		},
+		{
+			name:   "CaptureTransferLimitError",
+			method: http.MethodPost,
+			url:    "/holds/1/capture",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Capture(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.CaptureHoldResult{}, limitErr)
+			},
+			wantStatusCode: http.StatusForbidden,
+			wantError:      limitErr.Error(),
+		},
+		{
+			name:   "Void",
+			method: http.MethodPost,
+			url:    "/holds/1/void",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Void(gomock.Any(), gomock.Eq(username), gomock.Eq(int64(1))).Times(1).Return(hold, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "VoidErrHoldNotFound",
+			method: http.MethodPost,
+			url:    "/holds/1/void",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Void(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.Hold{}, domain.ErrHoldNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      domain.ErrHoldNotFound.Error(),
+		},
+		{
+			name:   "VoidErrInternal",
+			method: http.MethodPost,
+			url:    "/holds/1/void",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Void(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.Hold{}, errorspkg.ErrInternal)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      errorspkg.ErrInternal.Error(),
+		},
+		{
+			name:   "InvalidID",
+			method: http.MethodPost,
+			url:    "/holds/0/void",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Void(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "ID field is required",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewMockService(ctrl)
+			handler := NewHandler(service)
+			tc.buildStubs(service)
+
+			gin.SetMode(gin.ReleaseMode)
+			server := gin.New()
+			server.Use(middleware.AuthMiddleware(tokenMaker, nil))
+			server.POST("/holds", handler.Create)
+			server.GET("/holds/:id", handler.Get)
+			server.POST("/holds/:id/capture", handler.Capture)
+			server.POST("/holds/:id/void", handler.Void)
+
+			var body []byte
+			if tc.body != nil {
+				if body, err = json.Marshal(tc.body); err != nil {
+					t.Fatalf("Encoding request body error: %v", err)
+				}
+			}
+
+			req, err := http.NewRequest(tc.method, tc.url, bytes.NewReader(body))
+			if err != nil {
+				t.Fatalf("Creating request error: %v", err)
+			}
+
+			if err := middleware.AddAuthorization(req, tokenMaker, middleware.AuthTypeBearer, username, time.Minute); err != nil {
+				t.Fatalf("middleware.AddAuthorization(...) returned error: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			if got := w.Code; got != tc.wantStatusCode {
+				t.Errorf("Status code: got %v, want %v", got, tc.wantStatusCode)
+			}
+
+			data := &struct {
+				Hold domain.Hold `json:"hold"`
+			}{}
+			res := web.Response{Data: data}
+
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Fatalf("Decoding response body error: %v", err)
+			}
+
+			if res.Error != tc.wantError {
+				t.Errorf(`res.Error=%q, want %q`, res.Error, tc.wantError)
+			}
+
+			if tc.wantError == "" {
+				if diff := cmp.Diff(hold, data.Hold); diff != "" {
+					t.Errorf("Response returned unexpected diff: %s", diff)
+				}
+			}
+		})
+	}
+}
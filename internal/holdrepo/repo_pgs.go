@@ -0,0 +1,248 @@
+// Package holdrepo manages repository layer of holds.
+package holdrepo
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/dbpkg"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog"
+)
+
+// RepoPGS facilitates hold repository layer logic.
+type RepoPGS struct {
+	db dbpkg.SQLInterface
+}
+
+// NewRepoPGS returns hold RepoPGS.
+func NewRepoPGS(db dbpkg.SQLInterface) *RepoPGS {
+	return &RepoPGS{
+		db: db,
+	}
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanHold(row scanner) (domain.Hold, error) {
+	var (
+		h              domain.Hold
+		capturedAmount sql.NullString
+		transferID     sql.NullInt64
+		closedAt       sql.NullTime
+	)
+
+	err := row.Scan(
+		&h.ID,
+		&h.AccountID,
+		&h.ToAccountID,
+		&h.Amount,
+		&h.Status,
+		&capturedAmount,
+		&transferID,
+		&h.ExpiresAt,
+		&closedAt,
+		&h.CreatedAt,
+	)
+
+	h.CapturedAmount = capturedAmount.String
+	h.TransferID = transferID.Int64
+
+	if closedAt.Valid {
+		h.ClosedAt = &closedAt.Time
+	}
+
+	return h, err
+}
+
+const createQuery = `
+INSERT INTO
+    holds (account_id, to_account_id, amount, expires_at)
+VALUES
+    ($1, $2, $3, $4)
+RETURNING id, account_id, to_account_id, amount, status, captured_amount, transfer_id,
+    expires_at, closed_at, created_at
+`
+
+// Create creates the active hold and then returns it. The held amount of the
+// account is changed by the caller.
+func (r *RepoPGS) Create(ctx context.Context, arg domain.CreateHoldParams) (domain.Hold, error) {
+	l := zerolog.Ctx(ctx)
+
+	row := r.db.QueryRowContext(ctx, createQuery, arg.AccountID, arg.ToAccountID, arg.Amount, arg.ExpiresAt)
+
+	h, err := scanHold(row)
+	if err != nil {
+		l.Error().Err(err).Send()
+
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Constraint {
+			case "holds_account_id_fkey", "holds_to_account_id_fkey":
+				return h, domain.ErrAccountNotFound
+			case "holds_amount_check":
+				return h, domain.ErrInvalidAmount
+			}
+		}
+
+		return h, errorspkg.ErrInternal
+	}
+
+	return h, nil
+}
+
+const getQuery = `
+SELECT
+	id, account_id, to_account_id, amount, status, captured_amount, transfer_id,
+	expires_at, closed_at, created_at
+FROM holds
+WHERE id = $1
+`
+
+// Get returns the hold with the given id.
+func (r *RepoPGS) Get(ctx context.Context, id int64) (domain.Hold, error) {
+	return r.get(ctx, getQuery, id)
+}
+
+const getForUpdateQuery = `
+SELECT
+	id, account_id, to_account_id, amount, status, captured_amount, transfer_id,
+	expires_at, closed_at, created_at
+FROM holds
+WHERE id = $1
+FOR UPDATE
+`
+
+// GetForUpdate returns the hold with the given id and locks its row until the
+// end of the current transaction.
+func (r *RepoPGS) GetForUpdate(ctx context.Context, id int64) (domain.Hold, error) {
+	return r.get(ctx, getForUpdateQuery, id)
+}
+
+func (r *RepoPGS) get(ctx context.Context, query string, id int64) (domain.Hold, error) {
+	l := zerolog.Ctx(ctx)
+
+	h, err := scanHold(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		l.Error().Err(err).Send()
+
+		if err == sql.ErrNoRows {
+			return h, domain.ErrHoldNotFound
+		}
+
+		return h, errorspkg.ErrInternal
+	}
+
+	return h, nil
+}
+
+const closeQuery = `
+UPDATE holds
+SET status = $2, captured_amount = $3, transfer_id = $4, closed_at = now()
+WHERE id = $1 AND status = 'active'
+RETURNING id, account_id, to_account_id, amount, status, captured_amount, transfer_id,
+    expires_at, closed_at, created_at
+`
+
+// Close sets the final status of the active hold and returns it. It returns
+// domain.ErrHoldNotActive if the hold has already been closed. The held amount
+// of the account is changed by the caller.
+func (r *RepoPGS) Close(ctx context.Context, arg domain.CloseHoldParams) (domain.Hold, error) {
+	l := zerolog.Ctx(ctx)
+
+	row := r.db.QueryRowContext(ctx, closeQuery,
+		arg.ID,
+		arg.Status,
+		sql.NullString{String: arg.CapturedAmount, Valid: arg.CapturedAmount != ""},
+		sql.NullInt64{Int64: arg.TransferID, Valid: arg.TransferID != 0},
+	)
+
+	h, err := scanHold(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return h, domain.ErrHoldNotActive
+		}
+
+		l.Error().Err(err).Send()
+
+		return h, errorspkg.ErrInternal
+	}
+
+	return h, nil
+}
+
+const listExpiredAccountsQuery = `
+SELECT DISTINCT account_id
+FROM holds
+WHERE status = 'active' AND expires_at <= now()
+`
+
+// ListExpiredAccounts returns the ids of the accounts with active holds which
+// have expired.
+func (r *RepoPGS) ListExpiredAccounts(ctx context.Context) ([]int32, error) {
+	l := zerolog.Ctx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, listExpiredAccountsQuery)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+	defer rows.Close()
+
+	ids := []int32{}
+
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			l.Error().Err(err).Send()
+			return nil, errorspkg.ErrInternal
+		}
+
+		ids = append(ids, id)
+	}
+
+	if err := rows.Close(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	if err := rows.Err(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	return ids, nil
+}
+
+const expireQuery = `
+WITH expired AS (
+    UPDATE holds
+    SET status = 'expired', closed_at = now()
+    WHERE account_id = $1 AND status = 'active' AND expires_at <= now()
+    RETURNING amount
+), released AS (
+    UPDATE accounts
+    SET held_amount = held_amount - (SELECT sum(amount) FROM expired)
+    WHERE id = $1 AND EXISTS (SELECT 1 FROM expired)
+)
+SELECT count(*) FROM expired
+`
+
+// Expire closes the expired holds of the account and releases their amount
+// in one statement. It returns the number of expired holds.
+//
+// The holds are locked before the account like in the capture transaction.
+func (r *RepoPGS) Expire(ctx context.Context, accountID int32) (int64, error) {
+	l := zerolog.Ctx(ctx)
+
+	var n int64
+	if err := r.db.QueryRowContext(ctx, expireQuery, accountID).Scan(&n); err != nil {
+		l.Error().Err(err).Send()
+		return 0, errorspkg.ErrInternal
+	}
+
+	return n, nil
+}
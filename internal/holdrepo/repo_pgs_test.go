@@ -0,0 +1,127 @@
+//go:build integration
+
+package holdrepo_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/holdrepo"
+	"github.com/go-petr/pet-bank/internal/integrationtest"
+	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
+	"github.com/go-petr/pet-bank/pkg/configpkg"
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	dbDriver string
+	dbSource string
+)
+
+func TestMain(m *testing.M) {
+	config, err := configpkg.Load("../../configs")
+	if err != nil {
+		log.Fatal("cannot load config:", err)
+	}
+
+	dbDriver = config.DBDriver
+	dbSource = config.DBSource
+
+	os.Exit(m.Run())
+}
+
+func TestCreateClose(t *testing.T) {
+	t.Parallel()
+
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	user := helpers.SeedUser(t, tx)
+	account := helpers.SeedAccountWith1000USDBalance(t, tx, helpers.SeedUser(t, tx).Username)
+	toAccount := helpers.SeedAccountWith1000USDBalance(t, tx, user.Username)
+	holdRepo := holdrepo.NewRepoPGS(tx)
+	ctx := context.Background()
+
+	arg := domain.CreateHoldParams{
+		AccountID:   account.ID,
+		ToAccountID: toAccount.ID,
+		Amount:      "100",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+
+	hold, err := holdRepo.Create(ctx, arg)
+	if err != nil {
+		t.Fatalf("holdRepo.Create(ctx, %+v) returned error: %v", arg, err)
+	}
+
+	if hold.Status != domain.HoldStatusActive || hold.Amount != arg.Amount {
+		t.Errorf("holdRepo.Create(ctx, %+v) returned %+v", arg, hold)
+	}
+
+	got, err := holdRepo.GetForUpdate(ctx, hold.ID)
+	if err != nil {
+		t.Fatalf("holdRepo.GetForUpdate(ctx, %v) returned error: %v", hold.ID, err)
+	}
+
+	if diff := cmp.Diff(hold, got); diff != "" {
+		t.Errorf("holdRepo.GetForUpdate(ctx, %v) returned unexpected difference (-want +got):\n%s", hold.ID, diff)
+	}
+
+	closed, err := holdRepo.Close(ctx, domain.CloseHoldParams{ID: hold.ID, Status: domain.HoldStatusVoided})
+	if err != nil {
+		t.Fatalf("holdRepo.Close(ctx, %v) returned error: %v", hold.ID, err)
+	}
+
+	if closed.Status != domain.HoldStatusVoided || closed.ClosedAt == nil {
+		t.Errorf("holdRepo.Close(ctx, %v) returned %+v", hold.ID, closed)
+	}
+
+	if _, err := holdRepo.Close(ctx, domain.CloseHoldParams{ID: hold.ID, Status: domain.HoldStatusVoided}); err != domain.ErrHoldNotActive {
+		t.Errorf("holdRepo.Close(ctx, %v) returned error: %v, want %v", hold.ID, err, domain.ErrHoldNotActive)
+	}
+
+	if _, err := holdRepo.Get(ctx, 0); err != domain.ErrHoldNotFound {
+		t.Errorf("holdRepo.Get(ctx, 0) returned error: %v, want %v", err, domain.ErrHoldNotFound)
+	}
+}
+
+func TestExpire(t *testing.T) {
+	t.Parallel()
+
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	account := helpers.SeedAccountWith1000USDBalance(t, tx, helpers.SeedUser(t, tx).Username)
+	toAccount := helpers.SeedAccountWith1000USDBalance(t, tx, helpers.SeedUser(t, tx).Username)
+	holdRepo := holdrepo.NewRepoPGS(tx)
+	ctx := context.Background()
+
+	if _, err := tx.Exec("UPDATE accounts SET held_amount = 300 WHERE id = $1", account.ID); err != nil {
+		t.Fatalf("holding amount error: %v", err)
+	}
+
+	for _, expiresAt := range []time.Time{time.Now().Add(-time.Minute), time.Now().Add(-time.Second), time.Now().Add(time.Hour)} {
+		arg := domain.CreateHoldParams{AccountID: account.ID, ToAccountID: toAccount.ID, Amount: "100", ExpiresAt: expiresAt}
+		if _, err := holdRepo.Create(ctx, arg); err != nil {
+			t.Fatalf("holdRepo.Create(ctx, %+v) returned error: %v", arg, err)
+		}
+	}
+
+	n, err := holdRepo.Expire(ctx, account.ID)
+	if err != nil {
+		t.Fatalf("holdRepo.Expire(ctx, %v) returned error: %v", account.ID, err)
+	}
+
+	if n != 2 {
+		t.Errorf("holdRepo.Expire(ctx, %v) = %v, want 2", account.ID, n)
+	}
+
+	var held string
+	if err := tx.QueryRow("SELECT held_amount FROM accounts WHERE id = $1", account.ID).Scan(&held); err != nil {
+		t.Fatalf("selecting held amount error: %v", err)
+	}
+
+	if held != "100" {
+		t.Errorf("held_amount = %v, want 100", held)
+	}
+}
@@ -0,0 +1,289 @@
+// Package holdservice manages business logic layer of holds.
+package holdservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+)
+
+// Repo provides data access layer interface needed by hold service layer.
+//
+//go:generate mockgen -source service.go -destination service_mock.go -package holdservice
+type Repo interface {
+	CreateHold(ctx context.Context, username string, arg domain.CreateHoldParams) (domain.Hold, error)
+	CaptureHold(ctx context.Context, username string, id int64, amount string, limit *domain.TransferLimit) (domain.CaptureHoldResult, error)
+	VoidHold(ctx context.Context, username string, id int64) (domain.Hold, error)
+	GetHold(ctx context.Context, id int64) (domain.Hold, error)
+	ExpireHolds(ctx context.Context) (int64, error)
+}
+
+// AccountRepo provides account data access needed to check holds ownership.
+type AccountRepo interface {
+	Get(ctx context.Context, id int32) (domain.Account, error)
+}
+
+// Limits provides the payer's outgoing transfer limits.
+type Limits interface {
+	Get(ctx context.Context, username, currency string) (domain.TransferLimit, error)
+}
+
+// Monitor assesses the holds with the transaction monitoring rules.
+type Monitor interface {
+	Assess(ctx context.Context, check domain.RiskCheck) (domain.RiskAssessment, error)
+}
+
+// Screener screens the hold recipients against the sanctions list.
+type Screener interface {
+	ScreenRecipient(ctx context.Context, fromUsername string, toAccountID int32) error
+}
+
+// Auditor records audit events of the holds.
+type Auditor interface {
+	Record(ctx context.Context, eventType, actor string, before, after any)
+}
+
+// Service facilitates hold service layer logic.
+type Service struct {
+	repo        Repo
+	accountRepo AccountRepo
+	limits      Limits
+	monitor     Monitor
+	screener    Screener
+	auditor     Auditor
+	ttl         time.Duration
+}
+
+// New returns hold service struct to manage hold bussines logic. Holds expire
+// ttl after they are created. Transfer limits are not checked if lr is nil,
+// holds are not monitored if m is nil, recipients are not screened if sc is
+// nil and audit events are not recorded if a is nil.
+func New(hr Repo, ar AccountRepo, lr Limits, m Monitor, sc Screener, a Auditor, ttl time.Duration) *Service {
+	return &Service{
+		repo:        hr,
+		accountRepo: ar,
+		limits:      lr,
+		monitor:     m,
+		screener:    sc,
+		auditor:     a,
+		ttl:         ttl,
+	}
+}
+
+func validAmount(ctx context.Context, amount string) error {
+	l := zerolog.Ctx(ctx)
+
+	amountDecimal, err := decimal.NewFromString(amount)
+	if err != nil {
+		l.Info().Err(err).Send()
+		return domain.ErrInvalidAmount
+	}
+
+	if amountDecimal.LessThanOrEqual(decimal.Zero) {
+		l.Info().Err(domain.ErrNegativeAmount).Send()
+		return domain.ErrNegativeAmount
+	}
+
+	return nil
+}
+
+// Create checks if the hold amount is valid and then places the hold on the
+// user's account.
+//
+// Ownership, currency, balance and the user's transfer limits checks are done
+// by the repo on the locked account. Active holds count towards the limits.
+//
+// The hold is screened and assessed with the transaction monitoring rules as
+// the transfer it is captured into, which goes to the same recipient and
+// doesn't exceed its amount. Holds for the recipients matching the sanctions
+// list are rejected with domain.ErrSanctionsHit and the flagged hold is
+// rejected with domain.ErrHoldBlocked.
+func (s *Service) Create(ctx context.Context, username string, arg domain.CreateHoldParams) (domain.Hold, error) {
+	if err := validAmount(ctx, arg.Amount); err != nil {
+		return domain.Hold{}, err
+	}
+
+	if s.limits != nil {
+		limit, err := s.limit(ctx, arg.AccountID)
+		if err != nil {
+			return domain.Hold{}, err
+		}
+
+		arg.Limit = &limit
+	}
+
+	if s.screener != nil {
+		if err := s.screener.ScreenRecipient(ctx, username, arg.ToAccountID); err != nil {
+			return domain.Hold{}, err
+		}
+	}
+
+	if s.monitor != nil {
+		if err := s.assess(ctx, username, arg); err != nil {
+			return domain.Hold{}, err
+		}
+	}
+
+	arg.ExpiresAt = time.Now().Add(s.ttl)
+
+	hold, err := s.repo.CreateHold(ctx, username, arg)
+	if err != nil {
+		return hold, err
+	}
+
+	if s.auditor != nil {
+		s.auditor.Record(ctx, domain.AuditHoldCreated, username, nil, hold)
+	}
+
+	return hold, nil
+}
+
+// riskEvent is the audit snapshot of the hold flagged by the transaction
+// monitoring rules.
+type riskEvent struct {
+	Check      domain.RiskCheck      `json:"check"`
+	Assessment domain.RiskAssessment `json:"assessment"`
+}
+
+// assess checks the hold with the transaction monitoring rules and returns
+// ErrHoldBlocked if the hold is flagged. Holds flagged for review are blocked
+// too, since the reviews execute transfers and the hold would expire before
+// the review.
+func (s *Service) assess(ctx context.Context, username string, arg domain.CreateHoldParams) error {
+	check := domain.RiskCheck{
+		Username:      username,
+		SessionID:     arg.SessionID,
+		FromAccountID: arg.AccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		Limit:         arg.Limit,
+	}
+
+	assessment, err := s.monitor.Assess(ctx, check)
+	if err != nil {
+		return err
+	}
+
+	if assessment.Decision == domain.RiskDecisionAllow {
+		return nil
+	}
+
+	zerolog.Ctx(ctx).Warn().Err(domain.ErrHoldBlocked).Strs("rules", assessment.Rules).Send()
+
+	if s.auditor != nil {
+		s.auditor.Record(ctx, domain.AuditHoldBlocked, username, nil, riskEvent{Check: check, Assessment: assessment})
+	}
+
+	return domain.ErrHoldBlocked
+}
+
+// Capture transfers the amount of the hold, or the whole hold if amount is
+// empty, and releases the rest.
+//
+// The amount is checked by the repo against the transfer limits of the hold
+// account owner, who may have lowered them since the hold was placed.
+func (s *Service) Capture(ctx context.Context, username string, id int64, amount string) (domain.CaptureHoldResult, error) {
+	if amount != "" {
+		if err := validAmount(ctx, amount); err != nil {
+			return domain.CaptureHoldResult{}, err
+		}
+	}
+
+	var limit *domain.TransferLimit
+
+	if s.limits != nil {
+		hold, err := s.repo.GetHold(ctx, id)
+		if err != nil {
+			return domain.CaptureHoldResult{}, err
+		}
+
+		holdLimit, err := s.limit(ctx, hold.AccountID)
+		if err != nil {
+			return domain.CaptureHoldResult{}, err
+		}
+
+		limit = &holdLimit
+	}
+
+	result, err := s.repo.CaptureHold(ctx, username, id, amount, limit)
+	if err != nil {
+		return result, err
+	}
+
+	if s.auditor != nil {
+		s.auditor.Record(ctx, domain.AuditHoldCaptured, username, nil, result)
+	}
+
+	return result, nil
+}
+
+// Void releases the hold.
+func (s *Service) Void(ctx context.Context, username string, id int64) (domain.Hold, error) {
+	hold, err := s.repo.VoidHold(ctx, username, id)
+	if err != nil {
+		return hold, err
+	}
+
+	if s.auditor != nil {
+		s.auditor.Record(ctx, domain.AuditHoldVoided, username, nil, hold)
+	}
+
+	return hold, nil
+}
+
+// Get returns the hold if it is on or to one of the user's accounts.
+func (s *Service) Get(ctx context.Context, username string, id int64) (domain.Hold, error) {
+	hold, err := s.repo.GetHold(ctx, id)
+	if err != nil {
+		return hold, err
+	}
+
+	for _, accountID := range []int32{hold.AccountID, hold.ToAccountID} {
+		account, err := s.accountRepo.Get(ctx, accountID)
+		if err != nil {
+			return domain.Hold{}, err
+		}
+
+		if account.Owner == username {
+			return hold, nil
+		}
+	}
+
+	return domain.Hold{}, domain.ErrHoldOwnerMismatch
+}
+
+// limit returns the transfer limits of the account owner in the account currency.
+func (s *Service) limit(ctx context.Context, accountID int32) (domain.TransferLimit, error) {
+	account, err := s.accountRepo.Get(ctx, accountID)
+	if err != nil {
+		return domain.TransferLimit{}, err
+	}
+
+	return s.limits.Get(ctx, account.Owner, account.Currency)
+}
+
+// RunReaper expires holds every interval until the context is done.
+func (s *Service) RunReaper(ctx context.Context, interval time.Duration) {
+	l := zerolog.Ctx(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := s.repo.ExpireHolds(ctx)
+			if err != nil {
+				l.Error().Err(err).Msg("Cannot expire holds")
+				continue
+			}
+
+			l.Info().Int64("count", n).Msg("Expired holds")
+		}
+	}
+}
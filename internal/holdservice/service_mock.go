@@ -0,0 +1,297 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package holdservice is a generated GoMock package.
+package holdservice
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/go-petr/pet-bank/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepo is a mock of Repo interface.
+type MockRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoMockRecorder
+}
+
+// MockRepoMockRecorder is the mock recorder for MockRepo.
+type MockRepoMockRecorder struct {
+	mock *MockRepo
+}
+
+// NewMockRepo creates a new mock instance.
+func NewMockRepo(ctrl *gomock.Controller) *MockRepo {
+	mock := &MockRepo{ctrl: ctrl}
+	mock.recorder = &MockRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
+	return m.recorder
+}
+
+// CaptureHold mocks base method.
+func (m *MockRepo) CaptureHold(ctx context.Context, username string, id int64, amount string, limit *domain.TransferLimit) (domain.CaptureHoldResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CaptureHold", ctx, username, id, amount, limit)
+	ret0, _ := ret[0].(domain.CaptureHoldResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CaptureHold indicates an expected call of CaptureHold.
+func (mr *MockRepoMockRecorder) CaptureHold(ctx, username, id, amount, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureHold", reflect.TypeOf((*MockRepo)(nil).CaptureHold), ctx, username, id, amount, limit)
+}
+
+// CreateHold mocks base method.
+func (m *MockRepo) CreateHold(ctx context.Context, username string, arg domain.CreateHoldParams) (domain.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHold", ctx, username, arg)
+	ret0, _ := ret[0].(domain.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHold indicates an expected call of CreateHold.
+func (mr *MockRepoMockRecorder) CreateHold(ctx, username, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHold", reflect.TypeOf((*MockRepo)(nil).CreateHold), ctx, username, arg)
+}
+
+// ExpireHolds mocks base method.
+func (m *MockRepo) ExpireHolds(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireHolds", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireHolds indicates an expected call of ExpireHolds.
+func (mr *MockRepoMockRecorder) ExpireHolds(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireHolds", reflect.TypeOf((*MockRepo)(nil).ExpireHolds), ctx)
+}
+
+// GetHold mocks base method.
+func (m *MockRepo) GetHold(ctx context.Context, id int64) (domain.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHold", ctx, id)
+	ret0, _ := ret[0].(domain.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHold indicates an expected call of GetHold.
+func (mr *MockRepoMockRecorder) GetHold(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHold", reflect.TypeOf((*MockRepo)(nil).GetHold), ctx, id)
+}
+
+// VoidHold mocks base method.
+func (m *MockRepo) VoidHold(ctx context.Context, username string, id int64) (domain.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoidHold", ctx, username, id)
+	ret0, _ := ret[0].(domain.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VoidHold indicates an expected call of VoidHold.
+func (mr *MockRepoMockRecorder) VoidHold(ctx, username, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoidHold", reflect.TypeOf((*MockRepo)(nil).VoidHold), ctx, username, id)
+}
+
+// MockAccountRepo is a mock of AccountRepo interface.
+type MockAccountRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountRepoMockRecorder
+}
+
+// MockAccountRepoMockRecorder is the mock recorder for MockAccountRepo.
+type MockAccountRepoMockRecorder struct {
+	mock *MockAccountRepo
+}
+
+// NewMockAccountRepo creates a new mock instance.
+func NewMockAccountRepo(ctrl *gomock.Controller) *MockAccountRepo {
+	mock := &MockAccountRepo{ctrl: ctrl}
+	mock.recorder = &MockAccountRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountRepo) EXPECT() *MockAccountRepoMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockAccountRepo) Get(ctx context.Context, id int32) (domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAccountRepoMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAccountRepo)(nil).Get), ctx, id)
+}
+
+// MockLimits is a mock of Limits interface.
+type MockLimits struct {
+	ctrl     *gomock.Controller
+	recorder *MockLimitsMockRecorder
+}
+
+// MockLimitsMockRecorder is the mock recorder for MockLimits.
+type MockLimitsMockRecorder struct {
+	mock *MockLimits
+}
+
+// NewMockLimits creates a new mock instance.
+func NewMockLimits(ctrl *gomock.Controller) *MockLimits {
+	mock := &MockLimits{ctrl: ctrl}
+	mock.recorder = &MockLimitsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLimits) EXPECT() *MockLimitsMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockLimits) Get(ctx context.Context, username, currency string) (domain.TransferLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, username, currency)
+	ret0, _ := ret[0].(domain.TransferLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockLimitsMockRecorder) Get(ctx, username, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockLimits)(nil).Get), ctx, username, currency)
+}
+
+// MockMonitor is a mock of Monitor interface.
+type MockMonitor struct {
+	ctrl     *gomock.Controller
+	recorder *MockMonitorMockRecorder
+}
+
+// MockMonitorMockRecorder is the mock recorder for MockMonitor.
+type MockMonitorMockRecorder struct {
+	mock *MockMonitor
+}
+
+// NewMockMonitor creates a new mock instance.
+func NewMockMonitor(ctrl *gomock.Controller) *MockMonitor {
+	mock := &MockMonitor{ctrl: ctrl}
+	mock.recorder = &MockMonitorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMonitor) EXPECT() *MockMonitorMockRecorder {
+	return m.recorder
+}
+
+// Assess mocks base method.
+func (m *MockMonitor) Assess(ctx context.Context, check domain.RiskCheck) (domain.RiskAssessment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assess", ctx, check)
+	ret0, _ := ret[0].(domain.RiskAssessment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Assess indicates an expected call of Assess.
+func (mr *MockMonitorMockRecorder) Assess(ctx, check interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assess", reflect.TypeOf((*MockMonitor)(nil).Assess), ctx, check)
+}
+
+// MockScreener is a mock of Screener interface.
+type MockScreener struct {
+	ctrl     *gomock.Controller
+	recorder *MockScreenerMockRecorder
+}
+
+// MockScreenerMockRecorder is the mock recorder for MockScreener.
+type MockScreenerMockRecorder struct {
+	mock *MockScreener
+}
+
+// NewMockScreener creates a new mock instance.
+func NewMockScreener(ctrl *gomock.Controller) *MockScreener {
+	mock := &MockScreener{ctrl: ctrl}
+	mock.recorder = &MockScreenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScreener) EXPECT() *MockScreenerMockRecorder {
+	return m.recorder
+}
+
+// ScreenRecipient mocks base method.
+func (m *MockScreener) ScreenRecipient(ctx context.Context, fromUsername string, toAccountID int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScreenRecipient", ctx, fromUsername, toAccountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScreenRecipient indicates an expected call of ScreenRecipient.
+func (mr *MockScreenerMockRecorder) ScreenRecipient(ctx, fromUsername, toAccountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScreenRecipient", reflect.TypeOf((*MockScreener)(nil).ScreenRecipient), ctx, fromUsername, toAccountID)
+}
+
+// MockAuditor is a mock of Auditor interface.
+type MockAuditor struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditorMockRecorder
+}
+
+// MockAuditorMockRecorder is the mock recorder for MockAuditor.
+type MockAuditorMockRecorder struct {
+	mock *MockAuditor
+}
+
+// NewMockAuditor creates a new mock instance.
+func NewMockAuditor(ctrl *gomock.Controller) *MockAuditor {
+	mock := &MockAuditor{ctrl: ctrl}
+	mock.recorder = &MockAuditorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditor) EXPECT() *MockAuditorMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockAuditor) Record(ctx context.Context, eventType, actor string, before, after any) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", ctx, eventType, actor, before, after)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAuditorMockRecorder) Record(ctx, eventType, actor, before, after interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAuditor)(nil).Record), ctx, eventType, actor, before, after)
+}
@@ -0,0 +1,393 @@
+package holdservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+)
+
+const ttl = time.Hour
+
+type mocks struct {
+	repo        *MockRepo
+	accountRepo *MockAccountRepo
+	limits      *MockLimits
+	monitor     *MockMonitor
+	screener    *MockScreener
+	auditor     *MockAuditor
+}
+
+func newService(t *testing.T, buildStubs func(m mocks)) *Service {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	m := mocks{
+		repo:        NewMockRepo(ctrl),
+		accountRepo: NewMockAccountRepo(ctrl),
+		limits:      NewMockLimits(ctrl),
+		monitor:     NewMockMonitor(ctrl),
+		screener:    NewMockScreener(ctrl),
+		auditor:     NewMockAuditor(ctrl),
+	}
+
+	buildStubs(m)
+
+	return New(m.repo, m.accountRepo, m.limits, m.monitor, m.screener, m.auditor, ttl)
+}
+
+func TestCreate(t *testing.T) {
+	username := randompkg.Owner()
+	account := domain.Account{ID: 1, Owner: username, Currency: "USD"}
+	limit := domain.TransferLimit{Currency: account.Currency, Daily: "500"}
+	hold := domain.Hold{ID: 1, AccountID: 1, ToAccountID: 2, Amount: "100", Status: domain.HoldStatusActive}
+	limitErr := &domain.TransferLimitError{Limit: domain.LimitDaily, Currency: account.Currency, Remaining: "50"}
+
+	expectLimit := func(m mocks) {
+		m.accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+		m.limits.EXPECT().Get(gomock.Any(), gomock.Eq(username), gomock.Eq(account.Currency)).Times(1).Return(limit, nil)
+		m.screener.EXPECT().ScreenRecipient(gomock.Any(), gomock.Eq(username), gomock.Eq(int32(2))).Times(1).Return(nil)
+	}
+
+	check := domain.RiskCheck{Username: username, FromAccountID: 1, ToAccountID: 2, Amount: "100", Limit: &limit}
+
+	expectAssess := func(m mocks, decision string) {
+		m.monitor.EXPECT().Assess(gomock.Any(), gomock.Eq(check)).
+			Times(1).
+			Return(domain.RiskAssessment{Decision: decision, Rules: []string{"large_amount"}}, nil)
+	}
+
+	testCases := []struct {
+		name       string
+		amount     string
+		buildStubs func(m mocks)
+		wantErr    error
+	}{
+		{
+			name:   "OK",
+			amount: "100",
+			buildStubs: func(m mocks) {
+				expectLimit(m)
+				expectAssess(m, domain.RiskDecisionAllow)
+				m.repo.EXPECT().CreateHold(gomock.Any(), gomock.Eq(username), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, _ string, arg domain.CreateHoldParams) (domain.Hold, error) {
+						if d := time.Until(arg.ExpiresAt); d <= 0 || d > ttl {
+							t.Errorf("arg.ExpiresAt = %v, want within %v", arg.ExpiresAt, ttl)
+						}
+
+						if arg.Limit == nil || *arg.Limit != limit {
+							t.Errorf("arg.Limit = %v, want %+v", arg.Limit, limit)
+						}
+
+						return hold, nil
+					})
+				m.auditor.EXPECT().Record(gomock.Any(), domain.AuditHoldCreated, username, nil, gomock.Eq(hold)).Times(1)
+			},
+		},
+		{
+			name:   "ErrNegativeAmount",
+			amount: "-1",
+			buildStubs: func(m mocks) {
+				m.repo.EXPECT().CreateHold(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrNegativeAmount,
+		},
+		{
+			name:   "ErrAccountNotFound",
+			amount: "100",
+			buildStubs: func(m mocks) {
+				m.accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(domain.Account{}, domain.ErrAccountNotFound)
+				m.repo.EXPECT().CreateHold(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrAccountNotFound,
+		},
+		{
+			name:   "ErrSanctionsHit",
+			amount: "100",
+			buildStubs: func(m mocks) {
+				m.accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				m.limits.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(limit, nil)
+				m.screener.EXPECT().ScreenRecipient(gomock.Any(), gomock.Eq(username), gomock.Eq(int32(2))).
+					Times(1).
+					Return(domain.ErrSanctionsHit)
+				m.monitor.EXPECT().Assess(gomock.Any(), gomock.Any()).Times(0)
+				m.repo.EXPECT().CreateHold(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrSanctionsHit,
+		},
+		{
+			name:   "ErrHoldBlocked",
+			amount: "100",
+			buildStubs: func(m mocks) {
+				expectLimit(m)
+				expectAssess(m, domain.RiskDecisionBlock)
+				m.auditor.EXPECT().Record(gomock.Any(), domain.AuditHoldBlocked, username, nil, gomock.Any()).Times(1)
+				m.repo.EXPECT().CreateHold(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrHoldBlocked,
+		},
+		{
+			name:   "ReviewBlocked",
+			amount: "100",
+			buildStubs: func(m mocks) {
+				expectLimit(m)
+				expectAssess(m, domain.RiskDecisionReview)
+				m.auditor.EXPECT().Record(gomock.Any(), domain.AuditHoldBlocked, username, nil, gomock.Any()).Times(1)
+				m.repo.EXPECT().CreateHold(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrHoldBlocked,
+		},
+		{
+			name:   "AssessErrInternal",
+			amount: "100",
+			buildStubs: func(m mocks) {
+				expectLimit(m)
+				m.monitor.EXPECT().Assess(gomock.Any(), gomock.Any()).Times(1).Return(domain.RiskAssessment{}, errorspkg.ErrInternal)
+				m.repo.EXPECT().CreateHold(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: errorspkg.ErrInternal,
+		},
+		{
+			name:   "TransferLimitError",
+			amount: "100",
+			buildStubs: func(m mocks) {
+				expectLimit(m)
+				expectAssess(m, domain.RiskDecisionAllow)
+				m.repo.EXPECT().CreateHold(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.Hold{}, limitErr)
+				m.auditor.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: limitErr,
+		},
+		{
+			name:   "ErrInsufficientBalance",
+			amount: "100",
+			buildStubs: func(m mocks) {
+				expectLimit(m)
+				expectAssess(m, domain.RiskDecisionAllow)
+				m.repo.EXPECT().CreateHold(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.Hold{}, domain.ErrInsufficientBalance)
+				m.auditor.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrInsufficientBalance,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			arg := domain.CreateHoldParams{AccountID: 1, ToAccountID: 2, Amount: tc.amount}
+
+			got, err := newService(t, tc.buildStubs).Create(context.Background(), username, arg)
+			if err != tc.wantErr {
+				t.Fatalf("Create(ctx, %v, %+v) returned error: %v, want %v", username, arg, err, tc.wantErr)
+			}
+
+			if err == nil {
+				if diff := cmp.Diff(hold, got); diff != "" {
+					t.Errorf("Create(ctx, %v, %+v) returned unexpected difference (-want +got):\n%s", username, arg, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestCapture(t *testing.T) {
+	username := randompkg.Owner()
+	account := domain.Account{ID: 1, Owner: randompkg.Owner(), Currency: "USD"}
+	limit := domain.TransferLimit{Currency: account.Currency, Daily: "500"}
+	hold := domain.Hold{ID: 1, AccountID: account.ID, ToAccountID: 2, Amount: "100", Status: domain.HoldStatusActive}
+	result := domain.CaptureHoldResult{
+		Hold: domain.Hold{ID: 1, Amount: "100", Status: domain.HoldStatusCaptured, CapturedAmount: "60", TransferID: 7},
+	}
+	limitErr := &domain.TransferLimitError{Limit: domain.LimitDaily, Currency: account.Currency, Remaining: "50"}
+
+	// The limits are of the hold account owner, not of the capturing user.
+	expectLimit := func(m mocks) {
+		m.repo.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.ID)).Times(1).Return(hold, nil)
+		m.accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+		m.limits.EXPECT().Get(gomock.Any(), gomock.Eq(account.Owner), gomock.Eq(account.Currency)).Times(1).Return(limit, nil)
+	}
+
+	testCases := []struct {
+		name       string
+		amount     string
+		buildStubs func(m mocks)
+		wantErr    error
+	}{
+		{
+			name:   "Partial",
+			amount: "60",
+			buildStubs: func(m mocks) {
+				expectLimit(m)
+				m.repo.EXPECT().CaptureHold(gomock.Any(), gomock.Eq(username), gomock.Eq(int64(1)), gomock.Eq("60"), gomock.Eq(&limit)).
+					Times(1).
+					Return(result, nil)
+				m.auditor.EXPECT().Record(gomock.Any(), domain.AuditHoldCaptured, username, nil, gomock.Eq(result)).Times(1)
+			},
+		},
+		{
+			name:   "Full",
+			amount: "",
+			buildStubs: func(m mocks) {
+				expectLimit(m)
+				m.repo.EXPECT().CaptureHold(gomock.Any(), gomock.Eq(username), gomock.Eq(int64(1)), gomock.Eq(""), gomock.Eq(&limit)).
+					Times(1).
+					Return(result, nil)
+				m.auditor.EXPECT().Record(gomock.Any(), domain.AuditHoldCaptured, username, nil, gomock.Eq(result)).Times(1)
+			},
+		},
+		{
+			name:   "ErrInvalidAmount",
+			amount: "abc",
+			buildStubs: func(m mocks) {
+				m.repo.EXPECT().CaptureHold(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrInvalidAmount,
+		},
+		{
+			name:   "ErrHoldNotFound",
+			amount: "60",
+			buildStubs: func(m mocks) {
+				m.repo.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.ID)).Times(1).Return(domain.Hold{}, domain.ErrHoldNotFound)
+				m.repo.EXPECT().CaptureHold(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrHoldNotFound,
+		},
+		{
+			name:   "TransferLimitError",
+			amount: "60",
+			buildStubs: func(m mocks) {
+				expectLimit(m)
+				m.repo.EXPECT().CaptureHold(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.CaptureHoldResult{}, limitErr)
+			},
+			wantErr: limitErr,
+		},
+		{
+			name:   "ErrHoldAmountExceeded",
+			amount: "101",
+			buildStubs: func(m mocks) {
+				expectLimit(m)
+				m.repo.EXPECT().CaptureHold(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.CaptureHoldResult{}, domain.ErrHoldAmountExceeded)
+			},
+			wantErr: domain.ErrHoldAmountExceeded,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := newService(t, tc.buildStubs).Capture(context.Background(), username, 1, tc.amount)
+			if err != tc.wantErr {
+				t.Fatalf("Capture(ctx, %v, 1, %q) returned error: %v, want %v", username, tc.amount, err, tc.wantErr)
+			}
+
+			if err == nil {
+				if diff := cmp.Diff(result, got); diff != "" {
+					t.Errorf("Capture(ctx, %v, 1, %q) returned unexpected difference (-want +got):\n%s", username, tc.amount, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	owner := domain.Account{ID: 1, Owner: randompkg.Owner()}
+	payee := domain.Account{ID: 2, Owner: randompkg.Owner()}
+	hold := domain.Hold{ID: 1, AccountID: owner.ID, ToAccountID: payee.ID, Amount: "100", Status: domain.HoldStatusActive}
+
+	testCases := []struct {
+		name       string
+		username   string
+		buildStubs func(repo *MockRepo, accountRepo *MockAccountRepo)
+		wantErr    error
+	}{
+		{
+			name:     "Owner",
+			username: owner.Owner,
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.ID)).Times(1).Return(hold, nil)
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(owner.ID)).Times(1).Return(owner, nil)
+			},
+		},
+		{
+			name:     "Payee",
+			username: payee.Owner,
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.ID)).Times(1).Return(hold, nil)
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(owner.ID)).Times(1).Return(owner, nil)
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(payee.ID)).Times(1).Return(payee, nil)
+			},
+		},
+		{
+			name:     "ErrHoldOwnerMismatch",
+			username: randompkg.Owner(),
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.ID)).Times(1).Return(hold, nil)
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(owner.ID)).Times(1).Return(owner, nil)
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(payee.ID)).Times(1).Return(payee, nil)
+			},
+			wantErr: domain.ErrHoldOwnerMismatch,
+		},
+		{
+			name:     "ErrHoldNotFound",
+			username: owner.Owner,
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.ID)).Times(1).Return(domain.Hold{}, domain.ErrHoldNotFound)
+			},
+			wantErr: domain.ErrHoldNotFound,
+		},
+		{
+			name:     "ErrInternal",
+			username: owner.Owner,
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.ID)).Times(1).Return(hold, nil)
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(owner.ID)).Times(1).Return(domain.Account{}, errorspkg.ErrInternal)
+			},
+			wantErr: errorspkg.ErrInternal,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepo(ctrl)
+			accountRepo := NewMockAccountRepo(ctrl)
+			tc.buildStubs(repo, accountRepo)
+
+			got, err := New(repo, accountRepo, nil, nil, nil, nil, ttl).Get(context.Background(), tc.username, hold.ID)
+			if err != tc.wantErr {
+				t.Fatalf("Get(ctx, %v, %v) returned error: %v, want %v", tc.username, hold.ID, err, tc.wantErr)
+			}
+
+			if err == nil {
+				if diff := cmp.Diff(hold, got); diff != "" {
+					t.Errorf("Get(ctx, %v, %v) returned unexpected difference (-want +got):\n%s", tc.username, hold.ID, diff)
+				}
+			}
+		})
+	}
+}
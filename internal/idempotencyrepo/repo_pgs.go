@@ -0,0 +1,146 @@
+// Package idempotencyrepo manages repository layer of idempotency keys.
+package idempotencyrepo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/dbpkg"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog"
+)
+
+// RepoPGS facilitates idempotency key repository layer logic.
+type RepoPGS struct {
+	db dbpkg.SQLInterface
+}
+
+// NewRepoPGS returns idempotency key RepoPGS.
+func NewRepoPGS(db dbpkg.SQLInterface) *RepoPGS {
+	return &RepoPGS{
+		db: db,
+	}
+}
+
+const createQuery = `
+INSERT INTO idempotency_keys (
+	key,
+	username,
+	request_hash,
+	response_status,
+	response_body
+) VALUES (
+	$1, $2, $3, $4, $5
+) RETURNING key, username, request_hash, response_status, response_body, created_at
+`
+
+// Create stores the idempotency key with the request result and then returns it.
+func (r *RepoPGS) Create(ctx context.Context, arg domain.CreateIdempotencyKeyParams) (domain.IdempotencyKey, error) {
+	l := zerolog.Ctx(ctx)
+
+	row := r.db.QueryRowContext(ctx, createQuery,
+		arg.Key,
+		arg.Username,
+		arg.RequestHash,
+		arg.ResponseStatus,
+		arg.ResponseBody,
+	)
+
+	var k domain.IdempotencyKey
+
+	err := row.Scan(
+		&k.Key,
+		&k.Username,
+		&k.RequestHash,
+		&k.ResponseStatus,
+		&k.ResponseBody,
+		&k.CreatedAt,
+	)
+
+	if err != nil {
+		l.Error().Err(err).Send()
+
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Constraint {
+			case "idempotency_keys_pkey":
+				return k, domain.ErrIdempotencyKeyExists
+			case "idempotency_keys_username_fkey":
+				return k, domain.ErrUserNotFound
+			}
+		}
+
+		return k, errorspkg.ErrInternal
+	}
+
+	return k, nil
+}
+
+const getQuery = `
+SELECT
+	key,
+	username,
+	request_hash,
+	response_status,
+	response_body,
+	created_at
+FROM idempotency_keys
+WHERE username = $1 AND key = $2
+`
+
+// Get returns the idempotency key stored by the given user.
+func (r *RepoPGS) Get(ctx context.Context, username, key string) (domain.IdempotencyKey, error) {
+	l := zerolog.Ctx(ctx)
+
+	row := r.db.QueryRowContext(ctx, getQuery, username, key)
+
+	var k domain.IdempotencyKey
+
+	err := row.Scan(
+		&k.Key,
+		&k.Username,
+		&k.RequestHash,
+		&k.ResponseStatus,
+		&k.ResponseBody,
+		&k.CreatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return k, domain.ErrIdempotencyKeyNotFound
+		}
+
+		l.Error().Err(err).Send()
+
+		return k, errorspkg.ErrInternal
+	}
+
+	return k, nil
+}
+
+const deleteCreatedBeforeQuery = `
+DELETE FROM idempotency_keys
+WHERE created_at < $1
+`
+
+// DeleteCreatedBefore removes the idempotency keys created before the given time
+// and returns the number of removed keys.
+func (r *RepoPGS) DeleteCreatedBefore(ctx context.Context, before time.Time) (int64, error) {
+	l := zerolog.Ctx(ctx)
+
+	res, err := r.db.ExecContext(ctx, deleteCreatedBeforeQuery, before)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return 0, errorspkg.ErrInternal
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		l.Error().Err(err).Send()
+		return 0, errorspkg.ErrInternal
+	}
+
+	return n, nil
+}
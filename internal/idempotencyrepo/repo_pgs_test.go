@@ -0,0 +1,221 @@
+//go:build integration
+
+package idempotencyrepo_test
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/idempotencyrepo"
+	"github.com/go-petr/pet-bank/internal/integrationtest"
+	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
+	"github.com/go-petr/pet-bank/pkg/configpkg"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	_ "github.com/lib/pq"
+)
+
+var (
+	dbDriver string
+	dbSource string
+)
+
+func TestMain(m *testing.M) {
+	config, err := configpkg.Load("../../configs")
+	if err != nil {
+		log.Fatal("cannot load config:", err)
+	}
+
+	dbDriver = config.DBDriver
+	dbSource = config.DBSource
+
+	os.Exit(m.Run())
+}
+
+func randomParams(username string) domain.CreateIdempotencyKeyParams {
+	return domain.CreateIdempotencyKeyParams{
+		Key:            randompkg.String(20),
+		Username:       username,
+		RequestHash:    randompkg.String(64),
+		ResponseStatus: 201,
+		ResponseBody:   []byte(`{"amount":"10"}`),
+	}
+}
+
+func SeedIdempotencyKey(t *testing.T, tx *sql.Tx, username string) domain.IdempotencyKey {
+	arg := randomParams(username)
+	repo := idempotencyrepo.NewRepoPGS(tx)
+
+	key, err := repo.Create(context.Background(), arg)
+	if err != nil {
+		t.Fatalf("repo.Create(context.Background(), %+v) returned error: %v", arg, err)
+	}
+
+	return key
+}
+
+func TestCreate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		arg     func(tx *sql.Tx) domain.CreateIdempotencyKeyParams
+		wantErr error
+	}{
+		{
+			name: "OK",
+			arg: func(tx *sql.Tx) domain.CreateIdempotencyKeyParams {
+				user := helpers.SeedUser(t, tx)
+				return randomParams(user.Username)
+			},
+		},
+		{
+			name: "ErrUserNotFound",
+			arg: func(tx *sql.Tx) domain.CreateIdempotencyKeyParams {
+				return randomParams(randompkg.Owner())
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name: "ErrIdempotencyKeyExists",
+			arg: func(tx *sql.Tx) domain.CreateIdempotencyKeyParams {
+				user := helpers.SeedUser(t, tx)
+				key := SeedIdempotencyKey(t, tx, user.Username)
+				arg := randomParams(user.Username)
+				arg.Key = key.Key
+
+				return arg
+			},
+			wantErr: domain.ErrIdempotencyKeyExists,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+			arg := tc.arg(tx)
+			repo := idempotencyrepo.NewRepoPGS(tx)
+
+			got, err := repo.Create(context.Background(), arg)
+			if err != nil {
+				if err == tc.wantErr {
+					return
+				}
+				t.Fatalf("repo.Create(context.Background(), %+v) returned error: %v", arg, err)
+			}
+
+			want := domain.IdempotencyKey{
+				Key:            arg.Key,
+				Username:       arg.Username,
+				RequestHash:    arg.RequestHash,
+				ResponseStatus: arg.ResponseStatus,
+				ResponseBody:   arg.ResponseBody,
+				CreatedAt:      time.Now(),
+			}
+
+			compareCreatedAt := cmpopts.EquateApproxTime(time.Second)
+			if diff := cmp.Diff(want, got, compareCreatedAt); diff != "" {
+				t.Errorf("repo.Create(context.Background(), %+v) returned unexpected difference (-want +got):\n%s",
+					arg, diff)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	testCases := []struct {
+		name    string
+		wantKey func(tx *sql.Tx) domain.IdempotencyKey
+		wantErr error
+	}{
+		{
+			name: "OK",
+			wantKey: func(tx *sql.Tx) domain.IdempotencyKey {
+				user := helpers.SeedUser(t, tx)
+				return SeedIdempotencyKey(t, tx, user.Username)
+			},
+		},
+		{
+			name: "ErrIdempotencyKeyNotFound",
+			wantKey: func(tx *sql.Tx) domain.IdempotencyKey {
+				return domain.IdempotencyKey{Key: randompkg.String(20), Username: randompkg.Owner()}
+			},
+			wantErr: domain.ErrIdempotencyKeyNotFound,
+		},
+		{
+			name: "AnotherUserKey",
+			wantKey: func(tx *sql.Tx) domain.IdempotencyKey {
+				user1 := helpers.SeedUser(t, tx)
+				user2 := helpers.SeedUser(t, tx)
+				key := SeedIdempotencyKey(t, tx, user1.Username)
+
+				return domain.IdempotencyKey{Key: key.Key, Username: user2.Username}
+			},
+			wantErr: domain.ErrIdempotencyKeyNotFound,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+			want := tc.wantKey(tx)
+			repo := idempotencyrepo.NewRepoPGS(tx)
+
+			got, err := repo.Get(context.Background(), want.Username, want.Key)
+			if err != nil {
+				if err == tc.wantErr {
+					return
+				}
+				t.Fatalf("repo.Get(context.Background(), %v, %v) returned error: %v", want.Username, want.Key, err)
+			}
+
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("repo.Get(context.Background(), %v, %v) returned unexpected difference (-want +got):\n%s",
+					want.Username, want.Key, diff)
+			}
+		})
+	}
+}
+
+func TestDeleteCreatedBefore(t *testing.T) {
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	user := helpers.SeedUser(t, tx)
+	key := SeedIdempotencyKey(t, tx, user.Username)
+	repo := idempotencyrepo.NewRepoPGS(tx)
+
+	n, err := repo.DeleteCreatedBefore(context.Background(), key.CreatedAt.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("repo.DeleteCreatedBefore(context.Background(), ...) returned error: %v", err)
+	}
+
+	if n != 0 {
+		t.Errorf("repo.DeleteCreatedBefore(context.Background(), ...) = %v, want 0", n)
+	}
+
+	n, err = repo.DeleteCreatedBefore(context.Background(), key.CreatedAt.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("repo.DeleteCreatedBefore(context.Background(), ...) returned error: %v", err)
+	}
+
+	if n < 1 {
+		t.Errorf("repo.DeleteCreatedBefore(context.Background(), ...) = %v, want at least 1", n)
+	}
+
+	_, err = repo.Get(context.Background(), key.Username, key.Key)
+	if err != domain.ErrIdempotencyKeyNotFound {
+		t.Errorf("repo.Get(context.Background(), %v, %v) returned error: %v, want %v",
+			key.Username, key.Key, err, domain.ErrIdempotencyKeyNotFound)
+	}
+}
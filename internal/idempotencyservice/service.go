@@ -0,0 +1,58 @@
+// Package idempotencyservice manages business logic layer of idempotency keys.
+package idempotencyservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Repo provides data access layer interface needed by idempotency service layer.
+//
+//go:generate mockgen -source service.go -destination service_mock.go -package idempotencyservice
+type Repo interface {
+	DeleteCreatedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Service facilitates idempotency key service layer logic.
+type Service struct {
+	repo Repo
+	ttl  time.Duration
+}
+
+// New returns idempotency service struct to manage idempotency keys expiration.
+func New(r Repo, ttl time.Duration) *Service {
+	return &Service{
+		repo: r,
+		ttl:  ttl,
+	}
+}
+
+// Expire removes the idempotency keys older than the configured TTL.
+func (s *Service) Expire(ctx context.Context) (int64, error) {
+	return s.repo.DeleteCreatedBefore(ctx, time.Now().Add(-s.ttl))
+}
+
+// RunReaper expires idempotency keys every interval until the context is done.
+func (s *Service) RunReaper(ctx context.Context, interval time.Duration) {
+	l := zerolog.Ctx(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := s.Expire(ctx)
+			if err != nil {
+				l.Error().Err(err).Msg("Cannot expire idempotency keys")
+				continue
+			}
+
+			l.Info().Int64("count", n).Msg("Expired idempotency keys")
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package idempotencyservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/golang/mock/gomock"
+)
+
+func TestExpire(t *testing.T) {
+	ttl := time.Hour
+
+	testCases := []struct {
+		name       string
+		buildStubs func(repo *MockRepo)
+		want       int64
+		wantErr    error
+	}{
+		{
+			name: "OK",
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().
+					DeleteCreatedBefore(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, before time.Time) (int64, error) {
+						if d := time.Until(before) + ttl; d > time.Second || d < -time.Second {
+							t.Errorf("before = %v, want about %v ago", before, ttl)
+						}
+
+						return 3, nil
+					})
+			},
+			want: 3,
+		},
+		{
+			name: "RepoError",
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().
+					DeleteCreatedBefore(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), errorspkg.ErrInternal)
+			},
+			wantErr: errorspkg.ErrInternal,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockRepo(ctrl)
+			tc.buildStubs(repo)
+
+			service := New(repo, ttl)
+
+			got, err := service.Expire(context.Background())
+			if err != tc.wantErr {
+				t.Fatalf("service.Expire(context.Background()) returned error: %v, want %v", err, tc.wantErr)
+			}
+
+			if got != tc.want {
+				t.Errorf("service.Expire(context.Background()) = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
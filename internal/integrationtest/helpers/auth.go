@@ -0,0 +1,64 @@
+package helpers
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/middleware"
+	"github.com/go-petr/pet-bank/pkg/dbpkg"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/go-petr/pet-bank/pkg/tokenpkg"
+	"github.com/google/uuid"
+)
+
+// AddAuthorization seeds a session of the user inside a test transaction and
+// sets authorization token bound to it with the customer role scopes to the
+// given request.
+func AddAuthorization(
+	t *testing.T,
+	tx dbpkg.SQLInterface,
+	r *http.Request,
+	tm tokenpkg.Maker,
+	authType, username string,
+	d time.Duration,
+) error {
+	t.Helper()
+
+	claims := tokenpkg.Claims{
+		Username: username,
+		Role:     domain.RoleCustomer,
+		Scopes:   domain.RoleScopes(domain.RoleCustomer),
+	}
+
+	return AddAuthorizationWithClaims(t, tx, r, tm, authType, claims, d)
+}
+
+// AddAuthorizationWithClaims seeds a session of claims.Username inside a test
+// transaction and sets authorization token with the given claims bound to it
+// to the given request.
+func AddAuthorizationWithClaims(
+	t *testing.T,
+	tx dbpkg.SQLInterface,
+	r *http.Request,
+	tm tokenpkg.Maker,
+	authType string,
+	claims tokenpkg.Claims,
+	d time.Duration,
+) error {
+	t.Helper()
+
+	session := SeedSession(t, tx, domain.CreateSessionParams{
+		ID:           uuid.New(),
+		Username:     claims.Username,
+		RefreshToken: randompkg.String(32),
+		UserAgent:    "Mozilla/5.0",
+		ClientIP:     "123.123.123.123",
+		ExpiresAt:    time.Now().Add(time.Hour),
+	})
+
+	claims.SessionID = session.ID
+
+	return middleware.AddAuthorizationWithClaims(r, tm, authType, claims, d)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/go-petr/pet-bank/internal/accountrepo"
 	"github.com/go-petr/pet-bank/internal/domain"
 	"github.com/go-petr/pet-bank/internal/entryrepo"
+	"github.com/go-petr/pet-bank/internal/fxrepo"
 	"github.com/go-petr/pet-bank/internal/sessionrepo"
 	"github.com/go-petr/pet-bank/internal/userrepo"
 	"github.com/go-petr/pet-bank/pkg/currencypkg"
@@ -74,10 +75,11 @@ func SeedEntry(t *testing.T, tx dbpkg.SQLInterface, amount string, accountID int
 
 	entryRepo := entryrepo.NewRepoPGS(tx)
 
-	entry, err := entryRepo.Create(context.Background(), amount, accountID)
+	arg := domain.CreateEntryParams{AccountID: accountID, Amount: amount}
+
+	entry, err := entryRepo.Create(context.Background(), arg)
 	if err != nil {
-		t.Fatalf("entryRepo.Create(context.Background(), %v, %v) returned error: %v",
-			amount, accountID, err)
+		t.Fatalf("entryRepo.Create(context.Background(), %+v) returned error: %v", arg, err)
 	}
 
 	return entry
@@ -156,3 +158,48 @@ func SeedSession(t *testing.T, tx dbpkg.SQLInterface, arg domain.CreateSessionPa
 
 	return session
 }
+
+// SeedFXQuote creates fx quote inside a test transaction.
+func SeedFXQuote(t *testing.T, tx dbpkg.SQLInterface, arg domain.CreateFXQuoteParams) domain.FXQuote {
+	t.Helper()
+
+	fxRepo := fxrepo.NewRepoPGS(tx)
+
+	quote, err := fxRepo.Create(context.Background(), arg)
+	if err != nil {
+		t.Fatalf("fxRepo.Create(context.Background(), %+v) returned error: %v", arg, err)
+	}
+
+	return quote
+}
+
+// FreezeAccount freezes the account inside a test transaction.
+func FreezeAccount(t *testing.T, tx dbpkg.SQLInterface, id int32) domain.Account {
+	t.Helper()
+
+	accountRepo := accountrepo.NewRepoPGS(tx)
+
+	account, err := accountRepo.Freeze(context.Background(), id)
+	if err != nil {
+		t.Fatalf("accountRepo.Freeze(context.Background(), %v) returned error: %v", id, err)
+	}
+
+	return account
+}
+
+// SeedUserWithRole creates random User with the given role inside a test transaction.
+func SeedUserWithRole(t *testing.T, tx dbpkg.SQLInterface, password, role string) domain.User {
+	t.Helper()
+
+	user := SeedUserWith(t, tx, password)
+
+	const query = `UPDATE users SET role = $1 WHERE username = $2`
+
+	if _, err := tx.ExecContext(context.Background(), query, role, user.Username); err != nil {
+		t.Fatalf("setting role %v of user %v returned error: %v", role, user.Username, err)
+	}
+
+	user.Role = role
+
+	return user
+}
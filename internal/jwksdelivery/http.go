@@ -0,0 +1,31 @@
+// Package jwksdelivery manages delivery layer of the token verification keys.
+package jwksdelivery
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-petr/pet-bank/pkg/tokenpkg"
+)
+
+// Handler facilitates JWKS delivery layer logic.
+type Handler struct {
+	provider tokenpkg.PublicKeyProvider
+}
+
+// NewHandler returns JWKS handler.
+func NewHandler(p tokenpkg.PublicKeyProvider) *Handler {
+	return &Handler{
+		provider: p,
+	}
+}
+
+// Get handles http request to get the public keys which verify tokens.
+//
+// The key set is returned as defined by RFC 7517 without the response envelope,
+// so that standard clients can consume it.
+func (h *Handler) Get(gctx *gin.Context) {
+	gctx.Header("Cache-Control", "public, max-age=300")
+	gctx.JSON(http.StatusOK, h.provider.JWKS())
+}
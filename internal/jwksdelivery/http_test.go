@@ -0,0 +1,72 @@
+package jwksdelivery
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-petr/pet-bank/pkg/tokenpkg"
+)
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+
+	oldPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey(rand.Reader) returned error: %v", err)
+	}
+
+	seed := base64.StdEncoding.EncodeToString(make([]byte, ed25519.SeedSize))
+	publicKeys := "old=" + base64.StdEncoding.EncodeToString(oldPub)
+
+	keys, err := tokenpkg.ParseKeySet("new", seed, publicKeys)
+	if err != nil {
+		t.Fatalf("tokenpkg.ParseKeySet(new, %v, %v) returned error: %v", seed, publicKeys, err)
+	}
+
+	maker, err := tokenpkg.NewJWTEdDSAMaker(keys)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewJWTEdDSAMaker(keys) returned error: %v", err)
+	}
+
+	provider := maker.(tokenpkg.PublicKeyProvider)
+
+	gin.SetMode(gin.ReleaseMode)
+	server := gin.New()
+
+	url := "/.well-known/jwks.json"
+	server.GET(url, NewHandler(provider).Get)
+
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest(%v, %v, nil) returned error: %v", http.MethodGet, url, err)
+	}
+
+	server.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("recorder.Code = %v, want %v", recorder.Code, http.StatusOK)
+	}
+
+	var got tokenpkg.JWKS
+	if err := json.NewDecoder(recorder.Body).Decode(&got); err != nil {
+		t.Fatalf("Decoding response body error: %v", err)
+	}
+
+	if diff := cmp.Diff(provider.JWKS(), got); diff != "" {
+		t.Errorf("JWKS returned unexpected diff: %s", diff)
+	}
+
+	if len(got.Keys) != 2 {
+		t.Errorf("len(got.Keys) = %v, want 2", len(got.Keys))
+	}
+}
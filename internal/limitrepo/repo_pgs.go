@@ -0,0 +1,187 @@
+// Package limitrepo manages repository layer of the per user transfer limit
+// overrides.
+package limitrepo
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/dbpkg"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog"
+)
+
+// RepoPGS facilitates transfer limit repository layer logic.
+type RepoPGS struct {
+	db dbpkg.SQLInterface
+}
+
+// NewRepoPGS returns transfer limit RepoPGS.
+func NewRepoPGS(db dbpkg.SQLInterface) *RepoPGS {
+	return &RepoPGS{
+		db: db,
+	}
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanLimit(row scanner) (domain.TransferLimit, error) {
+	var (
+		l                              domain.TransferLimit
+		perTransaction, daily, monthly sql.NullString
+	)
+
+	err := row.Scan(&l.Username, &l.Currency, &perTransaction, &daily, &monthly)
+
+	l.PerTransaction, l.Daily, l.Monthly = perTransaction.String, daily.String, monthly.String
+
+	return l, err
+}
+
+// nullAmount stores the empty limit as NULL.
+func nullAmount(amount string) sql.NullString {
+	return sql.NullString{String: amount, Valid: amount != ""}
+}
+
+const getQuery = `
+SELECT username, currency, per_transaction, daily, monthly
+FROM user_transfer_limits
+WHERE username = $1 AND currency = $2
+`
+
+// Get returns the user's limit override in the currency.
+func (r *RepoPGS) Get(ctx context.Context, username, currency string) (domain.TransferLimit, error) {
+	l := zerolog.Ctx(ctx)
+
+	limit, err := scanLimit(r.db.QueryRowContext(ctx, getQuery, username, currency))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return limit, domain.ErrTransferLimitNotFound
+		}
+
+		l.Error().Err(err).Send()
+
+		return limit, errorspkg.ErrInternal
+	}
+
+	return limit, nil
+}
+
+const listQuery = `
+SELECT username, currency, per_transaction, daily, monthly
+FROM user_transfer_limits
+WHERE username = $1
+ORDER BY currency
+`
+
+// List returns all the limit overrides of the user ordered by currency.
+func (r *RepoPGS) List(ctx context.Context, username string) ([]domain.TransferLimit, error) {
+	l := zerolog.Ctx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, listQuery, username)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+	defer rows.Close()
+
+	items := []domain.TransferLimit{}
+
+	for rows.Next() {
+		limit, err := scanLimit(rows)
+		if err != nil {
+			l.Error().Err(err).Send()
+			return nil, errorspkg.ErrInternal
+		}
+
+		items = append(items, limit)
+	}
+
+	if err := rows.Close(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	if err := rows.Err(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	return items, nil
+}
+
+const upsertQuery = `
+INSERT INTO user_transfer_limits (username, currency, per_transaction, daily, monthly)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (username, currency) DO UPDATE
+SET per_transaction = EXCLUDED.per_transaction,
+    daily = EXCLUDED.daily,
+    monthly = EXCLUDED.monthly,
+    updated_at = now()
+RETURNING username, currency, per_transaction, daily, monthly
+`
+
+// Upsert sets the user's limit override in the currency and returns it.
+func (r *RepoPGS) Upsert(ctx context.Context, arg domain.TransferLimit) (domain.TransferLimit, error) {
+	l := zerolog.Ctx(ctx)
+
+	limit, err := scanLimit(r.db.QueryRowContext(ctx, upsertQuery,
+		arg.Username,
+		arg.Currency,
+		nullAmount(arg.PerTransaction),
+		nullAmount(arg.Daily),
+		nullAmount(arg.Monthly),
+	))
+	if err != nil {
+		l.Error().Err(err).Send()
+
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Constraint {
+			case "user_transfer_limits_username_fkey":
+				return limit, domain.ErrUserNotFound
+			case
+				"user_transfer_limits_per_transaction_check",
+				"user_transfer_limits_daily_check",
+				"user_transfer_limits_monthly_check":
+				return limit, domain.ErrInvalidTransferLimit
+			}
+		}
+
+		return limit, errorspkg.ErrInternal
+	}
+
+	return limit, nil
+}
+
+const deleteQuery = `
+DELETE FROM user_transfer_limits
+WHERE username = $1 AND currency = $2
+`
+
+// Delete deletes the user's limit override in the currency. It returns
+// domain.ErrTransferLimitNotFound if there is none.
+func (r *RepoPGS) Delete(ctx context.Context, username, currency string) error {
+	l := zerolog.Ctx(ctx)
+
+	res, err := r.db.ExecContext(ctx, deleteQuery, username, currency)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return errorspkg.ErrInternal
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		l.Error().Err(err).Send()
+		return errorspkg.ErrInternal
+	}
+
+	if n == 0 {
+		return domain.ErrTransferLimitNotFound
+	}
+
+	return nil
+}
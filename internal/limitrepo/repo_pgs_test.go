@@ -0,0 +1,142 @@
+//go:build integration
+
+package limitrepo_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/integrationtest"
+	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
+	"github.com/go-petr/pet-bank/internal/limitrepo"
+	"github.com/go-petr/pet-bank/pkg/configpkg"
+	"github.com/go-petr/pet-bank/pkg/currencypkg"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	dbDriver string
+	dbSource string
+)
+
+func TestMain(m *testing.M) {
+	config, err := configpkg.Load("../../configs")
+	if err != nil {
+		log.Fatal("cannot load config:", err)
+	}
+
+	dbDriver = config.DBDriver
+	dbSource = config.DBSource
+
+	os.Exit(m.Run())
+}
+
+func TestLimits(t *testing.T) {
+	t.Parallel()
+
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	limitRepo := limitrepo.NewRepoPGS(tx)
+	ctx := context.Background()
+
+	user := helpers.SeedUser(t, tx)
+
+	if _, err := limitRepo.Get(ctx, user.Username, currencypkg.USD); err != domain.ErrTransferLimitNotFound {
+		t.Errorf("limitRepo.Get(ctx, %q, USD) returned error: %v, want %v", user.Username, err, domain.ErrTransferLimitNotFound)
+	}
+
+	arg := domain.TransferLimit{Username: user.Username, Currency: currencypkg.USD, Daily: "1000"}
+
+	limit, err := limitRepo.Upsert(ctx, arg)
+	if err != nil {
+		t.Fatalf("limitRepo.Upsert(ctx, %+v) returned error: %v", arg, err)
+	}
+
+	if diff := cmp.Diff(arg, limit); diff != "" {
+		t.Errorf("limitRepo.Upsert(ctx, %+v) returned unexpected difference (-want +got):\n%s", arg, diff)
+	}
+
+	// Upsert replaces the whole override.
+	arg = domain.TransferLimit{Username: user.Username, Currency: currencypkg.USD, PerTransaction: "100", Monthly: "5000"}
+
+	if _, err := limitRepo.Upsert(ctx, arg); err != nil {
+		t.Fatalf("limitRepo.Upsert(ctx, %+v) returned error: %v", arg, err)
+	}
+
+	got, err := limitRepo.Get(ctx, user.Username, currencypkg.USD)
+	if err != nil {
+		t.Fatalf("limitRepo.Get(ctx, %q, USD) returned error: %v", user.Username, err)
+	}
+
+	if diff := cmp.Diff(arg, got); diff != "" {
+		t.Errorf("limitRepo.Get(ctx, %q, USD) returned unexpected difference (-want +got):\n%s", user.Username, diff)
+	}
+
+	eur := domain.TransferLimit{Username: user.Username, Currency: currencypkg.EUR, Daily: "200"}
+	if _, err := limitRepo.Upsert(ctx, eur); err != nil {
+		t.Fatalf("limitRepo.Upsert(ctx, %+v) returned error: %v", eur, err)
+	}
+
+	limits, err := limitRepo.List(ctx, user.Username)
+	if err != nil {
+		t.Fatalf("limitRepo.List(ctx, %q) returned error: %v", user.Username, err)
+	}
+
+	if diff := cmp.Diff([]domain.TransferLimit{eur, arg}, limits); diff != "" {
+		t.Errorf("limitRepo.List(ctx, %q) returned unexpected difference (-want +got):\n%s", user.Username, diff)
+	}
+
+	if err := limitRepo.Delete(ctx, user.Username, currencypkg.USD); err != nil {
+		t.Fatalf("limitRepo.Delete(ctx, %q, USD) returned error: %v", user.Username, err)
+	}
+
+	if err := limitRepo.Delete(ctx, user.Username, currencypkg.USD); err != domain.ErrTransferLimitNotFound {
+		t.Errorf("limitRepo.Delete(ctx, %q, USD) returned error: %v, want %v", user.Username, err, domain.ErrTransferLimitNotFound)
+	}
+}
+
+// Each constraint violation aborts the transaction, so every case runs in its own.
+func TestUpsertErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		arg     func(username string) domain.TransferLimit
+		wantErr error
+	}{
+		{
+			name: "ErrUserNotFound",
+			arg: func(string) domain.TransferLimit {
+				return domain.TransferLimit{Username: randompkg.Owner(), Currency: currencypkg.USD, Daily: "1000"}
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name: "ErrInvalidTransferLimit",
+			arg: func(username string) domain.TransferLimit {
+				return domain.TransferLimit{Username: username, Currency: currencypkg.USD, Monthly: "0"}
+			},
+			wantErr: domain.ErrInvalidTransferLimit,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+			limitRepo := limitrepo.NewRepoPGS(tx)
+			user := helpers.SeedUser(t, tx)
+			arg := tc.arg(user.Username)
+
+			if _, err := limitRepo.Upsert(context.Background(), arg); err != tc.wantErr {
+				t.Errorf("limitRepo.Upsert(ctx, %+v) returned error: %v, want %v", arg, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// Package limitservice manages business logic layer of the outgoing transfer
+// limits.
+package limitservice
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/currencypkg"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+)
+
+// Repo provides data access layer interface needed by transfer limit service layer.
+//
+//go:generate mockgen -source service.go -destination service_mock.go -package limitservice
+type Repo interface {
+	Get(ctx context.Context, username, currency string) (domain.TransferLimit, error)
+	List(ctx context.Context, username string) ([]domain.TransferLimit, error)
+	Upsert(ctx context.Context, arg domain.TransferLimit) (domain.TransferLimit, error)
+	Delete(ctx context.Context, username, currency string) error
+}
+
+// Service facilitates transfer limit service layer logic.
+//
+// The limits of a user are the configured default limits of the currency
+// overridden by the user's limit override.
+type Service struct {
+	repo     Repo
+	defaults map[string]domain.TransferLimit
+}
+
+// New returns transfer limit service struct with the default limits by
+// currency. Currencies without default limits are unlimited by default.
+func New(r Repo, defaults map[string]domain.TransferLimit) *Service {
+	return &Service{
+		repo:     r,
+		defaults: defaults,
+	}
+}
+
+// ParseLimits returns the default limits by currency from the comma separated
+// lists of currency:amount per transaction, daily and monthly limits, e.g.
+// "USD:10000,EUR:9000".
+func ParseLimits(perTransaction, daily, monthly string) (map[string]domain.TransferLimit, error) {
+	limits := make(map[string]domain.TransferLimit)
+
+	for _, list := range []struct {
+		period string
+		value  string
+	}{
+		{domain.LimitPerTransaction, perTransaction},
+		{domain.LimitDaily, daily},
+		{domain.LimitMonthly, monthly},
+	} {
+		if list.value == "" {
+			continue
+		}
+
+		for _, item := range strings.Split(list.value, ",") {
+			currency, amount, ok := strings.Cut(strings.TrimSpace(item), ":")
+			if !ok || !currencypkg.IsSupportedCurrency(currency) || validLimit(amount) != nil {
+				return nil, fmt.Errorf("invalid %s transfer limit %q", list.period, item)
+			}
+
+			limit := limits[currency]
+			limit.Currency = currency
+
+			switch list.period {
+			case domain.LimitPerTransaction:
+				limit.PerTransaction = amount
+			case domain.LimitDaily:
+				limit.Daily = amount
+			case domain.LimitMonthly:
+				limit.Monthly = amount
+			}
+
+			limits[currency] = limit
+		}
+	}
+
+	return limits, nil
+}
+
+// validLimit checks that the limit is empty or a positive amount.
+func validLimit(amount string) error {
+	if amount == "" {
+		return nil
+	}
+
+	d, err := decimal.NewFromString(amount)
+	if err != nil || !d.IsPositive() {
+		return domain.ErrInvalidTransferLimit
+	}
+
+	return nil
+}
+
+// Get returns the limits of the user in the currency.
+func (s *Service) Get(ctx context.Context, username, currency string) (domain.TransferLimit, error) {
+	limit := s.defaults[currency]
+	limit.Username, limit.Currency = username, currency
+
+	override, err := s.repo.Get(ctx, username, currency)
+	if err != nil {
+		if err == domain.ErrTransferLimitNotFound {
+			return limit, nil
+		}
+
+		return domain.TransferLimit{}, err
+	}
+
+	return limit.Override(override), nil
+}
+
+// List returns the limits of the user in all the supported currencies.
+func (s *Service) List(ctx context.Context, username string) ([]domain.TransferLimit, error) {
+	overrides, err := s.repo.List(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := make([]domain.TransferLimit, 0, len(currencypkg.SupportedCurrencies))
+
+	for _, currency := range currencypkg.SupportedCurrencies {
+		limit := s.defaults[currency]
+		limit.Username, limit.Currency = username, currency
+
+		for _, o := range overrides {
+			if o.Currency == currency {
+				limit = limit.Override(o)
+			}
+		}
+
+		limits = append(limits, limit)
+	}
+
+	return limits, nil
+}
+
+// Set replaces the user's limit override in arg.Currency and returns the
+// resulting limits. Empty limits of arg fall back to the default limits.
+func (s *Service) Set(ctx context.Context, arg domain.TransferLimit) (domain.TransferLimit, error) {
+	for _, amount := range []string{arg.PerTransaction, arg.Daily, arg.Monthly} {
+		if err := validLimit(amount); err != nil {
+			zerolog.Ctx(ctx).Info().Err(err).Send()
+			return domain.TransferLimit{}, err
+		}
+	}
+
+	override, err := s.repo.Upsert(ctx, arg)
+	if err != nil {
+		return domain.TransferLimit{}, err
+	}
+
+	limit := s.defaults[arg.Currency]
+	limit.Username, limit.Currency = arg.Username, arg.Currency
+
+	return limit.Override(override), nil
+}
+
+// Reset deletes the user's limit override in the currency, so the default
+// limits apply again.
+func (s *Service) Reset(ctx context.Context, username, currency string) error {
+	return s.repo.Delete(ctx, username, currency)
+}
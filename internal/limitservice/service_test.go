@@ -0,0 +1,252 @@
+package limitservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/currencypkg"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/golang/mock/gomock"
+)
+
+func TestParseLimits(t *testing.T) {
+	testCases := []struct {
+		name           string
+		perTransaction string
+		daily          string
+		monthly        string
+		want           map[string]domain.TransferLimit
+		wantErr        bool
+	}{
+		{
+			name:           "OK",
+			perTransaction: "USD:1000,EUR:900",
+			daily:          "USD:5000",
+			monthly:        " USD:20000",
+			want: map[string]domain.TransferLimit{
+				currencypkg.USD: {Currency: currencypkg.USD, PerTransaction: "1000", Daily: "5000", Monthly: "20000"},
+				currencypkg.EUR: {Currency: currencypkg.EUR, PerTransaction: "900"},
+			},
+		},
+		{
+			name: "Empty",
+			want: map[string]domain.TransferLimit{},
+		},
+		{
+			name:    "UnsupportedCurrency",
+			daily:   "GBP:1000",
+			wantErr: true,
+		},
+		{
+			name:    "NegativeAmount",
+			monthly: "USD:-1000",
+			wantErr: true,
+		},
+		{
+			name:           "NoSeparator",
+			perTransaction: "USD1000",
+			wantErr:        true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseLimits(tc.perTransaction, tc.daily, tc.monthly)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseLimits(%q, %q, %q) returned error: %v, want error %v",
+					tc.perTransaction, tc.daily, tc.monthly, err, tc.wantErr)
+			}
+
+			if len(got) != len(tc.want) {
+				t.Fatalf("ParseLimits(%q, %q, %q) = %+v, want %+v", tc.perTransaction, tc.daily, tc.monthly, got, tc.want)
+			}
+
+			for currency, want := range tc.want {
+				if got[currency] != want {
+					t.Errorf("ParseLimits(...)[%v] = %+v, want %+v", currency, got[currency], want)
+				}
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	username := randompkg.Owner()
+	defaults := map[string]domain.TransferLimit{
+		currencypkg.USD: {Currency: currencypkg.USD, PerTransaction: "1000", Daily: "5000"},
+	}
+
+	testCases := []struct {
+		name     string
+		currency string
+		override domain.TransferLimit
+		repoErr  error
+		want     domain.TransferLimit
+		wantErr  error
+	}{
+		{
+			name:     "Defaults",
+			currency: currencypkg.USD,
+			repoErr:  domain.ErrTransferLimitNotFound,
+			want:     domain.TransferLimit{Username: username, Currency: currencypkg.USD, PerTransaction: "1000", Daily: "5000"},
+		},
+		{
+			name:     "Override",
+			currency: currencypkg.USD,
+			override: domain.TransferLimit{Username: username, Currency: currencypkg.USD, Daily: "100", Monthly: "1000"},
+			want: domain.TransferLimit{
+				Username:       username,
+				Currency:       currencypkg.USD,
+				PerTransaction: "1000",
+				Daily:          "100",
+				Monthly:        "1000",
+			},
+		},
+		{
+			name:     "Unlimited",
+			currency: currencypkg.EUR,
+			repoErr:  domain.ErrTransferLimitNotFound,
+			want:     domain.TransferLimit{Username: username, Currency: currencypkg.EUR},
+		},
+		{
+			name:     "RepoError",
+			currency: currencypkg.USD,
+			repoErr:  domain.ErrUserNotFound,
+			wantErr:  domain.ErrUserNotFound,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepo(ctrl)
+			repo.EXPECT().Get(gomock.Any(), gomock.Eq(username), gomock.Eq(tc.currency)).Times(1).Return(tc.override, tc.repoErr)
+
+			service := New(repo, defaults)
+
+			got, err := service.Get(context.Background(), username, tc.currency)
+			if err != tc.wantErr {
+				t.Fatalf("service.Get(ctx, %v, %v) returned error: %v, want %v", username, tc.currency, err, tc.wantErr)
+			}
+
+			if got != tc.want {
+				t.Errorf("service.Get(ctx, %v, %v) = %+v, want %+v", username, tc.currency, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	username := randompkg.Owner()
+	defaults := map[string]domain.TransferLimit{
+		currencypkg.USD: {Currency: currencypkg.USD, Daily: "5000"},
+	}
+
+	ctrl := gomock.NewController(t)
+	repo := NewMockRepo(ctrl)
+	repo.EXPECT().List(gomock.Any(), gomock.Eq(username)).
+		Times(1).
+		Return([]domain.TransferLimit{{Username: username, Currency: currencypkg.EUR, Monthly: "100"}}, nil)
+
+	service := New(repo, defaults)
+
+	got, err := service.List(context.Background(), username)
+	if err != nil {
+		t.Fatalf("service.List(ctx, %v) returned error: %v", username, err)
+	}
+
+	if len(got) != len(currencypkg.SupportedCurrencies) {
+		t.Fatalf("len(service.List(ctx, %v)) = %v, want %v", username, len(got), len(currencypkg.SupportedCurrencies))
+	}
+
+	for _, limit := range got {
+		var want domain.TransferLimit
+
+		switch limit.Currency {
+		case currencypkg.USD:
+			want = domain.TransferLimit{Username: username, Currency: currencypkg.USD, Daily: "5000"}
+		case currencypkg.EUR:
+			want = domain.TransferLimit{Username: username, Currency: currencypkg.EUR, Monthly: "100"}
+		default:
+			want = domain.TransferLimit{Username: username, Currency: limit.Currency}
+		}
+
+		if limit != want {
+			t.Errorf("service.List(ctx, %v) returned %+v, want %+v", username, limit, want)
+		}
+	}
+}
+
+func TestSet(t *testing.T) {
+	username := randompkg.Owner()
+	defaults := map[string]domain.TransferLimit{
+		currencypkg.USD: {Currency: currencypkg.USD, PerTransaction: "1000", Daily: "5000"},
+	}
+
+	testCases := []struct {
+		name       string
+		arg        domain.TransferLimit
+		buildStubs func(repo *MockRepo)
+		want       domain.TransferLimit
+		wantErr    error
+	}{
+		{
+			name: "OK",
+			arg:  domain.TransferLimit{Username: username, Currency: currencypkg.USD, Daily: "10000"},
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().Upsert(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.TransferLimit{Username: username, Currency: currencypkg.USD, Daily: "10000"}, nil)
+			},
+			want: domain.TransferLimit{Username: username, Currency: currencypkg.USD, PerTransaction: "1000", Daily: "10000"},
+		},
+		{
+			name: "ErrInvalidTransferLimit",
+			arg:  domain.TransferLimit{Username: username, Currency: currencypkg.USD, Monthly: "0"},
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().Upsert(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrInvalidTransferLimit,
+		},
+		{
+			name: "ErrUserNotFound",
+			arg:  domain.TransferLimit{Username: username, Currency: currencypkg.USD, Daily: "10000"},
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().Upsert(gomock.Any(), gomock.Any()).Times(1).Return(domain.TransferLimit{}, domain.ErrUserNotFound)
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepo(ctrl)
+			tc.buildStubs(repo)
+
+			service := New(repo, defaults)
+
+			got, err := service.Set(context.Background(), tc.arg)
+			if err != tc.wantErr {
+				t.Fatalf("service.Set(ctx, %+v) returned error: %v, want %v", tc.arg, err, tc.wantErr)
+			}
+
+			if got != tc.want {
+				t.Errorf("service.Set(ctx, %+v) = %+v, want %+v", tc.arg, got, tc.want)
+			}
+		})
+	}
+}
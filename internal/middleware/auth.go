@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -8,6 +9,8 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
 	"github.com/go-petr/pet-bank/pkg/tokenpkg"
 	"github.com/go-petr/pet-bank/pkg/web"
 )
@@ -25,11 +28,26 @@ var (
 	ErrBadAuthHeaderFormat = errors.New("invalid authorization header format")
 	// ErrUnsupportedAuthType indicates unsupported authorization type.
 	ErrUnsupportedAuthType = errors.New("unsupported authorization type")
+	// ErrInsufficientScope indicates that the token lacks the scope required by the route.
+	ErrInsufficientScope = errors.New("insufficient token scope")
 )
 
-// AddAuthorization sets authorization token to the given request.
+// AddAuthorization sets authorization token with the customer role scopes to
+// the given request.
 func AddAuthorization(r *http.Request, tm tokenpkg.Maker, authType string, username string, d time.Duration) error {
-	token, _, err := tm.CreateToken(username, d)
+	claims := tokenpkg.Claims{
+		Username: username,
+		Role:     domain.RoleCustomer,
+		Scopes:   domain.RoleScopes(domain.RoleCustomer),
+	}
+
+	return AddAuthorizationWithClaims(r, tm, authType, claims, d)
+}
+
+// AddAuthorizationWithClaims sets authorization token with the given claims to
+// the given request.
+func AddAuthorizationWithClaims(r *http.Request, tm tokenpkg.Maker, authType string, claims tokenpkg.Claims, d time.Duration) error {
+	token, _, err := tm.CreateTokenWithClaims(claims, d)
 	if err != nil {
 		return err
 	}
@@ -40,8 +58,16 @@ func AddAuthorization(r *http.Request, tm tokenpkg.Maker, authType string, usern
 	return nil
 }
 
-// AuthMiddleware verifies request authorization token.
-func AuthMiddleware(tokenMaker tokenpkg.Maker) gin.HandlerFunc {
+// RevocationChecker checks whether the verified access token has been revoked.
+type RevocationChecker interface {
+	// CheckRevoked returns tokenpkg.ErrRevokedToken if the token has been revoked
+	// and tokenpkg.ErrInvalidToken if it is not an access token.
+	CheckRevoked(ctx context.Context, payload *tokenpkg.Payload) error
+}
+
+// AuthMiddleware verifies request authorization token and rejects revoked
+// tokens. Revocation is not checked if rc is nil.
+func AuthMiddleware(tokenMaker tokenpkg.Maker, rc RevocationChecker) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authorizationHeader := ctx.GetHeader(AuthHeaderKey)
 		if len(authorizationHeader) == 0 {
@@ -69,7 +95,43 @@ func AuthMiddleware(tokenMaker tokenpkg.Maker) gin.HandlerFunc {
 			return
 		}
 
+		if rc != nil {
+			if err := rc.CheckRevoked(ctx.Request.Context(), payload); err != nil {
+				if err == tokenpkg.ErrRevokedToken || err == tokenpkg.ErrInvalidToken {
+					ctx.AbortWithStatusJSON(http.StatusUnauthorized, web.Error(err))
+					return
+				}
+
+				ctx.AbortWithStatusJSON(http.StatusInternalServerError, web.Error(errorspkg.ErrInternal))
+
+				return
+			}
+		}
+
 		ctx.Set(AuthPayloadKey, payload)
 		ctx.Next()
 	}
 }
+
+// RequireScope rejects requests which authorization token lacks any of the
+// scopes. It must be used after AuthMiddleware.
+func RequireScope(scopes ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		value, _ := ctx.Get(AuthPayloadKey)
+
+		payload, ok := value.(*tokenpkg.Payload)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, web.Error(ErrAuthHeaderNotFound))
+			return
+		}
+
+		for _, s := range scopes {
+			if !payload.HasScope(s) {
+				ctx.AbortWithStatusJSON(http.StatusForbidden, web.Error(ErrInsufficientScope))
+				return
+			}
+		}
+
+		ctx.Next()
+	}
+}
@@ -1,18 +1,28 @@
 package middleware
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
 	"github.com/go-petr/pet-bank/pkg/randompkg"
 	"github.com/go-petr/pet-bank/pkg/tokenpkg"
 	"github.com/go-petr/pet-bank/pkg/web"
 )
 
+type checkerFunc func(ctx context.Context, payload *tokenpkg.Payload) error
+
+func (f checkerFunc) CheckRevoked(ctx context.Context, payload *tokenpkg.Payload) error {
+	return f(ctx, payload)
+}
+
 func TestAuthMiddleware(t *testing.T) {
 	tokenSymmetricKey := randompkg.String(32)
 
@@ -24,6 +34,7 @@ func TestAuthMiddleware(t *testing.T) {
 	testCases := []struct {
 		name           string
 		setupAuth      func(t *testing.T, r *http.Request) error
+		checker        RevocationChecker
 		wantStatusCode int
 		wantError      string
 		checkResponse  func(t *testing.T, recorder *httptest.ResponseRecorder)
@@ -60,6 +71,49 @@ func TestAuthMiddleware(t *testing.T) {
 			wantStatusCode: http.StatusUnauthorized,
 			wantError:      tokenpkg.ErrExpiredToken.Error(),
 		},
+		{
+			name: "RevokedToken",
+			setupAuth: func(t *testing.T, r *http.Request) error {
+				return AddAuthorization(r, tokenMaker, AuthTypeBearer, "user", time.Minute)
+			},
+			checker: checkerFunc(func(ctx context.Context, payload *tokenpkg.Payload) error {
+				return tokenpkg.ErrRevokedToken
+			}),
+			wantStatusCode: http.StatusUnauthorized,
+			wantError:      tokenpkg.ErrRevokedToken.Error(),
+		},
+		{
+			name: "NotAccessToken",
+			setupAuth: func(t *testing.T, r *http.Request) error {
+				return AddAuthorization(r, tokenMaker, AuthTypeBearer, "user", time.Minute)
+			},
+			checker: checkerFunc(func(ctx context.Context, payload *tokenpkg.Payload) error {
+				return tokenpkg.ErrInvalidToken
+			}),
+			wantStatusCode: http.StatusUnauthorized,
+			wantError:      tokenpkg.ErrInvalidToken.Error(),
+		},
+		{
+			name: "CheckerInternalError",
+			setupAuth: func(t *testing.T, r *http.Request) error {
+				return AddAuthorization(r, tokenMaker, AuthTypeBearer, "user", time.Minute)
+			},
+			checker: checkerFunc(func(ctx context.Context, payload *tokenpkg.Payload) error {
+				return errors.New("db is down")
+			}),
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      errorspkg.ErrInternal.Error(),
+		},
+		{
+			name: "OKWithChecker",
+			setupAuth: func(t *testing.T, r *http.Request) error {
+				return AddAuthorization(r, tokenMaker, AuthTypeBearer, "user", time.Minute)
+			},
+			checker: checkerFunc(func(ctx context.Context, payload *tokenpkg.Payload) error {
+				return nil
+			}),
+			wantStatusCode: http.StatusOK,
+		},
 		{
 			name: "OK",
 			setupAuth: func(t *testing.T, r *http.Request) error {
@@ -82,7 +136,7 @@ func TestAuthMiddleware(t *testing.T) {
 			handler := func(ctx *gin.Context) {
 				ctx.JSON(http.StatusOK, gin.H{})
 			}
-			server.GET(authPath, AuthMiddleware(tokenMaker), handler)
+			server.GET(authPath, AuthMiddleware(tokenMaker, tc.checker), handler)
 
 			recorder := httptest.NewRecorder()
 			request, err := http.NewRequest(http.MethodGet, authPath, nil)
@@ -112,3 +166,91 @@ func TestAuthMiddleware(t *testing.T) {
 		})
 	}
 }
+
+func TestRequireScope(t *testing.T) {
+	tokenSymmetricKey := randompkg.String(32)
+
+	tokenMaker, err := tokenpkg.NewPasetoMaker(tokenSymmetricKey)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(%v) returned error: %v", tokenSymmetricKey, err)
+	}
+
+	testCases := []struct {
+		name           string
+		scopes         []string
+		required       []string
+		wantStatusCode int
+		wantError      string
+	}{
+		{
+			name:           "OK",
+			scopes:         []string{domain.ScopeAccountsRead, domain.ScopeAccountsWrite},
+			required:       []string{domain.ScopeAccountsRead},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "AllRequired",
+			scopes:         []string{domain.ScopeAccountsRead, domain.ScopeAccountsWrite},
+			required:       []string{domain.ScopeAccountsRead, domain.ScopeAccountsWrite},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "ReadOnlyToken",
+			scopes:         []string{domain.ScopeAccountsRead},
+			required:       []string{domain.ScopeAccountsWrite},
+			wantStatusCode: http.StatusForbidden,
+			wantError:      ErrInsufficientScope.Error(),
+		},
+		{
+			name:           "NoScopes",
+			required:       []string{domain.ScopeAdminRead},
+			wantStatusCode: http.StatusForbidden,
+			wantError:      ErrInsufficientScope.Error(),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gin.SetMode(gin.ReleaseMode)
+			server := gin.New()
+
+			authPath := "/auth"
+			handler := func(ctx *gin.Context) {
+				ctx.JSON(http.StatusOK, gin.H{})
+			}
+			server.GET(authPath, AuthMiddleware(tokenMaker, nil), RequireScope(tc.required...), handler)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, authPath, nil)
+			if err != nil {
+				t.Fatalf("http.NewRequest(%v, %v, nil) returned error: %v", http.MethodGet, authPath, err)
+			}
+
+			claims := tokenpkg.Claims{Username: "user", Scopes: tc.scopes}
+			if err := AddAuthorizationWithClaims(request, tokenMaker, AuthTypeBearer, claims, time.Minute); err != nil {
+				t.Fatalf("AddAuthorizationWithClaims(%v, tokenMaker, %v, %+v, %v) returned error: %v",
+					request, AuthTypeBearer, claims, time.Minute, err)
+			}
+
+			server.ServeHTTP(recorder, request)
+
+			if recorder.Code != tc.wantStatusCode {
+				t.Errorf("recorder.Code = %v, tc.wantStatusCode = %v, want equal",
+					recorder.Code, tc.wantStatusCode)
+			}
+
+			got := web.Response{}
+			if err := json.NewDecoder(recorder.Body).Decode(&got); err != nil {
+				t.Fatalf("Decoding response body error: %v", err)
+			}
+
+			if got.Error != tc.wantError {
+				t.Errorf("got.Error = %v, tc.wantError = %v, want equal", got.Error, tc.wantError)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-petr/pet-bank/pkg/configpkg"
+	"github.com/go-petr/pet-bank/pkg/requestpkg"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/pkgerrors"
@@ -40,7 +41,8 @@ func CreateLogger(config configpkg.Config) zerolog.Logger {
 	return log
 }
 
-// RequestLogger logs a gin HTTP request in JSON format.
+// RequestLogger logs a gin HTTP request in JSON format. The request id and
+// client IP are stored in the request context with requestpkg.
 func RequestLogger(logger zerolog.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
@@ -52,9 +54,14 @@ func RequestLogger(logger zerolog.Logger) gin.HandlerFunc {
 			c.Writer.Header().Set("X-Request-ID", requestID)
 		}
 
-		logger = logger.With().Str("request_id", requestID).Logger()
+		logger := logger.With().Str("request_id", requestID).Logger()
 
-		c.Request = c.Request.WithContext(logger.WithContext(c.Request.Context()))
+		ctx := requestpkg.NewContext(c.Request.Context(), requestpkg.Info{
+			ID:       requestID,
+			ClientIP: c.ClientIP(),
+		})
+
+		c.Request = c.Request.WithContext(logger.WithContext(ctx))
 
 		// Process request
 		c.Next()
@@ -0,0 +1,114 @@
+// Package monitoringrepo manages repository layer of the transaction
+// monitoring rules.
+package monitoringrepo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/go-petr/pet-bank/pkg/dbpkg"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// RepoPGS facilitates transaction monitoring repository layer logic.
+type RepoPGS struct {
+	db dbpkg.SQLInterface
+}
+
+// NewRepoPGS returns transaction monitoring RepoPGS.
+func NewRepoPGS(db dbpkg.SQLInterface) *RepoPGS {
+	return &RepoPGS{
+		db: db,
+	}
+}
+
+const newPayeesQuery = `
+WITH paid AS (
+	SELECT t.to_account_id, MIN(t.created_at) AS first_paid_at
+	FROM (
+		SELECT transfers.to_account_id, transfers.created_at
+		FROM transfers
+		JOIN accounts ON accounts.id = transfers.from_account_id
+		WHERE accounts.owner = $1 AND transfers.kind = 'transfer'
+		UNION ALL
+		SELECT to_account_id, created_at
+		FROM transfer_reviews
+		WHERE username = $1 AND status = 'pending_review'
+	) t
+	GROUP BY t.to_account_id
+)
+SELECT
+	NOT EXISTS (SELECT 1 FROM paid WHERE to_account_id = $2),
+	COUNT(*) FILTER (WHERE first_paid_at >= $3)
+FROM paid
+`
+
+// NewPayees reports whether the user has never paid the to account and
+// returns the number of accounts the user paid for the first time since the
+// given time. Transfers pending review are counted as paid.
+func (r *RepoPGS) NewPayees(ctx context.Context, username string, toAccountID int32, since time.Time) (bool, int64, error) {
+	var (
+		isNew bool
+		count int64
+	)
+
+	err := r.db.QueryRowContext(ctx, newPayeesQuery, username, toAccountID, since).Scan(&isNew, &count)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Send()
+		return false, 0, errorspkg.ErrInternal
+	}
+
+	return isNew, count, nil
+}
+
+const firstTransferFromNewIPQuery = `
+WITH current AS (
+	SELECT s.client_ip, MIN(f.created_at) AS started_at
+	FROM sessions s
+	JOIN sessions f ON f.family_id = s.family_id
+	WHERE s.id = $2 AND s.username = $1
+	GROUP BY s.client_ip
+)
+SELECT
+	EXISTS (
+		SELECT 1 FROM sessions
+		WHERE username = $1 AND created_at < current.started_at
+	)
+	AND NOT EXISTS (
+		SELECT 1 FROM sessions
+		WHERE username = $1 AND created_at < current.started_at AND client_ip = current.client_ip
+	)
+	AND NOT EXISTS (
+		SELECT 1 FROM transfers
+		JOIN accounts ON accounts.id = transfers.from_account_id
+		WHERE accounts.owner = $1 AND transfers.kind = 'transfer' AND transfers.created_at >= current.started_at
+	)
+	AND NOT EXISTS (
+		SELECT 1 FROM transfer_reviews
+		WHERE username = $1 AND created_at >= current.started_at
+	)
+FROM current
+`
+
+// IsFirstTransferFromNewIP reports whether the user logged in before from
+// other IPs only and hasn't made transfers since the login of the session.
+// The session's refresh token rotations belong to the same login. It reports
+// false if the user has no such session.
+func (r *RepoPGS) IsFirstTransferFromNewIP(ctx context.Context, username string, sessionID uuid.UUID) (bool, error) {
+	var first bool
+
+	err := r.db.QueryRowContext(ctx, firstTransferFromNewIPQuery, username, sessionID).Scan(&first)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Send()
+		return false, errorspkg.ErrInternal
+	}
+
+	return first, nil
+}
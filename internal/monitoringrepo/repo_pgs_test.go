@@ -0,0 +1,159 @@
+//go:build integration
+
+package monitoringrepo_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/integrationtest"
+	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
+	"github.com/go-petr/pet-bank/internal/monitoringrepo"
+	"github.com/go-petr/pet-bank/internal/transferrepo"
+	"github.com/go-petr/pet-bank/pkg/configpkg"
+	"github.com/go-petr/pet-bank/pkg/dbpkg"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/google/uuid"
+)
+
+var (
+	dbDriver string
+	dbSource string
+)
+
+func TestMain(m *testing.M) {
+	config, err := configpkg.Load("../../configs")
+	if err != nil {
+		log.Fatal("cannot load config:", err)
+	}
+
+	dbDriver = config.DBDriver
+	dbSource = config.DBSource
+
+	os.Exit(m.Run())
+}
+
+func TestNewPayees(t *testing.T) {
+	t.Parallel()
+
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	monitoringRepo := monitoringrepo.NewRepoPGS(tx)
+	ctx := context.Background()
+
+	user := helpers.SeedUser(t, tx)
+	from := helpers.SeedAccountWith1000USDBalance(t, tx, user.Username)
+	to1 := helpers.SeedAccountWith1000USDBalance(t, tx, helpers.SeedUser(t, tx).Username)
+	to2 := helpers.SeedAccountWith1000USDBalance(t, tx, helpers.SeedUser(t, tx).Username)
+	since := time.Now().Add(-time.Hour)
+
+	isNew, count, err := monitoringRepo.NewPayees(ctx, user.Username, to1.ID, since)
+	if err != nil {
+		t.Fatalf("monitoringRepo.NewPayees(ctx, %v, %v, %v) returned error: %v", user.Username, to1.ID, since, err)
+	}
+
+	if !isNew || count != 0 {
+		t.Errorf("monitoringRepo.NewPayees(ctx, %v, %v, %v) = %v, %v, want true, 0", user.Username, to1.ID, since, isNew, count)
+	}
+
+	transferRepo := transferrepo.NewTxRepoPGS(tx)
+
+	for _, to := range []int32{to1.ID, to1.ID, to2.ID} {
+		arg := domain.CreateTransferParams{FromAccountID: from.ID, ToAccountID: to, Amount: "10", Kind: domain.TransferKindTransfer}
+		if _, err := transferRepo.Create(ctx, arg); err != nil {
+			t.Fatalf("transferRepo.Create(ctx, %+v) returned error: %v", arg, err)
+		}
+	}
+
+	isNew, count, err = monitoringRepo.NewPayees(ctx, user.Username, to1.ID, since)
+	if err != nil {
+		t.Fatalf("monitoringRepo.NewPayees(ctx, %v, %v, %v) returned error: %v", user.Username, to1.ID, since, err)
+	}
+
+	if isNew || count != 2 {
+		t.Errorf("monitoringRepo.NewPayees(ctx, %v, %v, %v) = %v, %v, want false, 2", user.Username, to1.ID, since, isNew, count)
+	}
+}
+
+func TestIsFirstTransferFromNewIP(t *testing.T) {
+	t.Parallel()
+
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	monitoringRepo := monitoringrepo.NewRepoPGS(tx)
+	ctx := context.Background()
+
+	user := helpers.SeedUser(t, tx)
+
+	seedSession := func(t *testing.T, clientIP string, age time.Duration) domain.Session {
+		t.Helper()
+
+		session := helpers.SeedSession(t, tx, domain.CreateSessionParams{
+			ID:           uuid.New(),
+			Username:     user.Username,
+			RefreshToken: randompkg.String(10),
+			UserAgent:    randompkg.String(10),
+			ClientIP:     clientIP,
+			ExpiresAt:    time.Now().Add(time.Hour),
+		})
+
+		setCreatedAt(t, tx, session.ID, time.Now().Add(-age))
+
+		return session
+	}
+
+	first := seedSession(t, "10.0.0.1", 48*time.Hour)
+
+	// The user never logged in before.
+	got, err := monitoringRepo.IsFirstTransferFromNewIP(ctx, user.Username, first.ID)
+	if err != nil {
+		t.Fatalf("monitoringRepo.IsFirstTransferFromNewIP(ctx, %v, %v) returned error: %v", user.Username, first.ID, err)
+	}
+
+	if got {
+		t.Errorf("monitoringRepo.IsFirstTransferFromNewIP(ctx, %v, %v) = true, want false", user.Username, first.ID)
+	}
+
+	testCases := []struct {
+		name     string
+		clientIP string
+		want     bool
+	}{
+		{name: "KnownIP", clientIP: "10.0.0.1", want: false},
+		{name: "NewIP", clientIP: "10.0.0.2", want: true},
+	}
+
+	for _, tc := range testCases {
+		session := seedSession(t, tc.clientIP, time.Hour)
+
+		got, err := monitoringRepo.IsFirstTransferFromNewIP(ctx, user.Username, session.ID)
+		if err != nil {
+			t.Fatalf("%v: monitoringRepo.IsFirstTransferFromNewIP(ctx, %v, %v) returned error: %v", tc.name, user.Username, session.ID, err)
+		}
+
+		if got != tc.want {
+			t.Errorf("%v: monitoringRepo.IsFirstTransferFromNewIP(ctx, %v, %v) = %v, want %v", tc.name, user.Username, session.ID, got, tc.want)
+		}
+	}
+
+	got, err = monitoringRepo.IsFirstTransferFromNewIP(ctx, user.Username, uuid.New())
+	if err != nil {
+		t.Fatalf("monitoringRepo.IsFirstTransferFromNewIP(ctx, %v, unknown) returned error: %v", user.Username, err)
+	}
+
+	if got {
+		t.Errorf("monitoringRepo.IsFirstTransferFromNewIP(ctx, %v, unknown) = true, want false", user.Username)
+	}
+}
+
+func setCreatedAt(t *testing.T, tx dbpkg.SQLInterface, id uuid.UUID, createdAt time.Time) {
+	t.Helper()
+
+	const query = `UPDATE sessions SET created_at = $1 WHERE id = $2`
+
+	if _, err := tx.ExecContext(context.Background(), query, createdAt, id); err != nil {
+		t.Fatalf("setting created_at of session %v returned error: %v", id, err)
+	}
+}
@@ -0,0 +1,138 @@
+package monitoringservice
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// payeesRule matches the transfers to a new payee if the sender has already
+// paid max new payees within the window.
+type payeesRule struct {
+	repo   Repo
+	window time.Duration
+	max    int64
+}
+
+func newPayeesRule(params ruleParams, r Repo) (Rule, error) {
+	p := struct {
+		Window time.Duration `yaml:"window"`
+		Max    int64         `yaml:"max"`
+	}{
+		Window: time.Hour,
+	}
+
+	if err := params(&p); err != nil {
+		return nil, err
+	}
+
+	if p.Window <= 0 || p.Max < 0 {
+		return nil, errors.New("window must be positive and max must not be negative")
+	}
+
+	return payeesRule{repo: r, window: p.Window, max: p.Max}, nil
+}
+
+func (r payeesRule) Match(ctx context.Context, check domain.RiskCheck) (bool, error) {
+	isNew, count, err := r.repo.NewPayees(ctx, check.Username, check.ToAccountID, time.Now().Add(-r.window))
+	if err != nil {
+		return false, err
+	}
+
+	return isNew && count >= r.max, nil
+}
+
+// roundAmountRule matches the round amounts just under the threshold, the
+// sender's per transaction limit by default, which may be structuring.
+type roundAmountRule struct {
+	multiple  decimal.Decimal
+	margin    decimal.Decimal
+	threshold decimal.Decimal
+}
+
+func newRoundAmountRule(params ruleParams, _ Repo) (Rule, error) {
+	p := struct {
+		Multiple  string `yaml:"multiple"`
+		Margin    string `yaml:"margin"`
+		Threshold string `yaml:"threshold"`
+	}{
+		Multiple: "100",
+		Margin:   "0.1",
+	}
+
+	if err := params(&p); err != nil {
+		return nil, err
+	}
+
+	var (
+		r   roundAmountRule
+		err error
+	)
+
+	if r.multiple, err = decimal.NewFromString(p.Multiple); err != nil || !r.multiple.IsPositive() {
+		return nil, errors.New("multiple must be a positive amount")
+	}
+
+	if r.margin, err = decimal.NewFromString(p.Margin); err != nil ||
+		!r.margin.IsPositive() || r.margin.GreaterThanOrEqual(decimal.NewFromInt(1)) {
+		return nil, errors.New("margin must be between 0 and 1")
+	}
+
+	if p.Threshold != "" {
+		if r.threshold, err = decimal.NewFromString(p.Threshold); err != nil || !r.threshold.IsPositive() {
+			return nil, errors.New("threshold must be a positive amount")
+		}
+	}
+
+	return r, nil
+}
+
+func (r roundAmountRule) Match(_ context.Context, check domain.RiskCheck) (bool, error) {
+	threshold := r.threshold
+
+	if threshold.IsZero() {
+		if check.Limit == nil || check.Limit.PerTransaction == "" {
+			return false, nil
+		}
+
+		var err error
+		if threshold, err = decimal.NewFromString(check.Limit.PerTransaction); err != nil {
+			return false, err
+		}
+	}
+
+	amount, err := decimal.NewFromString(check.Amount)
+	if err != nil {
+		return false, err
+	}
+
+	if !amount.Mod(r.multiple).IsZero() {
+		return false, nil
+	}
+
+	floor := threshold.Mul(decimal.NewFromInt(1).Sub(r.margin))
+
+	return amount.GreaterThanOrEqual(floor) && amount.LessThan(threshold), nil
+}
+
+// sessionIPRule matches the first transfer of the session from an IP the
+// sender has never logged in from.
+type sessionIPRule struct {
+	repo Repo
+}
+
+func newSessionIPRule(_ ruleParams, r Repo) (Rule, error) {
+	return sessionIPRule{repo: r}, nil
+}
+
+func (r sessionIPRule) Match(ctx context.Context, check domain.RiskCheck) (bool, error) {
+	if check.SessionID == uuid.Nil {
+		return false, nil
+	}
+
+	return r.repo.IsFirstTransferFromNewIP(ctx, check.Username, check.SessionID)
+}
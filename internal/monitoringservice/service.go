@@ -0,0 +1,167 @@
+// Package monitoringservice manages business logic layer of the transaction
+// monitoring rules.
+package monitoringservice
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"gopkg.in/yaml.v3"
+)
+
+// Repo provides data access layer interface needed by the monitoring rules.
+//
+//go:generate mockgen -source service.go -destination service_mock.go -package monitoringservice
+type Repo interface {
+	NewPayees(ctx context.Context, username string, toAccountID int32, since time.Time) (bool, int64, error)
+	IsFirstTransferFromNewIP(ctx context.Context, username string, sessionID uuid.UUID) (bool, error)
+}
+
+// Rule matches the transfers of a suspicious pattern.
+type Rule interface {
+	Match(ctx context.Context, check domain.RiskCheck) (bool, error)
+}
+
+// ruleParams decodes the params of the rule from the rules file into v.
+type ruleParams func(v any) error
+
+// ruleFactory returns the rule of a type with the given params.
+type ruleFactory func(params ruleParams, r Repo) (Rule, error)
+
+// factories are the rule types available to the rules files.
+var factories = map[string]ruleFactory{
+	"new_payees":               newPayeesRule,
+	"round_amount_under_limit": newRoundAmountRule,
+	"new_session_ip":           newSessionIPRule,
+}
+
+// severity orders the decisions from the least to the most severe.
+var severity = map[string]int{
+	domain.RiskDecisionAllow:  0,
+	domain.RiskDecisionReview: 1,
+	domain.RiskDecisionBlock:  2,
+}
+
+type rulesFile struct {
+	Rules []struct {
+		Name   string    `yaml:"name"`
+		Type   string    `yaml:"type"`
+		Action string    `yaml:"action"`
+		Params yaml.Node `yaml:"params"`
+	} `yaml:"rules"`
+}
+
+type namedRule struct {
+	name   string
+	action string
+	rule   Rule
+}
+
+// Service facilitates transaction monitoring service layer logic.
+type Service struct {
+	rules []namedRule
+}
+
+// Load returns transaction monitoring service struct with the rules read from
+// the YAML or JSON file, e.g.
+//
+//	rules:
+//	  - name: many_new_payees
+//	    type: new_payees
+//	    action: review
+//	    params:
+//	      window: 1h
+//	      max: 3
+func Load(path string, r Repo) (*Service, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := Parse(b, r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse rules file %q: %w", path, err)
+	}
+
+	return s, nil
+}
+
+// Parse returns transaction monitoring service struct with the rules of the
+// YAML or JSON document. See Load for its format.
+func Parse(b []byte, r Repo) (*Service, error) {
+	var file rulesFile
+	if err := yaml.Unmarshal(b, &file); err != nil {
+		return nil, err
+	}
+
+	s := &Service{
+		rules: make([]namedRule, 0, len(file.Rules)),
+	}
+
+	names := make(map[string]bool, len(file.Rules))
+
+	for _, cfg := range file.Rules {
+		if cfg.Name == "" || names[cfg.Name] {
+			return nil, fmt.Errorf("rule name %q is empty or not unique", cfg.Name)
+		}
+
+		names[cfg.Name] = true
+
+		if cfg.Action != domain.RiskDecisionReview && cfg.Action != domain.RiskDecisionBlock {
+			return nil, fmt.Errorf("rule %q: invalid action %q", cfg.Name, cfg.Action)
+		}
+
+		factory, ok := factories[cfg.Type]
+		if !ok {
+			return nil, fmt.Errorf("rule %q: unknown type %q", cfg.Name, cfg.Type)
+		}
+
+		params := cfg.Params
+
+		rule, err := factory(func(v any) error {
+			if params.Kind == 0 {
+				return nil
+			}
+
+			return params.Decode(v)
+		}, r)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", cfg.Name, err)
+		}
+
+		s.rules = append(s.rules, namedRule{name: cfg.Name, action: cfg.Action, rule: rule})
+	}
+
+	return s, nil
+}
+
+// Assess matches the transfer against all the rules. The decision is the most
+// severe action of the matched rules, allow if none matched.
+func (s *Service) Assess(ctx context.Context, check domain.RiskCheck) (domain.RiskAssessment, error) {
+	assessment := domain.RiskAssessment{Decision: domain.RiskDecisionAllow}
+
+	for _, r := range s.rules {
+		matched, err := r.rule.Match(ctx, check)
+		if err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Str("rule", r.name).Send()
+			return domain.RiskAssessment{}, err
+		}
+
+		if !matched {
+			continue
+		}
+
+		assessment.Rules = append(assessment.Rules, r.name)
+
+		if severity[r.action] > severity[assessment.Decision] {
+			assessment.Decision = r.action
+		}
+	}
+
+	return assessment, nil
+}
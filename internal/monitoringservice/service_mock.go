@@ -0,0 +1,107 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package monitoringservice is a generated GoMock package.
+package monitoringservice
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/go-petr/pet-bank/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockRepo is a mock of Repo interface.
+type MockRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoMockRecorder
+}
+
+// MockRepoMockRecorder is the mock recorder for MockRepo.
+type MockRepoMockRecorder struct {
+	mock *MockRepo
+}
+
+// NewMockRepo creates a new mock instance.
+func NewMockRepo(ctrl *gomock.Controller) *MockRepo {
+	mock := &MockRepo{ctrl: ctrl}
+	mock.recorder = &MockRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
+	return m.recorder
+}
+
+// IsFirstTransferFromNewIP mocks base method.
+func (m *MockRepo) IsFirstTransferFromNewIP(ctx context.Context, username string, sessionID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsFirstTransferFromNewIP", ctx, username, sessionID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsFirstTransferFromNewIP indicates an expected call of IsFirstTransferFromNewIP.
+func (mr *MockRepoMockRecorder) IsFirstTransferFromNewIP(ctx, username, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFirstTransferFromNewIP", reflect.TypeOf((*MockRepo)(nil).IsFirstTransferFromNewIP), ctx, username, sessionID)
+}
+
+// NewPayees mocks base method.
+func (m *MockRepo) NewPayees(ctx context.Context, username string, toAccountID int32, since time.Time) (bool, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewPayees", ctx, username, toAccountID, since)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// NewPayees indicates an expected call of NewPayees.
+func (mr *MockRepoMockRecorder) NewPayees(ctx, username, toAccountID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewPayees", reflect.TypeOf((*MockRepo)(nil).NewPayees), ctx, username, toAccountID, since)
+}
+
+// MockRule is a mock of Rule interface.
+type MockRule struct {
+	ctrl     *gomock.Controller
+	recorder *MockRuleMockRecorder
+}
+
+// MockRuleMockRecorder is the mock recorder for MockRule.
+type MockRuleMockRecorder struct {
+	mock *MockRule
+}
+
+// NewMockRule creates a new mock instance.
+func NewMockRule(ctrl *gomock.Controller) *MockRule {
+	mock := &MockRule{ctrl: ctrl}
+	mock.recorder = &MockRuleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRule) EXPECT() *MockRuleMockRecorder {
+	return m.recorder
+}
+
+// Match mocks base method.
+func (m *MockRule) Match(ctx context.Context, check domain.RiskCheck) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Match", ctx, check)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Match indicates an expected call of Match.
+func (mr *MockRuleMockRecorder) Match(ctx, check interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Match", reflect.TypeOf((*MockRule)(nil).Match), ctx, check)
+}
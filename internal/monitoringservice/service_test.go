@@ -0,0 +1,224 @@
+package monitoringservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+const testRules = `
+rules:
+  - name: many_new_payees
+    type: new_payees
+    action: review
+    params:
+      window: 30m
+      max: 2
+  - name: structuring
+    type: round_amount_under_limit
+    action: review
+  - name: new_ip
+    type: new_session_ip
+    action: block
+`
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rules   string
+		wantErr bool
+	}{
+		{name: "YAML", rules: testRules},
+		{name: "JSON", rules: `{"rules": [{"name": "new_ip", "type": "new_session_ip", "action": "review"}]}`},
+		{name: "Empty", rules: ``},
+		{name: "UnknownType", rules: `{"rules": [{"name": "a", "type": "velocity", "action": "review"}]}`, wantErr: true},
+		{name: "InvalidAction", rules: `{"rules": [{"name": "a", "type": "new_session_ip", "action": "allow"}]}`, wantErr: true},
+		{
+			name:    "DuplicateName",
+			rules:   `{"rules": [{"name": "a", "type": "new_session_ip", "action": "review"}, {"name": "a", "type": "new_payees", "action": "block"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "InvalidParams",
+			rules:   `{"rules": [{"name": "a", "type": "new_payees", "action": "review", "params": {"window": "soon"}}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "InvalidMargin",
+			rules:   `{"rules": [{"name": "a", "type": "round_amount_under_limit", "action": "review", "params": {"margin": "1"}}]}`,
+			wantErr: true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse([]byte(tc.rules), nil)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Parse(%q, nil) returned error: %v, want error %v", tc.rules, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := "../../configs/monitoring_rules.yaml"
+
+	service, err := Load(path, nil)
+	if err != nil {
+		t.Fatalf("Load(%q, nil) returned error: %v", path, err)
+	}
+
+	if len(service.rules) != 3 {
+		t.Errorf("Load(%q, nil) loaded %v rules, want 3", path, len(service.rules))
+	}
+
+	if _, err := Load("missing.yaml", nil); err == nil {
+		t.Errorf("Load(missing.yaml, nil) returned no error")
+	}
+}
+
+func TestAssess(t *testing.T) {
+	check := domain.RiskCheck{
+		Username:      randompkg.Owner(),
+		SessionID:     uuid.New(),
+		FromAccountID: 1,
+		ToAccountID:   2,
+		Amount:        "950",
+		Limit:         &domain.TransferLimit{PerTransaction: "1000"},
+	}
+
+	testCases := []struct {
+		name      string
+		amount    string
+		isNew     bool
+		newPayees int64
+		newIP     bool
+		repoErr   error
+		want      domain.RiskAssessment
+		wantErr   error
+	}{
+		{
+			name:   "Allow",
+			amount: "955",
+			isNew:  true,
+			want:   domain.RiskAssessment{Decision: domain.RiskDecisionAllow},
+		},
+		{
+			name:      "Review",
+			amount:    "900",
+			isNew:     true,
+			newPayees: 2,
+			want:      domain.RiskAssessment{Decision: domain.RiskDecisionReview, Rules: []string{"many_new_payees", "structuring"}},
+		},
+		{
+			name:   "Block",
+			amount: "900",
+			newIP:  true,
+			want:   domain.RiskAssessment{Decision: domain.RiskDecisionBlock, Rules: []string{"structuring", "new_ip"}},
+		},
+		{
+			name:    "RepoError",
+			amount:  "900",
+			repoErr: errorspkg.ErrInternal,
+			wantErr: errorspkg.ErrInternal,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			check := check
+			check.Amount = tc.amount
+
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepo(ctrl)
+			repo.EXPECT().NewPayees(gomock.Any(), gomock.Eq(check.Username), gomock.Eq(check.ToAccountID), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, _ int32, since time.Time) (bool, int64, error) {
+					if d := time.Since(since); d < 30*time.Minute || d > 31*time.Minute {
+						t.Errorf("repo.NewPayees(...) since = %v, want 30m ago", since)
+					}
+
+					return tc.isNew, tc.newPayees, tc.repoErr
+				})
+			repo.EXPECT().IsFirstTransferFromNewIP(gomock.Any(), gomock.Eq(check.Username), gomock.Eq(check.SessionID)).
+				AnyTimes().
+				Return(tc.newIP, nil)
+
+			service, err := Parse([]byte(testRules), repo)
+			if err != nil {
+				t.Fatalf("Parse(testRules, repo) returned error: %v", err)
+			}
+
+			got, err := service.Assess(context.Background(), check)
+			if err != tc.wantErr {
+				t.Fatalf("service.Assess(ctx, %+v) returned error: %v, want %v", check, err, tc.wantErr)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("service.Assess(ctx, %+v) returned unexpected difference (-want +got):\n%s", check, diff)
+			}
+		})
+	}
+}
+
+func TestRoundAmountRule(t *testing.T) {
+	limit := &domain.TransferLimit{PerTransaction: "10000"}
+
+	testCases := []struct {
+		name   string
+		params string
+		amount string
+		limit  *domain.TransferLimit
+		want   bool
+	}{
+		{name: "JustUnderLimit", amount: "9900", limit: limit, want: true},
+		{name: "AtLimit", amount: "10000", limit: limit, want: false},
+		{name: "NotRound", amount: "9950.5", limit: limit, want: false},
+		{name: "FarUnderLimit", amount: "8900", limit: limit, want: false},
+		{name: "NoLimit", amount: "9900", want: false},
+		{name: "Threshold", params: `{"threshold": "3000", "multiple": "500", "margin": "0.2"}`, amount: "2500", want: true},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rules := `{"rules": [{"name": "a", "type": "round_amount_under_limit", "action": "review"}]}`
+			if tc.params != "" {
+				rules = `{"rules": [{"name": "a", "type": "round_amount_under_limit", "action": "review", "params": ` + tc.params + `}]}`
+			}
+
+			service, err := Parse([]byte(rules), nil)
+			if err != nil {
+				t.Fatalf("Parse(%q, nil) returned error: %v", rules, err)
+			}
+
+			check := domain.RiskCheck{Amount: tc.amount, Limit: tc.limit}
+
+			got, err := service.Assess(context.Background(), check)
+			if err != nil {
+				t.Fatalf("service.Assess(ctx, %+v) returned error: %v", check, err)
+			}
+
+			if matched := got.Decision == domain.RiskDecisionReview; matched != tc.want {
+				t.Errorf("service.Assess(ctx, %+v) = %+v, want matched %v", check, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+// Package payeedelivery manages delivery layer of saved payees.
+package payeedelivery
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/middleware"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/tokenpkg"
+	"github.com/go-petr/pet-bank/pkg/web"
+)
+
+// Service provides service layer interface needed by payee delivery layer.
+//
+//go:generate mockgen -source http.go -destination http_mock.go -package payeedelivery
+type Service interface {
+	Create(ctx context.Context, arg domain.CreatePayeeParams) (domain.Payee, error)
+	Get(ctx context.Context, username string, id int64) (domain.Payee, error)
+	List(ctx context.Context, username string) ([]domain.Payee, error)
+	UpdateNickname(ctx context.Context, username string, id int64, nickname string) (domain.Payee, error)
+	Delete(ctx context.Context, username string, id int64) error
+}
+
+// Handler facilitates payee delivery layer logic.
+type Handler struct {
+	service Service
+}
+
+// NewHandler returns payee handler.
+func NewHandler(ps Service) *Handler {
+	return &Handler{
+		service: ps,
+	}
+}
+
+func (h *Handler) serviceError(gctx *gin.Context, err error) {
+	zerolog.Ctx(gctx.Request.Context()).Info().Err(err).Send()
+
+	switch err {
+	case
+		domain.ErrPayeeNotFound,
+		domain.ErrAccountNotFound,
+		domain.ErrRecipientNotFound,
+		domain.ErrRecipientAccountNotFound:
+		gctx.JSON(http.StatusNotFound, web.Error(err))
+		return
+	case
+		domain.ErrPayeeAlreadyExists,
+		domain.ErrAmbiguousRecipient:
+		gctx.JSON(http.StatusConflict, web.Error(err))
+		return
+	case
+		domain.ErrSystemAccount,
+		domain.ErrInvalidAlias:
+		gctx.JSON(http.StatusBadRequest, web.Error(err))
+		return
+	}
+
+	gctx.JSON(http.StatusInternalServerError, web.Error(errorspkg.ErrInternal))
+}
+
+func (h *Handler) bindError(gctx *gin.Context, err error) {
+	zerolog.Ctx(gctx.Request.Context()).Info().Err(err).Send()
+
+	var ve validator.ValidationErrors
+	if errors.As(err, &ve) {
+		gctx.JSON(http.StatusBadRequest, web.Response{Error: web.GetErrorMsg(ve)})
+
+		return
+	}
+
+	gctx.JSON(http.StatusBadRequest, web.Error(err))
+}
+
+type payeeResponse struct {
+	Payee domain.Payee `json:"payee"`
+}
+
+// createRequest identifies the payee account by exactly one of AccountID,
+// Username or Alias. Currency picks the account of the payee given by
+// username or alias.
+type createRequest struct {
+	Nickname  string `json:"nickname" binding:"required,max=64"`
+	AccountID int32  `json:"account_id" binding:"required_without_all=Username Alias,omitempty,min=1"`
+	Username  string `json:"username" binding:"omitempty,alphanum,excluded_with=AccountID Alias"`
+	Alias     string `json:"alias" binding:"omitempty,max=255,excluded_with=AccountID"`
+	Currency  string `json:"currency" binding:"required_without=AccountID,omitempty,currency,excluded_with=AccountID"`
+}
+
+// Create handles http request to save a payee of the user.
+func (h *Handler) Create(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	var req createRequest
+	if err := gctx.ShouldBindJSON(&req); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	arg := domain.CreatePayeeParams{
+		Username:  authPayload.Username,
+		Nickname:  req.Nickname,
+		AccountID: req.AccountID,
+	}
+
+	if req.AccountID == 0 {
+		arg.Recipient = &domain.Recipient{
+			Username: req.Username,
+			Alias:    req.Alias,
+			Currency: req.Currency,
+		}
+	}
+
+	payee, err := h.service.Create(ctx, arg)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	gctx.JSON(http.StatusCreated, web.Response{Data: payeeResponse{Payee: payee}})
+}
+
+type listResponse struct {
+	Payees []domain.Payee `json:"payees"`
+}
+
+// List handles http request to list the payees of the user.
+func (h *Handler) List(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	payees, err := h.service.List(ctx, authPayload.Username)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	gctx.JSON(http.StatusOK, web.Response{Data: listResponse{Payees: payees}})
+}
+
+type idRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// Get handles http request to get the payee of the user.
+func (h *Handler) Get(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	var req idRequest
+	if err := gctx.ShouldBindUri(&req); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	payee, err := h.service.Get(ctx, authPayload.Username, req.ID)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	gctx.JSON(http.StatusOK, web.Response{Data: payeeResponse{Payee: payee}})
+}
+
+type updateRequest struct {
+	Nickname string `json:"nickname" binding:"required,max=64"`
+}
+
+// Update handles http request to rename the payee of the user.
+func (h *Handler) Update(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	var uri idRequest
+	if err := gctx.ShouldBindUri(&uri); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	var req updateRequest
+	if err := gctx.ShouldBindJSON(&req); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	payee, err := h.service.UpdateNickname(ctx, authPayload.Username, uri.ID, req.Nickname)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	gctx.JSON(http.StatusOK, web.Response{Data: payeeResponse{Payee: payee}})
+}
+
+// Delete handles http request to delete the payee of the user.
+func (h *Handler) Delete(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	var req idRequest
+	if err := gctx.ShouldBindUri(&req); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	if err := h.service.Delete(ctx, authPayload.Username, req.ID); err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	gctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,300 @@
+package payeedelivery
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/middleware"
+	"github.com/go-petr/pet-bank/pkg/currencypkg"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/go-petr/pet-bank/pkg/tokenpkg"
+	"github.com/go-petr/pet-bank/pkg/web"
+)
+
+func newServer(t *testing.T, tokenMaker tokenpkg.Maker, handler *Handler) *gin.Engine {
+	t.Helper()
+
+	gin.SetMode(gin.ReleaseMode)
+	server := gin.New()
+	server.Use(middleware.AuthMiddleware(tokenMaker, nil))
+	server.POST("/payees", handler.Create)
+	server.GET("/payees", handler.List)
+	server.GET("/payees/:id", handler.Get)
+	server.PATCH("/payees/:id", handler.Update)
+	server.DELETE("/payees/:id", handler.Delete)
+
+	return server
+}
+
+func TestHandler(t *testing.T) {
+	username := randompkg.Owner()
+	symmetricKey := randompkg.String(32)
+
+	tokenMaker, err := tokenpkg.NewPasetoMaker(symmetricKey)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(%v) returned error: %v", symmetricKey, err)
+	}
+
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		if err := v.RegisterValidation("currency", currencypkg.ValidCurrency); err != nil {
+			t.Fatalf("v.RegisterValidation(currency) returned error: %v", err)
+		}
+	}
+
+	payee := domain.Payee{
+		ID:        1,
+		Username:  username,
+		Nickname:  "landlord",
+		AccountID: 7,
+		Currency:  currencypkg.EUR,
+		UsableAt:  time.Now().UTC().Truncate(time.Second),
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           any
+		buildStubs     func(service *MockService)
+		wantStatusCode int
+		wantError      string
+	}{
+		{
+			name:   "CreateAccountID",
+			method: http.MethodPost,
+			url:    "/payees",
+			body:   gin.H{"nickname": "landlord", "account_id": 7},
+			buildStubs: func(service *MockService) {
+				arg := domain.CreatePayeeParams{Username: username, Nickname: "landlord", AccountID: 7}
+				service.EXPECT().Create(gomock.Any(), gomock.Eq(arg)).Times(1).Return(payee, nil)
+			},
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name:   "CreateAlias",
+			method: http.MethodPost,
+			url:    "/payees",
+			body:   gin.H{"nickname": "landlord", "alias": "@landlord", "currency": "EUR"},
+			buildStubs: func(service *MockService) {
+				arg := domain.CreatePayeeParams{
+					Username:  username,
+					Nickname:  "landlord",
+					Recipient: &domain.Recipient{Alias: "@landlord", Currency: currencypkg.EUR},
+				}
+				service.EXPECT().Create(gomock.Any(), gomock.Eq(arg)).Times(1).Return(payee, nil)
+			},
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name:   "CreateRequiresAccount",
+			method: http.MethodPost,
+			url:    "/payees",
+			body:   gin.H{"nickname": "landlord"},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "AccountID field is required without Username Alias",
+		},
+		{
+			name:   "CreateRequiresCurrency",
+			method: http.MethodPost,
+			url:    "/payees",
+			body:   gin.H{"nickname": "landlord", "username": "landlord"},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "Currency field is required without AccountID",
+		},
+		{
+			name:   "CreateRequiresNickname",
+			method: http.MethodPost,
+			url:    "/payees",
+			body:   gin.H{"account_id": 7},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "Nickname field is required",
+		},
+		{
+			name:   "CreateErrPayeeAlreadyExists",
+			method: http.MethodPost,
+			url:    "/payees",
+			body:   gin.H{"nickname": "landlord", "account_id": 7},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(domain.Payee{}, domain.ErrPayeeAlreadyExists)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantError:      domain.ErrPayeeAlreadyExists.Error(),
+		},
+		{
+			name:   "CreateErrRecipientAccountNotFound",
+			method: http.MethodPost,
+			url:    "/payees",
+			body:   gin.H{"nickname": "landlord", "username": "landlord", "currency": "EUR"},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.Payee{}, domain.ErrRecipientAccountNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      domain.ErrRecipientAccountNotFound.Error(),
+		},
+		{
+			name:   "CreateErrSystemAccount",
+			method: http.MethodPost,
+			url:    "/payees",
+			body:   gin.H{"nickname": "bank", "account_id": 1},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(domain.Payee{}, domain.ErrSystemAccount)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      domain.ErrSystemAccount.Error(),
+		},
+		{
+			name:   "Get",
+			method: http.MethodGet,
+			url:    "/payees/1",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Get(gomock.Any(), gomock.Eq(username), gomock.Eq(int64(1))).Times(1).Return(payee, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "GetErrPayeeNotFound",
+			method: http.MethodGet,
+			url:    "/payees/1",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(domain.Payee{}, domain.ErrPayeeNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      domain.ErrPayeeNotFound.Error(),
+		},
+		{
+			name:   "Update",
+			method: http.MethodPatch,
+			url:    "/payees/1",
+			body:   gin.H{"nickname": "landlord"},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().UpdateNickname(gomock.Any(), gomock.Eq(username), gomock.Eq(int64(1)), "landlord").
+					Times(1).
+					Return(payee, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "UpdateRequiresNickname",
+			method: http.MethodPatch,
+			url:    "/payees/1",
+			body:   gin.H{},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().UpdateNickname(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "Nickname field is required",
+		},
+		{
+			name:   "Delete",
+			method: http.MethodDelete,
+			url:    "/payees/1",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Delete(gomock.Any(), gomock.Eq(username), gomock.Eq(int64(1))).Times(1).Return(nil)
+			},
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name:   "DeleteErrInternal",
+			method: http.MethodDelete,
+			url:    "/payees/1",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(errorspkg.ErrInternal)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      errorspkg.ErrInternal.Error(),
+		},
+		{
+			name:   "InvalidID",
+			method: http.MethodGet,
+			url:    "/payees/0",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "ID field is required",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewMockService(ctrl)
+			tc.buildStubs(service)
+			server := newServer(t, tokenMaker, NewHandler(service))
+
+			var body []byte
+			if tc.body != nil {
+				if body, err = json.Marshal(tc.body); err != nil {
+					t.Fatalf("Encoding request body error: %v", err)
+				}
+			}
+
+			req, err := http.NewRequest(tc.method, tc.url, bytes.NewReader(body))
+			if err != nil {
+				t.Fatalf("Creating request error: %v", err)
+			}
+
+			if err := middleware.AddAuthorization(req, tokenMaker, middleware.AuthTypeBearer, username, time.Minute); err != nil {
+				t.Fatalf("middleware.AddAuthorization(...) returned error: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			if got := w.Code; got != tc.wantStatusCode {
+				t.Errorf("Status code: got %v, want %v", got, tc.wantStatusCode)
+			}
+
+			if w.Code == http.StatusNoContent {
+				return
+			}
+
+			data := &payeeResponse{}
+			res := web.Response{Data: data}
+
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Fatalf("Decoding response body error: %v", err)
+			}
+
+			if res.Error != tc.wantError {
+				t.Errorf(`res.Error=%q, want %q`, res.Error, tc.wantError)
+			}
+
+			if tc.wantError == "" {
+				if diff := cmp.Diff(payee, data.Payee); diff != "" {
+					t.Errorf("Response returned unexpected diff: %s", diff)
+				}
+			}
+		})
+	}
+}
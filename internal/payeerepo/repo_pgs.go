@@ -0,0 +1,195 @@
+// Package payeerepo manages repository layer of saved payees.
+package payeerepo
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/dbpkg"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog"
+)
+
+// RepoPGS facilitates payee repository layer logic.
+type RepoPGS struct {
+	db dbpkg.SQLInterface
+}
+
+// NewRepoPGS returns payee RepoPGS.
+func NewRepoPGS(db dbpkg.SQLInterface) *RepoPGS {
+	return &RepoPGS{
+		db: db,
+	}
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPayee(row scanner) (domain.Payee, error) {
+	var p domain.Payee
+
+	err := row.Scan(
+		&p.ID,
+		&p.Username,
+		&p.Nickname,
+		&p.AccountID,
+		&p.Currency,
+		&p.UsableAt,
+		&p.LastUsedAt,
+		&p.CreatedAt,
+	)
+
+	return p, err
+}
+
+// scanError maps the payee row error to the domain error.
+func scanError(ctx context.Context, err error) error {
+	zerolog.Ctx(ctx).Error().Err(err).Send()
+
+	if err == sql.ErrNoRows {
+		return domain.ErrPayeeNotFound
+	}
+
+	if pqErr, ok := err.(*pq.Error); ok {
+		switch pqErr.Constraint {
+		case "payees_username_nickname_key", "payees_username_account_id_key":
+			return domain.ErrPayeeAlreadyExists
+		case "payees_username_fkey":
+			return domain.ErrUserNotFound
+		case "payees_account_id_fkey":
+			return domain.ErrAccountNotFound
+		}
+	}
+
+	return errorspkg.ErrInternal
+}
+
+const createQuery = `
+INSERT INTO payees (username, nickname, account_id, currency, usable_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, username, nickname, account_id, currency, usable_at, last_used_at, created_at
+`
+
+// Create saves the payee and then returns it.
+func (r *RepoPGS) Create(ctx context.Context, arg domain.CreatePayeeParams) (domain.Payee, error) {
+	p, err := scanPayee(r.db.QueryRowContext(ctx, createQuery,
+		arg.Username, arg.Nickname, arg.AccountID, arg.Currency, arg.UsableAt))
+	if err != nil {
+		return p, scanError(ctx, err)
+	}
+
+	return p, nil
+}
+
+const getQuery = `
+SELECT id, username, nickname, account_id, currency, usable_at, last_used_at, created_at
+FROM payees
+WHERE id = $1 AND username = $2
+`
+
+// Get returns the user's payee with the given id.
+func (r *RepoPGS) Get(ctx context.Context, username string, id int64) (domain.Payee, error) {
+	p, err := scanPayee(r.db.QueryRowContext(ctx, getQuery, id, username))
+	if err != nil {
+		return p, scanError(ctx, err)
+	}
+
+	return p, nil
+}
+
+const listQuery = `
+SELECT id, username, nickname, account_id, currency, usable_at, last_used_at, created_at
+FROM payees
+WHERE username = $1
+ORDER BY last_used_at DESC NULLS LAST, id
+`
+
+// List returns all the payees of the user, the most recently used first.
+func (r *RepoPGS) List(ctx context.Context, username string) ([]domain.Payee, error) {
+	l := zerolog.Ctx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, listQuery, username)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+	defer rows.Close()
+
+	items := []domain.Payee{}
+
+	for rows.Next() {
+		p, err := scanPayee(rows)
+		if err != nil {
+			l.Error().Err(err).Send()
+			return nil, errorspkg.ErrInternal
+		}
+
+		items = append(items, p)
+	}
+
+	if err := rows.Close(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	if err := rows.Err(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	return items, nil
+}
+
+const updateNicknameQuery = `
+UPDATE payees
+SET nickname = $3
+WHERE id = $1 AND username = $2
+RETURNING id, username, nickname, account_id, currency, usable_at, last_used_at, created_at
+`
+
+// UpdateNickname renames the user's payee and returns it.
+func (r *RepoPGS) UpdateNickname(ctx context.Context, username string, id int64, nickname string) (domain.Payee, error) {
+	p, err := scanPayee(r.db.QueryRowContext(ctx, updateNicknameQuery, id, username, nickname))
+	if err != nil {
+		return p, scanError(ctx, err)
+	}
+
+	return p, nil
+}
+
+const deleteQuery = `
+DELETE FROM payees
+WHERE id = $1 AND username = $2
+RETURNING id, username, nickname, account_id, currency, usable_at, last_used_at, created_at
+`
+
+// Delete deletes the user's payee with the given id and returns it.
+func (r *RepoPGS) Delete(ctx context.Context, username string, id int64) (domain.Payee, error) {
+	p, err := scanPayee(r.db.QueryRowContext(ctx, deleteQuery, id, username))
+	if err != nil {
+		return p, scanError(ctx, err)
+	}
+
+	return p, nil
+}
+
+const markUsedQuery = `
+UPDATE payees
+SET last_used_at = now()
+WHERE id = $1
+`
+
+// MarkUsed sets the last used time of the payee to now.
+func (r *RepoPGS) MarkUsed(ctx context.Context, id int64) error {
+	l := zerolog.Ctx(ctx)
+
+	if _, err := r.db.ExecContext(ctx, markUsedQuery, id); err != nil {
+		l.Error().Err(err).Send()
+		return errorspkg.ErrInternal
+	}
+
+	return nil
+}
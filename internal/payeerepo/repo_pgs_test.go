@@ -0,0 +1,178 @@
+//go:build integration
+
+package payeerepo_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/integrationtest"
+	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
+	"github.com/go-petr/pet-bank/internal/payeerepo"
+	"github.com/go-petr/pet-bank/pkg/configpkg"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+var (
+	dbDriver string
+	dbSource string
+)
+
+func TestMain(m *testing.M) {
+	config, err := configpkg.Load("../../configs")
+	if err != nil {
+		log.Fatal("cannot load config:", err)
+	}
+
+	dbDriver = config.DBDriver
+	dbSource = config.DBSource
+
+	os.Exit(m.Run())
+}
+
+func TestPayees(t *testing.T) {
+	t.Parallel()
+
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	payeeRepo := payeerepo.NewRepoPGS(tx)
+	ctx := context.Background()
+
+	user := helpers.SeedUser(t, tx)
+	recipient := helpers.SeedUser(t, tx)
+	account := helpers.SeedAccountWith1000USDBalance(t, tx, recipient.Username)
+
+	arg := domain.CreatePayeeParams{
+		Username:  user.Username,
+		Nickname:  "landlord",
+		AccountID: account.ID,
+		Currency:  account.Currency,
+		UsableAt:  time.Now().UTC().Add(time.Hour),
+	}
+
+	payee, err := payeeRepo.Create(ctx, arg)
+	if err != nil {
+		t.Fatalf("payeeRepo.Create(ctx, %+v) returned error: %v", arg, err)
+	}
+
+	compareTime := cmpopts.EquateApproxTime(time.Second)
+	want := domain.Payee{
+		ID:        payee.ID,
+		Username:  user.Username,
+		Nickname:  arg.Nickname,
+		AccountID: account.ID,
+		Currency:  account.Currency,
+		UsableAt:  arg.UsableAt,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if diff := cmp.Diff(want, payee, compareTime); diff != "" {
+		t.Errorf("payeeRepo.Create(ctx, %+v) returned unexpected difference (-want +got):\n%s", arg, diff)
+	}
+
+	if _, err := payeeRepo.Get(ctx, recipient.Username, payee.ID); err != domain.ErrPayeeNotFound {
+		t.Errorf("payeeRepo.Get(ctx, %q, %v) returned error: %v, want %v", recipient.Username, payee.ID, err, domain.ErrPayeeNotFound)
+	}
+
+	if err := payeeRepo.MarkUsed(ctx, payee.ID); err != nil {
+		t.Fatalf("payeeRepo.MarkUsed(ctx, %v) returned error: %v", payee.ID, err)
+	}
+
+	renamed, err := payeeRepo.UpdateNickname(ctx, user.Username, payee.ID, "rent")
+	if err != nil {
+		t.Fatalf("payeeRepo.UpdateNickname(ctx, %q, %v, rent) returned error: %v", user.Username, payee.ID, err)
+	}
+
+	if renamed.Nickname != "rent" || renamed.LastUsedAt == nil {
+		t.Errorf("payeeRepo.UpdateNickname(ctx, %q, %v, rent) returned %+v, want renamed used payee", user.Username, payee.ID, renamed)
+	}
+
+	payees, err := payeeRepo.List(ctx, user.Username)
+	if err != nil {
+		t.Fatalf("payeeRepo.List(ctx, %q) returned error: %v", user.Username, err)
+	}
+
+	if diff := cmp.Diff([]domain.Payee{renamed}, payees); diff != "" {
+		t.Errorf("payeeRepo.List(ctx, %q) returned unexpected difference (-want +got):\n%s", user.Username, diff)
+	}
+
+	if _, err := payeeRepo.Delete(ctx, user.Username, payee.ID); err != nil {
+		t.Fatalf("payeeRepo.Delete(ctx, %q, %v) returned error: %v", user.Username, payee.ID, err)
+	}
+
+	if _, err := payeeRepo.Get(ctx, user.Username, payee.ID); err != domain.ErrPayeeNotFound {
+		t.Errorf("payeeRepo.Get(ctx, %q, %v) returned error: %v, want %v", user.Username, payee.ID, err, domain.ErrPayeeNotFound)
+	}
+}
+
+func TestCreateErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		arg     func(existing domain.CreatePayeeParams, otherAccountID int32) domain.CreatePayeeParams
+		wantErr error
+	}{
+		{
+			name: "SameAccount",
+			arg: func(existing domain.CreatePayeeParams, otherAccountID int32) domain.CreatePayeeParams {
+				existing.Nickname = "rent"
+				return existing
+			},
+			wantErr: domain.ErrPayeeAlreadyExists,
+		},
+		{
+			name: "SameNickname",
+			arg: func(existing domain.CreatePayeeParams, otherAccountID int32) domain.CreatePayeeParams {
+				existing.AccountID = otherAccountID
+				return existing
+			},
+			wantErr: domain.ErrPayeeAlreadyExists,
+		},
+		{
+			name: "ErrAccountNotFound",
+			arg: func(existing domain.CreatePayeeParams, otherAccountID int32) domain.CreatePayeeParams {
+				existing.Nickname = "rent"
+				existing.AccountID = 0
+				return existing
+			},
+			wantErr: domain.ErrAccountNotFound,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+			payeeRepo := payeerepo.NewRepoPGS(tx)
+			ctx := context.Background()
+
+			user := helpers.SeedUser(t, tx)
+			recipient := helpers.SeedUser(t, tx)
+			accounts := helpers.SeedAllCurrenciesAccountsWith1000Balance(t, tx, recipient.Username)
+
+			existing := domain.CreatePayeeParams{
+				Username:  user.Username,
+				Nickname:  "landlord",
+				AccountID: accounts[0].ID,
+				Currency:  accounts[0].Currency,
+				UsableAt:  time.Now().UTC(),
+			}
+
+			if _, err := payeeRepo.Create(ctx, existing); err != nil {
+				t.Fatalf("payeeRepo.Create(ctx, %+v) returned error: %v", existing, err)
+			}
+
+			arg := tc.arg(existing, accounts[1].ID)
+
+			if _, err := payeeRepo.Create(ctx, arg); err != tc.wantErr {
+				t.Errorf("payeeRepo.Create(ctx, %+v) returned error: %v, want %v", arg, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+// Package payeeservice manages business logic layer of saved payees.
+package payeeservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/rs/zerolog"
+)
+
+// Repo provides data access layer interface needed by payee service layer.
+//
+//go:generate mockgen -source service.go -destination service_mock.go -package payeeservice
+type Repo interface {
+	Create(ctx context.Context, arg domain.CreatePayeeParams) (domain.Payee, error)
+	Get(ctx context.Context, username string, id int64) (domain.Payee, error)
+	List(ctx context.Context, username string) ([]domain.Payee, error)
+	UpdateNickname(ctx context.Context, username string, id int64, nickname string) (domain.Payee, error)
+	Delete(ctx context.Context, username string, id int64) (domain.Payee, error)
+	MarkUsed(ctx context.Context, id int64) error
+}
+
+// AccountService provides the account lookup used to validate payee accounts.
+type AccountService interface {
+	Get(ctx context.Context, id int32) (domain.Account, error)
+}
+
+// Resolver resolves the payee given by username or alias to its account.
+type Resolver interface {
+	Resolve(ctx context.Context, recipient domain.Recipient) (domain.Account, error)
+}
+
+// Auditor records audit events of the payees.
+type Auditor interface {
+	Record(ctx context.Context, eventType, actor string, before, after any)
+}
+
+// Service facilitates payee service layer logic.
+type Service struct {
+	repo           Repo
+	accountService AccountService
+	resolver       Resolver
+	auditor        Auditor
+	coolingOff     time.Duration
+}
+
+// New returns payee service struct to manage payee bussines logic. New payees
+// can be paid once coolingOff has passed, immediately if it is zero. Audit
+// events are not recorded if a is nil.
+func New(r Repo, as AccountService, rr Resolver, a Auditor, coolingOff time.Duration) *Service {
+	return &Service{
+		repo:           r,
+		accountService: as,
+		resolver:       rr,
+		auditor:        a,
+		coolingOff:     coolingOff,
+	}
+}
+
+// Create saves the payee account, given by id or resolved from arg.Recipient,
+// under the nickname. The payee currency is the account currency.
+func (s *Service) Create(ctx context.Context, arg domain.CreatePayeeParams) (domain.Payee, error) {
+	l := zerolog.Ctx(ctx)
+
+	if arg.AccountID == 0 && arg.Recipient != nil {
+		account, err := s.resolver.Resolve(ctx, *arg.Recipient)
+		if err != nil {
+			return domain.Payee{}, err
+		}
+
+		arg.AccountID = account.ID
+	}
+
+	account, err := s.accountService.Get(ctx, arg.AccountID)
+	if err != nil {
+		return domain.Payee{}, err
+	}
+
+	if account.IsSystem {
+		l.Info().Err(domain.ErrSystemAccount).Send()
+		return domain.Payee{}, domain.ErrSystemAccount
+	}
+
+	arg.Currency = account.Currency
+	arg.UsableAt = time.Now().UTC().Add(s.coolingOff)
+
+	payee, err := s.repo.Create(ctx, arg)
+	if err != nil {
+		return payee, err
+	}
+
+	if s.auditor != nil {
+		s.auditor.Record(ctx, domain.AuditPayeeCreated, arg.Username, nil, payee)
+	}
+
+	return payee, nil
+}
+
+// Get returns the user's payee.
+func (s *Service) Get(ctx context.Context, username string, id int64) (domain.Payee, error) {
+	return s.repo.Get(ctx, username, id)
+}
+
+// List returns all the payees of the user, the most recently used first.
+func (s *Service) List(ctx context.Context, username string) ([]domain.Payee, error) {
+	return s.repo.List(ctx, username)
+}
+
+// UpdateNickname renames the user's payee.
+func (s *Service) UpdateNickname(ctx context.Context, username string, id int64, nickname string) (domain.Payee, error) {
+	before, err := s.repo.Get(ctx, username, id)
+	if err != nil {
+		return before, err
+	}
+
+	payee, err := s.repo.UpdateNickname(ctx, username, id, nickname)
+	if err != nil {
+		return payee, err
+	}
+
+	if s.auditor != nil {
+		s.auditor.Record(ctx, domain.AuditPayeeUpdated, username, before, payee)
+	}
+
+	return payee, nil
+}
+
+// Delete deletes the user's payee.
+func (s *Service) Delete(ctx context.Context, username string, id int64) error {
+	payee, err := s.repo.Delete(ctx, username, id)
+	if err != nil {
+		return err
+	}
+
+	if s.auditor != nil {
+		s.auditor.Record(ctx, domain.AuditPayeeDeleted, username, payee, nil)
+	}
+
+	return nil
+}
+
+// GetUsable returns the user's payee to pay. It returns
+// domain.ErrPayeeCoolingOff if the payee cooling-off period has not ended yet.
+func (s *Service) GetUsable(ctx context.Context, username string, id int64) (domain.Payee, error) {
+	payee, err := s.repo.Get(ctx, username, id)
+	if err != nil {
+		return payee, err
+	}
+
+	if time.Now().Before(payee.UsableAt) {
+		zerolog.Ctx(ctx).Info().Err(domain.ErrPayeeCoolingOff).Send()
+		return domain.Payee{}, domain.ErrPayeeCoolingOff
+	}
+
+	return payee, nil
+}
+
+// MarkUsed records that the payee has just been paid.
+func (s *Service) MarkUsed(ctx context.Context, id int64) error {
+	return s.repo.MarkUsed(ctx, id)
+}
@@ -0,0 +1,236 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package payeeservice is a generated GoMock package.
+package payeeservice
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/go-petr/pet-bank/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepo is a mock of Repo interface.
+type MockRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoMockRecorder
+}
+
+// MockRepoMockRecorder is the mock recorder for MockRepo.
+type MockRepoMockRecorder struct {
+	mock *MockRepo
+}
+
+// NewMockRepo creates a new mock instance.
+func NewMockRepo(ctrl *gomock.Controller) *MockRepo {
+	mock := &MockRepo{ctrl: ctrl}
+	mock.recorder = &MockRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRepo) Create(ctx context.Context, arg domain.CreatePayeeParams) (domain.Payee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, arg)
+	ret0, _ := ret[0].(domain.Payee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepoMockRecorder) Create(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepo)(nil).Create), ctx, arg)
+}
+
+// Delete mocks base method.
+func (m *MockRepo) Delete(ctx context.Context, username string, id int64) (domain.Payee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, username, id)
+	ret0, _ := ret[0].(domain.Payee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepoMockRecorder) Delete(ctx, username, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepo)(nil).Delete), ctx, username, id)
+}
+
+// Get mocks base method.
+func (m *MockRepo) Get(ctx context.Context, username string, id int64) (domain.Payee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, username, id)
+	ret0, _ := ret[0].(domain.Payee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepoMockRecorder) Get(ctx, username, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepo)(nil).Get), ctx, username, id)
+}
+
+// List mocks base method.
+func (m *MockRepo) List(ctx context.Context, username string) ([]domain.Payee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, username)
+	ret0, _ := ret[0].([]domain.Payee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepoMockRecorder) List(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepo)(nil).List), ctx, username)
+}
+
+// MarkUsed mocks base method.
+func (m *MockRepo) MarkUsed(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockRepoMockRecorder) MarkUsed(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockRepo)(nil).MarkUsed), ctx, id)
+}
+
+// UpdateNickname mocks base method.
+func (m *MockRepo) UpdateNickname(ctx context.Context, username string, id int64, nickname string) (domain.Payee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNickname", ctx, username, id, nickname)
+	ret0, _ := ret[0].(domain.Payee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNickname indicates an expected call of UpdateNickname.
+func (mr *MockRepoMockRecorder) UpdateNickname(ctx, username, id, nickname interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNickname", reflect.TypeOf((*MockRepo)(nil).UpdateNickname), ctx, username, id, nickname)
+}
+
+// MockAccountService is a mock of AccountService interface.
+type MockAccountService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountServiceMockRecorder
+}
+
+// MockAccountServiceMockRecorder is the mock recorder for MockAccountService.
+type MockAccountServiceMockRecorder struct {
+	mock *MockAccountService
+}
+
+// NewMockAccountService creates a new mock instance.
+func NewMockAccountService(ctrl *gomock.Controller) *MockAccountService {
+	mock := &MockAccountService{ctrl: ctrl}
+	mock.recorder = &MockAccountServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountService) EXPECT() *MockAccountServiceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockAccountService) Get(ctx context.Context, id int32) (domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAccountServiceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAccountService)(nil).Get), ctx, id)
+}
+
+// MockResolver is a mock of Resolver interface.
+type MockResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockResolverMockRecorder
+}
+
+// MockResolverMockRecorder is the mock recorder for MockResolver.
+type MockResolverMockRecorder struct {
+	mock *MockResolver
+}
+
+// NewMockResolver creates a new mock instance.
+func NewMockResolver(ctrl *gomock.Controller) *MockResolver {
+	mock := &MockResolver{ctrl: ctrl}
+	mock.recorder = &MockResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResolver) EXPECT() *MockResolverMockRecorder {
+	return m.recorder
+}
+
+// Resolve mocks base method.
+func (m *MockResolver) Resolve(ctx context.Context, recipient domain.Recipient) (domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, recipient)
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockResolverMockRecorder) Resolve(ctx, recipient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockResolver)(nil).Resolve), ctx, recipient)
+}
+
+// MockAuditor is a mock of Auditor interface.
+type MockAuditor struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditorMockRecorder
+}
+
+// MockAuditorMockRecorder is the mock recorder for MockAuditor.
+type MockAuditorMockRecorder struct {
+	mock *MockAuditor
+}
+
+// NewMockAuditor creates a new mock instance.
+func NewMockAuditor(ctrl *gomock.Controller) *MockAuditor {
+	mock := &MockAuditor{ctrl: ctrl}
+	mock.recorder = &MockAuditorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditor) EXPECT() *MockAuditorMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockAuditor) Record(ctx context.Context, eventType, actor string, before, after any) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", ctx, eventType, actor, before, after)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAuditorMockRecorder) Record(ctx, eventType, actor, before, after interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAuditor)(nil).Record), ctx, eventType, actor, before, after)
+}
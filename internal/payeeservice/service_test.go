@@ -0,0 +1,176 @@
+package payeeservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/currencypkg"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/golang/mock/gomock"
+)
+
+func TestCreate(t *testing.T) {
+	username := randompkg.Owner()
+	account := domain.Account{ID: 7, Owner: randompkg.Owner(), Balance: "0", Currency: currencypkg.EUR}
+	settlement := domain.Account{ID: 1, Owner: domain.SettlementOwner, Currency: currencypkg.EUR, IsSystem: true}
+
+	testCases := []struct {
+		name       string
+		arg        domain.CreatePayeeParams
+		coolingOff time.Duration
+		buildStubs func(repo *MockRepo, accountService *MockAccountService, resolver *MockResolver, auditor *MockAuditor)
+		wantErr    error
+	}{
+		{
+			name:       "AccountID",
+			arg:        domain.CreatePayeeParams{Username: username, Nickname: "landlord", AccountID: account.ID},
+			coolingOff: 24 * time.Hour,
+			buildStubs: func(repo *MockRepo, accountService *MockAccountService, resolver *MockResolver, auditor *MockAuditor) {
+				accountService.EXPECT().Get(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, got domain.CreatePayeeParams) (domain.Payee, error) {
+						if got.Currency != currencypkg.EUR {
+							t.Errorf("got.Currency = %v, want %v", got.Currency, currencypkg.EUR)
+						}
+
+						if d := time.Until(got.UsableAt); d < 23*time.Hour || d > 24*time.Hour {
+							t.Errorf("got.UsableAt = %v, want in 24h", got.UsableAt)
+						}
+
+						return domain.Payee{ID: 1}, nil
+					})
+				auditor.EXPECT().Record(gomock.Any(), domain.AuditPayeeCreated, username, nil, gomock.Any()).Times(1)
+			},
+		},
+		{
+			name: "Recipient",
+			arg: domain.CreatePayeeParams{
+				Username:  username,
+				Nickname:  "landlord",
+				Recipient: &domain.Recipient{Alias: "@landlord", Currency: currencypkg.EUR},
+			},
+			buildStubs: func(repo *MockRepo, accountService *MockAccountService, resolver *MockResolver, auditor *MockAuditor) {
+				resolver.EXPECT().Resolve(gomock.Any(), gomock.Eq(domain.Recipient{Alias: "@landlord", Currency: currencypkg.EUR})).
+					Times(1).
+					Return(account, nil)
+				accountService.EXPECT().Get(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(domain.Payee{ID: 1}, nil)
+				auditor.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+			},
+		},
+		{
+			name: "ErrRecipientNotFound",
+			arg: domain.CreatePayeeParams{
+				Username:  username,
+				Nickname:  "landlord",
+				Recipient: &domain.Recipient{Username: "nobody", Currency: currencypkg.EUR},
+			},
+			buildStubs: func(repo *MockRepo, accountService *MockAccountService, resolver *MockResolver, auditor *MockAuditor) {
+				resolver.EXPECT().Resolve(gomock.Any(), gomock.Any()).Times(1).Return(domain.Account{}, domain.ErrRecipientNotFound)
+				accountService.EXPECT().Get(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrRecipientNotFound,
+		},
+		{
+			name: "ErrAccountNotFound",
+			arg:  domain.CreatePayeeParams{Username: username, Nickname: "landlord", AccountID: account.ID},
+			buildStubs: func(repo *MockRepo, accountService *MockAccountService, resolver *MockResolver, auditor *MockAuditor) {
+				accountService.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(domain.Account{}, domain.ErrAccountNotFound)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrAccountNotFound,
+		},
+		{
+			name: "ErrSystemAccount",
+			arg:  domain.CreatePayeeParams{Username: username, Nickname: "bank", AccountID: settlement.ID},
+			buildStubs: func(repo *MockRepo, accountService *MockAccountService, resolver *MockResolver, auditor *MockAuditor) {
+				accountService.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(settlement, nil)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrSystemAccount,
+		},
+		{
+			name: "ErrPayeeAlreadyExists",
+			arg:  domain.CreatePayeeParams{Username: username, Nickname: "landlord", AccountID: account.ID},
+			buildStubs: func(repo *MockRepo, accountService *MockAccountService, resolver *MockResolver, auditor *MockAuditor) {
+				accountService.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(account, nil)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(domain.Payee{}, domain.ErrPayeeAlreadyExists)
+				auditor.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrPayeeAlreadyExists,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepo(ctrl)
+			accountService := NewMockAccountService(ctrl)
+			resolver := NewMockResolver(ctrl)
+			auditor := NewMockAuditor(ctrl)
+			tc.buildStubs(repo, accountService, resolver, auditor)
+
+			service := New(repo, accountService, resolver, auditor, tc.coolingOff)
+
+			if _, err := service.Create(context.Background(), tc.arg); err != tc.wantErr {
+				t.Errorf("service.Create(ctx, %+v) returned error: %v, want %v", tc.arg, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetUsable(t *testing.T) {
+	username := randompkg.Owner()
+
+	testCases := []struct {
+		name    string
+		payee   domain.Payee
+		repoErr error
+		wantErr error
+	}{
+		{
+			name:  "OK",
+			payee: domain.Payee{ID: 1, Username: username, UsableAt: time.Now().Add(-time.Minute)},
+		},
+		{
+			name:    "ErrPayeeCoolingOff",
+			payee:   domain.Payee{ID: 1, Username: username, UsableAt: time.Now().Add(time.Hour)},
+			wantErr: domain.ErrPayeeCoolingOff,
+		},
+		{
+			name:    "ErrPayeeNotFound",
+			repoErr: domain.ErrPayeeNotFound,
+			wantErr: domain.ErrPayeeNotFound,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepo(ctrl)
+			repo.EXPECT().Get(gomock.Any(), gomock.Eq(username), gomock.Eq(int64(1))).Times(1).Return(tc.payee, tc.repoErr)
+
+			service := New(repo, NewMockAccountService(ctrl), NewMockResolver(ctrl), nil, time.Hour)
+
+			got, err := service.GetUsable(context.Background(), username, 1)
+			if err != tc.wantErr {
+				t.Fatalf("service.GetUsable(ctx, %v, 1) returned error: %v, want %v", username, err, tc.wantErr)
+			}
+
+			if err == nil && got.ID != tc.payee.ID {
+				t.Errorf("service.GetUsable(ctx, %v, 1) returned %+v, want %+v", username, got, tc.payee)
+			}
+		})
+	}
+}
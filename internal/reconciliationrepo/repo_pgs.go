@@ -0,0 +1,225 @@
+// Package reconciliationrepo manages repository layer of the ledger reconciliation.
+package reconciliationrepo
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/dbpkg"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/rs/zerolog"
+)
+
+// RepoPGS facilitates reconciliation repository layer logic.
+type RepoPGS struct {
+	db dbpkg.SQLInterface
+}
+
+// NewRepoPGS returns reconciliation RepoPGS.
+func NewRepoPGS(db dbpkg.SQLInterface) *RepoPGS {
+	return &RepoPGS{
+		db: db,
+	}
+}
+
+const countQuery = `
+SELECT (SELECT count(*) FROM accounts), (SELECT count(*) FROM transfers)
+`
+
+// Count returns the number of accounts and transfers.
+func (r *RepoPGS) Count(ctx context.Context) (accounts, transfers int64, err error) {
+	l := zerolog.Ctx(ctx)
+
+	if err := r.db.QueryRowContext(ctx, countQuery).Scan(&accounts, &transfers); err != nil {
+		l.Error().Err(err).Send()
+		return 0, 0, errorspkg.ErrInternal
+	}
+
+	return accounts, transfers, nil
+}
+
+// The balances and entries are read by a single statement, so concurrent
+// transfers cannot cause false discrepancies.
+const listBalanceDiscrepanciesQuery = `
+SELECT a.id, COALESCE(SUM(e.amount), 0), a.balance
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id
+GROUP BY a.id
+HAVING a.balance <> COALESCE(SUM(e.amount), 0)
+ORDER BY a.id
+`
+
+// ListBalanceDiscrepancies returns the accounts which balance differs from
+// the sum of their entries.
+func (r *RepoPGS) ListBalanceDiscrepancies(ctx context.Context) ([]domain.Discrepancy, error) {
+	l := zerolog.Ctx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, listBalanceDiscrepanciesQuery)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+	defer rows.Close()
+
+	items := []domain.Discrepancy{}
+
+	for rows.Next() {
+		d := domain.Discrepancy{Kind: domain.DiscrepancyBalance}
+
+		if err := rows.Scan(&d.AccountID, &d.Expected, &d.Actual); err != nil {
+			l.Error().Err(err).Send()
+			return nil, errorspkg.ErrInternal
+		}
+
+		items = append(items, d)
+	}
+
+	if err := rows.Close(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	if err := rows.Err(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	return items, nil
+}
+
+// The credit of a cross-currency transfer is the amount converted at the
+// applied rate and rounded the same way as by the transfer.
+const listTransferDiscrepanciesQuery = `
+SELECT
+    id,
+    entries, entries <> 2,
+    expected_debit, debit, debit <> expected_debit,
+    expected_credit, credit, credit <> expected_credit
+FROM (
+    SELECT
+        t.id,
+        count(e.id) AS entries,
+        -t.amount AS expected_debit,
+        COALESCE(SUM(e.amount) FILTER (WHERE e.amount < 0), 0) AS debit,
+        CASE WHEN t.fx_rate IS NULL THEN t.amount ELSE round(t.amount * t.fx_rate, 2) END AS expected_credit,
+        COALESCE(SUM(e.amount) FILTER (WHERE e.amount > 0), 0) AS credit
+    FROM transfers t
+    LEFT JOIN entries e ON e.transfer_id = t.id
+    GROUP BY t.id
+) totals
+WHERE entries <> 2 OR debit <> expected_debit OR credit <> expected_credit
+ORDER BY id
+`
+
+// ListTransferDiscrepancies returns the discrepancies of the transfers which
+// do not have exactly two entries netting to zero.
+func (r *RepoPGS) ListTransferDiscrepancies(ctx context.Context) ([]domain.Discrepancy, error) {
+	l := zerolog.Ctx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, listTransferDiscrepanciesQuery)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+	defer rows.Close()
+
+	items := []domain.Discrepancy{}
+
+	for rows.Next() {
+		var (
+			id                              int64
+			entries                         string
+			expectedDebit, debit            string
+			expectedCredit, credit          string
+			badEntries, badDebit, badCredit bool
+		)
+
+		err := rows.Scan(
+			&id,
+			&entries, &badEntries,
+			&expectedDebit, &debit, &badDebit,
+			&expectedCredit, &credit, &badCredit,
+		)
+		if err != nil {
+			l.Error().Err(err).Send()
+			return nil, errorspkg.ErrInternal
+		}
+
+		if badEntries {
+			items = append(items, domain.Discrepancy{
+				Kind: domain.DiscrepancyTransferEntries, TransferID: id, Expected: "2", Actual: entries,
+			})
+		}
+
+		if badDebit {
+			items = append(items, domain.Discrepancy{
+				Kind: domain.DiscrepancyTransferDebit, TransferID: id, Expected: expectedDebit, Actual: debit,
+			})
+		}
+
+		if badCredit {
+			items = append(items, domain.Discrepancy{
+				Kind: domain.DiscrepancyTransferCredit, TransferID: id, Expected: expectedCredit, Actual: credit,
+			})
+		}
+	}
+
+	if err := rows.Close(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	if err := rows.Err(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	return items, nil
+}
+
+const createReportQuery = `
+INSERT INTO reconciliation_reports (
+	accounts,
+	transfers,
+	discrepancies,
+	started_at
+) VALUES (
+	$1, $2, $3, $4
+) RETURNING id, accounts, transfers, discrepancies, started_at, created_at
+`
+
+// CreateReport stores the reconciliation report and then returns it.
+func (r *RepoPGS) CreateReport(ctx context.Context, arg domain.CreateReconciliationReportParams) (domain.ReconciliationReport, error) {
+	l := zerolog.Ctx(ctx)
+
+	var report domain.ReconciliationReport
+
+	discrepancies := arg.Discrepancies
+	if discrepancies == nil {
+		discrepancies = []domain.Discrepancy{}
+	}
+
+	data, err := json.Marshal(discrepancies)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return report, errorspkg.ErrInternal
+	}
+
+	row := r.db.QueryRowContext(ctx, createReportQuery, arg.Accounts, arg.Transfers, data, arg.StartedAt)
+
+	var stored []byte
+
+	err = row.Scan(&report.ID, &report.Accounts, &report.Transfers, &stored, &report.StartedAt, &report.CreatedAt)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return report, errorspkg.ErrInternal
+	}
+
+	if err := json.Unmarshal(stored, &report.Discrepancies); err != nil {
+		l.Error().Err(err).Send()
+		return report, errorspkg.ErrInternal
+	}
+
+	return report, nil
+}
@@ -0,0 +1,222 @@
+//go:build integration
+
+package reconciliationrepo_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/integrationtest"
+	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
+	"github.com/go-petr/pet-bank/internal/reconciliationrepo"
+	"github.com/go-petr/pet-bank/internal/transferrepo"
+	"github.com/go-petr/pet-bank/pkg/configpkg"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	_ "github.com/lib/pq"
+)
+
+var (
+	dbDriver string
+	dbSource string
+)
+
+func TestMain(m *testing.M) {
+	config, err := configpkg.Load("../../configs")
+	if err != nil {
+		log.Fatal("cannot load config:", err)
+	}
+
+	dbDriver = config.DBDriver
+	dbSource = config.DBSource
+
+	os.Exit(m.Run())
+}
+
+func TestDiscrepancies(t *testing.T) {
+	// The transfer runs its own transaction, so the test uses the database
+	// that is cleaned up after the test.
+	db := integrationtest.SetupDB(t, dbDriver, dbSource)
+	ctx := context.Background()
+
+	user := helpers.SeedUser(t, db)
+	account1 := helpers.SeedAccountWith1000USDBalance(t, db, user.Username)
+	account2 := helpers.SeedAccountWith1000USDBalance(t, db, user.Username)
+
+	// The seeded balances have no entries, so they are balanced by an entry.
+	helpers.SeedEntry(t, db, "1000", account1.ID)
+	helpers.SeedEntry(t, db, "1000", account2.ID)
+
+	repo := reconciliationrepo.NewRepoPGS(db)
+
+	arg := domain.CreateTransferParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: "100"}
+
+	result, err := transferrepo.NewRepoPGS(db).Transfer(ctx, user.Username, arg)
+	if err != nil {
+		t.Fatalf("Transfer(ctx, %q, %+v) returned error: %v", user.Username, arg, err)
+	}
+
+	balances, err := repo.ListBalanceDiscrepancies(ctx)
+	if err != nil {
+		t.Fatalf("repo.ListBalanceDiscrepancies(ctx) returned error: %v", err)
+	}
+
+	transfers, err := repo.ListTransferDiscrepancies(ctx)
+	if err != nil {
+		t.Fatalf("repo.ListTransferDiscrepancies(ctx) returned error: %v", err)
+	}
+
+	if len(balances) != 0 || len(transfers) != 0 {
+		t.Fatalf("discrepancies of the balanced ledger = %+v, %+v, want none", balances, transfers)
+	}
+
+	// Break the ledger behind the application's back.
+	if _, err := db.Exec(`UPDATE accounts SET balance = balance + 1 WHERE id = $1`, account1.ID); err != nil {
+		t.Fatalf("updating balance returned error: %v", err)
+	}
+
+	if _, err := db.Exec(`UPDATE entries SET amount = 101 WHERE id = $1`, result.ToEntry.ID); err != nil {
+		t.Fatalf("updating entry returned error: %v", err)
+	}
+
+	balances, err = repo.ListBalanceDiscrepancies(ctx)
+	if err != nil {
+		t.Fatalf("repo.ListBalanceDiscrepancies(ctx) returned error: %v", err)
+	}
+
+	wantBalances := []domain.Discrepancy{
+		{Kind: domain.DiscrepancyBalance, AccountID: account1.ID, Expected: "900", Actual: "901"},
+		{Kind: domain.DiscrepancyBalance, AccountID: account2.ID, Expected: "1101", Actual: "1100"},
+	}
+
+	if diff := cmp.Diff(wantBalances, balances); diff != "" {
+		t.Errorf("repo.ListBalanceDiscrepancies(ctx) returned unexpected difference (-want +got):\n%s", diff)
+	}
+
+	transfers, err = repo.ListTransferDiscrepancies(ctx)
+	if err != nil {
+		t.Fatalf("repo.ListTransferDiscrepancies(ctx) returned error: %v", err)
+	}
+
+	wantTransfers := []domain.Discrepancy{
+		{Kind: domain.DiscrepancyTransferCredit, TransferID: result.Transfer.ID, Expected: "100", Actual: "101"},
+	}
+
+	if diff := cmp.Diff(wantTransfers, transfers); diff != "" {
+		t.Errorf("repo.ListTransferDiscrepancies(ctx) returned unexpected difference (-want +got):\n%s", diff)
+	}
+
+	accounts, count, err := repo.Count(ctx)
+	if err != nil {
+		t.Fatalf("repo.Count(ctx) returned error: %v", err)
+	}
+
+	if accounts != 2 || count != 1 {
+		t.Errorf("repo.Count(ctx) = %d, %d, want 2, 1", accounts, count)
+	}
+}
+
+func TestBackfillLegacyTransfer(t *testing.T) {
+	t.Parallel()
+
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	repo := reconciliationrepo.NewRepoPGS(tx)
+	ctx := context.Background()
+
+	user := helpers.SeedUser(t, tx)
+	account1 := helpers.SeedAccountWith1000USDBalance(t, tx, user.Username)
+	account2 := helpers.SeedAccountWith1000USDBalance(t, tx, user.Username)
+
+	// The transfer and its entries are inserted the way they were before
+	// entries had transfer_id.
+	var transferID int64
+
+	err := tx.QueryRowContext(ctx, `
+INSERT INTO transfers (from_account_id, to_account_id, amount) VALUES ($1, $2, 100) RETURNING id
+`, account1.ID, account2.ID).Scan(&transferID)
+	if err != nil {
+		t.Fatalf("inserting legacy transfer returned error: %v", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+INSERT INTO entries (account_id, amount) VALUES ($1, -100), ($2, 100)
+`, account1.ID, account2.ID)
+	if err != nil {
+		t.Fatalf("inserting legacy entries returned error: %v", err)
+	}
+
+	transferDiscrepancies := func() []domain.Discrepancy {
+		t.Helper()
+
+		all, err := repo.ListTransferDiscrepancies(ctx)
+		if err != nil {
+			t.Fatalf("repo.ListTransferDiscrepancies(ctx) returned error: %v", err)
+		}
+
+		var items []domain.Discrepancy
+
+		for _, d := range all {
+			if d.TransferID == transferID {
+				items = append(items, d)
+			}
+		}
+
+		return items
+	}
+
+	if got := transferDiscrepancies(); len(got) == 0 {
+		t.Fatalf("discrepancies of the legacy transfer %v before backfill = none, want some", transferID)
+	}
+
+	const migration = "../../configs/db/migration/000023_backfill_entries_transfer_id.up.sql"
+
+	query, err := os.ReadFile(migration)
+	if err != nil {
+		t.Fatalf("os.ReadFile(%v) returned error: %v", migration, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, string(query)); err != nil {
+		t.Fatalf("running %v returned error: %v", migration, err)
+	}
+
+	if got := transferDiscrepancies(); len(got) != 0 {
+		t.Errorf("discrepancies of the legacy transfer %v after backfill = %+v, want none", transferID, got)
+	}
+}
+
+func TestCreateReport(t *testing.T) {
+	t.Parallel()
+
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	repo := reconciliationrepo.NewRepoPGS(tx)
+
+	arg := domain.CreateReconciliationReportParams{
+		Accounts:  2,
+		Transfers: 1,
+		Discrepancies: []domain.Discrepancy{
+			{Kind: domain.DiscrepancyBalance, AccountID: 1, Expected: "900", Actual: "901"},
+		},
+		StartedAt: time.Now(),
+	}
+
+	got, err := repo.CreateReport(context.Background(), arg)
+	if err != nil {
+		t.Fatalf("repo.CreateReport(ctx, %+v) returned error: %v", arg, err)
+	}
+
+	want := domain.ReconciliationReport{
+		Accounts:      arg.Accounts,
+		Transfers:     arg.Transfers,
+		Discrepancies: arg.Discrepancies,
+		StartedAt:     arg.StartedAt,
+	}
+	ignoreFields := cmpopts.IgnoreFields(domain.ReconciliationReport{}, "ID", "CreatedAt")
+
+	if diff := cmp.Diff(want, got, ignoreFields, cmpopts.EquateApproxTime(time.Second)); diff != "" {
+		t.Errorf("repo.CreateReport(ctx, %+v) returned unexpected difference (-want +got):\n%s", arg, diff)
+	}
+}
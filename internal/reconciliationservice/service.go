@@ -0,0 +1,94 @@
+// Package reconciliationservice manages business logic layer of the ledger reconciliation.
+package reconciliationservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/rs/zerolog"
+)
+
+// Repo provides data access layer interface needed by reconciliation service layer.
+//
+//go:generate mockgen -source service.go -destination service_mock.go -package reconciliationservice
+type Repo interface {
+	Count(ctx context.Context) (accounts, transfers int64, err error)
+	ListBalanceDiscrepancies(ctx context.Context) ([]domain.Discrepancy, error)
+	ListTransferDiscrepancies(ctx context.Context) ([]domain.Discrepancy, error)
+	CreateReport(ctx context.Context, arg domain.CreateReconciliationReportParams) (domain.ReconciliationReport, error)
+}
+
+// Service facilitates reconciliation service layer logic.
+type Service struct {
+	repo Repo
+}
+
+// New returns reconciliation service struct to check the ledger invariants.
+func New(r Repo) *Service {
+	return &Service{
+		repo: r,
+	}
+}
+
+// Run checks that each account balance equals the sum of its entries and that
+// each transfer has exactly two entries netting to zero. The report is stored
+// whether or not discrepancies are found.
+func (s *Service) Run(ctx context.Context) (domain.ReconciliationReport, error) {
+	l := zerolog.Ctx(ctx)
+
+	arg := domain.CreateReconciliationReportParams{StartedAt: time.Now()}
+
+	var err error
+
+	arg.Accounts, arg.Transfers, err = s.repo.Count(ctx)
+	if err != nil {
+		return domain.ReconciliationReport{}, err
+	}
+
+	balances, err := s.repo.ListBalanceDiscrepancies(ctx)
+	if err != nil {
+		return domain.ReconciliationReport{}, err
+	}
+
+	transfers, err := s.repo.ListTransferDiscrepancies(ctx)
+	if err != nil {
+		return domain.ReconciliationReport{}, err
+	}
+
+	arg.Discrepancies = append(balances, transfers...)
+
+	report, err := s.repo.CreateReport(ctx, arg)
+	if err != nil {
+		return domain.ReconciliationReport{}, err
+	}
+
+	if !report.Balanced() {
+		l.Error().Int64("report_id", report.ID).Int("discrepancies", len(report.Discrepancies)).Msg("Ledger is out of balance")
+	}
+
+	return report, nil
+}
+
+// RunScheduled runs the reconciliation every interval until the context is done.
+func (s *Service) RunScheduled(ctx context.Context, interval time.Duration) {
+	l := zerolog.Ctx(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := s.Run(ctx)
+			if err != nil {
+				l.Error().Err(err).Msg("Cannot reconcile ledger")
+				continue
+			}
+
+			l.Info().Int64("report_id", report.ID).Int("discrepancies", len(report.Discrepancies)).Msg("Reconciled ledger")
+		}
+	}
+}
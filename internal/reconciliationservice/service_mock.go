@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package reconciliationservice is a generated GoMock package.
+package reconciliationservice
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/go-petr/pet-bank/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepo is a mock of Repo interface.
+type MockRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoMockRecorder
+}
+
+// MockRepoMockRecorder is the mock recorder for MockRepo.
+type MockRepoMockRecorder struct {
+	mock *MockRepo
+}
+
+// NewMockRepo creates a new mock instance.
+func NewMockRepo(ctrl *gomock.Controller) *MockRepo {
+	mock := &MockRepo{ctrl: ctrl}
+	mock.recorder = &MockRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockRepo) Count(ctx context.Context) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockRepoMockRecorder) Count(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockRepo)(nil).Count), ctx)
+}
+
+// CreateReport mocks base method.
+func (m *MockRepo) CreateReport(ctx context.Context, arg domain.CreateReconciliationReportParams) (domain.ReconciliationReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReport", ctx, arg)
+	ret0, _ := ret[0].(domain.ReconciliationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReport indicates an expected call of CreateReport.
+func (mr *MockRepoMockRecorder) CreateReport(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReport", reflect.TypeOf((*MockRepo)(nil).CreateReport), ctx, arg)
+}
+
+// ListBalanceDiscrepancies mocks base method.
+func (m *MockRepo) ListBalanceDiscrepancies(ctx context.Context) ([]domain.Discrepancy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBalanceDiscrepancies", ctx)
+	ret0, _ := ret[0].([]domain.Discrepancy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBalanceDiscrepancies indicates an expected call of ListBalanceDiscrepancies.
+func (mr *MockRepoMockRecorder) ListBalanceDiscrepancies(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBalanceDiscrepancies", reflect.TypeOf((*MockRepo)(nil).ListBalanceDiscrepancies), ctx)
+}
+
+// ListTransferDiscrepancies mocks base method.
+func (m *MockRepo) ListTransferDiscrepancies(ctx context.Context) ([]domain.Discrepancy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransferDiscrepancies", ctx)
+	ret0, _ := ret[0].([]domain.Discrepancy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransferDiscrepancies indicates an expected call of ListTransferDiscrepancies.
+func (mr *MockRepoMockRecorder) ListTransferDiscrepancies(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferDiscrepancies", reflect.TypeOf((*MockRepo)(nil).ListTransferDiscrepancies), ctx)
+}
@@ -0,0 +1,115 @@
+package reconciliationservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestRun(t *testing.T) {
+	balance := domain.Discrepancy{Kind: domain.DiscrepancyBalance, AccountID: 1, Expected: "900", Actual: "1000"}
+	entries := domain.Discrepancy{Kind: domain.DiscrepancyTransferEntries, TransferID: 2, Expected: "2", Actual: "1"}
+
+	// createReport returns the stored report built from the argument.
+	createReport := func(t *testing.T, want domain.CreateReconciliationReportParams) func(context.Context, domain.CreateReconciliationReportParams) (domain.ReconciliationReport, error) {
+		return func(_ context.Context, arg domain.CreateReconciliationReportParams) (domain.ReconciliationReport, error) {
+			ignoreStartedAt := cmpopts.IgnoreFields(domain.CreateReconciliationReportParams{}, "StartedAt")
+			if diff := cmp.Diff(want, arg, ignoreStartedAt, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("CreateReport(ctx, arg) unexpected difference (-want +got):\n%s", diff)
+			}
+
+			if time.Since(arg.StartedAt) > time.Second {
+				t.Errorf("arg.StartedAt = %v, want about now", arg.StartedAt)
+			}
+
+			return domain.ReconciliationReport{
+				ID:            1,
+				Accounts:      arg.Accounts,
+				Transfers:     arg.Transfers,
+				Discrepancies: arg.Discrepancies,
+			}, nil
+		}
+	}
+
+	testCases := []struct {
+		name         string
+		buildStubs   func(t *testing.T, repo *MockRepo)
+		wantBalanced bool
+		wantErr      error
+	}{
+		{
+			name: "Balanced",
+			buildStubs: func(t *testing.T, repo *MockRepo) {
+				repo.EXPECT().Count(gomock.Any()).Times(1).Return(int64(3), int64(5), nil)
+				repo.EXPECT().ListBalanceDiscrepancies(gomock.Any()).Times(1).Return([]domain.Discrepancy{}, nil)
+				repo.EXPECT().ListTransferDiscrepancies(gomock.Any()).Times(1).Return([]domain.Discrepancy{}, nil)
+				repo.EXPECT().CreateReport(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(createReport(t, domain.CreateReconciliationReportParams{Accounts: 3, Transfers: 5}))
+			},
+			wantBalanced: true,
+		},
+		{
+			name: "Discrepancies",
+			buildStubs: func(t *testing.T, repo *MockRepo) {
+				want := domain.CreateReconciliationReportParams{
+					Accounts:      3,
+					Transfers:     5,
+					Discrepancies: []domain.Discrepancy{balance, entries},
+				}
+
+				repo.EXPECT().Count(gomock.Any()).Times(1).Return(int64(3), int64(5), nil)
+				repo.EXPECT().ListBalanceDiscrepancies(gomock.Any()).Times(1).Return([]domain.Discrepancy{balance}, nil)
+				repo.EXPECT().ListTransferDiscrepancies(gomock.Any()).Times(1).Return([]domain.Discrepancy{entries}, nil)
+				repo.EXPECT().CreateReport(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(createReport(t, want))
+			},
+		},
+		{
+			name: "ListError",
+			buildStubs: func(t *testing.T, repo *MockRepo) {
+				repo.EXPECT().Count(gomock.Any()).Times(1).Return(int64(3), int64(5), nil)
+				repo.EXPECT().ListBalanceDiscrepancies(gomock.Any()).Times(1).Return(nil, errorspkg.ErrInternal)
+				repo.EXPECT().ListTransferDiscrepancies(gomock.Any()).Times(0)
+				repo.EXPECT().CreateReport(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: errorspkg.ErrInternal,
+		},
+		{
+			name: "CreateReportError",
+			buildStubs: func(t *testing.T, repo *MockRepo) {
+				repo.EXPECT().Count(gomock.Any()).Times(1).Return(int64(3), int64(5), nil)
+				repo.EXPECT().ListBalanceDiscrepancies(gomock.Any()).Times(1).Return([]domain.Discrepancy{}, nil)
+				repo.EXPECT().ListTransferDiscrepancies(gomock.Any()).Times(1).Return([]domain.Discrepancy{}, nil)
+				repo.EXPECT().CreateReport(gomock.Any(), gomock.Any()).Times(1).
+					Return(domain.ReconciliationReport{}, errorspkg.ErrInternal)
+			},
+			wantErr: errorspkg.ErrInternal,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepo(ctrl)
+			tc.buildStubs(t, repo)
+
+			got, err := New(repo).Run(context.Background())
+			if err != tc.wantErr {
+				t.Fatalf("Run(ctx) returned error: %v, want %v", err, tc.wantErr)
+			}
+
+			if err == nil && got.Balanced() != tc.wantBalanced {
+				t.Errorf("Run(ctx).Balanced() = %v, want %v", got.Balanced(), tc.wantBalanced)
+			}
+		})
+	}
+}
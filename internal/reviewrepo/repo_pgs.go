@@ -0,0 +1,255 @@
+// Package reviewrepo manages repository layer of the transfers held for
+// review by the transaction monitoring.
+package reviewrepo
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/dbpkg"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog"
+)
+
+// RepoPGS facilitates transfer review repository layer logic.
+type RepoPGS struct {
+	db dbpkg.SQLInterface
+}
+
+// NewRepoPGS returns transfer review RepoPGS.
+func NewRepoPGS(db dbpkg.SQLInterface) *RepoPGS {
+	return &RepoPGS{
+		db: db,
+	}
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanReview(row scanner) (domain.TransferReview, error) {
+	var (
+		r          domain.TransferReview
+		transferID sql.NullInt64
+		reviewer   sql.NullString
+		reviewedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&r.ID,
+		&r.Username,
+		&r.FromAccountID,
+		&r.ToAccountID,
+		&r.Amount,
+		pq.Array(&r.Rules),
+		&r.Status,
+		&transferID,
+		&reviewer,
+		&reviewedAt,
+		&r.CreatedAt,
+		&r.Description,
+		&r.Reference,
+		(*dbpkg.JSONMap)(&r.Metadata),
+	)
+
+	r.TransferID = transferID.Int64
+	r.Reviewer = reviewer.String
+
+	if reviewedAt.Valid {
+		r.ReviewedAt = &reviewedAt.Time
+	}
+
+	return r, err
+}
+
+const createQuery = `
+INSERT INTO
+    transfer_reviews (
+        username, from_account_id, to_account_id, amount, rules, description, reference, metadata
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, username, from_account_id, to_account_id, amount, rules, status, transfer_id,
+    reviewer, reviewed_at, created_at, description, reference, metadata
+`
+
+// Create creates the pending transfer review and then returns it. The held
+// amount of the from account is changed by the caller.
+func (r *RepoPGS) Create(ctx context.Context, arg domain.CreateTransferReviewParams) (domain.TransferReview, error) {
+	l := zerolog.Ctx(ctx)
+
+	row := r.db.QueryRowContext(ctx, createQuery,
+		arg.Username,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		pq.Array(arg.Rules),
+		arg.Description,
+		arg.Reference,
+		dbpkg.JSONMap(arg.Metadata),
+	)
+
+	review, err := scanReview(row)
+	if err != nil {
+		l.Error().Err(err).Send()
+
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Constraint {
+			case "transfer_reviews_from_account_id_fkey", "transfer_reviews_to_account_id_fkey":
+				return review, domain.ErrAccountNotFound
+			case "transfer_reviews_username_fkey":
+				return review, domain.ErrUserNotFound
+			case "transfer_reviews_amount_check":
+				return review, domain.ErrInvalidAmount
+			}
+		}
+
+		return review, errorspkg.ErrInternal
+	}
+
+	return review, nil
+}
+
+const getQuery = `
+SELECT
+	id, username, from_account_id, to_account_id, amount, rules, status, transfer_id,
+	reviewer, reviewed_at, created_at, description, reference, metadata
+FROM transfer_reviews
+WHERE id = $1
+`
+
+// Get returns the transfer review with the given id.
+func (r *RepoPGS) Get(ctx context.Context, id int64) (domain.TransferReview, error) {
+	return r.get(ctx, getQuery, id)
+}
+
+const getForUpdateQuery = `
+SELECT
+	id, username, from_account_id, to_account_id, amount, rules, status, transfer_id,
+	reviewer, reviewed_at, created_at, description, reference, metadata
+FROM transfer_reviews
+WHERE id = $1
+FOR UPDATE
+`
+
+// GetForUpdate returns the transfer review with the given id and locks its
+// row until the end of the current transaction.
+func (r *RepoPGS) GetForUpdate(ctx context.Context, id int64) (domain.TransferReview, error) {
+	return r.get(ctx, getForUpdateQuery, id)
+}
+
+func (r *RepoPGS) get(ctx context.Context, query string, id int64) (domain.TransferReview, error) {
+	l := zerolog.Ctx(ctx)
+
+	review, err := scanReview(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		l.Error().Err(err).Send()
+
+		if err == sql.ErrNoRows {
+			return review, domain.ErrTransferReviewNotFound
+		}
+
+		return review, errorspkg.ErrInternal
+	}
+
+	return review, nil
+}
+
+const listQuery = `
+SELECT
+	id, username, from_account_id, to_account_id, amount, rules, status, transfer_id,
+	reviewer, reviewed_at, created_at, description, reference, metadata
+FROM transfer_reviews
+WHERE ($1 = '' OR status = $1)
+    AND ($2 = 0 OR id > $2)
+    AND ($3 = 0 OR id < $3)
+ORDER BY CASE WHEN $3 = 0 THEN id END, id DESC
+LIMIT $4 OFFSET $5
+`
+
+// List returns the specified number of transfer reviews with arg.Status, or
+// all of them if it is empty, ordered by id.
+//
+// If arg.BeforeID is set, the reviews right before it are returned.
+func (r *RepoPGS) List(ctx context.Context, arg domain.ListTransferReviewsParams) ([]domain.TransferReview, error) {
+	l := zerolog.Ctx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, listQuery,
+		arg.Status,
+		arg.AfterID,
+		arg.BeforeID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+	defer rows.Close()
+
+	items := []domain.TransferReview{}
+
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			l.Error().Err(err).Send()
+			return nil, errorspkg.ErrInternal
+		}
+
+		items = append(items, review)
+	}
+
+	if err := rows.Close(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	if err := rows.Err(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	if arg.BeforeID != 0 {
+		pagepkg.Reverse(items)
+	}
+
+	return items, nil
+}
+
+const closeQuery = `
+UPDATE transfer_reviews
+SET status = $2, reviewer = $3, transfer_id = $4, reviewed_at = now()
+WHERE id = $1 AND status = 'pending_review'
+RETURNING id, username, from_account_id, to_account_id, amount, rules, status, transfer_id,
+    reviewer, reviewed_at, created_at, description, reference, metadata
+`
+
+// Close sets the final status of the pending transfer review and returns it.
+// It returns domain.ErrTransferReviewClosed if the review has already been
+// closed. The held amount of the from account is changed by the caller.
+func (r *RepoPGS) Close(ctx context.Context, arg domain.CloseTransferReviewParams) (domain.TransferReview, error) {
+	l := zerolog.Ctx(ctx)
+
+	row := r.db.QueryRowContext(ctx, closeQuery,
+		arg.ID,
+		arg.Status,
+		arg.Reviewer,
+		sql.NullInt64{Int64: arg.TransferID, Valid: arg.TransferID != 0},
+	)
+
+	review, err := scanReview(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return review, domain.ErrTransferReviewClosed
+		}
+
+		l.Error().Err(err).Send()
+
+		return review, errorspkg.ErrInternal
+	}
+
+	return review, nil
+}
@@ -0,0 +1,115 @@
+//go:build integration
+
+package reviewrepo_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/integrationtest"
+	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
+	"github.com/go-petr/pet-bank/internal/reviewrepo"
+	"github.com/go-petr/pet-bank/pkg/configpkg"
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	dbDriver string
+	dbSource string
+)
+
+func TestMain(m *testing.M) {
+	config, err := configpkg.Load("../../configs")
+	if err != nil {
+		log.Fatal("cannot load config:", err)
+	}
+
+	dbDriver = config.DBDriver
+	dbSource = config.DBSource
+
+	os.Exit(m.Run())
+}
+
+func TestReviews(t *testing.T) {
+	t.Parallel()
+
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	reviewRepo := reviewrepo.NewRepoPGS(tx)
+	ctx := context.Background()
+
+	user := helpers.SeedUser(t, tx)
+	from := helpers.SeedAccountWith1000USDBalance(t, tx, user.Username)
+	to := helpers.SeedAccountWith1000USDBalance(t, tx, helpers.SeedUser(t, tx).Username)
+
+	arg := domain.CreateTransferReviewParams{
+		Username:      user.Username,
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        "9900",
+		Rules:         []string{"round_amount", "new_payees"},
+		Memo:          domain.Memo{Description: "rent", Metadata: map[string]any{"invoice": "42"}},
+	}
+
+	review, err := reviewRepo.Create(ctx, arg)
+	if err != nil {
+		t.Fatalf("reviewRepo.Create(ctx, %+v) returned error: %v", arg, err)
+	}
+
+	want := domain.TransferReview{
+		ID:            review.ID,
+		Username:      arg.Username,
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		Rules:         arg.Rules,
+		Status:        domain.TransferReviewStatusPending,
+		CreatedAt:     review.CreatedAt,
+		Memo:          arg.Memo,
+	}
+
+	if diff := cmp.Diff(want, review); diff != "" {
+		t.Errorf("reviewRepo.Create(ctx, %+v) returned unexpected difference (-want +got):\n%s", arg, diff)
+	}
+
+	got, err := reviewRepo.Get(ctx, review.ID)
+	if err != nil {
+		t.Fatalf("reviewRepo.Get(ctx, %v) returned error: %v", review.ID, err)
+	}
+
+	if diff := cmp.Diff(review, got); diff != "" {
+		t.Errorf("reviewRepo.Get(ctx, %v) returned unexpected difference (-want +got):\n%s", review.ID, diff)
+	}
+
+	list := domain.ListTransferReviewsParams{Status: domain.TransferReviewStatusPending, Limit: 1000}
+
+	reviews, err := reviewRepo.List(ctx, list)
+	if err != nil {
+		t.Fatalf("reviewRepo.List(ctx, %+v) returned error: %v", list, err)
+	}
+
+	if len(reviews) == 0 || reviews[len(reviews)-1].ID != review.ID {
+		t.Errorf("reviewRepo.List(ctx, %+v) doesn't end with review %v", list, review.ID)
+	}
+
+	closeArg := domain.CloseTransferReviewParams{ID: review.ID, Status: domain.TransferReviewStatusRejected, Reviewer: "admin"}
+
+	closed, err := reviewRepo.Close(ctx, closeArg)
+	if err != nil {
+		t.Fatalf("reviewRepo.Close(ctx, %+v) returned error: %v", closeArg, err)
+	}
+
+	if closed.Status != domain.TransferReviewStatusRejected || closed.Reviewer != "admin" || closed.ReviewedAt == nil {
+		t.Errorf("reviewRepo.Close(ctx, %+v) returned %+v", closeArg, closed)
+	}
+
+	if _, err := reviewRepo.Close(ctx, closeArg); err != domain.ErrTransferReviewClosed {
+		t.Errorf("reviewRepo.Close(ctx, %+v) returned error: %v, want %v", closeArg, err, domain.ErrTransferReviewClosed)
+	}
+
+	if _, err := reviewRepo.Get(ctx, -1); err != domain.ErrTransferReviewNotFound {
+		t.Errorf("reviewRepo.Get(ctx, -1) returned error: %v, want %v", err, domain.ErrTransferReviewNotFound)
+	}
+}
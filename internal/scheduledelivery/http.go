@@ -0,0 +1,274 @@
+// Package scheduledelivery manages delivery layer of scheduled transfers.
+package scheduledelivery
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/middleware"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
+	"github.com/go-petr/pet-bank/pkg/tokenpkg"
+	"github.com/go-petr/pet-bank/pkg/web"
+)
+
+// Service provides service layer interface needed by scheduled transfer delivery layer.
+//
+//go:generate mockgen -source http.go -destination http_mock.go -package scheduledelivery
+type Service interface {
+	Create(ctx context.Context, username string, arg domain.CreateScheduledTransferParams) (domain.ScheduledTransfer, error)
+	Get(ctx context.Context, username string, id int64) (domain.ScheduledTransfer, error)
+	List(ctx context.Context, username string, page pagepkg.Request) ([]domain.ScheduledTransfer, pagepkg.Page, error)
+	ListRuns(ctx context.Context, username string, id int64, page pagepkg.Request) ([]domain.ScheduledTransferRun, pagepkg.Page, error)
+	Pause(ctx context.Context, username string, id int64) (domain.ScheduledTransfer, error)
+	Resume(ctx context.Context, username string, id int64) (domain.ScheduledTransfer, error)
+	Cancel(ctx context.Context, username string, id int64) (domain.ScheduledTransfer, error)
+}
+
+// Handler facilitates scheduled transfer delivery layer logic.
+type Handler struct {
+	service Service
+}
+
+// NewHandler returns scheduled transfer handler.
+func NewHandler(ss Service) *Handler {
+	return &Handler{
+		service: ss,
+	}
+}
+
+func (h *Handler) serviceError(gctx *gin.Context, err error) {
+	zerolog.Ctx(gctx.Request.Context()).Info().Err(err).Send()
+
+	switch err {
+	case
+		domain.ErrInvalidOwner,
+		domain.ErrScheduledTransferOwnerMismatch:
+		gctx.JSON(http.StatusUnauthorized, web.Error(err))
+		return
+	case
+		domain.ErrAccountNotFound,
+		domain.ErrScheduledTransferNotFound:
+		gctx.JSON(http.StatusNotFound, web.Error(err))
+		return
+	case domain.ErrScheduledTransferStatus:
+		gctx.JSON(http.StatusConflict, web.Error(err))
+		return
+	case
+		domain.ErrInvalidAmount,
+		domain.ErrNegativeAmount,
+		domain.ErrCurrencyMismatch,
+		domain.ErrInvalidSchedule:
+		gctx.JSON(http.StatusBadRequest, web.Error(err))
+		return
+	}
+
+	gctx.JSON(http.StatusInternalServerError, web.Error(errorspkg.ErrInternal))
+}
+
+func (h *Handler) bindError(gctx *gin.Context, err error) {
+	zerolog.Ctx(gctx.Request.Context()).Info().Err(err).Send()
+
+	var ve validator.ValidationErrors
+	if errors.As(err, &ve) {
+		gctx.JSON(http.StatusBadRequest, web.Response{Error: web.GetErrorMsg(ve)})
+
+		return
+	}
+
+	gctx.JSON(http.StatusBadRequest, web.Error(err))
+}
+
+type scheduledTransferResponse struct {
+	ScheduledTransfer domain.ScheduledTransfer `json:"scheduled_transfer"`
+}
+
+type createRequest struct {
+	FromAccountID int32     `json:"from_account_id" binding:"required,min=1"`
+	ToAccountID   int32     `json:"to_account_id" binding:"required,min=1"`
+	Amount        string    `json:"amount" binding:"required"`
+	StartAt       time.Time `json:"start_at"`
+	Recurrence    string    `json:"recurrence"`
+}
+
+// Create handles http request to schedule a one-off or recurring transfer
+// from the user's account.
+func (h *Handler) Create(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	var req createRequest
+	if err := gctx.ShouldBindJSON(&req); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	arg := domain.CreateScheduledTransferParams{
+		FromAccountID: req.FromAccountID,
+		ToAccountID:   req.ToAccountID,
+		Amount:        req.Amount,
+		StartAt:       req.StartAt,
+		Recurrence:    req.Recurrence,
+	}
+
+	st, err := h.service.Create(ctx, authPayload.Username, arg)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	gctx.JSON(http.StatusCreated, web.Response{Data: scheduledTransferResponse{ScheduledTransfer: st}})
+}
+
+type idRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// Get handles http request to get the user's scheduled transfer.
+func (h *Handler) Get(gctx *gin.Context) {
+	h.byID(gctx, http.StatusOK, h.service.Get)
+}
+
+// Pause handles http request to pause the user's scheduled transfer.
+func (h *Handler) Pause(gctx *gin.Context) {
+	h.byID(gctx, http.StatusOK, h.service.Pause)
+}
+
+// Resume handles http request to resume the user's paused scheduled transfer.
+func (h *Handler) Resume(gctx *gin.Context) {
+	h.byID(gctx, http.StatusOK, h.service.Resume)
+}
+
+// Cancel handles http request to cancel the user's scheduled transfer.
+func (h *Handler) Cancel(gctx *gin.Context) {
+	h.byID(gctx, http.StatusOK, h.service.Cancel)
+}
+
+type byIDFunc func(ctx context.Context, username string, id int64) (domain.ScheduledTransfer, error)
+
+func (h *Handler) byID(gctx *gin.Context, status int, do byIDFunc) {
+	ctx := gctx.Request.Context()
+
+	var uri idRequest
+	if err := gctx.ShouldBindUri(&uri); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	st, err := do(ctx, authPayload.Username, uri.ID)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	gctx.JSON(status, web.Response{Data: scheduledTransferResponse{ScheduledTransfer: st}})
+}
+
+type listRequest struct {
+	PageID    int32  `form:"page_id" binding:"required_without=PageToken,omitempty,min=1"`
+	PageSize  int32  `form:"page_size" binding:"required,min=1,max=100"`
+	PageToken string `form:"page_token"`
+}
+
+func (h *Handler) pageRequest(gctx *gin.Context) (pagepkg.Request, bool) {
+	var req listRequest
+	if err := gctx.ShouldBindQuery(&req); err != nil {
+		h.bindError(gctx, err)
+		return pagepkg.Request{}, false
+	}
+
+	pageReq := pagepkg.Request{PageID: req.PageID, PageSize: req.PageSize}
+
+	if req.PageToken != "" {
+		cursor, err := pagepkg.Parse(req.PageToken)
+		if err != nil {
+			h.bindError(gctx, err)
+			return pagepkg.Request{}, false
+		}
+
+		pageReq.Cursor = cursor
+	}
+
+	return pageReq, true
+}
+
+// List handles http request to list the user's scheduled transfers.
+//
+// The page is located by page_token if it is set, otherwise by page_id.
+func (h *Handler) List(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	pageReq, ok := h.pageRequest(gctx)
+	if !ok {
+		return
+	}
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	items, page, err := h.service.List(ctx, authPayload.Username, pageReq)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	res := web.Response{
+		Data: struct {
+			ScheduledTransfers []domain.ScheduledTransfer `json:"scheduled_transfers"`
+		}{
+			ScheduledTransfers: items,
+		},
+		NextCursor: page.Next,
+		PrevCursor: page.Prev,
+	}
+
+	gctx.JSON(http.StatusOK, res)
+}
+
+// ListRuns handles http request to list the runs of the user's scheduled
+// transfer.
+//
+// The page is located by page_token if it is set, otherwise by page_id.
+func (h *Handler) ListRuns(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	var uri idRequest
+	if err := gctx.ShouldBindUri(&uri); err != nil {
+		h.bindError(gctx, err)
+		return
+	}
+
+	pageReq, ok := h.pageRequest(gctx)
+	if !ok {
+		return
+	}
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	runs, page, err := h.service.ListRuns(ctx, authPayload.Username, uri.ID, pageReq)
+	if err != nil {
+		h.serviceError(gctx, err)
+		return
+	}
+
+	res := web.Response{
+		Data: struct {
+			Runs []domain.ScheduledTransferRun `json:"runs"`
+		}{
+			Runs: runs,
+		},
+		NextCursor: page.Next,
+		PrevCursor: page.Prev,
+	}
+
+	gctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: http.go
+
+// Package scheduledelivery is a generated GoMock package.
+package scheduledelivery
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/go-petr/pet-bank/internal/domain"
+	pagepkg "github.com/go-petr/pet-bank/pkg/pagepkg"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockService) Cancel(ctx context.Context, username string, id int64) (domain.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, username, id)
+	ret0, _ := ret[0].(domain.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockServiceMockRecorder) Cancel(ctx, username, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockService)(nil).Cancel), ctx, username, id)
+}
+
+// Create mocks base method.
+func (m *MockService) Create(ctx context.Context, username string, arg domain.CreateScheduledTransferParams) (domain.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, username, arg)
+	ret0, _ := ret[0].(domain.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockServiceMockRecorder) Create(ctx, username, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockService)(nil).Create), ctx, username, arg)
+}
+
+// Get mocks base method.
+func (m *MockService) Get(ctx context.Context, username string, id int64) (domain.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, username, id)
+	ret0, _ := ret[0].(domain.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockServiceMockRecorder) Get(ctx, username, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockService)(nil).Get), ctx, username, id)
+}
+
+// List mocks base method.
+func (m *MockService) List(ctx context.Context, username string, page pagepkg.Request) ([]domain.ScheduledTransfer, pagepkg.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, username, page)
+	ret0, _ := ret[0].([]domain.ScheduledTransfer)
+	ret1, _ := ret[1].(pagepkg.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockServiceMockRecorder) List(ctx, username, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List), ctx, username, page)
+}
+
+// ListRuns mocks base method.
+func (m *MockService) ListRuns(ctx context.Context, username string, id int64, page pagepkg.Request) ([]domain.ScheduledTransferRun, pagepkg.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRuns", ctx, username, id, page)
+	ret0, _ := ret[0].([]domain.ScheduledTransferRun)
+	ret1, _ := ret[1].(pagepkg.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListRuns indicates an expected call of ListRuns.
+func (mr *MockServiceMockRecorder) ListRuns(ctx, username, id, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRuns", reflect.TypeOf((*MockService)(nil).ListRuns), ctx, username, id, page)
+}
+
+// Pause mocks base method.
+func (m *MockService) Pause(ctx context.Context, username string, id int64) (domain.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pause", ctx, username, id)
+	ret0, _ := ret[0].(domain.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pause indicates an expected call of Pause.
+func (mr *MockServiceMockRecorder) Pause(ctx, username, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pause", reflect.TypeOf((*MockService)(nil).Pause), ctx, username, id)
+}
+
+// Resume mocks base method.
+func (m *MockService) Resume(ctx context.Context, username string, id int64) (domain.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resume", ctx, username, id)
+	ret0, _ := ret[0].(domain.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resume indicates an expected call of Resume.
+func (mr *MockServiceMockRecorder) Resume(ctx, username, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockService)(nil).Resume), ctx, username, id)
+}
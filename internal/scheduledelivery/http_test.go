@@ -0,0 +1,323 @@
+package scheduledelivery
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/middleware"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/go-petr/pet-bank/pkg/tokenpkg"
+	"github.com/go-petr/pet-bank/pkg/web"
+)
+
+func newServer(t *testing.T, tokenMaker tokenpkg.Maker, handler *Handler) *gin.Engine {
+	t.Helper()
+
+	gin.SetMode(gin.ReleaseMode)
+	server := gin.New()
+	server.Use(middleware.AuthMiddleware(tokenMaker, nil))
+	server.POST("/scheduled-transfers", handler.Create)
+	server.GET("/scheduled-transfers", handler.List)
+	server.GET("/scheduled-transfers/:id", handler.Get)
+	server.GET("/scheduled-transfers/:id/runs", handler.ListRuns)
+	server.POST("/scheduled-transfers/:id/pause", handler.Pause)
+	server.POST("/scheduled-transfers/:id/resume", handler.Resume)
+	server.POST("/scheduled-transfers/:id/cancel", handler.Cancel)
+
+	return server
+}
+
+func TestHandler(t *testing.T) {
+	username := randompkg.Owner()
+	symmetricKey := randompkg.String(32)
+
+	tokenMaker, err := tokenpkg.NewPasetoMaker(symmetricKey)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(%v) returned error: %v", symmetricKey, err)
+	}
+
+	start := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	st := domain.ScheduledTransfer{
+		ID:            1,
+		Username:      username,
+		FromAccountID: 1,
+		ToAccountID:   2,
+		Amount:        "100",
+		Recurrence:    "@monthly",
+		StartAt:       start,
+		NextRunAt:     &start,
+		Status:        domain.ScheduledTransferStatusActive,
+		CreatedAt:     time.Now().UTC().Truncate(time.Second),
+	}
+
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           any
+		buildStubs     func(service *MockService)
+		wantStatusCode int
+		wantError      string
+	}{
+		{
+			name:   "Create",
+			method: http.MethodPost,
+			url:    "/scheduled-transfers",
+			body: gin.H{
+				"from_account_id": 1,
+				"to_account_id":   2,
+				"amount":          "100",
+				"start_at":        start.Format(time.RFC3339),
+				"recurrence":      "@monthly",
+			},
+			buildStubs: func(service *MockService) {
+				arg := domain.CreateScheduledTransferParams{
+					FromAccountID: 1,
+					ToAccountID:   2,
+					Amount:        "100",
+					StartAt:       start,
+					Recurrence:    "@monthly",
+				}
+				service.EXPECT().Create(gomock.Any(), gomock.Eq(username), gomock.Eq(arg)).Times(1).Return(st, nil)
+			},
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name:   "CreateRequiresAmount",
+			method: http.MethodPost,
+			url:    "/scheduled-transfers",
+			body:   gin.H{"from_account_id": 1, "to_account_id": 2},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "Amount field is required",
+		},
+		{
+			name:   "CreateErrInvalidSchedule",
+			method: http.MethodPost,
+			url:    "/scheduled-transfers",
+			body:   gin.H{"from_account_id": 1, "to_account_id": 2, "amount": "100", "recurrence": "daily"},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.ScheduledTransfer{}, domain.ErrInvalidSchedule)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      domain.ErrInvalidSchedule.Error(),
+		},
+		{
+			name:   "Get",
+			method: http.MethodGet,
+			url:    "/scheduled-transfers/1",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Get(gomock.Any(), gomock.Eq(username), gomock.Eq(int64(1))).Times(1).Return(st, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "GetErrScheduledTransferOwnerMismatch",
+			method: http.MethodGet,
+			url:    "/scheduled-transfers/1",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.ScheduledTransfer{}, domain.ErrScheduledTransferOwnerMismatch)
+			},
+			wantStatusCode: http.StatusUnauthorized,
+			wantError:      domain.ErrScheduledTransferOwnerMismatch.Error(),
+		},
+		{
+			name:   "Pause",
+			method: http.MethodPost,
+			url:    "/scheduled-transfers/1/pause",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Pause(gomock.Any(), gomock.Eq(username), gomock.Eq(int64(1))).Times(1).Return(st, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "Resume",
+			method: http.MethodPost,
+			url:    "/scheduled-transfers/1/resume",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Resume(gomock.Any(), gomock.Eq(username), gomock.Eq(int64(1))).Times(1).Return(st, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "CancelErrScheduledTransferStatus",
+			method: http.MethodPost,
+			url:    "/scheduled-transfers/1/cancel",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Cancel(gomock.Any(), gomock.Eq(username), gomock.Eq(int64(1))).
+					Times(1).
+					Return(domain.ScheduledTransfer{}, domain.ErrScheduledTransferStatus)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantError:      domain.ErrScheduledTransferStatus.Error(),
+		},
+		{
+			name:   "CancelErrScheduledTransferNotFound",
+			method: http.MethodPost,
+			url:    "/scheduled-transfers/1/cancel",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Cancel(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.ScheduledTransfer{}, domain.ErrScheduledTransferNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      domain.ErrScheduledTransferNotFound.Error(),
+		},
+		{
+			name:   "CancelErrInternal",
+			method: http.MethodPost,
+			url:    "/scheduled-transfers/1/cancel",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Cancel(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.ScheduledTransfer{}, errorspkg.ErrInternal)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      errorspkg.ErrInternal.Error(),
+		},
+		{
+			name:   "InvalidID",
+			method: http.MethodPost,
+			url:    "/scheduled-transfers/0/pause",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Pause(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "ID field is required",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewMockService(ctrl)
+			tc.buildStubs(service)
+			server := newServer(t, tokenMaker, NewHandler(service))
+
+			var body []byte
+			if tc.body != nil {
+				if body, err = json.Marshal(tc.body); err != nil {
+					t.Fatalf("Encoding request body error: %v", err)
+				}
+			}
+
+			req, err := http.NewRequest(tc.method, tc.url, bytes.NewReader(body))
+			if err != nil {
+				t.Fatalf("Creating request error: %v", err)
+			}
+
+			if err := middleware.AddAuthorization(req, tokenMaker, middleware.AuthTypeBearer, username, time.Minute); err != nil {
+				t.Fatalf("middleware.AddAuthorization(...) returned error: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			if got := w.Code; got != tc.wantStatusCode {
+				t.Errorf("Status code: got %v, want %v", got, tc.wantStatusCode)
+			}
+
+			data := &scheduledTransferResponse{}
+			res := web.Response{Data: data}
+
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Fatalf("Decoding response body error: %v", err)
+			}
+
+			if res.Error != tc.wantError {
+				t.Errorf(`res.Error=%q, want %q`, res.Error, tc.wantError)
+			}
+
+			if tc.wantError == "" {
+				if diff := cmp.Diff(st, data.ScheduledTransfer); diff != "" {
+					t.Errorf("Response returned unexpected diff: %s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestListRuns(t *testing.T) {
+	username := randompkg.Owner()
+	symmetricKey := randompkg.String(32)
+
+	tokenMaker, err := tokenpkg.NewPasetoMaker(symmetricKey)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(%v) returned error: %v", symmetricKey, err)
+	}
+
+	runs := []domain.ScheduledTransferRun{
+		{
+			ID:                  1,
+			ScheduledTransferID: 1,
+			ScheduledAt:         time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC),
+			Status:              domain.ScheduledRunStatusFailed,
+			Error:               domain.ErrInsufficientBalance.Error(),
+		},
+	}
+	next := pagepkg.Cursor{AfterID: 1}.Token()
+
+	ctrl := gomock.NewController(t)
+	service := NewMockService(ctrl)
+	service.EXPECT().ListRuns(gomock.Any(), gomock.Eq(username), gomock.Eq(int64(1)), gomock.Eq(pagepkg.Request{PageID: 1, PageSize: 1})).
+		Times(1).
+		Return(runs, pagepkg.Page{Next: next}, nil)
+
+	server := newServer(t, tokenMaker, NewHandler(service))
+
+	req, err := http.NewRequest(http.MethodGet, "/scheduled-transfers/1/runs?page_id=1&page_size=1", nil)
+	if err != nil {
+		t.Fatalf("Creating request error: %v", err)
+	}
+
+	if err := middleware.AddAuthorization(req, tokenMaker, middleware.AuthTypeBearer, username, time.Minute); err != nil {
+		t.Fatalf("middleware.AddAuthorization(...) returned error: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Status code: got %v, want %v", w.Code, http.StatusOK)
+	}
+
+	data := &struct {
+		Runs []domain.ScheduledTransferRun `json:"runs"`
+	}{}
+	res := web.Response{Data: data}
+
+	if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+		t.Fatalf("Decoding response body error: %v", err)
+	}
+
+	if diff := cmp.Diff(runs, data.Runs); diff != "" {
+		t.Errorf("Response returned unexpected diff: %s", diff)
+	}
+
+	if res.NextCursor != next {
+		t.Errorf("res.NextCursor = %q, want %q", res.NextCursor, next)
+	}
+}
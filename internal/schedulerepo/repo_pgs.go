@@ -0,0 +1,500 @@
+// Package schedulerepo manages repository layer of scheduled transfers.
+package schedulerepo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/dbpkg"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog"
+)
+
+// RepoPGS facilitates scheduled transfer repository layer logic.
+type RepoPGS struct {
+	db dbpkg.SQLInterface
+}
+
+// NewRepoPGS returns scheduled transfer RepoPGS.
+func NewRepoPGS(db dbpkg.SQLInterface) *RepoPGS {
+	return &RepoPGS{
+		db: db,
+	}
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanScheduledTransfer(row scanner) (domain.ScheduledTransfer, error) {
+	var (
+		st        domain.ScheduledTransfer
+		nextRunAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&st.ID,
+		&st.Username,
+		&st.FromAccountID,
+		&st.ToAccountID,
+		&st.Amount,
+		&st.Recurrence,
+		&st.StartAt,
+		&nextRunAt,
+		&st.Status,
+		&st.CreatedAt,
+	)
+
+	if nextRunAt.Valid {
+		st.NextRunAt = &nextRunAt.Time
+	}
+
+	return st, err
+}
+
+func scanRun(row scanner) (domain.ScheduledTransferRun, error) {
+	var (
+		run        domain.ScheduledTransferRun
+		transferID sql.NullInt64
+		reviewID   sql.NullInt64
+		finishedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&run.ID,
+		&run.ScheduledTransferID,
+		&run.ScheduledAt,
+		&run.Status,
+		&transferID,
+		&reviewID,
+		&run.Error,
+		&finishedAt,
+		&run.CreatedAt,
+	)
+
+	run.TransferID = transferID.Int64
+	run.TransferReviewID = reviewID.Int64
+
+	if finishedAt.Valid {
+		run.FinishedAt = &finishedAt.Time
+	}
+
+	return run, err
+}
+
+const createQuery = `
+INSERT INTO
+    scheduled_transfers (username, from_account_id, to_account_id, amount, recurrence, start_at, next_run_at)
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, username, from_account_id, to_account_id, amount, recurrence, start_at,
+    next_run_at, status, created_at
+`
+
+// Create creates the active scheduled transfer and then returns it.
+func (r *RepoPGS) Create(ctx context.Context, arg domain.CreateScheduledTransferParams) (domain.ScheduledTransfer, error) {
+	l := zerolog.Ctx(ctx)
+
+	row := r.db.QueryRowContext(ctx, createQuery,
+		arg.Username,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Recurrence,
+		arg.StartAt,
+		arg.NextRunAt,
+	)
+
+	st, err := scanScheduledTransfer(row)
+	if err != nil {
+		l.Error().Err(err).Send()
+
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Constraint {
+			case "scheduled_transfers_username_fkey":
+				return st, domain.ErrUserNotFound
+			case "scheduled_transfers_from_account_id_fkey", "scheduled_transfers_to_account_id_fkey":
+				return st, domain.ErrAccountNotFound
+			case "scheduled_transfers_amount_check":
+				return st, domain.ErrInvalidAmount
+			}
+		}
+
+		return st, errorspkg.ErrInternal
+	}
+
+	return st, nil
+}
+
+const getQuery = `
+SELECT
+	id, username, from_account_id, to_account_id, amount, recurrence, start_at,
+	next_run_at, status, created_at
+FROM scheduled_transfers
+WHERE id = $1
+`
+
+// Get returns the scheduled transfer with the given id.
+func (r *RepoPGS) Get(ctx context.Context, id int64) (domain.ScheduledTransfer, error) {
+	l := zerolog.Ctx(ctx)
+
+	st, err := scanScheduledTransfer(r.db.QueryRowContext(ctx, getQuery, id))
+	if err != nil {
+		l.Error().Err(err).Send()
+
+		if err == sql.ErrNoRows {
+			return st, domain.ErrScheduledTransferNotFound
+		}
+
+		return st, errorspkg.ErrInternal
+	}
+
+	return st, nil
+}
+
+const listQuery = `
+SELECT
+	id, username, from_account_id, to_account_id, amount, recurrence, start_at,
+	next_run_at, status, created_at
+FROM scheduled_transfers
+WHERE username = $1
+    AND ($2 = 0 OR id > $2)
+    AND ($3 = 0 OR id < $3)
+ORDER BY CASE WHEN $3 = 0 THEN id END, id DESC
+LIMIT $4 OFFSET $5
+`
+
+// List returns the specified number of the user's scheduled transfers ordered
+// by id.
+//
+// If arg.BeforeID is set, the scheduled transfers right before it are returned.
+func (r *RepoPGS) List(ctx context.Context, arg domain.ListScheduledTransfersParams) ([]domain.ScheduledTransfer, error) {
+	l := zerolog.Ctx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, listQuery,
+		arg.Username,
+		arg.AfterID,
+		arg.BeforeID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+	defer rows.Close()
+
+	items := []domain.ScheduledTransfer{}
+
+	for rows.Next() {
+		st, err := scanScheduledTransfer(rows)
+		if err != nil {
+			l.Error().Err(err).Send()
+			return nil, errorspkg.ErrInternal
+		}
+
+		items = append(items, st)
+	}
+
+	if err := rows.Close(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	if err := rows.Err(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	if arg.BeforeID != 0 {
+		pagepkg.Reverse(items)
+	}
+
+	return items, nil
+}
+
+const updateStatusQuery = `
+UPDATE scheduled_transfers
+SET status = $3, next_run_at = $4
+WHERE id = $1 AND status = $2
+RETURNING id, username, from_account_id, to_account_id, amount, recurrence, start_at,
+    next_run_at, status, created_at
+`
+
+// UpdateStatus changes the status and the next run time of the scheduled
+// transfer and returns it. It returns domain.ErrScheduledTransferStatus if
+// the scheduled transfer is no longer in the arg.From status.
+func (r *RepoPGS) UpdateStatus(ctx context.Context, arg domain.UpdateScheduledTransferStatusParams) (domain.ScheduledTransfer, error) {
+	l := zerolog.Ctx(ctx)
+
+	var nextRunAt sql.NullTime
+	if arg.NextRunAt != nil {
+		nextRunAt = sql.NullTime{Time: *arg.NextRunAt, Valid: true}
+	}
+
+	row := r.db.QueryRowContext(ctx, updateStatusQuery, arg.ID, arg.From, arg.Status, nextRunAt)
+
+	st, err := scanScheduledTransfer(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return st, domain.ErrScheduledTransferStatus
+		}
+
+		l.Error().Err(err).Send()
+
+		return st, errorspkg.ErrInternal
+	}
+
+	return st, nil
+}
+
+const claimDueQuery = `
+UPDATE scheduled_transfers
+SET locked_until = now() + $2 * interval '1 microsecond'
+WHERE id IN (
+    SELECT id
+    FROM scheduled_transfers
+    WHERE status = 'active'
+        AND next_run_at <= now()
+        AND (locked_until IS NULL OR locked_until <= now())
+    ORDER BY next_run_at, id
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, username, from_account_id, to_account_id, amount, recurrence, start_at,
+    next_run_at, status, created_at
+`
+
+// ClaimDue leases up to limit active scheduled transfers which next run is due
+// to the calling scheduler for the lease duration and returns them.
+//
+// Rows locked or leased by other schedulers are skipped, so each due run is
+// claimed by a single scheduler at a time.
+func (r *RepoPGS) ClaimDue(ctx context.Context, limit int32, lease time.Duration) ([]domain.ScheduledTransfer, error) {
+	l := zerolog.Ctx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, claimDueQuery, limit, lease.Microseconds())
+	if err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+	defer rows.Close()
+
+	items := []domain.ScheduledTransfer{}
+
+	for rows.Next() {
+		st, err := scanScheduledTransfer(rows)
+		if err != nil {
+			l.Error().Err(err).Send()
+			return nil, errorspkg.ErrInternal
+		}
+
+		items = append(items, st)
+	}
+
+	if err := rows.Close(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	if err := rows.Err(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	return items, nil
+}
+
+const advanceQuery = `
+UPDATE scheduled_transfers
+SET next_run_at = $3,
+    status = CASE WHEN $3::timestamptz IS NULL AND status = 'active' THEN 'completed' ELSE status END,
+    locked_until = NULL
+WHERE id = $1 AND next_run_at = $2
+RETURNING id, username, from_account_id, to_account_id, amount, recurrence, start_at,
+    next_run_at, status, created_at
+`
+
+// Advance moves the scheduled transfer from the run at runAt to the next run
+// and releases its lease. The active scheduled transfer is completed if next
+// is nil. It returns domain.ErrScheduledTransferStatus if the next run time has
+// been changed in the meantime.
+func (r *RepoPGS) Advance(ctx context.Context, id int64, runAt time.Time, next *time.Time) (domain.ScheduledTransfer, error) {
+	l := zerolog.Ctx(ctx)
+
+	var nextRunAt sql.NullTime
+	if next != nil {
+		nextRunAt = sql.NullTime{Time: *next, Valid: true}
+	}
+
+	st, err := scanScheduledTransfer(r.db.QueryRowContext(ctx, advanceQuery, id, runAt, nextRunAt))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return st, domain.ErrScheduledTransferStatus
+		}
+
+		l.Error().Err(err).Send()
+
+		return st, errorspkg.ErrInternal
+	}
+
+	return st, nil
+}
+
+const createRunQuery = `
+INSERT INTO
+    scheduled_transfer_runs (scheduled_transfer_id, scheduled_at)
+VALUES
+    ($1, $2)
+RETURNING id, scheduled_transfer_id, scheduled_at, status, transfer_id, transfer_review_id, error, finished_at, created_at
+`
+
+// CreateRun records the start of the run at scheduledAt and returns it. It
+// returns domain.ErrScheduledRunExists if the run has already been started.
+func (r *RepoPGS) CreateRun(ctx context.Context, scheduledTransferID int64, scheduledAt time.Time) (domain.ScheduledTransferRun, error) {
+	l := zerolog.Ctx(ctx)
+
+	run, err := scanRun(r.db.QueryRowContext(ctx, createRunQuery, scheduledTransferID, scheduledAt))
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Constraint {
+			case "scheduled_transfer_runs_scheduled_transfer_id_scheduled_at_key":
+				return run, domain.ErrScheduledRunExists
+			case "scheduled_transfer_runs_scheduled_transfer_id_fkey":
+				l.Error().Err(err).Send()
+				return run, domain.ErrScheduledTransferNotFound
+			}
+		}
+
+		l.Error().Err(err).Send()
+
+		return run, errorspkg.ErrInternal
+	}
+
+	return run, nil
+}
+
+const finishRunQuery = `
+UPDATE scheduled_transfer_runs
+SET status = $2, transfer_id = $3, transfer_review_id = $5, error = $4,
+    finished_at = CASE WHEN $2 = 'pending_review' THEN NULL ELSE now() END
+WHERE id = $1
+RETURNING id, scheduled_transfer_id, scheduled_at, status, transfer_id, transfer_review_id, error, finished_at, created_at
+`
+
+// FinishRun records the outcome of the run and returns it. The run pending
+// review is finished by FinishReviewedRun.
+func (r *RepoPGS) FinishRun(ctx context.Context, arg domain.FinishScheduledTransferRunParams) (domain.ScheduledTransferRun, error) {
+	l := zerolog.Ctx(ctx)
+
+	row := r.db.QueryRowContext(ctx, finishRunQuery,
+		arg.ID,
+		arg.Status,
+		sql.NullInt64{Int64: arg.TransferID, Valid: arg.TransferID != 0},
+		arg.Error,
+		sql.NullInt64{Int64: arg.TransferReviewID, Valid: arg.TransferReviewID != 0},
+	)
+
+	run, err := scanRun(row)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return run, errorspkg.ErrInternal
+	}
+
+	return run, nil
+}
+
+const finishReviewedRunQuery = `
+UPDATE scheduled_transfer_runs
+SET status = $2, transfer_id = $3, error = $4, finished_at = now()
+WHERE transfer_review_id = $1 AND status = 'pending_review'
+`
+
+// FinishReviewedRun finishes the run pending the transfer review, if any. The
+// run succeeds with the transfer of the approved review, or fails if
+// transferID is zero as the review has been rejected.
+func (r *RepoPGS) FinishReviewedRun(ctx context.Context, reviewID, transferID int64) error {
+	l := zerolog.Ctx(ctx)
+
+	status, errMsg := domain.ScheduledRunStatusSucceeded, ""
+	if transferID == 0 {
+		status, errMsg = domain.ScheduledRunStatusFailed, domain.ErrTransferReviewRejected.Error()
+	}
+
+	_, err := r.db.ExecContext(ctx, finishReviewedRunQuery,
+		reviewID,
+		status,
+		sql.NullInt64{Int64: transferID, Valid: transferID != 0},
+		errMsg,
+	)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return errorspkg.ErrInternal
+	}
+
+	return nil
+}
+
+const listRunsQuery = `
+SELECT
+	id, scheduled_transfer_id, scheduled_at, status, transfer_id, transfer_review_id, error, finished_at, created_at
+FROM scheduled_transfer_runs
+WHERE scheduled_transfer_id = $1
+    AND ($2 = 0 OR id > $2)
+    AND ($3 = 0 OR id < $3)
+ORDER BY CASE WHEN $3 = 0 THEN id END, id DESC
+LIMIT $4 OFFSET $5
+`
+
+// ListRuns returns the specified number of runs of the scheduled transfer
+// ordered by id.
+//
+// If arg.BeforeID is set, the runs right before it are returned.
+func (r *RepoPGS) ListRuns(ctx context.Context, arg domain.ListScheduledTransferRunsParams) ([]domain.ScheduledTransferRun, error) {
+	l := zerolog.Ctx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, listRunsQuery,
+		arg.ScheduledTransferID,
+		arg.AfterID,
+		arg.BeforeID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+	defer rows.Close()
+
+	items := []domain.ScheduledTransferRun{}
+
+	for rows.Next() {
+		run, err := scanRun(rows)
+		if err != nil {
+			l.Error().Err(err).Send()
+			return nil, errorspkg.ErrInternal
+		}
+
+		items = append(items, run)
+	}
+
+	if err := rows.Close(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	if err := rows.Err(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	if arg.BeforeID != 0 {
+		pagepkg.Reverse(items)
+	}
+
+	return items, nil
+}
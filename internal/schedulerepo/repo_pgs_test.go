@@ -0,0 +1,227 @@
+//go:build integration
+
+package schedulerepo_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/integrationtest"
+	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
+	"github.com/go-petr/pet-bank/internal/schedulerepo"
+	"github.com/go-petr/pet-bank/pkg/configpkg"
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	dbDriver string
+	dbSource string
+)
+
+func TestMain(m *testing.M) {
+	config, err := configpkg.Load("../../configs")
+	if err != nil {
+		log.Fatal("cannot load config:", err)
+	}
+
+	dbDriver = config.DBDriver
+	dbSource = config.DBSource
+
+	os.Exit(m.Run())
+}
+
+func TestCreateList(t *testing.T) {
+	t.Parallel()
+
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	user := helpers.SeedUser(t, tx)
+	account := helpers.SeedAccountWith1000USDBalance(t, tx, user.Username)
+	toAccount := helpers.SeedAccountWith1000USDBalance(t, tx, helpers.SeedUser(t, tx).Username)
+	scheduleRepo := schedulerepo.NewRepoPGS(tx)
+	ctx := context.Background()
+
+	start := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	arg := domain.CreateScheduledTransferParams{
+		Username:      user.Username,
+		FromAccountID: account.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        "100",
+		Recurrence:    "@monthly",
+		StartAt:       start,
+		NextRunAt:     start,
+	}
+
+	st, err := scheduleRepo.Create(ctx, arg)
+	if err != nil {
+		t.Fatalf("scheduleRepo.Create(ctx, %+v) returned error: %v", arg, err)
+	}
+
+	if st.Status != domain.ScheduledTransferStatusActive || st.NextRunAt == nil || !st.NextRunAt.Equal(start) {
+		t.Errorf("scheduleRepo.Create(ctx, %+v) returned %+v", arg, st)
+	}
+
+	got, err := scheduleRepo.Get(ctx, st.ID)
+	if err != nil {
+		t.Fatalf("scheduleRepo.Get(ctx, %v) returned error: %v", st.ID, err)
+	}
+
+	if diff := cmp.Diff(st, got); diff != "" {
+		t.Errorf("scheduleRepo.Get(ctx, %v) returned unexpected difference (-want +got):\n%s", st.ID, diff)
+	}
+
+	listArg := domain.ListScheduledTransfersParams{Username: user.Username, Limit: 5}
+
+	list, err := scheduleRepo.List(ctx, listArg)
+	if err != nil {
+		t.Fatalf("scheduleRepo.List(ctx, %+v) returned error: %v", listArg, err)
+	}
+
+	if diff := cmp.Diff([]domain.ScheduledTransfer{st}, list); diff != "" {
+		t.Errorf("scheduleRepo.List(ctx, %+v) returned unexpected difference (-want +got):\n%s", listArg, diff)
+	}
+
+	pause := domain.UpdateScheduledTransferStatusParams{
+		ID:        st.ID,
+		From:      domain.ScheduledTransferStatusActive,
+		Status:    domain.ScheduledTransferStatusPaused,
+		NextRunAt: st.NextRunAt,
+	}
+
+	paused, err := scheduleRepo.UpdateStatus(ctx, pause)
+	if err != nil {
+		t.Fatalf("scheduleRepo.UpdateStatus(ctx, %+v) returned error: %v", pause, err)
+	}
+
+	if paused.Status != domain.ScheduledTransferStatusPaused {
+		t.Errorf("scheduleRepo.UpdateStatus(ctx, %+v) returned %+v", pause, paused)
+	}
+
+	if _, err := scheduleRepo.UpdateStatus(ctx, pause); err != domain.ErrScheduledTransferStatus {
+		t.Errorf("scheduleRepo.UpdateStatus(ctx, %+v) returned error: %v, want %v", pause, err, domain.ErrScheduledTransferStatus)
+	}
+
+	if _, err := scheduleRepo.Get(ctx, 0); err != domain.ErrScheduledTransferNotFound {
+		t.Errorf("scheduleRepo.Get(ctx, 0) returned error: %v, want %v", err, domain.ErrScheduledTransferNotFound)
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	user := helpers.SeedUser(t, tx)
+	account := helpers.SeedAccountWith1000USDBalance(t, tx, user.Username)
+	toAccount := helpers.SeedAccountWith1000USDBalance(t, tx, helpers.SeedUser(t, tx).Username)
+	scheduleRepo := schedulerepo.NewRepoPGS(tx)
+	ctx := context.Background()
+
+	runAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	arg := domain.CreateScheduledTransferParams{
+		Username:      user.Username,
+		FromAccountID: account.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        "100",
+		StartAt:       runAt,
+		NextRunAt:     runAt,
+	}
+
+	st, err := scheduleRepo.Create(ctx, arg)
+	if err != nil {
+		t.Fatalf("scheduleRepo.Create(ctx, %+v) returned error: %v", arg, err)
+	}
+
+	claimed, err := scheduleRepo.ClaimDue(ctx, 100, time.Minute)
+	if err != nil {
+		t.Fatalf("scheduleRepo.ClaimDue(ctx) returned error: %v", err)
+	}
+
+	if !containsID(claimed, st.ID) {
+		t.Fatalf("scheduleRepo.ClaimDue(ctx) = %+v, want to contain %v", claimed, st.ID)
+	}
+
+	// The lease keeps the claimed run from other schedulers.
+	claimed, err = scheduleRepo.ClaimDue(ctx, 100, time.Minute)
+	if err != nil {
+		t.Fatalf("scheduleRepo.ClaimDue(ctx) returned error: %v", err)
+	}
+
+	if containsID(claimed, st.ID) {
+		t.Errorf("scheduleRepo.ClaimDue(ctx) = %+v, want not to contain %v", claimed, st.ID)
+	}
+
+	run, err := scheduleRepo.CreateRun(ctx, st.ID, runAt)
+	if err != nil {
+		t.Fatalf("scheduleRepo.CreateRun(ctx, %v, %v) returned error: %v", st.ID, runAt, err)
+	}
+
+	if run.Status != domain.ScheduledRunStatusStarted {
+		t.Errorf("scheduleRepo.CreateRun(ctx, %v, %v) returned %+v", st.ID, runAt, run)
+	}
+
+	if _, err := tx.Exec("SAVEPOINT run"); err != nil {
+		t.Fatalf("savepoint error: %v", err)
+	}
+
+	if _, err := scheduleRepo.CreateRun(ctx, st.ID, runAt); err != domain.ErrScheduledRunExists {
+		t.Errorf("scheduleRepo.CreateRun(ctx, %v, %v) returned error: %v, want %v", st.ID, runAt, err, domain.ErrScheduledRunExists)
+	}
+
+	if _, err := tx.Exec("ROLLBACK TO SAVEPOINT run"); err != nil {
+		t.Fatalf("rollback to savepoint error: %v", err)
+	}
+
+	finish := domain.FinishScheduledTransferRunParams{
+		ID:     run.ID,
+		Status: domain.ScheduledRunStatusFailed,
+		Error:  domain.ErrInsufficientBalance.Error(),
+	}
+
+	finished, err := scheduleRepo.FinishRun(ctx, finish)
+	if err != nil {
+		t.Fatalf("scheduleRepo.FinishRun(ctx, %+v) returned error: %v", finish, err)
+	}
+
+	if finished.Status != finish.Status || finished.Error != finish.Error || finished.FinishedAt == nil {
+		t.Errorf("scheduleRepo.FinishRun(ctx, %+v) returned %+v", finish, finished)
+	}
+
+	advanced, err := scheduleRepo.Advance(ctx, st.ID, runAt, nil)
+	if err != nil {
+		t.Fatalf("scheduleRepo.Advance(ctx, %v) returned error: %v", st.ID, err)
+	}
+
+	if advanced.Status != domain.ScheduledTransferStatusCompleted || advanced.NextRunAt != nil {
+		t.Errorf("scheduleRepo.Advance(ctx, %v) returned %+v", st.ID, advanced)
+	}
+
+	if _, err := scheduleRepo.Advance(ctx, st.ID, runAt, nil); err != domain.ErrScheduledTransferStatus {
+		t.Errorf("scheduleRepo.Advance(ctx, %v) returned error: %v, want %v", st.ID, err, domain.ErrScheduledTransferStatus)
+	}
+
+	listArg := domain.ListScheduledTransferRunsParams{ScheduledTransferID: st.ID, Limit: 5}
+
+	runs, err := scheduleRepo.ListRuns(ctx, listArg)
+	if err != nil {
+		t.Fatalf("scheduleRepo.ListRuns(ctx, %+v) returned error: %v", listArg, err)
+	}
+
+	if diff := cmp.Diff([]domain.ScheduledTransferRun{finished}, runs); diff != "" {
+		t.Errorf("scheduleRepo.ListRuns(ctx, %+v) returned unexpected difference (-want +got):\n%s", listArg, diff)
+	}
+}
+
+func containsID(items []domain.ScheduledTransfer, id int64) bool {
+	for _, st := range items {
+		if st.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
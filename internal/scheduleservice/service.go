@@ -0,0 +1,434 @@
+// Package scheduleservice manages business logic layer of scheduled transfers.
+package scheduleservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
+	"github.com/go-petr/pet-bank/pkg/schedulepkg"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+)
+
+// claimBatchSize is the maximum number of due runs claimed by one scheduler
+// tick.
+const claimBatchSize = 100
+
+// Repo provides data access layer interface needed by schedule service layer.
+//
+//go:generate mockgen -source service.go -destination service_mock.go -package scheduleservice
+type Repo interface {
+	Create(ctx context.Context, arg domain.CreateScheduledTransferParams) (domain.ScheduledTransfer, error)
+	Get(ctx context.Context, id int64) (domain.ScheduledTransfer, error)
+	List(ctx context.Context, arg domain.ListScheduledTransfersParams) ([]domain.ScheduledTransfer, error)
+	UpdateStatus(ctx context.Context, arg domain.UpdateScheduledTransferStatusParams) (domain.ScheduledTransfer, error)
+	ClaimDue(ctx context.Context, limit int32, lease time.Duration) ([]domain.ScheduledTransfer, error)
+	Advance(ctx context.Context, id int64, runAt time.Time, next *time.Time) (domain.ScheduledTransfer, error)
+	CreateRun(ctx context.Context, scheduledTransferID int64, scheduledAt time.Time) (domain.ScheduledTransferRun, error)
+	FinishRun(ctx context.Context, arg domain.FinishScheduledTransferRunParams) (domain.ScheduledTransferRun, error)
+	ListRuns(ctx context.Context, arg domain.ListScheduledTransferRunsParams) ([]domain.ScheduledTransferRun, error)
+}
+
+// AccountRepo provides account data access needed to check the scheduled
+// transfer accounts.
+type AccountRepo interface {
+	Get(ctx context.Context, id int32) (domain.Account, error)
+}
+
+// Transferer executes the due transfers, it is implemented by
+// transferservice.Service.
+type Transferer interface {
+	Transfer(ctx context.Context, fromUsername string, arg domain.CreateTransferParams) (domain.TransferTxResult, error)
+}
+
+// Auditor records audit events of the scheduled transfers.
+type Auditor interface {
+	Record(ctx context.Context, eventType, actor string, before, after any)
+}
+
+// Service facilitates schedule service layer logic.
+type Service struct {
+	repo        Repo
+	accountRepo AccountRepo
+	transferer  Transferer
+	auditor     Auditor
+}
+
+// New returns schedule service struct to manage scheduled transfers bussines
+// logic. Audit events are not recorded if a is nil.
+func New(r Repo, ar AccountRepo, t Transferer, a Auditor) *Service {
+	return &Service{
+		repo:        r,
+		accountRepo: ar,
+		transferer:  t,
+		auditor:     a,
+	}
+}
+
+func validAmount(ctx context.Context, amount string) error {
+	l := zerolog.Ctx(ctx)
+
+	amountDecimal, err := decimal.NewFromString(amount)
+	if err != nil {
+		l.Info().Err(err).Send()
+		return domain.ErrInvalidAmount
+	}
+
+	if amountDecimal.LessThanOrEqual(decimal.Zero) {
+		l.Info().Err(domain.ErrNegativeAmount).Send()
+		return domain.ErrNegativeAmount
+	}
+
+	return nil
+}
+
+// Create schedules the transfer from the user's account. The first run is at
+// arg.StartAt, or now if it is zero, for one-off and monthly transfers and at
+// the first cron time after it otherwise.
+//
+// Balance and frozen accounts are checked by every run.
+func (s *Service) Create(ctx context.Context, username string, arg domain.CreateScheduledTransferParams) (domain.ScheduledTransfer, error) {
+	l := zerolog.Ctx(ctx)
+
+	if err := validAmount(ctx, arg.Amount); err != nil {
+		return domain.ScheduledTransfer{}, err
+	}
+
+	now := time.Now().Truncate(time.Second)
+
+	if arg.StartAt.IsZero() {
+		arg.StartAt = now
+	}
+
+	arg.StartAt = arg.StartAt.UTC().Truncate(time.Second)
+
+	if arg.StartAt.Before(now) {
+		l.Info().Err(domain.ErrInvalidSchedule).Msg("start in the past")
+		return domain.ScheduledTransfer{}, domain.ErrInvalidSchedule
+	}
+
+	schedule, err := schedulepkg.Parse(arg.Recurrence, arg.StartAt)
+	if err != nil {
+		l.Info().Err(err).Send()
+		return domain.ScheduledTransfer{}, domain.ErrInvalidSchedule
+	}
+
+	arg.NextRunAt = schedulepkg.First(schedule, arg.StartAt)
+	if arg.NextRunAt.IsZero() {
+		l.Info().Err(domain.ErrInvalidSchedule).Msg("no runs")
+		return domain.ScheduledTransfer{}, domain.ErrInvalidSchedule
+	}
+
+	fromAccount, err := s.accountRepo.Get(ctx, arg.FromAccountID)
+	if err != nil {
+		return domain.ScheduledTransfer{}, err
+	}
+
+	if fromAccount.Owner != username {
+		l.Warn().Err(domain.ErrInvalidOwner).Send()
+		return domain.ScheduledTransfer{}, domain.ErrInvalidOwner
+	}
+
+	toAccount, err := s.accountRepo.Get(ctx, arg.ToAccountID)
+	if err != nil {
+		return domain.ScheduledTransfer{}, err
+	}
+
+	if fromAccount.Currency != toAccount.Currency {
+		l.Info().Err(domain.ErrCurrencyMismatch).Send()
+		return domain.ScheduledTransfer{}, domain.ErrCurrencyMismatch
+	}
+
+	arg.Username = username
+
+	st, err := s.repo.Create(ctx, arg)
+	if err != nil {
+		return st, err
+	}
+
+	if s.auditor != nil {
+		s.auditor.Record(ctx, domain.AuditScheduledTransferCreated, username, nil, st)
+	}
+
+	return st, nil
+}
+
+// Get returns the scheduled transfer if it is created by the user.
+func (s *Service) Get(ctx context.Context, username string, id int64) (domain.ScheduledTransfer, error) {
+	st, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return st, err
+	}
+
+	if st.Username != username {
+		zerolog.Ctx(ctx).Warn().Err(domain.ErrScheduledTransferOwnerMismatch).Send()
+		return domain.ScheduledTransfer{}, domain.ErrScheduledTransferOwnerMismatch
+	}
+
+	return st, nil
+}
+
+// List returns the page of the user's scheduled transfers.
+func (s *Service) List(ctx context.Context, username string, page pagepkg.Request) ([]domain.ScheduledTransfer, pagepkg.Page, error) {
+	arg := domain.ListScheduledTransfersParams{
+		Username: username,
+		AfterID:  page.Cursor.AfterID,
+		BeforeID: page.Cursor.BeforeID,
+		Limit:    page.Limit(),
+		Offset:   page.Offset(),
+	}
+
+	items, err := s.repo.List(ctx, arg)
+	if err != nil {
+		return nil, pagepkg.Page{}, err
+	}
+
+	items, p := pagepkg.Trim(items, page, func(st domain.ScheduledTransfer) int64 { return st.ID })
+
+	return items, p, nil
+}
+
+// ListRuns returns the page of runs of the user's scheduled transfer.
+func (s *Service) ListRuns(ctx context.Context, username string, id int64, page pagepkg.Request) ([]domain.ScheduledTransferRun, pagepkg.Page, error) {
+	if _, err := s.Get(ctx, username, id); err != nil {
+		return nil, pagepkg.Page{}, err
+	}
+
+	arg := domain.ListScheduledTransferRunsParams{
+		ScheduledTransferID: id,
+		AfterID:             page.Cursor.AfterID,
+		BeforeID:            page.Cursor.BeforeID,
+		Limit:               page.Limit(),
+		Offset:              page.Offset(),
+	}
+
+	runs, err := s.repo.ListRuns(ctx, arg)
+	if err != nil {
+		return nil, pagepkg.Page{}, err
+	}
+
+	runs, p := pagepkg.Trim(runs, page, func(run domain.ScheduledTransferRun) int64 { return run.ID })
+
+	return runs, p, nil
+}
+
+// Pause stops the runs of the active scheduled transfer until it is resumed.
+func (s *Service) Pause(ctx context.Context, username string, id int64) (domain.ScheduledTransfer, error) {
+	st, err := s.Get(ctx, username, id)
+	if err != nil {
+		return st, err
+	}
+
+	if st.Status != domain.ScheduledTransferStatusActive {
+		return domain.ScheduledTransfer{}, domain.ErrScheduledTransferStatus
+	}
+
+	return s.updateStatus(ctx, username, domain.AuditScheduledTransferPaused, st, domain.UpdateScheduledTransferStatusParams{
+		ID:        st.ID,
+		From:      st.Status,
+		Status:    domain.ScheduledTransferStatusPaused,
+		NextRunAt: st.NextRunAt,
+	})
+}
+
+// Resume restarts the runs of the paused scheduled transfer. Recurring runs
+// missed while paused are skipped, a missed one-off run is executed right away.
+func (s *Service) Resume(ctx context.Context, username string, id int64) (domain.ScheduledTransfer, error) {
+	l := zerolog.Ctx(ctx)
+
+	st, err := s.Get(ctx, username, id)
+	if err != nil {
+		return st, err
+	}
+
+	if st.Status != domain.ScheduledTransferStatusPaused {
+		return domain.ScheduledTransfer{}, domain.ErrScheduledTransferStatus
+	}
+
+	next := st.NextRunAt
+
+	if now := time.Now(); st.Recurrence != "" && next != nil && next.Before(now) {
+		schedule, err := schedulepkg.Parse(st.Recurrence, st.StartAt)
+		if err != nil {
+			l.Error().Err(err).Send()
+			return domain.ScheduledTransfer{}, err
+		}
+
+		next = nextRun(schedule, now)
+	}
+
+	return s.updateStatus(ctx, username, domain.AuditScheduledTransferResumed, st, domain.UpdateScheduledTransferStatusParams{
+		ID:        st.ID,
+		From:      st.Status,
+		Status:    domain.ScheduledTransferStatusActive,
+		NextRunAt: next,
+	})
+}
+
+// Cancel stops the runs of the active or paused scheduled transfer for good.
+func (s *Service) Cancel(ctx context.Context, username string, id int64) (domain.ScheduledTransfer, error) {
+	st, err := s.Get(ctx, username, id)
+	if err != nil {
+		return st, err
+	}
+
+	if st.Status != domain.ScheduledTransferStatusActive && st.Status != domain.ScheduledTransferStatusPaused {
+		return domain.ScheduledTransfer{}, domain.ErrScheduledTransferStatus
+	}
+
+	return s.updateStatus(ctx, username, domain.AuditScheduledTransferCancelled, st, domain.UpdateScheduledTransferStatusParams{
+		ID:     st.ID,
+		From:   st.Status,
+		Status: domain.ScheduledTransferStatusCancelled,
+	})
+}
+
+func (s *Service) updateStatus(
+	ctx context.Context,
+	username, eventType string,
+	before domain.ScheduledTransfer,
+	arg domain.UpdateScheduledTransferStatusParams,
+) (domain.ScheduledTransfer, error) {
+	st, err := s.repo.UpdateStatus(ctx, arg)
+	if err != nil {
+		return st, err
+	}
+
+	if s.auditor != nil {
+		s.auditor.Record(ctx, eventType, username, before, st)
+	}
+
+	return st, nil
+}
+
+// nextRun returns the first run of the schedule after t, or nil if there is none.
+func nextRun(schedule schedulepkg.Schedule, t time.Time) *time.Time {
+	next := schedule.Next(t)
+	if next.IsZero() {
+		return nil
+	}
+
+	return &next
+}
+
+// RunDue executes the due runs claimed by this scheduler for the lease
+// duration and returns the number of executed runs.
+//
+// Each run is recorded before its transfer is made, and a run can be recorded
+// only once, so a run is never executed twice even if its lease expires.
+func (s *Service) RunDue(ctx context.Context, lease time.Duration) (int, error) {
+	due, err := s.repo.ClaimDue(ctx, claimBatchSize, lease)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+
+	for _, st := range due {
+		executed, err := s.run(ctx, st)
+		if err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Int64("scheduled_transfer_id", st.ID).Msg("Cannot run scheduled transfer")
+			continue
+		}
+
+		if executed {
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+// run executes the due run of the claimed scheduled transfer and moves it to
+// the next run. It reports whether the transfer has been attempted.
+func (s *Service) run(ctx context.Context, st domain.ScheduledTransfer) (bool, error) {
+	l := zerolog.Ctx(ctx).With().Int64("scheduled_transfer_id", st.ID).Logger()
+	runAt := *st.NextRunAt
+
+	schedule, err := schedulepkg.Parse(st.Recurrence, st.StartAt)
+	if err != nil {
+		return false, err
+	}
+
+	// Runs missed while the scheduler was down are not caught up.
+	next := nextRun(schedule, time.Now())
+
+	run, err := s.repo.CreateRun(ctx, st.ID, runAt)
+	if err == domain.ErrScheduledRunExists {
+		// The run has been started by a scheduler which lease has expired.
+		l.Warn().Err(err).Time("scheduled_at", runAt).Send()
+
+		_, err = s.repo.Advance(ctx, st.ID, runAt, next)
+
+		return false, err
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	finish := domain.FinishScheduledTransferRunParams{
+		ID:     run.ID,
+		Status: domain.ScheduledRunStatusSucceeded,
+	}
+
+	result, err := s.transferer.Transfer(ctx, st.Username, domain.CreateTransferParams{
+		FromAccountID: st.FromAccountID,
+		ToAccountID:   st.ToAccountID,
+		Amount:        st.Amount,
+	})
+	if err != nil {
+		l.Info().Err(err).Msg("Scheduled transfer failed")
+
+		finish.Status = domain.ScheduledRunStatusFailed
+		finish.Error = err.Error()
+	}
+
+	// The transfer held for review is executed once it is approved, the run
+	// is finished then.
+	if result.Review != nil {
+		l.Info().Int64("transfer_review_id", result.Review.ID).Msg("Scheduled transfer held for review")
+
+		finish.Status = domain.ScheduledRunStatusPendingReview
+		finish.TransferReviewID = result.Review.ID
+	}
+
+	finish.TransferID = result.Transfer.ID
+
+	if _, err := s.repo.FinishRun(ctx, finish); err != nil {
+		return true, err
+	}
+
+	if _, err := s.repo.Advance(ctx, st.ID, runAt, next); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// RunScheduler executes the due runs every interval until the context is done.
+func (s *Service) RunScheduler(ctx context.Context, interval, lease time.Duration) {
+	l := zerolog.Ctx(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := s.RunDue(ctx, lease)
+			if err != nil {
+				l.Error().Err(err).Msg("Cannot run scheduled transfers")
+				continue
+			}
+
+			if n > 0 {
+				l.Info().Int("count", n).Msg("Executed scheduled transfers")
+			}
+		}
+	}
+}
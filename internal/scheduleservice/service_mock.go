@@ -0,0 +1,283 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package scheduleservice is a generated GoMock package.
+package scheduleservice
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/go-petr/pet-bank/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepo is a mock of Repo interface.
+type MockRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoMockRecorder
+}
+
+// MockRepoMockRecorder is the mock recorder for MockRepo.
+type MockRepoMockRecorder struct {
+	mock *MockRepo
+}
+
+// NewMockRepo creates a new mock instance.
+func NewMockRepo(ctrl *gomock.Controller) *MockRepo {
+	mock := &MockRepo{ctrl: ctrl}
+	mock.recorder = &MockRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
+	return m.recorder
+}
+
+// Advance mocks base method.
+func (m *MockRepo) Advance(ctx context.Context, id int64, runAt time.Time, next *time.Time) (domain.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Advance", ctx, id, runAt, next)
+	ret0, _ := ret[0].(domain.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Advance indicates an expected call of Advance.
+func (mr *MockRepoMockRecorder) Advance(ctx, id, runAt, next interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Advance", reflect.TypeOf((*MockRepo)(nil).Advance), ctx, id, runAt, next)
+}
+
+// ClaimDue mocks base method.
+func (m *MockRepo) ClaimDue(ctx context.Context, limit int32, lease time.Duration) ([]domain.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDue", ctx, limit, lease)
+	ret0, _ := ret[0].([]domain.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDue indicates an expected call of ClaimDue.
+func (mr *MockRepoMockRecorder) ClaimDue(ctx, limit, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDue", reflect.TypeOf((*MockRepo)(nil).ClaimDue), ctx, limit, lease)
+}
+
+// Create mocks base method.
+func (m *MockRepo) Create(ctx context.Context, arg domain.CreateScheduledTransferParams) (domain.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, arg)
+	ret0, _ := ret[0].(domain.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepoMockRecorder) Create(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepo)(nil).Create), ctx, arg)
+}
+
+// CreateRun mocks base method.
+func (m *MockRepo) CreateRun(ctx context.Context, scheduledTransferID int64, scheduledAt time.Time) (domain.ScheduledTransferRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRun", ctx, scheduledTransferID, scheduledAt)
+	ret0, _ := ret[0].(domain.ScheduledTransferRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRun indicates an expected call of CreateRun.
+func (mr *MockRepoMockRecorder) CreateRun(ctx, scheduledTransferID, scheduledAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRun", reflect.TypeOf((*MockRepo)(nil).CreateRun), ctx, scheduledTransferID, scheduledAt)
+}
+
+// FinishRun mocks base method.
+func (m *MockRepo) FinishRun(ctx context.Context, arg domain.FinishScheduledTransferRunParams) (domain.ScheduledTransferRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishRun", ctx, arg)
+	ret0, _ := ret[0].(domain.ScheduledTransferRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishRun indicates an expected call of FinishRun.
+func (mr *MockRepoMockRecorder) FinishRun(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishRun", reflect.TypeOf((*MockRepo)(nil).FinishRun), ctx, arg)
+}
+
+// Get mocks base method.
+func (m *MockRepo) Get(ctx context.Context, id int64) (domain.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(domain.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepoMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepo)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockRepo) List(ctx context.Context, arg domain.ListScheduledTransfersParams) ([]domain.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, arg)
+	ret0, _ := ret[0].([]domain.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepoMockRecorder) List(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepo)(nil).List), ctx, arg)
+}
+
+// ListRuns mocks base method.
+func (m *MockRepo) ListRuns(ctx context.Context, arg domain.ListScheduledTransferRunsParams) ([]domain.ScheduledTransferRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRuns", ctx, arg)
+	ret0, _ := ret[0].([]domain.ScheduledTransferRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRuns indicates an expected call of ListRuns.
+func (mr *MockRepoMockRecorder) ListRuns(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRuns", reflect.TypeOf((*MockRepo)(nil).ListRuns), ctx, arg)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockRepo) UpdateStatus(ctx context.Context, arg domain.UpdateScheduledTransferStatusParams) (domain.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, arg)
+	ret0, _ := ret[0].(domain.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockRepoMockRecorder) UpdateStatus(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockRepo)(nil).UpdateStatus), ctx, arg)
+}
+
+// MockAccountRepo is a mock of AccountRepo interface.
+type MockAccountRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountRepoMockRecorder
+}
+
+// MockAccountRepoMockRecorder is the mock recorder for MockAccountRepo.
+type MockAccountRepoMockRecorder struct {
+	mock *MockAccountRepo
+}
+
+// NewMockAccountRepo creates a new mock instance.
+func NewMockAccountRepo(ctrl *gomock.Controller) *MockAccountRepo {
+	mock := &MockAccountRepo{ctrl: ctrl}
+	mock.recorder = &MockAccountRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountRepo) EXPECT() *MockAccountRepoMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockAccountRepo) Get(ctx context.Context, id int32) (domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAccountRepoMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAccountRepo)(nil).Get), ctx, id)
+}
+
+// MockTransferer is a mock of Transferer interface.
+type MockTransferer struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransfererMockRecorder
+}
+
+// MockTransfererMockRecorder is the mock recorder for MockTransferer.
+type MockTransfererMockRecorder struct {
+	mock *MockTransferer
+}
+
+// NewMockTransferer creates a new mock instance.
+func NewMockTransferer(ctrl *gomock.Controller) *MockTransferer {
+	mock := &MockTransferer{ctrl: ctrl}
+	mock.recorder = &MockTransfererMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransferer) EXPECT() *MockTransfererMockRecorder {
+	return m.recorder
+}
+
+// Transfer mocks base method.
+func (m *MockTransferer) Transfer(ctx context.Context, fromUsername string, arg domain.CreateTransferParams) (domain.TransferTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transfer", ctx, fromUsername, arg)
+	ret0, _ := ret[0].(domain.TransferTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transfer indicates an expected call of Transfer.
+func (mr *MockTransfererMockRecorder) Transfer(ctx, fromUsername, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockTransferer)(nil).Transfer), ctx, fromUsername, arg)
+}
+
+// MockAuditor is a mock of Auditor interface.
+type MockAuditor struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditorMockRecorder
+}
+
+// MockAuditorMockRecorder is the mock recorder for MockAuditor.
+type MockAuditorMockRecorder struct {
+	mock *MockAuditor
+}
+
+// NewMockAuditor creates a new mock instance.
+func NewMockAuditor(ctrl *gomock.Controller) *MockAuditor {
+	mock := &MockAuditor{ctrl: ctrl}
+	mock.recorder = &MockAuditorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditor) EXPECT() *MockAuditorMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockAuditor) Record(ctx context.Context, eventType, actor string, before, after any) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", ctx, eventType, actor, before, after)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAuditorMockRecorder) Record(ctx, eventType, actor, before, after interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAuditor)(nil).Record), ctx, eventType, actor, before, after)
+}
@@ -0,0 +1,384 @@
+package scheduleservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCreate(t *testing.T) {
+	username := randompkg.Owner()
+	fromAccount := domain.Account{ID: 1, Owner: username, Currency: "USD"}
+	toAccount := domain.Account{ID: 2, Owner: randompkg.Owner(), Currency: "USD"}
+	start := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+	scheduled := domain.ScheduledTransfer{ID: 1, Username: username, Status: domain.ScheduledTransferStatusActive}
+
+	testCases := []struct {
+		name       string
+		arg        domain.CreateScheduledTransferParams
+		buildStubs func(repo *MockRepo, accountRepo *MockAccountRepo, auditor *MockAuditor)
+		wantErr    error
+	}{
+		{
+			name: "OK",
+			arg:  domain.CreateScheduledTransferParams{FromAccountID: 1, ToAccountID: 2, Amount: "100", Recurrence: "0 9 1 * *", StartAt: start},
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo, auditor *MockAuditor) {
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(int32(1))).Times(1).Return(fromAccount, nil)
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(int32(2))).Times(1).Return(toAccount, nil)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg domain.CreateScheduledTransferParams) (domain.ScheduledTransfer, error) {
+						if arg.Username != username {
+							t.Errorf("arg.Username = %v, want %v", arg.Username, username)
+						}
+
+						if arg.NextRunAt.Day() != 1 || arg.NextRunAt.Hour() != 9 || arg.NextRunAt.Before(start) {
+							t.Errorf("arg.NextRunAt = %v, want first 09:00 on the 1st after %v", arg.NextRunAt, start)
+						}
+
+						return scheduled, nil
+					})
+				auditor.EXPECT().Record(gomock.Any(), domain.AuditScheduledTransferCreated, username, nil, gomock.Eq(scheduled)).Times(1)
+			},
+		},
+		{
+			name: "OneOffAtStart",
+			arg:  domain.CreateScheduledTransferParams{FromAccountID: 1, ToAccountID: 2, Amount: "100", StartAt: start},
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo, auditor *MockAuditor) {
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(int32(1))).Times(1).Return(fromAccount, nil)
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(int32(2))).Times(1).Return(toAccount, nil)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg domain.CreateScheduledTransferParams) (domain.ScheduledTransfer, error) {
+						if !arg.NextRunAt.Equal(start) {
+							t.Errorf("arg.NextRunAt = %v, want %v", arg.NextRunAt, start)
+						}
+
+						return scheduled, nil
+					})
+				auditor.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+			},
+		},
+		{
+			name: "ErrInvalidScheduleStartInPast",
+			arg:  domain.CreateScheduledTransferParams{FromAccountID: 1, ToAccountID: 2, Amount: "100", StartAt: time.Now().Add(-time.Hour)},
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo, auditor *MockAuditor) {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrInvalidSchedule,
+		},
+		{
+			name: "ErrInvalidScheduleRecurrence",
+			arg:  domain.CreateScheduledTransferParams{FromAccountID: 1, ToAccountID: 2, Amount: "100", Recurrence: "every day"},
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo, auditor *MockAuditor) {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrInvalidSchedule,
+		},
+		{
+			name: "ErrNegativeAmount",
+			arg:  domain.CreateScheduledTransferParams{FromAccountID: 1, ToAccountID: 2, Amount: "-1"},
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo, auditor *MockAuditor) {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrNegativeAmount,
+		},
+		{
+			name: "ErrInvalidOwner",
+			arg:  domain.CreateScheduledTransferParams{FromAccountID: 2, ToAccountID: 1, Amount: "100"},
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo, auditor *MockAuditor) {
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(int32(2))).Times(1).Return(toAccount, nil)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrInvalidOwner,
+		},
+		{
+			name: "ErrCurrencyMismatch",
+			arg:  domain.CreateScheduledTransferParams{FromAccountID: 1, ToAccountID: 2, Amount: "100"},
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo, auditor *MockAuditor) {
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(int32(1))).Times(1).Return(fromAccount, nil)
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(int32(2))).
+					Times(1).
+					Return(domain.Account{ID: 2, Currency: "EUR"}, nil)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrCurrencyMismatch,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepo(ctrl)
+			accountRepo := NewMockAccountRepo(ctrl)
+			auditor := NewMockAuditor(ctrl)
+			tc.buildStubs(repo, accountRepo, auditor)
+
+			got, err := New(repo, accountRepo, NewMockTransferer(ctrl), auditor).Create(context.Background(), username, tc.arg)
+			if err != tc.wantErr {
+				t.Fatalf("Create(ctx, %v, %+v) returned error: %v, want %v", username, tc.arg, err, tc.wantErr)
+			}
+
+			if err == nil {
+				if diff := cmp.Diff(scheduled, got); diff != "" {
+					t.Errorf("Create(ctx, %v, %+v) returned unexpected difference (-want +got):\n%s", username, tc.arg, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestRunDue(t *testing.T) {
+	runAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+	oneOff := domain.ScheduledTransfer{
+		ID:            1,
+		Username:      randompkg.Owner(),
+		FromAccountID: 1,
+		ToAccountID:   2,
+		Amount:        "100",
+		StartAt:       runAt,
+		NextRunAt:     &runAt,
+		Status:        domain.ScheduledTransferStatusActive,
+	}
+	monthly := oneOff
+	monthly.Recurrence = "@monthly"
+
+	testCases := []struct {
+		name       string
+		due        domain.ScheduledTransfer
+		buildStubs func(repo *MockRepo, transferer *MockTransferer)
+		want       int
+	}{
+		{
+			name: "Succeeded",
+			due:  oneOff,
+			buildStubs: func(repo *MockRepo, transferer *MockTransferer) {
+				repo.EXPECT().CreateRun(gomock.Any(), gomock.Eq(int64(1)), gomock.Eq(runAt)).
+					Times(1).
+					Return(domain.ScheduledTransferRun{ID: 5}, nil)
+				transferer.EXPECT().Transfer(gomock.Any(), gomock.Eq(oneOff.Username), gomock.Eq(domain.CreateTransferParams{
+					FromAccountID: 1,
+					ToAccountID:   2,
+					Amount:        "100",
+				})).
+					Times(1).
+					Return(domain.TransferTxResult{Transfer: domain.Transfer{ID: 9}}, nil)
+				repo.EXPECT().FinishRun(gomock.Any(), gomock.Eq(domain.FinishScheduledTransferRunParams{
+					ID:         5,
+					Status:     domain.ScheduledRunStatusSucceeded,
+					TransferID: 9,
+				})).
+					Times(1)
+				repo.EXPECT().Advance(gomock.Any(), gomock.Eq(int64(1)), gomock.Eq(runAt), gomock.Nil()).Times(1)
+			},
+			want: 1,
+		},
+		{
+			name: "HeldForReview",
+			due:  oneOff,
+			buildStubs: func(repo *MockRepo, transferer *MockTransferer) {
+				repo.EXPECT().CreateRun(gomock.Any(), gomock.Eq(int64(1)), gomock.Eq(runAt)).
+					Times(1).
+					Return(domain.ScheduledTransferRun{ID: 5}, nil)
+				transferer.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.TransferTxResult{Review: &domain.TransferReview{ID: 3}}, nil)
+				repo.EXPECT().FinishRun(gomock.Any(), gomock.Eq(domain.FinishScheduledTransferRunParams{
+					ID:               5,
+					Status:           domain.ScheduledRunStatusPendingReview,
+					TransferReviewID: 3,
+				})).
+					Times(1)
+				repo.EXPECT().Advance(gomock.Any(), gomock.Eq(int64(1)), gomock.Eq(runAt), gomock.Nil()).Times(1)
+			},
+			want: 1,
+		},
+		{
+			name: "InsufficientBalance",
+			due:  monthly,
+			buildStubs: func(repo *MockRepo, transferer *MockTransferer) {
+				repo.EXPECT().CreateRun(gomock.Any(), gomock.Eq(int64(1)), gomock.Eq(runAt)).
+					Times(1).
+					Return(domain.ScheduledTransferRun{ID: 5}, nil)
+				transferer.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.TransferTxResult{}, domain.ErrInsufficientBalance)
+				repo.EXPECT().FinishRun(gomock.Any(), gomock.Eq(domain.FinishScheduledTransferRunParams{
+					ID:     5,
+					Status: domain.ScheduledRunStatusFailed,
+					Error:  domain.ErrInsufficientBalance.Error(),
+				})).
+					Times(1)
+				repo.EXPECT().Advance(gomock.Any(), gomock.Eq(int64(1)), gomock.Eq(runAt), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, _ int64, _ time.Time, next *time.Time) (domain.ScheduledTransfer, error) {
+						if next == nil || !next.After(time.Now()) {
+							t.Errorf("next = %v, want the next monthly run", next)
+						}
+
+						return domain.ScheduledTransfer{}, nil
+					})
+			},
+			want: 1,
+		},
+		{
+			name: "RunExists",
+			due:  monthly,
+			buildStubs: func(repo *MockRepo, transferer *MockTransferer) {
+				repo.EXPECT().CreateRun(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.ScheduledTransferRun{}, domain.ErrScheduledRunExists)
+				transferer.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				repo.EXPECT().FinishRun(gomock.Any(), gomock.Any()).Times(0)
+				repo.EXPECT().Advance(gomock.Any(), gomock.Eq(int64(1)), gomock.Eq(runAt), gomock.Not(gomock.Nil())).Times(1)
+			},
+			want: 0,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepo(ctrl)
+			transferer := NewMockTransferer(ctrl)
+
+			repo.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), gomock.Eq(time.Minute)).
+				Times(1).
+				Return([]domain.ScheduledTransfer{tc.due}, nil)
+			tc.buildStubs(repo, transferer)
+
+			got, err := New(repo, NewMockAccountRepo(ctrl), transferer, nil).RunDue(context.Background(), time.Minute)
+			if err != nil {
+				t.Fatalf("RunDue(ctx) returned error: %v", err)
+			}
+
+			if got != tc.want {
+				t.Errorf("RunDue(ctx) = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUpdateStatus(t *testing.T) {
+	username := randompkg.Owner()
+	past := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+
+	testCases := []struct {
+		name       string
+		current    domain.ScheduledTransfer
+		update     func(s *Service, id int64) (domain.ScheduledTransfer, error)
+		buildStubs func(repo *MockRepo)
+		wantErr    error
+	}{
+		{
+			name:    "Pause",
+			current: domain.ScheduledTransfer{ID: 1, Username: username, Status: domain.ScheduledTransferStatusActive, NextRunAt: &past},
+			update: func(s *Service, id int64) (domain.ScheduledTransfer, error) {
+				return s.Pause(context.Background(), username, id)
+			},
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().UpdateStatus(gomock.Any(), gomock.Eq(domain.UpdateScheduledTransferStatusParams{
+					ID:        1,
+					From:      domain.ScheduledTransferStatusActive,
+					Status:    domain.ScheduledTransferStatusPaused,
+					NextRunAt: &past,
+				})).
+					Times(1)
+			},
+		},
+		{
+			name: "ResumeSkipsMissedRuns",
+			current: domain.ScheduledTransfer{
+				ID:         1,
+				Username:   username,
+				Recurrence: "0 * * * *",
+				StartAt:    past,
+				Status:     domain.ScheduledTransferStatusPaused,
+				NextRunAt:  &past,
+			},
+			update: func(s *Service, id int64) (domain.ScheduledTransfer, error) {
+				return s.Resume(context.Background(), username, id)
+			},
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg domain.UpdateScheduledTransferStatusParams) (domain.ScheduledTransfer, error) {
+						if arg.Status != domain.ScheduledTransferStatusActive || arg.NextRunAt == nil || !arg.NextRunAt.After(time.Now()) {
+							t.Errorf("UpdateStatus(ctx, %+v), want active with next run in the future", arg)
+						}
+
+						return domain.ScheduledTransfer{}, nil
+					})
+			},
+		},
+		{
+			name:    "Cancel",
+			current: domain.ScheduledTransfer{ID: 1, Username: username, Status: domain.ScheduledTransferStatusPaused, NextRunAt: &past},
+			update: func(s *Service, id int64) (domain.ScheduledTransfer, error) {
+				return s.Cancel(context.Background(), username, id)
+			},
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().UpdateStatus(gomock.Any(), gomock.Eq(domain.UpdateScheduledTransferStatusParams{
+					ID:     1,
+					From:   domain.ScheduledTransferStatusPaused,
+					Status: domain.ScheduledTransferStatusCancelled,
+				})).
+					Times(1)
+			},
+		},
+		{
+			name:    "ErrScheduledTransferStatus",
+			current: domain.ScheduledTransfer{ID: 1, Username: username, Status: domain.ScheduledTransferStatusCompleted},
+			update: func(s *Service, id int64) (domain.ScheduledTransfer, error) {
+				return s.Cancel(context.Background(), username, id)
+			},
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrScheduledTransferStatus,
+		},
+		{
+			name:    "ErrScheduledTransferOwnerMismatch",
+			current: domain.ScheduledTransfer{ID: 1, Username: randompkg.Owner(), Status: domain.ScheduledTransferStatusActive},
+			update: func(s *Service, id int64) (domain.ScheduledTransfer, error) {
+				return s.Pause(context.Background(), username, id)
+			},
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrScheduledTransferOwnerMismatch,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepo(ctrl)
+			repo.EXPECT().Get(gomock.Any(), gomock.Eq(tc.current.ID)).Times(1).Return(tc.current, nil)
+			tc.buildStubs(repo)
+
+			s := New(repo, NewMockAccountRepo(ctrl), NewMockTransferer(ctrl), nil)
+
+			if _, err := tc.update(s, tc.current.ID); err != tc.wantErr {
+				t.Fatalf("returned error: %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+// Package screeningrepo manages repository layer of the sanctions screening
+// compliance cases.
+package screeningrepo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/dbpkg"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
+	"github.com/rs/zerolog"
+)
+
+// RepoPGS facilitates compliance case repository layer logic.
+type RepoPGS struct {
+	db dbpkg.SQLInterface
+}
+
+// NewRepoPGS returns compliance case RepoPGS.
+func NewRepoPGS(db dbpkg.SQLInterface) *RepoPGS {
+	return &RepoPGS{
+		db: db,
+	}
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanCase(row scanner) (domain.ComplianceCase, error) {
+	var (
+		c          domain.ComplianceCase
+		matches    []byte
+		reviewer   sql.NullString
+		reviewedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&c.ID,
+		&c.Action,
+		&c.Actor,
+		&c.Username,
+		&c.Name,
+		&matches,
+		&c.Status,
+		&reviewer,
+		&reviewedAt,
+		&c.CreatedAt,
+	)
+	if err != nil {
+		return c, err
+	}
+
+	c.Reviewer = reviewer.String
+
+	if reviewedAt.Valid {
+		c.ReviewedAt = &reviewedAt.Time
+	}
+
+	return c, json.Unmarshal(matches, &c.Matches)
+}
+
+const createQuery = `
+INSERT INTO
+    compliance_cases (action, actor, username, name, matches)
+VALUES
+    ($1, $2, $3, $4, $5)
+ON CONFLICT (action, actor, username, name) WHERE status = 'open'
+DO UPDATE SET matches = EXCLUDED.matches
+RETURNING id, action, actor, username, name, matches, status, reviewer, reviewed_at, created_at
+`
+
+// Create opens the compliance case and then returns it. If the same action
+// of the same actor and name already has an open case, its matches are
+// updated and it is returned instead.
+func (r *RepoPGS) Create(ctx context.Context, arg domain.CreateComplianceCaseParams) (domain.ComplianceCase, error) {
+	l := zerolog.Ctx(ctx)
+
+	matches, err := json.Marshal(arg.Matches)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return domain.ComplianceCase{}, errorspkg.ErrInternal
+	}
+
+	row := r.db.QueryRowContext(ctx, createQuery,
+		arg.Action,
+		arg.Actor,
+		arg.Username,
+		arg.Name,
+		matches,
+	)
+
+	c, err := scanCase(row)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return c, errorspkg.ErrInternal
+	}
+
+	return c, nil
+}
+
+const getQuery = `
+SELECT
+	id, action, actor, username, name, matches, status, reviewer, reviewed_at, created_at
+FROM compliance_cases
+WHERE id = $1
+`
+
+// Get returns the compliance case with the given id.
+func (r *RepoPGS) Get(ctx context.Context, id int64) (domain.ComplianceCase, error) {
+	l := zerolog.Ctx(ctx)
+
+	c, err := scanCase(r.db.QueryRowContext(ctx, getQuery, id))
+	if err != nil {
+		l.Error().Err(err).Send()
+
+		if err == sql.ErrNoRows {
+			return c, domain.ErrComplianceCaseNotFound
+		}
+
+		return c, errorspkg.ErrInternal
+	}
+
+	return c, nil
+}
+
+const listQuery = `
+SELECT
+	id, action, actor, username, name, matches, status, reviewer, reviewed_at, created_at
+FROM compliance_cases
+WHERE ($1 = '' OR status = $1)
+    AND ($2 = 0 OR id > $2)
+    AND ($3 = 0 OR id < $3)
+ORDER BY CASE WHEN $3 = 0 THEN id END, id DESC
+LIMIT $4 OFFSET $5
+`
+
+// List returns the specified number of compliance cases with arg.Status, or
+// all of them if it is empty, ordered by id.
+//
+// If arg.BeforeID is set, the cases right before it are returned.
+func (r *RepoPGS) List(ctx context.Context, arg domain.ListComplianceCasesParams) ([]domain.ComplianceCase, error) {
+	l := zerolog.Ctx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, listQuery,
+		arg.Status,
+		arg.AfterID,
+		arg.BeforeID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+	defer rows.Close()
+
+	items := []domain.ComplianceCase{}
+
+	for rows.Next() {
+		c, err := scanCase(rows)
+		if err != nil {
+			l.Error().Err(err).Send()
+			return nil, errorspkg.ErrInternal
+		}
+
+		items = append(items, c)
+	}
+
+	if err := rows.Close(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	if err := rows.Err(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	if arg.BeforeID != 0 {
+		pagepkg.Reverse(items)
+	}
+
+	return items, nil
+}
+
+const closeQuery = `
+UPDATE compliance_cases
+SET status = $2, reviewer = $3, reviewed_at = now()
+WHERE id = $1 AND status = 'open'
+RETURNING id, action, actor, username, name, matches, status, reviewer, reviewed_at, created_at
+`
+
+// Close sets the final status of the open compliance case and returns it. It
+// returns domain.ErrComplianceCaseClosed if the case has already been closed.
+func (r *RepoPGS) Close(ctx context.Context, arg domain.CloseComplianceCaseParams) (domain.ComplianceCase, error) {
+	l := zerolog.Ctx(ctx)
+
+	c, err := scanCase(r.db.QueryRowContext(ctx, closeQuery, arg.ID, arg.Status, arg.Reviewer))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return c, domain.ErrComplianceCaseClosed
+		}
+
+		l.Error().Err(err).Send()
+
+		return c, errorspkg.ErrInternal
+	}
+
+	return c, nil
+}
+
+const isClearedQuery = `
+SELECT EXISTS (
+    SELECT 1 FROM compliance_cases
+    WHERE username = $1 AND name = $2 AND status = 'cleared'
+)
+`
+
+// IsCleared reports whether a compliance case of the user's name has been
+// cleared as a false positive.
+func (r *RepoPGS) IsCleared(ctx context.Context, username, name string) (bool, error) {
+	l := zerolog.Ctx(ctx)
+
+	var cleared bool
+
+	if err := r.db.QueryRowContext(ctx, isClearedQuery, username, name).Scan(&cleared); err != nil {
+		l.Error().Err(err).Send()
+		return false, errorspkg.ErrInternal
+	}
+
+	return cleared, nil
+}
+
+const getAccountHolderQuery = `
+SELECT u.username, u.full_name, u.email, u.role, u.created_at
+FROM accounts a
+JOIN users u ON u.username = a.owner
+WHERE a.id = $1
+`
+
+// GetAccountHolder returns the user who owns the account.
+func (r *RepoPGS) GetAccountHolder(ctx context.Context, accountID int32) (domain.UserWihtoutPassword, error) {
+	l := zerolog.Ctx(ctx)
+
+	var u domain.UserWihtoutPassword
+
+	err := r.db.QueryRowContext(ctx, getAccountHolderQuery, accountID).Scan(
+		&u.Username,
+		&u.FullName,
+		&u.Email,
+		&u.Role,
+		&u.CreatedAt,
+	)
+	if err != nil {
+		l.Error().Err(err).Send()
+
+		if err == sql.ErrNoRows {
+			return u, domain.ErrAccountNotFound
+		}
+
+		return u, errorspkg.ErrInternal
+	}
+
+	return u, nil
+}
@@ -0,0 +1,175 @@
+//go:build integration
+
+package screeningrepo_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/integrationtest"
+	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
+	"github.com/go-petr/pet-bank/internal/screeningrepo"
+	"github.com/go-petr/pet-bank/pkg/configpkg"
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	dbDriver string
+	dbSource string
+)
+
+func TestMain(m *testing.M) {
+	config, err := configpkg.Load("../../configs")
+	if err != nil {
+		log.Fatal("cannot load config:", err)
+	}
+
+	dbDriver = config.DBDriver
+	dbSource = config.DBSource
+
+	os.Exit(m.Run())
+}
+
+func TestCases(t *testing.T) {
+	t.Parallel()
+
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	screeningRepo := screeningrepo.NewRepoPGS(tx)
+	ctx := context.Background()
+
+	arg := domain.CreateComplianceCaseParams{
+		Action:   domain.ScreeningActionUserCreate,
+		Actor:    "abunidal",
+		Username: "abunidal",
+		Name:     "Abu Nidal",
+		Matches:  []domain.SanctionsMatch{{UID: "2674", Name: "ABU NIDAL", Score: 1}},
+	}
+
+	c, err := screeningRepo.Create(ctx, arg)
+	if err != nil {
+		t.Fatalf("screeningRepo.Create(ctx, %+v) returned error: %v", arg, err)
+	}
+
+	want := domain.ComplianceCase{
+		ID:        c.ID,
+		Action:    arg.Action,
+		Actor:     arg.Actor,
+		Username:  arg.Username,
+		Name:      arg.Name,
+		Matches:   arg.Matches,
+		Status:    domain.ComplianceCaseStatusOpen,
+		CreatedAt: c.CreatedAt,
+	}
+
+	if diff := cmp.Diff(want, c); diff != "" {
+		t.Errorf("screeningRepo.Create(ctx, %+v) returned unexpected difference (-want +got):\n%s", arg, diff)
+	}
+
+	// The repeated attempt updates the open case.
+	arg.Matches = []domain.SanctionsMatch{{UID: "2674", Name: "ABU NIDAL", Score: 0.95}}
+
+	again, err := screeningRepo.Create(ctx, arg)
+	if err != nil {
+		t.Fatalf("screeningRepo.Create(ctx, %+v) returned error: %v", arg, err)
+	}
+
+	want.Matches = arg.Matches
+
+	if diff := cmp.Diff(want, again); diff != "" {
+		t.Errorf("screeningRepo.Create(ctx, %+v) returned unexpected difference (-want +got):\n%s", arg, diff)
+	}
+
+	got, err := screeningRepo.Get(ctx, c.ID)
+	if err != nil {
+		t.Fatalf("screeningRepo.Get(ctx, %v) returned error: %v", c.ID, err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("screeningRepo.Get(ctx, %v) returned unexpected difference (-want +got):\n%s", c.ID, diff)
+	}
+
+	list := domain.ListComplianceCasesParams{Status: domain.ComplianceCaseStatusOpen, Limit: 1000}
+
+	cases, err := screeningRepo.List(ctx, list)
+	if err != nil {
+		t.Fatalf("screeningRepo.List(ctx, %+v) returned error: %v", list, err)
+	}
+
+	if len(cases) == 0 || cases[len(cases)-1].ID != c.ID {
+		t.Errorf("screeningRepo.List(ctx, %+v) doesn't end with case %v", list, c.ID)
+	}
+
+	cleared, err := screeningRepo.IsCleared(ctx, arg.Username, arg.Name)
+	if err != nil || cleared {
+		t.Errorf("screeningRepo.IsCleared(ctx, %v, %v) = %v, %v, want false, nil", arg.Username, arg.Name, cleared, err)
+	}
+
+	closeArg := domain.CloseComplianceCaseParams{ID: c.ID, Status: domain.ComplianceCaseStatusCleared, Reviewer: "admin"}
+
+	closed, err := screeningRepo.Close(ctx, closeArg)
+	if err != nil {
+		t.Fatalf("screeningRepo.Close(ctx, %+v) returned error: %v", closeArg, err)
+	}
+
+	if closed.Status != domain.ComplianceCaseStatusCleared || closed.Reviewer != "admin" || closed.ReviewedAt == nil {
+		t.Errorf("screeningRepo.Close(ctx, %+v) returned %+v", closeArg, closed)
+	}
+
+	if _, err := screeningRepo.Close(ctx, closeArg); err != domain.ErrComplianceCaseClosed {
+		t.Errorf("screeningRepo.Close(ctx, %+v) returned error: %v, want %v", closeArg, err, domain.ErrComplianceCaseClosed)
+	}
+
+	cleared, err = screeningRepo.IsCleared(ctx, arg.Username, arg.Name)
+	if err != nil || !cleared {
+		t.Errorf("screeningRepo.IsCleared(ctx, %v, %v) = %v, %v, want true, nil", arg.Username, arg.Name, cleared, err)
+	}
+
+	// A new case is opened once the previous one is closed.
+	reopened, err := screeningRepo.Create(ctx, arg)
+	if err != nil {
+		t.Fatalf("screeningRepo.Create(ctx, %+v) returned error: %v", arg, err)
+	}
+
+	if reopened.ID == c.ID {
+		t.Errorf("screeningRepo.Create(ctx, %+v) returned the closed case %v", arg, c.ID)
+	}
+
+	if _, err := screeningRepo.Get(ctx, -1); err != domain.ErrComplianceCaseNotFound {
+		t.Errorf("screeningRepo.Get(ctx, -1) returned error: %v, want %v", err, domain.ErrComplianceCaseNotFound)
+	}
+}
+
+func TestGetAccountHolder(t *testing.T) {
+	t.Parallel()
+
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	screeningRepo := screeningrepo.NewRepoPGS(tx)
+	ctx := context.Background()
+
+	user := helpers.SeedUser(t, tx)
+	account := helpers.SeedAccountWith1000USDBalance(t, tx, user.Username)
+
+	got, err := screeningRepo.GetAccountHolder(ctx, account.ID)
+	if err != nil {
+		t.Fatalf("screeningRepo.GetAccountHolder(ctx, %v) returned error: %v", account.ID, err)
+	}
+
+	want := domain.UserWihtoutPassword{
+		Username:  user.Username,
+		FullName:  user.FullName,
+		Email:     user.Email,
+		Role:      user.Role,
+		CreatedAt: got.CreatedAt,
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("screeningRepo.GetAccountHolder(ctx, %v) returned unexpected difference (-want +got):\n%s", account.ID, diff)
+	}
+
+	if _, err := screeningRepo.GetAccountHolder(ctx, -1); err != domain.ErrAccountNotFound {
+		t.Errorf("screeningRepo.GetAccountHolder(ctx, -1) returned error: %v, want %v", err, domain.ErrAccountNotFound)
+	}
+}
@@ -0,0 +1,281 @@
+// Package screeningservice manages business logic layer of the sanctions
+// screening.
+package screeningservice
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
+	"github.com/go-petr/pet-bank/pkg/sanctionspkg"
+	"github.com/rs/zerolog"
+)
+
+// Repo provides data access layer interface needed by screening service layer.
+//
+//go:generate mockgen -source service.go -destination service_mock.go -package screeningservice
+type Repo interface {
+	Create(ctx context.Context, arg domain.CreateComplianceCaseParams) (domain.ComplianceCase, error)
+	Get(ctx context.Context, id int64) (domain.ComplianceCase, error)
+	List(ctx context.Context, arg domain.ListComplianceCasesParams) ([]domain.ComplianceCase, error)
+	Close(ctx context.Context, arg domain.CloseComplianceCaseParams) (domain.ComplianceCase, error)
+	IsCleared(ctx context.Context, username, name string) (bool, error)
+	GetAccountHolder(ctx context.Context, accountID int32) (domain.UserWihtoutPassword, error)
+}
+
+// Auditor records audit events of the sanctions screening.
+type Auditor interface {
+	Record(ctx context.Context, eventType, actor string, before, after any)
+}
+
+// Service facilitates sanctions screening service layer logic.
+//
+// The sanctions list is reread once its file changes, so that it can be
+// updated without a restart.
+type Service struct {
+	repo      Repo
+	auditor   Auditor
+	path      string
+	threshold float64
+
+	mu       sync.RWMutex
+	list     *sanctionspkg.List
+	modTime  time.Time
+	loadedAt time.Time
+}
+
+// New returns sanctions screening service struct with the list read from the
+// file at path, in a format supported by sanctionspkg.Load. Names which
+// similarity to a listed name is at least threshold, from 0 to 1, are hits.
+//
+// Names are not screened if path is empty. Audit events are not recorded if a
+// is nil.
+func New(r Repo, a Auditor, path string, threshold float64) (*Service, error) {
+	s := &Service{
+		repo:      r,
+		auditor:   a,
+		path:      path,
+		threshold: threshold,
+	}
+
+	if path == "" {
+		return s, nil
+	}
+
+	if threshold <= 0 || threshold > 1 {
+		return nil, fmt.Errorf("invalid sanctions match threshold %v: must be in (0, 1]", threshold)
+	}
+
+	if _, err := s.load(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *Service) audit(ctx context.Context, eventType, actor string, before, after any) {
+	if s.auditor != nil {
+		s.auditor.Record(ctx, eventType, actor, before, after)
+	}
+}
+
+// load reads the list file. The list is replaced only if the file is valid,
+// but the file modification time is recorded either way, so that the broken
+// file is not reread until it changes again.
+func (s *Service) load() (domain.SanctionsList, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return domain.SanctionsList{}, err
+	}
+
+	s.modTime = info.ModTime()
+
+	list, err := sanctionspkg.Load(s.path)
+	if err != nil {
+		return domain.SanctionsList{}, err
+	}
+
+	s.list = list
+	s.loadedAt = time.Now().UTC()
+
+	return domain.SanctionsList{File: s.path, Entries: list.Len(), LoadedAt: s.loadedAt}, nil
+}
+
+// current returns the sanctions list, reread if its file has changed since it
+// was loaded. The loaded list is kept if the file can't be read.
+func (s *Service) current(ctx context.Context) *sanctionspkg.List {
+	l := zerolog.Ctx(ctx)
+
+	s.mu.RLock()
+	list, modTime := s.list, s.modTime
+	s.mu.RUnlock()
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		l.Error().Err(err).Msg("Cannot check sanctions list file")
+		return list
+	}
+
+	if info.ModTime().Equal(modTime) {
+		return list
+	}
+
+	loaded, err := s.load()
+	if err != nil {
+		l.Error().Err(err).Msg("Cannot reload sanctions list, the loaded one is used")
+		return list
+	}
+
+	l.Info().Int("entries", loaded.Entries).Msg("Sanctions list reloaded")
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.list
+}
+
+// Reload rereads the sanctions list file. The loaded list is kept if the file
+// is invalid. actor is the staff member who reloaded the list.
+func (s *Service) Reload(ctx context.Context, actor string) (domain.SanctionsList, error) {
+	l := zerolog.Ctx(ctx)
+
+	if s.path == "" {
+		l.Info().Err(domain.ErrSanctionsListNotConfigured).Send()
+		return domain.SanctionsList{}, domain.ErrSanctionsListNotConfigured
+	}
+
+	loaded, err := s.load()
+	if err != nil {
+		l.Error().Err(err).Send()
+		return domain.SanctionsList{}, domain.ErrInvalidSanctionsList
+	}
+
+	s.audit(ctx, domain.AuditSanctionsListReloaded, actor, nil, loaded)
+
+	return loaded, nil
+}
+
+// ScreenUser screens the full name of the user being created.
+//
+// It returns domain.ErrSanctionsHit and opens a compliance case if the name
+// matches the sanctions list, unless a case of the same name and username has
+// been cleared.
+func (s *Service) ScreenUser(ctx context.Context, username, fullName string) error {
+	return s.screen(ctx, domain.CreateComplianceCaseParams{
+		Action:   domain.ScreeningActionUserCreate,
+		Actor:    username,
+		Username: username,
+		Name:     fullName,
+	})
+}
+
+// ScreenRecipient screens the full name of the holder of the account the user
+// is paying, the same way as ScreenUser.
+func (s *Service) ScreenRecipient(ctx context.Context, fromUsername string, toAccountID int32) error {
+	if s.path == "" {
+		return nil
+	}
+
+	holder, err := s.repo.GetAccountHolder(ctx, toAccountID)
+	if err != nil {
+		return err
+	}
+
+	return s.screen(ctx, domain.CreateComplianceCaseParams{
+		Action:   domain.ScreeningActionTransfer,
+		Actor:    fromUsername,
+		Username: holder.Username,
+		Name:     holder.FullName,
+	})
+}
+
+func (s *Service) screen(ctx context.Context, arg domain.CreateComplianceCaseParams) error {
+	l := zerolog.Ctx(ctx)
+
+	if s.path == "" {
+		return nil
+	}
+
+	found := s.current(ctx).Match(arg.Name, s.threshold)
+	if len(found) == 0 {
+		return nil
+	}
+
+	cleared, err := s.repo.IsCleared(ctx, arg.Username, arg.Name)
+	if err != nil {
+		return err
+	}
+
+	if cleared {
+		l.Info().Str("username", arg.Username).Msg("Sanctions match has been cleared")
+		return nil
+	}
+
+	for _, m := range found {
+		arg.Matches = append(arg.Matches, domain.SanctionsMatch{UID: m.UID, Name: m.Name, Score: m.Score})
+	}
+
+	c, err := s.repo.Create(ctx, arg)
+	if err != nil {
+		return err
+	}
+
+	l.Warn().Err(domain.ErrSanctionsHit).Int64("compliance_case_id", c.ID).Str("action", arg.Action).Send()
+
+	s.audit(ctx, domain.AuditSanctionsHit, arg.Actor, nil, c)
+
+	return domain.ErrSanctionsHit
+}
+
+// GetCase returns the compliance case with the given id.
+func (s *Service) GetCase(ctx context.Context, id int64) (domain.ComplianceCase, error) {
+	return s.repo.Get(ctx, id)
+}
+
+// ListCases returns the page of the compliance cases with the given status,
+// or all of them if it is empty.
+func (s *Service) ListCases(ctx context.Context, status string, page pagepkg.Request) ([]domain.ComplianceCase, pagepkg.Page, error) {
+	cases, err := s.repo.List(ctx, domain.ListComplianceCasesParams{
+		Status:   status,
+		AfterID:  page.Cursor.AfterID,
+		BeforeID: page.Cursor.BeforeID,
+		Limit:    page.Limit(),
+		Offset:   page.Offset(),
+	})
+	if err != nil {
+		return nil, pagepkg.Page{}, err
+	}
+
+	cases, p := pagepkg.Trim(cases, page, func(c domain.ComplianceCase) int64 { return c.ID })
+
+	return cases, p, nil
+}
+
+// CloseCase clears the open compliance case as a false positive or confirms
+// it. reviewer is the staff member who closed it.
+func (s *Service) CloseCase(ctx context.Context, reviewer string, id int64, status string) (domain.ComplianceCase, error) {
+	c, err := s.repo.Close(ctx, domain.CloseComplianceCaseParams{
+		ID:       id,
+		Status:   status,
+		Reviewer: reviewer,
+	})
+	if err != nil {
+		return c, err
+	}
+
+	eventType := domain.AuditComplianceCaseConfirmed
+	if status == domain.ComplianceCaseStatusCleared {
+		eventType = domain.AuditComplianceCaseCleared
+	}
+
+	s.audit(ctx, eventType, reviewer, nil, c)
+
+	return c, nil
+}
@@ -0,0 +1,161 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package screeningservice is a generated GoMock package.
+package screeningservice
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/go-petr/pet-bank/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepo is a mock of Repo interface.
+type MockRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoMockRecorder
+}
+
+// MockRepoMockRecorder is the mock recorder for MockRepo.
+type MockRepoMockRecorder struct {
+	mock *MockRepo
+}
+
+// NewMockRepo creates a new mock instance.
+func NewMockRepo(ctrl *gomock.Controller) *MockRepo {
+	mock := &MockRepo{ctrl: ctrl}
+	mock.recorder = &MockRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockRepo) Close(ctx context.Context, arg domain.CloseComplianceCaseParams) (domain.ComplianceCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", ctx, arg)
+	ret0, _ := ret[0].(domain.ComplianceCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockRepoMockRecorder) Close(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockRepo)(nil).Close), ctx, arg)
+}
+
+// Create mocks base method.
+func (m *MockRepo) Create(ctx context.Context, arg domain.CreateComplianceCaseParams) (domain.ComplianceCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, arg)
+	ret0, _ := ret[0].(domain.ComplianceCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepoMockRecorder) Create(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepo)(nil).Create), ctx, arg)
+}
+
+// Get mocks base method.
+func (m *MockRepo) Get(ctx context.Context, id int64) (domain.ComplianceCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(domain.ComplianceCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepoMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepo)(nil).Get), ctx, id)
+}
+
+// GetAccountHolder mocks base method.
+func (m *MockRepo) GetAccountHolder(ctx context.Context, accountID int32) (domain.UserWihtoutPassword, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountHolder", ctx, accountID)
+	ret0, _ := ret[0].(domain.UserWihtoutPassword)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountHolder indicates an expected call of GetAccountHolder.
+func (mr *MockRepoMockRecorder) GetAccountHolder(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountHolder", reflect.TypeOf((*MockRepo)(nil).GetAccountHolder), ctx, accountID)
+}
+
+// IsCleared mocks base method.
+func (m *MockRepo) IsCleared(ctx context.Context, username, name string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCleared", ctx, username, name)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsCleared indicates an expected call of IsCleared.
+func (mr *MockRepoMockRecorder) IsCleared(ctx, username, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCleared", reflect.TypeOf((*MockRepo)(nil).IsCleared), ctx, username, name)
+}
+
+// List mocks base method.
+func (m *MockRepo) List(ctx context.Context, arg domain.ListComplianceCasesParams) ([]domain.ComplianceCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, arg)
+	ret0, _ := ret[0].([]domain.ComplianceCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepoMockRecorder) List(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepo)(nil).List), ctx, arg)
+}
+
+// MockAuditor is a mock of Auditor interface.
+type MockAuditor struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditorMockRecorder
+}
+
+// MockAuditorMockRecorder is the mock recorder for MockAuditor.
+type MockAuditorMockRecorder struct {
+	mock *MockAuditor
+}
+
+// NewMockAuditor creates a new mock instance.
+func NewMockAuditor(ctrl *gomock.Controller) *MockAuditor {
+	mock := &MockAuditor{ctrl: ctrl}
+	mock.recorder = &MockAuditorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditor) EXPECT() *MockAuditorMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockAuditor) Record(ctx context.Context, eventType, actor string, before, after any) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", ctx, eventType, actor, before, after)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAuditorMockRecorder) Record(ctx, eventType, actor, before, after interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAuditor)(nil).Record), ctx, eventType, actor, before, after)
+}
@@ -0,0 +1,282 @@
+package screeningservice
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+)
+
+const testList = `2674,"ABU NIDAL","individual","SDT] [SDGT",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,"a.k.a. 'AL-BANNA, Sabri Khalil'."
+`
+
+func writeList(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("os.WriteFile(%v) returned error: %v", path, err)
+	}
+}
+
+func newTestService(t *testing.T, r Repo) *Service {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "sdn.csv")
+	writeList(t, path, testList)
+
+	s, err := New(r, nil, path, 0.9)
+	if err != nil {
+		t.Fatalf("New(%v) returned error: %v", path, err)
+	}
+
+	return s
+}
+
+func TestNew(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sdn.csv")
+	writeList(t, path, testList)
+
+	testCases := []struct {
+		name      string
+		path      string
+		threshold float64
+		wantErr   bool
+	}{
+		{name: "OK", path: path, threshold: 0.9},
+		{name: "ExampleList", path: "../../configs/sanctions_list.csv", threshold: 0.9},
+		{name: "NotConfigured", path: ""},
+		{name: "MissingFile", path: path + ".missing", threshold: 0.9, wantErr: true},
+		{name: "ZeroThreshold", path: path, threshold: 0, wantErr: true},
+		{name: "ThresholdAboveOne", path: path, threshold: 1.1, wantErr: true},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(nil, nil, tc.path, tc.threshold)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New(%v, %v) returned error: %v, want error: %v", tc.path, tc.threshold, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestScreenUser(t *testing.T) {
+	hit := domain.CreateComplianceCaseParams{
+		Action:   domain.ScreeningActionUserCreate,
+		Actor:    "sabri",
+		Username: "sabri",
+		Name:     "Sabri Khalil al-Banna",
+		Matches:  []domain.SanctionsMatch{{UID: "2674", Name: "AL-BANNA, Sabri Khalil", Score: 1}},
+	}
+
+	testCases := []struct {
+		name       string
+		fullName   string
+		buildStubs func(r *MockRepo)
+		wantErr    error
+	}{
+		{
+			name:     "NoMatch",
+			fullName: "John Smith",
+			buildStubs: func(r *MockRepo) {
+				r.EXPECT().IsCleared(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				r.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name:     "Hit",
+			fullName: hit.Name,
+			buildStubs: func(r *MockRepo) {
+				r.EXPECT().IsCleared(gomock.Any(), gomock.Eq(hit.Username), gomock.Eq(hit.Name)).Return(false, nil)
+				r.EXPECT().Create(gomock.Any(), gomock.Eq(hit)).Return(domain.ComplianceCase{ID: 1}, nil)
+			},
+			wantErr: domain.ErrSanctionsHit,
+		},
+		{
+			name:     "Cleared",
+			fullName: hit.Name,
+			buildStubs: func(r *MockRepo) {
+				r.EXPECT().IsCleared(gomock.Any(), gomock.Eq(hit.Username), gomock.Eq(hit.Name)).Return(true, nil)
+				r.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name:     "CreateError",
+			fullName: hit.Name,
+			buildStubs: func(r *MockRepo) {
+				r.EXPECT().IsCleared(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				r.EXPECT().Create(gomock.Any(), gomock.Any()).Return(domain.ComplianceCase{}, errorspkg.ErrInternal)
+			},
+			wantErr: errorspkg.ErrInternal,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepo(ctrl)
+			tc.buildStubs(repo)
+
+			s := newTestService(t, repo)
+
+			if err := s.ScreenUser(context.Background(), hit.Username, tc.fullName); err != tc.wantErr {
+				t.Errorf("s.ScreenUser(ctx, %v, %v) returned error: %v, want %v", hit.Username, tc.fullName, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestScreenRecipient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := NewMockRepo(ctrl)
+
+	holder := domain.UserWihtoutPassword{Username: "abunidal", FullName: "Abu Nidal"}
+	want := domain.CreateComplianceCaseParams{
+		Action:   domain.ScreeningActionTransfer,
+		Actor:    "alice",
+		Username: holder.Username,
+		Name:     holder.FullName,
+		Matches:  []domain.SanctionsMatch{{UID: "2674", Name: "ABU NIDAL", Score: 1}},
+	}
+
+	repo.EXPECT().GetAccountHolder(gomock.Any(), gomock.Eq(int32(7))).Return(holder, nil)
+	repo.EXPECT().IsCleared(gomock.Any(), gomock.Eq(holder.Username), gomock.Eq(holder.FullName)).Return(false, nil)
+	repo.EXPECT().Create(gomock.Any(), gomock.Eq(want)).Return(domain.ComplianceCase{ID: 1}, nil)
+
+	s := newTestService(t, repo)
+
+	if err := s.ScreenRecipient(context.Background(), "alice", 7); err != domain.ErrSanctionsHit {
+		t.Errorf("s.ScreenRecipient(ctx, alice, 7) returned error: %v, want %v", err, domain.ErrSanctionsHit)
+	}
+
+	repo.EXPECT().GetAccountHolder(gomock.Any(), gomock.Any()).Return(domain.UserWihtoutPassword{}, domain.ErrAccountNotFound)
+
+	if err := s.ScreenRecipient(context.Background(), "alice", 8); err != domain.ErrAccountNotFound {
+		t.Errorf("s.ScreenRecipient(ctx, alice, 8) returned error: %v, want %v", err, domain.ErrAccountNotFound)
+	}
+}
+
+func TestNotConfigured(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := NewMockRepo(ctrl)
+
+	s, err := New(repo, nil, "", 0)
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+
+	ctx := context.Background()
+
+	if err := s.ScreenUser(ctx, "abunidal", "Abu Nidal"); err != nil {
+		t.Errorf("s.ScreenUser(ctx, abunidal, Abu Nidal) returned error: %v", err)
+	}
+
+	if err := s.ScreenRecipient(ctx, "alice", 7); err != nil {
+		t.Errorf("s.ScreenRecipient(ctx, alice, 7) returned error: %v", err)
+	}
+
+	if _, err := s.Reload(ctx, "admin"); err != domain.ErrSanctionsListNotConfigured {
+		t.Errorf("s.Reload(ctx, admin) returned error: %v, want %v", err, domain.ErrSanctionsListNotConfigured)
+	}
+}
+
+func TestReload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := NewMockRepo(ctrl)
+	repo.EXPECT().IsCleared(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+	repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(domain.ComplianceCase{ID: 1}, nil).AnyTimes()
+
+	path := filepath.Join(t.TempDir(), "sdn.csv")
+	writeList(t, path, testList)
+
+	s, err := New(repo, nil, path, 0.9)
+	if err != nil {
+		t.Fatalf("New(%v) returned error: %v", path, err)
+	}
+
+	ctx := context.Background()
+
+	// touch sets a later modification time, which the coarse clock of some
+	// file systems wouldn't change.
+	modTime := time.Now()
+	touch := func() {
+		t.Helper()
+
+		modTime = modTime.Add(time.Second)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("os.Chtimes(%v) returned error: %v", path, err)
+		}
+	}
+
+	// The changed file is reread on screening.
+	writeList(t, path, testList+`7,"JOHN DOE",-0- ,"SDGT"`+"\n")
+	touch()
+
+	if err := s.ScreenUser(ctx, "johndoe", "John Doe"); err != domain.ErrSanctionsHit {
+		t.Errorf("s.ScreenUser(ctx, johndoe, John Doe) returned error: %v, want %v", err, domain.ErrSanctionsHit)
+	}
+
+	// The broken file is ignored.
+	writeList(t, path, `7,-0- `+"\n")
+	touch()
+
+	if err := s.ScreenUser(ctx, "johndoe", "John Doe"); err != domain.ErrSanctionsHit {
+		t.Errorf("s.ScreenUser(ctx, johndoe, John Doe) with broken list returned error: %v, want %v", err, domain.ErrSanctionsHit)
+	}
+
+	if _, err := s.Reload(ctx, "admin"); err != domain.ErrInvalidSanctionsList {
+		t.Errorf("s.Reload(ctx, admin) returned error: %v, want %v", err, domain.ErrInvalidSanctionsList)
+	}
+
+	writeList(t, path, testList)
+	touch()
+
+	got, err := s.Reload(ctx, "admin")
+	if err != nil {
+		t.Fatalf("s.Reload(ctx, admin) returned error: %v", err)
+	}
+
+	want := domain.SanctionsList{File: path, Entries: 1, LoadedAt: got.LoadedAt}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("s.Reload(ctx, admin) returned unexpected difference (-want +got):\n%s", diff)
+	}
+
+	if err := s.ScreenUser(ctx, "johndoe", "John Doe"); err != nil {
+		t.Errorf("s.ScreenUser(ctx, johndoe, John Doe) returned error: %v", err)
+	}
+}
+
+func TestCloseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := NewMockRepo(ctrl)
+
+	arg := domain.CloseComplianceCaseParams{ID: 1, Status: domain.ComplianceCaseStatusCleared, Reviewer: "admin"}
+	want := domain.ComplianceCase{ID: 1, Status: domain.ComplianceCaseStatusCleared, Reviewer: "admin"}
+
+	repo.EXPECT().Close(gomock.Any(), gomock.Eq(arg)).Return(want, nil)
+
+	s := newTestService(t, repo)
+
+	got, err := s.CloseCase(context.Background(), arg.Reviewer, arg.ID, arg.Status)
+	if err != nil {
+		t.Fatalf("s.CloseCase(ctx, %+v) returned error: %v", arg, err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("s.CloseCase(ctx, %+v) returned unexpected difference (-want +got):\n%s", arg, diff)
+	}
+}
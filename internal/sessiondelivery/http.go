@@ -9,10 +9,12 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/middleware"
 	"github.com/go-petr/pet-bank/pkg/errorspkg"
 	"github.com/go-petr/pet-bank/pkg/tokenpkg"
 	"github.com/go-petr/pet-bank/pkg/web"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 )
 
@@ -20,7 +22,11 @@ import (
 //
 //go:generate mockgen -source http.go -destination http_mock.go -package sessiondelivery
 type Service interface {
-	RenewAccessToken(ctx context.Context, refreshToken string) (string, time.Time, error)
+	RenewAccessToken(ctx context.Context, refreshToken string) (string, time.Time, domain.Session, error)
+	Logout(ctx context.Context, username, refreshToken string) error
+	List(ctx context.Context, username string) ([]domain.Session, error)
+	Revoke(ctx context.Context, username string, id uuid.UUID) error
+	RevokeAll(ctx context.Context, username string) (int64, error)
 }
 
 // Handler facilitates session delivery layer logic.
@@ -40,6 +46,8 @@ type renewAccessTokenRequest struct {
 }
 
 // RenewAccessToken handles http request to renew access token.
+//
+// The refresh token is rotated, so the new one must be used for the next renewal.
 func (h *Handler) RenewAccessToken(gctx *gin.Context) {
 	ctx := gctx.Request.Context()
 	l := zerolog.Ctx(ctx)
@@ -59,7 +67,7 @@ func (h *Handler) RenewAccessToken(gctx *gin.Context) {
 		return
 	}
 
-	accessToken, accessTokenExpiresAt, err := h.service.RenewAccessToken(ctx, req.RefreshToken)
+	accessToken, accessTokenExpiresAt, sess, err := h.service.RenewAccessToken(ctx, req.RefreshToken)
 	if err != nil {
 		switch err {
 		case
@@ -72,6 +80,7 @@ func (h *Handler) RenewAccessToken(gctx *gin.Context) {
 			domain.ErrBlockedSession,
 			domain.ErrInvalidUser,
 			domain.ErrMismatchedRefreshToken,
+			domain.ErrRefreshTokenReused,
 			domain.ErrExpiredSession:
 			gctx.JSON(http.StatusForbidden, web.Error(err))
 			return
@@ -84,8 +93,156 @@ func (h *Handler) RenewAccessToken(gctx *gin.Context) {
 	}
 
 	res := web.Response{
-		AccessToken:          accessToken,
-		AccessTokenExpiresAt: &accessTokenExpiresAt,
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  &accessTokenExpiresAt,
+		RefreshToken:          sess.RefreshToken,
+		RefreshTokenExpiresAt: &sess.ExpiresAt,
 	}
 	gctx.JSON(http.StatusCreated, res)
 }
+
+type logoutRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// Logout handles http request to end the session of the given refresh token.
+func (h *Handler) Logout(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+	l := zerolog.Ctx(ctx)
+
+	var req logoutRequest
+	if err := gctx.ShouldBindJSON(&req); err != nil {
+		var ve validator.ValidationErrors
+		if errors.As(err, &ve) {
+			gctx.JSON(http.StatusBadRequest, web.Response{Error: web.GetErrorMsg(ve)})
+
+			return
+		}
+
+		l.Error().Err(err).Send()
+		gctx.JSON(http.StatusBadRequest, web.Error(errorspkg.ErrInternal))
+
+		return
+	}
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	if err := h.service.Logout(ctx, authPayload.Username, req.RefreshToken); err != nil {
+		h.revokeError(gctx, err)
+		return
+	}
+
+	gctx.Status(http.StatusNoContent)
+}
+
+// sessionResponse is the session data that is safe to show to the user.
+type sessionResponse struct {
+	ID        uuid.UUID `json:"id"`
+	UserAgent string    `json:"user_agent"`
+	ClientIP  string    `json:"client_ip"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// List handles http request to list the user's active sessions.
+func (h *Handler) List(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	sessions, err := h.service.List(ctx, authPayload.Username)
+	if err != nil {
+		gctx.JSON(http.StatusInternalServerError, web.Error(errorspkg.ErrInternal))
+		return
+	}
+
+	items := make([]sessionResponse, len(sessions))
+	for i, s := range sessions {
+		items[i] = sessionResponse{
+			ID:        s.ID,
+			UserAgent: s.UserAgent,
+			ClientIP:  s.ClientIP,
+			ExpiresAt: s.ExpiresAt,
+			CreatedAt: s.CreatedAt,
+		}
+	}
+
+	res := web.Response{
+		Data: &struct {
+			Sessions []sessionResponse `json:"sessions"`
+		}{
+			Sessions: items,
+		},
+	}
+
+	gctx.JSON(http.StatusOK, res)
+}
+
+type revokeRequest struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// Revoke handles http request to end the given session of the user.
+func (h *Handler) Revoke(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+	l := zerolog.Ctx(ctx)
+
+	var req revokeRequest
+	if err := gctx.ShouldBindUri(&req); err != nil {
+		l.Info().Err(err).Send()
+
+		var ve validator.ValidationErrors
+		if errors.As(err, &ve) {
+			gctx.JSON(http.StatusBadRequest, web.Response{Error: web.GetErrorMsg(ve)})
+
+			return
+		}
+
+		gctx.JSON(http.StatusBadRequest, web.Error(err))
+
+		return
+	}
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	if err := h.service.Revoke(ctx, authPayload.Username, uuid.MustParse(req.ID)); err != nil {
+		h.revokeError(gctx, err)
+		return
+	}
+
+	gctx.Status(http.StatusNoContent)
+}
+
+// RevokeAll handles http request to end all sessions of the user.
+func (h *Handler) RevokeAll(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	if _, err := h.service.RevokeAll(ctx, authPayload.Username); err != nil {
+		gctx.JSON(http.StatusInternalServerError, web.Error(errorspkg.ErrInternal))
+		return
+	}
+
+	gctx.Status(http.StatusNoContent)
+}
+
+func (h *Handler) revokeError(gctx *gin.Context, err error) {
+	switch err {
+	case domain.ErrSessionNotFound:
+		gctx.JSON(http.StatusNotFound, web.Error(err))
+		return
+	case domain.ErrSessionOwnerMismatch:
+		gctx.JSON(http.StatusUnauthorized, web.Error(err))
+		return
+	case
+		tokenpkg.ErrExpiredToken,
+		tokenpkg.ErrInvalidToken,
+		domain.ErrMismatchedRefreshToken:
+		gctx.JSON(http.StatusForbidden, web.Error(err))
+		return
+	}
+
+	zerolog.Ctx(gctx.Request.Context()).Info().Err(err).Send()
+	gctx.JSON(http.StatusInternalServerError, web.Error(errorspkg.ErrInternal))
+}
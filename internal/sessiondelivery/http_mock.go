@@ -9,7 +9,9 @@ import (
 	reflect "reflect"
 	time "time"
 
+	domain "github.com/go-petr/pet-bank/internal/domain"
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 )
 
 // MockService is a mock of Service interface.
@@ -35,14 +37,44 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// List mocks base method.
+func (m *MockService) List(ctx context.Context, username string) ([]domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, username)
+	ret0, _ := ret[0].([]domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockServiceMockRecorder) List(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List), ctx, username)
+}
+
+// Logout mocks base method.
+func (m *MockService) Logout(ctx context.Context, username, refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, username, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockServiceMockRecorder) Logout(ctx, username, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockService)(nil).Logout), ctx, username, refreshToken)
+}
+
 // RenewAccessToken mocks base method.
-func (m *MockService) RenewAccessToken(ctx context.Context, refreshToken string) (string, time.Time, error) {
+func (m *MockService) RenewAccessToken(ctx context.Context, refreshToken string) (string, time.Time, domain.Session, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RenewAccessToken", ctx, refreshToken)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(time.Time)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret2, _ := ret[2].(domain.Session)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
 }
 
 // RenewAccessToken indicates an expected call of RenewAccessToken.
@@ -50,3 +82,32 @@ func (mr *MockServiceMockRecorder) RenewAccessToken(ctx, refreshToken interface{
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewAccessToken", reflect.TypeOf((*MockService)(nil).RenewAccessToken), ctx, refreshToken)
 }
+
+// Revoke mocks base method.
+func (m *MockService) Revoke(ctx context.Context, username string, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, username, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockServiceMockRecorder) Revoke(ctx, username, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockService)(nil).Revoke), ctx, username, id)
+}
+
+// RevokeAll mocks base method.
+func (m *MockService) RevokeAll(ctx context.Context, username string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", ctx, username)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockServiceMockRecorder) RevokeAll(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockService)(nil).RevokeAll), ctx, username)
+}
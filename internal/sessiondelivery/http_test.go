@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/middleware"
 	"github.com/go-petr/pet-bank/pkg/errorspkg"
 	"github.com/go-petr/pet-bank/pkg/randompkg"
 	"github.com/go-petr/pet-bank/pkg/tokenpkg"
@@ -16,6 +18,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
 )
 
 func TestRenewAccessToken(t *testing.T) {
@@ -38,6 +41,13 @@ func TestRenewAccessToken(t *testing.T) {
 		t.Fatalf("tokenMaker.CreateToken(%v, %v) returned error: %v", username, duration, err)
 	}
 
+	sess := domain.Session{
+		ID:           uuid.New(),
+		Username:     username,
+		RefreshToken: randompkg.String(32),
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}
+
 	testCases := []struct {
 		name           string
 		requestBody    requestBody
@@ -55,15 +65,17 @@ func TestRenewAccessToken(t *testing.T) {
 				service.EXPECT().
 					RenewAccessToken(gomock.Any(), token).
 					Times(1).
-					Return(token, payload.ExpiredAt, nil)
+					Return(token, payload.ExpiredAt, sess, nil)
 			},
 			wantStatusCode: http.StatusCreated,
 			checkData: func(t *testing.T, got web.Response) {
 				t.Helper()
 
 				want := web.Response{
-					AccessToken:          token,
-					AccessTokenExpiresAt: &payload.ExpiredAt,
+					AccessToken:           token,
+					AccessTokenExpiresAt:  &payload.ExpiredAt,
+					RefreshToken:          sess.RefreshToken,
+					RefreshTokenExpiresAt: &sess.ExpiresAt,
 				}
 
 				compareCreatedAt := cmpopts.EquateApproxTime(time.Second)
@@ -94,11 +106,25 @@ func TestRenewAccessToken(t *testing.T) {
 				service.EXPECT().
 					RenewAccessToken(gomock.Any(), token).
 					Times(1).
-					Return("", time.Now(), errorspkg.ErrInternal)
+					Return("", time.Now(), domain.Session{}, errorspkg.ErrInternal)
 			},
 			wantStatusCode: http.StatusInternalServerError,
 			wantError:      errorspkg.ErrInternal.Error(),
 		},
+		{
+			name: "ErrRefreshTokenReused",
+			requestBody: requestBody{
+				RefreshToken: token,
+			},
+			buildStubs: func(service *MockService) {
+				service.EXPECT().
+					RenewAccessToken(gomock.Any(), token).
+					Times(1).
+					Return("", time.Time{}, domain.Session{}, domain.ErrRefreshTokenReused)
+			},
+			wantStatusCode: http.StatusForbidden,
+			wantError:      domain.ErrRefreshTokenReused.Error(),
+		},
 	}
 
 	for i := range testCases {
@@ -157,3 +183,331 @@ func TestRenewAccessToken(t *testing.T) {
 		})
 	}
 }
+
+func TestLogout(t *testing.T) {
+	tokenMaker, err := tokenpkg.NewPasetoMaker(randompkg.String(32))
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(...) returned error: %v", err)
+	}
+
+	username := randompkg.Owner()
+	refreshToken := randompkg.String(32)
+
+	testCases := []struct {
+		name           string
+		body           string
+		buildStubs     func(service *MockService)
+		wantStatusCode int
+		wantError      string
+	}{
+		{
+			name: "OK",
+			body: `{"refresh_token":"` + refreshToken + `"}`,
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Logout(gomock.Any(), username, refreshToken).Times(1).Return(nil)
+			},
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name: "RequiredRefreshToken",
+			body: `{}`,
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Logout(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "RefreshToken field is required",
+		},
+		{
+			name: "ErrSessionOwnerMismatch",
+			body: `{"refresh_token":"` + refreshToken + `"}`,
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Logout(gomock.Any(), username, refreshToken).Times(1).
+					Return(domain.ErrSessionOwnerMismatch)
+			},
+			wantStatusCode: http.StatusUnauthorized,
+			wantError:      domain.ErrSessionOwnerMismatch.Error(),
+		},
+		{
+			name: "ErrMismatchedRefreshToken",
+			body: `{"refresh_token":"` + refreshToken + `"}`,
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Logout(gomock.Any(), username, refreshToken).Times(1).
+					Return(domain.ErrMismatchedRefreshToken)
+			},
+			wantStatusCode: http.StatusForbidden,
+			wantError:      domain.ErrMismatchedRefreshToken.Error(),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sessionService := NewMockService(ctrl)
+			sessionHandler := NewHandler(sessionService)
+			tc.buildStubs(sessionService)
+
+			server := gin.New()
+			server.Use(middleware.AuthMiddleware(tokenMaker, nil))
+			server.DELETE("/sessions/current", sessionHandler.Logout)
+
+			req, err := http.NewRequest(http.MethodDelete, "/sessions/current", bytes.NewReader([]byte(tc.body)))
+			if err != nil {
+				t.Fatalf("Creating request error: %v", err)
+			}
+
+			if err := middleware.AddAuthorization(req, tokenMaker, middleware.AuthTypeBearer, username, time.Minute); err != nil {
+				t.Fatalf("middleware.AddAuthorization(...) returned error: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			if got := w.Code; got != tc.wantStatusCode {
+				t.Errorf("Status code: got %v, want %v", got, tc.wantStatusCode)
+			}
+
+			if tc.wantError == "" {
+				return
+			}
+
+			var res web.Response
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Fatalf("Decoding response body error: %v", err)
+			}
+
+			if res.Error != tc.wantError {
+				t.Errorf(`res.Error=%q, want %q`, res.Error, tc.wantError)
+			}
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	tokenMaker, err := tokenpkg.NewPasetoMaker(randompkg.String(32))
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(...) returned error: %v", err)
+	}
+
+	username := randompkg.Owner()
+	sessions := []domain.Session{
+		{
+			ID:           uuid.New(),
+			Username:     username,
+			RefreshToken: randompkg.String(32),
+			UserAgent:    "Mozilla/5.0",
+			ClientIP:     "123.123.123.123",
+			ExpiresAt:    time.Now().Add(time.Hour).UTC().Truncate(time.Second),
+			CreatedAt:    time.Now().UTC().Truncate(time.Second),
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		buildStubs     func(service *MockService)
+		wantStatusCode int
+		wantError      string
+	}{
+		{
+			name: "OK",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().List(gomock.Any(), username).Times(1).Return(sessions, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().List(gomock.Any(), username).Times(1).Return(nil, errorspkg.ErrInternal)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      errorspkg.ErrInternal.Error(),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sessionService := NewMockService(ctrl)
+			sessionHandler := NewHandler(sessionService)
+			tc.buildStubs(sessionService)
+
+			server := gin.New()
+			server.Use(middleware.AuthMiddleware(tokenMaker, nil))
+			server.GET("/sessions", sessionHandler.List)
+
+			req, err := http.NewRequest(http.MethodGet, "/sessions", nil)
+			if err != nil {
+				t.Fatalf("Creating request error: %v", err)
+			}
+
+			if err := middleware.AddAuthorization(req, tokenMaker, middleware.AuthTypeBearer, username, time.Minute); err != nil {
+				t.Fatalf("middleware.AddAuthorization(...) returned error: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			if got := w.Code; got != tc.wantStatusCode {
+				t.Errorf("Status code: got %v, want %v", got, tc.wantStatusCode)
+			}
+
+			if bytes.Contains(w.Body.Bytes(), []byte("refresh_token")) {
+				t.Errorf("response body %s exposes refresh token", w.Body.String())
+			}
+
+			data := &struct {
+				Sessions []sessionResponse `json:"sessions"`
+			}{}
+			res := web.Response{Data: data}
+
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Fatalf("Decoding response body error: %v", err)
+			}
+
+			if res.Error != tc.wantError {
+				t.Errorf(`res.Error=%q, want %q`, res.Error, tc.wantError)
+			}
+
+			if tc.wantError != "" {
+				return
+			}
+
+			want := []sessionResponse{
+				{
+					ID:        sessions[0].ID,
+					UserAgent: sessions[0].UserAgent,
+					ClientIP:  sessions[0].ClientIP,
+					ExpiresAt: sessions[0].ExpiresAt,
+					CreatedAt: sessions[0].CreatedAt,
+				},
+			}
+
+			if diff := cmp.Diff(want, data.Sessions); diff != "" {
+				t.Errorf("res.Data mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	tokenMaker, err := tokenpkg.NewPasetoMaker(randompkg.String(32))
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(...) returned error: %v", err)
+	}
+
+	username := randompkg.Owner()
+	id := uuid.New()
+
+	testCases := []struct {
+		name           string
+		url            string
+		buildStubs     func(service *MockService)
+		wantStatusCode int
+		wantError      string
+	}{
+		{
+			name: "OK",
+			url:  "/sessions/" + id.String(),
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Revoke(gomock.Any(), username, id).Times(1).Return(nil)
+			},
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name: "InvalidID",
+			url:  "/sessions/123",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Revoke(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "ID must be a valid UUID",
+		},
+		{
+			name: "ErrSessionNotFound",
+			url:  "/sessions/" + id.String(),
+			buildStubs: func(service *MockService) {
+				service.EXPECT().Revoke(gomock.Any(), username, id).Times(1).Return(domain.ErrSessionNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      domain.ErrSessionNotFound.Error(),
+		},
+		{
+			name: "AllSessions",
+			url:  "/sessions",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().RevokeAll(gomock.Any(), username).Times(1).Return(int64(3), nil)
+			},
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name: "AllSessionsInternalError",
+			url:  "/sessions",
+			buildStubs: func(service *MockService) {
+				service.EXPECT().RevokeAll(gomock.Any(), username).Times(1).Return(int64(0), errorspkg.ErrInternal)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      errorspkg.ErrInternal.Error(),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sessionService := NewMockService(ctrl)
+			sessionHandler := NewHandler(sessionService)
+			tc.buildStubs(sessionService)
+
+			server := gin.New()
+			server.Use(middleware.AuthMiddleware(tokenMaker, nil))
+			server.DELETE("/sessions", sessionHandler.RevokeAll)
+			server.DELETE("/sessions/:id", sessionHandler.Revoke)
+
+			req, err := http.NewRequest(http.MethodDelete, tc.url, nil)
+			if err != nil {
+				t.Fatalf("Creating request error: %v", err)
+			}
+
+			if err := middleware.AddAuthorization(req, tokenMaker, middleware.AuthTypeBearer, username, time.Minute); err != nil {
+				t.Fatalf("middleware.AddAuthorization(...) returned error: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			if got := w.Code; got != tc.wantStatusCode {
+				t.Errorf("Status code: got %v, want %v", got, tc.wantStatusCode)
+			}
+
+			if tc.wantError == "" {
+				return
+			}
+
+			var res web.Response
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Fatalf("Decoding response body error: %v", err)
+			}
+
+			if res.Error != tc.wantError {
+				t.Errorf(`res.Error=%q, want %q`, res.Error, tc.wantError)
+			}
+		})
+	}
+}
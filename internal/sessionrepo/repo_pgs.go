@@ -25,6 +25,52 @@ func NewRepoPGS(db dbpkg.SQLInterface) *RepoPGS {
 	}
 }
 
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSession(row scanner) (domain.Session, error) {
+	var (
+		s         domain.Session
+		parentID  uuid.NullUUID
+		rotatedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&s.ID,
+		&s.Username,
+		&s.RefreshToken,
+		&s.UserAgent,
+		&s.ClientIP,
+		&s.IsBlocked,
+		&parentID,
+		&s.FamilyID,
+		&rotatedAt,
+		pq.Array(&s.Scopes),
+		&s.ExpiresAt,
+		&s.CreatedAt,
+	)
+
+	if parentID.Valid {
+		s.ParentID = &parentID.UUID
+	}
+
+	if rotatedAt.Valid {
+		s.RotatedAt = &rotatedAt.Time
+	}
+
+	return s, err
+}
+
+// scopes returns non nil scopes, since nil array is stored as NULL.
+func scopes(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+
+	return s
+}
+
 const createQuery = `
 INSERT INTO sessions (
 	id,
@@ -33,13 +79,16 @@ INSERT INTO sessions (
 	user_agent,
 	client_ip,
 	is_blocked,
-	expires_at
+	expires_at,
+	scopes,
+	family_id
 ) VALUES (
-	$1, $2, $3, $4, $5, $6, $7
-) RETURNING id, username, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at;
+	$1, $2, $3, $4, $5, $6, $7, $8, $1
+) RETURNING id, username, refresh_token, user_agent, client_ip, is_blocked,
+	parent_id, family_id, rotated_at, scopes, expires_at, created_at;
 `
 
-// Create creates a session and then returns it.
+// Create creates a session that starts a new family and then returns it.
 func (r *RepoPGS) Create(ctx context.Context, arg domain.CreateSessionParams) (domain.Session, error) {
 	l := zerolog.Ctx(ctx)
 
@@ -51,21 +100,10 @@ func (r *RepoPGS) Create(ctx context.Context, arg domain.CreateSessionParams) (d
 		arg.ClientIP,
 		arg.IsBlocked,
 		arg.ExpiresAt,
+		pq.Array(scopes(arg.Scopes)),
 	)
 
-	var s domain.Session
-
-	err := row.Scan(
-		&s.ID,
-		&s.Username,
-		&s.RefreshToken,
-		&s.UserAgent,
-		&s.ClientIP,
-		&s.IsBlocked,
-		&s.ExpiresAt,
-		&s.CreatedAt,
-	)
-
+	s, err := scanSession(row)
 	if err != nil {
 		l.Error().Err(err).Send()
 
@@ -82,13 +120,17 @@ func (r *RepoPGS) Create(ctx context.Context, arg domain.CreateSessionParams) (d
 }
 
 const getSession = `
-SELECT 
+SELECT
 	id,
 	username,
 	refresh_token,
 	user_agent,
 	client_ip,
 	is_blocked,
+	parent_id,
+	family_id,
+	rotated_at,
+	scopes,
 	expires_at,
 	created_at
 FROM sessions
@@ -101,18 +143,7 @@ func (r *RepoPGS) Get(ctx context.Context, id uuid.UUID) (domain.Session, error)
 
 	row := r.db.QueryRowContext(ctx, getSession, id)
 
-	var s domain.Session
-
-	err := row.Scan(
-		&s.ID,
-		&s.Username,
-		&s.RefreshToken,
-		&s.UserAgent,
-		&s.ClientIP,
-		&s.IsBlocked,
-		&s.ExpiresAt,
-		&s.CreatedAt,
-	)
+	s, err := scanSession(row)
 
 	if err != nil {
 		l.Error().Err(err).Send()
@@ -126,3 +157,176 @@ func (r *RepoPGS) Get(ctx context.Context, id uuid.UUID) (domain.Session, error)
 
 	return s, nil
 }
+
+const rotateQuery = `
+WITH parent AS (
+	UPDATE sessions
+	SET rotated_at = now()
+	WHERE id = $1 AND rotated_at IS NULL AND NOT is_blocked
+	RETURNING id, family_id, scopes
+)
+INSERT INTO sessions (
+	id,
+	username,
+	refresh_token,
+	user_agent,
+	client_ip,
+	expires_at,
+	parent_id,
+	family_id,
+	scopes
+)
+SELECT $2, $3, $4, $5, $6, $7, parent.id, parent.family_id, parent.scopes
+FROM parent
+RETURNING id, username, refresh_token, user_agent, client_ip, is_blocked,
+	parent_id, family_id, rotated_at, scopes, expires_at, created_at;
+`
+
+// Rotate marks the parent session as rotated and creates its child session
+// in the same family with the parent scopes. It returns
+// domain.ErrRefreshTokenReused if the parent session has already been rotated
+// or blocked.
+func (r *RepoPGS) Rotate(ctx context.Context, parentID uuid.UUID, arg domain.CreateSessionParams) (domain.Session, error) {
+	l := zerolog.Ctx(ctx)
+
+	row := r.db.QueryRowContext(ctx, rotateQuery,
+		parentID,
+		arg.ID,
+		arg.Username,
+		arg.RefreshToken,
+		arg.UserAgent,
+		arg.ClientIP,
+		arg.ExpiresAt,
+	)
+
+	s, err := scanSession(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return s, domain.ErrRefreshTokenReused
+		}
+
+		l.Error().Err(err).Send()
+
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Constraint == "sessions_username_fkey" {
+				return s, domain.ErrUserNotFound
+			}
+		}
+
+		return s, errorspkg.ErrInternal
+	}
+
+	return s, nil
+}
+
+const blockFamilyQuery = `
+UPDATE sessions
+SET is_blocked = true
+WHERE family_id = $1 AND NOT is_blocked
+`
+
+// BlockFamily blocks all sessions of the family and returns the number of
+// newly blocked sessions.
+func (r *RepoPGS) BlockFamily(ctx context.Context, familyID uuid.UUID) (int64, error) {
+	l := zerolog.Ctx(ctx)
+
+	res, err := r.db.ExecContext(ctx, blockFamilyQuery, familyID)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return 0, errorspkg.ErrInternal
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		l.Error().Err(err).Send()
+		return 0, errorspkg.ErrInternal
+	}
+
+	return n, nil
+}
+
+// The family root is the session created at login, so its created_at is the
+// login time of the family.
+const listActiveQuery = `
+SELECT
+	s.id,
+	s.username,
+	s.refresh_token,
+	s.user_agent,
+	s.client_ip,
+	s.is_blocked,
+	s.parent_id,
+	s.family_id,
+	s.rotated_at,
+	s.scopes,
+	s.expires_at,
+	COALESCE(root.created_at, s.created_at) AS logged_in_at
+FROM sessions s
+LEFT JOIN sessions root ON root.id = s.family_id
+WHERE s.username = $1 AND NOT s.is_blocked AND s.rotated_at IS NULL AND s.expires_at > now()
+ORDER BY logged_in_at, s.id
+`
+
+// ListActive returns the user's sessions which refresh tokens can still be
+// used, ordered by login. CreatedAt of the sessions is the login time of their
+// family rather than the time of the last renewal.
+func (r *RepoPGS) ListActive(ctx context.Context, username string) ([]domain.Session, error) {
+	l := zerolog.Ctx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, listActiveQuery, username)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+	defer rows.Close()
+
+	items := []domain.Session{}
+
+	for rows.Next() {
+		s, err := scanSession(rows)
+		if err != nil {
+			l.Error().Err(err).Send()
+			return nil, errorspkg.ErrInternal
+		}
+
+		items = append(items, s)
+	}
+
+	if err := rows.Close(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	if err := rows.Err(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	return items, nil
+}
+
+const blockUserQuery = `
+UPDATE sessions
+SET is_blocked = true
+WHERE username = $1 AND NOT is_blocked
+`
+
+// BlockUser blocks all sessions of the user and returns the number of newly
+// blocked sessions.
+func (r *RepoPGS) BlockUser(ctx context.Context, username string) (int64, error) {
+	l := zerolog.Ctx(ctx)
+
+	res, err := r.db.ExecContext(ctx, blockUserQuery, username)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return 0, errorspkg.ErrInternal
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		l.Error().Err(err).Send()
+		return 0, errorspkg.ErrInternal
+	}
+
+	return n, nil
+}
@@ -145,7 +145,9 @@ func TestCreate(t *testing.T) {
 				t.Fatalf("sessionRepo.Create(context.Background(), %+v) returned error: %v", arg, err)
 			}
 
-			if diff := cmp.Diff(want, got, cmpopts.EquateApproxTime(time.Second)); diff != "" {
+			want.FamilyID = want.ID
+
+			if diff := cmp.Diff(want, got, cmpopts.EquateApproxTime(time.Second), cmpopts.EquateEmpty()); diff != "" {
 				t.Errorf(`sessionRepo.Create(context.Background(), %+v) returned unexpected difference (-want +got):\n%s"`,
 					arg, diff)
 			}
@@ -194,10 +196,263 @@ func TestGetSession(t *testing.T) {
 				t.Fatalf("sessionRepo.Create(context.Background(), %+v) returned error: %v", want.ID, err)
 			}
 
-			if diff := cmp.Diff(want, got, cmpopts.EquateApproxTime(time.Second)); diff != "" {
+			if diff := cmp.Diff(want, got, cmpopts.EquateApproxTime(time.Second), cmpopts.EquateEmpty()); diff != "" {
 				t.Errorf(`sessionRepo.Get(context.Background(), %v) returned unexpected difference (-want +got):\n%s"`,
 					want.ID, diff)
 			}
 		})
 	}
 }
+
+func TestRotate(t *testing.T) {
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	user := helpers.SeedUser(t, tx)
+	sessionRepo := sessionrepo.NewRepoPGS(tx)
+
+	parent, err := sessionRepo.Create(context.Background(), domain.CreateSessionParams{
+		ID:           uuid.New(),
+		Username:     user.Username,
+		RefreshToken: randompkg.String(10),
+		UserAgent:    randompkg.String(10),
+		ClientIP:     randompkg.String(10),
+		Scopes:       []string{domain.ScopeAccountsRead},
+		ExpiresAt:    time.Now().Add(time.Hour).Truncate(time.Second).UTC(),
+	})
+	if err != nil {
+		t.Fatalf("sessionRepo.Create(context.Background(), arg) returned error: %v", err)
+	}
+
+	arg := domain.CreateSessionParams{
+		ID:           uuid.New(),
+		Username:     user.Username,
+		RefreshToken: randompkg.String(10),
+		UserAgent:    parent.UserAgent,
+		ClientIP:     parent.ClientIP,
+		ExpiresAt:    time.Now().Add(time.Hour).Truncate(time.Second).UTC(),
+	}
+
+	got, err := sessionRepo.Rotate(context.Background(), parent.ID, arg)
+	if err != nil {
+		t.Fatalf("sessionRepo.Rotate(context.Background(), %v, %+v) returned error: %v", parent.ID, arg, err)
+	}
+
+	want := domain.Session{
+		ID:           arg.ID,
+		Username:     arg.Username,
+		RefreshToken: arg.RefreshToken,
+		UserAgent:    arg.UserAgent,
+		ClientIP:     arg.ClientIP,
+		ParentID:     &parent.ID,
+		FamilyID:     parent.FamilyID,
+		Scopes:       []string{domain.ScopeAccountsRead},
+		ExpiresAt:    arg.ExpiresAt,
+		CreatedAt:    time.Now(),
+	}
+
+	if diff := cmp.Diff(want, got, cmpopts.EquateApproxTime(time.Second), cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("sessionRepo.Rotate(context.Background(), %v, %+v) returned unexpected difference (-want +got):\n%s",
+			parent.ID, arg, diff)
+	}
+
+	rotated, err := sessionRepo.Get(context.Background(), parent.ID)
+	if err != nil {
+		t.Fatalf("sessionRepo.Get(context.Background(), %v) returned error: %v", parent.ID, err)
+	}
+
+	if rotated.RotatedAt == nil {
+		t.Errorf("sessionRepo.Get(context.Background(), %v) returned session with nil RotatedAt", parent.ID)
+	}
+
+	// The rotated session cannot be rotated again.
+	arg.ID = uuid.New()
+
+	_, err = sessionRepo.Rotate(context.Background(), parent.ID, arg)
+	if err != domain.ErrRefreshTokenReused {
+		t.Errorf("sessionRepo.Rotate(context.Background(), %v, %+v) returned error: %v, want %v",
+			parent.ID, arg, err, domain.ErrRefreshTokenReused)
+	}
+}
+
+func TestBlockFamily(t *testing.T) {
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	user := helpers.SeedUser(t, tx)
+	parent := SeedSession(t, tx, user.Username)
+	another := SeedSession(t, tx, user.Username)
+	sessionRepo := sessionrepo.NewRepoPGS(tx)
+
+	arg := domain.CreateSessionParams{
+		ID:           uuid.New(),
+		Username:     user.Username,
+		RefreshToken: randompkg.String(10),
+		UserAgent:    parent.UserAgent,
+		ClientIP:     parent.ClientIP,
+		ExpiresAt:    time.Now().Add(time.Hour).UTC(),
+	}
+
+	child, err := sessionRepo.Rotate(context.Background(), parent.ID, arg)
+	if err != nil {
+		t.Fatalf("sessionRepo.Rotate(context.Background(), %v, %+v) returned error: %v", parent.ID, arg, err)
+	}
+
+	n, err := sessionRepo.BlockFamily(context.Background(), parent.FamilyID)
+	if err != nil {
+		t.Fatalf("sessionRepo.BlockFamily(context.Background(), %v) returned error: %v", parent.FamilyID, err)
+	}
+
+	if n != 2 {
+		t.Errorf("sessionRepo.BlockFamily(context.Background(), %v) = %v, want 2", parent.FamilyID, n)
+	}
+
+	for _, id := range []uuid.UUID{parent.ID, child.ID} {
+		s, err := sessionRepo.Get(context.Background(), id)
+		if err != nil {
+			t.Fatalf("sessionRepo.Get(context.Background(), %v) returned error: %v", id, err)
+		}
+
+		if !s.IsBlocked {
+			t.Errorf("session %v is not blocked", id)
+		}
+	}
+
+	s, err := sessionRepo.Get(context.Background(), another.ID)
+	if err != nil {
+		t.Fatalf("sessionRepo.Get(context.Background(), %v) returned error: %v", another.ID, err)
+	}
+
+	if s.IsBlocked {
+		t.Errorf("session %v of another family is blocked", another.ID)
+	}
+}
+
+func TestListActive(t *testing.T) {
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	user := helpers.SeedUser(t, tx)
+	sessionRepo := sessionrepo.NewRepoPGS(tx)
+
+	seed := func(expiresAt time.Time) domain.Session {
+		return helpers.SeedSession(t, tx, domain.CreateSessionParams{
+			ID:           uuid.New(),
+			Username:     user.Username,
+			RefreshToken: randompkg.String(10),
+			UserAgent:    randompkg.String(10),
+			ClientIP:     randompkg.String(10),
+			ExpiresAt:    expiresAt,
+		})
+	}
+
+	active := seed(time.Now().Add(time.Hour))
+	seed(time.Now().Add(-time.Hour))
+	blocked := seed(time.Now().Add(time.Hour))
+	rotated := seed(time.Now().Add(time.Hour))
+
+	if _, err := sessionRepo.BlockFamily(context.Background(), blocked.FamilyID); err != nil {
+		t.Fatalf("sessionRepo.BlockFamily(context.Background(), %v) returned error: %v", blocked.FamilyID, err)
+	}
+
+	child, err := sessionRepo.Rotate(context.Background(), rotated.ID, domain.CreateSessionParams{
+		ID:           uuid.New(),
+		Username:     user.Username,
+		RefreshToken: randompkg.String(10),
+		UserAgent:    rotated.UserAgent,
+		ClientIP:     rotated.ClientIP,
+		ExpiresAt:    time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("sessionRepo.Rotate(context.Background(), %v, ...) returned error: %v", rotated.ID, err)
+	}
+
+	got, err := sessionRepo.ListActive(context.Background(), user.Username)
+	if err != nil {
+		t.Fatalf("sessionRepo.ListActive(context.Background(), %v) returned error: %v", user.Username, err)
+	}
+
+	want := []domain.Session{active, child}
+	sortByID := cmpopts.SortSlices(func(a, b domain.Session) bool { return a.ID.String() < b.ID.String() })
+
+	if diff := cmp.Diff(want, got, cmpopts.EquateApproxTime(time.Second), cmpopts.EquateEmpty(), sortByID); diff != "" {
+		t.Errorf("sessionRepo.ListActive(context.Background(), %v) returned unexpected difference (-want +got):\n%s",
+			user.Username, diff)
+	}
+}
+
+func TestListActiveRotatedLoginTime(t *testing.T) {
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	user := helpers.SeedUser(t, tx)
+	sessionRepo := sessionrepo.NewRepoPGS(tx)
+	ctx := context.Background()
+
+	seed := func(loggedInAt time.Time) domain.Session {
+		s := helpers.SeedSession(t, tx, domain.CreateSessionParams{
+			ID:           uuid.New(),
+			Username:     user.Username,
+			RefreshToken: randompkg.String(10),
+			UserAgent:    randompkg.String(10),
+			ClientIP:     randompkg.String(10),
+			ExpiresAt:    time.Now().Add(time.Hour),
+		})
+
+		if _, err := tx.ExecContext(ctx, `UPDATE sessions SET created_at = $2 WHERE id = $1`, s.ID, loggedInAt); err != nil {
+			t.Fatalf("updating created_at of session %v returned error: %v", s.ID, err)
+		}
+
+		return s
+	}
+
+	first := seed(time.Now().Add(-2 * time.Hour))
+	second := seed(time.Now().Add(-time.Hour))
+
+	// The renewal of the first session is newer than the second login.
+	child, err := sessionRepo.Rotate(ctx, first.ID, domain.CreateSessionParams{
+		ID:           uuid.New(),
+		Username:     user.Username,
+		RefreshToken: randompkg.String(10),
+		UserAgent:    first.UserAgent,
+		ClientIP:     first.ClientIP,
+		ExpiresAt:    time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("sessionRepo.Rotate(ctx, %v, ...) returned error: %v", first.ID, err)
+	}
+
+	got, err := sessionRepo.ListActive(ctx, user.Username)
+	if err != nil {
+		t.Fatalf("sessionRepo.ListActive(ctx, %v) returned error: %v", user.Username, err)
+	}
+
+	if len(got) != 2 || got[0].ID != child.ID || got[1].ID != second.ID {
+		t.Fatalf("sessionRepo.ListActive(ctx, %v) = %+v, want sessions %v, %v", user.Username, got, child.ID, second.ID)
+	}
+
+	wantLogin := time.Now().Add(-2 * time.Hour)
+	if d := got[0].CreatedAt.Sub(wantLogin); d < -time.Minute || d > time.Minute {
+		t.Errorf("got[0].CreatedAt = %v, want the login time %v", got[0].CreatedAt, wantLogin)
+	}
+}
+
+func TestBlockUser(t *testing.T) {
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	user := helpers.SeedUser(t, tx)
+	another := helpers.SeedUser(t, tx)
+	SeedSession(t, tx, user.Username)
+	SeedSession(t, tx, user.Username)
+	anotherSession := SeedSession(t, tx, another.Username)
+	sessionRepo := sessionrepo.NewRepoPGS(tx)
+
+	n, err := sessionRepo.BlockUser(context.Background(), user.Username)
+	if err != nil {
+		t.Fatalf("sessionRepo.BlockUser(context.Background(), %v) returned error: %v", user.Username, err)
+	}
+
+	if n != 2 {
+		t.Errorf("sessionRepo.BlockUser(context.Background(), %v) = %v, want 2", user.Username, n)
+	}
+
+	s, err := sessionRepo.Get(context.Background(), anotherSession.ID)
+	if err != nil {
+		t.Fatalf("sessionRepo.Get(context.Background(), %v) returned error: %v", anotherSession.ID, err)
+	}
+
+	if s.IsBlocked {
+		t.Errorf("session %v of another user is blocked", anotherSession.ID)
+	}
+}
@@ -0,0 +1,204 @@
+package sessionservice
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/tokenpkg"
+	"github.com/google/uuid"
+)
+
+// maxRevocationCacheEntries bounds each revocation cache map. Expired entries
+// are dropped once the bound is reached.
+const maxRevocationCacheEntries = 10000
+
+type cachedSession struct {
+	username  string
+	familyID  uuid.UUID
+	isBlocked bool
+	expiresAt time.Time
+}
+
+type cachedUser struct {
+	passwordChangedAt time.Time
+	expiresAt         time.Time
+}
+
+// revocationCache is an in-process cache of the session and password state
+// fed from the sessions and users tables. Entries live for ttl, so changes
+// made by other instances are picked up within ttl. Changes made by this
+// instance evict the affected entries right away.
+type revocationCache struct {
+	ttl time.Duration
+
+	mu       sync.Mutex
+	sessions map[uuid.UUID]cachedSession
+	users    map[string]cachedUser
+}
+
+func newRevocationCache(ttl time.Duration) *revocationCache {
+	return &revocationCache{
+		ttl:      ttl,
+		sessions: make(map[uuid.UUID]cachedSession),
+		users:    make(map[string]cachedUser),
+	}
+}
+
+func (c *revocationCache) session(id uuid.UUID) (cachedSession, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.sessions[id]
+	if !ok || time.Now().After(s.expiresAt) {
+		return cachedSession{}, false
+	}
+
+	return s, true
+}
+
+func (c *revocationCache) putSession(id uuid.UUID, s cachedSession) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+
+	if len(c.sessions) >= maxRevocationCacheEntries {
+		for k, v := range c.sessions {
+			if now.After(v.expiresAt) {
+				delete(c.sessions, k)
+			}
+		}
+
+		if len(c.sessions) >= maxRevocationCacheEntries {
+			c.sessions = make(map[uuid.UUID]cachedSession)
+		}
+	}
+
+	s.expiresAt = now.Add(c.ttl)
+	c.sessions[id] = s
+}
+
+func (c *revocationCache) user(username string) (cachedUser, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	u, ok := c.users[username]
+	if !ok || time.Now().After(u.expiresAt) {
+		return cachedUser{}, false
+	}
+
+	return u, true
+}
+
+func (c *revocationCache) putUser(username string, u cachedUser) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+
+	if len(c.users) >= maxRevocationCacheEntries {
+		for k, v := range c.users {
+			if now.After(v.expiresAt) {
+				delete(c.users, k)
+			}
+		}
+
+		if len(c.users) >= maxRevocationCacheEntries {
+			c.users = make(map[string]cachedUser)
+		}
+	}
+
+	u.expiresAt = now.Add(c.ttl)
+	c.users[username] = u
+}
+
+// evictFamily removes the cached sessions of the family.
+func (c *revocationCache) evictFamily(familyID uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, v := range c.sessions {
+		if v.familyID == familyID {
+			delete(c.sessions, k)
+		}
+	}
+}
+
+// evictUser removes the cached sessions and password state of the user.
+func (c *revocationCache) evictUser(username string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, v := range c.sessions {
+		if v.username == username {
+			delete(c.sessions, k)
+		}
+	}
+
+	delete(c.users, username)
+}
+
+// CheckRevoked returns tokenpkg.ErrRevokedToken if the session of the access
+// token is blocked or the user password has been changed after the token was
+// issued.
+//
+// It returns tokenpkg.ErrInvalidToken if the token has no session, since only
+// access tokens are bound to one. Refresh tokens can't be revoked this way and
+// must not be accepted in their place.
+func (s *Service) CheckRevoked(ctx context.Context, payload *tokenpkg.Payload) error {
+	if payload.SessionID == uuid.Nil {
+		return tokenpkg.ErrInvalidToken
+	}
+
+	sess, ok := s.revocations.session(payload.SessionID)
+	if !ok {
+		got, err := s.repo.Get(ctx, payload.SessionID)
+		if err != nil {
+			if err == domain.ErrSessionNotFound {
+				return tokenpkg.ErrRevokedToken
+			}
+
+			return err
+		}
+
+		sess = cachedSession{
+			username:  got.Username,
+			familyID:  got.FamilyID,
+			isBlocked: got.IsBlocked,
+		}
+		s.revocations.putSession(payload.SessionID, sess)
+	}
+
+	if sess.isBlocked || sess.username != payload.Username {
+		return tokenpkg.ErrRevokedToken
+	}
+
+	u, ok := s.revocations.user(payload.Username)
+	if !ok {
+		got, err := s.userRepo.Get(ctx, payload.Username)
+		if err != nil && err != domain.ErrUserNotFound {
+			return err
+		}
+
+		// Unknown user has no password change to check against, the handlers
+		// report the missing user themselves.
+		u = cachedUser{passwordChangedAt: got.PasswordChangedAt}
+		s.revocations.putUser(payload.Username, u)
+	}
+
+	if payload.IssuedAt.Before(u.passwordChangedAt) {
+		return tokenpkg.ErrRevokedToken
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package sessionservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/go-petr/pet-bank/pkg/tokenpkg"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+)
+
+func TestCheckRevoked(t *testing.T) {
+	t.Parallel()
+
+	username := randompkg.Owner()
+	sess := domain.Session{
+		ID:       uuid.New(),
+		Username: username,
+		FamilyID: uuid.New(),
+	}
+	user := domain.User{
+		Username:          username,
+		PasswordChangedAt: time.Now().Add(-time.Hour),
+	}
+	payload := &tokenpkg.Payload{
+		ID:        uuid.New(),
+		Username:  username,
+		SessionID: sess.ID,
+		IssuedAt:  time.Now(),
+		ExpiredAt: time.Now().Add(time.Minute),
+	}
+	sessionless := &tokenpkg.Payload{
+		ID:        uuid.New(),
+		Username:  username,
+		IssuedAt:  time.Now(),
+		ExpiredAt: time.Now().Add(time.Minute),
+	}
+
+	testCases := []struct {
+		name       string
+		payload    *tokenpkg.Payload
+		buildStubs func(repo *MockRepo, userRepo *MockUserRepo)
+		wantError  error
+	}{
+		{
+			name:    "OK",
+			payload: payload,
+			buildStubs: func(repo *MockRepo, userRepo *MockUserRepo) {
+				repo.EXPECT().Get(gomock.Any(), gomock.Eq(sess.ID)).Times(1).Return(sess, nil)
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Eq(username)).Times(1).Return(user, nil)
+			},
+		},
+		{
+			name:    "BlockedSession",
+			payload: payload,
+			buildStubs: func(repo *MockRepo, userRepo *MockUserRepo) {
+				blocked := sess
+				blocked.IsBlocked = true
+				repo.EXPECT().Get(gomock.Any(), gomock.Eq(sess.ID)).Times(1).Return(blocked, nil)
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantError: tokenpkg.ErrRevokedToken,
+		},
+		{
+			name:    "SessionNotFound",
+			payload: payload,
+			buildStubs: func(repo *MockRepo, userRepo *MockUserRepo) {
+				repo.EXPECT().Get(gomock.Any(), gomock.Eq(sess.ID)).Times(1).
+					Return(domain.Session{}, domain.ErrSessionNotFound)
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantError: tokenpkg.ErrRevokedToken,
+		},
+		{
+			name:    "PasswordChanged",
+			payload: payload,
+			buildStubs: func(repo *MockRepo, userRepo *MockUserRepo) {
+				changed := user
+				changed.PasswordChangedAt = payload.IssuedAt.Add(time.Second)
+				repo.EXPECT().Get(gomock.Any(), gomock.Eq(sess.ID)).Times(1).Return(sess, nil)
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Eq(username)).Times(1).Return(changed, nil)
+			},
+			wantError: tokenpkg.ErrRevokedToken,
+		},
+		{
+			name:    "Sessionless",
+			payload: sessionless,
+			buildStubs: func(repo *MockRepo, userRepo *MockUserRepo) {
+				repo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(0)
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantError: tokenpkg.ErrInvalidToken,
+		},
+		{
+			name:    "UserNotFound",
+			payload: payload,
+			buildStubs: func(repo *MockRepo, userRepo *MockUserRepo) {
+				repo.EXPECT().Get(gomock.Any(), gomock.Eq(sess.ID)).Times(1).Return(sess, nil)
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Eq(username)).Times(1).
+					Return(domain.User{}, domain.ErrUserNotFound)
+			},
+		},
+		{
+			name:    "RepoInternalError",
+			payload: payload,
+			buildStubs: func(repo *MockRepo, userRepo *MockUserRepo) {
+				repo.EXPECT().Get(gomock.Any(), gomock.Eq(sess.ID)).Times(1).
+					Return(domain.Session{}, errorspkg.ErrInternal)
+			},
+			wantError: errorspkg.ErrInternal,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sessionRepoMock := NewMockRepo(ctrl)
+			userRepoMock := NewMockUserRepo(ctrl)
+			tc.buildStubs(sessionRepoMock, userRepoMock)
+
+			sessionService, err := New(sessionRepoMock, userRepoMock, config, nil, nil)
+			if err != nil {
+				t.Fatalf("New(%v, %v, %v, nil) failed: %v", sessionRepoMock, userRepoMock, config, err)
+			}
+
+			err = sessionService.CheckRevoked(context.Background(), tc.payload)
+			if err != tc.wantError {
+				t.Errorf("sessionService.CheckRevoked(context.Background(), %+v) returned error: %v, want %v",
+					tc.payload, err, tc.wantError)
+			}
+		})
+	}
+}
+
+func TestCheckRevokedCache(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	username := randompkg.Owner()
+	sess := domain.Session{
+		ID:       uuid.New(),
+		Username: username,
+		FamilyID: uuid.New(),
+	}
+	payload := &tokenpkg.Payload{
+		ID:        uuid.New(),
+		Username:  username,
+		SessionID: sess.ID,
+		IssuedAt:  time.Now(),
+		ExpiredAt: time.Now().Add(time.Minute),
+	}
+
+	sessionRepoMock := NewMockRepo(ctrl)
+	userRepoMock := NewMockUserRepo(ctrl)
+
+	cacheConfig := config
+	cacheConfig.RevocationCacheTTL = time.Minute
+
+	sessionService, err := New(sessionRepoMock, userRepoMock, cacheConfig, nil, nil)
+	if err != nil {
+		t.Fatalf("New(%v, %v, %v, nil) failed: %v", sessionRepoMock, userRepoMock, cacheConfig, err)
+	}
+
+	blocked := sess
+	blocked.IsBlocked = true
+
+	gomock.InOrder(
+		sessionRepoMock.EXPECT().Get(gomock.Any(), gomock.Eq(sess.ID)).Times(1).Return(sess, nil),
+		sessionRepoMock.EXPECT().BlockUser(gomock.Any(), gomock.Eq(username)).Times(1).Return(int64(1), nil),
+		sessionRepoMock.EXPECT().Get(gomock.Any(), gomock.Eq(sess.ID)).Times(1).Return(blocked, nil),
+	)
+	userRepoMock.EXPECT().Get(gomock.Any(), gomock.Eq(username)).Times(1).
+		Return(domain.User{Username: username}, nil)
+
+	ctx := context.Background()
+
+	// The second check is served from the cache.
+	for i := 0; i < 2; i++ {
+		if err := sessionService.CheckRevoked(ctx, payload); err != nil {
+			t.Fatalf("sessionService.CheckRevoked(ctx, %+v) returned error: %v", payload, err)
+		}
+	}
+
+	if _, err := sessionService.RevokeAll(ctx, username); err != nil {
+		t.Fatalf("sessionService.RevokeAll(ctx, %v) returned error: %v", username, err)
+	}
+
+	// Revocation evicts the cached session.
+	if err := sessionService.CheckRevoked(ctx, payload); err != tokenpkg.ErrRevokedToken {
+		t.Errorf("sessionService.CheckRevoked(ctx, %+v) returned error: %v, want %v",
+			payload, err, tokenpkg.ErrRevokedToken)
+	}
+}
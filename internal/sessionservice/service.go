@@ -19,37 +19,68 @@ import (
 type Repo interface {
 	Create(ctx context.Context, arg domain.CreateSessionParams) (domain.Session, error)
 	Get(ctx context.Context, id uuid.UUID) (domain.Session, error)
+	Rotate(ctx context.Context, parentID uuid.UUID, arg domain.CreateSessionParams) (domain.Session, error)
+	BlockFamily(ctx context.Context, familyID uuid.UUID) (int64, error)
+	ListActive(ctx context.Context, username string) ([]domain.Session, error)
+	BlockUser(ctx context.Context, username string) (int64, error)
+}
+
+// UserRepo provides user data access needed to check access token revocation.
+type UserRepo interface {
+	Get(ctx context.Context, username string) (domain.User, error)
+}
+
+// Auditor records audit events of the session renewal.
+type Auditor interface {
+	Record(ctx context.Context, eventType, actor string, before, after any)
 }
 
 // Service facilitates session service layer logic.
 type Service struct {
-	repo       Repo
-	TokenMaker tokenpkg.Maker
-	config     configpkg.Config
+	repo        Repo
+	userRepo    UserRepo
+	auditor     Auditor
+	TokenMaker  tokenpkg.Maker
+	config      configpkg.Config
+	revocations *revocationCache
 }
 
-// New returns session service struct to manage session bussines logic.
-func New(sr Repo, config configpkg.Config, tm tokenpkg.Maker) (*Service, error) {
+// New returns session service struct to manage session bussines logic. Audit
+// events are not recorded if a is nil.
+func New(sr Repo, ur UserRepo, config configpkg.Config, tm tokenpkg.Maker, a Auditor) (*Service, error) {
 	return &Service{
-		repo:       sr,
-		TokenMaker: tm,
-		config:     config,
+		repo:        sr,
+		userRepo:    ur,
+		auditor:     a,
+		TokenMaker:  tm,
+		config:      config,
+		revocations: newRevocationCache(config.RevocationCacheTTL),
 	}, nil
 }
 
-// Create session and access token.
+// Create session and access token bound to it.
+//
+// The access token is granted arg.Scopes, or all scopes of the user role if
+// none are requested.
 func (s *Service) Create(ctx context.Context, arg domain.CreateSessionParams) (string, time.Time, domain.Session, error) {
 	l := zerolog.Ctx(ctx)
 
 	var sess domain.Session
 
-	accessToken, accessPayload, err := s.TokenMaker.CreateToken(arg.Username, s.config.AccessTokenDuration)
+	claims, err := s.claims(ctx, arg.Username, arg.Scopes)
+	if err != nil {
+		return "", time.Time{}, sess, err
+	}
+
+	refreshToken, refreshPayload, err := s.TokenMaker.CreateToken(arg.Username, s.config.RefreshTokenDuration)
 	if err != nil {
 		l.Error().Err(err).Send()
 		return "", time.Time{}, sess, errorspkg.ErrInternal
 	}
 
-	refreshToken, refreshPayload, err := s.TokenMaker.CreateToken(arg.Username, s.config.RefreshTokenDuration)
+	claims.SessionID = refreshPayload.ID
+
+	accessToken, accessPayload, err := s.TokenMaker.CreateTokenWithClaims(claims, s.config.AccessTokenDuration)
 	if err != nil {
 		l.Error().Err(err).Send()
 		return "", time.Time{}, sess, errorspkg.ErrInternal
@@ -68,54 +99,232 @@ func (s *Service) Create(ctx context.Context, arg domain.CreateSessionParams) (s
 	return accessToken, accessPayload.ExpiredAt, sess, nil
 }
 
-// RenewAccessToken verifies refresh token, renews access token and returns it.
-func (s *Service) RenewAccessToken(ctx context.Context, refreshToken string) (string, time.Time, error) {
+// claims returns access token claims with the user role and the requested
+// scopes granted to it.
+func (s *Service) claims(ctx context.Context, username string, requested []string) (tokenpkg.Claims, error) {
 	l := zerolog.Ctx(ctx)
 
+	user, err := s.userRepo.Get(ctx, username)
+	if err != nil {
+		return tokenpkg.Claims{}, err
+	}
+
+	scopes, err := domain.GrantScopes(user.Role, requested)
+	if err != nil {
+		l.Info().Err(err).Strs("scopes", requested).Str("role", user.Role).Send()
+		return tokenpkg.Claims{}, err
+	}
+
+	claims := tokenpkg.Claims{
+		Username: username,
+		Role:     user.Role,
+		Scopes:   scopes,
+	}
+
+	return claims, nil
+}
+
+// RenewAccessToken verifies refresh token, rotates it and returns new access
+// token together with the child session holding the new refresh token.
+//
+// If the refresh token has already been rotated, the whole session family is
+// blocked since the token is likely stolen.
+func (s *Service) RenewAccessToken(ctx context.Context, refreshToken string) (string, time.Time, domain.Session, error) {
+	l := zerolog.Ctx(ctx)
+
+	var child domain.Session
+
 	refreshPayload, err := s.TokenMaker.VerifyToken(refreshToken)
 	if err != nil {
 		if err == tokenpkg.ErrExpiredToken || err == tokenpkg.ErrInvalidToken {
-			return "", time.Time{}, err
+			return "", time.Time{}, child, err
 		}
 
 		l.Error().Err(err).Send()
 
-		return "", time.Time{}, errorspkg.ErrInternal
+		return "", time.Time{}, child, errorspkg.ErrInternal
 	}
 
 	sess, err := s.repo.Get(ctx, refreshPayload.ID)
 	if err != nil {
-		return "", time.Time{}, err
+		return "", time.Time{}, child, err
 	}
 
 	if sess.IsBlocked {
 		l.Info().Err(err).Send()
-		return "", time.Time{}, domain.ErrBlockedSession
+		return "", time.Time{}, child, domain.ErrBlockedSession
 	}
 
 	if sess.Username != refreshPayload.Username {
 		l.Info().Err(err).Send()
-		return "", time.Time{}, domain.ErrInvalidUser
+		return "", time.Time{}, child, domain.ErrInvalidUser
 	}
 
 	if sess.RefreshToken != refreshToken {
 		l.Info().Err(err).Send()
-		return "", time.Time{}, domain.ErrMismatchedRefreshToken
+		return "", time.Time{}, child, domain.ErrMismatchedRefreshToken
+	}
+
+	if sess.RotatedAt != nil {
+		return "", time.Time{}, child, s.blockFamily(ctx, sess)
 	}
 
 	if time.Now().After(sess.ExpiresAt) {
 		l.Info().Err(domain.ErrExpiredSession).Send()
-		return "", time.Time{}, domain.ErrExpiredSession
+		return "", time.Time{}, child, domain.ErrExpiredSession
+	}
+
+	claims, err := s.claims(ctx, sess.Username, sess.Scopes)
+	if err != nil {
+		return "", time.Time{}, child, err
 	}
 
-	accessToken, accessPayload, err := s.TokenMaker.CreateToken(
+	newRefreshToken, newRefreshPayload, err := s.TokenMaker.CreateToken(
 		refreshPayload.Username,
-		s.config.AccessTokenDuration,
+		s.config.RefreshTokenDuration,
 	)
 	if err != nil {
 		l.Error().Err(err).Send()
-		return "", time.Time{}, errorspkg.ErrInternal
+		return "", time.Time{}, child, errorspkg.ErrInternal
+	}
+
+	claims.SessionID = newRefreshPayload.ID
+
+	accessToken, accessPayload, err := s.TokenMaker.CreateTokenWithClaims(claims, s.config.AccessTokenDuration)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return "", time.Time{}, child, errorspkg.ErrInternal
+	}
+
+	child, err = s.repo.Rotate(ctx, sess.ID, domain.CreateSessionParams{
+		ID:           newRefreshPayload.ID,
+		Username:     sess.Username,
+		RefreshToken: newRefreshToken,
+		UserAgent:    sess.UserAgent,
+		ClientIP:     sess.ClientIP,
+		ExpiresAt:    newRefreshPayload.ExpiredAt,
+	})
+	if err != nil {
+		if err == domain.ErrRefreshTokenReused {
+			// The concurrent renewal has rotated the session first.
+			return "", time.Time{}, domain.Session{}, s.blockFamily(ctx, sess)
+		}
+
+		return "", time.Time{}, child, err
+	}
+
+	if s.auditor != nil {
+		s.auditor.Record(ctx, domain.AuditSessionRenewed, sess.Username, auditSnapshot(sess), auditSnapshot(child))
+	}
+
+	return accessToken, accessPayload.ExpiredAt, child, nil
+}
+
+// auditSnapshot returns the session without its refresh token.
+func auditSnapshot(sess domain.Session) domain.Session {
+	sess.RefreshToken = ""
+	return sess
+}
+
+// blockFamily blocks all sessions of the reused session family and logs the
+// security event. It returns domain.ErrRefreshTokenReused on success.
+func (s *Service) blockFamily(ctx context.Context, sess domain.Session) error {
+	l := zerolog.Ctx(ctx)
+
+	n, err := s.repo.BlockFamily(ctx, sess.FamilyID)
+	if err != nil {
+		return err
+	}
+
+	s.revocations.evictFamily(sess.FamilyID)
+
+	l.Warn().
+		Str("event", "refresh_token_reuse").
+		Str("username", sess.Username).
+		Str("session_id", sess.ID.String()).
+		Str("family_id", sess.FamilyID.String()).
+		Int64("blocked_sessions", n).
+		Msg("refresh token reuse detected, session family is blocked")
+
+	return domain.ErrRefreshTokenReused
+}
+
+// Logout blocks the session family of the given refresh token owned by the user.
+func (s *Service) Logout(ctx context.Context, username, refreshToken string) error {
+	l := zerolog.Ctx(ctx)
+
+	refreshPayload, err := s.TokenMaker.VerifyToken(refreshToken)
+	if err != nil {
+		if err == tokenpkg.ErrExpiredToken || err == tokenpkg.ErrInvalidToken {
+			return err
+		}
+
+		l.Error().Err(err).Send()
+
+		return errorspkg.ErrInternal
+	}
+
+	sess, err := s.repo.Get(ctx, refreshPayload.ID)
+	if err != nil {
+		return err
+	}
+
+	if sess.Username != username {
+		l.Warn().Err(domain.ErrSessionOwnerMismatch).Send()
+		return domain.ErrSessionOwnerMismatch
 	}
 
-	return accessToken, accessPayload.ExpiredAt, nil
+	if sess.RefreshToken != refreshToken {
+		l.Info().Err(domain.ErrMismatchedRefreshToken).Send()
+		return domain.ErrMismatchedRefreshToken
+	}
+
+	if _, err := s.repo.BlockFamily(ctx, sess.FamilyID); err != nil {
+		return err
+	}
+
+	s.revocations.evictFamily(sess.FamilyID)
+
+	return nil
+}
+
+// List returns the user's active sessions. CreatedAt of the sessions is their
+// login time.
+func (s *Service) List(ctx context.Context, username string) ([]domain.Session, error) {
+	return s.repo.ListActive(ctx, username)
+}
+
+// Revoke blocks the session family of the given session owned by the user.
+func (s *Service) Revoke(ctx context.Context, username string, id uuid.UUID) error {
+	l := zerolog.Ctx(ctx)
+
+	sess, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if sess.Username != username {
+		l.Warn().Err(domain.ErrSessionOwnerMismatch).Send()
+		return domain.ErrSessionOwnerMismatch
+	}
+
+	if _, err := s.repo.BlockFamily(ctx, sess.FamilyID); err != nil {
+		return err
+	}
+
+	s.revocations.evictFamily(sess.FamilyID)
+
+	return nil
+}
+
+// RevokeAll blocks all sessions of the user and returns the number of revoked sessions.
+func (s *Service) RevokeAll(ctx context.Context, username string) (int64, error) {
+	n, err := s.repo.BlockUser(ctx, username)
+	if err != nil {
+		return 0, err
+	}
+
+	s.revocations.evictUser(username)
+
+	return n, nil
 }
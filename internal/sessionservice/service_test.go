@@ -13,6 +13,7 @@ import (
 	"github.com/go-petr/pet-bank/pkg/tokenpkg"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
 )
 
 var config configpkg.Config
@@ -70,8 +71,51 @@ func TestCreate(t *testing.T) {
 				if diff := cmp.Diff(want, got); diff != "" {
 					t.Errorf("session returned unexpected diff: %s", diff)
 				}
+
+				accessPayload, err := tokenMaker.VerifyToken(accessToken)
+				if err != nil {
+					t.Fatalf("tokenMaker.VerifyToken(%v) returned error: %v", accessToken, err)
+				}
+
+				if diff := cmp.Diff(domain.RoleScopes(domain.RoleCustomer), accessPayload.Scopes); diff != "" {
+					t.Errorf("accessPayload.Scopes returned unexpected diff: %s", diff)
+				}
+			},
+		},
+		{
+			name: "ReadOnlyScopes",
+			arg: domain.CreateSessionParams{
+				Username: username,
+				Scopes:   []string{domain.ScopeAccountsRead},
+			},
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(domain.CreateSessionParams{})).
+					Times(1).
+					Return(want, nil)
+			},
+			checkResponse: func(accessToken string, accessTokenExpiresAt time.Time, got domain.Session) {
+				accessPayload, err := tokenMaker.VerifyToken(accessToken)
+				if err != nil {
+					t.Fatalf("tokenMaker.VerifyToken(%v) returned error: %v", accessToken, err)
+				}
+
+				if diff := cmp.Diff([]string{domain.ScopeAccountsRead}, accessPayload.Scopes); diff != "" {
+					t.Errorf("accessPayload.Scopes returned unexpected diff: %s", diff)
+				}
 			},
 		},
+		{
+			name: "ErrScopeNotAllowed",
+			arg: domain.CreateSessionParams{
+				Username: username,
+				Scopes:   []string{domain.ScopeAdminWrite},
+			},
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantError: domain.ErrScopeNotAllowed,
+		},
 		{
 			name: "RepoInternalError",
 			arg: domain.CreateSessionParams{
@@ -97,7 +141,15 @@ func TestCreate(t *testing.T) {
 			defer ctrl.Finish()
 
 			sessionRepoMock := NewMockRepo(ctrl)
-			sessionService, err := New(sessionRepoMock, config, tokenMaker)
+			userRepoMock := NewMockUserRepo(ctrl)
+			userRepoMock.EXPECT().
+				Get(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				DoAndReturn(func(_ context.Context, username string) (domain.User, error) {
+					return domain.User{Username: username, Role: domain.RoleCustomer}, nil
+				})
+
+			sessionService, err := New(sessionRepoMock, userRepoMock, config, tokenMaker, nil)
 			if err != nil {
 				t.Fatalf("New(%v, %v, %v) failed: %v", sessionRepoMock, config, tokenMaker, err)
 			}
@@ -142,6 +194,7 @@ func TestRenewAccessToken(t *testing.T) {
 	}
 
 	unauthUsername := randompkg.Owner()
+	familyID := uuid.New()
 
 	token2, payload2, err := tokenMaker.CreateToken(unauthUsername, config.RefreshTokenDuration)
 	if err != nil {
@@ -153,7 +206,7 @@ func TestRenewAccessToken(t *testing.T) {
 		name          string
 		token         string
 		buildStubs    func(repo *MockRepo)
-		checkResponse func(t *testing.T, accessToken string, accessTokenExpiresAt time.Time)
+		checkResponse func(t *testing.T, accessToken string, accessTokenExpiresAt time.Time, sess domain.Session)
 		wantError     error
 	}{
 		{
@@ -161,16 +214,36 @@ func TestRenewAccessToken(t *testing.T) {
 			token: token1,
 			buildStubs: func(repo *MockRepo) {
 				s := domain.Session{
+					ID:           payload1.ID,
 					Username:     username,
 					RefreshToken: token1,
+					FamilyID:     familyID,
 					ExpiresAt:    payload1.ExpiredAt,
 				}
 				repo.EXPECT().
 					Get(gomock.Any(), gomock.Eq(payload1.ID)).
 					Times(1).
 					Return(s, nil)
+				repo.EXPECT().
+					Rotate(gomock.Any(), gomock.Eq(payload1.ID), gomock.AssignableToTypeOf(domain.CreateSessionParams{})).
+					Times(1).
+					DoAndReturn(func(_ context.Context, parentID uuid.UUID, arg domain.CreateSessionParams) (domain.Session, error) {
+						if arg.RefreshToken == token1 {
+							t.Error("arg.RefreshToken is the rotated token, want new token")
+						}
+
+						return domain.Session{
+							ID:           arg.ID,
+							Username:     arg.Username,
+							RefreshToken: arg.RefreshToken,
+							ParentID:     &parentID,
+							FamilyID:     familyID,
+							ExpiresAt:    arg.ExpiresAt,
+						}, nil
+					})
+				repo.EXPECT().BlockFamily(gomock.Any(), gomock.Any()).Times(0)
 			},
-			checkResponse: func(t *testing.T, accessToken string, accessTokenExpiresAt time.Time) {
+			checkResponse: func(t *testing.T, accessToken string, accessTokenExpiresAt time.Time, sess domain.Session) {
 				if accessToken == "" {
 					t.Error(`accessToken = "", want non empty`)
 				}
@@ -178,8 +251,100 @@ func TestRenewAccessToken(t *testing.T) {
 				if accessTokenExpiresAt.IsZero() {
 					t.Error(`accessTokenExpiresAt is zero, want non zero`)
 				}
+
+				if sess.RefreshToken == "" || sess.RefreshToken == token1 {
+					t.Errorf("sess.RefreshToken = %q, want new non empty token", sess.RefreshToken)
+				}
+
+				if sess.ParentID == nil || *sess.ParentID != payload1.ID {
+					t.Errorf("sess.ParentID = %v, want %v", sess.ParentID, payload1.ID)
+				}
+
+				accessPayload, err := tokenMaker.VerifyToken(accessToken)
+				if err != nil {
+					t.Fatalf("tokenMaker.VerifyToken(%v) returned error: %v", accessToken, err)
+				}
+
+				if accessPayload.SessionID != sess.ID {
+					t.Errorf("accessPayload.SessionID = %v, want %v", accessPayload.SessionID, sess.ID)
+				}
 			},
 		},
+		{
+			name:  "ErrRefreshTokenReused",
+			token: token1,
+			buildStubs: func(repo *MockRepo) {
+				rotatedAt := time.Now().Add(-time.Minute)
+				s := domain.Session{
+					ID:           payload1.ID,
+					Username:     username,
+					RefreshToken: token1,
+					FamilyID:     familyID,
+					RotatedAt:    &rotatedAt,
+					ExpiresAt:    payload1.ExpiredAt,
+				}
+				repo.EXPECT().
+					Get(gomock.Any(), gomock.Eq(payload1.ID)).
+					Times(1).
+					Return(s, nil)
+				repo.EXPECT().Rotate(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				repo.EXPECT().
+					BlockFamily(gomock.Any(), gomock.Eq(familyID)).
+					Times(1).
+					Return(int64(3), nil)
+			},
+			wantError: domain.ErrRefreshTokenReused,
+		},
+		{
+			name:  "ConcurrentRotation",
+			token: token1,
+			buildStubs: func(repo *MockRepo) {
+				s := domain.Session{
+					ID:           payload1.ID,
+					Username:     username,
+					RefreshToken: token1,
+					FamilyID:     familyID,
+					ExpiresAt:    payload1.ExpiredAt,
+				}
+				repo.EXPECT().
+					Get(gomock.Any(), gomock.Eq(payload1.ID)).
+					Times(1).
+					Return(s, nil)
+				repo.EXPECT().
+					Rotate(gomock.Any(), gomock.Eq(payload1.ID), gomock.Any()).
+					Times(1).
+					Return(domain.Session{}, domain.ErrRefreshTokenReused)
+				repo.EXPECT().
+					BlockFamily(gomock.Any(), gomock.Eq(familyID)).
+					Times(1).
+					Return(int64(2), nil)
+			},
+			wantError: domain.ErrRefreshTokenReused,
+		},
+		{
+			name:  "BlockFamilyInternalError",
+			token: token1,
+			buildStubs: func(repo *MockRepo) {
+				rotatedAt := time.Now().Add(-time.Minute)
+				s := domain.Session{
+					ID:           payload1.ID,
+					Username:     username,
+					RefreshToken: token1,
+					FamilyID:     familyID,
+					RotatedAt:    &rotatedAt,
+					ExpiresAt:    payload1.ExpiredAt,
+				}
+				repo.EXPECT().
+					Get(gomock.Any(), gomock.Eq(payload1.ID)).
+					Times(1).
+					Return(s, nil)
+				repo.EXPECT().
+					BlockFamily(gomock.Any(), gomock.Eq(familyID)).
+					Times(1).
+					Return(int64(0), errorspkg.ErrInternal)
+			},
+			wantError: errorspkg.ErrInternal,
+		},
 		{
 			name:  "ErrExpiredToken",
 			token: expired,
@@ -271,14 +436,22 @@ func TestRenewAccessToken(t *testing.T) {
 			defer ctrl.Finish()
 
 			sessionRepoMock := NewMockRepo(ctrl)
-			sessionService, err := New(sessionRepoMock, config, tokenMaker)
+			userRepoMock := NewMockUserRepo(ctrl)
+			userRepoMock.EXPECT().
+				Get(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				DoAndReturn(func(_ context.Context, username string) (domain.User, error) {
+					return domain.User{Username: username, Role: domain.RoleCustomer}, nil
+				})
+
+			sessionService, err := New(sessionRepoMock, userRepoMock, config, tokenMaker, nil)
 			if err != nil {
 				t.Fatalf("New(%v, %v, %v) failed: %v", sessionRepoMock, config, tokenMaker, err)
 			}
 
 			tc.buildStubs(sessionRepoMock)
 
-			accessToken, expires, err := sessionService.RenewAccessToken(context.Background(), tc.token)
+			accessToken, expires, sess, err := sessionService.RenewAccessToken(context.Background(), tc.token)
 			if err != nil {
 				if err == tc.wantError {
 					return
@@ -286,7 +459,200 @@ func TestRenewAccessToken(t *testing.T) {
 				t.Fatalf("sessionService.RenewAccessToken(context.Background(),  %v) failed: %v", tc.token, err)
 			}
 
-			tc.checkResponse(t, accessToken, expires)
+			tc.checkResponse(t, accessToken, expires, sess)
+		})
+	}
+}
+
+func TestLogout(t *testing.T) {
+	t.Parallel()
+
+	tokenMaker, err := tokenpkg.NewPasetoMaker(config.TokenSymmetricKey)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(%v) failed: %v", config.TokenSymmetricKey, err)
+	}
+
+	username := randompkg.Owner()
+	familyID := uuid.New()
+
+	token, payload, err := tokenMaker.CreateToken(username, config.RefreshTokenDuration)
+	if err != nil {
+		t.Fatalf("tokenpkg.CreateToken(%v, %v) failed: %v", username, config.RefreshTokenDuration, err)
+	}
+
+	sess := domain.Session{
+		ID:           payload.ID,
+		Username:     username,
+		RefreshToken: token,
+		FamilyID:     familyID,
+		ExpiresAt:    payload.ExpiredAt,
+	}
+
+	testCases := []struct {
+		name       string
+		username   string
+		token      string
+		buildStubs func(repo *MockRepo)
+		wantError  error
+	}{
+		{
+			name:     "OK",
+			username: username,
+			token:    token,
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().Get(gomock.Any(), gomock.Eq(payload.ID)).Times(1).Return(sess, nil)
+				repo.EXPECT().BlockFamily(gomock.Any(), gomock.Eq(familyID)).Times(1).Return(int64(1), nil)
+			},
+		},
+		{
+			name:     "ErrInvalidToken",
+			username: username,
+			token:    "invalid",
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(0)
+				repo.EXPECT().BlockFamily(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantError: tokenpkg.ErrInvalidToken,
+		},
+		{
+			name:     "ErrSessionNotFound",
+			username: username,
+			token:    token,
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().Get(gomock.Any(), gomock.Eq(payload.ID)).Times(1).
+					Return(domain.Session{}, domain.ErrSessionNotFound)
+				repo.EXPECT().BlockFamily(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantError: domain.ErrSessionNotFound,
+		},
+		{
+			name:     "ErrSessionOwnerMismatch",
+			username: randompkg.Owner(),
+			token:    token,
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().Get(gomock.Any(), gomock.Eq(payload.ID)).Times(1).Return(sess, nil)
+				repo.EXPECT().BlockFamily(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantError: domain.ErrSessionOwnerMismatch,
+		},
+		{
+			name:     "ErrMismatchedRefreshToken",
+			username: username,
+			token:    token,
+			buildStubs: func(repo *MockRepo) {
+				s := sess
+				s.RefreshToken = randompkg.String(10)
+
+				repo.EXPECT().Get(gomock.Any(), gomock.Eq(payload.ID)).Times(1).Return(s, nil)
+				repo.EXPECT().BlockFamily(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantError: domain.ErrMismatchedRefreshToken,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sessionRepoMock := NewMockRepo(ctrl)
+			sessionService, err := New(sessionRepoMock, NewMockUserRepo(ctrl), config, tokenMaker, nil)
+			if err != nil {
+				t.Fatalf("New(%v, %v, %v) failed: %v", sessionRepoMock, config, tokenMaker, err)
+			}
+
+			tc.buildStubs(sessionRepoMock)
+
+			err = sessionService.Logout(context.Background(), tc.username, tc.token)
+			if err != tc.wantError {
+				t.Errorf("sessionService.Logout(context.Background(), %v, %v) returned error: %v, want %v",
+					tc.username, tc.token, err, tc.wantError)
+			}
+		})
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	t.Parallel()
+
+	username := randompkg.Owner()
+	sess := domain.Session{
+		ID:       uuid.New(),
+		Username: username,
+		FamilyID: uuid.New(),
+	}
+
+	testCases := []struct {
+		name       string
+		username   string
+		buildStubs func(repo *MockRepo)
+		wantError  error
+	}{
+		{
+			name:     "OK",
+			username: username,
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().Get(gomock.Any(), gomock.Eq(sess.ID)).Times(1).Return(sess, nil)
+				repo.EXPECT().BlockFamily(gomock.Any(), gomock.Eq(sess.FamilyID)).Times(1).Return(int64(2), nil)
+			},
+		},
+		{
+			name:     "ErrSessionNotFound",
+			username: username,
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().Get(gomock.Any(), gomock.Eq(sess.ID)).Times(1).
+					Return(domain.Session{}, domain.ErrSessionNotFound)
+				repo.EXPECT().BlockFamily(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantError: domain.ErrSessionNotFound,
+		},
+		{
+			name:     "ErrSessionOwnerMismatch",
+			username: randompkg.Owner(),
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().Get(gomock.Any(), gomock.Eq(sess.ID)).Times(1).Return(sess, nil)
+				repo.EXPECT().BlockFamily(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantError: domain.ErrSessionOwnerMismatch,
+		},
+		{
+			name:     "BlockFamilyInternalError",
+			username: username,
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().Get(gomock.Any(), gomock.Eq(sess.ID)).Times(1).Return(sess, nil)
+				repo.EXPECT().BlockFamily(gomock.Any(), gomock.Eq(sess.FamilyID)).Times(1).
+					Return(int64(0), errorspkg.ErrInternal)
+			},
+			wantError: errorspkg.ErrInternal,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sessionRepoMock := NewMockRepo(ctrl)
+			tc.buildStubs(sessionRepoMock)
+
+			sessionService, err := New(sessionRepoMock, NewMockUserRepo(ctrl), config, nil, nil)
+			if err != nil {
+				t.Fatalf("New(%v, %v, nil) failed: %v", sessionRepoMock, config, err)
+			}
+
+			err = sessionService.Revoke(context.Background(), tc.username, sess.ID)
+			if err != tc.wantError {
+				t.Errorf("sessionService.Revoke(context.Background(), %v, %v) returned error: %v, want %v",
+					tc.username, sess.ID, err, tc.wantError)
+			}
 		})
 	}
 }
@@ -3,16 +3,22 @@ package transferdelivery
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 
 	"github.com/go-petr/pet-bank/internal/domain"
 	"github.com/go-petr/pet-bank/internal/middleware"
 	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
 	"github.com/go-petr/pet-bank/pkg/tokenpkg"
 	"github.com/go-petr/pet-bank/pkg/web"
 )
@@ -22,8 +28,25 @@ import (
 //go:generate mockgen -source http.go -destination http_mock.go -package transferdelivery
 type Service interface {
 	Transfer(ctx context.Context, fromUsername string, arg domain.CreateTransferParams) (domain.TransferTxResult, error)
+	Deposit(ctx context.Context, actor string, arg domain.CreateCashParams) (domain.TransferTxResult, error)
+	Withdraw(ctx context.Context, actor string, arg domain.CreateCashParams) (domain.TransferTxResult, error)
+	GetIdempotencyKey(ctx context.Context, username, key string) (domain.IdempotencyKey, error)
+	CreateIdempotencyKey(ctx context.Context, arg domain.CreateIdempotencyKeyParams) (domain.IdempotencyKey, error)
+	Get(ctx context.Context, username string, id int64) (domain.Transfer, error)
+	List(ctx context.Context, arg domain.ListTransfersParams, page pagepkg.Request) ([]domain.Transfer, pagepkg.Page, error)
+	Reverse(ctx context.Context, actor string, asAdmin bool, arg domain.ReverseTransferParams) (domain.ReversalTxResult, error)
 }
 
+const (
+	// IdempotencyKeyHeader is the header used by clients to make transfer requests safe to retry.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on responses replayed from a stored idempotency key.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	jsonContentType         = "application/json; charset=utf-8"
+)
+
 // Handler facilitates transfer delivery layer logic.
 type Handler struct {
 	service Service
@@ -36,10 +59,54 @@ func NewHandler(ts Service) *Handler {
 	}
 }
 
+// memoRequest holds the optional memo of the transfer. The limits are
+// domain.MaxMemoDescriptionLength, domain.MaxMemoReferenceLength and
+// domain.MaxMemoMetadataKeys, the metadata size is checked by validMemo.
+type memoRequest struct {
+	Description string         `json:"description,omitempty" binding:"max=255"`
+	Reference   string         `json:"reference,omitempty" binding:"max=64"`
+	Metadata    map[string]any `json:"metadata,omitempty" binding:"max=50"`
+}
+
+func (m memoRequest) memo() domain.Memo {
+	return domain.Memo{
+		Description: m.Description,
+		Reference:   m.Reference,
+		Metadata:    m.Metadata,
+	}
+}
+
+// validMemo checks the size of the JSON encoded metadata.
+func validMemo(m memoRequest) error {
+	if len(m.Metadata) == 0 {
+		return nil
+	}
+
+	b, err := json.Marshal(m.Metadata)
+	if err != nil {
+		return err
+	}
+
+	if len(b) > domain.MaxMemoMetadataSize {
+		return domain.ErrMetadataTooLarge
+	}
+
+	return nil
+}
+
+// request identifies the recipient by exactly one of ToAccountID, PayeeID,
+// ToUsername or ToAlias. Currency picks the recipient's account when it is
+// identified by username or alias.
 type request struct {
 	FromAccountID int32  `json:"from_account_id" binding:"required,min=1"`
-	ToAccountID   int32  `json:"to_account_id" binding:"required,min=1"`
+	ToAccountID   int32  `json:"to_account_id" binding:"required_without_all=PayeeID ToUsername ToAlias,omitempty,min=1"`
+	PayeeID       int64  `json:"payee_id,omitempty" binding:"omitempty,min=1,excluded_with=ToAccountID ToUsername ToAlias"`
+	ToUsername    string `json:"to_username,omitempty" binding:"omitempty,alphanum,excluded_with=ToAccountID ToAlias"`
+	ToAlias       string `json:"to_alias,omitempty" binding:"omitempty,max=255,excluded_with=ToAccountID"`
+	Currency      string `json:"currency,omitempty" binding:"omitempty,currency,excluded_with=ToAccountID PayeeID"`
 	Amount        string `json:"amount" binding:"required"`
+	QuoteID       string `json:"quote_id,omitempty" binding:"omitempty,uuid"`
+	memoRequest
 }
 
 // Create handles http request to create a transfer between two accounts.
@@ -62,29 +129,491 @@ func (h *Handler) Create(gctx *gin.Context) {
 		return
 	}
 
+	if err := validMemo(req.memoRequest); err != nil {
+		l.Info().Err(err).Send()
+		gctx.JSON(http.StatusBadRequest, web.Error(err))
+
+		return
+	}
+
 	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
 
 	arg := domain.CreateTransferParams{
 		FromAccountID: req.FromAccountID,
 		ToAccountID:   req.ToAccountID,
 		Amount:        req.Amount,
+		PayeeID:       req.PayeeID,
+		SessionID:     authPayload.SessionID,
+		Memo:          req.memo(),
+	}
+
+	if req.ToAccountID == 0 && req.PayeeID == 0 {
+		arg.Recipient = &domain.Recipient{
+			Username: req.ToUsername,
+			Alias:    req.ToAlias,
+			Currency: req.Currency,
+		}
+	}
+
+	if req.QuoteID != "" {
+		quoteID := uuid.MustParse(req.QuoteID)
+		arg.FXQuoteID = &quoteID
+	}
+
+	var done bool
+	if arg.Idempotency, done = h.idempotency(gctx, authPayload.Username, req); done {
+		return
 	}
 
 	result, err := h.service.Transfer(ctx, authPayload.Username, arg)
 	if err != nil {
 		l.Info().Err(err).Send()
 
+		// A concurrent request with the same key has committed first.
+		if err == domain.ErrIdempotencyKeyExists && h.replay(gctx, arg.Idempotency) {
+			return
+		}
+
+		status, res := transferError(err)
+		h.fail(gctx, arg.Idempotency, status, res)
+
+		return
+	}
+
+	res := web.Response{
+		Data: struct {
+			Transfer domain.TransferTxResult `json:"transfer"`
+		}{
+			Transfer: result,
+		},
+	}
+
+	// The transfer flagged by the transaction monitoring is held for review.
+	if result.Review != nil {
+		gctx.JSON(http.StatusAccepted, res)
+
+		return
+	}
+
+	gctx.JSON(http.StatusCreated, res)
+}
+
+// transferError returns the response status and body of the failed transfer.
+func transferError(err error) (int, web.Response) {
+	// The limit error reports the remaining allowance to the client.
+	var limitErr *domain.TransferLimitError
+	if errors.As(err, &limitErr) {
+		return http.StatusForbidden, web.Response{
+			Data: struct {
+				Limit *domain.TransferLimitError `json:"limit"`
+			}{
+				Limit: limitErr,
+			},
+			Error: err.Error(),
+		}
+	}
+
+	switch err {
+	case
+		domain.ErrInvalidOwner:
+		return http.StatusUnauthorized, web.Error(err)
+	case
+		domain.ErrAccountFrozen,
+		domain.ErrTransferBlocked,
+		domain.ErrSanctionsHit:
+		return http.StatusForbidden, web.Error(err)
+	case
+		domain.ErrAccountNotFound,
+		domain.ErrFXQuoteNotFound,
+		domain.ErrRecipientNotFound,
+		domain.ErrRecipientAccountNotFound,
+		domain.ErrPayeeNotFound:
+		return http.StatusNotFound, web.Error(err)
+	case
+		domain.ErrPayeeCoolingOff,
+		domain.ErrAmbiguousRecipient:
+		return http.StatusConflict, web.Error(err)
+	case
+		domain.ErrInvalidAmount,
+		domain.ErrNegativeAmount,
+		domain.ErrInsufficientBalance,
+		domain.ErrCurrencyMismatch,
+		domain.ErrSystemAccount,
+		domain.ErrInvalidAlias,
+		domain.ErrFXQuoteExpired,
+		domain.ErrFXQuoteUsed,
+		domain.ErrFXQuoteCurrencyMismatch:
+		return http.StatusBadRequest, web.Error(err)
+	}
+
+	return http.StatusInternalServerError, web.Error(errorspkg.ErrInternal)
+}
+
+// fail writes the error response of the request. If the request has an
+// idempotency key, the response is stored under it first, so that a retry
+// gets the same response instead of being processed again. Internal errors
+// are not stored, the request is safe to retry after them since nothing has
+// been booked.
+func (h *Handler) fail(gctx *gin.Context, idempotency *domain.CreateIdempotencyKeyParams, status int, res web.Response) {
+	ctx := gctx.Request.Context()
+	l := zerolog.Ctx(ctx)
+
+	if idempotency == nil || status >= http.StatusInternalServerError {
+		gctx.JSON(status, res)
+		return
+	}
+
+	body, err := json.Marshal(res)
+	if err != nil {
+		l.Error().Err(err).Send()
+		gctx.JSON(http.StatusInternalServerError, web.Error(errorspkg.ErrInternal))
+
+		return
+	}
+
+	arg := *idempotency
+	arg.ResponseStatus = status
+	arg.ResponseBody = body
+
+	if _, err := h.service.CreateIdempotencyKey(ctx, arg); err != nil {
+		// A concurrent request with the same key has stored its result first.
+		if err == domain.ErrIdempotencyKeyExists && h.replay(gctx, idempotency) {
+			return
+		}
+
+		l.Error().Err(err).Send()
+	}
+
+	gctx.JSON(status, res)
+}
+
+// idempotency returns the idempotency key params if the Idempotency-Key header
+// is set. It writes the response and returns true if the request must not be
+// processed: the key is invalid or its stored response has been replayed.
+func (h *Handler) idempotency(gctx *gin.Context, username string, req any) (*domain.CreateIdempotencyKeyParams, bool) {
+	l := zerolog.Ctx(gctx.Request.Context())
+
+	key := gctx.GetHeader(IdempotencyKeyHeader)
+	if key == "" {
+		return nil, false
+	}
+
+	if len(key) > maxIdempotencyKeyLength {
+		gctx.JSON(http.StatusBadRequest, web.Error(domain.ErrInvalidIdempotencyKey))
+		return nil, true
+	}
+
+	requestHash, err := hashRequest(req)
+	if err != nil {
+		l.Error().Err(err).Send()
+		gctx.JSON(http.StatusInternalServerError, web.Error(errorspkg.ErrInternal))
+
+		return nil, true
+	}
+
+	arg := &domain.CreateIdempotencyKeyParams{
+		Key:            key,
+		Username:       username,
+		RequestHash:    requestHash,
+		ResponseStatus: http.StatusCreated,
+	}
+
+	return arg, h.replay(gctx, arg)
+}
+
+// hashRequest returns the fingerprint of the request payload bound to an idempotency key.
+func hashRequest(req any) (string, error) {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// replay writes the stored response for the idempotency key if it exists.
+// The stored error response is written as is.
+//
+// It returns false if nothing was written and the request must be processed.
+func (h *Handler) replay(gctx *gin.Context, arg *domain.CreateIdempotencyKeyParams) bool {
+	ctx := gctx.Request.Context()
+	l := zerolog.Ctx(ctx)
+
+	stored, err := h.service.GetIdempotencyKey(ctx, arg.Username, arg.Key)
+	if err != nil {
+		if err == domain.ErrIdempotencyKeyNotFound {
+			return false
+		}
+
+		l.Error().Err(err).Send()
+		gctx.JSON(http.StatusInternalServerError, web.Error(errorspkg.ErrInternal))
+
+		return true
+	}
+
+	if stored.RequestHash != arg.RequestHash {
+		l.Info().Err(domain.ErrIdempotencyKeyMismatch).Send()
+		gctx.JSON(http.StatusUnprocessableEntity, web.Error(domain.ErrIdempotencyKeyMismatch))
+
+		return true
+	}
+
+	gctx.Header(IdempotentReplayedHeader, "true")
+
+	if stored.ResponseStatus >= http.StatusBadRequest {
+		gctx.Data(stored.ResponseStatus, jsonContentType, stored.ResponseBody)
+		return true
+	}
+
+	res := web.Response{
+		Data: struct {
+			Transfer json.RawMessage `json:"transfer"`
+		}{
+			Transfer: stored.ResponseBody,
+		},
+	}
+
+	gctx.JSON(stored.ResponseStatus, res)
+
+	return true
+}
+
+type cashRequest struct {
+	// Kind keeps the fingerprints of deposits and withdrawals apart.
+	Kind      string `json:"kind"`
+	AccountID int32  `json:"account_id" binding:"required,min=1"`
+	Amount    string `json:"amount" binding:"required"`
+	memoRequest
+}
+
+// Deposit handles http request to credit the account from the settlement
+// account of its currency.
+func (h *Handler) Deposit(gctx *gin.Context) {
+	h.cash(gctx, domain.TransferKindDeposit, h.service.Deposit)
+}
+
+// Withdraw handles http request to debit the account to the settlement account
+// of its currency.
+func (h *Handler) Withdraw(gctx *gin.Context) {
+	h.cash(gctx, domain.TransferKindWithdrawal, h.service.Withdraw)
+}
+
+type cashFunc func(ctx context.Context, actor string, arg domain.CreateCashParams) (domain.TransferTxResult, error)
+
+func (h *Handler) cash(gctx *gin.Context, kind string, move cashFunc) {
+	ctx := gctx.Request.Context()
+	l := zerolog.Ctx(ctx)
+
+	var req cashRequest
+	if err := gctx.ShouldBindJSON(&req); err != nil {
+		var ve validator.ValidationErrors
+		if errors.As(err, &ve) {
+			gctx.JSON(http.StatusBadRequest, web.Response{Error: web.GetErrorMsg(ve)})
+
+			return
+		}
+
+		l.Error().Err(err).Send()
+		gctx.JSON(http.StatusBadRequest, web.Error(errorspkg.ErrInternal))
+
+		return
+	}
+
+	if err := validMemo(req.memoRequest); err != nil {
+		l.Info().Err(err).Send()
+		gctx.JSON(http.StatusBadRequest, web.Error(err))
+
+		return
+	}
+
+	req.Kind = kind
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	arg := domain.CreateCashParams{
+		AccountID: req.AccountID,
+		Amount:    req.Amount,
+		Memo:      req.memo(),
+	}
+
+	var done bool
+	if arg.Idempotency, done = h.idempotency(gctx, authPayload.Username, req); done {
+		return
+	}
+
+	result, err := move(ctx, authPayload.Username, arg)
+	if err != nil {
+		l.Info().Err(err).Send()
+
+		// A concurrent request with the same key has committed first.
+		if err == domain.ErrIdempotencyKeyExists && h.replay(gctx, arg.Idempotency) {
+			return
+		}
+
+		status, res := cashError(err)
+		h.fail(gctx, arg.Idempotency, status, res)
+
+		return
+	}
+
+	res := web.Response{
+		Data: struct {
+			Transfer domain.TransferTxResult `json:"transfer"`
+		}{
+			Transfer: result,
+		},
+	}
+
+	gctx.JSON(http.StatusCreated, res)
+}
+
+// cashError returns the response status and body of the failed deposit or
+// withdrawal.
+func cashError(err error) (int, web.Response) {
+	switch err {
+	case
+		domain.ErrAccountFrozen:
+		return http.StatusForbidden, web.Error(err)
+	case
+		domain.ErrAccountNotFound:
+		return http.StatusNotFound, web.Error(err)
+	case
+		domain.ErrInvalidAmount,
+		domain.ErrNegativeAmount,
+		domain.ErrInsufficientBalance,
+		domain.ErrSystemAccount:
+		return http.StatusBadRequest, web.Error(err)
+	}
+
+	return http.StatusInternalServerError, web.Error(errorspkg.ErrInternal)
+}
+
+type getRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// Get handles http request to get a transfer of the user's accounts.
+func (h *Handler) Get(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+	l := zerolog.Ctx(ctx)
+
+	var req getRequest
+	if err := gctx.ShouldBindUri(&req); err != nil {
+		l.Info().Err(err).Send()
+
+		var ve validator.ValidationErrors
+		if errors.As(err, &ve) {
+			gctx.JSON(http.StatusBadRequest, web.Response{Error: web.GetErrorMsg(ve)})
+
+			return
+		}
+
+		gctx.JSON(http.StatusBadRequest, web.Error(err))
+
+		return
+	}
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	transfer, err := h.service.Get(ctx, authPayload.Username, req.ID)
+	if err != nil {
 		switch err {
-		case
-			domain.ErrInvalidOwner:
+		case domain.ErrTransferNotFound:
+			gctx.JSON(http.StatusNotFound, web.Error(err))
+			return
+		case domain.ErrTransferOwnerMismatch:
+			l.Warn().Err(err).Send()
 			gctx.JSON(http.StatusUnauthorized, web.Error(err))
 
+			return
+		}
+
+		gctx.JSON(http.StatusInternalServerError, web.Error(errorspkg.ErrInternal))
+
+		return
+	}
+
+	res := web.Response{
+		Data: &struct {
+			Transfer domain.Transfer `json:"transfer"`
+		}{
+			Transfer: transfer,
+		},
+	}
+
+	gctx.JSON(http.StatusOK, res)
+}
+
+type reverseRequest struct {
+	Amount string `json:"amount"`
+}
+
+// Reverse handles http request to refund the transfer, or the given amount of
+// it, back to the sender. The whole amount left to refund is reversed if the
+// request has no body.
+//
+// Only the recipient or an admin can reverse the transfer.
+func (h *Handler) Reverse(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+	l := zerolog.Ctx(ctx)
+
+	var uri getRequest
+	if err := gctx.ShouldBindUri(&uri); err != nil {
+		l.Info().Err(err).Send()
+
+		var ve validator.ValidationErrors
+		if errors.As(err, &ve) {
+			gctx.JSON(http.StatusBadRequest, web.Response{Error: web.GetErrorMsg(ve)})
+
+			return
+		}
+
+		gctx.JSON(http.StatusBadRequest, web.Error(err))
+
+		return
+	}
+
+	var req reverseRequest
+	if gctx.Request.ContentLength != 0 {
+		if err := gctx.ShouldBindJSON(&req); err != nil {
+			l.Info().Err(err).Send()
+			gctx.JSON(http.StatusBadRequest, web.Error(err))
+
+			return
+		}
+	}
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	arg := domain.ReverseTransferParams{
+		TransferID: uri.ID,
+		Amount:     req.Amount,
+	}
+
+	result, err := h.service.Reverse(ctx, authPayload.Username, authPayload.HasScope(domain.ScopeAdminWrite), arg)
+	if err != nil {
+		l.Info().Err(err).Send()
+
+		switch err {
+		case domain.ErrTransferNotFound:
+			gctx.JSON(http.StatusNotFound, web.Error(err))
+
+			return
+		case
+			domain.ErrReversalNotAllowed,
+			domain.ErrAccountFrozen:
+			gctx.JSON(http.StatusForbidden, web.Error(err))
+
 			return
 		case
+			domain.ErrTransferNotReversible,
+			domain.ErrTransferReversed,
+			domain.ErrRefundAmountExceeded,
 			domain.ErrInvalidAmount,
 			domain.ErrNegativeAmount,
-			domain.ErrInsufficientBalance,
-			domain.ErrCurrencyMismatch:
+			domain.ErrInsufficientBalance:
 			gctx.JSON(http.StatusBadRequest, web.Error(err))
 
 			return
@@ -97,11 +626,116 @@ func (h *Handler) Create(gctx *gin.Context) {
 
 	res := web.Response{
 		Data: struct {
-			Transfer domain.TransferTxResult `json:"transfer"`
+			Reversal domain.ReversalTxResult `json:"reversal"`
 		}{
-			Transfer: result,
+			Reversal: result,
 		},
 	}
 
 	gctx.JSON(http.StatusCreated, res)
 }
+
+type listRequest struct {
+	PageID    int32     `form:"page_id" binding:"required_without=PageToken,omitempty,min=1"`
+	PageSize  int32     `form:"page_size" binding:"required,min=1,max=100"`
+	PageToken string    `form:"page_token"`
+	AccountID int32     `form:"account_id" binding:"omitempty,min=1"`
+	Direction string    `form:"direction" binding:"omitempty,oneof=incoming outgoing"`
+	MinAmount string    `form:"min_amount"`
+	MaxAmount string    `form:"max_amount"`
+	StartDate time.Time `form:"start_date" time_format:"2006-01-02" time_utc:"1"`
+	EndDate   time.Time `form:"end_date" time_format:"2006-01-02" time_utc:"1"`
+	Search    string    `form:"search" binding:"max=255"`
+	Reference string    `form:"reference" binding:"max=64"`
+}
+
+// List handles http request to list transfers of the user's accounts.
+//
+// The end date is inclusive. The page is located by page_token if it is set,
+// otherwise by page_id.
+func (h *Handler) List(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+	l := zerolog.Ctx(ctx)
+
+	var req listRequest
+	if err := gctx.ShouldBindQuery(&req); err != nil {
+		l.Info().Err(err).Send()
+
+		var ve validator.ValidationErrors
+		if errors.As(err, &ve) {
+			gctx.JSON(http.StatusBadRequest, web.Response{Error: web.GetErrorMsg(ve)})
+
+			return
+		}
+
+		gctx.JSON(http.StatusBadRequest, web.Error(err))
+
+		return
+	}
+
+	pageReq := pagepkg.Request{PageID: req.PageID, PageSize: req.PageSize}
+
+	if req.PageToken != "" {
+		cursor, err := pagepkg.Parse(req.PageToken)
+		if err != nil {
+			l.Info().Err(err).Send()
+			gctx.JSON(http.StatusBadRequest, web.Error(err))
+
+			return
+		}
+
+		pageReq.Cursor = cursor
+	}
+
+	authPayload := gctx.MustGet(middleware.AuthPayloadKey).(*tokenpkg.Payload)
+
+	arg := domain.ListTransfersParams{
+		Username:  authPayload.Username,
+		AccountID: req.AccountID,
+		Direction: req.Direction,
+		MinAmount: req.MinAmount,
+		MaxAmount: req.MaxAmount,
+		StartTime: req.StartDate,
+		Search:    req.Search,
+		Reference: req.Reference,
+	}
+
+	if !req.EndDate.IsZero() {
+		arg.EndTime = req.EndDate.AddDate(0, 0, 1)
+	}
+
+	transfers, page, err := h.service.List(ctx, arg, pageReq)
+	if err != nil {
+		switch err {
+		case domain.ErrAccountNotFound:
+			gctx.JSON(http.StatusNotFound, web.Error(err))
+			return
+		case domain.ErrAccountOwnerMismatch:
+			gctx.JSON(http.StatusUnauthorized, web.Error(err))
+			return
+		case
+			domain.ErrInvalidAmount,
+			domain.ErrNegativeAmount,
+			domain.ErrInvalidDateRange:
+			gctx.JSON(http.StatusBadRequest, web.Error(err))
+
+			return
+		}
+
+		gctx.JSON(http.StatusInternalServerError, web.Error(errorspkg.ErrInternal))
+
+		return
+	}
+
+	res := web.Response{
+		Data: &struct {
+			Transfers []domain.Transfer `json:"transfers"`
+		}{
+			Transfers: transfers,
+		},
+		NextCursor: page.Next,
+		PrevCursor: page.Prev,
+	}
+
+	gctx.JSON(http.StatusOK, res)
+}
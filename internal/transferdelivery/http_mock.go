@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	domain "github.com/go-petr/pet-bank/internal/domain"
+	pagepkg "github.com/go-petr/pet-bank/pkg/pagepkg"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -35,6 +36,97 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// CreateIdempotencyKey mocks base method.
+func (m *MockService) CreateIdempotencyKey(ctx context.Context, arg domain.CreateIdempotencyKeyParams) (domain.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIdempotencyKey", ctx, arg)
+	ret0, _ := ret[0].(domain.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIdempotencyKey indicates an expected call of CreateIdempotencyKey.
+func (mr *MockServiceMockRecorder) CreateIdempotencyKey(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIdempotencyKey", reflect.TypeOf((*MockService)(nil).CreateIdempotencyKey), ctx, arg)
+}
+
+// Deposit mocks base method.
+func (m *MockService) Deposit(ctx context.Context, actor string, arg domain.CreateCashParams) (domain.TransferTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deposit", ctx, actor, arg)
+	ret0, _ := ret[0].(domain.TransferTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deposit indicates an expected call of Deposit.
+func (mr *MockServiceMockRecorder) Deposit(ctx, actor, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deposit", reflect.TypeOf((*MockService)(nil).Deposit), ctx, actor, arg)
+}
+
+// Get mocks base method.
+func (m *MockService) Get(ctx context.Context, username string, id int64) (domain.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, username, id)
+	ret0, _ := ret[0].(domain.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockServiceMockRecorder) Get(ctx, username, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockService)(nil).Get), ctx, username, id)
+}
+
+// GetIdempotencyKey mocks base method.
+func (m *MockService) GetIdempotencyKey(ctx context.Context, username, key string) (domain.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdempotencyKey", ctx, username, key)
+	ret0, _ := ret[0].(domain.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdempotencyKey indicates an expected call of GetIdempotencyKey.
+func (mr *MockServiceMockRecorder) GetIdempotencyKey(ctx, username, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdempotencyKey", reflect.TypeOf((*MockService)(nil).GetIdempotencyKey), ctx, username, key)
+}
+
+// List mocks base method.
+func (m *MockService) List(ctx context.Context, arg domain.ListTransfersParams, page pagepkg.Request) ([]domain.Transfer, pagepkg.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, arg, page)
+	ret0, _ := ret[0].([]domain.Transfer)
+	ret1, _ := ret[1].(pagepkg.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockServiceMockRecorder) List(ctx, arg, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List), ctx, arg, page)
+}
+
+// Reverse mocks base method.
+func (m *MockService) Reverse(ctx context.Context, actor string, asAdmin bool, arg domain.ReverseTransferParams) (domain.ReversalTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reverse", ctx, actor, asAdmin, arg)
+	ret0, _ := ret[0].(domain.ReversalTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reverse indicates an expected call of Reverse.
+func (mr *MockServiceMockRecorder) Reverse(ctx, actor, asAdmin, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reverse", reflect.TypeOf((*MockService)(nil).Reverse), ctx, actor, asAdmin, arg)
+}
+
 // Transfer mocks base method.
 func (m *MockService) Transfer(ctx context.Context, fromUsername string, arg domain.CreateTransferParams) (domain.TransferTxResult, error) {
 	m.ctrl.T.Helper()
@@ -49,3 +141,18 @@ func (mr *MockServiceMockRecorder) Transfer(ctx, fromUsername, arg interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockService)(nil).Transfer), ctx, fromUsername, arg)
 }
+
+// Withdraw mocks base method.
+func (m *MockService) Withdraw(ctx context.Context, actor string, arg domain.CreateCashParams) (domain.TransferTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Withdraw", ctx, actor, arg)
+	ret0, _ := ret[0].(domain.TransferTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Withdraw indicates an expected call of Withdraw.
+func (mr *MockServiceMockRecorder) Withdraw(ctx, actor, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockService)(nil).Withdraw), ctx, actor, arg)
+}
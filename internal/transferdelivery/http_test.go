@@ -3,24 +3,31 @@ package transferdelivery
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"time"
 
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
 	"github.com/go-petr/pet-bank/internal/domain"
 	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
 	"github.com/go-petr/pet-bank/internal/middleware"
+	"github.com/go-petr/pet-bank/pkg/currencypkg"
 	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
 	"github.com/go-petr/pet-bank/pkg/randompkg"
 	"github.com/go-petr/pet-bank/pkg/tokenpkg"
 	"github.com/go-petr/pet-bank/pkg/web"
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 )
 
 func TestCreate(t *testing.T) {
@@ -36,15 +43,32 @@ func TestCreate(t *testing.T) {
 		t.Fatalf("tokenpkg.NewPasetoMaker(%v) returned error: %v", symmetricKey, err)
 	}
 
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		if err := v.RegisterValidation("currency", currencypkg.ValidCurrency); err != nil {
+			t.Fatalf("v.RegisterValidation(currency) returned error: %v", err)
+		}
+	}
+
 	authType := middleware.AuthTypeBearer
 	duration := time.Minute
 
 	type requestBody struct {
-		FromAccountID int32  `json:"from_account_id" binding:"required,min=1"`
-		ToAccountID   int32  `json:"to_account_id" binding:"required,min=1"`
-		Amount        string `json:"amount" binding:"required"`
+		FromAccountID int32          `json:"from_account_id" binding:"required,min=1"`
+		ToAccountID   int32          `json:"to_account_id" binding:"required,min=1"`
+		Amount        string         `json:"amount" binding:"required"`
+		PayeeID       int64          `json:"payee_id,omitempty"`
+		ToUsername    string         `json:"to_username,omitempty"`
+		ToAlias       string         `json:"to_alias,omitempty"`
+		Currency      string         `json:"currency,omitempty"`
+		QuoteID       string         `json:"quote_id,omitempty"`
+		Description   string         `json:"description,omitempty"`
+		Reference     string         `json:"reference,omitempty"`
+		Metadata      map[string]any `json:"metadata,omitempty"`
 	}
 
+	quoteID := uuid.New()
+	tooLarge := map[string]any{"note": strings.Repeat("a", domain.MaxMemoMetadataSize)}
+
 	want := domain.TransferTxResult{
 		Transfer: domain.Transfer{
 			FromAccountID: account1.ID,
@@ -142,6 +166,72 @@ func TestCreate(t *testing.T) {
 			wantStatusCode: http.StatusUnauthorized,
 			wantError:      middleware.ErrAuthHeaderNotFound.Error(),
 		},
+		{
+			name: "Memo",
+			requestBody: requestBody{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        amount,
+				Description:   "Rent for March",
+				Reference:     "INV-42",
+				Metadata:      map[string]any{"flat": "12B"},
+			},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username1, duration)
+			},
+			buildStubs: func(transferService *MockService) {
+				arg := domain.CreateTransferParams{
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        amount,
+					Memo: domain.Memo{
+						Description: "Rent for March",
+						Reference:   "INV-42",
+						Metadata:    map[string]any{"flat": "12B"},
+					},
+				}
+
+				transferService.EXPECT().
+					Transfer(gomock.Any(), gomock.Eq(username1), gomock.Eq(arg)).
+					Times(1).
+					Return(want, nil)
+			},
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name: "DescriptionTooLong",
+			requestBody: requestBody{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        amount,
+				Description:   strings.Repeat("a", domain.MaxMemoDescriptionLength+1),
+			},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username1, duration)
+			},
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "Description must be less than 255",
+		},
+		{
+			name: "ErrMetadataTooLarge",
+			requestBody: requestBody{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        amount,
+				Metadata:      tooLarge,
+			},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username1, duration)
+			},
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      domain.ErrMetadataTooLarge.Error(),
+		},
 		{
 			name: "RequiredFromAccountID",
 			requestBody: requestBody{
@@ -172,7 +262,257 @@ func TestCreate(t *testing.T) {
 				transferService.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 			},
 			wantStatusCode: http.StatusBadRequest,
-			wantError:      "ToAccountID field is required",
+			wantError:      "ToAccountID field is required without PayeeID ToUsername ToAlias",
+		},
+		{
+			name: "ToUsername",
+			requestBody: requestBody{
+				FromAccountID: account1.ID,
+				ToUsername:    username2,
+				Amount:        amount,
+			},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username1, duration)
+			},
+			buildStubs: func(transferService *MockService) {
+				arg := domain.CreateTransferParams{
+					FromAccountID: account1.ID,
+					Amount:        amount,
+					Recipient:     &domain.Recipient{Username: username2},
+				}
+
+				transferService.EXPECT().
+					Transfer(gomock.Any(), gomock.Eq(username1), gomock.Eq(arg)).
+					Times(1).
+					Return(want, nil)
+			},
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name: "ToAlias",
+			requestBody: requestBody{
+				FromAccountID: account1.ID,
+				ToAlias:       "+15550102030",
+				Currency:      account2.Currency,
+				Amount:        amount,
+			},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username1, duration)
+			},
+			buildStubs: func(transferService *MockService) {
+				arg := domain.CreateTransferParams{
+					FromAccountID: account1.ID,
+					Amount:        amount,
+					Recipient:     &domain.Recipient{Alias: "+15550102030", Currency: account2.Currency},
+				}
+
+				transferService.EXPECT().
+					Transfer(gomock.Any(), gomock.Eq(username1), gomock.Eq(arg)).
+					Times(1).
+					Return(want, nil)
+			},
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name: "PayeeID",
+			requestBody: requestBody{
+				FromAccountID: account1.ID,
+				PayeeID:       3,
+				Amount:        amount,
+			},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username1, duration)
+			},
+			buildStubs: func(transferService *MockService) {
+				arg := domain.CreateTransferParams{
+					FromAccountID: account1.ID,
+					Amount:        amount,
+					PayeeID:       3,
+				}
+
+				transferService.EXPECT().
+					Transfer(gomock.Any(), gomock.Eq(username1), gomock.Eq(arg)).
+					Times(1).
+					Return(want, nil)
+			},
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name: "PayeeIDWithToAccountID",
+			requestBody: requestBody{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				PayeeID:       3,
+				Amount:        amount,
+			},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username1, duration)
+			},
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "PayeeID can't be used with ToAccountID ToUsername ToAlias",
+		},
+		{
+			name: "ErrPayeeCoolingOff",
+			requestBody: requestBody{
+				FromAccountID: account1.ID,
+				PayeeID:       3,
+				Amount:        amount,
+			},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username1, duration)
+			},
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().
+					Transfer(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.TransferTxResult{}, domain.ErrPayeeCoolingOff)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantError:      domain.ErrPayeeCoolingOff.Error(),
+		},
+		{
+			name: "ErrAmbiguousRecipient",
+			requestBody: requestBody{
+				FromAccountID: account1.ID,
+				ToAlias:       "alice@example.com",
+				Currency:      account2.Currency,
+				Amount:        amount,
+			},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username1, duration)
+			},
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().
+					Transfer(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.TransferTxResult{}, domain.ErrAmbiguousRecipient)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantError:      domain.ErrAmbiguousRecipient.Error(),
+		},
+		{
+			name: "ErrTransferBlocked",
+			requestBody: requestBody{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        amount,
+			},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username1, duration)
+			},
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().
+					Transfer(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.TransferTxResult{}, domain.ErrTransferBlocked)
+			},
+			wantStatusCode: http.StatusForbidden,
+			wantError:      domain.ErrTransferBlocked.Error(),
+		},
+		{
+			name: "ErrSanctionsHit",
+			requestBody: requestBody{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        amount,
+			},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username1, duration)
+			},
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().
+					Transfer(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.TransferTxResult{}, domain.ErrSanctionsHit)
+			},
+			wantStatusCode: http.StatusForbidden,
+			wantError:      domain.ErrSanctionsHit.Error(),
+		},
+		{
+			name: "HeldForReview",
+			requestBody: requestBody{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        amount,
+			},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username1, duration)
+			},
+			buildStubs: func(transferService *MockService) {
+				held := domain.TransferTxResult{
+					Review: &domain.TransferReview{
+						ID:            1,
+						Username:      username1,
+						FromAccountID: account1.ID,
+						ToAccountID:   account2.ID,
+						Amount:        amount,
+						Rules:         []string{"many_new_payees"},
+						Status:        domain.TransferReviewStatusPending,
+					},
+				}
+
+				transferService.EXPECT().
+					Transfer(gomock.Any(), gomock.Eq(username1), gomock.Any()).
+					Times(1).
+					Return(held, nil)
+			},
+			wantStatusCode: http.StatusAccepted,
+		},
+		{
+			name: "ToUsernameWithToAccountID",
+			requestBody: requestBody{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				ToUsername:    username2,
+				Amount:        amount,
+			},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username1, duration)
+			},
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "ToUsername can't be used with ToAccountID ToAlias",
+		},
+		{
+			name: "UnsupportedCurrency",
+			requestBody: requestBody{
+				FromAccountID: account1.ID,
+				ToUsername:    username2,
+				Currency:      "XYZ",
+				Amount:        amount,
+			},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username1, duration)
+			},
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "Currency is not supported",
+		},
+		{
+			name: "ErrRecipientAccountNotFound",
+			requestBody: requestBody{
+				FromAccountID: account1.ID,
+				ToUsername:    username2,
+				Amount:        amount,
+			},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username1, duration)
+			},
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().
+					Transfer(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.TransferTxResult{}, domain.ErrRecipientAccountNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      domain.ErrRecipientAccountNotFound.Error(),
 		},
 		{
 			name: "RequiredAmount",
@@ -216,7 +556,7 @@ func TestCreate(t *testing.T) {
 			wantError:      domain.ErrInvalidOwner.Error(),
 		},
 		{
-			name: "ErrCurrencyMismatch",
+			name: "ErrAccountFrozen",
 			requestBody: requestBody{
 				FromAccountID: account1.ID,
 				ToAccountID:   account2.ID,
@@ -235,13 +575,13 @@ func TestCreate(t *testing.T) {
 				transferService.EXPECT().
 					Transfer(gomock.Any(), gomock.Eq(username1), gomock.Eq(arg)).
 					Times(1).
-					Return(domain.TransferTxResult{}, domain.ErrCurrencyMismatch)
+					Return(domain.TransferTxResult{}, domain.ErrAccountFrozen)
 			},
-			wantStatusCode: http.StatusBadRequest,
-			wantError:      domain.ErrCurrencyMismatch.Error(),
+			wantStatusCode: http.StatusForbidden,
+			wantError:      domain.ErrAccountFrozen.Error(),
 		},
 		{
-			name: "InvalidTransferInternalError",
+			name: "TransferLimitError",
 			requestBody: requestBody{
 				FromAccountID: account1.ID,
 				ToAccountID:   account2.ID,
@@ -251,19 +591,153 @@ func TestCreate(t *testing.T) {
 				return middleware.AddAuthorization(r, tokenMaker, authType, username1, duration)
 			},
 			buildStubs: func(transferService *MockService) {
-				arg := domain.CreateTransferParams{
-					FromAccountID: account1.ID,
-					ToAccountID:   account2.ID,
-					Amount:        amount,
-				}
-
 				transferService.EXPECT().
-					Transfer(gomock.Any(), gomock.Eq(username1), gomock.Eq(arg)).
+					Transfer(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(domain.TransferTxResult{}, errorspkg.ErrInternal)
+					Return(domain.TransferTxResult{}, &domain.TransferLimitError{
+						Limit:     domain.LimitDaily,
+						Currency:  account1.Currency,
+						Remaining: "50",
+					})
 			},
-			wantStatusCode: http.StatusInternalServerError,
-			wantError:      errorspkg.ErrInternal.Error(),
+			wantStatusCode: http.StatusForbidden,
+			wantError:      "daily transfer limit exceeded, 50 " + account1.Currency + " remaining",
+		},
+		{
+			name: "ErrCurrencyMismatch",
+			requestBody: requestBody{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        amount,
+			},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username1, duration)
+			},
+			buildStubs: func(transferService *MockService) {
+				arg := domain.CreateTransferParams{
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        amount,
+				}
+
+				transferService.EXPECT().
+					Transfer(gomock.Any(), gomock.Eq(username1), gomock.Eq(arg)).
+					Times(1).
+					Return(domain.TransferTxResult{}, domain.ErrCurrencyMismatch)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      domain.ErrCurrencyMismatch.Error(),
+		},
+		{
+			name: "WithQuote",
+			requestBody: requestBody{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        amount,
+				QuoteID:       quoteID.String(),
+			},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username1, duration)
+			},
+			buildStubs: func(transferService *MockService) {
+				arg := domain.CreateTransferParams{
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        amount,
+					FXQuoteID:     &quoteID,
+				}
+
+				transferService.EXPECT().
+					Transfer(gomock.Any(), gomock.Eq(username1), gomock.Eq(arg)).
+					Times(1).
+					Return(want, nil)
+			},
+			wantStatusCode: http.StatusCreated,
+			checkData:      func(req requestBody, data any) {},
+		},
+		{
+			name: "InvalidQuoteID",
+			requestBody: requestBody{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        amount,
+				QuoteID:       "quote",
+			},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username1, duration)
+			},
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().
+					Transfer(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "QuoteID must be a valid UUID",
+		},
+		{
+			name: "ErrFXQuoteNotFound",
+			requestBody: requestBody{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        amount,
+				QuoteID:       quoteID.String(),
+			},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username1, duration)
+			},
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().
+					Transfer(gomock.Any(), gomock.Eq(username1), gomock.Any()).
+					Times(1).
+					Return(domain.TransferTxResult{}, domain.ErrFXQuoteNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      domain.ErrFXQuoteNotFound.Error(),
+		},
+		{
+			name: "ErrFXQuoteExpired",
+			requestBody: requestBody{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        amount,
+				QuoteID:       quoteID.String(),
+			},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username1, duration)
+			},
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().
+					Transfer(gomock.Any(), gomock.Eq(username1), gomock.Any()).
+					Times(1).
+					Return(domain.TransferTxResult{}, domain.ErrFXQuoteExpired)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      domain.ErrFXQuoteExpired.Error(),
+		},
+		{
+			name: "InvalidTransferInternalError",
+			requestBody: requestBody{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        amount,
+			},
+			setupAuth: func(r *http.Request) error {
+				return middleware.AddAuthorization(r, tokenMaker, authType, username1, duration)
+			},
+			buildStubs: func(transferService *MockService) {
+				arg := domain.CreateTransferParams{
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        amount,
+				}
+
+				transferService.EXPECT().
+					Transfer(gomock.Any(), gomock.Eq(username1), gomock.Eq(arg)).
+					Times(1).
+					Return(domain.TransferTxResult{}, errorspkg.ErrInternal)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      errorspkg.ErrInternal.Error(),
 		},
 	}
 
@@ -284,7 +758,7 @@ func TestCreate(t *testing.T) {
 			server := gin.New()
 			url := "/transfers"
 
-			server.Use(middleware.AuthMiddleware(tokenMaker))
+			server.Use(middleware.AuthMiddleware(tokenMaker, nil))
 			server.POST(url, transferHandler.Create)
 
 			tc.buildStubs(transferService)
@@ -332,3 +806,1097 @@ func TestCreate(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateIdempotent(t *testing.T) {
+	username := randompkg.Owner()
+	account1 := helpers.RandomAccount(username)
+	account2 := helpers.RandomAccount(randompkg.Owner())
+	symmetricKey := randompkg.String(32)
+
+	tokenMaker, err := tokenpkg.NewPasetoMaker(symmetricKey)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(%v) returned error: %v", symmetricKey, err)
+	}
+
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		if err := v.RegisterValidation("currency", currencypkg.ValidCurrency); err != nil {
+			t.Fatalf("v.RegisterValidation(currency) returned error: %v", err)
+		}
+	}
+
+	req := request{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        "100",
+	}
+
+	requestHash, err := hashRequest(req)
+	if err != nil {
+		t.Fatalf("hashRequest(%+v) returned error: %v", req, err)
+	}
+
+	idempotencyKey := randompkg.String(20)
+
+	result := domain.TransferTxResult{
+		Transfer: domain.Transfer{
+			ID:            1,
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        req.Amount,
+			CreatedAt:     time.Now().UTC().Truncate(time.Second),
+		},
+	}
+
+	resultBody, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("json.Marshal(%+v) returned error: %v", result, err)
+	}
+
+	stored := domain.IdempotencyKey{
+		Key:            idempotencyKey,
+		Username:       username,
+		RequestHash:    requestHash,
+		ResponseStatus: http.StatusCreated,
+		ResponseBody:   resultBody,
+	}
+
+	wantArg := domain.CreateTransferParams{
+		FromAccountID: req.FromAccountID,
+		ToAccountID:   req.ToAccountID,
+		Amount:        req.Amount,
+		Idempotency: &domain.CreateIdempotencyKeyParams{
+			Key:            idempotencyKey,
+			Username:       username,
+			RequestHash:    requestHash,
+			ResponseStatus: http.StatusCreated,
+		},
+	}
+
+	failedBody, err := json.Marshal(web.Error(domain.ErrInsufficientBalance))
+	if err != nil {
+		t.Fatalf("json.Marshal(%v) returned error: %v", domain.ErrInsufficientBalance, err)
+	}
+
+	failedArg := domain.CreateIdempotencyKeyParams{
+		Key:            idempotencyKey,
+		Username:       username,
+		RequestHash:    requestHash,
+		ResponseStatus: http.StatusBadRequest,
+		ResponseBody:   failedBody,
+	}
+
+	storedFailed := domain.IdempotencyKey{
+		Key:            idempotencyKey,
+		Username:       username,
+		RequestHash:    requestHash,
+		ResponseStatus: http.StatusBadRequest,
+		ResponseBody:   failedBody,
+	}
+
+	testCases := []struct {
+		name           string
+		requestBody    request
+		idempotencyKey string
+		buildStubs     func(transferService *MockService)
+		wantStatusCode int
+		wantReplayed   bool
+		wantError      string
+	}{
+		{
+			name:           "FirstRequest",
+			requestBody:    req,
+			idempotencyKey: idempotencyKey,
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().
+					GetIdempotencyKey(gomock.Any(), gomock.Eq(username), gomock.Eq(idempotencyKey)).
+					Times(1).
+					Return(domain.IdempotencyKey{}, domain.ErrIdempotencyKeyNotFound)
+				transferService.EXPECT().
+					Transfer(gomock.Any(), gomock.Eq(username), gomock.Eq(wantArg)).
+					Times(1).
+					Return(result, nil)
+			},
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name:           "Replay",
+			requestBody:    req,
+			idempotencyKey: idempotencyKey,
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().
+					GetIdempotencyKey(gomock.Any(), gomock.Eq(username), gomock.Eq(idempotencyKey)).
+					Times(1).
+					Return(stored, nil)
+				transferService.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusCreated,
+			wantReplayed:   true,
+		},
+		{
+			name: "ErrIdempotencyKeyMismatch",
+			requestBody: request{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        "200",
+			},
+			idempotencyKey: idempotencyKey,
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().
+					GetIdempotencyKey(gomock.Any(), gomock.Eq(username), gomock.Eq(idempotencyKey)).
+					Times(1).
+					Return(stored, nil)
+				transferService.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusUnprocessableEntity,
+			wantError:      domain.ErrIdempotencyKeyMismatch.Error(),
+		},
+		{
+			name:           "ConcurrentRequestCommittedFirst",
+			requestBody:    req,
+			idempotencyKey: idempotencyKey,
+			buildStubs: func(transferService *MockService) {
+				gomock.InOrder(
+					transferService.EXPECT().
+						GetIdempotencyKey(gomock.Any(), gomock.Eq(username), gomock.Eq(idempotencyKey)).
+						Times(1).
+						Return(domain.IdempotencyKey{}, domain.ErrIdempotencyKeyNotFound),
+					transferService.EXPECT().
+						Transfer(gomock.Any(), gomock.Eq(username), gomock.Eq(wantArg)).
+						Times(1).
+						Return(domain.TransferTxResult{}, domain.ErrIdempotencyKeyExists),
+					transferService.EXPECT().
+						GetIdempotencyKey(gomock.Any(), gomock.Eq(username), gomock.Eq(idempotencyKey)).
+						Times(1).
+						Return(stored, nil),
+				)
+			},
+			wantStatusCode: http.StatusCreated,
+			wantReplayed:   true,
+		},
+		{
+			name:           "FailedFirstRequest",
+			requestBody:    req,
+			idempotencyKey: idempotencyKey,
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().
+					GetIdempotencyKey(gomock.Any(), gomock.Eq(username), gomock.Eq(idempotencyKey)).
+					Times(1).
+					Return(domain.IdempotencyKey{}, domain.ErrIdempotencyKeyNotFound)
+				transferService.EXPECT().
+					Transfer(gomock.Any(), gomock.Eq(username), gomock.Eq(wantArg)).
+					Times(1).
+					Return(domain.TransferTxResult{}, domain.ErrInsufficientBalance)
+				transferService.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), gomock.Eq(failedArg)).
+					Times(1).
+					Return(storedFailed, nil)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      domain.ErrInsufficientBalance.Error(),
+		},
+		{
+			name:           "ReplayFailure",
+			requestBody:    req,
+			idempotencyKey: idempotencyKey,
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().
+					GetIdempotencyKey(gomock.Any(), gomock.Eq(username), gomock.Eq(idempotencyKey)).
+					Times(1).
+					Return(storedFailed, nil)
+				transferService.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantReplayed:   true,
+			wantError:      domain.ErrInsufficientBalance.Error(),
+		},
+		{
+			name:           "ConcurrentRequestStoredFirst",
+			requestBody:    req,
+			idempotencyKey: idempotencyKey,
+			buildStubs: func(transferService *MockService) {
+				gomock.InOrder(
+					transferService.EXPECT().
+						GetIdempotencyKey(gomock.Any(), gomock.Eq(username), gomock.Eq(idempotencyKey)).
+						Times(1).
+						Return(domain.IdempotencyKey{}, domain.ErrIdempotencyKeyNotFound),
+					transferService.EXPECT().
+						Transfer(gomock.Any(), gomock.Eq(username), gomock.Eq(wantArg)).
+						Times(1).
+						Return(domain.TransferTxResult{}, domain.ErrInsufficientBalance),
+					transferService.EXPECT().
+						CreateIdempotencyKey(gomock.Any(), gomock.Eq(failedArg)).
+						Times(1).
+						Return(domain.IdempotencyKey{}, domain.ErrIdempotencyKeyExists),
+					transferService.EXPECT().
+						GetIdempotencyKey(gomock.Any(), gomock.Eq(username), gomock.Eq(idempotencyKey)).
+						Times(1).
+						Return(stored, nil),
+				)
+			},
+			wantStatusCode: http.StatusCreated,
+			wantReplayed:   true,
+		},
+		{
+			name:           "InternalErrorNotStored",
+			requestBody:    req,
+			idempotencyKey: idempotencyKey,
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().
+					GetIdempotencyKey(gomock.Any(), gomock.Eq(username), gomock.Eq(idempotencyKey)).
+					Times(1).
+					Return(domain.IdempotencyKey{}, domain.ErrIdempotencyKeyNotFound)
+				transferService.EXPECT().
+					Transfer(gomock.Any(), gomock.Eq(username), gomock.Eq(wantArg)).
+					Times(1).
+					Return(domain.TransferTxResult{}, errorspkg.ErrInternal)
+				transferService.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      errorspkg.ErrInternal.Error(),
+		},
+		{
+			name:           "ErrInvalidIdempotencyKey",
+			requestBody:    req,
+			idempotencyKey: randompkg.String(maxIdempotencyKeyLength + 1),
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().GetIdempotencyKey(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				transferService.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      domain.ErrInvalidIdempotencyKey.Error(),
+		},
+		{
+			name:           "GetIdempotencyKeyInternalError",
+			requestBody:    req,
+			idempotencyKey: idempotencyKey,
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().
+					GetIdempotencyKey(gomock.Any(), gomock.Eq(username), gomock.Eq(idempotencyKey)).
+					Times(1).
+					Return(domain.IdempotencyKey{}, errorspkg.ErrInternal)
+				transferService.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      errorspkg.ErrInternal.Error(),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transferService := NewMockService(ctrl)
+			transferHandler := NewHandler(transferService)
+
+			gin.SetMode(gin.ReleaseMode)
+			server := gin.New()
+			url := "/transfers"
+
+			server.Use(middleware.AuthMiddleware(tokenMaker, nil))
+			server.POST(url, transferHandler.Create)
+
+			tc.buildStubs(transferService)
+
+			body, err := json.Marshal(tc.requestBody)
+			if err != nil {
+				t.Fatalf("Encoding request body error: %v", err)
+			}
+
+			r, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+			if err != nil {
+				t.Fatalf("Creating request error: %v", err)
+			}
+
+			r.Header.Set(IdempotencyKeyHeader, tc.idempotencyKey)
+
+			err = middleware.AddAuthorization(r, tokenMaker, middleware.AuthTypeBearer, username, time.Minute)
+			if err != nil {
+				t.Fatalf("middleware.AddAuthorization returned error: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, r)
+
+			if got := w.Code; got != tc.wantStatusCode {
+				t.Errorf("Status code: got %v, want %v", got, tc.wantStatusCode)
+			}
+
+			if got := w.Header().Get(IdempotentReplayedHeader) == "true"; got != tc.wantReplayed {
+				t.Errorf("%s header set = %v, want %v", IdempotentReplayedHeader, got, tc.wantReplayed)
+			}
+
+			res := web.Response{
+				Data: &struct {
+					Transfer domain.TransferTxResult `json:"transfer"`
+				}{},
+			}
+
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Fatalf("Decoding response body error: %v", err)
+			}
+
+			if tc.wantError != "" {
+				if res.Error != tc.wantError {
+					t.Errorf(`res.Error=%q, want %q`, res.Error, tc.wantError)
+				}
+
+				return
+			}
+
+			got, ok := res.Data.(*struct {
+				Transfer domain.TransferTxResult `json:"transfer"`
+			})
+			if !ok {
+				t.Fatalf(`res.Data=%#v, failed type conversion`, res.Data)
+			}
+
+			compareCreatedAt := cmpopts.EquateApproxTime(time.Second)
+			if diff := cmp.Diff(result, got.Transfer, compareCreatedAt); diff != "" {
+				t.Errorf("res.Data mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	username := randompkg.Owner()
+	account1 := helpers.RandomAccount(username)
+	account2 := helpers.RandomAccount(randompkg.Owner())
+	symmetricKey := randompkg.String(32)
+
+	tokenMaker, err := tokenpkg.NewPasetoMaker(symmetricKey)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(%v) returned error: %v", symmetricKey, err)
+	}
+
+	authType := middleware.AuthTypeBearer
+	duration := time.Minute
+
+	transfer := domain.Transfer{
+		ID:            1,
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        "100",
+		CreatedAt:     time.Now().UTC().Truncate(time.Second),
+	}
+
+	testCases := []struct {
+		name           string
+		transferID     string
+		buildStubs     func(transferService *MockService)
+		wantStatusCode int
+		wantError      string
+	}{
+		{
+			name:       "OK",
+			transferID: fmt.Sprint(transfer.ID),
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().
+					Get(gomock.Any(), gomock.Eq(username), gomock.Eq(transfer.ID)).
+					Times(1).
+					Return(transfer, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:       "InvalidID",
+			transferID: "0",
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "ID field is required",
+		},
+		{
+			name:       "ErrTransferNotFound",
+			transferID: fmt.Sprint(transfer.ID),
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().
+					Get(gomock.Any(), gomock.Eq(username), gomock.Eq(transfer.ID)).
+					Times(1).
+					Return(domain.Transfer{}, domain.ErrTransferNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      domain.ErrTransferNotFound.Error(),
+		},
+		{
+			name:       "ErrTransferOwnerMismatch",
+			transferID: fmt.Sprint(transfer.ID),
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().
+					Get(gomock.Any(), gomock.Eq(username), gomock.Eq(transfer.ID)).
+					Times(1).
+					Return(domain.Transfer{}, domain.ErrTransferOwnerMismatch)
+			},
+			wantStatusCode: http.StatusUnauthorized,
+			wantError:      domain.ErrTransferOwnerMismatch.Error(),
+		},
+		{
+			name:       "InternalServerError",
+			transferID: fmt.Sprint(transfer.ID),
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().
+					Get(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.Transfer{}, errorspkg.ErrInternal)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      errorspkg.ErrInternal.Error(),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transferService := NewMockService(ctrl)
+			transferHandler := NewHandler(transferService)
+
+			server := gin.New()
+			server.Use(middleware.AuthMiddleware(tokenMaker, nil))
+			server.GET("/transfers/:id", transferHandler.Get)
+
+			tc.buildStubs(transferService)
+
+			req, err := http.NewRequest(http.MethodGet, "/transfers/"+tc.transferID, nil)
+			if err != nil {
+				t.Fatalf("Creating request error: %v", err)
+			}
+
+			if err := middleware.AddAuthorization(req, tokenMaker, authType, username, duration); err != nil {
+				t.Fatalf("middleware.AddAuthorization(...) returned error: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			if got := w.Code; got != tc.wantStatusCode {
+				t.Errorf("Status code: got %v, want %v", got, tc.wantStatusCode)
+			}
+
+			data := &struct {
+				Transfer domain.Transfer `json:"transfer"`
+			}{}
+			res := web.Response{Data: data}
+
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Errorf("Decoding response body error: %v", err)
+			}
+
+			if res.Error != tc.wantError {
+				t.Errorf(`res.Error=%q, want %q`, res.Error, tc.wantError)
+			}
+
+			if tc.wantError != "" {
+				return
+			}
+
+			if diff := cmp.Diff(transfer, data.Transfer); diff != "" {
+				t.Errorf("res.Data mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	username := randompkg.Owner()
+	account := helpers.RandomAccount(username)
+	symmetricKey := randompkg.String(32)
+
+	tokenMaker, err := tokenpkg.NewPasetoMaker(symmetricKey)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(%v) returned error: %v", symmetricKey, err)
+	}
+
+	authType := middleware.AuthTypeBearer
+	duration := time.Minute
+
+	transfers := []domain.Transfer{
+		{
+			ID:            1,
+			FromAccountID: account.ID,
+			ToAccountID:   account.ID + 1,
+			Amount:        "100",
+			CreatedAt:     time.Now().UTC().Truncate(time.Second),
+		},
+	}
+
+	cursor := pagepkg.Cursor{AfterID: 1}
+	page := pagepkg.Page{Prev: pagepkg.Cursor{BeforeID: 2}.Token()}
+
+	testCases := []struct {
+		name           string
+		query          string
+		buildStubs     func(transferService *MockService)
+		wantStatusCode int
+		wantPage       pagepkg.Page
+		wantError      string
+	}{
+		{
+			name:  "OK",
+			query: "page_id=1&page_size=5",
+			buildStubs: func(transferService *MockService) {
+				arg := domain.ListTransfersParams{Username: username}
+
+				transferService.EXPECT().
+					List(gomock.Any(), gomock.Eq(arg), gomock.Eq(pagepkg.Request{PageID: 1, PageSize: 5})).
+					Times(1).
+					Return(transfers, pagepkg.Page{}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:  "PageToken",
+			query: "page_size=5&page_token=" + cursor.Token(),
+			buildStubs: func(transferService *MockService) {
+				arg := domain.ListTransfersParams{Username: username}
+
+				transferService.EXPECT().
+					List(gomock.Any(), gomock.Eq(arg), gomock.Eq(pagepkg.Request{PageSize: 5, Cursor: cursor})).
+					Times(1).
+					Return(transfers, page, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantPage:       page,
+		},
+		{
+			name:  "InvalidPageToken",
+			query: "page_size=5&page_token=invalid",
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      pagepkg.ErrInvalidPageToken.Error(),
+		},
+		{
+			name:  "NoPageIDAndPageToken",
+			query: "page_size=5",
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "PageID field is required without PageToken",
+		},
+		{
+			name: "Filters",
+			query: fmt.Sprintf("page_id=2&page_size=10&account_id=%d&direction=incoming"+
+				"&min_amount=10&max_amount=200&start_date=2023-01-01&end_date=2023-01-31&search=rent&reference=INV-42", account.ID),
+			buildStubs: func(transferService *MockService) {
+				arg := domain.ListTransfersParams{
+					Username:  username,
+					AccountID: account.ID,
+					Direction: domain.TransferDirectionIncoming,
+					MinAmount: "10",
+					MaxAmount: "200",
+					StartTime: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+					Search:    "rent",
+					Reference: "INV-42",
+				}
+
+				transferService.EXPECT().
+					List(gomock.Any(), gomock.Eq(arg), gomock.Eq(pagepkg.Request{PageID: 2, PageSize: 10})).
+					Times(1).
+					Return(transfers, pagepkg.Page{}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:  "InvalidDirection",
+			query: "page_id=1&page_size=5&direction=sideways",
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "Direction must be one of: incoming outgoing",
+		},
+		{
+			name:  "InvalidPageSize",
+			query: "page_id=1&page_size=1000",
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "PageSize must be less than 100",
+		},
+		{
+			name:  "ErrAccountOwnerMismatch",
+			query: fmt.Sprintf("page_id=1&page_size=5&account_id=%d", account.ID),
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().
+					List(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, pagepkg.Page{}, domain.ErrAccountOwnerMismatch)
+			},
+			wantStatusCode: http.StatusUnauthorized,
+			wantError:      domain.ErrAccountOwnerMismatch.Error(),
+		},
+		{
+			name:  "ErrInvalidDateRange",
+			query: "page_id=1&page_size=5&start_date=2023-02-01&end_date=2023-01-01",
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().
+					List(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, pagepkg.Page{}, domain.ErrInvalidDateRange)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      domain.ErrInvalidDateRange.Error(),
+		},
+		{
+			name:  "InternalServerError",
+			query: "page_id=1&page_size=5",
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().
+					List(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, pagepkg.Page{}, errorspkg.ErrInternal)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      errorspkg.ErrInternal.Error(),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transferService := NewMockService(ctrl)
+			transferHandler := NewHandler(transferService)
+
+			server := gin.New()
+			server.Use(middleware.AuthMiddleware(tokenMaker, nil))
+			server.GET("/transfers", transferHandler.List)
+
+			tc.buildStubs(transferService)
+
+			req, err := http.NewRequest(http.MethodGet, "/transfers?"+tc.query, nil)
+			if err != nil {
+				t.Fatalf("Creating request error: %v", err)
+			}
+
+			if err := middleware.AddAuthorization(req, tokenMaker, authType, username, duration); err != nil {
+				t.Fatalf("middleware.AddAuthorization(...) returned error: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			if got := w.Code; got != tc.wantStatusCode {
+				t.Errorf("Status code: got %v, want %v", got, tc.wantStatusCode)
+			}
+
+			data := &struct {
+				Transfers []domain.Transfer `json:"transfers"`
+			}{}
+			res := web.Response{Data: data}
+
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Errorf("Decoding response body error: %v", err)
+			}
+
+			if res.Error != tc.wantError {
+				t.Errorf(`res.Error=%q, want %q`, res.Error, tc.wantError)
+			}
+
+			if tc.wantError != "" {
+				return
+			}
+
+			if diff := cmp.Diff(transfers, data.Transfers); diff != "" {
+				t.Errorf("res.Data mismatch (-want +got):\n%s", diff)
+			}
+
+			if got := (pagepkg.Page{Next: res.NextCursor, Prev: res.PrevCursor}); got != tc.wantPage {
+				t.Errorf("res cursors = %+v, want %+v", got, tc.wantPage)
+			}
+		})
+	}
+}
+
+func TestCash(t *testing.T) {
+	username := randompkg.Owner()
+	account := helpers.RandomAccount(username)
+	symmetricKey := randompkg.String(32)
+
+	tokenMaker, err := tokenpkg.NewPasetoMaker(symmetricKey)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(%v) returned error: %v", symmetricKey, err)
+	}
+
+	want := domain.TransferTxResult{
+		Transfer: domain.Transfer{
+			ID:          1,
+			ToAccountID: account.ID,
+			Amount:      "100",
+			Kind:        domain.TransferKindDeposit,
+			CreatedAt:   time.Now().UTC().Truncate(time.Second),
+		},
+		ToAccount: account,
+		ToEntry:   domain.Entry{AccountID: account.ID, Amount: "100"},
+	}
+
+	testCases := []struct {
+		name           string
+		url            string
+		requestBody    gin.H
+		buildStubs     func(transferService *MockService)
+		wantStatusCode int
+		wantError      string
+	}{
+		{
+			name:        "Deposit",
+			url:         "/deposits",
+			requestBody: gin.H{"account_id": account.ID, "amount": "100"},
+			buildStubs: func(transferService *MockService) {
+				arg := domain.CreateCashParams{AccountID: account.ID, Amount: "100"}
+				transferService.EXPECT().Deposit(gomock.Any(), gomock.Eq(username), gomock.Eq(arg)).
+					Times(1).
+					Return(want, nil)
+			},
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name:        "Withdraw",
+			url:         "/withdrawals",
+			requestBody: gin.H{"account_id": account.ID, "amount": "100"},
+			buildStubs: func(transferService *MockService) {
+				arg := domain.CreateCashParams{AccountID: account.ID, Amount: "100"}
+				transferService.EXPECT().Withdraw(gomock.Any(), gomock.Eq(username), gomock.Eq(arg)).
+					Times(1).
+					Return(want, nil)
+			},
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name:        "InvalidAccountID",
+			url:         "/deposits",
+			requestBody: gin.H{"account_id": 0, "amount": "100"},
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().Deposit(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "AccountID field is required",
+		},
+		{
+			name:        "ErrInsufficientBalance",
+			url:         "/withdrawals",
+			requestBody: gin.H{"account_id": account.ID, "amount": "100"},
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().Withdraw(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.TransferTxResult{}, domain.ErrInsufficientBalance)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      domain.ErrInsufficientBalance.Error(),
+		},
+		{
+			name:        "ErrSystemAccount",
+			url:         "/deposits",
+			requestBody: gin.H{"account_id": account.ID, "amount": "100"},
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().Deposit(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.TransferTxResult{}, domain.ErrSystemAccount)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      domain.ErrSystemAccount.Error(),
+		},
+		{
+			name:        "ErrAccountNotFound",
+			url:         "/deposits",
+			requestBody: gin.H{"account_id": account.ID, "amount": "100"},
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().Deposit(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.TransferTxResult{}, domain.ErrAccountNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      domain.ErrAccountNotFound.Error(),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transferService := NewMockService(ctrl)
+			transferHandler := NewHandler(transferService)
+
+			gin.SetMode(gin.ReleaseMode)
+			server := gin.New()
+
+			server.Use(middleware.AuthMiddleware(tokenMaker, nil))
+			server.POST("/deposits", transferHandler.Deposit)
+			server.POST("/withdrawals", transferHandler.Withdraw)
+
+			tc.buildStubs(transferService)
+
+			body, err := json.Marshal(tc.requestBody)
+			if err != nil {
+				t.Fatalf("Encoding request body error: %v", err)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, tc.url, bytes.NewReader(body))
+			if err != nil {
+				t.Fatalf("Creating request error: %v", err)
+			}
+
+			if err := middleware.AddAuthorization(req, tokenMaker, middleware.AuthTypeBearer, username, time.Minute); err != nil {
+				t.Fatalf("middleware.AddAuthorization(...) returned error: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			if got := w.Code; got != tc.wantStatusCode {
+				t.Errorf("Status code: got %v, want %v", got, tc.wantStatusCode)
+			}
+
+			data := &struct {
+				Transfer domain.TransferTxResult `json:"transfer"`
+			}{}
+			res := web.Response{Data: data}
+
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Fatalf("Decoding response body error: %v", err)
+			}
+
+			if res.Error != tc.wantError {
+				t.Errorf(`res.Error=%q, want %q`, res.Error, tc.wantError)
+			}
+
+			if tc.wantStatusCode == http.StatusCreated {
+				if diff := cmp.Diff(want, data.Transfer); diff != "" {
+					t.Errorf("Response returned unexpected diff: %s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestReverse(t *testing.T) {
+	username := randompkg.Owner()
+	symmetricKey := randompkg.String(32)
+
+	tokenMaker, err := tokenpkg.NewPasetoMaker(symmetricKey)
+	if err != nil {
+		t.Fatalf("tokenpkg.NewPasetoMaker(%v) returned error: %v", symmetricKey, err)
+	}
+
+	want := domain.ReversalTxResult{
+		ReversedTransfer: domain.Transfer{
+			ID:             1,
+			FromAccountID:  1,
+			ToAccountID:    2,
+			Amount:         "100",
+			Kind:           domain.TransferKindTransfer,
+			Status:         domain.TransferStatusPartiallyRefunded,
+			RefundedAmount: "40",
+		},
+		Reversal: domain.TransferTxResult{
+			Transfer: domain.Transfer{
+				ID:                 2,
+				FromAccountID:      2,
+				ToAccountID:        1,
+				Amount:             "40",
+				Kind:               domain.TransferKindReversal,
+				Status:             domain.TransferStatusCompleted,
+				RefundedAmount:     "0",
+				ReversedTransferID: 1,
+			},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		url            string
+		requestBody    gin.H
+		role           string
+		buildStubs     func(transferService *MockService)
+		wantStatusCode int
+		wantError      string
+	}{
+		{
+			name:        "PartialRefund",
+			url:         "/transfers/1/reversal",
+			requestBody: gin.H{"amount": "40"},
+			role:        domain.RoleCustomer,
+			buildStubs: func(transferService *MockService) {
+				arg := domain.ReverseTransferParams{TransferID: 1, Amount: "40"}
+				transferService.EXPECT().Reverse(gomock.Any(), gomock.Eq(username), gomock.Eq(false), gomock.Eq(arg)).
+					Times(1).
+					Return(want, nil)
+			},
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name: "AdminNoBody",
+			url:  "/transfers/1/reversal",
+			role: domain.RoleAdmin,
+			buildStubs: func(transferService *MockService) {
+				arg := domain.ReverseTransferParams{TransferID: 1}
+				transferService.EXPECT().Reverse(gomock.Any(), gomock.Eq(username), gomock.Eq(true), gomock.Eq(arg)).
+					Times(1).
+					Return(want, nil)
+			},
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name: "InvalidID",
+			url:  "/transfers/0/reversal",
+			role: domain.RoleCustomer,
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().Reverse(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "ID field is required",
+		},
+		{
+			name: "ErrReversalNotAllowed",
+			url:  "/transfers/1/reversal",
+			role: domain.RoleCustomer,
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().Reverse(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.ReversalTxResult{}, domain.ErrReversalNotAllowed)
+			},
+			wantStatusCode: http.StatusForbidden,
+			wantError:      domain.ErrReversalNotAllowed.Error(),
+		},
+		{
+			name: "ErrTransferReversed",
+			url:  "/transfers/1/reversal",
+			role: domain.RoleCustomer,
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().Reverse(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.ReversalTxResult{}, domain.ErrTransferReversed)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      domain.ErrTransferReversed.Error(),
+		},
+		{
+			name:        "ErrRefundAmountExceeded",
+			url:         "/transfers/1/reversal",
+			requestBody: gin.H{"amount": "200"},
+			role:        domain.RoleCustomer,
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().Reverse(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.ReversalTxResult{}, domain.ErrRefundAmountExceeded)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      domain.ErrRefundAmountExceeded.Error(),
+		},
+		{
+			name: "ErrTransferNotFound",
+			url:  "/transfers/1/reversal",
+			role: domain.RoleCustomer,
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().Reverse(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.ReversalTxResult{}, domain.ErrTransferNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      domain.ErrTransferNotFound.Error(),
+		},
+		{
+			name: "ErrInternal",
+			url:  "/transfers/1/reversal",
+			role: domain.RoleCustomer,
+			buildStubs: func(transferService *MockService) {
+				transferService.EXPECT().Reverse(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.ReversalTxResult{}, errorspkg.ErrInternal)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      errorspkg.ErrInternal.Error(),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transferService := NewMockService(ctrl)
+			transferHandler := NewHandler(transferService)
+
+			gin.SetMode(gin.ReleaseMode)
+			server := gin.New()
+
+			server.Use(middleware.AuthMiddleware(tokenMaker, nil))
+			server.POST("/transfers/:id/reversal", transferHandler.Reverse)
+
+			tc.buildStubs(transferService)
+
+			var body []byte
+			if tc.requestBody != nil {
+				if body, err = json.Marshal(tc.requestBody); err != nil {
+					t.Fatalf("Encoding request body error: %v", err)
+				}
+			}
+
+			req, err := http.NewRequest(http.MethodPost, tc.url, bytes.NewReader(body))
+			if err != nil {
+				t.Fatalf("Creating request error: %v", err)
+			}
+
+			claims := tokenpkg.Claims{Username: username, Role: tc.role, Scopes: domain.RoleScopes(tc.role)}
+			if err := middleware.AddAuthorizationWithClaims(req, tokenMaker, middleware.AuthTypeBearer, claims, time.Minute); err != nil {
+				t.Fatalf("middleware.AddAuthorizationWithClaims(...) returned error: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			if got := w.Code; got != tc.wantStatusCode {
+				t.Errorf("Status code: got %v, want %v", got, tc.wantStatusCode)
+			}
+
+			data := &struct {
+				Reversal domain.ReversalTxResult `json:"reversal"`
+			}{}
+			res := web.Response{Data: data}
+
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Fatalf("Decoding response body error: %v", err)
+			}
+
+			if res.Error != tc.wantError {
+				t.Errorf(`res.Error=%q, want %q`, res.Error, tc.wantError)
+			}
+
+			if tc.wantStatusCode == http.StatusCreated {
+				if diff := cmp.Diff(want, data.Reversal); diff != "" {
+					t.Errorf("Response returned unexpected diff: %s", diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package transferrepo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/accountrepo"
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/holdrepo"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+)
+
+// CreateHold reserves the amount of the account available balance for a
+// transfer to arg.ToAccountID.
+//
+// It locks the account, checks that it is owned by username, has sufficient
+// available balance and the same currency as the to account and that neither
+// account is frozen or a system account, checks arg.Limit if it is set, then
+// adds the amount to the account held amount and creates the hold within a
+// single dbpkg transaction.
+func (r *RepoPGS) CreateHold(ctx context.Context, username string, arg domain.CreateHoldParams) (domain.Hold, error) {
+	l := zerolog.Ctx(ctx)
+
+	var hold domain.Hold
+
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		accountRepo := accountrepo.NewRepoPGS(tx)
+
+		account, err := accountRepo.GetForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		toAccount, err := accountRepo.Get(ctx, arg.ToAccountID)
+		if err != nil {
+			return err
+		}
+
+		if err := validHold(username, account, toAccount, arg.Amount); err != nil {
+			l.Info().Err(err).Send()
+			return err
+		}
+
+		if arg.Limit != nil {
+			if err := checkLimit(ctx, NewTxRepoPGS(tx), account, arg.Amount, *arg.Limit, 0); err != nil {
+				l.Info().Err(err).Send()
+				return err
+			}
+		}
+
+		if _, err := accountRepo.AddHeld(ctx, arg.Amount, arg.AccountID); err != nil {
+			return err
+		}
+
+		hold, err = holdrepo.NewRepoPGS(tx).Create(ctx, arg)
+
+		return err
+	})
+
+	return hold, err
+}
+
+// validHold checks the locked account against the hold request.
+func validHold(username string, account, toAccount domain.Account, amount string) error {
+	if account.IsSystem || toAccount.IsSystem {
+		return domain.ErrSystemAccount
+	}
+
+	if err := validTransfer(username, account, amount); err != nil {
+		return err
+	}
+
+	if account.IsFrozen() || toAccount.IsFrozen() {
+		return domain.ErrAccountFrozen
+	}
+
+	if account.Currency != toAccount.Currency {
+		return domain.ErrCurrencyMismatch
+	}
+
+	return nil
+}
+
+// CaptureHold transfers the amount of the active hold to its to account and
+// releases the rest of the hold. The whole hold is captured if amount is
+// empty. username must own either hold account. If limit is set, the amount
+// is checked against the outgoing transfers and the other active holds of the
+// hold account.
+//
+// The hold is locked before its accounts, the accounts are locked in
+// consistent id order, so the capture doesn't deadlock with transfers or the
+// hold expiration.
+func (r *RepoPGS) CaptureHold(
+	ctx context.Context,
+	username string,
+	id int64,
+	amount string,
+	limit *domain.TransferLimit,
+) (domain.CaptureHoldResult, error) {
+	l := zerolog.Ctx(ctx)
+
+	var result domain.CaptureHoldResult
+
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		holdRepo := holdrepo.NewRepoPGS(tx)
+		accountRepo := accountrepo.NewRepoPGS(tx)
+
+		hold, err := lockHold(ctx, holdRepo, accountRepo, username, id)
+		if err != nil {
+			l.Info().Err(err).Send()
+			return err
+		}
+
+		if !hold.ExpiresAt.After(time.Now()) {
+			l.Info().Err(domain.ErrHoldExpired).Send()
+			return domain.ErrHoldExpired
+		}
+
+		if amount == "" {
+			amount = hold.Amount
+		}
+
+		if err := validCapture(hold, amount); err != nil {
+			l.Info().Err(err).Send()
+			return err
+		}
+
+		if _, err := accountRepo.AddHeld(ctx, "-"+hold.Amount, hold.AccountID); err != nil {
+			return err
+		}
+
+		arg := domain.CreateTransferParams{
+			FromAccountID: hold.AccountID,
+			ToAccountID:   hold.ToAccountID,
+			Amount:        amount,
+			Kind:          domain.TransferKindTransfer,
+		}
+
+		result.Transfer, err = transferTx(ctx, tx, "", arg, func(from, to domain.Account) error {
+			if err := sufficientBalance(from, amount); err != nil {
+				return err
+			}
+
+			if limit == nil {
+				return nil
+			}
+
+			return checkLimit(ctx, NewTxRepoPGS(tx), from, amount, *limit, hold.ID)
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Hold, err = holdRepo.Close(ctx, domain.CloseHoldParams{
+			ID:             hold.ID,
+			Status:         domain.HoldStatusCaptured,
+			CapturedAmount: amount,
+			TransferID:     result.Transfer.Transfer.ID,
+		})
+
+		return err
+	})
+
+	return result, err
+}
+
+// validCapture checks that the capture amount doesn't exceed the hold amount.
+func validCapture(hold domain.Hold, amount string) error {
+	amountDecimal, err := decimal.NewFromString(amount)
+	if err != nil {
+		return domain.ErrInvalidAmount
+	}
+
+	held, err := decimal.NewFromString(hold.Amount)
+	if err != nil {
+		return errorspkg.ErrInternal
+	}
+
+	if amountDecimal.GreaterThan(held) {
+		return domain.ErrHoldAmountExceeded
+	}
+
+	return nil
+}
+
+// VoidHold releases the active hold. username must own either hold account.
+func (r *RepoPGS) VoidHold(ctx context.Context, username string, id int64) (domain.Hold, error) {
+	l := zerolog.Ctx(ctx)
+
+	var hold domain.Hold
+
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		holdRepo := holdrepo.NewRepoPGS(tx)
+		accountRepo := accountrepo.NewRepoPGS(tx)
+
+		locked, err := lockHold(ctx, holdRepo, accountRepo, username, id)
+		if err != nil {
+			l.Info().Err(err).Send()
+			return err
+		}
+
+		if _, err := accountRepo.AddHeld(ctx, "-"+locked.Amount, locked.AccountID); err != nil {
+			return err
+		}
+
+		hold, err = holdRepo.Close(ctx, domain.CloseHoldParams{ID: locked.ID, Status: domain.HoldStatusVoided})
+
+		return err
+	})
+
+	return hold, err
+}
+
+// lockHold locks the active hold and its accounts and checks that username
+// owns either account.
+func lockHold(
+	ctx context.Context,
+	holdRepo *holdrepo.RepoPGS,
+	accountRepo *accountrepo.RepoPGS,
+	username string,
+	id int64,
+) (domain.Hold, error) {
+	hold, err := holdRepo.GetForUpdate(ctx, id)
+	if err != nil {
+		return hold, err
+	}
+
+	from, to, err := lockAccounts(ctx, accountRepo, hold.AccountID, hold.ToAccountID)
+	if err != nil {
+		return hold, err
+	}
+
+	if from.Owner != username && to.Owner != username {
+		return hold, domain.ErrHoldOwnerMismatch
+	}
+
+	if hold.Status != domain.HoldStatusActive {
+		return hold, domain.ErrHoldNotActive
+	}
+
+	return hold, nil
+}
+
+// GetHold returns the hold with the given id.
+func (r *RepoPGS) GetHold(ctx context.Context, id int64) (domain.Hold, error) {
+	return holdrepo.NewRepoPGS(r.db).Get(ctx, id)
+}
+
+// ExpireHolds closes the expired active holds and releases their amount. It
+// returns the number of expired holds.
+func (r *RepoPGS) ExpireHolds(ctx context.Context) (int64, error) {
+	holdRepo := holdrepo.NewRepoPGS(r.db)
+
+	accountIDs, err := holdRepo.ListExpiredAccounts(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+
+	// Expire account by account, so each statement locks a single account.
+	for _, id := range accountIDs {
+		n, err := holdRepo.Expire(ctx, id)
+		if err != nil {
+			return total, err
+		}
+
+		total += n
+	}
+
+	return total, nil
+}
@@ -0,0 +1,202 @@
+//go:build integration
+
+package transferrepo_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/accountrepo"
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/integrationtest"
+	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
+	"github.com/go-petr/pet-bank/internal/transferrepo"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHolds(t *testing.T) {
+	db := integrationtest.SetupDB(t, dbDriver, dbSource)
+	transferRepo := transferrepo.NewRepoPGS(db)
+	accountRepo := accountrepo.NewRepoPGS(db)
+
+	payer := helpers.SeedUser(t, db)
+	payee := helpers.SeedUser(t, db)
+	account := helpers.SeedAccountWith1000USDBalance(t, db, payer.Username)
+	toAccount := helpers.SeedAccountWith1000USDBalance(t, db, payee.Username)
+
+	available := func(t *testing.T, id int32, want string) {
+		t.Helper()
+
+		got, err := accountRepo.Get(ctx, id)
+		if err != nil {
+			t.Fatalf("accountRepo.Get(ctx, %v) returned error: %v", id, err)
+		}
+
+		if got.AvailableBalance != want {
+			t.Errorf("account %v available balance = %v, want %v", id, got.AvailableBalance, want)
+		}
+	}
+
+	arg := domain.CreateHoldParams{
+		AccountID:   account.ID,
+		ToAccountID: toAccount.ID,
+		Amount:      "700",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+
+	if _, err := transferRepo.CreateHold(ctx, payee.Username, arg); err != domain.ErrInvalidOwner {
+		t.Errorf("transferRepo.CreateHold(ctx, %v, %+v) returned error: %v, want %v", payee.Username, arg, err, domain.ErrInvalidOwner)
+	}
+
+	hold, err := transferRepo.CreateHold(ctx, payer.Username, arg)
+	if err != nil {
+		t.Fatalf("transferRepo.CreateHold(ctx, %v, %+v) returned error: %v", payer.Username, arg, err)
+	}
+
+	available(t, account.ID, "300")
+
+	// Transfers are checked against the available balance.
+	transfer := domain.CreateTransferParams{FromAccountID: account.ID, ToAccountID: toAccount.ID, Amount: "301"}
+	if _, err := transferRepo.Transfer(ctx, payer.Username, transfer); err != domain.ErrInsufficientBalance {
+		t.Errorf("transferRepo.Transfer(ctx, %v, %+v) returned error: %v, want %v", payer.Username, transfer, err, domain.ErrInsufficientBalance)
+	}
+
+	if _, err := transferRepo.CaptureHold(ctx, payee.Username, hold.ID, "701", nil); err != domain.ErrHoldAmountExceeded {
+		t.Errorf("transferRepo.CaptureHold(ctx, %v, %v, 701) returned error: %v, want %v", payee.Username, hold.ID, err, domain.ErrHoldAmountExceeded)
+	}
+
+	result, err := transferRepo.CaptureHold(ctx, payee.Username, hold.ID, "500", nil)
+	if err != nil {
+		t.Fatalf("transferRepo.CaptureHold(ctx, %v, %v, 500) returned error: %v", payee.Username, hold.ID, err)
+	}
+
+	if result.Hold.Status != domain.HoldStatusCaptured || result.Hold.CapturedAmount != "500" ||
+		result.Hold.TransferID != result.Transfer.Transfer.ID {
+		t.Errorf("transferRepo.CaptureHold(ctx, %v, %v, 500) returned hold %+v", payee.Username, hold.ID, result.Hold)
+	}
+
+	// The rest of the hold is released.
+	available(t, account.ID, "500")
+	available(t, toAccount.ID, "1500")
+
+	if _, err := transferRepo.VoidHold(ctx, payer.Username, hold.ID); err != domain.ErrHoldNotActive {
+		t.Errorf("transferRepo.VoidHold(ctx, %v, %v) returned error: %v, want %v", payer.Username, hold.ID, err, domain.ErrHoldNotActive)
+	}
+
+	arg.Amount = "200"
+
+	voided, err := transferRepo.CreateHold(ctx, payer.Username, arg)
+	if err != nil {
+		t.Fatalf("transferRepo.CreateHold(ctx, %v, %+v) returned error: %v", payer.Username, arg, err)
+	}
+
+	if _, err := transferRepo.VoidHold(ctx, helpers.SeedUser(t, db).Username, voided.ID); err != domain.ErrHoldOwnerMismatch {
+		t.Errorf("transferRepo.VoidHold(ctx, stranger, %v) returned error: %v, want %v", voided.ID, err, domain.ErrHoldOwnerMismatch)
+	}
+
+	voided, err = transferRepo.VoidHold(ctx, payer.Username, voided.ID)
+	if err != nil {
+		t.Fatalf("transferRepo.VoidHold(ctx, %v, %v) returned error: %v", payer.Username, voided.ID, err)
+	}
+
+	if voided.Status != domain.HoldStatusVoided || voided.ClosedAt == nil {
+		t.Errorf("transferRepo.VoidHold(ctx, %v, %v) returned %+v", payer.Username, voided.ID, voided)
+	}
+
+	available(t, account.ID, "500")
+
+	arg.ExpiresAt = time.Now().Add(-time.Second)
+
+	expired, err := transferRepo.CreateHold(ctx, payer.Username, arg)
+	if err != nil {
+		t.Fatalf("transferRepo.CreateHold(ctx, %v, %+v) returned error: %v", payer.Username, arg, err)
+	}
+
+	if _, err := transferRepo.CaptureHold(ctx, payee.Username, expired.ID, "", nil); err != domain.ErrHoldExpired {
+		t.Errorf("transferRepo.CaptureHold(ctx, %v, %v) returned error: %v, want %v", payee.Username, expired.ID, err, domain.ErrHoldExpired)
+	}
+
+	n, err := transferRepo.ExpireHolds(ctx)
+	if err != nil {
+		t.Fatalf("transferRepo.ExpireHolds(ctx) returned error: %v", err)
+	}
+
+	if n != 1 {
+		t.Errorf("transferRepo.ExpireHolds(ctx) = %v, want 1", n)
+	}
+
+	available(t, account.ID, "500")
+
+	got, err := transferRepo.GetHold(ctx, expired.ID)
+	if err != nil {
+		t.Fatalf("transferRepo.GetHold(ctx, %v) returned error: %v", expired.ID, err)
+	}
+
+	if got.Status != domain.HoldStatusExpired {
+		t.Errorf("got.Status = %v, want %v", got.Status, domain.HoldStatusExpired)
+	}
+}
+
+func TestHoldLimit(t *testing.T) {
+	db := integrationtest.SetupDB(t, dbDriver, dbSource)
+	transferRepo := transferrepo.NewRepoPGS(db)
+
+	payer := helpers.SeedUser(t, db)
+	payee := helpers.SeedUser(t, db)
+	account := helpers.SeedAccountWith1000USDBalance(t, db, payer.Username)
+	toAccount := helpers.SeedAccountWith1000USDBalance(t, db, payee.Username)
+
+	limit := domain.TransferLimit{Currency: account.Currency, Daily: "500"}
+	arg := domain.CreateHoldParams{
+		AccountID:   account.ID,
+		ToAccountID: toAccount.ID,
+		Amount:      "300",
+		ExpiresAt:   time.Now().Add(time.Hour),
+		Limit:       &limit,
+	}
+
+	wantLimitErr := func(t *testing.T, call string, err error, remaining string) {
+		t.Helper()
+
+		want := &domain.TransferLimitError{Limit: domain.LimitDaily, Currency: account.Currency, Remaining: remaining}
+
+		var limitErr *domain.TransferLimitError
+		if !errors.As(err, &limitErr) {
+			t.Fatalf("%v returned error: %v, want %v", call, err, want)
+		}
+
+		if diff := cmp.Diff(want, limitErr); diff != "" {
+			t.Errorf("%v returned unexpected difference (-want +got):\n%s", call, diff)
+		}
+	}
+
+	hold, err := transferRepo.CreateHold(ctx, payer.Username, arg)
+	if err != nil {
+		t.Fatalf("transferRepo.CreateHold(ctx, %v, %+v) returned error: %v", payer.Username, arg, err)
+	}
+
+	// The active hold counts towards the limit of the holds and transfers.
+	_, err = transferRepo.CreateHold(ctx, payer.Username, arg)
+	wantLimitErr(t, "transferRepo.CreateHold", err, "200")
+
+	transfer := domain.CreateTransferParams{FromAccountID: account.ID, ToAccountID: toAccount.ID, Amount: "201", Limit: &limit}
+	_, err = transferRepo.Transfer(ctx, payer.Username, transfer)
+	wantLimitErr(t, "transferRepo.Transfer", err, "200")
+
+	// The captured hold is not counted twice, but the capture is checked
+	// against the limit lowered since the hold was placed.
+	lowered := domain.TransferLimit{Currency: account.Currency, Daily: "250"}
+
+	_, err = transferRepo.CaptureHold(ctx, payee.Username, hold.ID, "", &lowered)
+	wantLimitErr(t, "transferRepo.CaptureHold", err, "250")
+
+	if _, err := transferRepo.CaptureHold(ctx, payee.Username, hold.ID, "250", &lowered); err != nil {
+		t.Fatalf("transferRepo.CaptureHold(ctx, %v, %v, 250) returned error: %v", payee.Username, hold.ID, err)
+	}
+
+	_, err = transferRepo.CaptureHold(ctx, payee.Username, hold.ID, "", &lowered)
+	if err != domain.ErrHoldNotActive {
+		t.Errorf("transferRepo.CaptureHold(ctx, %v, %v) returned error: %v, want %v", payee.Username, hold.ID, err, domain.ErrHoldNotActive)
+	}
+}
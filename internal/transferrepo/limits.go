@@ -0,0 +1,115 @@
+package transferrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+)
+
+const outgoingTotalsQuery = `
+SELECT
+	COALESCE(SUM(amount) FILTER (WHERE created_at >= $2), 0),
+	COALESCE(SUM(amount), 0)
+FROM (
+	SELECT amount, created_at
+	FROM transfers
+	WHERE from_account_id = $1 AND kind = 'transfer' AND created_at >= $3
+	UNION ALL
+	SELECT amount, created_at
+	FROM transfer_reviews
+	WHERE from_account_id = $1 AND status = 'pending_review' AND created_at >= $3
+	UNION ALL
+	SELECT amount, created_at
+	FROM holds
+	WHERE account_id = $1 AND status = 'active' AND id <> $4 AND created_at >= $3
+) outgoing
+`
+
+// OutgoingTotals returns the sums of the transfers from the account since
+// dayStart and since monthStart, which must not be after dayStart. Transfers
+// pending review and active holds are counted, captured holds are counted by
+// their transfers. Deposits, withdrawals and reversals are not counted.
+func (r *RepoPGS) OutgoingTotals(ctx context.Context, accountID int32, dayStart, monthStart time.Time) (string, string, error) {
+	return r.outgoingTotals(ctx, accountID, 0, dayStart, monthStart)
+}
+
+// outgoingTotals returns the totals of OutgoingTotals without the active hold
+// holdID, if it is not zero.
+func (r *RepoPGS) outgoingTotals(ctx context.Context, accountID int32, holdID int64, dayStart, monthStart time.Time) (string, string, error) {
+	l := zerolog.Ctx(ctx)
+
+	var daily, monthly string
+
+	err := r.db.QueryRowContext(ctx, outgoingTotalsQuery, accountID, dayStart, monthStart, holdID).Scan(&daily, &monthly)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return "", "", errorspkg.ErrInternal
+	}
+
+	return daily, monthly, nil
+}
+
+// checkLimit checks the amount against the limit of the locked from account.
+// holdID is the active hold being captured, which is not counted, or zero.
+//
+// The from account row lock serializes the outgoing transfers of the account,
+// and so of its owner in its currency, so the totals can't change until the
+// transfer is committed.
+func checkLimit(ctx context.Context, r *RepoPGS, from domain.Account, amount string, limit domain.TransferLimit, holdID int64) error {
+	now := time.Now().UTC()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	daily, monthly, err := r.outgoingTotals(ctx, from.ID, holdID, dayStart, monthStart)
+	if err != nil {
+		return err
+	}
+
+	amountDecimal, err := decimal.NewFromString(amount)
+	if err != nil {
+		return domain.ErrInvalidAmount
+	}
+
+	checks := []struct {
+		limit, value, spent string
+	}{
+		{domain.LimitPerTransaction, limit.PerTransaction, "0"},
+		{domain.LimitDaily, limit.Daily, daily},
+		{domain.LimitMonthly, limit.Monthly, monthly},
+	}
+
+	for _, c := range checks {
+		if c.value == "" {
+			continue
+		}
+
+		value, err := decimal.NewFromString(c.value)
+		if err != nil {
+			return errorspkg.ErrInternal
+		}
+
+		spent, err := decimal.NewFromString(c.spent)
+		if err != nil {
+			return errorspkg.ErrInternal
+		}
+
+		remaining := value.Sub(spent)
+		if remaining.LessThan(decimal.Zero) {
+			remaining = decimal.Zero
+		}
+
+		if amountDecimal.GreaterThan(remaining) {
+			return &domain.TransferLimitError{
+				Limit:     c.limit,
+				Currency:  from.Currency,
+				Remaining: remaining.String(),
+			}
+		}
+	}
+
+	return nil
+}
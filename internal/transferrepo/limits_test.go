@@ -0,0 +1,74 @@
+//go:build integration
+
+package transferrepo_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/integrationtest"
+	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
+	"github.com/go-petr/pet-bank/internal/transferrepo"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTransferLimit(t *testing.T) {
+	db := integrationtest.SetupDB(t, dbDriver, dbSource)
+	transferRepo := transferrepo.NewRepoPGS(db)
+
+	user1 := helpers.SeedUser(t, db)
+	account1 := helpers.SeedAccountWith1000USDBalance(t, db, user1.Username)
+	user2 := helpers.SeedUser(t, db)
+	account2 := helpers.SeedAccountWith1000USDBalance(t, db, user2.Username)
+
+	limit := domain.TransferLimit{Currency: account1.Currency, PerTransaction: "300", Daily: "500", Monthly: "600"}
+	arg := domain.CreateTransferParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Limit: &limit}
+
+	testCases := []struct {
+		amount  string
+		wantErr *domain.TransferLimitError
+	}{
+		{amount: "301", wantErr: &domain.TransferLimitError{Limit: domain.LimitPerTransaction, Currency: account1.Currency, Remaining: "300"}},
+		{amount: "300"},
+		{amount: "200"},
+		{amount: "1", wantErr: &domain.TransferLimitError{Limit: domain.LimitDaily, Currency: account1.Currency, Remaining: "0"}},
+	}
+
+	for _, tc := range testCases {
+		arg.Amount = tc.amount
+
+		_, err := transferRepo.Transfer(ctx, user1.Username, arg)
+		if tc.wantErr == nil {
+			if err != nil {
+				t.Fatalf("transferRepo.Transfer(ctx, %v, %+v) returned error: %v", user1.Username, arg, err)
+			}
+
+			continue
+		}
+
+		var limitErr *domain.TransferLimitError
+		if !errors.As(err, &limitErr) {
+			t.Fatalf("transferRepo.Transfer(ctx, %v, %+v) returned error: %v, want %v", user1.Username, arg, err, tc.wantErr)
+		}
+
+		if diff := cmp.Diff(tc.wantErr, limitErr); diff != "" {
+			t.Errorf("transferRepo.Transfer(ctx, %v, %+v) returned unexpected difference (-want +got):\n%s", user1.Username, arg, diff)
+		}
+	}
+
+	// Transfers to the account are not counted.
+	now := time.Now().UTC()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	daily, monthly, err := transferRepo.OutgoingTotals(ctx, account2.ID, dayStart, monthStart)
+	if err != nil {
+		t.Fatalf("transferRepo.OutgoingTotals(ctx, %v, ...) returned error: %v", account2.ID, err)
+	}
+
+	if daily != "0" || monthly != "0" {
+		t.Errorf("transferRepo.OutgoingTotals(ctx, %v, ...) = %v, %v, want 0, 0", account2.ID, daily, monthly)
+	}
+}
@@ -4,14 +4,22 @@ package transferrepo
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
 
 	"github.com/go-petr/pet-bank/internal/accountrepo"
 	"github.com/go-petr/pet-bank/internal/domain"
 	"github.com/go-petr/pet-bank/internal/entryrepo"
+	"github.com/go-petr/pet-bank/internal/fxrepo"
+	"github.com/go-petr/pet-bank/internal/idempotencyrepo"
 	"github.com/go-petr/pet-bank/pkg/dbpkg"
 	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
+	"github.com/google/uuid"
 	"github.com/lib/pq"
 	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
 )
 
 // RepoPGS facilitates transfer repository layer logic.
@@ -35,29 +43,77 @@ func NewRepoPGS(db *sql.DB) *RepoPGS {
 	}
 }
 
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTransfer(row scanner) (domain.Transfer, error) {
+	var (
+		t                  domain.Transfer
+		fxRate             sql.NullString
+		reversedTransferID sql.NullInt64
+	)
+
+	err := row.Scan(
+		&t.ID,
+		&t.FromAccountID,
+		&t.ToAccountID,
+		&t.Amount,
+		&fxRate,
+		&t.Kind,
+		&t.Status,
+		&t.RefundedAmount,
+		&reversedTransferID,
+		&t.CreatedAt,
+		&t.Description,
+		&t.Reference,
+		(*dbpkg.JSONMap)(&t.Metadata),
+	)
+
+	t.FXRate = fxRate.String
+	t.ReversedTransferID = reversedTransferID.Int64
+
+	return t, err
+}
+
 const createQuery = `
 INSERT INTO
-    transfers (from_account_id, to_account_id, amount)
+    transfers (
+        from_account_id, to_account_id, amount, fx_rate, kind, reversed_transfer_id,
+        description, reference, metadata
+    )
 VALUES
-    ($1, $2, $3)
-RETURNING id, from_account_id, to_account_id, amount, created_at
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, from_account_id, to_account_id, amount, fx_rate, kind, status, refunded_amount,
+    reversed_transfer_id, created_at, description, reference, metadata
 `
 
 // Create creates the transfer and then returns it.
 func (r *RepoPGS) Create(ctx context.Context, arg domain.CreateTransferParams) (domain.Transfer, error) {
 	l := zerolog.Ctx(ctx)
 
-	row := r.db.QueryRowContext(ctx, createQuery, arg.FromAccountID, arg.ToAccountID, arg.Amount)
+	fxRate := sql.NullString{String: arg.FXRate, Valid: arg.FXRate != ""}
 
-	var t domain.Transfer
-	err := row.Scan(
-		&t.ID,
-		&t.FromAccountID,
-		&t.ToAccountID,
-		&t.Amount,
-		&t.CreatedAt,
+	kind := arg.Kind
+	if kind == "" {
+		kind = domain.TransferKindTransfer
+	}
+
+	reversedTransferID := sql.NullInt64{Int64: arg.ReversedTransferID, Valid: arg.ReversedTransferID != 0}
+
+	row := r.db.QueryRowContext(ctx, createQuery,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		fxRate,
+		kind,
+		reversedTransferID,
+		arg.Description,
+		arg.Reference,
+		dbpkg.JSONMap(arg.Metadata),
 	)
 
+	t, err := scanTransfer(row)
 	if err != nil {
 		l.Error().Err(err).Msgf("Create(ctx context.Context, %+v)", arg)
 
@@ -69,6 +125,10 @@ func (r *RepoPGS) Create(ctx context.Context, arg domain.CreateTransferParams) (
 				return t, domain.ErrAccountNotFound
 			case "transfers_amount_check":
 				return t, domain.ErrInvalidAmount
+			case "transfers_fx_rate_check":
+				return t, domain.ErrInvalidAmount
+			case "transfers_reversed_transfer_id_fkey":
+				return t, domain.ErrTransferNotFound
 			}
 		}
 
@@ -80,7 +140,8 @@ func (r *RepoPGS) Create(ctx context.Context, arg domain.CreateTransferParams) (
 
 const getQuery = `
 SELECT 
-	id, from_account_id, to_account_id, amount, created_at 
+	id, from_account_id, to_account_id, amount, fx_rate, kind, status, refunded_amount,
+	reversed_transfer_id, created_at, description, reference, metadata
 FROM transfers
 WHERE id = $1
 `
@@ -91,16 +152,7 @@ func (r *RepoPGS) Get(ctx context.Context, id int64) (domain.Transfer, error) {
 
 	row := r.db.QueryRowContext(ctx, getQuery, id)
 
-	var t domain.Transfer
-
-	err := row.Scan(
-		&t.ID,
-		&t.FromAccountID,
-		&t.ToAccountID,
-		&t.Amount,
-		&t.CreatedAt,
-	)
-
+	t, err := scanTransfer(row)
 	if err != nil {
 		l.Error().Err(err).Send()
 
@@ -116,23 +168,50 @@ func (r *RepoPGS) Get(ctx context.Context, id int64) (domain.Transfer, error) {
 
 const listTransfers = `
 SELECT 
-	id, from_account_id, to_account_id, amount, created_at 
-FROM transfers
+	t.id, t.from_account_id, t.to_account_id, t.amount, t.fx_rate, t.kind, t.status,
+	t.refunded_amount, t.reversed_transfer_id, t.created_at, t.description, t.reference, t.metadata
+FROM transfers t
+JOIN accounts fa ON fa.id = t.from_account_id
+JOIN accounts ta ON ta.id = t.to_account_id
 WHERE 
-    from_account_id = $1 OR to_account_id = $2
-ORDER BY id
-LIMIT $3 OFFSET $4
+    (
+        ($2 <> 'incoming' AND fa.owner = $1 AND ($3 = 0 OR t.from_account_id = $3))
+        OR
+        ($2 <> 'outgoing' AND ta.owner = $1 AND ($3 = 0 OR t.to_account_id = $3))
+    )
+    AND ($4::numeric IS NULL OR t.amount >= $4)
+    AND ($5::numeric IS NULL OR t.amount <= $5)
+    AND ($6::timestamptz IS NULL OR t.created_at >= $6)
+    AND ($7::timestamptz IS NULL OR t.created_at < $7)
+    AND ($12 = '' OR t.description ILIKE $12 OR t.reference ILIKE $12)
+    AND ($13 = '' OR t.reference = $13)
+    AND ($10::bigint = 0 OR t.id > $10)
+    AND ($11::bigint = 0 OR t.id < $11)
+ORDER BY CASE WHEN $11 = 0 THEN t.id END, t.id DESC
+LIMIT $8 OFFSET $9
 `
 
-// List returns the transfers betweem the specified accounts.
+// List returns the transfers from or to the user's accounts matching the given filters
+// ordered by id.
+//
+// If arg.BeforeID is set, the transfers right before it are returned.
 func (r *RepoPGS) List(ctx context.Context, arg domain.ListTransfersParams) ([]domain.Transfer, error) {
 	l := zerolog.Ctx(ctx)
 
 	rows, err := r.db.QueryContext(ctx, listTransfers,
-		arg.FromAccountID,
-		arg.ToAccountID,
+		arg.Username,
+		arg.Direction,
+		arg.AccountID,
+		sql.NullString{String: arg.MinAmount, Valid: arg.MinAmount != ""},
+		sql.NullString{String: arg.MaxAmount, Valid: arg.MaxAmount != ""},
+		sql.NullTime{Time: arg.StartTime, Valid: !arg.StartTime.IsZero()},
+		sql.NullTime{Time: arg.EndTime, Valid: !arg.EndTime.IsZero()},
 		arg.Limit,
 		arg.Offset,
+		arg.AfterID,
+		arg.BeforeID,
+		containsPattern(arg.Search),
+		arg.Reference,
 	)
 	if err != nil {
 		l.Error().Err(err).Send()
@@ -143,14 +222,8 @@ func (r *RepoPGS) List(ctx context.Context, arg domain.ListTransfersParams) ([]d
 	items := []domain.Transfer{}
 
 	for rows.Next() {
-		var t domain.Transfer
-		if err := rows.Scan(
-			&t.ID,
-			&t.FromAccountID,
-			&t.ToAccountID,
-			&t.Amount,
-			&t.CreatedAt,
-		); err != nil {
+		t, err := scanTransfer(rows)
+		if err != nil {
 			return nil, err
 		}
 
@@ -167,22 +240,147 @@ func (r *RepoPGS) List(ctx context.Context, arg domain.ListTransfersParams) ([]d
 		return nil, errorspkg.ErrInternal
 	}
 
+	if arg.BeforeID != 0 {
+		pagepkg.Reverse(items)
+	}
+
 	return items, nil
 }
 
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern returns the LIKE pattern matching the strings containing s,
+// or an empty string if s is empty.
+func containsPattern(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	return "%" + likeEscaper.Replace(s) + "%"
+}
+
 // Transfer performs a money transfer between two accounts.
 //
-// It creates a transfer record, add account entries, and update accounts' balance
-// within a single dbpkg transaction.
-func (r *RepoPGS) Transfer(ctx context.Context, arg domain.CreateTransferParams) (domain.TransferTxResult, error) {
+// It locks both accounts, checks that the from account is owned by fromUsername,
+// has sufficient balance and the same currency as the to account and that
+// neither account is frozen or a system account, then creates a transfer
+// record, add account entries, and update accounts' balance within a single
+// dbpkg transaction. If arg.Idempotency is set, the result is stored under the
+// idempotency key within the same transaction.
+//
+// If arg.FXQuoteID is set, the accounts may have different currencies: the quote
+// is locked and consumed, the from account is debited with the amount and the to
+// account is credited with the amount converted at the quoted rate.
+//
+// If arg.Limit is set, the amount and the from account outgoing transfers of
+// the day and month are checked against it once the accounts are locked.
+func (r *RepoPGS) Transfer(ctx context.Context, fromUsername string, arg domain.CreateTransferParams) (domain.TransferTxResult, error) {
+	arg.Kind = domain.TransferKindTransfer
+
+	return r.transfer(ctx, fromUsername, arg, func(from, to domain.Account) error {
+		if from.IsSystem || to.IsSystem {
+			return domain.ErrSystemAccount
+		}
+
+		return validTransfer(fromUsername, from, arg.Amount)
+	})
+}
+
+// Deposit credits the account with the amount debited from the settlement
+// account of its currency, so the ledger stays balanced. The settlement
+// account is created on first use.
+func (r *RepoPGS) Deposit(ctx context.Context, arg domain.CreateCashParams) (domain.TransferTxResult, error) {
+	settlement, err := r.settlement(ctx, arg.AccountID)
+	if err != nil {
+		return domain.TransferTxResult{}, err
+	}
+
+	transferArg := domain.CreateTransferParams{
+		FromAccountID: settlement.ID,
+		ToAccountID:   arg.AccountID,
+		Amount:        arg.Amount,
+		Idempotency:   arg.Idempotency,
+		Kind:          domain.TransferKindDeposit,
+		Memo:          arg.Memo,
+	}
+
+	return r.transfer(ctx, "", transferArg, func(from, to domain.Account) error {
+		if to.IsSystem {
+			return domain.ErrSystemAccount
+		}
+
+		return nil
+	})
+}
+
+// Withdraw debits the account with the amount credited to the settlement
+// account of its currency. The account must have sufficient balance.
+func (r *RepoPGS) Withdraw(ctx context.Context, arg domain.CreateCashParams) (domain.TransferTxResult, error) {
+	settlement, err := r.settlement(ctx, arg.AccountID)
+	if err != nil {
+		return domain.TransferTxResult{}, err
+	}
+
+	transferArg := domain.CreateTransferParams{
+		FromAccountID: arg.AccountID,
+		ToAccountID:   settlement.ID,
+		Amount:        arg.Amount,
+		Idempotency:   arg.Idempotency,
+		Kind:          domain.TransferKindWithdrawal,
+		Memo:          arg.Memo,
+	}
+
+	return r.transfer(ctx, "", transferArg, func(from, to domain.Account) error {
+		if from.IsSystem {
+			return domain.ErrSystemAccount
+		}
+
+		return sufficientBalance(from, arg.Amount)
+	})
+}
+
+// settlement returns the settlement account of the account currency.
+func (r *RepoPGS) settlement(ctx context.Context, accountID int32) (domain.Account, error) {
 	l := zerolog.Ctx(ctx)
 
+	accountRepo := accountrepo.NewRepoPGS(r.db)
+
+	account, err := accountRepo.Get(ctx, accountID)
+	if err != nil {
+		l.Info().Err(err).Send()
+		return account, err
+	}
+
+	return accountRepo.GetSettlement(ctx, account.Currency)
+}
+
+// transfer runs the transfer in its own dbpkg transaction.
+func (r *RepoPGS) transfer(
+	ctx context.Context,
+	fromUsername string,
+	arg domain.CreateTransferParams,
+	validate func(from, to domain.Account) error,
+) (domain.TransferTxResult, error) {
 	var result domain.TransferTxResult
 
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = transferTx(ctx, tx, fromUsername, arg, validate)
+
+		return err
+	})
+
+	return result, err
+}
+
+// inTx runs fn within a dbpkg transaction which is committed if fn succeeds.
+func (r *RepoPGS) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	l := zerolog.Ctx(ctx)
+
 	tx, err := r.conn.BeginTx(ctx, nil)
 	if err != nil {
 		l.Error().Err(err).Send()
-		return result, err
+		return err
 	}
 
 	defer func() {
@@ -193,22 +391,104 @@ func (r *RepoPGS) Transfer(ctx context.Context, arg domain.CreateTransferParams)
 		}
 	}()
 
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		l.Error().Err(err).Send()
+		return err
+	}
+
+	return nil
+}
+
+// transferTx performs the transfer within tx. validate checks the locked
+// accounts before the frozen, currency and fx checks.
+func transferTx(
+	ctx context.Context,
+	tx *sql.Tx,
+	fromUsername string,
+	arg domain.CreateTransferParams,
+	validate func(from, to domain.Account) error,
+) (domain.TransferTxResult, error) {
+	l := zerolog.Ctx(ctx)
+
+	var result domain.TransferTxResult
+
+	transferRepo := NewTxRepoPGS(tx)
 	entryRepo := entryrepo.NewRepoPGS(tx)
 	accountRepo := accountrepo.NewRepoPGS(tx)
 
-	result.Transfer, err = r.Create(ctx, arg)
+	lockedFromAccount, lockedToAccount, err := lockAccounts(ctx, accountRepo, arg.FromAccountID, arg.ToAccountID)
+	if err != nil {
+		l.Info().Err(err).Send()
+		return result, err
+	}
+
+	if err := validate(lockedFromAccount, lockedToAccount); err != nil {
+		l.Info().Err(err).Send()
+		return result, err
+	}
+
+	if arg.Limit != nil {
+		if err := checkLimit(ctx, transferRepo, lockedFromAccount, arg.Amount, *arg.Limit, 0); err != nil {
+			l.Info().Err(err).Send()
+			return result, err
+		}
+	}
+
+	if lockedFromAccount.IsFrozen() || lockedToAccount.IsFrozen() {
+		l.Info().Err(domain.ErrAccountFrozen).Send()
+		return result, domain.ErrAccountFrozen
+	}
+
+	creditAmount := arg.Amount
+
+	if arg.FXQuoteID == nil {
+		if lockedFromAccount.Currency != lockedToAccount.Currency {
+			l.Info().Err(domain.ErrCurrencyMismatch).Send()
+			return result, domain.ErrCurrencyMismatch
+		}
+	} else {
+		quote, err := useQuote(ctx, fxrepo.NewRepoPGS(tx), *arg.FXQuoteID, fromUsername, lockedFromAccount, lockedToAccount)
+		if err != nil {
+			l.Info().Err(err).Send()
+			return result, err
+		}
+
+		creditAmount, err = convert(arg.Amount, quote.Rate)
+		if err != nil {
+			l.Error().Err(err).Send()
+			return result, err
+		}
+
+		arg.FXRate = quote.Rate
+	}
+
+	result.Transfer, err = transferRepo.Create(ctx, arg)
 	if err != nil {
 		l.Error().Err(err).Send()
 		return result, err
 	}
 
-	result.FromEntry, err = entryRepo.Create(ctx, "-"+arg.Amount, arg.FromAccountID)
+	result.FromEntry, err = entryRepo.Create(ctx, domain.CreateEntryParams{
+		AccountID:  arg.FromAccountID,
+		Amount:     "-" + arg.Amount,
+		TransferID: result.Transfer.ID,
+		Memo:       arg.Memo,
+	})
 	if err != nil {
 		l.Error().Err(err).Send()
 		return result, err
 	}
 
-	result.ToEntry, err = entryRepo.Create(ctx, arg.Amount, arg.ToAccountID)
+	result.ToEntry, err = entryRepo.Create(ctx, domain.CreateEntryParams{
+		AccountID:  arg.ToAccountID,
+		Amount:     creditAmount,
+		TransferID: result.Transfer.ID,
+		Memo:       arg.Memo,
+	})
 	if err != nil {
 		l.Error().Err(err).Send()
 		return result, err
@@ -221,14 +501,14 @@ func (r *RepoPGS) Transfer(ctx context.Context, arg domain.CreateTransferParams)
 			account1ID: arg.FromAccountID,
 			amount1:    "-" + arg.Amount,
 			account2ID: arg.ToAccountID,
-			amount2:    arg.Amount,
+			amount2:    creditAmount,
 		}
 
 		fromAccount, toAccount, err = addBalances(ctx, accountRepo, argAddBalance)
 	} else {
 		argAddBalance := addBalanceParams{
 			account1ID: arg.ToAccountID,
-			amount1:    arg.Amount,
+			amount1:    creditAmount,
 			account2ID: arg.FromAccountID,
 			amount2:    "-" + arg.Amount,
 		}
@@ -243,14 +523,122 @@ func (r *RepoPGS) Transfer(ctx context.Context, arg domain.CreateTransferParams)
 
 	result.FromAccount, result.ToAccount = fromAccount, toAccount
 
-	if err := tx.Commit(); err != nil {
-		l.Error().Err(err).Send()
-		return result, err
+	if arg.Idempotency != nil {
+		if err := storeIdempotencyKey(ctx, idempotencyrepo.NewRepoPGS(tx), *arg.Idempotency, result); err != nil {
+			l.Error().Err(err).Send()
+			return result, err
+		}
 	}
 
 	return result, nil
 }
 
+// lockAccounts locks both transfer accounts in consistent id order to avoid deadlocks.
+func lockAccounts(ctx context.Context, r *accountrepo.RepoPGS, fromAccountID, toAccountID int32) (domain.Account, domain.Account, error) {
+	firstID, secondID := fromAccountID, toAccountID
+	if toAccountID < fromAccountID {
+		firstID, secondID = toAccountID, fromAccountID
+	}
+
+	first, err := r.GetForUpdate(ctx, firstID)
+	if err != nil {
+		return domain.Account{}, domain.Account{}, err
+	}
+
+	second, err := r.GetForUpdate(ctx, secondID)
+	if err != nil {
+		return domain.Account{}, domain.Account{}, err
+	}
+
+	if firstID == fromAccountID {
+		return first, second, nil
+	}
+
+	return second, first, nil
+}
+
+// validTransfer checks the locked from account against the transfer request.
+func validTransfer(fromUsername string, fromAccount domain.Account, amount string) error {
+	if fromAccount.Owner != fromUsername {
+		return domain.ErrInvalidOwner
+	}
+
+	return sufficientBalance(fromAccount, amount)
+}
+
+// sufficientBalance checks that the locked account available balance covers
+// the amount.
+func sufficientBalance(account domain.Account, amount string) error {
+	amountDecimal, err := decimal.NewFromString(amount)
+	if err != nil {
+		return domain.ErrInvalidAmount
+	}
+
+	balance, err := decimal.NewFromString(account.AvailableBalance)
+	if err != nil {
+		return errorspkg.ErrInternal
+	}
+
+	if balance.LessThan(amountDecimal) {
+		return domain.ErrInsufficientBalance
+	}
+
+	return nil
+}
+
+// useQuote locks the fx quote, checks it against the transfer accounts and marks it as used.
+func useQuote(
+	ctx context.Context,
+	r *fxrepo.RepoPGS,
+	id uuid.UUID,
+	fromUsername string,
+	fromAccount, toAccount domain.Account,
+) (domain.FXQuote, error) {
+	quote, err := r.GetForUpdate(ctx, id)
+	if err != nil {
+		return quote, err
+	}
+
+	// Do not disclose quotes of other users.
+	if quote.Username != fromUsername {
+		return quote, domain.ErrFXQuoteNotFound
+	}
+
+	if quote.UsedAt != nil {
+		return quote, domain.ErrFXQuoteUsed
+	}
+
+	if time.Now().After(quote.ExpiresAt) {
+		return quote, domain.ErrFXQuoteExpired
+	}
+
+	if quote.FromCurrency != fromAccount.Currency || quote.ToCurrency != toAccount.Currency {
+		return quote, domain.ErrFXQuoteCurrencyMismatch
+	}
+
+	return quote, r.MarkUsed(ctx, id)
+}
+
+// convert returns the amount exchanged at the rate rounded to cents.
+func convert(amount, rate string) (string, error) {
+	amountDecimal, err := decimal.NewFromString(amount)
+	if err != nil {
+		return "", domain.ErrInvalidAmount
+	}
+
+	rateDecimal, err := decimal.NewFromString(rate)
+	if err != nil {
+		return "", errorspkg.ErrInternal
+	}
+
+	converted := amountDecimal.Mul(rateDecimal).Round(2)
+	if converted.LessThanOrEqual(decimal.Zero) {
+		return "", domain.ErrInvalidAmount
+	}
+
+	return converted.String(), nil
+}
+
 type addBalanceParams struct {
 	account1ID int32
 	amount1    string
@@ -271,3 +659,32 @@ func addBalances(ctx context.Context, r *accountrepo.RepoPGS, arg addBalancePara
 
 	return account1, account2, nil
 }
+
+func storeIdempotencyKey(
+	ctx context.Context,
+	r *idempotencyrepo.RepoPGS,
+	arg domain.CreateIdempotencyKeyParams,
+	result domain.TransferTxResult,
+) error {
+	body, err := json.Marshal(result)
+	if err != nil {
+		return errorspkg.ErrInternal
+	}
+
+	arg.ResponseBody = body
+
+	_, err = r.Create(ctx, arg)
+
+	return err
+}
+
+// GetIdempotencyKey returns the transfer result stored under the given user's idempotency key.
+func (r *RepoPGS) GetIdempotencyKey(ctx context.Context, username, key string) (domain.IdempotencyKey, error) {
+	return idempotencyrepo.NewRepoPGS(r.db).Get(ctx, username, key)
+}
+
+// CreateIdempotencyKey stores the result of the request which booked no
+// transfer under the idempotency key.
+func (r *RepoPGS) CreateIdempotencyKey(ctx context.Context, arg domain.CreateIdempotencyKeyParams) (domain.IdempotencyKey, error) {
+	return idempotencyrepo.NewRepoPGS(r.db).Create(ctx, arg)
+}
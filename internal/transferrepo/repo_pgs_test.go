@@ -5,6 +5,7 @@ package transferrepo_test
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"log"
 	"os"
 	"testing"
@@ -17,10 +18,12 @@ import (
 	"github.com/go-petr/pet-bank/internal/middleware"
 	"github.com/go-petr/pet-bank/internal/transferrepo"
 	"github.com/go-petr/pet-bank/pkg/configpkg"
+	"github.com/go-petr/pet-bank/pkg/currencypkg"
 	"github.com/go-petr/pet-bank/pkg/errorspkg"
 	"github.com/go-petr/pet-bank/pkg/randompkg"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
 
@@ -60,10 +63,13 @@ func TestCreate(t *testing.T) {
 				user2 := helpers.SeedUser(t, tx)
 				account2 := helpers.SeedAccountWith1000USDBalance(t, tx, user2.Username)
 				transfer := domain.Transfer{
-					FromAccountID: account1.ID,
-					ToAccountID:   account2.ID,
-					Amount:        randompkg.MoneyAmountBetween(100, 1000),
-					CreatedAt:     time.Now().UTC().Truncate(time.Second),
+					FromAccountID:  account1.ID,
+					ToAccountID:    account2.ID,
+					Amount:         randompkg.MoneyAmountBetween(100, 1000),
+					Kind:           domain.TransferKindTransfer,
+					Status:         domain.TransferStatusCompleted,
+					RefundedAmount: "0",
+					CreatedAt:      time.Now().UTC().Truncate(time.Second),
 				}
 
 				return transfer
@@ -314,14 +320,12 @@ func TestListTransfers(t *testing.T) {
 			account2 := helpers.SeedAccountWith1000USDBalance(t, tx, user2.Username)
 
 			want := tc.wantTransfers(tx, account1.ID, account2.ID)
-			firstTransfer := want[0]
 			transferRepo := transferrepo.NewTxRepoPGS(tx)
 
 			arg := domain.ListTransfersParams{
-				FromAccountID: firstTransfer.FromAccountID,
-				ToAccountID:   firstTransfer.ToAccountID,
-				Limit:         tc.limit,
-				Offset:        tc.offset,
+				Username: user1.Username,
+				Limit:    tc.limit,
+				Offset:   tc.offset,
 			}
 
 			// Run test
@@ -343,6 +347,98 @@ func TestListTransfers(t *testing.T) {
 	}
 }
 
+func TestListTransfersFilters(t *testing.T) {
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+
+	user1 := helpers.SeedUser(t, tx)
+	account1 := helpers.SeedAccountWith1000USDBalance(t, tx, user1.Username)
+	user2 := helpers.SeedUser(t, tx)
+	account2 := helpers.SeedAccountWith1000USDBalance(t, tx, user2.Username)
+	user3 := helpers.SeedUser(t, tx)
+	account3 := helpers.SeedAccountWith1000USDBalance(t, tx, user3.Username)
+
+	outgoing := SeedTransfer(t, tx, account1.ID, account2.ID, "10")
+	incoming := SeedTransfer(t, tx, account2.ID, account1.ID, "100")
+	another := SeedTransfer(t, tx, account2.ID, account3.ID, "50")
+
+	testCases := []struct {
+		name string
+		arg  domain.ListTransfersParams
+		want []domain.Transfer
+	}{
+		{
+			name: "AllUserTransfers",
+			arg:  domain.ListTransfersParams{Username: user1.Username},
+			want: []domain.Transfer{outgoing, incoming},
+		},
+		{
+			name: "Outgoing",
+			arg:  domain.ListTransfersParams{Username: user1.Username, Direction: domain.TransferDirectionOutgoing},
+			want: []domain.Transfer{outgoing},
+		},
+		{
+			name: "Incoming",
+			arg:  domain.ListTransfersParams{Username: user1.Username, Direction: domain.TransferDirectionIncoming},
+			want: []domain.Transfer{incoming},
+		},
+		{
+			name: "AccountID",
+			arg:  domain.ListTransfersParams{Username: user2.Username, AccountID: account2.ID},
+			want: []domain.Transfer{outgoing, incoming, another},
+		},
+		{
+			name: "AfterID",
+			arg:  domain.ListTransfersParams{Username: user2.Username, AccountID: account2.ID, AfterID: outgoing.ID},
+			want: []domain.Transfer{incoming, another},
+		},
+		{
+			name: "BeforeID",
+			arg:  domain.ListTransfersParams{Username: user2.Username, AccountID: account2.ID, BeforeID: another.ID},
+			want: []domain.Transfer{outgoing, incoming},
+		},
+		{
+			name: "AnotherUserAccountID",
+			arg:  domain.ListTransfersParams{Username: user1.Username, AccountID: account3.ID},
+			want: []domain.Transfer{},
+		},
+		{
+			name: "AmountRange",
+			arg:  domain.ListTransfersParams{Username: user1.Username, MinAmount: "50", MaxAmount: "100"},
+			want: []domain.Transfer{incoming},
+		},
+		{
+			name: "DateRange",
+			arg: domain.ListTransfersParams{
+				Username:  user1.Username,
+				StartTime: outgoing.CreatedAt.Add(time.Hour),
+				EndTime:   outgoing.CreatedAt.Add(2 * time.Hour),
+			},
+			want: []domain.Transfer{},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			transferRepo := transferrepo.NewTxRepoPGS(tx)
+
+			arg := tc.arg
+			arg.Limit = 10
+
+			got, err := transferRepo.List(context.Background(), arg)
+			if err != nil {
+				t.Fatalf(`transferRepo.List(context.Background(), %+v) returned unexpected error: %v`, arg, err)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf(`transferRepo.List(context.Background(), %+v) returned unexpected difference (-want +got):\n%s"`,
+					arg, diff)
+			}
+		})
+	}
+}
+
 func TestTransferTx(t *testing.T) {
 	db := integrationtest.SetupDB(t, dbDriver, dbSource)
 
@@ -368,7 +464,7 @@ func TestTransferTx(t *testing.T) {
 
 	for i := 0; i < n; i++ {
 		go func() {
-			result, err := transferRepo.Transfer(ctx, arg)
+			result, err := transferRepo.Transfer(ctx, user1.Username, arg)
 
 			errs <- err
 			results <- result
@@ -380,9 +476,12 @@ func TestTransferTx(t *testing.T) {
 	existed := make(map[int]bool)
 
 	wantTransfer := domain.Transfer{
-		FromAccountID: account1.ID,
-		ToAccountID:   account2.ID,
-		Amount:        amount,
+		FromAccountID:  account1.ID,
+		ToAccountID:    account2.ID,
+		Amount:         amount,
+		Kind:           domain.TransferKindTransfer,
+		Status:         domain.TransferStatusCompleted,
+		RefundedAmount: "0",
 	}
 	wantFromEntry := domain.Entry{AccountID: account1.ID, Amount: "-" + amount}
 	wantToEntry := domain.Entry{AccountID: account2.ID, Amount: amount}
@@ -418,7 +517,7 @@ func TestTransferTx(t *testing.T) {
 		}
 
 		// check entries
-		ignoreFields = cmpopts.IgnoreFields(domain.Entry{}, "ID", "CreatedAt")
+		ignoreFields = cmpopts.IgnoreFields(domain.Entry{}, "ID", "TransferID", "CreatedAt", "PrevHash", "Hash")
 		if diff := cmp.Diff(wantFromEntry, got.FromEntry, ignoreFields); diff != "" {
 			t.Errorf(`transferRepo.Transfer(ctx, %v) returned unexpected difference (-want +got):\n%s"`,
 				arg, diff)
@@ -429,6 +528,11 @@ func TestTransferTx(t *testing.T) {
 				arg, diff)
 		}
 
+		if got.FromEntry.TransferID != got.Transfer.ID || got.ToEntry.TransferID != got.Transfer.ID {
+			t.Errorf("entries transfer ids = %v, %v, want %v",
+				got.FromEntry.TransferID, got.ToEntry.TransferID, got.Transfer.ID)
+		}
+
 		// check accounts's balances
 		account1BalanceAfter, err := decimal.NewFromString(got.FromAccount.Balance)
 		if err != nil {
@@ -505,7 +609,7 @@ func TestTransferFromAccountInsuffisientBalanceTx(t *testing.T) {
 		Amount:        amount,
 	}
 
-	_, err := transferRepo.Transfer(ctx, arg)
+	_, err := transferRepo.Transfer(ctx, user1.Username, arg)
 
 	if err == nil {
 		t.Error("got nil, want error")
@@ -533,10 +637,10 @@ func TestTransferTxDeadlock(t *testing.T) {
 	errs := make(chan error)
 
 	for i := 0; i < n; i++ {
-		fromAccountID, toAccountID := account1.ID, account2.ID
+		fromUsername, fromAccountID, toAccountID := user1.Username, account1.ID, account2.ID
 		// Change transfer direction
 		if i%2 == 0 {
-			fromAccountID, toAccountID = account2.ID, account1.ID
+			fromUsername, fromAccountID, toAccountID = user2.Username, account2.ID, account1.ID
 		}
 
 		arg := domain.CreateTransferParams{
@@ -546,7 +650,7 @@ func TestTransferTxDeadlock(t *testing.T) {
 		}
 
 		go func() {
-			_, err := transferRepo.Transfer(context.Background(), arg)
+			_, err := transferRepo.Transfer(context.Background(), fromUsername, arg)
 			errs <- err
 		}()
 	}
@@ -582,3 +686,501 @@ func TestTransferTxDeadlock(t *testing.T) {
 			account2.Balance, updatedAccount2.Balance)
 	}
 }
+
+func TestTransferTxIdempotencyKey(t *testing.T) {
+	db := integrationtest.SetupDB(t, dbDriver, dbSource)
+
+	user1 := helpers.SeedUser(t, db)
+	account1 := helpers.SeedAccountWith1000USDBalance(t, db, user1.Username)
+	user2 := helpers.SeedUser(t, db)
+	account2 := helpers.SeedAccountWith1000USDBalance(t, db, user2.Username)
+
+	transferRepo := transferrepo.NewRepoPGS(db)
+
+	arg := domain.CreateTransferParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        "10",
+		Idempotency: &domain.CreateIdempotencyKeyParams{
+			Key:            randompkg.String(20),
+			Username:       user1.Username,
+			RequestHash:    randompkg.String(64),
+			ResponseStatus: 201,
+		},
+	}
+
+	result, err := transferRepo.Transfer(ctx, user1.Username, arg)
+	if err != nil {
+		t.Fatalf("transferRepo.Transfer(ctx, %+v) returned error: %v", arg, err)
+	}
+
+	stored, err := transferRepo.GetIdempotencyKey(ctx, user1.Username, arg.Idempotency.Key)
+	if err != nil {
+		t.Fatalf("transferRepo.GetIdempotencyKey(ctx, %v, %v) returned error: %v",
+			user1.Username, arg.Idempotency.Key, err)
+	}
+
+	var got domain.TransferTxResult
+	if err := json.Unmarshal(stored.ResponseBody, &got); err != nil {
+		t.Fatalf("json.Unmarshal(%s) returned error: %v", stored.ResponseBody, err)
+	}
+
+	compareCreatedAt := cmpopts.EquateApproxTime(time.Second)
+	if diff := cmp.Diff(result, got, compareCreatedAt); diff != "" {
+		t.Errorf("stored result mismatch (-want +got):\n%s", diff)
+	}
+
+	// The same key must not book the transfer twice
+	_, err = transferRepo.Transfer(ctx, user1.Username, arg)
+	if err != domain.ErrIdempotencyKeyExists {
+		t.Errorf("transferRepo.Transfer(ctx, %+v) returned error: %v, want %v",
+			arg, err, domain.ErrIdempotencyKeyExists)
+	}
+
+	accountRepo := accountrepo.NewRepoPGS(db)
+
+	updatedAccount1, err := accountRepo.Get(ctx, account1.ID)
+	if err != nil {
+		t.Fatalf("accountRepo.Get(ctx, %v) returned error: %v", account1.ID, err)
+	}
+
+	if updatedAccount1.Balance != result.FromAccount.Balance {
+		t.Errorf("updatedAccount1.Balance = %v, want %v", updatedAccount1.Balance, result.FromAccount.Balance)
+	}
+
+	transfers, err := transferRepo.List(ctx, domain.ListTransfersParams{
+		Username:  user1.Username,
+		AccountID: account1.ID,
+		Limit:     10,
+	})
+	if err != nil {
+		t.Fatalf("transferRepo.List(ctx, ...) returned error: %v", err)
+	}
+
+	if len(transfers) != 1 {
+		t.Errorf("len(transfers) = %v, want 1", len(transfers))
+	}
+}
+
+func TestTransferTxValidation(t *testing.T) {
+	testCases := []struct {
+		name    string
+		arg     func(t *testing.T, db *sql.DB) (string, domain.CreateTransferParams)
+		wantErr error
+	}{
+		{
+			name: "ErrInvalidOwner",
+			arg: func(t *testing.T, db *sql.DB) (string, domain.CreateTransferParams) {
+				user1 := helpers.SeedUser(t, db)
+				account1 := helpers.SeedAccountWith1000USDBalance(t, db, user1.Username)
+				user2 := helpers.SeedUser(t, db)
+				account2 := helpers.SeedAccountWith1000USDBalance(t, db, user2.Username)
+
+				return user2.Username, domain.CreateTransferParams{
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        "10",
+				}
+			},
+			wantErr: domain.ErrInvalidOwner,
+		},
+		{
+			name: "ErrCurrencyMismatch",
+			arg: func(t *testing.T, db *sql.DB) (string, domain.CreateTransferParams) {
+				user1 := helpers.SeedUser(t, db)
+				account1 := helpers.SeedAccountWith1000USDBalance(t, db, user1.Username)
+				user2 := helpers.SeedUser(t, db)
+				account2 := helpers.SeedAccountWith1000Balance(t, db, user2.Username, currencypkg.EUR)
+
+				return user1.Username, domain.CreateTransferParams{
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        "10",
+				}
+			},
+			wantErr: domain.ErrCurrencyMismatch,
+		},
+		{
+			name: "ErrAccountFrozenFrom",
+			arg: func(t *testing.T, db *sql.DB) (string, domain.CreateTransferParams) {
+				user1 := helpers.SeedUser(t, db)
+				account1 := helpers.SeedAccountWith1000USDBalance(t, db, user1.Username)
+				user2 := helpers.SeedUser(t, db)
+				account2 := helpers.SeedAccountWith1000USDBalance(t, db, user2.Username)
+				helpers.FreezeAccount(t, db, account1.ID)
+
+				return user1.Username, domain.CreateTransferParams{
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        "10",
+				}
+			},
+			wantErr: domain.ErrAccountFrozen,
+		},
+		{
+			name: "ErrAccountFrozenTo",
+			arg: func(t *testing.T, db *sql.DB) (string, domain.CreateTransferParams) {
+				user1 := helpers.SeedUser(t, db)
+				account1 := helpers.SeedAccountWith1000USDBalance(t, db, user1.Username)
+				user2 := helpers.SeedUser(t, db)
+				account2 := helpers.SeedAccountWith1000USDBalance(t, db, user2.Username)
+				helpers.FreezeAccount(t, db, account2.ID)
+
+				return user1.Username, domain.CreateTransferParams{
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        "10",
+				}
+			},
+			wantErr: domain.ErrAccountFrozen,
+		},
+		{
+			name: "ErrAccountNotFound",
+			arg: func(t *testing.T, db *sql.DB) (string, domain.CreateTransferParams) {
+				user1 := helpers.SeedUser(t, db)
+				account1 := helpers.SeedAccountWith1000USDBalance(t, db, user1.Username)
+
+				return user1.Username, domain.CreateTransferParams{
+					FromAccountID: account1.ID,
+					ToAccountID:   account1.ID + 1000,
+					Amount:        "10",
+				}
+			},
+			wantErr: domain.ErrAccountNotFound,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			db := integrationtest.SetupDB(t, dbDriver, dbSource)
+			fromUsername, arg := tc.arg(t, db)
+			transferRepo := transferrepo.NewRepoPGS(db)
+
+			_, err := transferRepo.Transfer(ctx, fromUsername, arg)
+			if err != tc.wantErr {
+				t.Errorf("transferRepo.Transfer(ctx, %v, %+v) returned error: %v, want %v",
+					fromUsername, arg, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestTransferTxConcurrentDrain(t *testing.T) {
+	db := integrationtest.SetupDB(t, dbDriver, dbSource)
+
+	user1 := helpers.SeedUser(t, db)
+	account1 := helpers.SeedAccountWith1000USDBalance(t, db, user1.Username)
+	user2 := helpers.SeedUser(t, db)
+	account2 := helpers.SeedAccountWith1000USDBalance(t, db, user2.Username)
+
+	transferRepo := transferrepo.NewRepoPGS(db)
+
+	// hammer one account with more transfers than its balance allows
+	n := 50
+	amount := "100"
+	wantSucceeded := 10
+
+	arg := domain.CreateTransferParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+	}
+
+	errs := make(chan error)
+
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := transferRepo.Transfer(context.Background(), user1.Username, arg)
+			errs <- err
+		}()
+	}
+
+	succeeded := 0
+
+	for i := 0; i < n; i++ {
+		err := <-errs
+
+		switch err {
+		case nil:
+			succeeded++
+		case domain.ErrInsufficientBalance:
+		default:
+			t.Errorf("transferRepo.Transfer(ctx, %v, %+v) returned error: %v", user1.Username, arg, err)
+		}
+	}
+
+	if succeeded != wantSucceeded {
+		t.Errorf("succeeded = %v, want %v", succeeded, wantSucceeded)
+	}
+
+	accountRepo := accountrepo.NewRepoPGS(db)
+
+	updatedAccount1, err := accountRepo.Get(context.Background(), account1.ID)
+	if err != nil {
+		t.Fatalf("accountRepo.Get(ctx, %v) returned error: %v", account1.ID, err)
+	}
+
+	if updatedAccount1.Balance != "0" {
+		t.Errorf("updatedAccount1.Balance = %v, want 0", updatedAccount1.Balance)
+	}
+
+	updatedAccount2, err := accountRepo.Get(context.Background(), account2.ID)
+	if err != nil {
+		t.Fatalf("accountRepo.Get(ctx, %v) returned error: %v", account2.ID, err)
+	}
+
+	if updatedAccount2.Balance != "2000" {
+		t.Errorf("updatedAccount2.Balance = %v, want 2000", updatedAccount2.Balance)
+	}
+}
+
+func TestTransferTxFXQuote(t *testing.T) {
+	seedQuote := func(t *testing.T, db *sql.DB, username, from, to string, expiresAt time.Time) domain.FXQuote {
+		return helpers.SeedFXQuote(t, db, domain.CreateFXQuoteParams{
+			ID:           uuid.New(),
+			Username:     username,
+			FromCurrency: from,
+			ToCurrency:   to,
+			Rate:         "0.92",
+			ExpiresAt:    expiresAt,
+		})
+	}
+
+	testCases := []struct {
+		name    string
+		arg     func(t *testing.T, db *sql.DB) (string, domain.CreateTransferParams)
+		wantErr error
+	}{
+		{
+			name: "OK",
+			arg: func(t *testing.T, db *sql.DB) (string, domain.CreateTransferParams) {
+				user1 := helpers.SeedUser(t, db)
+				account1 := helpers.SeedAccountWith1000USDBalance(t, db, user1.Username)
+				user2 := helpers.SeedUser(t, db)
+				account2 := helpers.SeedAccountWith1000Balance(t, db, user2.Username, currencypkg.EUR)
+				quote := seedQuote(t, db, user1.Username, currencypkg.USD, currencypkg.EUR, time.Now().Add(time.Minute))
+
+				return user1.Username, domain.CreateTransferParams{
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        "100",
+					FXQuoteID:     &quote.ID,
+				}
+			},
+		},
+		{
+			name: "ErrFXQuoteNotFound",
+			arg: func(t *testing.T, db *sql.DB) (string, domain.CreateTransferParams) {
+				user1 := helpers.SeedUser(t, db)
+				account1 := helpers.SeedAccountWith1000USDBalance(t, db, user1.Username)
+				user2 := helpers.SeedUser(t, db)
+				account2 := helpers.SeedAccountWith1000Balance(t, db, user2.Username, currencypkg.EUR)
+				quote := seedQuote(t, db, user2.Username, currencypkg.USD, currencypkg.EUR, time.Now().Add(time.Minute))
+
+				return user1.Username, domain.CreateTransferParams{
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        "100",
+					FXQuoteID:     &quote.ID,
+				}
+			},
+			wantErr: domain.ErrFXQuoteNotFound,
+		},
+		{
+			name: "ErrFXQuoteExpired",
+			arg: func(t *testing.T, db *sql.DB) (string, domain.CreateTransferParams) {
+				user1 := helpers.SeedUser(t, db)
+				account1 := helpers.SeedAccountWith1000USDBalance(t, db, user1.Username)
+				user2 := helpers.SeedUser(t, db)
+				account2 := helpers.SeedAccountWith1000Balance(t, db, user2.Username, currencypkg.EUR)
+				quote := seedQuote(t, db, user1.Username, currencypkg.USD, currencypkg.EUR, time.Now().Add(-time.Minute))
+
+				return user1.Username, domain.CreateTransferParams{
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        "100",
+					FXQuoteID:     &quote.ID,
+				}
+			},
+			wantErr: domain.ErrFXQuoteExpired,
+		},
+		{
+			name: "ErrFXQuoteCurrencyMismatch",
+			arg: func(t *testing.T, db *sql.DB) (string, domain.CreateTransferParams) {
+				user1 := helpers.SeedUser(t, db)
+				account1 := helpers.SeedAccountWith1000USDBalance(t, db, user1.Username)
+				user2 := helpers.SeedUser(t, db)
+				account2 := helpers.SeedAccountWith1000Balance(t, db, user2.Username, currencypkg.EUR)
+				quote := seedQuote(t, db, user1.Username, currencypkg.USD, currencypkg.RMB, time.Now().Add(time.Minute))
+
+				return user1.Username, domain.CreateTransferParams{
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        "100",
+					FXQuoteID:     &quote.ID,
+				}
+			},
+			wantErr: domain.ErrFXQuoteCurrencyMismatch,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			db := integrationtest.SetupDB(t, dbDriver, dbSource)
+			fromUsername, arg := tc.arg(t, db)
+			transferRepo := transferrepo.NewRepoPGS(db)
+
+			result, err := transferRepo.Transfer(ctx, fromUsername, arg)
+			if err != tc.wantErr {
+				t.Fatalf("transferRepo.Transfer(ctx, %v, %+v) returned error: %v, want %v",
+					fromUsername, arg, err, tc.wantErr)
+			}
+
+			if tc.wantErr != nil {
+				return
+			}
+
+			if result.Transfer.FXRate != "0.92" {
+				t.Errorf("result.Transfer.FXRate = %v, want 0.92", result.Transfer.FXRate)
+			}
+
+			if result.FromEntry.Amount != "-100" || result.ToEntry.Amount != "92" {
+				t.Errorf("entries amounts = %v, %v, want -100, 92", result.FromEntry.Amount, result.ToEntry.Amount)
+			}
+
+			if result.FromAccount.Balance != "900" || result.ToAccount.Balance != "1092" {
+				t.Errorf("balances = %v, %v, want 900, 1092", result.FromAccount.Balance, result.ToAccount.Balance)
+			}
+
+			// A quote can be used only once
+			_, err = transferRepo.Transfer(ctx, fromUsername, arg)
+			if err != domain.ErrFXQuoteUsed {
+				t.Errorf("transferRepo.Transfer(ctx, %v, %+v) returned error: %v, want %v",
+					fromUsername, arg, err, domain.ErrFXQuoteUsed)
+			}
+		})
+	}
+}
+
+func TestDepositWithdraw(t *testing.T) {
+	db := integrationtest.SetupDB(t, dbDriver, dbSource)
+	transferRepo := transferrepo.NewRepoPGS(db)
+
+	user := helpers.SeedUser(t, db)
+	account := helpers.SeedAccountWith1000USDBalance(t, db, user.Username)
+
+	deposit, err := transferRepo.Deposit(ctx, domain.CreateCashParams{AccountID: account.ID, Amount: "100"})
+	if err != nil {
+		t.Fatalf("transferRepo.Deposit(ctx, %v, 100) returned error: %v", account.ID, err)
+	}
+
+	settlement := deposit.FromAccount
+	if !settlement.IsSystem || settlement.Currency != account.Currency {
+		t.Fatalf("deposit.FromAccount = %+v, want %s settlement account", settlement, account.Currency)
+	}
+
+	if deposit.Transfer.Kind != domain.TransferKindDeposit || deposit.ToAccount.Balance != "1100" {
+		t.Errorf("transferRepo.Deposit(ctx, %v, 100) returned %+v", account.ID, deposit)
+	}
+
+	withdrawal, err := transferRepo.Withdraw(ctx, domain.CreateCashParams{AccountID: account.ID, Amount: "300"})
+	if err != nil {
+		t.Fatalf("transferRepo.Withdraw(ctx, %v, 300) returned error: %v", account.ID, err)
+	}
+
+	if withdrawal.Transfer.Kind != domain.TransferKindWithdrawal || withdrawal.FromAccount.Balance != "800" {
+		t.Errorf("transferRepo.Withdraw(ctx, %v, 300) returned %+v", account.ID, withdrawal)
+	}
+
+	// The settlement account mirrors the net flow, so the ledger sums to zero.
+	if withdrawal.ToAccount.ID != settlement.ID || withdrawal.ToAccount.Balance != "200" {
+		t.Errorf("withdrawal.ToAccount = %+v, want settlement account %v with 200 balance", withdrawal.ToAccount, settlement.ID)
+	}
+
+	if _, err := transferRepo.Withdraw(ctx, domain.CreateCashParams{AccountID: account.ID, Amount: "801"}); err != domain.ErrInsufficientBalance {
+		t.Errorf("transferRepo.Withdraw(ctx, %v, 801) returned error: %v, want %v", account.ID, err, domain.ErrInsufficientBalance)
+	}
+
+	if _, err := transferRepo.Deposit(ctx, domain.CreateCashParams{AccountID: settlement.ID, Amount: "1"}); err != domain.ErrSystemAccount {
+		t.Errorf("transferRepo.Deposit(ctx, %v, 1) returned error: %v, want %v", settlement.ID, err, domain.ErrSystemAccount)
+	}
+
+	transfer := domain.CreateTransferParams{FromAccountID: account.ID, ToAccountID: settlement.ID, Amount: "1"}
+	if _, err := transferRepo.Transfer(ctx, user.Username, transfer); err != domain.ErrSystemAccount {
+		t.Errorf("transferRepo.Transfer(ctx, %v, %+v) returned error: %v, want %v", user.Username, transfer, err, domain.ErrSystemAccount)
+	}
+}
+
+func TestTransferTxMemo(t *testing.T) {
+	db := integrationtest.SetupDB(t, dbDriver, dbSource)
+	transferRepo := transferrepo.NewRepoPGS(db)
+
+	user1 := helpers.SeedUser(t, db)
+	account1 := helpers.SeedAccountWith1000USDBalance(t, db, user1.Username)
+	user2 := helpers.SeedUser(t, db)
+	account2 := helpers.SeedAccountWith1000USDBalance(t, db, user2.Username)
+
+	memo := domain.Memo{
+		Description: "Rent 100% paid",
+		Reference:   "INV-" + randompkg.String(8),
+		Metadata:    map[string]any{"flat": "12B", "month": float64(3)},
+	}
+	arg := domain.CreateTransferParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: "10", Memo: memo}
+
+	result, err := transferRepo.Transfer(ctx, user1.Username, arg)
+	if err != nil {
+		t.Fatalf("transferRepo.Transfer(ctx, %v, %+v) returned error: %v", user1.Username, arg, err)
+	}
+
+	for name, got := range map[string]domain.Memo{
+		"transfer":   result.Transfer.Memo,
+		"from entry": result.FromEntry.Memo,
+		"to entry":   result.ToEntry.Memo,
+	} {
+		if diff := cmp.Diff(memo, got); diff != "" {
+			t.Errorf("%s memo mismatch (-want +got):\n%s", name, diff)
+		}
+	}
+
+	plain := domain.CreateTransferParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: "10"}
+	if _, err := transferRepo.Transfer(ctx, user1.Username, plain); err != nil {
+		t.Fatalf("transferRepo.Transfer(ctx, %v, %+v) returned error: %v", user1.Username, plain, err)
+	}
+
+	testCases := []struct {
+		name      string
+		search    string
+		reference string
+		wantCount int
+	}{
+		{name: "All", wantCount: 2},
+		{name: "SearchDescription", search: "rent", wantCount: 1},
+		{name: "SearchReference", search: memo.Reference[2:], wantCount: 1},
+		{name: "SearchEscapesWildcards", search: "100%", wantCount: 1},
+		{name: "SearchWildcardIsLiteral", search: "%_", wantCount: 0},
+		{name: "Reference", reference: memo.Reference, wantCount: 1},
+		{name: "ReferenceIsExact", reference: memo.Reference[1:], wantCount: 0},
+	}
+
+	for _, tc := range testCases {
+		listArg := domain.ListTransfersParams{
+			Username:  user1.Username,
+			Search:    tc.search,
+			Reference: tc.reference,
+			Limit:     10,
+		}
+
+		got, err := transferRepo.List(ctx, listArg)
+		if err != nil {
+			t.Fatalf("%s: transferRepo.List(ctx, %+v) returned error: %v", tc.name, listArg, err)
+		}
+
+		if len(got) != tc.wantCount {
+			t.Errorf("%s: transferRepo.List(ctx, %+v) returned %d transfers, want %d", tc.name, listArg, len(got), tc.wantCount)
+		}
+	}
+}
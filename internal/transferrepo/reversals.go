@@ -0,0 +1,162 @@
+package transferrepo
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+)
+
+const getForUpdateQuery = `
+SELECT
+	id, from_account_id, to_account_id, amount, fx_rate, kind, status, refunded_amount,
+	reversed_transfer_id, created_at, description, reference, metadata
+FROM transfers
+WHERE id = $1
+FOR UPDATE
+`
+
+// GetForUpdate returns the transfer with the given id and locks its row until
+// the end of the current transaction.
+func (r *RepoPGS) GetForUpdate(ctx context.Context, id int64) (domain.Transfer, error) {
+	l := zerolog.Ctx(ctx)
+
+	t, err := scanTransfer(r.db.QueryRowContext(ctx, getForUpdateQuery, id))
+	if err != nil {
+		l.Error().Err(err).Send()
+
+		if err == sql.ErrNoRows {
+			return t, domain.ErrTransferNotFound
+		}
+
+		return t, errorspkg.ErrInternal
+	}
+
+	return t, nil
+}
+
+const addRefundQuery = `
+UPDATE transfers
+SET refunded_amount = refunded_amount + $2,
+    status = CASE WHEN refunded_amount + $2 = amount THEN 'reversed' ELSE 'partially_refunded' END
+WHERE id = $1
+RETURNING id, from_account_id, to_account_id, amount, fx_rate, kind, status, refunded_amount,
+    reversed_transfer_id, created_at, description, reference, metadata
+`
+
+// AddRefund adds the amount to the refunded amount of the transfer and
+// updates its status. It returns domain.ErrRefundAmountExceeded if the
+// refunded amount would exceed the transfer amount.
+func (r *RepoPGS) AddRefund(ctx context.Context, id int64, amount string) (domain.Transfer, error) {
+	l := zerolog.Ctx(ctx)
+
+	t, err := scanTransfer(r.db.QueryRowContext(ctx, addRefundQuery, id, amount))
+	if err != nil {
+		l.Error().Err(err).Send()
+
+		if err == sql.ErrNoRows {
+			return t, domain.ErrTransferNotFound
+		}
+
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Constraint == "transfers_refunded_amount_check" {
+			return t, domain.ErrRefundAmountExceeded
+		}
+
+		return t, errorspkg.ErrInternal
+	}
+
+	return t, nil
+}
+
+// Reverse refunds the amount of the transfer, or all of the amount left to
+// refund if arg.Amount is empty, with a reversal from the recipient back to
+// the sender.
+//
+// It locks the transfer and then both accounts, checks that the transfer is a
+// same-currency transfer with enough amount left to refund and that the
+// recipient has sufficient balance, then updates the transfer refunded amount
+// and status and creates the reversal within a single dbpkg transaction.
+func (r *RepoPGS) Reverse(ctx context.Context, arg domain.ReverseTransferParams) (domain.ReversalTxResult, error) {
+	l := zerolog.Ctx(ctx)
+
+	var result domain.ReversalTxResult
+
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		transferRepo := NewTxRepoPGS(tx)
+
+		original, err := transferRepo.GetForUpdate(ctx, arg.TransferID)
+		if err != nil {
+			return err
+		}
+
+		amount, err := refundAmount(original, arg.Amount)
+		if err != nil {
+			l.Info().Err(err).Send()
+			return err
+		}
+
+		result.ReversedTransfer, err = transferRepo.AddRefund(ctx, original.ID, amount)
+		if err != nil {
+			return err
+		}
+
+		reversalArg := domain.CreateTransferParams{
+			FromAccountID:      original.ToAccountID,
+			ToAccountID:        original.FromAccountID,
+			Amount:             amount,
+			Kind:               domain.TransferKindReversal,
+			ReversedTransferID: original.ID,
+		}
+
+		result.Reversal, err = transferTx(ctx, tx, "", reversalArg, func(from, to domain.Account) error {
+			return sufficientBalance(from, amount)
+		})
+
+		return err
+	})
+
+	return result, err
+}
+
+// refundAmount checks the locked transfer against the refund and returns the
+// refund amount.
+func refundAmount(original domain.Transfer, amount string) (string, error) {
+	if original.Kind != domain.TransferKindTransfer || original.FXRate != "" {
+		return "", domain.ErrTransferNotReversible
+	}
+
+	if original.Status == domain.TransferStatusReversed {
+		return "", domain.ErrTransferReversed
+	}
+
+	total, err := decimal.NewFromString(original.Amount)
+	if err != nil {
+		return "", errorspkg.ErrInternal
+	}
+
+	refunded, err := decimal.NewFromString(original.RefundedAmount)
+	if err != nil {
+		return "", errorspkg.ErrInternal
+	}
+
+	left := total.Sub(refunded)
+
+	if amount == "" {
+		return left.String(), nil
+	}
+
+	amountDecimal, err := decimal.NewFromString(amount)
+	if err != nil {
+		return "", domain.ErrInvalidAmount
+	}
+
+	if amountDecimal.GreaterThan(left) {
+		return "", domain.ErrRefundAmountExceeded
+	}
+
+	return amount, nil
+}
@@ -0,0 +1,83 @@
+//go:build integration
+
+package transferrepo_test
+
+import (
+	"testing"
+
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/integrationtest"
+	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
+	"github.com/go-petr/pet-bank/internal/transferrepo"
+)
+
+func TestReverse(t *testing.T) {
+	db := integrationtest.SetupDB(t, dbDriver, dbSource)
+	transferRepo := transferrepo.NewRepoPGS(db)
+
+	user1 := helpers.SeedUser(t, db)
+	account1 := helpers.SeedAccountWith1000USDBalance(t, db, user1.Username)
+	user2 := helpers.SeedUser(t, db)
+	account2 := helpers.SeedAccountWith1000USDBalance(t, db, user2.Username)
+
+	arg := domain.CreateTransferParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: "100"}
+
+	transfer, err := transferRepo.Transfer(ctx, user1.Username, arg)
+	if err != nil {
+		t.Fatalf("transferRepo.Transfer(ctx, %v, %+v) returned error: %v", user1.Username, arg, err)
+	}
+
+	id := transfer.Transfer.ID
+
+	partial, err := transferRepo.Reverse(ctx, domain.ReverseTransferParams{TransferID: id, Amount: "40"})
+	if err != nil {
+		t.Fatalf("transferRepo.Reverse(ctx, %v, 40) returned error: %v", id, err)
+	}
+
+	if got := partial.ReversedTransfer; got.Status != domain.TransferStatusPartiallyRefunded || got.RefundedAmount != "40" {
+		t.Errorf("partial.ReversedTransfer = %+v, want partially refunded 40", got)
+	}
+
+	reversal := partial.Reversal
+	if reversal.Transfer.Kind != domain.TransferKindReversal || reversal.Transfer.ReversedTransferID != id ||
+		reversal.Transfer.FromAccountID != account2.ID || reversal.Transfer.ToAccountID != account1.ID {
+		t.Errorf("partial.Reversal.Transfer = %+v, want reversal of %v", reversal.Transfer, id)
+	}
+
+	if reversal.FromAccount.Balance != "1060" || reversal.ToAccount.Balance != "940" {
+		t.Errorf("balances after partial refund: %v and %v, want 1060 and 940",
+			reversal.FromAccount.Balance, reversal.ToAccount.Balance)
+	}
+
+	if _, err := transferRepo.Reverse(ctx, domain.ReverseTransferParams{TransferID: id, Amount: "61"}); err != domain.ErrRefundAmountExceeded {
+		t.Errorf("transferRepo.Reverse(ctx, %v, 61) returned error: %v, want %v", id, err, domain.ErrRefundAmountExceeded)
+	}
+
+	// No amount refunds the rest of the transfer.
+	rest, err := transferRepo.Reverse(ctx, domain.ReverseTransferParams{TransferID: id})
+	if err != nil {
+		t.Fatalf("transferRepo.Reverse(ctx, %v) returned error: %v", id, err)
+	}
+
+	if got := rest.ReversedTransfer; got.Status != domain.TransferStatusReversed || got.RefundedAmount != "100" {
+		t.Errorf("rest.ReversedTransfer = %+v, want reversed 100", got)
+	}
+
+	if rest.Reversal.Transfer.Amount != "60" || rest.Reversal.ToAccount.Balance != "1000" {
+		t.Errorf("rest.Reversal = %+v, want 60 refunded to 1000 balance", rest.Reversal)
+	}
+
+	if _, err := transferRepo.Reverse(ctx, domain.ReverseTransferParams{TransferID: id}); err != domain.ErrTransferReversed {
+		t.Errorf("transferRepo.Reverse(ctx, %v) returned error: %v, want %v", id, err, domain.ErrTransferReversed)
+	}
+
+	// A reversal can't be reversed itself.
+	reversalID := rest.Reversal.Transfer.ID
+	if _, err := transferRepo.Reverse(ctx, domain.ReverseTransferParams{TransferID: reversalID}); err != domain.ErrTransferNotReversible {
+		t.Errorf("transferRepo.Reverse(ctx, %v) returned error: %v, want %v", reversalID, err, domain.ErrTransferNotReversible)
+	}
+
+	if _, err := transferRepo.Reverse(ctx, domain.ReverseTransferParams{TransferID: 0}); err != domain.ErrTransferNotFound {
+		t.Errorf("transferRepo.Reverse(ctx, 0) returned error: %v, want %v", err, domain.ErrTransferNotFound)
+	}
+}
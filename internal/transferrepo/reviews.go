@@ -0,0 +1,218 @@
+package transferrepo
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/go-petr/pet-bank/internal/accountrepo"
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/idempotencyrepo"
+	"github.com/go-petr/pet-bank/internal/reviewrepo"
+	"github.com/go-petr/pet-bank/internal/schedulerepo"
+	"github.com/rs/zerolog"
+)
+
+// CreateReview holds the transfer for review by a staff member instead of
+// executing it. rules are the names of the monitoring rules which flagged it.
+//
+// It locks both accounts, runs the checks of Transfer, including arg.Limit,
+// then adds the amount to the from account held amount and creates the
+// pending review within a single dbpkg transaction. If arg.Idempotency is set,
+// the result is stored under the idempotency key with the 202 Accepted status.
+func (r *RepoPGS) CreateReview(
+	ctx context.Context,
+	fromUsername string,
+	arg domain.CreateTransferParams,
+	rules []string,
+) (domain.TransferTxResult, error) {
+	l := zerolog.Ctx(ctx)
+
+	var result domain.TransferTxResult
+
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		accountRepo := accountrepo.NewRepoPGS(tx)
+
+		from, to, err := lockAccounts(ctx, accountRepo, arg.FromAccountID, arg.ToAccountID)
+		if err != nil {
+			l.Info().Err(err).Send()
+			return err
+		}
+
+		// The held amount is checked as a hold on the from account.
+		if err := validHold(fromUsername, from, to, arg.Amount); err != nil {
+			l.Info().Err(err).Send()
+			return err
+		}
+
+		if arg.Limit != nil {
+			if err := checkLimit(ctx, NewTxRepoPGS(tx), from, arg.Amount, *arg.Limit, 0); err != nil {
+				l.Info().Err(err).Send()
+				return err
+			}
+		}
+
+		result.FromAccount, err = accountRepo.AddHeld(ctx, arg.Amount, from.ID)
+		if err != nil {
+			return err
+		}
+
+		result.ToAccount = to
+
+		review, err := reviewrepo.NewRepoPGS(tx).Create(ctx, domain.CreateTransferReviewParams{
+			Username:      fromUsername,
+			FromAccountID: arg.FromAccountID,
+			ToAccountID:   arg.ToAccountID,
+			Amount:        arg.Amount,
+			Rules:         rules,
+			Memo:          arg.Memo,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Review = &review
+
+		if arg.Idempotency != nil {
+			idempotency := *arg.Idempotency
+			idempotency.ResponseStatus = http.StatusAccepted
+
+			if err := storeIdempotencyKey(ctx, idempotencyrepo.NewRepoPGS(tx), idempotency, result); err != nil {
+				l.Error().Err(err).Send()
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// ApproveReview releases the held amount of the pending review and executes
+// its transfer. reviewer is the staff member who approved it. The scheduled
+// transfer run held for the review succeeds.
+//
+// The review is locked before its accounts, so it can't be approved and
+// rejected concurrently. The from account must still have sufficient
+// available balance and neither account may be frozen.
+func (r *RepoPGS) ApproveReview(ctx context.Context, reviewer string, id int64) (domain.ApproveTransferReviewResult, error) {
+	l := zerolog.Ctx(ctx)
+
+	var result domain.ApproveTransferReviewResult
+
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		reviewRepo := reviewrepo.NewRepoPGS(tx)
+		accountRepo := accountrepo.NewRepoPGS(tx)
+
+		review, err := lockReview(ctx, reviewRepo, accountRepo, id)
+		if err != nil {
+			l.Info().Err(err).Send()
+			return err
+		}
+
+		if _, err := accountRepo.AddHeld(ctx, "-"+review.Amount, review.FromAccountID); err != nil {
+			return err
+		}
+
+		arg := domain.CreateTransferParams{
+			FromAccountID: review.FromAccountID,
+			ToAccountID:   review.ToAccountID,
+			Amount:        review.Amount,
+			Kind:          domain.TransferKindTransfer,
+			Memo:          review.Memo,
+		}
+
+		result.Transfer, err = transferTx(ctx, tx, "", arg, func(from, to domain.Account) error {
+			return sufficientBalance(from, review.Amount)
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Review, err = reviewRepo.Close(ctx, domain.CloseTransferReviewParams{
+			ID:         review.ID,
+			Status:     domain.TransferReviewStatusApproved,
+			Reviewer:   reviewer,
+			TransferID: result.Transfer.Transfer.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return schedulerepo.NewRepoPGS(tx).FinishReviewedRun(ctx, review.ID, result.Transfer.Transfer.ID)
+	})
+
+	return result, err
+}
+
+// RejectReview releases the held amount of the pending review without
+// executing its transfer. reviewer is the staff member who rejected it. The
+// scheduled transfer run held for the review fails.
+func (r *RepoPGS) RejectReview(ctx context.Context, reviewer string, id int64) (domain.TransferReview, error) {
+	l := zerolog.Ctx(ctx)
+
+	var review domain.TransferReview
+
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		reviewRepo := reviewrepo.NewRepoPGS(tx)
+		accountRepo := accountrepo.NewRepoPGS(tx)
+
+		locked, err := lockReview(ctx, reviewRepo, accountRepo, id)
+		if err != nil {
+			l.Info().Err(err).Send()
+			return err
+		}
+
+		if _, err := accountRepo.AddHeld(ctx, "-"+locked.Amount, locked.FromAccountID); err != nil {
+			return err
+		}
+
+		review, err = reviewRepo.Close(ctx, domain.CloseTransferReviewParams{
+			ID:       locked.ID,
+			Status:   domain.TransferReviewStatusRejected,
+			Reviewer: reviewer,
+		})
+		if err != nil {
+			return err
+		}
+
+		return schedulerepo.NewRepoPGS(tx).FinishReviewedRun(ctx, locked.ID, 0)
+	})
+
+	return review, err
+}
+
+// lockReview locks the pending review and its accounts.
+func lockReview(
+	ctx context.Context,
+	reviewRepo *reviewrepo.RepoPGS,
+	accountRepo *accountrepo.RepoPGS,
+	id int64,
+) (domain.TransferReview, error) {
+	review, err := reviewRepo.GetForUpdate(ctx, id)
+	if err != nil {
+		return review, err
+	}
+
+	if review.Status != domain.TransferReviewStatusPending {
+		return review, domain.ErrTransferReviewClosed
+	}
+
+	if _, _, err := lockAccounts(ctx, accountRepo, review.FromAccountID, review.ToAccountID); err != nil {
+		return review, err
+	}
+
+	return review, nil
+}
+
+// GetReview returns the transfer review with the given id.
+func (r *RepoPGS) GetReview(ctx context.Context, id int64) (domain.TransferReview, error) {
+	return reviewrepo.NewRepoPGS(r.db).Get(ctx, id)
+}
+
+// ListReviews returns the specified number of transfer reviews with
+// arg.Status, or all of them if it is empty, ordered by id.
+func (r *RepoPGS) ListReviews(ctx context.Context, arg domain.ListTransferReviewsParams) ([]domain.TransferReview, error) {
+	return reviewrepo.NewRepoPGS(r.db).List(ctx, arg)
+}
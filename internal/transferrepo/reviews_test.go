@@ -0,0 +1,211 @@
+//go:build integration
+
+package transferrepo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/internal/accountrepo"
+	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/internal/integrationtest"
+	"github.com/go-petr/pet-bank/internal/integrationtest/helpers"
+	"github.com/go-petr/pet-bank/internal/schedulerepo"
+	"github.com/go-petr/pet-bank/internal/transferrepo"
+)
+
+func TestReviews(t *testing.T) {
+	db := integrationtest.SetupDB(t, dbDriver, dbSource)
+	transferRepo := transferrepo.NewRepoPGS(db)
+	accountRepo := accountrepo.NewRepoPGS(db)
+
+	payer := helpers.SeedUser(t, db)
+	payee := helpers.SeedUser(t, db)
+	account := helpers.SeedAccountWith1000USDBalance(t, db, payer.Username)
+	toAccount := helpers.SeedAccountWith1000USDBalance(t, db, payee.Username)
+	rules := []string{"new_payees"}
+
+	balances := func(t *testing.T, id int32, wantBalance, wantAvailable string) {
+		t.Helper()
+
+		got, err := accountRepo.Get(ctx, id)
+		if err != nil {
+			t.Fatalf("accountRepo.Get(ctx, %v) returned error: %v", id, err)
+		}
+
+		if got.Balance != wantBalance || got.AvailableBalance != wantAvailable {
+			t.Errorf("account %v balance = %v, available = %v, want %v, %v",
+				id, got.Balance, got.AvailableBalance, wantBalance, wantAvailable)
+		}
+	}
+
+	arg := domain.CreateTransferParams{FromAccountID: account.ID, ToAccountID: toAccount.ID, Amount: "600"}
+
+	if _, err := transferRepo.CreateReview(ctx, payee.Username, arg, rules); err != domain.ErrInvalidOwner {
+		t.Errorf("transferRepo.CreateReview(ctx, %v, %+v) returned error: %v, want %v", payee.Username, arg, err, domain.ErrInvalidOwner)
+	}
+
+	result, err := transferRepo.CreateReview(ctx, payer.Username, arg, rules)
+	if err != nil {
+		t.Fatalf("transferRepo.CreateReview(ctx, %v, %+v) returned error: %v", payer.Username, arg, err)
+	}
+
+	review := result.Review
+	if review == nil || review.Status != domain.TransferReviewStatusPending || review.Amount != "600" || len(review.Rules) != 1 {
+		t.Fatalf("transferRepo.CreateReview(ctx, %v, %+v) returned review %+v", payer.Username, arg, review)
+	}
+
+	balances(t, account.ID, "1000", "400")
+
+	// Pending reviews count towards the transfer limits.
+	limit := domain.TransferLimit{Currency: account.Currency, Daily: "1000"}
+	limited := domain.CreateTransferParams{FromAccountID: account.ID, ToAccountID: toAccount.ID, Amount: "401", Limit: &limit}
+
+	if _, err := transferRepo.Transfer(ctx, payer.Username, limited); err == nil {
+		t.Errorf("transferRepo.Transfer(ctx, %v, %+v) returned no error", payer.Username, limited)
+	}
+
+	approved, err := transferRepo.ApproveReview(ctx, "admin", review.ID)
+	if err != nil {
+		t.Fatalf("transferRepo.ApproveReview(ctx, admin, %v) returned error: %v", review.ID, err)
+	}
+
+	if approved.Review.Status != domain.TransferReviewStatusApproved || approved.Review.Reviewer != "admin" ||
+		approved.Review.TransferID != approved.Transfer.Transfer.ID || approved.Review.ReviewedAt == nil {
+		t.Errorf("transferRepo.ApproveReview(ctx, admin, %v) returned review %+v", review.ID, approved.Review)
+	}
+
+	balances(t, account.ID, "400", "400")
+	balances(t, toAccount.ID, "1600", "1600")
+
+	if _, err := transferRepo.RejectReview(ctx, "admin", review.ID); err != domain.ErrTransferReviewClosed {
+		t.Errorf("transferRepo.RejectReview(ctx, admin, %v) returned error: %v, want %v", review.ID, err, domain.ErrTransferReviewClosed)
+	}
+
+	arg.Amount = "300"
+
+	result, err = transferRepo.CreateReview(ctx, payer.Username, arg, rules)
+	if err != nil {
+		t.Fatalf("transferRepo.CreateReview(ctx, %v, %+v) returned error: %v", payer.Username, arg, err)
+	}
+
+	balances(t, account.ID, "400", "100")
+
+	rejected, err := transferRepo.RejectReview(ctx, "admin", result.Review.ID)
+	if err != nil {
+		t.Fatalf("transferRepo.RejectReview(ctx, admin, %v) returned error: %v", result.Review.ID, err)
+	}
+
+	if rejected.Status != domain.TransferReviewStatusRejected || rejected.TransferID != 0 {
+		t.Errorf("transferRepo.RejectReview(ctx, admin, %v) returned %+v", result.Review.ID, rejected)
+	}
+
+	balances(t, account.ID, "400", "400")
+
+	if _, err := transferRepo.ApproveReview(ctx, "admin", rejected.ID); err != domain.ErrTransferReviewClosed {
+		t.Errorf("transferRepo.ApproveReview(ctx, admin, %v) returned error: %v, want %v", rejected.ID, err, domain.ErrTransferReviewClosed)
+	}
+
+	if _, err := transferRepo.ApproveReview(ctx, "admin", -1); err != domain.ErrTransferReviewNotFound {
+		t.Errorf("transferRepo.ApproveReview(ctx, admin, -1) returned error: %v, want %v", err, domain.ErrTransferReviewNotFound)
+	}
+}
+
+func TestReviewedScheduledRun(t *testing.T) {
+	db := integrationtest.SetupDB(t, dbDriver, dbSource)
+	transferRepo := transferrepo.NewRepoPGS(db)
+	scheduleRepo := schedulerepo.NewRepoPGS(db)
+
+	payer := helpers.SeedUser(t, db)
+	account := helpers.SeedAccountWith1000USDBalance(t, db, payer.Username)
+	toAccount := helpers.SeedAccountWith1000USDBalance(t, db, helpers.SeedUser(t, db).Username)
+
+	st, err := scheduleRepo.Create(ctx, domain.CreateScheduledTransferParams{
+		Username:      payer.Username,
+		FromAccountID: account.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        "100",
+		StartAt:       time.Now().Add(time.Hour),
+		NextRunAt:     time.Now().Add(time.Hour),
+		Recurrence:    "@monthly",
+	})
+	if err != nil {
+		t.Fatalf("scheduleRepo.Create(ctx, ...) returned error: %v", err)
+	}
+
+	// heldRun starts the run at scheduledAt and holds its transfer for review.
+	heldRun := func(t *testing.T, scheduledAt time.Time) (domain.ScheduledTransferRun, int64) {
+		t.Helper()
+
+		run, err := scheduleRepo.CreateRun(ctx, st.ID, scheduledAt)
+		if err != nil {
+			t.Fatalf("scheduleRepo.CreateRun(ctx, %v, %v) returned error: %v", st.ID, scheduledAt, err)
+		}
+
+		arg := domain.CreateTransferParams{FromAccountID: account.ID, ToAccountID: toAccount.ID, Amount: "100"}
+
+		result, err := transferRepo.CreateReview(ctx, payer.Username, arg, []string{"new_payees"})
+		if err != nil {
+			t.Fatalf("transferRepo.CreateReview(ctx, %v, %+v) returned error: %v", payer.Username, arg, err)
+		}
+
+		finish := domain.FinishScheduledTransferRunParams{
+			ID:               run.ID,
+			Status:           domain.ScheduledRunStatusPendingReview,
+			TransferReviewID: result.Review.ID,
+		}
+
+		run, err = scheduleRepo.FinishRun(ctx, finish)
+		if err != nil {
+			t.Fatalf("scheduleRepo.FinishRun(ctx, %+v) returned error: %v", finish, err)
+		}
+
+		if run.Status != domain.ScheduledRunStatusPendingReview || run.TransferReviewID != result.Review.ID || run.FinishedAt != nil {
+			t.Errorf("scheduleRepo.FinishRun(ctx, %+v) returned %+v", finish, run)
+		}
+
+		return run, result.Review.ID
+	}
+
+	getRun := func(t *testing.T, id int64) domain.ScheduledTransferRun {
+		t.Helper()
+
+		runs, err := scheduleRepo.ListRuns(ctx, domain.ListScheduledTransferRunsParams{ScheduledTransferID: st.ID, Limit: 10})
+		if err != nil {
+			t.Fatalf("scheduleRepo.ListRuns(ctx, %v) returned error: %v", st.ID, err)
+		}
+
+		for _, run := range runs {
+			if run.ID == id {
+				return run
+			}
+		}
+
+		t.Fatalf("scheduleRepo.ListRuns(ctx, %v) = %+v, want run %v", st.ID, runs, id)
+
+		return domain.ScheduledTransferRun{}
+	}
+
+	approvedRun, approvedID := heldRun(t, time.Now().Add(-time.Hour))
+
+	approved, err := transferRepo.ApproveReview(ctx, "admin", approvedID)
+	if err != nil {
+		t.Fatalf("transferRepo.ApproveReview(ctx, admin, %v) returned error: %v", approvedID, err)
+	}
+
+	got := getRun(t, approvedRun.ID)
+	if got.Status != domain.ScheduledRunStatusSucceeded || got.TransferID != approved.Transfer.Transfer.ID || got.FinishedAt == nil {
+		t.Errorf("run of the approved review = %+v, want succeeded with transfer %v", got, approved.Transfer.Transfer.ID)
+	}
+
+	rejectedRun, rejectedID := heldRun(t, time.Now().Add(-time.Minute))
+
+	if _, err := transferRepo.RejectReview(ctx, "admin", rejectedID); err != nil {
+		t.Fatalf("transferRepo.RejectReview(ctx, admin, %v) returned error: %v", rejectedID, err)
+	}
+
+	got = getRun(t, rejectedRun.ID)
+	if got.Status != domain.ScheduledRunStatusFailed || got.Error != domain.ErrTransferReviewRejected.Error() || got.TransferID != 0 {
+		t.Errorf("run of the rejected review = %+v, want failed with %v", got, domain.ErrTransferReviewRejected)
+	}
+}
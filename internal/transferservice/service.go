@@ -4,8 +4,8 @@ package transferservice
 import (
 	"context"
 
-	"github.com/go-petr/pet-bank/internal/accountdelivery"
 	"github.com/go-petr/pet-bank/internal/domain"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
 	"github.com/rs/zerolog"
 	"github.com/shopspring/decimal"
 )
@@ -14,24 +14,87 @@ import (
 //
 //go:generate mockgen -source service.go -destination service_mock.go -package transferservice
 type Repo interface {
-	Transfer(ctx context.Context, arg domain.CreateTransferParams) (domain.TransferTxResult, error)
+	Transfer(ctx context.Context, fromUsername string, arg domain.CreateTransferParams) (domain.TransferTxResult, error)
+	Deposit(ctx context.Context, arg domain.CreateCashParams) (domain.TransferTxResult, error)
+	Withdraw(ctx context.Context, arg domain.CreateCashParams) (domain.TransferTxResult, error)
+	GetIdempotencyKey(ctx context.Context, username, key string) (domain.IdempotencyKey, error)
+	CreateIdempotencyKey(ctx context.Context, arg domain.CreateIdempotencyKeyParams) (domain.IdempotencyKey, error)
+	Get(ctx context.Context, id int64) (domain.Transfer, error)
+	List(ctx context.Context, arg domain.ListTransfersParams) ([]domain.Transfer, error)
+	Reverse(ctx context.Context, arg domain.ReverseTransferParams) (domain.ReversalTxResult, error)
+	CreateReview(ctx context.Context, fromUsername string, arg domain.CreateTransferParams, rules []string) (domain.TransferTxResult, error)
+	ApproveReview(ctx context.Context, reviewer string, id int64) (domain.ApproveTransferReviewResult, error)
+	RejectReview(ctx context.Context, reviewer string, id int64) (domain.TransferReview, error)
+	GetReview(ctx context.Context, id int64) (domain.TransferReview, error)
+	ListReviews(ctx context.Context, arg domain.ListTransferReviewsParams) ([]domain.TransferReview, error)
+}
+
+// AccountRepo provides account data access needed to check transfers ownership.
+type AccountRepo interface {
+	Get(ctx context.Context, id int32) (domain.Account, error)
+}
+
+// Resolver resolves the transfer recipient to its account.
+type Resolver interface {
+	Resolve(ctx context.Context, recipient domain.Recipient) (domain.Account, error)
+}
+
+// Payees provides the sender's payees to pay.
+type Payees interface {
+	GetUsable(ctx context.Context, username string, id int64) (domain.Payee, error)
+	MarkUsed(ctx context.Context, id int64) error
+}
+
+// Limits provides the sender's outgoing transfer limits.
+type Limits interface {
+	Get(ctx context.Context, username, currency string) (domain.TransferLimit, error)
+}
+
+// Monitor assesses the transfers with the transaction monitoring rules.
+type Monitor interface {
+	Assess(ctx context.Context, check domain.RiskCheck) (domain.RiskAssessment, error)
+}
+
+// Screener screens the transfer recipients against the sanctions list.
+type Screener interface {
+	ScreenRecipient(ctx context.Context, fromUsername string, toAccountID int32) error
+}
+
+// Auditor records audit events of the transfers.
+type Auditor interface {
+	Record(ctx context.Context, eventType, actor string, before, after any)
 }
 
 // Service facilitates transfer service layer logic.
 type Service struct {
-	repo           Repo
-	accountService accountdelivery.Service
+	repo        Repo
+	accountRepo AccountRepo
+	resolver    Resolver
+	payees      Payees
+	limits      Limits
+	monitor     Monitor
+	screener    Screener
+	auditor     Auditor
 }
 
 // New return transfer service struct to manage transfer bussines logic.
-func New(tr Repo, as accountdelivery.Service) *Service {
+// Transfer limits are not checked if lr is nil, transfers are not monitored if
+// m is nil, recipients are not screened if sc is nil and audit events are not
+// recorded if a is nil.
+func New(tr Repo, ar AccountRepo, rr Resolver, pr Payees, lr Limits, m Monitor, sc Screener, a Auditor) *Service {
 	return &Service{
-		repo:           tr,
-		accountService: as,
+		repo:        tr,
+		accountRepo: ar,
+		resolver:    rr,
+		payees:      pr,
+		limits:      lr,
+		monitor:     m,
+		screener:    sc,
+		auditor:     a,
 	}
 }
 
-func (s *Service) validRequest(ctx context.Context, fromUsername string, fromAccountID, toAccountID int32, amount string) error {
+func validAmount(ctx context.Context, amount string) error {
 	l := zerolog.Ctx(ctx)
 
 	amountDecimal, err := decimal.NewFromString(amount)
@@ -41,54 +104,448 @@ func (s *Service) validRequest(ctx context.Context, fromUsername string, fromAcc
 	}
 
 	if amountDecimal.LessThanOrEqual(decimal.Zero) {
-		l.Info().Err(err).Send()
+		l.Info().Err(domain.ErrNegativeAmount).Send()
 		return domain.ErrNegativeAmount
 	}
 
-	fromAccount, err := s.accountService.Get(ctx, fromAccountID)
+	return nil
+}
+
+// Transfer checks if a transfer amount is valid and then executes transfer.
+//
+// If arg.ToAccountID is zero, the to account is the account of the sender's
+// payee arg.PayeeID, or arg.Recipient is resolved to the recipient's account in
+// its currency, the from account currency by default.
+//
+// Ownership, currency, balance and the sender's transfer limits checks are done
+// by the repo on locked accounts.
+//
+// Transfers to the recipients matching the sanctions list are rejected with
+// domain.ErrSanctionsHit. The transfer flagged by the transaction monitoring
+// rules is either blocked or held for review, in which case only the review
+// and the accounts of the result are set.
+func (s Service) Transfer(ctx context.Context, fromUsername string, arg domain.CreateTransferParams) (domain.TransferTxResult, error) {
+	if err := validAmount(ctx, arg.Amount); err != nil {
+		return domain.TransferTxResult{}, err
+	}
+
+	if s.limits != nil {
+		limit, err := s.limit(ctx, fromUsername, arg.FromAccountID)
+		if err != nil {
+			return domain.TransferTxResult{}, err
+		}
+
+		arg.Limit = &limit
+	}
+
+	var payeeID int64
+
+	switch {
+	case arg.ToAccountID != 0:
+	case arg.PayeeID != 0:
+		payee, err := s.payees.GetUsable(ctx, fromUsername, arg.PayeeID)
+		if err != nil {
+			return domain.TransferTxResult{}, err
+		}
+
+		arg.ToAccountID = payee.AccountID
+		payeeID = payee.ID
+	case arg.Recipient != nil:
+		toAccountID, err := s.resolveRecipient(ctx, fromUsername, arg.FromAccountID, *arg.Recipient)
+		if err != nil {
+			return domain.TransferTxResult{}, err
+		}
+
+		arg.ToAccountID = toAccountID
+	}
+
+	if s.screener != nil {
+		if err := s.screener.ScreenRecipient(ctx, fromUsername, arg.ToAccountID); err != nil {
+			return domain.TransferTxResult{}, err
+		}
+	}
+
+	if s.monitor != nil {
+		result, held, err := s.assess(ctx, fromUsername, arg)
+		if err != nil || held {
+			return result, err
+		}
+	}
+
+	result, err := s.repo.Transfer(ctx, fromUsername, arg)
 	if err != nil {
-		l.Error().Err(err).Send()
-		return err
+		return result, err
 	}
 
-	if fromAccount.Owner != fromUsername {
-		l.Info().Err(err).Send()
-		return domain.ErrInvalidOwner
+	// The transfer is done even if the payee last used time is not updated.
+	if payeeID != 0 {
+		if err := s.payees.MarkUsed(ctx, payeeID); err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Int64("payee_id", payeeID).Send()
+		}
+	}
+
+	if s.auditor != nil {
+		s.auditor.Record(ctx, domain.AuditTransferCreated, fromUsername, accountsBefore(result), result)
+	}
+
+	return result, nil
+}
+
+// riskEvent is the audit snapshot of the transfer flagged by the transaction
+// monitoring rules.
+type riskEvent struct {
+	Check      domain.RiskCheck      `json:"check"`
+	Assessment domain.RiskAssessment `json:"assessment"`
+}
+
+// assess checks the transfer with the transaction monitoring rules. It holds
+// the transfer for review and reports it held or returns ErrTransferBlocked if
+// the transfer is flagged.
+func (s Service) assess(ctx context.Context, fromUsername string, arg domain.CreateTransferParams) (domain.TransferTxResult, bool, error) {
+	l := zerolog.Ctx(ctx)
+
+	check := domain.RiskCheck{
+		Username:      fromUsername,
+		SessionID:     arg.SessionID,
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		Limit:         arg.Limit,
 	}
 
-	currentFromAccountBalance, err := decimal.NewFromString(fromAccount.Balance)
+	assessment, err := s.monitor.Assess(ctx, check)
 	if err != nil {
-		l.Error().Err(err).Send()
-		return err
+		return domain.TransferTxResult{}, false, err
+	}
+
+	// The FX quote would expire before the review, so conversions are blocked.
+	switch {
+	case assessment.Decision == domain.RiskDecisionAllow:
+		return domain.TransferTxResult{}, false, nil
+	case assessment.Decision == domain.RiskDecisionReview && arg.FXQuoteID == nil:
+		result, err := s.repo.CreateReview(ctx, fromUsername, arg, assessment.Rules)
+		if err != nil {
+			return result, false, err
+		}
+
+		l.Info().Int64("transfer_review_id", result.Review.ID).Strs("rules", assessment.Rules).Msg("Transfer held for review")
+
+		if s.auditor != nil {
+			s.auditor.Record(ctx, domain.AuditTransferReviewCreated, fromUsername, nil, result.Review)
+		}
+
+		return result, true, nil
 	}
 
-	if currentFromAccountBalance.LessThan(amountDecimal) {
-		return domain.ErrInsufficientBalance
+	l.Warn().Err(domain.ErrTransferBlocked).Strs("rules", assessment.Rules).Send()
+
+	if s.auditor != nil {
+		s.auditor.Record(ctx, domain.AuditTransferBlocked, fromUsername, nil, riskEvent{Check: check, Assessment: assessment})
 	}
 
-	toAccount, err := s.accountService.Get(ctx, toAccountID)
+	return domain.TransferTxResult{}, false, domain.ErrTransferBlocked
+}
+
+// ApproveReview executes the transfer held for review. reviewer is the staff
+// member who approved it.
+func (s Service) ApproveReview(ctx context.Context, reviewer string, id int64) (domain.ApproveTransferReviewResult, error) {
+	result, err := s.repo.ApproveReview(ctx, reviewer, id)
 	if err != nil {
-		l.Info().Err(err).Send()
-		return err
+		return result, err
 	}
 
-	if fromAccount.Currency != toAccount.Currency {
-		return domain.ErrCurrencyMismatch
+	if s.auditor != nil {
+		s.auditor.Record(ctx, domain.AuditTransferReviewApproved, reviewer, nil, result)
 	}
 
-	return nil
+	return result, nil
 }
 
-// Transfer checks if a transfer request is valid and then executes transfer.
-func (s Service) Transfer(ctx context.Context, fromUsername string, arg domain.CreateTransferParams) (domain.TransferTxResult, error) {
-	if err := s.validRequest(ctx, fromUsername, arg.FromAccountID, arg.ToAccountID, arg.Amount); err != nil {
+// RejectReview releases the amount of the transfer held for review without
+// executing it. reviewer is the staff member who rejected it.
+func (s Service) RejectReview(ctx context.Context, reviewer string, id int64) (domain.TransferReview, error) {
+	review, err := s.repo.RejectReview(ctx, reviewer, id)
+	if err != nil {
+		return review, err
+	}
+
+	if s.auditor != nil {
+		s.auditor.Record(ctx, domain.AuditTransferReviewRejected, reviewer, nil, review)
+	}
+
+	return review, nil
+}
+
+// GetReview returns the transfer review with the given id.
+func (s Service) GetReview(ctx context.Context, id int64) (domain.TransferReview, error) {
+	return s.repo.GetReview(ctx, id)
+}
+
+// ListReviews returns the page of the transfer reviews with the given status,
+// or all of them if it is empty.
+func (s Service) ListReviews(ctx context.Context, status string, page pagepkg.Request) ([]domain.TransferReview, pagepkg.Page, error) {
+	reviews, err := s.repo.ListReviews(ctx, domain.ListTransferReviewsParams{
+		Status:   status,
+		AfterID:  page.Cursor.AfterID,
+		BeforeID: page.Cursor.BeforeID,
+		Limit:    page.Limit(),
+		Offset:   page.Offset(),
+	})
+	if err != nil {
+		return nil, pagepkg.Page{}, err
+	}
+
+	reviews, p := pagepkg.Trim(reviews, page, func(r domain.TransferReview) int64 { return r.ID })
+
+	return reviews, p, nil
+}
+
+// limit returns the sender's transfer limits in the currency of the from account.
+func (s Service) limit(ctx context.Context, fromUsername string, fromAccountID int32) (domain.TransferLimit, error) {
+	fromAccount, err := s.accountRepo.Get(ctx, fromAccountID)
+	if err != nil {
+		return domain.TransferLimit{}, err
+	}
+
+	return s.limits.Get(ctx, fromUsername, fromAccount.Currency)
+}
+
+// resolveRecipient returns the recipient's account id in the recipient
+// currency, or in the currency of the sender's from account if it is empty.
+func (s Service) resolveRecipient(ctx context.Context, fromUsername string, fromAccountID int32, recipient domain.Recipient) (int32, error) {
+	l := zerolog.Ctx(ctx)
+
+	if recipient.Currency == "" {
+		fromAccount, err := s.accountRepo.Get(ctx, fromAccountID)
+		if err != nil {
+			return 0, err
+		}
+
+		if fromAccount.Owner != fromUsername {
+			l.Warn().Err(domain.ErrInvalidOwner).Send()
+			return 0, domain.ErrInvalidOwner
+		}
+
+		recipient.Currency = fromAccount.Currency
+	}
+
+	toAccount, err := s.resolver.Resolve(ctx, recipient)
+	if err != nil {
+		return 0, err
+	}
+
+	return toAccount.ID, nil
+}
+
+// Deposit checks if the amount is valid and then credits the account from the
+// settlement account of its currency. actor is the integration or the staff
+// member who made the deposit.
+func (s Service) Deposit(ctx context.Context, actor string, arg domain.CreateCashParams) (domain.TransferTxResult, error) {
+	if err := validAmount(ctx, arg.Amount); err != nil {
 		return domain.TransferTxResult{}, err
 	}
 
-	result, err := s.repo.Transfer(ctx, arg)
+	result, err := s.repo.Deposit(ctx, arg)
 	if err != nil {
 		return result, err
 	}
 
+	if s.auditor != nil {
+		s.auditor.Record(ctx, domain.AuditDepositCreated, actor, accountsBefore(result), result)
+	}
+
 	return result, nil
 }
+
+// Withdraw checks if the amount is valid and then debits the account to the
+// settlement account of its currency. actor is the integration or the staff
+// member who made the withdrawal.
+func (s Service) Withdraw(ctx context.Context, actor string, arg domain.CreateCashParams) (domain.TransferTxResult, error) {
+	if err := validAmount(ctx, arg.Amount); err != nil {
+		return domain.TransferTxResult{}, err
+	}
+
+	result, err := s.repo.Withdraw(ctx, arg)
+	if err != nil {
+		return result, err
+	}
+
+	if s.auditor != nil {
+		s.auditor.Record(ctx, domain.AuditWithdrawalCreated, actor, accountsBefore(result), result)
+	}
+
+	return result, nil
+}
+
+// Reverse refunds the transfer, or arg.Amount of it, back to the sender.
+//
+// Only the recipient, the owner of the to account, can reverse the transfer,
+// unless asAdmin is set. The amount left to refund and the recipient balance
+// are checked by the repo on the locked transfer and accounts.
+func (s Service) Reverse(ctx context.Context, actor string, asAdmin bool, arg domain.ReverseTransferParams) (domain.ReversalTxResult, error) {
+	l := zerolog.Ctx(ctx)
+
+	if arg.Amount != "" {
+		if err := validAmount(ctx, arg.Amount); err != nil {
+			return domain.ReversalTxResult{}, err
+		}
+	}
+
+	if !asAdmin {
+		transfer, err := s.repo.Get(ctx, arg.TransferID)
+		if err != nil {
+			return domain.ReversalTxResult{}, err
+		}
+
+		account, err := s.accountRepo.Get(ctx, transfer.ToAccountID)
+		if err != nil {
+			return domain.ReversalTxResult{}, err
+		}
+
+		if account.Owner != actor {
+			l.Warn().Err(domain.ErrReversalNotAllowed).Send()
+			return domain.ReversalTxResult{}, domain.ErrReversalNotAllowed
+		}
+	}
+
+	result, err := s.repo.Reverse(ctx, arg)
+	if err != nil {
+		return result, err
+	}
+
+	if s.auditor != nil {
+		s.auditor.Record(ctx, domain.AuditTransferReversed, actor, accountsBefore(result.Reversal), result)
+	}
+
+	return result, nil
+}
+
+// transferAccounts is the audit snapshot of the transfer accounts.
+type transferAccounts struct {
+	FromAccount domain.Account `json:"from_account"`
+	ToAccount   domain.Account `json:"to_account"`
+}
+
+// accountsBefore returns the transfer accounts with their balances before the
+// transfer entries.
+func accountsBefore(result domain.TransferTxResult) transferAccounts {
+	return transferAccounts{
+		FromAccount: balanceBefore(result.FromAccount, result.FromEntry),
+		ToAccount:   balanceBefore(result.ToAccount, result.ToEntry),
+	}
+}
+
+func balanceBefore(account domain.Account, entry domain.Entry) domain.Account {
+	balance, err := decimal.NewFromString(account.Balance)
+	if err != nil {
+		return account
+	}
+
+	amount, err := decimal.NewFromString(entry.Amount)
+	if err != nil {
+		return account
+	}
+
+	account.Balance = balance.Sub(amount).String()
+
+	return account
+}
+
+// GetIdempotencyKey returns the transfer result stored under the given user's idempotency key.
+func (s *Service) GetIdempotencyKey(ctx context.Context, username, key string) (domain.IdempotencyKey, error) {
+	return s.repo.GetIdempotencyKey(ctx, username, key)
+}
+
+// CreateIdempotencyKey stores the failed result of the transfer request under
+// the idempotency key. The successful results are stored by the transfer
+// transaction itself.
+func (s *Service) CreateIdempotencyKey(ctx context.Context, arg domain.CreateIdempotencyKeyParams) (domain.IdempotencyKey, error) {
+	return s.repo.CreateIdempotencyKey(ctx, arg)
+}
+
+// Get returns the transfer if it is from or to one of the user's accounts.
+func (s *Service) Get(ctx context.Context, username string, id int64) (domain.Transfer, error) {
+	transfer, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return transfer, err
+	}
+
+	for _, accountID := range []int32{transfer.FromAccountID, transfer.ToAccountID} {
+		account, err := s.accountRepo.Get(ctx, accountID)
+		if err != nil {
+			return domain.Transfer{}, err
+		}
+
+		if account.Owner == username {
+			return transfer, nil
+		}
+	}
+
+	return domain.Transfer{}, domain.ErrTransferOwnerMismatch
+}
+
+// List returns the page of the user's transfers matching the filters.
+//
+// If arg.AccountID is set, the account must be owned by arg.Username.
+func (s *Service) List(ctx context.Context, arg domain.ListTransfersParams, page pagepkg.Request) ([]domain.Transfer, pagepkg.Page, error) {
+	l := zerolog.Ctx(ctx)
+
+	if arg.AccountID != 0 {
+		account, err := s.accountRepo.Get(ctx, arg.AccountID)
+		if err != nil {
+			return nil, pagepkg.Page{}, err
+		}
+
+		if account.Owner != arg.Username {
+			l.Warn().Err(domain.ErrAccountOwnerMismatch).Send()
+			return nil, pagepkg.Page{}, domain.ErrAccountOwnerMismatch
+		}
+	}
+
+	if err := validAmountRange(ctx, arg.MinAmount, arg.MaxAmount); err != nil {
+		return nil, pagepkg.Page{}, err
+	}
+
+	if !arg.StartTime.IsZero() && !arg.EndTime.IsZero() && arg.EndTime.Before(arg.StartTime) {
+		l.Info().Err(domain.ErrInvalidDateRange).Send()
+		return nil, pagepkg.Page{}, domain.ErrInvalidDateRange
+	}
+
+	arg.AfterID = page.Cursor.AfterID
+	arg.BeforeID = page.Cursor.BeforeID
+	arg.Limit = page.Limit()
+	arg.Offset = page.Offset()
+
+	transfers, err := s.repo.List(ctx, arg)
+	if err != nil {
+		return nil, pagepkg.Page{}, err
+	}
+
+	transfers, p := pagepkg.Trim(transfers, page, func(t domain.Transfer) int64 { return t.ID })
+
+	return transfers, p, nil
+}
+
+// validAmountRange checks the optional amount filters.
+func validAmountRange(ctx context.Context, minAmount, maxAmount string) error {
+	for _, amount := range []string{minAmount, maxAmount} {
+		if amount == "" {
+			continue
+		}
+
+		if err := validAmount(ctx, amount); err != nil {
+			return err
+		}
+	}
+
+	if minAmount == "" || maxAmount == "" {
+		return nil
+	}
+
+	if decimal.RequireFromString(maxAmount).LessThan(decimal.RequireFromString(minAmount)) {
+		zerolog.Ctx(ctx).Info().Err(domain.ErrInvalidAmount).Send()
+		return domain.ErrInvalidAmount
+	}
+
+	return nil
+}
@@ -35,17 +35,473 @@ func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
 	return m.recorder
 }
 
+// ApproveReview mocks base method.
+func (m *MockRepo) ApproveReview(ctx context.Context, reviewer string, id int64) (domain.ApproveTransferReviewResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveReview", ctx, reviewer, id)
+	ret0, _ := ret[0].(domain.ApproveTransferReviewResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveReview indicates an expected call of ApproveReview.
+func (mr *MockRepoMockRecorder) ApproveReview(ctx, reviewer, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveReview", reflect.TypeOf((*MockRepo)(nil).ApproveReview), ctx, reviewer, id)
+}
+
+// CreateIdempotencyKey mocks base method.
+func (m *MockRepo) CreateIdempotencyKey(ctx context.Context, arg domain.CreateIdempotencyKeyParams) (domain.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIdempotencyKey", ctx, arg)
+	ret0, _ := ret[0].(domain.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIdempotencyKey indicates an expected call of CreateIdempotencyKey.
+func (mr *MockRepoMockRecorder) CreateIdempotencyKey(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIdempotencyKey", reflect.TypeOf((*MockRepo)(nil).CreateIdempotencyKey), ctx, arg)
+}
+
+// CreateReview mocks base method.
+func (m *MockRepo) CreateReview(ctx context.Context, fromUsername string, arg domain.CreateTransferParams, rules []string) (domain.TransferTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReview", ctx, fromUsername, arg, rules)
+	ret0, _ := ret[0].(domain.TransferTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReview indicates an expected call of CreateReview.
+func (mr *MockRepoMockRecorder) CreateReview(ctx, fromUsername, arg, rules interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*MockRepo)(nil).CreateReview), ctx, fromUsername, arg, rules)
+}
+
+// Deposit mocks base method.
+func (m *MockRepo) Deposit(ctx context.Context, arg domain.CreateCashParams) (domain.TransferTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deposit", ctx, arg)
+	ret0, _ := ret[0].(domain.TransferTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deposit indicates an expected call of Deposit.
+func (mr *MockRepoMockRecorder) Deposit(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deposit", reflect.TypeOf((*MockRepo)(nil).Deposit), ctx, arg)
+}
+
+// Get mocks base method.
+func (m *MockRepo) Get(ctx context.Context, id int64) (domain.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(domain.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepoMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepo)(nil).Get), ctx, id)
+}
+
+// GetIdempotencyKey mocks base method.
+func (m *MockRepo) GetIdempotencyKey(ctx context.Context, username, key string) (domain.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdempotencyKey", ctx, username, key)
+	ret0, _ := ret[0].(domain.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdempotencyKey indicates an expected call of GetIdempotencyKey.
+func (mr *MockRepoMockRecorder) GetIdempotencyKey(ctx, username, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdempotencyKey", reflect.TypeOf((*MockRepo)(nil).GetIdempotencyKey), ctx, username, key)
+}
+
+// GetReview mocks base method.
+func (m *MockRepo) GetReview(ctx context.Context, id int64) (domain.TransferReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReview", ctx, id)
+	ret0, _ := ret[0].(domain.TransferReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReview indicates an expected call of GetReview.
+func (mr *MockRepoMockRecorder) GetReview(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReview", reflect.TypeOf((*MockRepo)(nil).GetReview), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockRepo) List(ctx context.Context, arg domain.ListTransfersParams) ([]domain.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, arg)
+	ret0, _ := ret[0].([]domain.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepoMockRecorder) List(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepo)(nil).List), ctx, arg)
+}
+
+// ListReviews mocks base method.
+func (m *MockRepo) ListReviews(ctx context.Context, arg domain.ListTransferReviewsParams) ([]domain.TransferReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReviews", ctx, arg)
+	ret0, _ := ret[0].([]domain.TransferReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReviews indicates an expected call of ListReviews.
+func (mr *MockRepoMockRecorder) ListReviews(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviews", reflect.TypeOf((*MockRepo)(nil).ListReviews), ctx, arg)
+}
+
+// RejectReview mocks base method.
+func (m *MockRepo) RejectReview(ctx context.Context, reviewer string, id int64) (domain.TransferReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectReview", ctx, reviewer, id)
+	ret0, _ := ret[0].(domain.TransferReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectReview indicates an expected call of RejectReview.
+func (mr *MockRepoMockRecorder) RejectReview(ctx, reviewer, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectReview", reflect.TypeOf((*MockRepo)(nil).RejectReview), ctx, reviewer, id)
+}
+
+// Reverse mocks base method.
+func (m *MockRepo) Reverse(ctx context.Context, arg domain.ReverseTransferParams) (domain.ReversalTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reverse", ctx, arg)
+	ret0, _ := ret[0].(domain.ReversalTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reverse indicates an expected call of Reverse.
+func (mr *MockRepoMockRecorder) Reverse(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reverse", reflect.TypeOf((*MockRepo)(nil).Reverse), ctx, arg)
+}
+
 // Transfer mocks base method.
-func (m *MockRepo) Transfer(ctx context.Context, arg domain.CreateTransferParams) (domain.TransferTxResult, error) {
+func (m *MockRepo) Transfer(ctx context.Context, fromUsername string, arg domain.CreateTransferParams) (domain.TransferTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Transfer", ctx, arg)
+	ret := m.ctrl.Call(m, "Transfer", ctx, fromUsername, arg)
 	ret0, _ := ret[0].(domain.TransferTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Transfer indicates an expected call of Transfer.
-func (mr *MockRepoMockRecorder) Transfer(ctx, arg interface{}) *gomock.Call {
+func (mr *MockRepoMockRecorder) Transfer(ctx, fromUsername, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockRepo)(nil).Transfer), ctx, fromUsername, arg)
+}
+
+// Withdraw mocks base method.
+func (m *MockRepo) Withdraw(ctx context.Context, arg domain.CreateCashParams) (domain.TransferTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Withdraw", ctx, arg)
+	ret0, _ := ret[0].(domain.TransferTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Withdraw indicates an expected call of Withdraw.
+func (mr *MockRepoMockRecorder) Withdraw(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockRepo)(nil).Withdraw), ctx, arg)
+}
+
+// MockAccountRepo is a mock of AccountRepo interface.
+type MockAccountRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountRepoMockRecorder
+}
+
+// MockAccountRepoMockRecorder is the mock recorder for MockAccountRepo.
+type MockAccountRepoMockRecorder struct {
+	mock *MockAccountRepo
+}
+
+// NewMockAccountRepo creates a new mock instance.
+func NewMockAccountRepo(ctrl *gomock.Controller) *MockAccountRepo {
+	mock := &MockAccountRepo{ctrl: ctrl}
+	mock.recorder = &MockAccountRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountRepo) EXPECT() *MockAccountRepoMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockAccountRepo) Get(ctx context.Context, id int32) (domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAccountRepoMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAccountRepo)(nil).Get), ctx, id)
+}
+
+// MockResolver is a mock of Resolver interface.
+type MockResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockResolverMockRecorder
+}
+
+// MockResolverMockRecorder is the mock recorder for MockResolver.
+type MockResolverMockRecorder struct {
+	mock *MockResolver
+}
+
+// NewMockResolver creates a new mock instance.
+func NewMockResolver(ctrl *gomock.Controller) *MockResolver {
+	mock := &MockResolver{ctrl: ctrl}
+	mock.recorder = &MockResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResolver) EXPECT() *MockResolverMockRecorder {
+	return m.recorder
+}
+
+// Resolve mocks base method.
+func (m *MockResolver) Resolve(ctx context.Context, recipient domain.Recipient) (domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, recipient)
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockResolverMockRecorder) Resolve(ctx, recipient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockResolver)(nil).Resolve), ctx, recipient)
+}
+
+// MockPayees is a mock of Payees interface.
+type MockPayees struct {
+	ctrl     *gomock.Controller
+	recorder *MockPayeesMockRecorder
+}
+
+// MockPayeesMockRecorder is the mock recorder for MockPayees.
+type MockPayeesMockRecorder struct {
+	mock *MockPayees
+}
+
+// NewMockPayees creates a new mock instance.
+func NewMockPayees(ctrl *gomock.Controller) *MockPayees {
+	mock := &MockPayees{ctrl: ctrl}
+	mock.recorder = &MockPayeesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPayees) EXPECT() *MockPayeesMockRecorder {
+	return m.recorder
+}
+
+// GetUsable mocks base method.
+func (m *MockPayees) GetUsable(ctx context.Context, username string, id int64) (domain.Payee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsable", ctx, username, id)
+	ret0, _ := ret[0].(domain.Payee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsable indicates an expected call of GetUsable.
+func (mr *MockPayeesMockRecorder) GetUsable(ctx, username, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsable", reflect.TypeOf((*MockPayees)(nil).GetUsable), ctx, username, id)
+}
+
+// MarkUsed mocks base method.
+func (m *MockPayees) MarkUsed(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockPayeesMockRecorder) MarkUsed(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockPayees)(nil).MarkUsed), ctx, id)
+}
+
+// MockLimits is a mock of Limits interface.
+type MockLimits struct {
+	ctrl     *gomock.Controller
+	recorder *MockLimitsMockRecorder
+}
+
+// MockLimitsMockRecorder is the mock recorder for MockLimits.
+type MockLimitsMockRecorder struct {
+	mock *MockLimits
+}
+
+// NewMockLimits creates a new mock instance.
+func NewMockLimits(ctrl *gomock.Controller) *MockLimits {
+	mock := &MockLimits{ctrl: ctrl}
+	mock.recorder = &MockLimitsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLimits) EXPECT() *MockLimitsMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockLimits) Get(ctx context.Context, username, currency string) (domain.TransferLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, username, currency)
+	ret0, _ := ret[0].(domain.TransferLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockLimitsMockRecorder) Get(ctx, username, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockLimits)(nil).Get), ctx, username, currency)
+}
+
+// MockMonitor is a mock of Monitor interface.
+type MockMonitor struct {
+	ctrl     *gomock.Controller
+	recorder *MockMonitorMockRecorder
+}
+
+// MockMonitorMockRecorder is the mock recorder for MockMonitor.
+type MockMonitorMockRecorder struct {
+	mock *MockMonitor
+}
+
+// NewMockMonitor creates a new mock instance.
+func NewMockMonitor(ctrl *gomock.Controller) *MockMonitor {
+	mock := &MockMonitor{ctrl: ctrl}
+	mock.recorder = &MockMonitorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMonitor) EXPECT() *MockMonitorMockRecorder {
+	return m.recorder
+}
+
+// Assess mocks base method.
+func (m *MockMonitor) Assess(ctx context.Context, check domain.RiskCheck) (domain.RiskAssessment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assess", ctx, check)
+	ret0, _ := ret[0].(domain.RiskAssessment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Assess indicates an expected call of Assess.
+func (mr *MockMonitorMockRecorder) Assess(ctx, check interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assess", reflect.TypeOf((*MockMonitor)(nil).Assess), ctx, check)
+}
+
+// MockScreener is a mock of Screener interface.
+type MockScreener struct {
+	ctrl     *gomock.Controller
+	recorder *MockScreenerMockRecorder
+}
+
+// MockScreenerMockRecorder is the mock recorder for MockScreener.
+type MockScreenerMockRecorder struct {
+	mock *MockScreener
+}
+
+// NewMockScreener creates a new mock instance.
+func NewMockScreener(ctrl *gomock.Controller) *MockScreener {
+	mock := &MockScreener{ctrl: ctrl}
+	mock.recorder = &MockScreenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScreener) EXPECT() *MockScreenerMockRecorder {
+	return m.recorder
+}
+
+// ScreenRecipient mocks base method.
+func (m *MockScreener) ScreenRecipient(ctx context.Context, fromUsername string, toAccountID int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScreenRecipient", ctx, fromUsername, toAccountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScreenRecipient indicates an expected call of ScreenRecipient.
+func (mr *MockScreenerMockRecorder) ScreenRecipient(ctx, fromUsername, toAccountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScreenRecipient", reflect.TypeOf((*MockScreener)(nil).ScreenRecipient), ctx, fromUsername, toAccountID)
+}
+
+// MockAuditor is a mock of Auditor interface.
+type MockAuditor struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditorMockRecorder
+}
+
+// MockAuditorMockRecorder is the mock recorder for MockAuditor.
+type MockAuditorMockRecorder struct {
+	mock *MockAuditor
+}
+
+// NewMockAuditor creates a new mock instance.
+func NewMockAuditor(ctrl *gomock.Controller) *MockAuditor {
+	mock := &MockAuditor{ctrl: ctrl}
+	mock.recorder = &MockAuditorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditor) EXPECT() *MockAuditorMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockAuditor) Record(ctx context.Context, eventType, actor string, before, after any) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", ctx, eventType, actor, before, after)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAuditorMockRecorder) Record(ctx, eventType, actor, before, after interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockRepo)(nil).Transfer), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAuditor)(nil).Record), ctx, eventType, actor, before, after)
 }
@@ -2,17 +2,17 @@ package transferservice
 
 import (
 	"context"
-	"fmt"
 	"testing"
 	"time"
 
-	"github.com/go-petr/pet-bank/internal/accountdelivery"
 	"github.com/go-petr/pet-bank/internal/domain"
 	"github.com/go-petr/pet-bank/pkg/currencypkg"
 	"github.com/go-petr/pet-bank/pkg/errorspkg"
+	"github.com/go-petr/pet-bank/pkg/pagepkg"
 	"github.com/go-petr/pet-bank/pkg/randompkg"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
 )
 
 func randomAccount(id int32, balance, currency string) domain.Account {
@@ -51,7 +51,7 @@ func TestTransfer(t *testing.T) {
 	testCases := []struct {
 		name          string
 		input         input
-		buildStubs    func(repo *MockRepo, accountService *accountdelivery.MockService)
+		buildStubs    func(repo *MockRepo)
 		checkResponse func(t *testing.T, res domain.TransferTxResult)
 		wantError     string
 	}{
@@ -65,25 +65,16 @@ func TestTransfer(t *testing.T) {
 					Amount:        amount,
 				},
 			},
-			buildStubs: func(repo *MockRepo, accountService *accountdelivery.MockService) {
-				repo.EXPECT().Transfer(gomock.Any(), gomock.Any()).
-					Times(1).
-					Return(want, nil)
+			buildStubs: func(repo *MockRepo) {
+				arg := domain.CreateTransferParams{
+					FromAccountID: accountUSD1.ID,
+					ToAccountID:   accountUSD2.ID,
+					Amount:        amount,
+				}
 
-				accountService.EXPECT().Get(gomock.Any(), gomock.Eq(accountUSD1.ID)).
-					Times(1).
-					Return(domain.Account{
-						ID:       accountUSD1.ID,
-						Owner:    accountUSD1.Owner,
-						Balance:  accountUSD1.Balance,
-						Currency: accountUSD1.Currency,
-					}, nil)
-
-				accountService.EXPECT().Get(gomock.Any(), gomock.Eq(accountUSD2.ID)).
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Eq(accountUSD1.Owner), gomock.Eq(arg)).
 					Times(1).
-					Return(domain.Account{
-						Currency: accountUSD2.Currency,
-					}, nil)
+					Return(want, nil)
 			},
 			checkResponse: func(t *testing.T, got domain.TransferTxResult) {
 				if diff := cmp.Diff(want, got); diff != "" {
@@ -101,9 +92,8 @@ func TestTransfer(t *testing.T) {
 					Amount:        "!@#$",
 				},
 			},
-			buildStubs: func(repo *MockRepo, accountService *accountdelivery.MockService) {
-				repo.EXPECT().Transfer(gomock.Any(), gomock.Any()).Times(0)
-				accountService.EXPECT().Get(gomock.Any(), gomock.Any()).Times(0)
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 			},
 			wantError: domain.ErrInvalidAmount.Error(),
 		},
@@ -117,30 +107,25 @@ func TestTransfer(t *testing.T) {
 					Amount:        "-100",
 				},
 			},
-			buildStubs: func(repo *MockRepo, accountService *accountdelivery.MockService) {
-				repo.EXPECT().Transfer(gomock.Any(), gomock.Any()).Times(0)
-				accountService.EXPECT().Get(gomock.Any(), gomock.Any()).Times(0)
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 			},
 			wantError: domain.ErrNegativeAmount.Error(),
 		},
 		{
-			name: "AccountServiceInternalError",
+			name: "ZeroAmount",
 			input: input{
 				fromUsername: accountUSD1.Owner,
 				arg: domain.CreateTransferParams{
 					FromAccountID: accountUSD1.ID,
 					ToAccountID:   accountUSD2.ID,
-					Amount:        amount,
+					Amount:        "0",
 				},
 			},
-			buildStubs: func(repo *MockRepo, accountService *accountdelivery.MockService) {
-				repo.EXPECT().Transfer(gomock.Any(), gomock.Any()).Times(0)
-
-				accountService.EXPECT().Get(gomock.Any(), gomock.Eq(accountUSD1.ID)).
-					Times(1).
-					Return(domain.Account{}, errorspkg.ErrInternal)
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 			},
-			wantError: errorspkg.ErrInternal.Error(),
+			wantError: domain.ErrNegativeAmount.Error(),
 		},
 		{
 			name: "ErrInvalidOwner",
@@ -152,19 +137,15 @@ func TestTransfer(t *testing.T) {
 					Amount:        amount,
 				},
 			},
-			buildStubs: func(repo *MockRepo, accountService *accountdelivery.MockService) {
-				repo.EXPECT().Transfer(gomock.Any(), gomock.Any()).Times(0)
-
-				accountService.EXPECT().Get(gomock.Any(), gomock.Eq(accountUSD2.ID)).
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(domain.Account{
-						Owner: accountUSD2.Owner,
-					}, nil)
+					Return(domain.TransferTxResult{}, domain.ErrInvalidOwner)
 			},
 			wantError: domain.ErrInvalidOwner.Error(),
 		},
 		{
-			name: "FromAccountServiceInternalError",
+			name: "ErrAccountFrozen",
 			input: input{
 				fromUsername: accountUSD1.Owner,
 				arg: domain.CreateTransferParams{
@@ -173,17 +154,12 @@ func TestTransfer(t *testing.T) {
 					Amount:        amount,
 				},
 			},
-			buildStubs: func(repo *MockRepo, accountService *accountdelivery.MockService) {
-				repo.EXPECT().Transfer(gomock.Any(), gomock.Any()).Times(0)
-
-				accountService.EXPECT().Get(gomock.Any(), gomock.Eq(accountUSD1.ID)).
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(domain.Account{
-						Owner:   accountUSD1.Owner,
-						Balance: "invalid",
-					}, nil)
+					Return(domain.TransferTxResult{}, domain.ErrAccountFrozen)
 			},
-			wantError: fmt.Errorf("can't convert %s to decimal", "invalid").Error(),
+			wantError: domain.ErrAccountFrozen.Error(),
 		},
 		{
 			name: "ErrInsufficientBalance",
@@ -195,47 +171,13 @@ func TestTransfer(t *testing.T) {
 					Amount:        "10000",
 				},
 			},
-			buildStubs: func(repo *MockRepo, accountService *accountdelivery.MockService) {
-				repo.EXPECT().Transfer(gomock.Any(), gomock.Any()).Times(0)
-
-				accountService.EXPECT().Get(gomock.Any(), gomock.Eq(accountUSD1.ID)).
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(domain.Account{
-						ID:       accountUSD1.ID,
-						Owner:    accountUSD1.Owner,
-						Balance:  accountUSD1.Balance,
-						Currency: accountUSD1.Currency,
-					}, nil)
+					Return(domain.TransferTxResult{}, domain.ErrInsufficientBalance)
 			},
 			wantError: domain.ErrInsufficientBalance.Error(),
 		},
-		{
-			name: "ToAccountServiceInternalError",
-			input: input{
-				fromUsername: accountUSD1.Owner,
-				arg: domain.CreateTransferParams{
-					FromAccountID: accountUSD1.ID,
-					ToAccountID:   accountUSD2.ID,
-					Amount:        amount,
-				},
-			},
-			buildStubs: func(repo *MockRepo, accountService *accountdelivery.MockService) {
-				repo.EXPECT().Transfer(gomock.Any(), gomock.Any()).Times(0)
-
-				accountService.EXPECT().Get(gomock.Any(), gomock.Eq(accountUSD1.ID)).
-					Times(1).
-					Return(domain.Account{
-						ID:       accountUSD1.ID,
-						Owner:    accountUSD1.Owner,
-						Balance:  accountUSD1.Balance,
-						Currency: accountUSD1.Currency,
-					}, nil)
-				accountService.EXPECT().Get(gomock.Any(), gomock.Eq(accountUSD2.ID)).
-					Times(1).
-					Return(domain.Account{}, errorspkg.ErrInternal)
-			},
-			wantError: errorspkg.ErrInternal.Error(),
-		},
 		{
 			name: "ErrCurrencyMismatch",
 			input: input{
@@ -246,22 +188,10 @@ func TestTransfer(t *testing.T) {
 					Amount:        amount,
 				},
 			},
-			buildStubs: func(repo *MockRepo, accountService *accountdelivery.MockService) {
-				repo.EXPECT().Transfer(gomock.Any(), gomock.Any()).Times(0)
-
-				accountService.EXPECT().Get(gomock.Any(), gomock.Eq(accountUSD1.ID)).
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(domain.Account{
-						ID:       accountUSD1.ID,
-						Owner:    accountUSD1.Owner,
-						Balance:  accountUSD1.Balance,
-						Currency: accountUSD1.Currency,
-					}, nil)
-				accountService.EXPECT().Get(gomock.Any(), gomock.Eq(accountEUR3.ID)).
-					Times(1).
-					Return(domain.Account{
-						Currency: accountEUR3.Currency,
-					}, nil)
+					Return(domain.TransferTxResult{}, domain.ErrCurrencyMismatch)
 			},
 			wantError: domain.ErrCurrencyMismatch.Error(),
 		},
@@ -275,25 +205,10 @@ func TestTransfer(t *testing.T) {
 					Amount:        amount,
 				},
 			},
-			buildStubs: func(repo *MockRepo, accountService *accountdelivery.MockService) {
-				repo.EXPECT().Transfer(gomock.Any(), gomock.Any()).
+			buildStubs: func(repo *MockRepo) {
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(domain.TransferTxResult{}, errorspkg.ErrInternal)
-
-				accountService.EXPECT().Get(gomock.Any(), gomock.Eq(accountUSD1.ID)).
-					Times(1).
-					Return(domain.Account{
-						ID:       accountUSD1.ID,
-						Owner:    accountUSD1.Owner,
-						Balance:  accountUSD1.Balance,
-						Currency: accountUSD1.Currency,
-					}, nil)
-
-				accountService.EXPECT().Get(gomock.Any(), gomock.Eq(accountUSD2.ID)).
-					Times(1).
-					Return(domain.Account{
-						Currency: accountUSD2.Currency,
-					}, nil)
 			},
 			wantError: errorspkg.ErrInternal.Error(),
 		},
@@ -309,10 +224,9 @@ func TestTransfer(t *testing.T) {
 			defer ctrl.Finish()
 
 			tranferRepo := NewMockRepo(ctrl)
-			accountService := accountdelivery.NewMockService(ctrl)
-			transferService := New(tranferRepo, accountService)
+			transferService := New(tranferRepo, NewMockAccountRepo(ctrl), NewMockResolver(ctrl), NewMockPayees(ctrl), nil, nil, nil, nil)
 
-			tc.buildStubs(tranferRepo, accountService)
+			tc.buildStubs(tranferRepo)
 
 			got, err := transferService.Transfer(context.Background(), tc.input.fromUsername, tc.input.arg)
 			if err != nil {
@@ -328,3 +242,983 @@ func TestTransfer(t *testing.T) {
 		})
 	}
 }
+
+func TestGet(t *testing.T) {
+	account1 := randomAccount(1, "1000", currencypkg.USD)
+	account2 := randomAccount(2, "1000", currencypkg.USD)
+
+	transfer := domain.Transfer{
+		ID:            1,
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        "100",
+		CreatedAt:     time.Now().Truncate(time.Second).UTC(),
+	}
+
+	testCases := []struct {
+		name       string
+		username   string
+		buildStubs func(repo *MockRepo, accountRepo *MockAccountRepo)
+		wantErr    error
+	}{
+		{
+			name:     "Sender",
+			username: account1.Owner,
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().Get(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+			},
+		},
+		{
+			name:     "Recipient",
+			username: account2.Owner,
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().Get(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+			},
+		},
+		{
+			name:     "ErrTransferOwnerMismatch",
+			username: randompkg.Owner(),
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().Get(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+			},
+			wantErr: domain.ErrTransferOwnerMismatch,
+		},
+		{
+			name:     "ErrTransferNotFound",
+			username: account1.Owner,
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().Get(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).
+					Return(domain.Transfer{}, domain.ErrTransferNotFound)
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrTransferNotFound,
+		},
+		{
+			name:     "AccountRepoInternalError",
+			username: account1.Owner,
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().Get(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).
+					Return(domain.Account{}, errorspkg.ErrInternal)
+			},
+			wantErr: errorspkg.ErrInternal,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockRepo(ctrl)
+			accountRepo := NewMockAccountRepo(ctrl)
+			tc.buildStubs(repo, accountRepo)
+
+			transferService := New(repo, accountRepo, NewMockResolver(ctrl), NewMockPayees(ctrl), nil, nil, nil, nil)
+
+			got, err := transferService.Get(context.Background(), tc.username, transfer.ID)
+			if err != tc.wantErr {
+				t.Fatalf("transferService.Get(context.Background(), %v, %v) returned error: %v, want %v",
+					tc.username, transfer.ID, err, tc.wantErr)
+			}
+
+			if tc.wantErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(transfer, got); diff != "" {
+				t.Errorf("transferService.Get(context.Background(), %v, %v) returned unexpected difference (-want +got):\n%s",
+					tc.username, transfer.ID, diff)
+			}
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	account := randomAccount(1, "1000", currencypkg.USD)
+	now := time.Now().Truncate(time.Second).UTC()
+
+	transfers := []domain.Transfer{
+		{ID: 1, FromAccountID: account.ID, ToAccountID: 2, Amount: "10", CreatedAt: now},
+		{ID: 2, FromAccountID: 2, ToAccountID: account.ID, Amount: "20", CreatedAt: now},
+	}
+	page := pagepkg.Request{PageID: 2, PageSize: 5}
+	wantPage := pagepkg.Page{Prev: pagepkg.Cursor{BeforeID: 1}.Token()}
+
+	testCases := []struct {
+		name       string
+		arg        domain.ListTransfersParams
+		buildStubs func(repo *MockRepo, accountRepo *MockAccountRepo)
+		wantErr    error
+	}{
+		{
+			name: "OK",
+			arg: domain.ListTransfersParams{
+				Username:  account.Owner,
+				MinAmount: "5",
+				MaxAmount: "50",
+				StartTime: now.Add(-time.Hour),
+				EndTime:   now.Add(time.Hour),
+			},
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				arg := domain.ListTransfersParams{
+					Username:  account.Owner,
+					MinAmount: "5",
+					MaxAmount: "50",
+					StartTime: now.Add(-time.Hour),
+					EndTime:   now.Add(time.Hour),
+					Limit:     6,
+					Offset:    5,
+				}
+
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(0)
+				repo.EXPECT().List(gomock.Any(), gomock.Eq(arg)).Times(1).Return(transfers, nil)
+			},
+		},
+		{
+			name: "ByAccount",
+			arg: domain.ListTransfersParams{
+				Username:  account.Owner,
+				AccountID: account.ID,
+				Direction: domain.TransferDirectionOutgoing,
+			},
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				repo.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).Return(transfers, nil)
+			},
+		},
+		{
+			name: "ErrAccountOwnerMismatch",
+			arg: domain.ListTransfersParams{
+				Username:  randompkg.Owner(),
+				AccountID: account.ID,
+			},
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				repo.EXPECT().List(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrAccountOwnerMismatch,
+		},
+		{
+			name: "ErrAccountNotFound",
+			arg: domain.ListTransfersParams{
+				Username:  account.Owner,
+				AccountID: account.ID,
+			},
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(account.ID)).Times(1).
+					Return(domain.Account{}, domain.ErrAccountNotFound)
+				repo.EXPECT().List(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrAccountNotFound,
+		},
+		{
+			name: "InvalidMinAmount",
+			arg: domain.ListTransfersParams{
+				Username:  account.Owner,
+				MinAmount: "!@#$",
+			},
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().List(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrInvalidAmount,
+		},
+		{
+			name: "MaxAmountLessThanMinAmount",
+			arg: domain.ListTransfersParams{
+				Username:  account.Owner,
+				MinAmount: "50",
+				MaxAmount: "5",
+			},
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().List(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrInvalidAmount,
+		},
+		{
+			name: "ErrInvalidDateRange",
+			arg: domain.ListTransfersParams{
+				Username:  account.Owner,
+				StartTime: now,
+				EndTime:   now.Add(-time.Hour),
+			},
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo) {
+				repo.EXPECT().List(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrInvalidDateRange,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockRepo(ctrl)
+			accountRepo := NewMockAccountRepo(ctrl)
+			tc.buildStubs(repo, accountRepo)
+
+			transferService := New(repo, accountRepo, NewMockResolver(ctrl), NewMockPayees(ctrl), nil, nil, nil, nil)
+
+			got, gotPage, err := transferService.List(context.Background(), tc.arg, page)
+			if err != tc.wantErr {
+				t.Fatalf("transferService.List(context.Background(), %+v, %+v) returned error: %v, want %v",
+					tc.arg, page, err, tc.wantErr)
+			}
+
+			if tc.wantErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(transfers, got); diff != "" {
+				t.Errorf("transferService.List(context.Background(), %+v, %+v) returned unexpected difference (-want +got):\n%s",
+					tc.arg, page, diff)
+			}
+
+			if gotPage != wantPage {
+				t.Errorf("transferService.List(context.Background(), %+v, %+v) returned page %+v, want %+v",
+					tc.arg, page, gotPage, wantPage)
+			}
+		})
+	}
+}
+
+func TestTransferAudit(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	fromAccount := randomAccount(1, "900", currencypkg.USD)
+	toAccount := randomAccount(2, "1100", currencypkg.USD)
+	arg := domain.CreateTransferParams{FromAccountID: fromAccount.ID, ToAccountID: toAccount.ID, Amount: "100"}
+
+	result := domain.TransferTxResult{
+		Transfer:    domain.Transfer{ID: 1, FromAccountID: fromAccount.ID, ToAccountID: toAccount.ID, Amount: "100"},
+		FromAccount: fromAccount,
+		ToAccount:   toAccount,
+		FromEntry:   domain.Entry{AccountID: fromAccount.ID, Amount: "-100"},
+		ToEntry:     domain.Entry{AccountID: toAccount.ID, Amount: "100"},
+	}
+
+	wantFrom, wantTo := fromAccount, toAccount
+	wantFrom.Balance, wantTo.Balance = "1000", "1000"
+	before := transferAccounts{FromAccount: wantFrom, ToAccount: wantTo}
+
+	repo := NewMockRepo(ctrl)
+	repo.EXPECT().Transfer(gomock.Any(), gomock.Eq(fromAccount.Owner), gomock.Eq(arg)).Times(1).Return(result, nil)
+
+	auditor := NewMockAuditor(ctrl)
+	auditor.EXPECT().
+		Record(gomock.Any(), domain.AuditTransferCreated, fromAccount.Owner, gomock.Eq(before), gomock.Eq(result)).
+		Times(1)
+
+	if _, err := New(repo, NewMockAccountRepo(ctrl), NewMockResolver(ctrl), NewMockPayees(ctrl), nil, nil, nil, auditor).Transfer(context.Background(), fromAccount.Owner, arg); err != nil {
+		t.Fatalf("Transfer(...) returned error: %v", err)
+	}
+}
+
+func TestTransferRecipient(t *testing.T) {
+	fromAccount := randomAccount(1, "1000", currencypkg.USD)
+	toAccount := randomAccount(2, "1000", currencypkg.USD)
+
+	testCases := []struct {
+		name       string
+		username   string
+		recipient  domain.Recipient
+		buildStubs func(repo *MockRepo, accountRepo *MockAccountRepo, resolver *MockResolver)
+		wantErr    error
+	}{
+		{
+			name:      "FromAccountCurrency",
+			username:  fromAccount.Owner,
+			recipient: domain.Recipient{Username: toAccount.Owner},
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo, resolver *MockResolver) {
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+
+				recipient := domain.Recipient{Username: toAccount.Owner, Currency: currencypkg.USD}
+				resolver.EXPECT().Resolve(gomock.Any(), gomock.Eq(recipient)).Times(1).Return(toAccount, nil)
+
+				arg := domain.CreateTransferParams{
+					FromAccountID: fromAccount.ID,
+					ToAccountID:   toAccount.ID,
+					Amount:        "100",
+					Recipient:     &domain.Recipient{Username: toAccount.Owner},
+				}
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Eq(fromAccount.Owner), gomock.Eq(arg)).
+					Times(1).
+					Return(domain.TransferTxResult{}, nil)
+			},
+		},
+		{
+			name:      "Currency",
+			username:  fromAccount.Owner,
+			recipient: domain.Recipient{Alias: "@bob", Currency: currencypkg.EUR},
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo, resolver *MockResolver) {
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(0)
+				resolver.EXPECT().Resolve(gomock.Any(), gomock.Eq(domain.Recipient{Alias: "@bob", Currency: currencypkg.EUR})).
+					Times(1).
+					Return(toAccount, nil)
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(domain.TransferTxResult{}, nil)
+			},
+		},
+		{
+			name:      "ErrInvalidOwner",
+			username:  randompkg.Owner(),
+			recipient: domain.Recipient{Username: toAccount.Owner},
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo, resolver *MockResolver) {
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				resolver.EXPECT().Resolve(gomock.Any(), gomock.Any()).Times(0)
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrInvalidOwner,
+		},
+		{
+			name:      "ErrRecipientAccountNotFound",
+			username:  fromAccount.Owner,
+			recipient: domain.Recipient{Username: toAccount.Owner, Currency: currencypkg.EUR},
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo, resolver *MockResolver) {
+				resolver.EXPECT().Resolve(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.Account{}, domain.ErrRecipientAccountNotFound)
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrRecipientAccountNotFound,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepo(ctrl)
+			accountRepo := NewMockAccountRepo(ctrl)
+			resolver := NewMockResolver(ctrl)
+			tc.buildStubs(repo, accountRepo, resolver)
+
+			arg := domain.CreateTransferParams{
+				FromAccountID: fromAccount.ID,
+				Amount:        "100",
+				Recipient:     &tc.recipient,
+			}
+
+			_, err := New(repo, accountRepo, resolver, NewMockPayees(ctrl), nil, nil, nil, nil).Transfer(context.Background(), tc.username, arg)
+			if err != tc.wantErr {
+				t.Errorf("Transfer(ctx, %v, %+v) returned error: %v, want %v", tc.username, arg, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestTransferPayee(t *testing.T) {
+	fromAccount := randomAccount(1, "1000", currencypkg.USD)
+	toAccount := randomAccount(2, "1000", currencypkg.USD)
+	payee := domain.Payee{ID: 3, Username: fromAccount.Owner, AccountID: toAccount.ID, Currency: toAccount.Currency}
+
+	testCases := []struct {
+		name       string
+		buildStubs func(repo *MockRepo, payees *MockPayees)
+		wantErr    error
+	}{
+		{
+			name: "OK",
+			buildStubs: func(repo *MockRepo, payees *MockPayees) {
+				payees.EXPECT().GetUsable(gomock.Any(), gomock.Eq(fromAccount.Owner), gomock.Eq(payee.ID)).Times(1).Return(payee, nil)
+
+				arg := domain.CreateTransferParams{
+					FromAccountID: fromAccount.ID,
+					ToAccountID:   toAccount.ID,
+					Amount:        "100",
+					PayeeID:       payee.ID,
+				}
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Eq(fromAccount.Owner), gomock.Eq(arg)).
+					Times(1).
+					Return(domain.TransferTxResult{}, nil)
+				payees.EXPECT().MarkUsed(gomock.Any(), gomock.Eq(payee.ID)).Times(1).Return(nil)
+			},
+		},
+		{
+			name: "MarkUsedErrInternal",
+			buildStubs: func(repo *MockRepo, payees *MockPayees) {
+				payees.EXPECT().GetUsable(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(payee, nil)
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(domain.TransferTxResult{}, nil)
+				payees.EXPECT().MarkUsed(gomock.Any(), gomock.Any()).Times(1).Return(errorspkg.ErrInternal)
+			},
+		},
+		{
+			name: "ErrPayeeCoolingOff",
+			buildStubs: func(repo *MockRepo, payees *MockPayees) {
+				payees.EXPECT().GetUsable(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.Payee{}, domain.ErrPayeeCoolingOff)
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrPayeeCoolingOff,
+		},
+		{
+			name: "TransferErrInsufficientBalance",
+			buildStubs: func(repo *MockRepo, payees *MockPayees) {
+				payees.EXPECT().GetUsable(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(payee, nil)
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.TransferTxResult{}, domain.ErrInsufficientBalance)
+				payees.EXPECT().MarkUsed(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrInsufficientBalance,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepo(ctrl)
+			payees := NewMockPayees(ctrl)
+			tc.buildStubs(repo, payees)
+
+			arg := domain.CreateTransferParams{FromAccountID: fromAccount.ID, Amount: "100", PayeeID: payee.ID}
+
+			_, err := New(repo, NewMockAccountRepo(ctrl), NewMockResolver(ctrl), payees, nil, nil, nil, nil).Transfer(context.Background(), fromAccount.Owner, arg)
+			if err != tc.wantErr {
+				t.Errorf("Transfer(ctx, %v, %+v) returned error: %v, want %v", fromAccount.Owner, arg, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestTransferLimits(t *testing.T) {
+	fromAccount := randomAccount(1, "1000", currencypkg.USD)
+	toAccount := randomAccount(2, "1000", currencypkg.USD)
+	limit := domain.TransferLimit{Username: fromAccount.Owner, Currency: currencypkg.USD, Daily: "500"}
+	limitErr := &domain.TransferLimitError{Limit: domain.LimitDaily, Currency: currencypkg.USD, Remaining: "50"}
+
+	testCases := []struct {
+		name       string
+		buildStubs func(repo *MockRepo, accountRepo *MockAccountRepo, limits *MockLimits)
+		wantErr    error
+	}{
+		{
+			name: "OK",
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo, limits *MockLimits) {
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				limits.EXPECT().Get(gomock.Any(), gomock.Eq(fromAccount.Owner), gomock.Eq(currencypkg.USD)).Times(1).Return(limit, nil)
+
+				arg := domain.CreateTransferParams{
+					FromAccountID: fromAccount.ID,
+					ToAccountID:   toAccount.ID,
+					Amount:        "100",
+					Limit:         &limit,
+				}
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Eq(fromAccount.Owner), gomock.Eq(arg)).
+					Times(1).
+					Return(domain.TransferTxResult{}, nil)
+			},
+		},
+		{
+			name: "ErrAccountNotFound",
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo, limits *MockLimits) {
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(domain.Account{}, domain.ErrAccountNotFound)
+				limits.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrAccountNotFound,
+		},
+		{
+			name: "LimitsErrInternal",
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo, limits *MockLimits) {
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(fromAccount, nil)
+				limits.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(domain.TransferLimit{}, errorspkg.ErrInternal)
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: errorspkg.ErrInternal,
+		},
+		{
+			name: "TransferLimitError",
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo, limits *MockLimits) {
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(fromAccount, nil)
+				limits.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(limit, nil)
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(domain.TransferTxResult{}, limitErr)
+			},
+			wantErr: limitErr,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepo(ctrl)
+			accountRepo := NewMockAccountRepo(ctrl)
+			limits := NewMockLimits(ctrl)
+			tc.buildStubs(repo, accountRepo, limits)
+
+			arg := domain.CreateTransferParams{FromAccountID: fromAccount.ID, ToAccountID: toAccount.ID, Amount: "100"}
+
+			_, err := New(repo, accountRepo, NewMockResolver(ctrl), NewMockPayees(ctrl), limits, nil, nil, nil).Transfer(context.Background(), fromAccount.Owner, arg)
+			if err != tc.wantErr {
+				t.Errorf("Transfer(ctx, %v, %+v) returned error: %v, want %v", fromAccount.Owner, arg, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestTransferMonitoring(t *testing.T) {
+	fromAccount := randomAccount(1, "1000", currencypkg.USD)
+	toAccount := randomAccount(2, "1000", currencypkg.USD)
+	sessionID := uuid.New()
+	quoteID := uuid.New()
+	rules := []string{"many_new_payees"}
+	review := domain.TransferReview{ID: 1, Username: fromAccount.Owner, Amount: "100", Rules: rules, Status: domain.TransferReviewStatusPending}
+	held := domain.TransferTxResult{Review: &review, FromAccount: fromAccount, ToAccount: toAccount}
+
+	testCases := []struct {
+		name       string
+		fxQuoteID  *uuid.UUID
+		buildStubs func(repo *MockRepo, monitor *MockMonitor, auditor *MockAuditor)
+		want       domain.TransferTxResult
+		wantErr    error
+	}{
+		{
+			name: "Allow",
+			buildStubs: func(repo *MockRepo, monitor *MockMonitor, auditor *MockAuditor) {
+				check := domain.RiskCheck{
+					Username:      fromAccount.Owner,
+					SessionID:     sessionID,
+					FromAccountID: fromAccount.ID,
+					ToAccountID:   toAccount.ID,
+					Amount:        "100",
+				}
+				monitor.EXPECT().Assess(gomock.Any(), gomock.Eq(check)).
+					Times(1).
+					Return(domain.RiskAssessment{Decision: domain.RiskDecisionAllow}, nil)
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Eq(fromAccount.Owner), gomock.Any()).Times(1).Return(domain.TransferTxResult{}, nil)
+				repo.EXPECT().CreateReview(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				auditor.EXPECT().Record(gomock.Any(), gomock.Eq(domain.AuditTransferCreated), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+			},
+		},
+		{
+			name: "Review",
+			buildStubs: func(repo *MockRepo, monitor *MockMonitor, auditor *MockAuditor) {
+				monitor.EXPECT().Assess(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.RiskAssessment{Decision: domain.RiskDecisionReview, Rules: rules}, nil)
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				repo.EXPECT().CreateReview(gomock.Any(), gomock.Eq(fromAccount.Owner), gomock.Any(), gomock.Eq(rules)).
+					Times(1).
+					Return(held, nil)
+				auditor.EXPECT().Record(gomock.Any(), gomock.Eq(domain.AuditTransferReviewCreated), gomock.Eq(fromAccount.Owner), gomock.Nil(), gomock.Eq(&review)).
+					Times(1)
+			},
+			want: held,
+		},
+		{
+			name:      "ReviewFXQuote",
+			fxQuoteID: &quoteID,
+			buildStubs: func(repo *MockRepo, monitor *MockMonitor, auditor *MockAuditor) {
+				monitor.EXPECT().Assess(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.RiskAssessment{Decision: domain.RiskDecisionReview, Rules: rules}, nil)
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				repo.EXPECT().CreateReview(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				auditor.EXPECT().Record(gomock.Any(), gomock.Eq(domain.AuditTransferBlocked), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+			},
+			wantErr: domain.ErrTransferBlocked,
+		},
+		{
+			name: "Block",
+			buildStubs: func(repo *MockRepo, monitor *MockMonitor, auditor *MockAuditor) {
+				monitor.EXPECT().Assess(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.RiskAssessment{Decision: domain.RiskDecisionBlock, Rules: rules}, nil)
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				repo.EXPECT().CreateReview(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				auditor.EXPECT().Record(gomock.Any(), gomock.Eq(domain.AuditTransferBlocked), gomock.Eq(fromAccount.Owner), gomock.Nil(), gomock.Any()).Times(1)
+			},
+			wantErr: domain.ErrTransferBlocked,
+		},
+		{
+			name: "MonitorErrInternal",
+			buildStubs: func(repo *MockRepo, monitor *MockMonitor, auditor *MockAuditor) {
+				monitor.EXPECT().Assess(gomock.Any(), gomock.Any()).Times(1).Return(domain.RiskAssessment{}, errorspkg.ErrInternal)
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				auditor.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: errorspkg.ErrInternal,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepo(ctrl)
+			monitor := NewMockMonitor(ctrl)
+			auditor := NewMockAuditor(ctrl)
+			tc.buildStubs(repo, monitor, auditor)
+
+			arg := domain.CreateTransferParams{
+				FromAccountID: fromAccount.ID,
+				ToAccountID:   toAccount.ID,
+				Amount:        "100",
+				FXQuoteID:     tc.fxQuoteID,
+				SessionID:     sessionID,
+			}
+
+			service := New(repo, NewMockAccountRepo(ctrl), NewMockResolver(ctrl), NewMockPayees(ctrl), nil, monitor, nil, auditor)
+
+			got, err := service.Transfer(context.Background(), fromAccount.Owner, arg)
+			if err != tc.wantErr {
+				t.Fatalf("Transfer(ctx, %v, %+v) returned error: %v, want %v", fromAccount.Owner, arg, err, tc.wantErr)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Transfer(ctx, %v, %+v) returned unexpected difference (-want +got):\n%s", fromAccount.Owner, arg, diff)
+			}
+		})
+	}
+}
+
+func TestTransferScreening(t *testing.T) {
+	fromAccount := randomAccount(1, "1000", currencypkg.USD)
+	toAccount := randomAccount(2, "1000", currencypkg.USD)
+
+	testCases := []struct {
+		name       string
+		screenErr  error
+		buildStubs func(repo *MockRepo, monitor *MockMonitor)
+		wantErr    error
+	}{
+		{
+			name: "OK",
+			buildStubs: func(repo *MockRepo, monitor *MockMonitor) {
+				monitor.EXPECT().Assess(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.RiskAssessment{Decision: domain.RiskDecisionAllow}, nil)
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Eq(fromAccount.Owner), gomock.Any()).Times(1).Return(domain.TransferTxResult{}, nil)
+			},
+		},
+		{
+			name:      "ErrSanctionsHit",
+			screenErr: domain.ErrSanctionsHit,
+			buildStubs: func(repo *MockRepo, monitor *MockMonitor) {
+				monitor.EXPECT().Assess(gomock.Any(), gomock.Any()).Times(0)
+				repo.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrSanctionsHit,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepo(ctrl)
+			monitor := NewMockMonitor(ctrl)
+			tc.buildStubs(repo, monitor)
+
+			screener := NewMockScreener(ctrl)
+			screener.EXPECT().
+				ScreenRecipient(gomock.Any(), gomock.Eq(fromAccount.Owner), gomock.Eq(toAccount.ID)).
+				Times(1).
+				Return(tc.screenErr)
+
+			arg := domain.CreateTransferParams{
+				FromAccountID: fromAccount.ID,
+				ToAccountID:   toAccount.ID,
+				Amount:        "100",
+			}
+
+			service := New(repo, NewMockAccountRepo(ctrl), NewMockResolver(ctrl), NewMockPayees(ctrl), nil, monitor, screener, nil)
+
+			if _, err := service.Transfer(context.Background(), fromAccount.Owner, arg); err != tc.wantErr {
+				t.Errorf("Transfer(ctx, %v, %+v) returned error: %v, want %v", fromAccount.Owner, arg, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestReviews(t *testing.T) {
+	reviewer := randompkg.Owner()
+	approved := domain.ApproveTransferReviewResult{
+		Review:   domain.TransferReview{ID: 1, Status: domain.TransferReviewStatusApproved, Reviewer: reviewer, TransferID: 2},
+		Transfer: domain.TransferTxResult{Transfer: domain.Transfer{ID: 2}},
+	}
+	rejected := domain.TransferReview{ID: 3, Status: domain.TransferReviewStatusRejected, Reviewer: reviewer}
+
+	ctrl := gomock.NewController(t)
+	repo := NewMockRepo(ctrl)
+	auditor := NewMockAuditor(ctrl)
+
+	repo.EXPECT().ApproveReview(gomock.Any(), gomock.Eq(reviewer), gomock.Eq(int64(1))).Times(1).Return(approved, nil)
+	repo.EXPECT().RejectReview(gomock.Any(), gomock.Eq(reviewer), gomock.Eq(int64(3))).Times(1).Return(rejected, nil)
+	repo.EXPECT().RejectReview(gomock.Any(), gomock.Eq(reviewer), gomock.Eq(int64(1))).
+		Times(1).
+		Return(domain.TransferReview{}, domain.ErrTransferReviewClosed)
+	auditor.EXPECT().Record(gomock.Any(), gomock.Eq(domain.AuditTransferReviewApproved), gomock.Eq(reviewer), gomock.Nil(), gomock.Eq(approved)).Times(1)
+	auditor.EXPECT().Record(gomock.Any(), gomock.Eq(domain.AuditTransferReviewRejected), gomock.Eq(reviewer), gomock.Nil(), gomock.Eq(rejected)).Times(1)
+
+	service := New(repo, NewMockAccountRepo(ctrl), NewMockResolver(ctrl), NewMockPayees(ctrl), nil, nil, nil, auditor)
+
+	if _, err := service.ApproveReview(context.Background(), reviewer, 1); err != nil {
+		t.Errorf("service.ApproveReview(ctx, %v, 1) returned error: %v", reviewer, err)
+	}
+
+	if _, err := service.RejectReview(context.Background(), reviewer, 3); err != nil {
+		t.Errorf("service.RejectReview(ctx, %v, 3) returned error: %v", reviewer, err)
+	}
+
+	if _, err := service.RejectReview(context.Background(), reviewer, 1); err != domain.ErrTransferReviewClosed {
+		t.Errorf("service.RejectReview(ctx, %v, 1) returned error: %v, want %v", reviewer, err, domain.ErrTransferReviewClosed)
+	}
+}
+
+func TestDepositWithdraw(t *testing.T) {
+	account := randomAccount(1, "1100", currencypkg.USD)
+	settlement := randomAccount(2, "-100", currencypkg.USD)
+	settlement.Owner, settlement.IsSystem = domain.SettlementOwner, true
+	actor := randompkg.Owner()
+
+	deposit := domain.TransferTxResult{
+		Transfer:    domain.Transfer{ID: 1, FromAccountID: settlement.ID, ToAccountID: account.ID, Amount: "100", Kind: domain.TransferKindDeposit},
+		FromAccount: settlement,
+		ToAccount:   account,
+		FromEntry:   domain.Entry{AccountID: settlement.ID, Amount: "-100"},
+		ToEntry:     domain.Entry{AccountID: account.ID, Amount: "100"},
+	}
+
+	testCases := []struct {
+		name       string
+		amount     string
+		withdraw   bool
+		buildStubs func(repo *MockRepo, auditor *MockAuditor)
+		wantErr    error
+	}{
+		{
+			name:   "Deposit",
+			amount: "100",
+			buildStubs: func(repo *MockRepo, auditor *MockAuditor) {
+				arg := domain.CreateCashParams{AccountID: account.ID, Amount: "100"}
+				repo.EXPECT().Deposit(gomock.Any(), gomock.Eq(arg)).Times(1).Return(deposit, nil)
+				auditor.EXPECT().
+					Record(gomock.Any(), domain.AuditDepositCreated, actor, gomock.Any(), gomock.Eq(deposit)).
+					Times(1)
+			},
+		},
+		{
+			name:     "Withdraw",
+			amount:   "100",
+			withdraw: true,
+			buildStubs: func(repo *MockRepo, auditor *MockAuditor) {
+				arg := domain.CreateCashParams{AccountID: account.ID, Amount: "100"}
+				repo.EXPECT().Withdraw(gomock.Any(), gomock.Eq(arg)).Times(1).Return(deposit, nil)
+				auditor.EXPECT().
+					Record(gomock.Any(), domain.AuditWithdrawalCreated, actor, gomock.Any(), gomock.Eq(deposit)).
+					Times(1)
+			},
+		},
+		{
+			name:   "DepositErrNegativeAmount",
+			amount: "-100",
+			buildStubs: func(repo *MockRepo, auditor *MockAuditor) {
+				repo.EXPECT().Deposit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrNegativeAmount,
+		},
+		{
+			name:     "WithdrawErrInvalidAmount",
+			amount:   "abc",
+			withdraw: true,
+			buildStubs: func(repo *MockRepo, auditor *MockAuditor) {
+				repo.EXPECT().Withdraw(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrInvalidAmount,
+		},
+		{
+			name:     "WithdrawErrInsufficientBalance",
+			amount:   "100",
+			withdraw: true,
+			buildStubs: func(repo *MockRepo, auditor *MockAuditor) {
+				repo.EXPECT().Withdraw(gomock.Any(), gomock.Any()).Times(1).
+					Return(domain.TransferTxResult{}, domain.ErrInsufficientBalance)
+				auditor.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrInsufficientBalance,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepo(ctrl)
+			auditor := NewMockAuditor(ctrl)
+			tc.buildStubs(repo, auditor)
+
+			service := New(repo, NewMockAccountRepo(ctrl), NewMockResolver(ctrl), NewMockPayees(ctrl), nil, nil, nil, auditor)
+			arg := domain.CreateCashParams{AccountID: account.ID, Amount: tc.amount}
+
+			var err error
+			if tc.withdraw {
+				_, err = service.Withdraw(context.Background(), actor, arg)
+			} else {
+				_, err = service.Deposit(context.Background(), actor, arg)
+			}
+
+			if err != tc.wantErr {
+				t.Errorf("returned error: %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestReverse(t *testing.T) {
+	from := randomAccount(1, "900", currencypkg.USD)
+	to := randomAccount(2, "1100", currencypkg.USD)
+	transfer := domain.Transfer{ID: 1, FromAccountID: from.ID, ToAccountID: to.ID, Amount: "100", Kind: domain.TransferKindTransfer}
+
+	reversal := domain.ReversalTxResult{
+		ReversedTransfer: transfer,
+		Reversal: domain.TransferTxResult{
+			Transfer:    domain.Transfer{ID: 2, FromAccountID: to.ID, ToAccountID: from.ID, Amount: "40", Kind: domain.TransferKindReversal, ReversedTransferID: 1},
+			FromAccount: to,
+			ToAccount:   from,
+			FromEntry:   domain.Entry{AccountID: to.ID, Amount: "-40"},
+			ToEntry:     domain.Entry{AccountID: from.ID, Amount: "40"},
+		},
+	}
+	reversal.ReversedTransfer.Status = domain.TransferStatusPartiallyRefunded
+	reversal.ReversedTransfer.RefundedAmount = "40"
+
+	testCases := []struct {
+		name       string
+		actor      string
+		asAdmin    bool
+		amount     string
+		buildStubs func(repo *MockRepo, accountRepo *MockAccountRepo, auditor *MockAuditor)
+		wantErr    error
+	}{
+		{
+			name:   "Recipient",
+			actor:  to.Owner,
+			amount: "40",
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo, auditor *MockAuditor) {
+				repo.EXPECT().Get(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(to.ID)).Times(1).Return(to, nil)
+				arg := domain.ReverseTransferParams{TransferID: transfer.ID, Amount: "40"}
+				repo.EXPECT().Reverse(gomock.Any(), gomock.Eq(arg)).Times(1).Return(reversal, nil)
+				auditor.EXPECT().
+					Record(gomock.Any(), domain.AuditTransferReversed, to.Owner, gomock.Any(), gomock.Eq(reversal)).
+					Times(1)
+			},
+		},
+		{
+			name:    "Admin",
+			actor:   randompkg.Owner(),
+			asAdmin: true,
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo, auditor *MockAuditor) {
+				repo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(0)
+				arg := domain.ReverseTransferParams{TransferID: transfer.ID}
+				repo.EXPECT().Reverse(gomock.Any(), gomock.Eq(arg)).Times(1).Return(reversal, nil)
+				auditor.EXPECT().Record(gomock.Any(), domain.AuditTransferReversed, gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+			},
+		},
+		{
+			name:  "SenderErrReversalNotAllowed",
+			actor: from.Owner,
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo, auditor *MockAuditor) {
+				repo.EXPECT().Get(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				accountRepo.EXPECT().Get(gomock.Any(), gomock.Eq(to.ID)).Times(1).Return(to, nil)
+				repo.EXPECT().Reverse(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrReversalNotAllowed,
+		},
+		{
+			name:   "ErrNegativeAmount",
+			actor:  to.Owner,
+			amount: "-40",
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo, auditor *MockAuditor) {
+				repo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(0)
+				repo.EXPECT().Reverse(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrNegativeAmount,
+		},
+		{
+			name:  "ErrTransferNotFound",
+			actor: to.Owner,
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo, auditor *MockAuditor) {
+				repo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(domain.Transfer{}, domain.ErrTransferNotFound)
+				repo.EXPECT().Reverse(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrTransferNotFound,
+		},
+		{
+			name:    "ErrTransferReversed",
+			actor:   randompkg.Owner(),
+			asAdmin: true,
+			buildStubs: func(repo *MockRepo, accountRepo *MockAccountRepo, auditor *MockAuditor) {
+				repo.EXPECT().Reverse(gomock.Any(), gomock.Any()).Times(1).
+					Return(domain.ReversalTxResult{}, domain.ErrTransferReversed)
+				auditor.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domain.ErrTransferReversed,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepo(ctrl)
+			accountRepo := NewMockAccountRepo(ctrl)
+			auditor := NewMockAuditor(ctrl)
+			tc.buildStubs(repo, accountRepo, auditor)
+
+			service := New(repo, accountRepo, NewMockResolver(ctrl), NewMockPayees(ctrl), nil, nil, nil, auditor)
+			arg := domain.ReverseTransferParams{TransferID: transfer.ID, Amount: tc.amount}
+
+			got, err := service.Reverse(context.Background(), tc.actor, tc.asAdmin, arg)
+			if err != tc.wantErr {
+				t.Fatalf("returned error: %v, want %v", err, tc.wantErr)
+			}
+
+			if tc.wantErr == nil {
+				if diff := cmp.Diff(reversal, got); diff != "" {
+					t.Errorf("returned unexpected diff: %s", diff)
+				}
+			}
+		})
+	}
+}
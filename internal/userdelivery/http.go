@@ -80,6 +80,9 @@ func (h *Handler) Create(gctx *gin.Context) {
 		case domain.ErrEmailALreadyExists:
 			gctx.JSON(http.StatusConflict, web.Error(err))
 			return
+		case domain.ErrSanctionsHit:
+			gctx.JSON(http.StatusForbidden, web.Error(err))
+			return
 		}
 
 		gctx.JSON(http.StatusInternalServerError, web.Error(errorspkg.ErrInternal))
@@ -117,11 +120,15 @@ func (h *Handler) Create(gctx *gin.Context) {
 }
 
 type loginRequest struct {
-	Username string `json:"username" binding:"required,alphanum"`
-	Password string `json:"password" binding:"required,min=6"`
+	Username string   `json:"username" binding:"required,alphanum"`
+	Password string   `json:"password" binding:"required,min=6"`
+	Scopes   []string `json:"scopes" binding:"omitempty,dive,required"`
 }
 
 // Login handlek http login request and returns user and session data.
+//
+// The access token can be narrowed down to the requested scopes, e.g. to issue
+// a read-only token.
 func (h *Handler) Login(gctx *gin.Context) {
 	ctx := gctx.Request.Context()
 	l := zerolog.Ctx(ctx)
@@ -162,10 +169,16 @@ func (h *Handler) Login(gctx *gin.Context) {
 		Username:  req.Username,
 		UserAgent: gctx.Request.UserAgent(),
 		ClientIP:  gctx.ClientIP(),
+		Scopes:    req.Scopes,
 	}
 
 	accessToken, accessTokenExpiresAt, session, err := h.sessionMaker.Create(ctx, arg)
 	if err != nil {
+		if err == domain.ErrScopeNotAllowed {
+			gctx.JSON(http.StatusForbidden, web.Error(err))
+			return
+		}
+
 		l.Warn().Err(err).Send()
 		gctx.JSON(http.StatusInternalServerError, web.Error(errorspkg.ErrInternal))
 
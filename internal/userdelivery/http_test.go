@@ -245,6 +245,31 @@ func TestCreate(t *testing.T) {
 			wantStatusCode: http.StatusConflict,
 			wantError:      domain.ErrEmailALreadyExists.Error(),
 		},
+		{
+			name: "ErrSanctionsHit",
+			requestBody: requestBody{
+				Username: user.Username,
+				Password: user.HashedPassword,
+				FullName: user.FullName,
+				Email:    user.Email,
+			},
+			buildStubs: func(userService *MockService, sessionMaker *MockSessionMaker) {
+				userService.EXPECT().
+					Create(gomock.Any(),
+						gomock.Eq(user.Username),
+						gomock.Eq(user.HashedPassword),
+						gomock.Eq(user.FullName),
+						gomock.Eq(user.Email)).
+					Times(1).
+					Return(domain.UserWihtoutPassword{}, domain.ErrSanctionsHit)
+
+				sessionMaker.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			wantStatusCode: http.StatusForbidden,
+			wantError:      domain.ErrSanctionsHit.Error(),
+		},
 		{
 			name: "CreateInternalError",
 			requestBody: requestBody{
@@ -381,8 +406,9 @@ func TestLoginAPI(t *testing.T) {
 	server.POST(url, userHandler.Login)
 
 	type requestBody struct {
-		Username string `json:"username"`
-		Password string `json:"password"`
+		Username string   `json:"username"`
+		Password string   `json:"password"`
+		Scopes   []string `json:"scopes,omitempty"`
 	}
 
 	testCases := []struct {
@@ -551,6 +577,32 @@ func TestLoginAPI(t *testing.T) {
 			wantStatusCode: http.StatusInternalServerError,
 			wantError:      errorspkg.ErrInternal.Error(),
 		},
+		{
+			name: "ScopeNotAllowed",
+			requestBody: requestBody{
+				Username: user.Username,
+				Password: user.HashedPassword,
+				Scopes:   []string{domain.ScopeAdminWrite},
+			},
+			buildStubs: func(userService *MockService, sessionMaker *MockSessionMaker) {
+				userService.EXPECT().
+					CheckPassword(gomock.Any(), gomock.Eq(user.Username), gomock.Eq(user.HashedPassword)).
+					Times(1).
+					Return(userservice.NewUserWihtoutPassword(user), nil)
+
+				arg := domain.CreateSessionParams{
+					Username: user.Username,
+					Scopes:   []string{domain.ScopeAdminWrite},
+				}
+
+				sessionMaker.EXPECT().
+					Create(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return("", time.Time{}, domain.Session{}, domain.ErrScopeNotAllowed)
+			},
+			wantStatusCode: http.StatusForbidden,
+			wantError:      domain.ErrScopeNotAllowed.Error(),
+		},
 		{
 			name: "CreateSessionInternalError",
 			requestBody: requestBody{
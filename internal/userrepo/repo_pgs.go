@@ -33,7 +33,7 @@ INSERT INTO users (
     email
 ) VALUES (
     $1, $2, $3, $4
-) RETURNING username, hashed_password, full_name, email, password_changed_at, created_at
+) RETURNING username, hashed_password, full_name, email, role, password_changed_at, created_at
 `
 
 // Create creates the user and then returns it.
@@ -54,6 +54,7 @@ func (r *RepoPGS) Create(ctx context.Context, arg domain.CreateUserParams) (doma
 		&u.HashedPassword,
 		&u.FullName,
 		&u.Email,
+		&u.Role,
 		&u.PasswordChangedAt,
 		&u.CreatedAt,
 	)
@@ -84,6 +85,7 @@ SELECT
 	hashed_password, 
 	full_name, 
 	email, 
+	role, 
 	password_changed_at, 
 	created_at 
 FROM users
@@ -103,6 +105,7 @@ func (r *RepoPGS) Get(ctx context.Context, username string) (domain.User, error)
 		&u.HashedPassword,
 		&u.FullName,
 		&u.Email,
+		&u.Role,
 		&u.PasswordChangedAt,
 		&u.CreatedAt,
 	)
@@ -119,3 +122,56 @@ func (r *RepoPGS) Get(ctx context.Context, username string) (domain.User, error)
 
 	return u, nil
 }
+
+const searchQuery = `
+SELECT
+	username,
+	full_name,
+	email,
+	role,
+	created_at
+FROM users
+WHERE $1 = ''
+	OR strpos(lower(username), lower($1)) > 0
+	OR strpos(lower(full_name), lower($1)) > 0
+	OR strpos(lower(email), lower($1)) > 0
+ORDER BY username
+LIMIT $2 OFFSET $3
+`
+
+// Search returns the users which username, full name or email contains
+// arg.Query ignoring case, ordered by username. Empty query matches all users.
+func (r *RepoPGS) Search(ctx context.Context, arg domain.SearchUsersParams) ([]domain.UserWihtoutPassword, error) {
+	l := zerolog.Ctx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, searchQuery, arg.Query, arg.Limit, arg.Offset)
+	if err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+	defer rows.Close()
+
+	items := []domain.UserWihtoutPassword{}
+
+	for rows.Next() {
+		var u domain.UserWihtoutPassword
+		if err := rows.Scan(&u.Username, &u.FullName, &u.Email, &u.Role, &u.CreatedAt); err != nil {
+			l.Error().Err(err).Send()
+			return nil, errorspkg.ErrInternal
+		}
+
+		items = append(items, u)
+	}
+
+	if err := rows.Close(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	if err := rows.Err(); err != nil {
+		l.Error().Err(err).Send()
+		return nil, errorspkg.ErrInternal
+	}
+
+	return items, nil
+}
@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"log"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -130,6 +131,7 @@ func TestCreate(t *testing.T) {
 				HashedPassword:    arg.HashedPassword,
 				FullName:          arg.FullName,
 				Email:             arg.Email,
+				Role:              domain.RoleCustomer,
 				PasswordChangedAt: time.Now().UTC().Truncate(time.Second),
 				CreatedAt:         time.Now().UTC().Truncate(time.Second),
 			}
@@ -205,3 +207,55 @@ func TestGet(t *testing.T) {
 		})
 	}
 }
+
+func TestSearch(t *testing.T) {
+	tx := integrationtest.SetupTX(t, dbDriver, dbSource)
+	user := helpers.SeedUser(t, tx)
+	userRepo := userrepo.NewRepoPGS(tx)
+
+	want := []domain.UserWihtoutPassword{{
+		Username:  user.Username,
+		FullName:  user.FullName,
+		Email:     user.Email,
+		Role:      user.Role,
+		CreatedAt: user.CreatedAt,
+	}}
+
+	testCases := []struct {
+		name string
+		arg  domain.SearchUsersParams
+		want []domain.UserWihtoutPassword
+	}{
+		{
+			name: "Username",
+			arg:  domain.SearchUsersParams{Query: user.Username, Limit: 10},
+			want: want,
+		},
+		{
+			name: "EmailIgnoreCase",
+			arg:  domain.SearchUsersParams{Query: strings.ToUpper(user.Email), Limit: 10},
+			want: want,
+		},
+		{
+			name: "NotFound",
+			arg:  domain.SearchUsersParams{Query: "not-found-" + user.Email, Limit: 10},
+			want: []domain.UserWihtoutPassword{},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := userRepo.Search(context.Background(), tc.arg)
+			if err != nil {
+				t.Fatalf("userRepo.Search(context.Background(), %+v) returned error: %v", tc.arg, err)
+			}
+
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateApproxTime(time.Second)); diff != "" {
+				t.Errorf("userRepo.Search(context.Background(), %+v) returned unexpected difference (-want +got):\n%s",
+					tc.arg, diff)
+			}
+		})
+	}
+}
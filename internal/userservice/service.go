@@ -18,15 +18,36 @@ type Repo interface {
 	Get(ctx context.Context, username string) (domain.User, error)
 }
 
+// Screener screens the names of the new users against the sanctions list.
+type Screener interface {
+	ScreenUser(ctx context.Context, username, fullName string) error
+}
+
+// Auditor records audit events of the user creation and logins.
+type Auditor interface {
+	Record(ctx context.Context, eventType, actor string, before, after any)
+}
+
 // Service facilitates user service layer logic.
 type Service struct {
-	repo Repo
+	repo     Repo
+	screener Screener
+	auditor  Auditor
 }
 
-// New return user service struct to manage user bussines logic.
-func New(ur Repo) *Service {
+// New return user service struct to manage user bussines logic. Names are not
+// screened if sc is nil and audit events are not recorded if a is nil.
+func New(ur Repo, sc Screener, a Auditor) *Service {
 	return &Service{
-		repo: ur,
+		repo:     ur,
+		screener: sc,
+		auditor:  a,
+	}
+}
+
+func (s *Service) audit(ctx context.Context, eventType, actor string, before, after any) {
+	if s.auditor != nil {
+		s.auditor.Record(ctx, eventType, actor, before, after)
 	}
 }
 
@@ -36,16 +57,24 @@ func NewUserWihtoutPassword(u domain.User) domain.UserWihtoutPassword {
 		Username:  u.Username,
 		FullName:  u.FullName,
 		Email:     u.Email,
+		Role:      u.Role,
 		CreatedAt: u.CreatedAt,
 	}
 }
 
-// Create creates and returns user.
+// Create creates and returns user. It returns domain.ErrSanctionsHit if the
+// full name matches the sanctions list.
 func (s *Service) Create(ctx context.Context, username, password, fullname, email string) (domain.UserWihtoutPassword, error) {
 	l := zerolog.Ctx(ctx)
 
 	var result domain.UserWihtoutPassword
 
+	if s.screener != nil {
+		if err := s.screener.ScreenUser(ctx, username, fullname); err != nil {
+			return result, err
+		}
+	}
+
 	hashedPassword, err := passpkg.Hash(password)
 	if err != nil {
 		l.Error().Err(err).Send()
@@ -66,10 +95,13 @@ func (s *Service) Create(ctx context.Context, username, password, fullname, emai
 
 	result = NewUserWihtoutPassword(gotUser)
 
+	s.audit(ctx, domain.AuditUserCreated, username, nil, result)
+
 	return result, nil
 }
 
-// CheckPassword checks if the password is valid for the given username.
+// CheckPassword checks if the password is valid for the given username and
+// records the login attempt.
 func (s *Service) CheckPassword(ctx context.Context, username, pass string) (domain.UserWihtoutPassword, error) {
 	l := zerolog.Ctx(ctx)
 
@@ -77,16 +109,29 @@ func (s *Service) CheckPassword(ctx context.Context, username, pass string) (dom
 
 	gotUser, err := s.repo.Get(ctx, username)
 	if err != nil {
+		if err == domain.ErrUserNotFound {
+			s.audit(ctx, domain.AuditLoginFailed, username, nil, loginFailure{Reason: err.Error()})
+		}
+
 		return response, err
 	}
 
 	err = passpkg.Check(pass, gotUser.HashedPassword)
 	if err != nil {
 		l.Warn().Err(err).Send()
+		s.audit(ctx, domain.AuditLoginFailed, username, nil, loginFailure{Reason: domain.ErrWrongPassword.Error()})
+
 		return response, domain.ErrWrongPassword
 	}
 
 	response = NewUserWihtoutPassword(gotUser)
 
+	s.audit(ctx, domain.AuditLoginSucceeded, username, nil, response)
+
 	return response, nil
 }
+
+// loginFailure is the audit snapshot of the failed login attempt.
+type loginFailure struct {
+	Reason string `json:"reason"`
+}
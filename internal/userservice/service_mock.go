@@ -64,3 +64,75 @@ func (mr *MockRepoMockRecorder) Get(ctx, username interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepo)(nil).Get), ctx, username)
 }
+
+// MockScreener is a mock of Screener interface.
+type MockScreener struct {
+	ctrl     *gomock.Controller
+	recorder *MockScreenerMockRecorder
+}
+
+// MockScreenerMockRecorder is the mock recorder for MockScreener.
+type MockScreenerMockRecorder struct {
+	mock *MockScreener
+}
+
+// NewMockScreener creates a new mock instance.
+func NewMockScreener(ctrl *gomock.Controller) *MockScreener {
+	mock := &MockScreener{ctrl: ctrl}
+	mock.recorder = &MockScreenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScreener) EXPECT() *MockScreenerMockRecorder {
+	return m.recorder
+}
+
+// ScreenUser mocks base method.
+func (m *MockScreener) ScreenUser(ctx context.Context, username, fullName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScreenUser", ctx, username, fullName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScreenUser indicates an expected call of ScreenUser.
+func (mr *MockScreenerMockRecorder) ScreenUser(ctx, username, fullName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScreenUser", reflect.TypeOf((*MockScreener)(nil).ScreenUser), ctx, username, fullName)
+}
+
+// MockAuditor is a mock of Auditor interface.
+type MockAuditor struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditorMockRecorder
+}
+
+// MockAuditorMockRecorder is the mock recorder for MockAuditor.
+type MockAuditorMockRecorder struct {
+	mock *MockAuditor
+}
+
+// NewMockAuditor creates a new mock instance.
+func NewMockAuditor(ctrl *gomock.Controller) *MockAuditor {
+	mock := &MockAuditor{ctrl: ctrl}
+	mock.recorder = &MockAuditorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditor) EXPECT() *MockAuditorMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockAuditor) Record(ctx context.Context, eventType, actor string, before, after any) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", ctx, eventType, actor, before, after)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAuditorMockRecorder) Record(ctx, eventType, actor, before, after interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAuditor)(nil).Record), ctx, eventType, actor, before, after)
+}
@@ -158,7 +158,7 @@ func TestCreate(t *testing.T) {
 			defer ctrl.Finish()
 
 			userRepo := NewMockRepo(ctrl)
-			userService := New(userRepo)
+			userService := New(userRepo, nil, nil)
 
 			tc.buildStubs(userRepo)
 
@@ -249,7 +249,7 @@ func TestCheckPassword(t *testing.T) {
 			defer ctrl.Finish()
 
 			userRepo := NewMockRepo(ctrl)
-			userService := New(userRepo)
+			userService := New(userRepo, nil, nil)
 
 			tc.buildStubs(userRepo)
 
@@ -270,3 +270,123 @@ func TestCheckPassword(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckPasswordAudit(t *testing.T) {
+	t.Parallel()
+
+	user, password := randomUser(t)
+
+	testCases := []struct {
+		name       string
+		password   string
+		buildStubs func(userRepo *MockRepo, auditor *MockAuditor)
+	}{
+		{
+			name:     "LoginSucceeded",
+			password: password,
+			buildStubs: func(userRepo *MockRepo, auditor *MockAuditor) {
+				userRepo.EXPECT().Get(gomock.Any(), user.Username).Times(1).Return(user, nil)
+				auditor.EXPECT().
+					Record(gomock.Any(), domain.AuditLoginSucceeded, user.Username, nil, NewUserWihtoutPassword(user)).
+					Times(1)
+			},
+		},
+		{
+			name:     "WrongPassword",
+			password: "wrong",
+			buildStubs: func(userRepo *MockRepo, auditor *MockAuditor) {
+				userRepo.EXPECT().Get(gomock.Any(), user.Username).Times(1).Return(user, nil)
+				auditor.EXPECT().
+					Record(gomock.Any(), domain.AuditLoginFailed, user.Username, nil, loginFailure{Reason: domain.ErrWrongPassword.Error()}).
+					Times(1)
+			},
+		},
+		{
+			name:     "UserNotFound",
+			password: password,
+			buildStubs: func(userRepo *MockRepo, auditor *MockAuditor) {
+				userRepo.EXPECT().Get(gomock.Any(), user.Username).Times(1).Return(domain.User{}, domain.ErrUserNotFound)
+				auditor.EXPECT().
+					Record(gomock.Any(), domain.AuditLoginFailed, user.Username, nil, loginFailure{Reason: domain.ErrUserNotFound.Error()}).
+					Times(1)
+			},
+		},
+		{
+			name:     "InternalErrorIsNotRecorded",
+			password: password,
+			buildStubs: func(userRepo *MockRepo, auditor *MockAuditor) {
+				userRepo.EXPECT().Get(gomock.Any(), user.Username).Times(1).Return(domain.User{}, errorspkg.ErrInternal)
+				auditor.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := NewMockRepo(ctrl)
+			auditor := NewMockAuditor(ctrl)
+			tc.buildStubs(userRepo, auditor)
+
+			New(userRepo, nil, auditor).CheckPassword(context.Background(), user.Username, tc.password)
+		})
+	}
+}
+
+func TestCreateScreening(t *testing.T) {
+	t.Parallel()
+
+	user, password := randomUser(t)
+
+	testCases := []struct {
+		name       string
+		screenErr  error
+		buildStubs func(userRepo *MockRepo)
+		wantError  error
+	}{
+		{
+			name: "OK",
+			buildStubs: func(userRepo *MockRepo) {
+				userRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(user, nil)
+			},
+		},
+		{
+			name:      "ErrSanctionsHit",
+			screenErr: domain.ErrSanctionsHit,
+			buildStubs: func(userRepo *MockRepo) {
+				userRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantError: domain.ErrSanctionsHit,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			userRepo := NewMockRepo(ctrl)
+			tc.buildStubs(userRepo)
+
+			screener := NewMockScreener(ctrl)
+			screener.EXPECT().
+				ScreenUser(gomock.Any(), gomock.Eq(user.Username), gomock.Eq(user.FullName)).
+				Times(1).
+				Return(tc.screenErr)
+
+			_, err := New(userRepo, screener, nil).Create(context.Background(), user.Username, password, user.FullName, user.Email)
+			if err != tc.wantError {
+				t.Errorf("userService.Create(ctx, %v, ...) returned error: %v, want %v", user.Username, err, tc.wantError)
+			}
+		})
+	}
+}
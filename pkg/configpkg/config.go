@@ -18,6 +18,63 @@ type Config struct {
 	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
 	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
 	Environement         string        `mapstructure:"GO_ENV"`
+	// IdempotencyKeyTTL is how long transfer idempotency keys are kept.
+	IdempotencyKeyTTL time.Duration `mapstructure:"IDEMPOTENCY_KEY_TTL"`
+	// IdempotencyReaperInterval is how often expired idempotency keys are
+	// removed. They are not removed if it is not positive.
+	IdempotencyReaperInterval time.Duration `mapstructure:"IDEMPOTENCY_REAPER_INTERVAL"`
+	// FXRatesFile is the JSON file with exchange rates. Built-in rates are used if empty.
+	FXRatesFile string `mapstructure:"FX_RATES_FILE"`
+	// FXQuoteDuration is how long an exchange rate quote can be used for a transfer.
+	FXQuoteDuration time.Duration `mapstructure:"FX_QUOTE_DURATION"`
+	// RevocationCacheTTL is how long session and password change state used to
+	// check access token revocation is cached. It is not cached if zero.
+	RevocationCacheTTL time.Duration `mapstructure:"REVOCATION_CACHE_TTL"`
+	// TokenFormat is one of paseto.v2.local, paseto.v2.public, paseto.v4.public
+	// and jwt.eddsa. Symmetric paseto.v2.local is used if empty.
+	TokenFormat string `mapstructure:"TOKEN_FORMAT"`
+	// TokenSigningKeyID is the kid of the Ed25519 key which signs new tokens.
+	TokenSigningKeyID string `mapstructure:"TOKEN_SIGNING_KEY_ID"`
+	// TokenSigningKey is the base64 encoded Ed25519 seed of the signing key.
+	TokenSigningKey string `mapstructure:"TOKEN_SIGNING_KEY"`
+	// TokenVerificationKeys is the comma separated list of kid=base64 Ed25519
+	// public keys accepted besides the signing key, e.g. the rotated out ones.
+	TokenVerificationKeys string `mapstructure:"TOKEN_VERIFICATION_KEYS"`
+	// ReconciliationInterval is how often the ledger is reconciled by the
+	// server. Zero disables the scheduled reconciliation.
+	ReconciliationInterval time.Duration `mapstructure:"RECONCILIATION_INTERVAL"`
+	// HoldDuration is how long a hold reserves the funds unless it is
+	// captured or voided.
+	HoldDuration time.Duration `mapstructure:"HOLD_DURATION"`
+	// HoldReaperInterval is how often the expired holds are released. They
+	// are not released if it is not positive, though they can't be captured.
+	HoldReaperInterval time.Duration `mapstructure:"HOLD_REAPER_INTERVAL"`
+	// SchedulerInterval is how often the due scheduled transfers are executed.
+	// They are not executed if it is not positive.
+	SchedulerInterval time.Duration `mapstructure:"SCHEDULER_INTERVAL"`
+	// SchedulerLease is how long a claimed scheduled transfer is kept from the
+	// other instances. It must exceed the time needed to execute a batch.
+	SchedulerLease time.Duration `mapstructure:"SCHEDULER_LEASE"`
+	// PayeeCoolingOff is how long a new payee can't be paid for. New payees
+	// can be paid immediately if zero.
+	PayeeCoolingOff time.Duration `mapstructure:"PAYEE_COOLING_OFF"`
+	// TransferLimitPerTransaction, TransferLimitDaily and TransferLimitMonthly
+	// are the default outgoing transfer limits as comma separated
+	// currency:amount pairs, e.g. USD:10000,EUR:9000. Currencies not listed
+	// are unlimited unless admins set a limit for the user.
+	TransferLimitPerTransaction string `mapstructure:"TRANSFER_LIMIT_PER_TRANSACTION"`
+	TransferLimitDaily          string `mapstructure:"TRANSFER_LIMIT_DAILY"`
+	TransferLimitMonthly        string `mapstructure:"TRANSFER_LIMIT_MONTHLY"`
+	// MonitoringRulesFile is the YAML or JSON file with the transaction
+	// monitoring rules. Transfers are not monitored if empty.
+	MonitoringRulesFile string `mapstructure:"MONITORING_RULES_FILE"`
+	// SanctionsListFile is the CSV or XML file in the format of the OFAC SDN
+	// list which new users and transfer recipients are screened against. It
+	// is reread once changed. Names are not screened if empty.
+	SanctionsListFile string `mapstructure:"SANCTIONS_LIST_FILE"`
+	// SanctionsMatchThreshold is the minimum similarity, from 0 to 1, of the
+	// screened name to a listed name which blocks the action.
+	SanctionsMatchThreshold float64 `mapstructure:"SANCTIONS_MATCH_THRESHOLD"`
 }
 
 // Load read configuration from file or environment variables.
@@ -0,0 +1,51 @@
+package dbpkg
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// JSONMap is a JSON object stored in a json or jsonb column. An empty map is
+// stored as NULL.
+type JSONMap map[string]any
+
+// Value implements the driver.Valuer interface.
+func (m JSONMap) Value() (driver.Value, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(map[string]any(m))
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (m *JSONMap) Scan(src any) error {
+	var b []byte
+
+	switch v := src.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("dbpkg: can't scan %T into JSONMap", src)
+	}
+
+	var obj map[string]any
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return err
+	}
+
+	*m = obj
+
+	return nil
+}
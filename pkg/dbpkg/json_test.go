@@ -0,0 +1,39 @@
+package dbpkg
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestJSONMap(t *testing.T) {
+	t.Parallel()
+
+	if v, err := JSONMap(nil).Value(); v != nil || err != nil {
+		t.Errorf("JSONMap(nil).Value() = %v, %v, want nil, nil", v, err)
+	}
+
+	m := JSONMap{"order": "A-1", "items": float64(2)}
+
+	v, err := m.Value()
+	if err != nil {
+		t.Fatalf("m.Value() returned error: %v", err)
+	}
+
+	var got JSONMap
+	if err := got.Scan([]byte(v.(string))); err != nil {
+		t.Fatalf("got.Scan(%q) returned error: %v", v, err)
+	}
+
+	if diff := cmp.Diff(m, got); diff != "" {
+		t.Errorf("Scan(Value()) returned unexpected diff: %s", diff)
+	}
+
+	if err := got.Scan(nil); err != nil || got != nil {
+		t.Errorf("got.Scan(nil) = %v, got %v, want nil map", err, got)
+	}
+
+	if err := got.Scan(42); err == nil {
+		t.Error("got.Scan(42) returned nil error")
+	}
+}
@@ -0,0 +1,98 @@
+// Package fxpkg provides foreign exchange rate providers.
+package fxpkg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrRateNotFound indicates that there is no exchange rate for the currency pair.
+var ErrRateNotFound = errors.New("exchange rate not found")
+
+// DefaultRates holds exchange rates used when no rates file is configured.
+var DefaultRates = map[string]string{
+	"USD/EUR": "0.92",
+	"USD/RMB": "7.24",
+	"EUR/RMB": "7.87",
+}
+
+// RateProvider is an interface for getting exchange rates.
+type RateProvider interface {
+	// Rate returns how many units of the to currency one unit of the from currency buys.
+	Rate(ctx context.Context, from, to string) (decimal.Decimal, error)
+}
+
+// StaticProvider is a RateProvider serving rates from an in-memory table.
+type StaticProvider struct {
+	rates map[string]decimal.Decimal
+}
+
+// NewStaticProvider creates a new StaticProvider.
+//
+// The rates are keyed by currency pair in the "FROM/TO" form, e.g. "USD/EUR".
+// Inverse rates are derived for pairs that are given in one direction only.
+func NewStaticProvider(rates map[string]string) (RateProvider, error) {
+	p := &StaticProvider{
+		rates: make(map[string]decimal.Decimal, len(rates)),
+	}
+
+	for pair, rate := range rates {
+		currencies := strings.Split(pair, "/")
+		if len(currencies) != 2 || currencies[0] == "" || currencies[1] == "" {
+			return nil, fmt.Errorf("invalid currency pair %q", pair)
+		}
+
+		r, err := decimal.NewFromString(rate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate for %q: %w", pair, err)
+		}
+
+		if r.LessThanOrEqual(decimal.Zero) {
+			return nil, fmt.Errorf("invalid rate for %q: must be positive", pair)
+		}
+
+		p.rates[pair] = r
+	}
+
+	return p, nil
+}
+
+// NewFileProvider creates a new StaticProvider with rates read from a JSON file.
+//
+// The file holds an object of currency pairs to rates, e.g. {"USD/EUR": "0.92"}.
+func NewFileProvider(path string) (RateProvider, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var rates map[string]string
+	if err := json.Unmarshal(b, &rates); err != nil {
+		return nil, fmt.Errorf("cannot parse rates file %q: %w", path, err)
+	}
+
+	return NewStaticProvider(rates)
+}
+
+// Rate returns the exchange rate for the currency pair.
+func (p *StaticProvider) Rate(_ context.Context, from, to string) (decimal.Decimal, error) {
+	if from == to {
+		return decimal.NewFromInt(1), nil
+	}
+
+	if r, ok := p.rates[from+"/"+to]; ok {
+		return r, nil
+	}
+
+	if r, ok := p.rates[to+"/"+from]; ok {
+		return decimal.NewFromInt(1).DivRound(r, 8), nil
+	}
+
+	return decimal.Decimal{}, ErrRateNotFound
+}
@@ -0,0 +1,93 @@
+package fxpkg
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStaticProviderRate(t *testing.T) {
+	provider, err := NewStaticProvider(map[string]string{"USD/EUR": "0.8"})
+	if err != nil {
+		t.Fatalf("NewStaticProvider() returned error: %v", err)
+	}
+
+	testCases := []struct {
+		name    string
+		from    string
+		to      string
+		want    string
+		wantErr error
+	}{
+		{name: "Direct", from: "USD", to: "EUR", want: "0.8"},
+		{name: "Inverse", from: "EUR", to: "USD", want: "1.25"},
+		{name: "SameCurrency", from: "USD", to: "USD", want: "1"},
+		{name: "ErrRateNotFound", from: "USD", to: "RMB", wantErr: ErrRateNotFound},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := provider.Rate(context.Background(), tc.from, tc.to)
+			if err != tc.wantErr {
+				t.Fatalf("provider.Rate(ctx, %v, %v) returned error: %v, want %v", tc.from, tc.to, err, tc.wantErr)
+			}
+
+			if err == nil && got.String() != tc.want {
+				t.Errorf("provider.Rate(ctx, %v, %v) = %v, want %v", tc.from, tc.to, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewStaticProviderInvalidRates(t *testing.T) {
+	testCases := []struct {
+		name  string
+		rates map[string]string
+	}{
+		{name: "InvalidPair", rates: map[string]string{"USDEUR": "0.8"}},
+		{name: "InvalidRate", rates: map[string]string{"USD/EUR": "abc"}},
+		{name: "NegativeRate", rates: map[string]string{"USD/EUR": "-1"}},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := NewStaticProvider(tc.rates); err == nil {
+				t.Errorf("NewStaticProvider(%v) returned nil error, want error", tc.rates)
+			}
+		})
+	}
+}
+
+func TestNewFileProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	if err := os.WriteFile(path, []byte(`{"EUR/RMB": "7.5"}`), 0o600); err != nil {
+		t.Fatalf("os.WriteFile(%v) returned error: %v", path, err)
+	}
+
+	provider, err := NewFileProvider(path)
+	if err != nil {
+		t.Fatalf("NewFileProvider(%v) returned error: %v", path, err)
+	}
+
+	got, err := provider.Rate(context.Background(), "EUR", "RMB")
+	if err != nil {
+		t.Fatalf("provider.Rate(ctx, EUR, RMB) returned error: %v", err)
+	}
+
+	if got.String() != "7.5" {
+		t.Errorf("provider.Rate(ctx, EUR, RMB) = %v, want 7.5", got)
+	}
+
+	if _, err := NewFileProvider(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("NewFileProvider(missing file) returned nil error, want error")
+	}
+}
@@ -0,0 +1,131 @@
+// Package pagepkg provides offset and keyset pagination for list endpoints.
+package pagepkg
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidPageToken indicates that the page token is malformed.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// Cursor points to the position of a page in the list ordered by ID.
+//
+// Only one of AfterID and BeforeID is set.
+type Cursor struct {
+	AfterID  int64 `json:"a,omitempty"`
+	BeforeID int64 `json:"b,omitempty"`
+}
+
+// IsZero reports whether the cursor points to nothing.
+func (c Cursor) IsZero() bool {
+	return c.AfterID == 0 && c.BeforeID == 0
+}
+
+// Token returns the opaque page token of the cursor.
+func (c Cursor) Token() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Parse returns the cursor encoded in the page token.
+func Parse(token string) (Cursor, error) {
+	var c Cursor
+
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, ErrInvalidPageToken
+	}
+
+	if err := json.Unmarshal(b, &c); err != nil {
+		return Cursor{}, ErrInvalidPageToken
+	}
+
+	if c.AfterID < 0 || c.BeforeID < 0 || c.IsZero() || (c.AfterID != 0 && c.BeforeID != 0) {
+		return Cursor{}, ErrInvalidPageToken
+	}
+
+	return c, nil
+}
+
+// Request is the requested page.
+//
+// The page is located by Cursor if it is set, otherwise by PageID.
+type Request struct {
+	PageID   int32
+	PageSize int32
+	Cursor   Cursor
+}
+
+// Limit returns the number of rows to fetch. One extra row is fetched to find
+// out whether there are more rows beyond the page.
+func (r Request) Limit() int32 {
+	return r.PageSize + 1
+}
+
+// Offset returns the number of rows to skip.
+func (r Request) Offset() int32 {
+	if !r.Cursor.IsZero() || r.PageID < 1 {
+		return 0
+	}
+
+	return (r.PageID - 1) * r.PageSize
+}
+
+// Page holds the tokens of the neighbouring pages. Empty token means there is
+// no such page.
+type Page struct {
+	Next string
+	Prev string
+}
+
+// Trim cuts the extra row fetched for the request and returns the tokens of
+// the neighbouring pages. The items must be sorted by ID in ascending order.
+func Trim[T any](items []T, r Request, id func(T) int64) ([]T, Page) {
+	var page Page
+
+	hasMore := int32(len(items)) > r.PageSize
+
+	if r.Cursor.BeforeID != 0 {
+		// Backward page holds the rows right before the cursor, so the extra
+		// row is the first one.
+		if hasMore {
+			items = items[len(items)-int(r.PageSize):]
+		}
+
+		if len(items) > 0 {
+			page.Next = Cursor{AfterID: id(items[len(items)-1])}.Token()
+
+			if hasMore {
+				page.Prev = Cursor{BeforeID: id(items[0])}.Token()
+			}
+		}
+
+		return items, page
+	}
+
+	if hasMore {
+		items = items[:r.PageSize]
+	}
+
+	if len(items) > 0 {
+		if hasMore {
+			page.Next = Cursor{AfterID: id(items[len(items)-1])}.Token()
+		}
+
+		if r.Cursor.AfterID != 0 || r.Offset() > 0 {
+			page.Prev = Cursor{BeforeID: id(items[0])}.Token()
+		}
+	}
+
+	return items, page
+}
+
+// Reverse reverses the order of the items in place. It is used by
+// repositories to restore ascending order of a page fetched backwards.
+func Reverse[T any](items []T) {
+	for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+		items[i], items[j] = items[j], items[i]
+	}
+}
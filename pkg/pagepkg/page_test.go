@@ -0,0 +1,189 @@
+package pagepkg
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name    string
+		token   string
+		want    Cursor
+		wantErr error
+	}{
+		{
+			name:  "After",
+			token: Cursor{AfterID: 10}.Token(),
+			want:  Cursor{AfterID: 10},
+		},
+		{
+			name:  "Before",
+			token: Cursor{BeforeID: 7}.Token(),
+			want:  Cursor{BeforeID: 7},
+		},
+		{
+			name:    "NotBase64",
+			token:   "!!!",
+			wantErr: ErrInvalidPageToken,
+		},
+		{
+			name:    "NotJSON",
+			token:   "YWJj",
+			wantErr: ErrInvalidPageToken,
+		},
+		{
+			name:    "Empty",
+			token:   Cursor{}.Token(),
+			wantErr: ErrInvalidPageToken,
+		},
+		{
+			name:    "Both",
+			token:   Cursor{AfterID: 1, BeforeID: 5}.Token(),
+			wantErr: ErrInvalidPageToken,
+		},
+		{
+			name:    "Negative",
+			token:   Cursor{AfterID: -1}.Token(),
+			wantErr: ErrInvalidPageToken,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Parse(tc.token)
+			if err != tc.wantErr {
+				t.Fatalf("Parse(%q) returned error: %v, want %v", tc.token, err, tc.wantErr)
+			}
+
+			if got != tc.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", tc.token, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOffset(t *testing.T) {
+	testCases := []struct {
+		name string
+		req  Request
+		want int32
+	}{
+		{
+			name: "FirstPage",
+			req:  Request{PageID: 1, PageSize: 5},
+			want: 0,
+		},
+		{
+			name: "ThirdPage",
+			req:  Request{PageID: 3, PageSize: 5},
+			want: 10,
+		},
+		{
+			name: "Cursor",
+			req:  Request{PageID: 3, PageSize: 5, Cursor: Cursor{AfterID: 4}},
+			want: 0,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.req.Offset(); got != tc.want {
+				t.Errorf("%+v.Offset() = %v, want %v", tc.req, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTrim(t *testing.T) {
+	id := func(i int64) int64 { return i }
+
+	testCases := []struct {
+		name     string
+		items    []int64
+		req      Request
+		want     []int64
+		wantPage Page
+	}{
+		{
+			name:  "FirstPageHasMore",
+			items: []int64{1, 2, 3},
+			req:   Request{PageID: 1, PageSize: 2},
+			want:  []int64{1, 2},
+			wantPage: Page{
+				Next: Cursor{AfterID: 2}.Token(),
+			},
+		},
+		{
+			name:  "LastPage",
+			items: []int64{5, 6},
+			req:   Request{PageID: 3, PageSize: 2},
+			want:  []int64{5, 6},
+			wantPage: Page{
+				Prev: Cursor{BeforeID: 5}.Token(),
+			},
+		},
+		{
+			name:  "AfterCursor",
+			items: []int64{3, 4, 5},
+			req:   Request{PageSize: 2, Cursor: Cursor{AfterID: 2}},
+			want:  []int64{3, 4},
+			wantPage: Page{
+				Next: Cursor{AfterID: 4}.Token(),
+				Prev: Cursor{BeforeID: 3}.Token(),
+			},
+		},
+		{
+			name:  "BeforeCursorHasMore",
+			items: []int64{1, 2, 3},
+			req:   Request{PageSize: 2, Cursor: Cursor{BeforeID: 4}},
+			want:  []int64{2, 3},
+			wantPage: Page{
+				Next: Cursor{AfterID: 3}.Token(),
+				Prev: Cursor{BeforeID: 2}.Token(),
+			},
+		},
+		{
+			name:  "BeforeCursorFirstPage",
+			items: []int64{1, 2},
+			req:   Request{PageSize: 2, Cursor: Cursor{BeforeID: 3}},
+			want:  []int64{1, 2},
+			wantPage: Page{
+				Next: Cursor{AfterID: 2}.Token(),
+			},
+		},
+		{
+			name:     "Empty",
+			items:    []int64{},
+			req:      Request{PageSize: 2, Cursor: Cursor{AfterID: 9}},
+			want:     []int64{},
+			wantPage: Page{},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, gotPage := Trim(tc.items, tc.req, id)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Trim(%v, %+v, id) returned unexpected items (-want +got):\n%s", tc.items, tc.req, diff)
+			}
+
+			if gotPage != tc.wantPage {
+				t.Errorf("Trim(%v, %+v, id) returned page %+v, want %+v", tc.items, tc.req, gotPage, tc.wantPage)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+// Package requestpkg carries the http request metadata through the context.
+package requestpkg
+
+import "context"
+
+// Info holds the metadata of the http request.
+type Info struct {
+	ID       string // X-Request-ID
+	ClientIP string
+}
+
+type ctxKey struct{}
+
+// NewContext returns a copy of ctx holding the request info.
+func NewContext(ctx context.Context, info Info) context.Context {
+	return context.WithValue(ctx, ctxKey{}, info)
+}
+
+// FromContext returns the request info stored in ctx, or zero Info if there
+// is none.
+func FromContext(ctx context.Context) Info {
+	info, _ := ctx.Value(ctxKey{}).(Info)
+	return info
+}
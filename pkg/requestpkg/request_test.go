@@ -0,0 +1,18 @@
+package requestpkg
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFromContext(t *testing.T) {
+	info := Info{ID: "8c1e2c4a", ClientIP: "123.123.123.123"}
+
+	if got := FromContext(NewContext(context.Background(), info)); got != info {
+		t.Errorf("FromContext(NewContext(ctx, %+v)) = %+v", info, got)
+	}
+
+	if got := FromContext(context.Background()); got != (Info{}) {
+		t.Errorf("FromContext(context.Background()) = %+v, want zero Info", got)
+	}
+}
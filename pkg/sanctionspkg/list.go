@@ -0,0 +1,285 @@
+// Package sanctionspkg provides sanctions list parsing and fuzzy name matching.
+package sanctionspkg
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Sanctions list file formats.
+const (
+	FormatCSV = "csv"
+	FormatXML = "xml"
+)
+
+// Entry is a sanctioned person, entity, vessel or aircraft.
+type Entry struct {
+	UID      string   `json:"uid"`
+	Name     string   `json:"name"`
+	Type     string   `json:"type,omitempty"`
+	Programs []string `json:"programs,omitempty"`
+	Aliases  []string `json:"aliases,omitempty"`
+}
+
+// Match is the list entry which name or alias is similar to the screened name.
+type Match struct {
+	UID   string  `json:"uid"`
+	Name  string  `json:"name"` // the matched name or alias of the entry
+	Score float64 `json:"score"`
+}
+
+// List holds the sanctions list entries with their names prepared for
+// matching. It is safe for concurrent use.
+type List struct {
+	entries []Entry
+	names   []listName
+}
+
+// listName is the name or alias of the entry at the given index.
+type listName struct {
+	entry      int
+	name       string
+	normalized []rune
+}
+
+// NewList returns the list of the entries.
+func NewList(entries []Entry) *List {
+	l := &List{entries: entries}
+
+	for i, e := range entries {
+		for _, name := range append([]string{e.Name}, e.Aliases...) {
+			normalized := []rune(Normalize(name))
+			if len(normalized) == 0 {
+				continue
+			}
+
+			l.names = append(l.names, listName{entry: i, name: name, normalized: normalized})
+		}
+	}
+
+	return l
+}
+
+// Len returns the number of the list entries.
+func (l *List) Len() int {
+	return len(l.entries)
+}
+
+// Match returns the entries which name or any of the aliases is similar to
+// the name with at least the threshold score, from 0 to 1. Every entry is
+// returned once with its best scoring name, the best matches first.
+func (l *List) Match(name string, threshold float64) []Match {
+	normalized := []rune(Normalize(name))
+	if len(normalized) == 0 {
+		return nil
+	}
+
+	best := make(map[int]Match)
+
+	for _, n := range l.names {
+		score := similarity(normalized, n.normalized)
+		if score < threshold {
+			continue
+		}
+
+		if m, ok := best[n.entry]; ok && m.Score >= score {
+			continue
+		}
+
+		best[n.entry] = Match{UID: l.entries[n.entry].UID, Name: n.name, Score: score}
+	}
+
+	matches := make([]Match, 0, len(best))
+	for _, m := range best {
+		matches = append(matches, m)
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+
+		return matches[i].UID < matches[j].UID
+	})
+
+	return matches
+}
+
+// Load reads the sanctions list from the file. The format is given by the
+// file extension, .csv or .xml.
+func Load(path string) (*List, error) {
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	if format != FormatCSV && format != FormatXML {
+		return nil, fmt.Errorf("unsupported sanctions list file %q: want .csv or .xml", path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	list, err := Parse(f, format)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse sanctions list file %q: %w", path, err)
+	}
+
+	return list, nil
+}
+
+// Parse reads the sanctions list in the format of the OFAC SDN list.
+//
+// The CSV format has no header and the columns of sdn.csv: uid, name, type,
+// programs, title, call sign, vessel type, tonnage, GRT, vessel flag, vessel
+// owner and remarks. Only uid and name are required, "-0-" marks an empty
+// value. The programs are separated by "] [" and the aliases are given in the
+// remarks as a.k.a. 'NAME'.
+//
+// The XML format is the one of sdn.xml: sdnEntry elements with uid, firstName,
+// lastName, sdnType, programList and akaList.
+func Parse(r io.Reader, format string) (*List, error) {
+	var (
+		entries []Entry
+		err     error
+	)
+
+	switch format {
+	case FormatCSV:
+		entries, err = parseCSV(r)
+	case FormatXML:
+		entries, err = parseXML(r)
+	default:
+		return nil, fmt.Errorf("unsupported sanctions list format %q", format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewList(entries), nil
+}
+
+// akaPattern matches the aliases in the remarks of the CSV entries.
+var akaPattern = regexp.MustCompile(`a\.k\.a\. '([^']+)'`)
+
+func parseCSV(r io.Reader) ([]Entry, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+
+	var entries []Entry
+
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := cr.FieldPos(0)
+
+		// sdn.csv ends with the SUB control character on its own line.
+		if len(record) == 1 && strings.Trim(record[0], " \x1a") == "" {
+			continue
+		}
+
+		field := func(i int) string {
+			if i >= len(record) {
+				return ""
+			}
+
+			v := strings.TrimSpace(record[i])
+			if v == "-0-" {
+				return ""
+			}
+
+			return v
+		}
+
+		e := Entry{
+			UID:  field(0),
+			Name: field(1),
+			Type: field(2),
+		}
+
+		if e.UID == "" || e.Name == "" {
+			return nil, fmt.Errorf("line %d: uid and name are required", line)
+		}
+
+		if programs := strings.Trim(field(3), "[]"); programs != "" {
+			e.Programs = strings.Split(programs, "] [")
+		}
+
+		for _, m := range akaPattern.FindAllStringSubmatch(field(11), -1) {
+			e.Aliases = append(e.Aliases, m[1])
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
+
+type xmlList struct {
+	Entries []xmlEntry `xml:"sdnEntry"`
+}
+
+type xmlEntry struct {
+	UID       string    `xml:"uid"`
+	FirstName string    `xml:"firstName"`
+	LastName  string    `xml:"lastName"`
+	Type      string    `xml:"sdnType"`
+	Programs  []string  `xml:"programList>program"`
+	Akas      []xmlName `xml:"akaList>aka"`
+}
+
+type xmlName struct {
+	FirstName string `xml:"firstName"`
+	LastName  string `xml:"lastName"`
+}
+
+func fullName(first, last string) string {
+	return strings.TrimSpace(strings.TrimSpace(first) + " " + strings.TrimSpace(last))
+}
+
+func parseXML(r io.Reader) ([]Entry, error) {
+	var list xmlList
+	if err := xml.NewDecoder(r).Decode(&list); err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(list.Entries))
+
+	for i, x := range list.Entries {
+		e := Entry{
+			UID:      strings.TrimSpace(x.UID),
+			Name:     fullName(x.FirstName, x.LastName),
+			Type:     strings.TrimSpace(x.Type),
+			Programs: x.Programs,
+		}
+
+		if e.UID == "" || e.Name == "" {
+			return nil, fmt.Errorf("sdnEntry %d: uid and name are required", i+1)
+		}
+
+		for _, aka := range x.Akas {
+			if name := fullName(aka.FirstName, aka.LastName); name != "" {
+				e.Aliases = append(e.Aliases, name)
+			}
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,251 @@
+package sanctionspkg
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testCSV = `36,"AEROCARIBBEAN AIRLINES",-0- ,"CUBA",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0-
+173,"ANGLO-CARIBBEAN CO., LTD.",-0- ,"CUBA",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,"a.k.a. 'AN-CA CO'; a.k.a. 'ANGLO CARIBBEAN'."
+2674,"ABU NIDAL","individual","SDT] [SDGT",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,"DOB 1937; a.k.a. 'AL-BANNA, Sabri Khalil'."
+` + "\x1a\n"
+
+const testXML = `<?xml version="1.0" standalone="yes"?>
+<sdnList xmlns="http://tempuri.org/sdnList.xsd">
+  <publshInformation><Publish_Date>03/16/2023</Publish_Date></publshInformation>
+  <sdnEntry>
+    <uid>36</uid>
+    <lastName>AEROCARIBBEAN AIRLINES</lastName>
+    <sdnType>Entity</sdnType>
+    <programList><program>CUBA</program></programList>
+  </sdnEntry>
+  <sdnEntry>
+    <uid>2674</uid>
+    <firstName>Sabri Khalil</firstName>
+    <lastName>AL-BANNA</lastName>
+    <sdnType>Individual</sdnType>
+    <programList><program>SDT</program><program>SDGT</program></programList>
+    <akaList>
+      <aka><uid>1</uid><type>a.k.a.</type><category>strong</category><lastName>ABU NIDAL</lastName></aka>
+    </akaList>
+  </sdnEntry>
+</sdnList>
+`
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name   string
+		format string
+		input  string
+		want   []Entry
+	}{
+		{
+			name:   "CSV",
+			format: FormatCSV,
+			input:  testCSV,
+			want: []Entry{
+				{UID: "36", Name: "AEROCARIBBEAN AIRLINES", Programs: []string{"CUBA"}},
+				{UID: "173", Name: "ANGLO-CARIBBEAN CO., LTD.", Programs: []string{"CUBA"}, Aliases: []string{"AN-CA CO", "ANGLO CARIBBEAN"}},
+				{UID: "2674", Name: "ABU NIDAL", Type: "individual", Programs: []string{"SDT", "SDGT"}, Aliases: []string{"AL-BANNA, Sabri Khalil"}},
+			},
+		},
+		{
+			name:   "XML",
+			format: FormatXML,
+			input:  testXML,
+			want: []Entry{
+				{UID: "36", Name: "AEROCARIBBEAN AIRLINES", Type: "Entity", Programs: []string{"CUBA"}},
+				{UID: "2674", Name: "Sabri Khalil AL-BANNA", Type: "Individual", Programs: []string{"SDT", "SDGT"}, Aliases: []string{"ABU NIDAL"}},
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			list, err := Parse(strings.NewReader(tc.input), tc.format)
+			if err != nil {
+				t.Fatalf("Parse(%v) returned error: %v", tc.format, err)
+			}
+
+			if diff := cmp.Diff(tc.want, list.entries); diff != "" {
+				t.Errorf("Parse(%v) returned unexpected difference (-want +got):\n%s", tc.format, diff)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	testCases := []struct {
+		name   string
+		format string
+		input  string
+	}{
+		{name: "CSVWithoutName", format: FormatCSV, input: "36,-0- ,-0- \n"},
+		{name: "CSVWithoutUID", format: FormatCSV, input: `,"ABU NIDAL"` + "\n"},
+		{name: "XMLWithoutName", format: FormatXML, input: "<sdnList><sdnEntry><uid>1</uid></sdnEntry></sdnList>"},
+		{name: "MalformedXML", format: FormatXML, input: "<sdnList><sdnEntry>"},
+		{name: "UnsupportedFormat", format: "json", input: "[]"},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := Parse(strings.NewReader(tc.input), tc.format); err == nil {
+				t.Errorf("Parse(%q, %v) returned nil error, want error", tc.input, tc.format)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "sdn.CSV")
+	if err := os.WriteFile(path, []byte(testCSV), 0o600); err != nil {
+		t.Fatalf("os.WriteFile(%v) returned error: %v", path, err)
+	}
+
+	list, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load(%v) returned error: %v", path, err)
+	}
+
+	if list.Len() != 3 {
+		t.Errorf("Load(%v) returned %v entries, want 3", path, list.Len())
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing.xml")); err == nil {
+		t.Error("Load(missing file) returned nil error, want error")
+	}
+
+	if _, err := Load(filepath.Join(dir, "sdn.json")); err == nil {
+		t.Error("Load(json file) returned nil error, want error")
+	}
+}
+
+func TestListMatch(t *testing.T) {
+	list, err := Parse(strings.NewReader(testCSV), FormatCSV)
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+
+	testCases := []struct {
+		name      string
+		input     string
+		threshold float64
+		want      []Match
+	}{
+		{
+			name:      "ExactName",
+			input:     "Abu Nidal",
+			threshold: 0.9,
+			want:      []Match{{UID: "2674", Name: "ABU NIDAL", Score: 1}},
+		},
+		{
+			name:      "AliasInOtherOrder",
+			input:     "Sabri Khalil al Banna",
+			threshold: 0.9,
+			want:      []Match{{UID: "2674", Name: "AL-BANNA, Sabri Khalil", Score: 1}},
+		},
+		{
+			name:      "Diacritics",
+			input:     "Abú Nidál",
+			threshold: 0.9,
+			want:      []Match{{UID: "2674", Name: "ABU NIDAL", Score: 1}},
+		},
+		{
+			name:      "BestNameOfEntry",
+			input:     "Anglo Caribbean Co Ltd",
+			threshold: 0.9,
+			want:      []Match{{UID: "173", Name: "ANGLO-CARIBBEAN CO., LTD.", Score: 1}},
+		},
+		{
+			name:      "NoMatch",
+			input:     "John Smith",
+			threshold: 0.9,
+			want:      []Match{},
+		},
+		{
+			name:      "Empty",
+			input:     " - ",
+			threshold: 0.9,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := list.Match(tc.input, tc.threshold)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("list.Match(%q, %v) returned unexpected difference (-want +got):\n%s", tc.input, tc.threshold, diff)
+			}
+		})
+	}
+}
+
+func TestListMatchTypo(t *testing.T) {
+	list := NewList([]Entry{{UID: "1", Name: "Martha Stewart"}})
+
+	got := list.Match("Marhta Stewart", 0.9)
+	if len(got) != 1 || got[0].UID != "1" || got[0].Score >= 1 {
+		t.Errorf("list.Match(Marhta Stewart, 0.9) = %+v, want a partial match of entry 1", got)
+	}
+
+	if got := list.Match("Marhta Stewart", 1); len(got) != 0 {
+		t.Errorf("list.Match(Marhta Stewart, 1) = %+v, want no matches", got)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  string
+	}{
+		{input: "SMITH, John", want: "john smith"},
+		{input: "  John   Smith ", want: "john smith"},
+		{input: "José Müller-Lüdenscheidt", want: "jose ludenscheidt muller"},
+		{input: "", want: ""},
+	}
+
+	for _, tc := range testCases {
+		if got := Normalize(tc.input); got != tc.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	testCases := []struct {
+		a    string
+		b    string
+		want float64
+	}{
+		{a: "martha", b: "marhta", want: 0.961},
+		{a: "dwayne", b: "duane", want: 0.84},
+		{a: "dixon", b: "dicksonx", want: 0.813},
+		{a: "abc", b: "xyz", want: 0},
+		{a: "John Smith", b: "smith john", want: 1},
+	}
+
+	for _, tc := range testCases {
+		got := Similarity(tc.a, tc.b)
+		if math.Abs(got-tc.want) > 0.001 {
+			t.Errorf("Similarity(%q, %q) = %.3f, want %.3f", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
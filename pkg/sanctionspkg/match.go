@@ -0,0 +1,130 @@
+package sanctionspkg
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalize returns the name prepared for matching: lower case words without
+// diacritics and punctuation in alphabetical order, so that "SMITH, John" and
+// "John Smith" are the same name.
+func Normalize(name string) string {
+	// The transformer is stateful, so it is not shared.
+	stripMarks := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+	if s, _, err := transform.String(stripMarks, name); err == nil {
+		name = s
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	sort.Strings(words)
+
+	return strings.Join(words, " ")
+}
+
+// Similarity returns the Jaro-Winkler similarity of the normalized names, from
+// 0 for completely different names to 1 for the same names.
+func Similarity(a, b string) float64 {
+	return similarity([]rune(Normalize(a)), []rune(Normalize(b)))
+}
+
+// winklerPrefix is the maximum length of the common prefix which increases
+// the similarity and winklerScale is how much every prefix rune adds.
+const (
+	winklerPrefix = 4
+	winklerScale  = 0.1
+)
+
+func similarity(a, b []rune) float64 {
+	j := jaro(a, b)
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && prefix < winklerPrefix && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	return j + float64(prefix)*winklerScale*(1-j)
+}
+
+func jaro(a, b []rune) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	window := len(a)
+	if len(b) > window {
+		window = len(b)
+	}
+
+	window = window/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	aMatched := make([]bool, len(a))
+	bMatched := make([]bool, len(b))
+	matches := 0
+
+	for i := range a {
+		lo := i - window
+		if lo < 0 {
+			lo = 0
+		}
+
+		hi := i + window + 1
+		if hi > len(b) {
+			hi = len(b)
+		}
+
+		for j := lo; j < hi; j++ {
+			if bMatched[j] || a[i] != b[j] {
+				continue
+			}
+
+			aMatched[i] = true
+			bMatched[j] = true
+			matches++
+
+			break
+		}
+	}
+
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	k := 0
+
+	for i := range a {
+		if !aMatched[i] {
+			continue
+		}
+
+		for !bMatched[k] {
+			k++
+		}
+
+		if a[i] != b[k] {
+			transpositions++
+		}
+
+		k++
+	}
+
+	m := float64(matches)
+
+	return (m/float64(len(a)) + m/float64(len(b)) + (m-float64(transpositions)/2)/m) / 3
+}
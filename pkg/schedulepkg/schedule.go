@@ -0,0 +1,240 @@
+// Package schedulepkg computes the run times of one-off and recurring jobs.
+package schedulepkg
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSpec indicates that the schedule spec cannot be parsed.
+var ErrInvalidSpec = errors.New("invalid schedule spec")
+
+// Monthly is the spec of the schedule which runs every month on the day and
+// at the time of its start.
+const Monthly = "@monthly"
+
+// maxCronYears bounds the search of the next cron time, so specs that never
+// match, such as February 30, end the search.
+const maxCronYears = 5
+
+// Schedule returns the run times of a job. All times are in UTC.
+type Schedule interface {
+	// Next returns the first run time after t, or zero time if there is none.
+	Next(t time.Time) time.Time
+}
+
+// Parse returns the schedule of the spec starting at start:
+//
+//   - empty spec runs once at start;
+//   - Monthly runs on the day of start every month, or on the last day of
+//     shorter months, at the time of start;
+//   - otherwise the spec is a five field cron expression "minute hour
+//     day-of-month month day-of-week" evaluated in UTC.
+func Parse(spec string, start time.Time) (Schedule, error) {
+	start = start.UTC()
+
+	switch strings.TrimSpace(spec) {
+	case "":
+		return once{at: start}, nil
+	case Monthly:
+		return monthly{start: start}, nil
+	}
+
+	return parseCron(spec)
+}
+
+// First returns the first run time of the schedule at or after start.
+func First(s Schedule, start time.Time) time.Time {
+	return s.Next(start.Add(-time.Nanosecond))
+}
+
+type once struct {
+	at time.Time
+}
+
+func (s once) Next(t time.Time) time.Time {
+	if s.at.After(t) {
+		return s.at
+	}
+
+	return time.Time{}
+}
+
+type monthly struct {
+	start time.Time
+}
+
+func (s monthly) Next(t time.Time) time.Time {
+	t = t.UTC()
+	if t.Before(s.start) {
+		return s.start
+	}
+
+	for i := 0; ; i++ {
+		// The first day of the month keeps AddDate from normalizing into the
+		// month after.
+		month := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, i, 0)
+
+		day := s.start.Day()
+		if last := daysIn(month); day > last {
+			day = last
+		}
+
+		next := time.Date(month.Year(), month.Month(), day,
+			s.start.Hour(), s.start.Minute(), s.start.Second(), s.start.Nanosecond(), time.UTC)
+
+		if next.After(t) {
+			return next
+		}
+	}
+}
+
+func daysIn(month time.Time) int {
+	return month.AddDate(0, 1, -1).Day()
+}
+
+// cron holds the allowed values of each field as bit sets.
+type cron struct {
+	minute, hour, dom, month, dow uint64
+	// Day of month and day of week match either if both are restricted, like
+	// in the classic cron.
+	domStar, dowStar bool
+}
+
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = [5]field{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7},
+}
+
+func parseCron(spec string) (cron, error) {
+	parts := strings.Fields(spec)
+	if len(parts) != len(fields) {
+		return cron{}, fmt.Errorf("%w: %q must have %d fields", ErrInvalidSpec, spec, len(fields))
+	}
+
+	var (
+		c    cron
+		bits [5]uint64
+	)
+
+	for i, f := range fields {
+		b, err := parseField(parts[i], f)
+		if err != nil {
+			return cron{}, err
+		}
+
+		bits[i] = b
+	}
+
+	c.minute, c.hour, c.dom, c.month, c.dow = bits[0], bits[1], bits[2], bits[3], bits[4]
+	c.domStar = parts[2] == "*"
+	c.dowStar = parts[4] == "*"
+
+	// Both 0 and 7 are Sunday.
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+
+	return c, nil
+}
+
+// parseField parses the comma separated list of *, n or n-m items with an
+// optional /step.
+func parseField(s string, f field) (uint64, error) {
+	var bits uint64
+
+	for _, item := range strings.Split(s, ",") {
+		rng, stepStr, hasStep := strings.Cut(item, "/")
+
+		step := 1
+
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("%w: invalid %s step %q", ErrInvalidSpec, f.name, item)
+			}
+
+			step = n
+		}
+
+		lo, hi := f.min, f.max
+
+		if rng != "*" {
+			from, to, isRange := strings.Cut(rng, "-")
+
+			var err error
+
+			if lo, err = strconv.Atoi(from); err != nil {
+				return 0, fmt.Errorf("%w: invalid %s %q", ErrInvalidSpec, f.name, item)
+			}
+
+			hi = lo
+
+			if isRange {
+				if hi, err = strconv.Atoi(to); err != nil {
+					return 0, fmt.Errorf("%w: invalid %s %q", ErrInvalidSpec, f.name, item)
+				}
+			} else if hasStep {
+				hi = f.max
+			}
+		}
+
+		if lo < f.min || hi > f.max || lo > hi {
+			return 0, fmt.Errorf("%w: %s %q out of range %d-%d", ErrInvalidSpec, f.name, item, f.min, f.max)
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+func has(bits uint64, v int) bool {
+	return bits&(1<<uint(v)) != 0
+}
+
+func (c cron) dayMatches(t time.Time) bool {
+	dom := has(c.dom, t.Day())
+	dow := has(c.dow, int(t.Weekday()))
+
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+
+	return dom || dow
+}
+
+func (c cron) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxCronYears, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case !has(c.month, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case !has(c.hour, t.Hour()):
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case !has(c.minute, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
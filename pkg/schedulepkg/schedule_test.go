@@ -0,0 +1,110 @@
+package schedulepkg
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+func TestNext(t *testing.T) {
+	start := date("2023-01-31T09:00:00Z")
+
+	testCases := []struct {
+		name  string
+		spec  string
+		after string
+		want  string
+	}{
+		{name: "OnceBefore", spec: "", after: "2023-01-01T00:00:00Z", want: "2023-01-31T09:00:00Z"},
+		{name: "OnceAfter", spec: "", after: "2023-01-31T09:00:00Z", want: ""},
+		{name: "MonthlyFirst", spec: Monthly, after: "2023-01-01T00:00:00Z", want: "2023-01-31T09:00:00Z"},
+		{name: "MonthlyShortMonth", spec: Monthly, after: "2023-01-31T09:00:00Z", want: "2023-02-28T09:00:00Z"},
+		{name: "MonthlyLongMonth", spec: Monthly, after: "2023-02-28T09:00:00Z", want: "2023-03-31T09:00:00Z"},
+		{name: "MonthlySameDayLater", spec: Monthly, after: "2023-04-30T10:00:00Z", want: "2023-05-31T09:00:00Z"},
+		{name: "CronFirstOfMonth", spec: "0 9 1 * *", after: "2023-01-31T09:00:00Z", want: "2023-02-01T09:00:00Z"},
+		{name: "CronStep", spec: "*/15 * * * *", after: "2023-01-31T09:07:30Z", want: "2023-01-31T09:15:00Z"},
+		{name: "CronList", spec: "0 8,20 * * *", after: "2023-01-31T09:00:00Z", want: "2023-01-31T20:00:00Z"},
+		{name: "CronWeekdays", spec: "30 9 * * 1-5", after: "2023-02-03T10:00:00Z", want: "2023-02-06T09:30:00Z"},
+		{name: "CronSunday7", spec: "0 0 * * 7", after: "2023-02-01T00:00:00Z", want: "2023-02-05T00:00:00Z"},
+		{name: "CronDayOrWeekday", spec: "0 0 15 * 1", after: "2023-02-07T00:00:00Z", want: "2023-02-13T00:00:00Z"},
+		{name: "CronYearEnd", spec: "0 0 1 1 *", after: "2023-01-31T09:00:00Z", want: "2024-01-01T00:00:00Z"},
+		{name: "CronNever", spec: "0 0 30 2 *", after: "2023-01-31T09:00:00Z", want: ""},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, err := Parse(tc.spec, start)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tc.spec, err)
+			}
+
+			var want time.Time
+			if tc.want != "" {
+				want = date(tc.want)
+			}
+
+			if got := s.Next(date(tc.after)); !got.Equal(want) {
+				t.Errorf("Next(%v) = %v, want %v", tc.after, got, want)
+			}
+		})
+	}
+}
+
+func TestFirst(t *testing.T) {
+	start := date("2023-01-01T09:00:00Z")
+
+	testCases := []struct {
+		spec string
+		want string
+	}{
+		{spec: "", want: "2023-01-01T09:00:00Z"},
+		{spec: Monthly, want: "2023-01-01T09:00:00Z"},
+		{spec: "0 9 * * *", want: "2023-01-01T09:00:00Z"},
+		{spec: "0 10 * * *", want: "2023-01-01T10:00:00Z"},
+	}
+
+	for _, tc := range testCases {
+		s, err := Parse(tc.spec, start)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", tc.spec, err)
+		}
+
+		if got := First(s, start); !got.Equal(date(tc.want)) {
+			t.Errorf("First(%q) = %v, want %v", tc.spec, got, tc.want)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	specs := []string{
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@weekly",
+	}
+
+	for _, spec := range specs {
+		if _, err := Parse(spec, time.Now()); !errors.Is(err, ErrInvalidSpec) {
+			t.Errorf("Parse(%q) returned error: %v, want %v", spec, err, ErrInvalidSpec)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package tokenpkg
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// JWTEdDSAMaker is a JSON Web Token maker which signs tokens with Ed25519 and
+// sets the signing key id in the kid header.
+type JWTEdDSAMaker struct {
+	keys *KeySet
+}
+
+// NewJWTEdDSAMaker creates a new JWTEdDSAMaker.
+func NewJWTEdDSAMaker(keys *KeySet) (Maker, error) {
+	if keys == nil {
+		return nil, fmt.Errorf("key set is required")
+	}
+
+	return &JWTEdDSAMaker{keys: keys}, nil
+}
+
+// CreateToken creates a new token for a specific username and duration.
+func (maker *JWTEdDSAMaker) CreateToken(username string, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, duration)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return maker.sign(payload)
+}
+
+// CreateTokenWithClaims creates a new token with the given claims and duration.
+func (maker *JWTEdDSAMaker) CreateTokenWithClaims(claims Claims, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayloadWithClaims(claims, duration)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return maker.sign(payload)
+}
+
+func (maker *JWTEdDSAMaker) sign(payload *Payload) (string, *Payload, error) {
+	kid, key := maker.keys.SigningKey()
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodEdDSA, payload)
+	jwtToken.Header["kid"] = kid
+
+	token, err := jwtToken.SignedString(key)
+
+	return token, payload, err
+}
+
+// VerifyToken checks if the token is valid or not.
+func (maker *JWTEdDSAMaker) VerifyToken(token string) (*Payload, error) {
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		_, ok := token.Method.(*jwt.SigningMethodEd25519)
+		if !ok {
+			return nil, ErrInvalidToken
+		}
+
+		kid, ok := token.Header["kid"].(string)
+		if !ok {
+			return nil, ErrInvalidToken
+		}
+
+		return maker.keys.PublicKey(kid)
+	}
+
+	jwtToken, err := jwt.ParseWithClaims(token, &Payload{}, keyFunc)
+	if err != nil {
+		verr, ok := err.(*jwt.ValidationError)
+		if ok && errors.Is(verr.Inner, ErrExpiredToken) {
+			return nil, ErrExpiredToken
+		}
+
+		return nil, ErrInvalidToken
+	}
+
+	payload, ok := jwtToken.Claims.(*Payload)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	return payload, nil
+}
+
+// JWKS returns the verification keys.
+func (maker *JWTEdDSAMaker) JWKS() JWKS {
+	return maker.keys.JWKS(jwt.SigningMethodEdDSA.Alg())
+}
@@ -0,0 +1,95 @@
+package tokenpkg
+
+import (
+	"crypto/ed25519"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+func TestJWTEdDSAMaker(t *testing.T) {
+	t.Parallel()
+
+	oldKeys := newTestKeySet(t, "old", nil)
+
+	oldMaker, err := NewJWTEdDSAMaker(oldKeys)
+	if err != nil {
+		t.Fatalf("NewJWTEdDSAMaker(oldKeys) returned error: %v", err)
+	}
+
+	oldPub, err := oldKeys.PublicKey("old")
+	if err != nil {
+		t.Fatalf("oldKeys.PublicKey(old) returned error: %v", err)
+	}
+
+	newMaker, err := NewJWTEdDSAMaker(newTestKeySet(t, "new", map[string]ed25519.PublicKey{"old": oldPub}))
+	if err != nil {
+		t.Fatalf("NewJWTEdDSAMaker(newKeys) returned error: %v", err)
+	}
+
+	username := randompkg.Owner()
+	sessionID := uuid.New()
+
+	claims := Claims{Username: username, SessionID: sessionID}
+
+	token, _, err := oldMaker.CreateTokenWithClaims(claims, time.Minute)
+	if err != nil {
+		t.Fatalf("oldMaker.CreateTokenWithClaims(%+v, %v) returned error: %v", claims, time.Minute, err)
+	}
+
+	got, err := newMaker.VerifyToken(token)
+	if err != nil {
+		t.Fatalf("newMaker.VerifyToken(%v) returned error: %v", token, err)
+	}
+
+	if got.Username != username || got.SessionID != sessionID {
+		t.Errorf("newMaker.VerifyToken(%v) = %+v, want username %v and session %v", token, got, username, sessionID)
+	}
+
+	newToken, _, err := newMaker.CreateToken(username, time.Minute)
+	if err != nil {
+		t.Fatalf("newMaker.CreateToken(%v, %v) returned error: %v", username, time.Minute, err)
+	}
+
+	if _, err := oldMaker.VerifyToken(newToken); err != ErrInvalidToken {
+		t.Errorf("oldMaker.VerifyToken(%v) returned error: %v, want %v", newToken, err, ErrInvalidToken)
+	}
+
+	expired, _, err := newMaker.CreateToken(username, -time.Minute)
+	if err != nil {
+		t.Fatalf("newMaker.CreateToken(%v, %v) returned error: %v", username, -time.Minute, err)
+	}
+
+	if _, err := newMaker.VerifyToken(expired); err != ErrExpiredToken {
+		t.Errorf("newMaker.VerifyToken(%v) returned error: %v, want %v", expired, err, ErrExpiredToken)
+	}
+}
+
+func TestJWTEdDSAMakerRejectsHMAC(t *testing.T) {
+	t.Parallel()
+
+	maker, err := NewJWTEdDSAMaker(newTestKeySet(t, "k1", nil))
+	if err != nil {
+		t.Fatalf("NewJWTEdDSAMaker(ks) returned error: %v", err)
+	}
+
+	payload, err := NewPayload(randompkg.Owner(), time.Minute)
+	if err != nil {
+		t.Fatalf("NewPayload(...) returned error: %v", err)
+	}
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
+	jwtToken.Header["kid"] = "k1"
+
+	token, err := jwtToken.SignedString([]byte(randompkg.String(32)))
+	if err != nil {
+		t.Fatalf("jwtToken.SignedString(...) returned error: %v", err)
+	}
+
+	if _, err := maker.VerifyToken(token); err != ErrInvalidToken {
+		t.Errorf("maker.VerifyToken(%v) returned error: %v, want %v", token, err, ErrInvalidToken)
+	}
+}
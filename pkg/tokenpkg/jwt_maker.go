@@ -31,6 +31,20 @@ func (maker *JWTMaker) CreateToken(username string, duration time.Duration) (str
 		return "", nil, err
 	}
 
+	return maker.sign(payload)
+}
+
+// CreateTokenWithClaims creates a new token with the given claims and duration.
+func (maker *JWTMaker) CreateTokenWithClaims(claims Claims, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayloadWithClaims(claims, duration)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return maker.sign(payload)
+}
+
+func (maker *JWTMaker) sign(payload *Payload) (string, *Payload, error) {
 	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
 
 	token, err := jwtToken.SignedString([]byte(maker.secretKey))
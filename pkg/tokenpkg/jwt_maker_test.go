@@ -10,6 +10,7 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
 )
 
 func TestNewJWTMaker(t *testing.T) {
@@ -127,3 +128,43 @@ func TestInvalidJWTTokenAlgNone(t *testing.T) {
 		t.Errorf("maker.VerifyToken(%v) returned error: %v", token, err)
 	}
 }
+
+func TestJWTTokenWithClaims(t *testing.T) {
+	t.Parallel()
+
+	secretKey := randompkg.String(32)
+
+	maker, err := NewJWTMaker(secretKey)
+	if err != nil {
+		t.Fatalf("NewJWTMaker(%v) returned error: %v", secretKey, err)
+	}
+
+	username := randompkg.Owner()
+	sessionID := uuid.New()
+	duration := time.Minute
+
+	claims := Claims{
+		Username:  username,
+		SessionID: sessionID,
+		Role:      "customer",
+		Scopes:    []string{"accounts:read"},
+	}
+
+	token, _, err := maker.CreateTokenWithClaims(claims, duration)
+	if err != nil {
+		t.Fatalf("maker.CreateTokenWithClaims(%+v, %v) returned error: %v", claims, duration, err)
+	}
+
+	got, err := maker.VerifyToken(token)
+	if err != nil {
+		t.Fatalf("maker.VerifyToken(%v) returned error: %v", token, err)
+	}
+
+	if got.SessionID != sessionID || got.Role != claims.Role {
+		t.Errorf("got = %+v, want session %v and role %v", got, sessionID, claims.Role)
+	}
+
+	if !got.HasScope("accounts:read") || got.HasScope("accounts:write") {
+		t.Errorf("got.Scopes = %v, want %v", got.Scopes, claims.Scopes)
+	}
+}
@@ -0,0 +1,163 @@
+package tokenpkg
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrUnknownKeyID is returned when the token is signed with a key which is not
+// in the key set.
+var ErrUnknownKeyID = errors.New("unknown key id")
+
+// KeySet holds the Ed25519 key which signs new tokens and the public keys
+// which verify tokens.
+//
+// Keys are rotated by adding a new signing key and keeping the previous public
+// key for verification until the tokens signed with it expire.
+type KeySet struct {
+	signingKID string
+	signingKey ed25519.PrivateKey
+	publicKeys map[string]ed25519.PublicKey
+}
+
+// NewKeySet creates a key set that signs tokens with signingKey under
+// signingKID. The public key of signingKey is always accepted for
+// verification together with the given publicKeys.
+func NewKeySet(signingKID string, signingKey ed25519.PrivateKey, publicKeys map[string]ed25519.PublicKey) (*KeySet, error) {
+	if signingKID == "" {
+		return nil, errors.New("signing key id must not be empty")
+	}
+
+	if len(signingKey) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("invalid signing key size: must be exactly %d bytes", ed25519.PrivateKeySize)
+	}
+
+	ks := &KeySet{
+		signingKID: signingKID,
+		signingKey: signingKey,
+		publicKeys: make(map[string]ed25519.PublicKey, len(publicKeys)+1),
+	}
+
+	for kid, key := range publicKeys {
+		if kid == "" {
+			return nil, errors.New("verification key id must not be empty")
+		}
+
+		if len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid verification key %q size: must be exactly %d bytes", kid, ed25519.PublicKeySize)
+		}
+
+		ks.publicKeys[kid] = key
+	}
+
+	ks.publicKeys[signingKID] = signingKey.Public().(ed25519.PublicKey)
+
+	return ks, nil
+}
+
+// ParseKeySet creates a key set from the base64 encoded Ed25519 seed of the
+// signing key and the comma separated list of kid=base64 public keys.
+func ParseKeySet(signingKID, signingSeed, publicKeys string) (*KeySet, error) {
+	seed, err := base64.StdEncoding.DecodeString(signingSeed)
+	if err != nil {
+		return nil, fmt.Errorf("decode signing key: %w", err)
+	}
+
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("invalid signing key seed size: must be exactly %d bytes", ed25519.SeedSize)
+	}
+
+	keys := make(map[string]ed25519.PublicKey)
+
+	for _, item := range strings.Split(publicKeys, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		kid, encoded, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid verification key %q: must be kid=base64", item)
+		}
+
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("decode verification key %q: %w", kid, err)
+		}
+
+		keys[kid] = key
+	}
+
+	return NewKeySet(signingKID, ed25519.NewKeyFromSeed(seed), keys)
+}
+
+// SigningKey returns the signing key and its id.
+func (ks *KeySet) SigningKey() (string, ed25519.PrivateKey) {
+	return ks.signingKID, ks.signingKey
+}
+
+// PublicKey returns the public key with the given id.
+func (ks *KeySet) PublicKey(kid string) (ed25519.PublicKey, error) {
+	key, ok := ks.publicKeys[kid]
+	if !ok {
+		return nil, ErrUnknownKeyID
+	}
+
+	return key, nil
+}
+
+// JWKS returns the public keys as a JSON Web Key Set. The alg member is
+// omitted if alg is empty.
+func (ks *KeySet) JWKS(alg string) JWKS {
+	kids := make([]string, 0, len(ks.publicKeys))
+	for kid := range ks.publicKeys {
+		kids = append(kids, kid)
+	}
+
+	sort.Strings(kids)
+
+	set := JWKS{Keys: make([]JWK, 0, len(kids))}
+
+	for _, kid := range kids {
+		set.Keys = append(set.Keys, JWK{
+			KeyType: "OKP",
+			Curve:   "Ed25519",
+			X:       base64.RawURLEncoding.EncodeToString(ks.publicKeys[kid]),
+			KeyID:   kid,
+			Use:     "sig",
+			Alg:     alg,
+		})
+	}
+
+	return set
+}
+
+// JWK is an Ed25519 public key in JSON Web Key format (RFC 8037).
+type JWK struct {
+	KeyType string `json:"kty"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	Alg     string `json:"alg,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// PublicKeyProvider is implemented by makers which tokens can be verified by
+// other services with the published public keys.
+type PublicKeyProvider interface {
+	JWKS() JWKS
+}
+
+// keyIDFooter is the PASETO footer holding the signing key id.
+type keyIDFooter struct {
+	KeyID string `json:"kid"`
+}
@@ -0,0 +1,141 @@
+package tokenpkg
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+)
+
+// newTestKeySet returns a key set signing with a new key under kid.
+func newTestKeySet(t *testing.T, kid string, publicKeys map[string]ed25519.PublicKey) *KeySet {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey(rand.Reader) returned error: %v", err)
+	}
+
+	ks, err := NewKeySet(kid, key, publicKeys)
+	if err != nil {
+		t.Fatalf("NewKeySet(%v, key, %v) returned error: %v", kid, publicKeys, err)
+	}
+
+	return ks
+}
+
+func TestParseKeySet(t *testing.T) {
+	t.Parallel()
+
+	seed := make([]byte, ed25519.SeedSize)
+	if _, err := rand.Read(seed); err != nil {
+		t.Fatalf("rand.Read(seed) returned error: %v", err)
+	}
+
+	oldPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey(rand.Reader) returned error: %v", err)
+	}
+
+	encodedSeed := base64.StdEncoding.EncodeToString(seed)
+	publicKeys := "old=" + base64.StdEncoding.EncodeToString(oldPub)
+
+	testCases := []struct {
+		name       string
+		seed       string
+		publicKeys string
+		wantErr    bool
+	}{
+		{
+			name:       "OK",
+			seed:       encodedSeed,
+			publicKeys: publicKeys,
+		},
+		{
+			name: "NoPublicKeys",
+			seed: encodedSeed,
+		},
+		{
+			name:    "InvalidSeed",
+			seed:    "!!!",
+			wantErr: true,
+		},
+		{
+			name:    "ShortSeed",
+			seed:    base64.StdEncoding.EncodeToString(seed[:16]),
+			wantErr: true,
+		},
+		{
+			name:       "MissingKeyID",
+			seed:       encodedSeed,
+			publicKeys: base64.StdEncoding.EncodeToString(oldPub),
+			wantErr:    true,
+		},
+		{
+			name:       "ShortPublicKey",
+			seed:       encodedSeed,
+			publicKeys: "old=" + base64.StdEncoding.EncodeToString(oldPub[:16]),
+			wantErr:    true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ks, err := ParseKeySet("new", tc.seed, tc.publicKeys)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseKeySet(new, %q, %q) returned error: %v, want error %v",
+					tc.seed, tc.publicKeys, err, tc.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			kid, key := ks.SigningKey()
+			if kid != "new" || !key.Equal(ed25519.NewKeyFromSeed(seed)) {
+				t.Errorf("ks.SigningKey() = %v, key, want new and the key of the seed", kid)
+			}
+
+			if _, err := ks.PublicKey("new"); err != nil {
+				t.Errorf("ks.PublicKey(new) returned error: %v", err)
+			}
+		})
+	}
+}
+
+func TestKeySetJWKS(t *testing.T) {
+	t.Parallel()
+
+	oldPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey(rand.Reader) returned error: %v", err)
+	}
+
+	ks := newTestKeySet(t, "new", map[string]ed25519.PublicKey{"old": oldPub})
+
+	got := ks.JWKS("EdDSA")
+	if len(got.Keys) != 2 {
+		t.Fatalf("len(ks.JWKS(EdDSA).Keys) = %v, want 2", len(got.Keys))
+	}
+
+	want := JWK{
+		KeyType: "OKP",
+		Curve:   "Ed25519",
+		X:       base64.RawURLEncoding.EncodeToString(oldPub),
+		KeyID:   "old",
+		Use:     "sig",
+		Alg:     "EdDSA",
+	}
+
+	if got.Keys[1] != want {
+		t.Errorf("ks.JWKS(EdDSA).Keys[1] = %+v, want %+v", got.Keys[1], want)
+	}
+
+	if got.Keys[0].KeyID != "new" {
+		t.Errorf("ks.JWKS(EdDSA).Keys[0].KeyID = %v, want new", got.Keys[0].KeyID)
+	}
+}
@@ -10,6 +10,9 @@ type Maker interface {
 	// CreateToken creates a new token for a specific username and duration
 	CreateToken(username string, duration time.Duration) (string, *Payload, error)
 
+	// CreateTokenWithClaims creates a new token with the given claims and duration
+	CreateTokenWithClaims(claims Claims, duration time.Duration) (string, *Payload, error)
+
 	// VerifyToken checks if the token is valid or not
 	VerifyToken(token string) (*Payload, error)
 }
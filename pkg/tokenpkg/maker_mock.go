@@ -50,6 +50,22 @@ func (mr *MockMakerMockRecorder) CreateToken(username, duration interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateToken", reflect.TypeOf((*MockMaker)(nil).CreateToken), username, duration)
 }
 
+// CreateTokenWithClaims mocks base method.
+func (m *MockMaker) CreateTokenWithClaims(claims Claims, duration time.Duration) (string, *Payload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTokenWithClaims", claims, duration)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*Payload)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateTokenWithClaims indicates an expected call of CreateTokenWithClaims.
+func (mr *MockMakerMockRecorder) CreateTokenWithClaims(claims, duration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTokenWithClaims", reflect.TypeOf((*MockMaker)(nil).CreateTokenWithClaims), claims, duration)
+}
+
 // VerifyToken mocks base method.
 func (m *MockMaker) VerifyToken(token string) (*Payload, error) {
 	m.ctrl.T.Helper()
@@ -35,6 +35,20 @@ func (maker *PasetoMaker) CreateToken(username string, duration time.Duration) (
 		return "", nil, err
 	}
 
+	return maker.encrypt(payload)
+}
+
+// CreateTokenWithClaims creates a new token with the given claims and duration.
+func (maker *PasetoMaker) CreateTokenWithClaims(claims Claims, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayloadWithClaims(claims, duration)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return maker.encrypt(payload)
+}
+
+func (maker *PasetoMaker) encrypt(payload *Payload) (string, *Payload, error) {
 	token, err := maker.paseto.Encrypt(maker.symmetricKey, payload, nil)
 
 	return token, payload, err
@@ -7,6 +7,7 @@ import (
 	"github.com/go-petr/pet-bank/pkg/randompkg"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
 )
 
 func TestPasetoMaker(t *testing.T) {
@@ -69,3 +70,43 @@ func TestExpiredPasetoToken(t *testing.T) {
 		t.Errorf("maker.VerifyToken(%v) returned unexpected error: %v", token, err)
 	}
 }
+
+func TestPasetoTokenWithClaims(t *testing.T) {
+	t.Parallel()
+
+	secretKey := randompkg.String(32)
+
+	maker, err := NewPasetoMaker(secretKey)
+	if err != nil {
+		t.Fatalf("NewPasetoMaker(%v) returned error: %v", secretKey, err)
+	}
+
+	username := randompkg.Owner()
+	sessionID := uuid.New()
+	duration := time.Minute
+
+	claims := Claims{
+		Username:  username,
+		SessionID: sessionID,
+		Role:      "customer",
+		Scopes:    []string{"accounts:read"},
+	}
+
+	token, _, err := maker.CreateTokenWithClaims(claims, duration)
+	if err != nil {
+		t.Fatalf("maker.CreateTokenWithClaims(%+v, %v) returned error: %v", claims, duration, err)
+	}
+
+	got, err := maker.VerifyToken(token)
+	if err != nil {
+		t.Fatalf("maker.VerifyToken(%v) returned error: %v", token, err)
+	}
+
+	if got.SessionID != sessionID || got.Role != claims.Role {
+		t.Errorf("got = %+v, want session %v and role %v", got, sessionID, claims.Role)
+	}
+
+	if !got.HasScope("accounts:read") || got.HasScope("accounts:write") {
+		t.Errorf("got.Scopes = %v, want %v", got.Scopes, claims.Scopes)
+	}
+}
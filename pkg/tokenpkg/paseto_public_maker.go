@@ -0,0 +1,182 @@
+package tokenpkg
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/o1egl/paseto"
+)
+
+// PASETO public token versions supported by PasetoPublicMaker.
+const (
+	PasetoV2 = "v2"
+	PasetoV4 = "v4"
+)
+
+const headerV4Public = "v4.public."
+
+// PasetoPublicMaker is a PASETO v2.public or v4.public token maker. Tokens are
+// signed with Ed25519 and carry the signing key id in the footer.
+type PasetoPublicMaker struct {
+	version string
+	keys    *KeySet
+	v2      *paseto.V2
+}
+
+// NewPasetoPublicMaker creates a new PasetoPublicMaker of the given version.
+func NewPasetoPublicMaker(version string, keys *KeySet) (Maker, error) {
+	if version != PasetoV2 && version != PasetoV4 {
+		return nil, fmt.Errorf("unsupported paseto version %q", version)
+	}
+
+	if keys == nil {
+		return nil, fmt.Errorf("key set is required")
+	}
+
+	maker := &PasetoPublicMaker{
+		version: version,
+		keys:    keys,
+		v2:      paseto.NewV2(),
+	}
+
+	return maker, nil
+}
+
+// CreateToken creates a new token for a specific username and duration.
+func (maker *PasetoPublicMaker) CreateToken(username string, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, duration)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return maker.sign(payload)
+}
+
+// CreateTokenWithClaims creates a new token with the given claims and duration.
+func (maker *PasetoPublicMaker) CreateTokenWithClaims(claims Claims, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayloadWithClaims(claims, duration)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return maker.sign(payload)
+}
+
+func (maker *PasetoPublicMaker) sign(payload *Payload) (string, *Payload, error) {
+	kid, key := maker.keys.SigningKey()
+	footer := keyIDFooter{KeyID: kid}
+
+	if maker.version == PasetoV2 {
+		token, err := maker.v2.Sign(key, payload, footer)
+
+		return token, payload, err
+	}
+
+	message, err := json.Marshal(payload)
+	if err != nil {
+		return "", nil, err
+	}
+
+	footerBytes, err := json.Marshal(footer)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sig := ed25519.Sign(key, preAuthEncode([]byte(headerV4Public), message, footerBytes, nil))
+
+	token := headerV4Public +
+		base64.RawURLEncoding.EncodeToString(append(message, sig...)) + "." +
+		base64.RawURLEncoding.EncodeToString(footerBytes)
+
+	return token, payload, nil
+}
+
+// VerifyToken checks if the token is valid or not.
+func (maker *PasetoPublicMaker) VerifyToken(token string) (*Payload, error) {
+	var footer keyIDFooter
+	if err := paseto.ParseFooter(token, &footer); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	key, err := maker.keys.PublicKey(footer.KeyID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	payload := &Payload{}
+
+	if maker.version == PasetoV2 {
+		if err := maker.v2.Verify(token, key, payload, nil); err != nil {
+			return nil, ErrInvalidToken
+		}
+	} else if err := verifyV4Public(token, key, payload); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if err := payload.Valid(); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// JWKS returns the verification keys.
+func (maker *PasetoPublicMaker) JWKS() JWKS {
+	return maker.keys.JWKS("")
+}
+
+func verifyV4Public(token string, key ed25519.PublicKey, payload *Payload) error {
+	if !strings.HasPrefix(token, headerV4Public) {
+		return ErrInvalidToken
+	}
+
+	parts := strings.Split(token[len(headerV4Public):], ".")
+	if len(parts) > 2 {
+		return ErrInvalidToken
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil || len(data) < ed25519.SignatureSize {
+		return ErrInvalidToken
+	}
+
+	var footer []byte
+	if len(parts) == 2 {
+		if footer, err = base64.RawURLEncoding.DecodeString(parts[1]); err != nil {
+			return ErrInvalidToken
+		}
+	}
+
+	message := data[:len(data)-ed25519.SignatureSize]
+	sig := data[len(data)-ed25519.SignatureSize:]
+
+	if !ed25519.Verify(key, preAuthEncode([]byte(headerV4Public), message, footer, nil), sig) {
+		return ErrInvalidToken
+	}
+
+	return json.Unmarshal(message, payload)
+}
+
+// preAuthEncode implements the PASETO pre-authentication encoding.
+func preAuthEncode(pieces ...[]byte) []byte {
+	var buf bytes.Buffer
+
+	le64 := make([]byte, 8)
+
+	binary.LittleEndian.PutUint64(le64, uint64(len(pieces)))
+	buf.Write(le64)
+
+	for _, p := range pieces {
+		binary.LittleEndian.PutUint64(le64, uint64(len(p)))
+		buf.Write(le64)
+		buf.Write(p)
+	}
+
+	return buf.Bytes()
+}
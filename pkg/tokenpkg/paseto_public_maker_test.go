@@ -0,0 +1,135 @@
+package tokenpkg
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/go-petr/pet-bank/pkg/randompkg"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestPasetoPublicMaker(t *testing.T) {
+	t.Parallel()
+
+	for _, version := range []string{PasetoV2, PasetoV4} {
+		version := version
+
+		t.Run(version, func(t *testing.T) {
+			t.Parallel()
+
+			maker, err := NewPasetoPublicMaker(version, newTestKeySet(t, "k1", nil))
+			if err != nil {
+				t.Fatalf("NewPasetoPublicMaker(%v, ks) returned error: %v", version, err)
+			}
+
+			username := randompkg.Owner()
+			duration := time.Minute
+
+			token, payload, err := maker.CreateToken(username, duration)
+			if err != nil {
+				t.Fatalf("maker.CreateToken(%v, %v) returned error: %v", username, duration, err)
+			}
+
+			got, err := maker.VerifyToken(token)
+			if err != nil {
+				t.Fatalf("maker.VerifyToken(%v) returned error: %v", token, err)
+			}
+
+			if diff := cmp.Diff(payload, got, cmpopts.EquateApproxTime(time.Second)); diff != "" {
+				t.Errorf("maker.VerifyToken(%v) returned unexpected diff: %v", token, diff)
+			}
+
+			expired, _, err := maker.CreateToken(username, -time.Minute)
+			if err != nil {
+				t.Fatalf("maker.CreateToken(%v, %v) returned error: %v", username, -time.Minute, err)
+			}
+
+			if _, err := maker.VerifyToken(expired); err != ErrExpiredToken {
+				t.Errorf("maker.VerifyToken(%v) returned error: %v, want %v", expired, err, ErrExpiredToken)
+			}
+		})
+	}
+}
+
+func TestPasetoPublicMakerRotation(t *testing.T) {
+	t.Parallel()
+
+	for _, version := range []string{PasetoV2, PasetoV4} {
+		version := version
+
+		t.Run(version, func(t *testing.T) {
+			t.Parallel()
+
+			oldKeys := newTestKeySet(t, "old", nil)
+
+			oldMaker, err := NewPasetoPublicMaker(version, oldKeys)
+			if err != nil {
+				t.Fatalf("NewPasetoPublicMaker(%v, oldKeys) returned error: %v", version, err)
+			}
+
+			oldPub, err := oldKeys.PublicKey("old")
+			if err != nil {
+				t.Fatalf("oldKeys.PublicKey(old) returned error: %v", err)
+			}
+
+			newMaker, err := NewPasetoPublicMaker(version,
+				newTestKeySet(t, "new", map[string]ed25519.PublicKey{"old": oldPub}))
+			if err != nil {
+				t.Fatalf("NewPasetoPublicMaker(%v, newKeys) returned error: %v", version, err)
+			}
+
+			otherMaker, err := NewPasetoPublicMaker(version, newTestKeySet(t, "other", nil))
+			if err != nil {
+				t.Fatalf("NewPasetoPublicMaker(%v, otherKeys) returned error: %v", version, err)
+			}
+
+			username := randompkg.Owner()
+
+			oldToken, _, err := oldMaker.CreateToken(username, time.Minute)
+			if err != nil {
+				t.Fatalf("oldMaker.CreateToken(%v, %v) returned error: %v", username, time.Minute, err)
+			}
+
+			if _, err := newMaker.VerifyToken(oldToken); err != nil {
+				t.Errorf("newMaker.VerifyToken(%v) returned error: %v", oldToken, err)
+			}
+
+			if _, err := otherMaker.VerifyToken(oldToken); err != ErrInvalidToken {
+				t.Errorf("otherMaker.VerifyToken(%v) returned error: %v, want %v", oldToken, err, ErrInvalidToken)
+			}
+
+			newToken, _, err := newMaker.CreateToken(username, time.Minute)
+			if err != nil {
+				t.Fatalf("newMaker.CreateToken(%v, %v) returned error: %v", username, time.Minute, err)
+			}
+
+			if _, err := oldMaker.VerifyToken(newToken); err != ErrInvalidToken {
+				t.Errorf("oldMaker.VerifyToken(%v) returned error: %v, want %v", newToken, err, ErrInvalidToken)
+			}
+		})
+	}
+}
+
+func TestPasetoV4PublicVector(t *testing.T) {
+	t.Parallel()
+
+	// Test vector 4-S-1 of the PASETO specification.
+	seed, err := hex.DecodeString("b4cbfb43df4ce210727d953e4a713307fa19bb7d9f85041438d9e11b942a3774")
+	if err != nil {
+		t.Fatalf("hex.DecodeString(seed) returned error: %v", err)
+	}
+
+	message := []byte(`{"data":"this is a signed message","exp":"2022-01-01T00:00:00+00:00"}`)
+	want := "v4.public.eyJkYXRhIjoidGhpcyBpcyBhIHNpZ25lZCBtZXNzYWdlIiwiZXhwIjoiMjAyMi0wMS0wMVQwMDowMDowMCswMDowMCJ9" +
+		"bg_XBBzds8lTZShVlwwKSgeKpLT3yukTw6JUz3W4h_ExsQV-P0V54zemZDcAxFaSeef1QlXEFtkqxT1ciiQEDA"
+
+	sig := ed25519.Sign(ed25519.NewKeyFromSeed(seed), preAuthEncode([]byte(headerV4Public), message, nil, nil))
+
+	if got := headerV4Public + base64.RawURLEncoding.EncodeToString(append(message, sig...)); got != want {
+		t.Errorf("v4.public token = %v, want %v", got, want)
+	}
+}
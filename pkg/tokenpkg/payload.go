@@ -7,20 +7,34 @@ import (
 	"github.com/google/uuid"
 )
 
-// Different types of error returned by the VerifyToken function.
+// Different types of error returned by the token verification.
 var (
 	ErrInvalidToken = errors.New("token is invalid")
 	ErrExpiredToken = errors.New("token has expired")
+	// ErrRevokedToken is returned when the token session is blocked or the
+	// user password has been changed after the token was issued.
+	ErrRevokedToken = errors.New("token has been revoked")
 )
 
 // Payload contains the payload data of the token.
 type Payload struct {
 	ID        uuid.UUID `json:"id"`
 	Username  string    `json:"username"`
+	SessionID uuid.UUID `json:"session_id"`
+	Role      string    `json:"role,omitempty"`
+	Scopes    []string  `json:"scopes,omitempty"`
 	IssuedAt  time.Time `json:"issued_at"`
 	ExpiredAt time.Time `json:"expired_at"`
 }
 
+// Claims hold the authorization data of the token.
+type Claims struct {
+	Username  string
+	SessionID uuid.UUID
+	Role      string
+	Scopes    []string
+}
+
 // NewPayload creates a new token payload with a specific username and duration.
 func NewPayload(username string, duration time.Duration) (*Payload, error) {
 	tokenID, err := uuid.NewRandom()
@@ -38,6 +52,31 @@ func NewPayload(username string, duration time.Duration) (*Payload, error) {
 	return payload, nil
 }
 
+// NewPayloadWithClaims creates a new token payload with the given claims and duration.
+func NewPayloadWithClaims(claims Claims, duration time.Duration) (*Payload, error) {
+	payload, err := NewPayload(claims.Username, duration)
+	if err != nil {
+		return nil, err
+	}
+
+	payload.SessionID = claims.SessionID
+	payload.Role = claims.Role
+	payload.Scopes = claims.Scopes
+
+	return payload, nil
+}
+
+// HasScope reports whether the token grants the scope.
+func (payload *Payload) HasScope(scope string) bool {
+	for _, s := range payload.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Valid checks if the token payload is valid or not.
 func (payload *Payload) Valid() error {
 	if time.Now().After(payload.ExpiredAt) {
@@ -19,6 +19,8 @@ type Response struct {
 	RefreshToken          string     `json:"refresh_token,omitempty"`
 	RefreshTokenExpiresAt *time.Time `json:"refresh_token_expires_at,omitempty"`
 	Data                  any        `json:"data,omitempty"`
+	NextCursor            string     `json:"next_cursor,omitempty"`
+	PrevCursor            string     `json:"prev_cursor,omitempty"`
 	Error                 string     `json:"error,omitempty"`
 }
 
@@ -30,6 +32,12 @@ func GetErrorMsg(ve validator.ValidationErrors) string {
 	switch field.Tag() {
 	case "required":
 		errMsg += " field is required"
+	case "required_without":
+		errMsg += " field is required without " + field.Param()
+	case "required_without_all":
+		errMsg += " field is required without " + field.Param()
+	case "excluded_with":
+		errMsg += " can't be used with " + field.Param()
 	case "lte":
 		errMsg += " must be less than " + field.Param()
 	case "gte":
@@ -44,6 +52,10 @@ func GetErrorMsg(ve validator.ValidationErrors) string {
 		errMsg += " must contain a valid email"
 	case "currency":
 		errMsg += " is not supported"
+	case "oneof":
+		errMsg += " must be one of: " + field.Param()
+	case "uuid":
+		errMsg += " must be a valid UUID"
 	default:
 		errMsg += " unknown error"
 	}